@@ -205,6 +205,51 @@ func GenerateMiniBlogModels(g *gen.Generator) {
 		}),
 	)
 
+	// 租户 LDAP 配置表
+	g.GenerateModelAs(
+		"tenant_ldap_configs",
+		"TenantLDAPConfigM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("tenant_id", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_tenant_id")
+			return tag
+		}),
+		gen.FieldGORMTag("deleted_at", func(tag field.GormTag) field.GormTag {
+			tag.Set("index", "")
+			return tag
+		}),
+	)
+
+	// 租户 SAML 配置表
+	g.GenerateModelAs(
+		"tenant_saml_configs",
+		"TenantSAMLConfigM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("tenant_id", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_tenant_id")
+			return tag
+		}),
+		gen.FieldGORMTag("deleted_at", func(tag field.GormTag) field.GormTag {
+			tag.Set("index", "")
+			return tag
+		}),
+	)
+
+	// 租户 SCIM 访问令牌表
+	g.GenerateModelAs(
+		"tenant_scim_tokens",
+		"TenantSCIMTokenM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("token_hash", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_token_hash")
+			return tag
+		}),
+		gen.FieldGORMTag("deleted_at", func(tag field.GormTag) field.GormTag {
+			tag.Set("index", "")
+			return tag
+		}),
+	)
+
 	// 角色管理表
 	g.GenerateModelAs(
 		"roles",
@@ -214,6 +259,10 @@ func GenerateMiniBlogModels(g *gen.Generator) {
 			tag.Set("uniqueIndex", "idx_name_tenant")
 			return tag
 		}),
+		gen.FieldGORMTag("system_role", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_system_role_tenant")
+			return tag
+		}),
 		gen.FieldGORMTag("deleted_at", func(tag field.GormTag) field.GormTag {
 			tag.Set("index", "")
 			return tag
@@ -293,4 +342,94 @@ func GenerateMiniBlogModels(g *gen.Generator) {
 			return tag
 		}),
 	)
+
+	// 权限自动分配配置表
+	g.GenerateModelAs(
+		"permission_auto_assign_configs",
+		"PermissionAutoAssignConfigM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("tenant_id", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_tenant_id")
+			return tag
+		}),
+	)
+
+	// 权限自动分配规则表
+	g.GenerateModelAs(
+		"permission_auto_assign_rules",
+		"PermissionAutoAssignRuleM",
+		gen.FieldIgnore("placeholder"),
+	)
+
+	// 平台运营人员表
+	g.GenerateModelAs(
+		"platform_operators",
+		"PlatformOperatorM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("user_id", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_user_id")
+			return tag
+		}),
+	)
+
+	// 平台操作审计日志表
+	g.GenerateModelAs(
+		"platform_audit_logs",
+		"PlatformAuditLogM",
+		gen.FieldIgnore("placeholder"),
+	)
+
+	// 策略决策点服务凭证表
+	g.GenerateModelAs(
+		"service_clients",
+		"ServiceClientM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("token_hash", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_token_hash")
+			return tag
+		}),
+	)
+
+	// 限时角色授予表
+	g.GenerateModelAs(
+		"user_role_grants",
+		"UserRoleGrantM",
+		gen.FieldIgnore("placeholder"),
+	)
+
+	// 访问申请表
+	g.GenerateModelAs(
+		"access_requests",
+		"AccessRequestM",
+		gen.FieldIgnore("placeholder"),
+	)
+
+	// 访问申请审批记录表
+	g.GenerateModelAs(
+		"access_request_reviews",
+		"AccessRequestReviewM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("request_id", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_request_reviewer")
+			return tag
+		}),
+		gen.FieldGORMTag("reviewer_id", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_request_reviewer")
+			return tag
+		}),
+	)
+
+	// 访问申请审批策略表
+	g.GenerateModelAs(
+		"access_approval_policies",
+		"AccessApprovalPolicyM",
+		gen.FieldIgnore("placeholder"),
+	)
+
+	// 职责分离规则表
+	g.GenerateModelAs(
+		"sod_rules",
+		"SoDRuleM",
+		gen.FieldIgnore("placeholder"),
+	)
 }
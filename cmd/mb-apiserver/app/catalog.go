@@ -0,0 +1,144 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ashwinyue/one-auth/cmd/mb-apiserver/app/options"
+	"github.com/ashwinyue/one-auth/internal/apiserver"
+	catalogv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/catalog"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+)
+
+// newCatalogCommand 创建权限目录导入导出子命令.
+func newCatalogCommand(opts *options.ServerOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "Import or export the permission catalog of a tenant",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(newCatalogImportCommand(opts), newCatalogExportCommand(opts))
+	return cmd
+}
+
+// newCatalogImportCommand 创建权限目录导入子命令.
+func newCatalogImportCommand(opts *options.ServerOptions) *cobra.Command {
+	rq := &apiv1.ImportPermissionCatalogRequest{}
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Reconcile a YAML/JSON permission manifest into a tenant",
+		Example: `  # 查看将 configs/permissions.yaml 同步到租户 1 的变更计划
+  mb-apiserver catalog import -f configs/permissions.yaml --tenant-id 1 --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			rq.Manifest = string(data)
+
+			catalog, err := newCatalog(opts)
+			if err != nil {
+				return err
+			}
+			resp, err := catalog.Import(context.Background(), rq)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for _, change := range resp.Changes {
+				line := fmt.Sprintf("%-6s %-15s %s", change.Op, change.Kind, change.Key)
+				if len(change.Fields) > 0 {
+					line += " (" + strings.Join(change.Fields, ", ") + ")"
+				}
+				fmt.Fprintln(out, line)
+			}
+			verb := "applied"
+			if resp.DryRun {
+				verb = "planned"
+			}
+			fmt.Fprintf(out, "tenant %d: %d created, %d updated, %d deleted (%s)\n", resp.TenantId, resp.Created, resp.Updated, resp.Deleted, verb)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "configs/permissions.yaml", "Path to the permission manifest.")
+	cmd.Flags().Int64Var(&rq.TenantId, "tenant-id", 1, "Tenant to reconcile the manifest into.")
+	cmd.Flags().BoolVar(&rq.DryRun, "dry-run", false, "Only print the change plan.")
+	cmd.Flags().BoolVar(&rq.Prune, "prune", false, "Delete objects that are not declared in the manifest.")
+	return cmd
+}
+
+// newCatalogExportCommand 创建权限目录导出子命令.
+func newCatalogExportCommand(opts *options.ServerOptions) *cobra.Command {
+	rq := &apiv1.ExportPermissionCatalogRequest{}
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the permission catalog of a tenant as a manifest",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catalog, err := newCatalog(opts)
+			if err != nil {
+				return err
+			}
+			resp, err := catalog.Export(context.Background(), rq)
+			if err != nil {
+				return err
+			}
+
+			if output == "" || output == "-" {
+				_, err = fmt.Fprint(cmd.OutOrStdout(), resp.Manifest)
+				return err
+			}
+			return os.WriteFile(output, []byte(resp.Manifest), 0o644)
+		},
+	}
+
+	cmd.Flags().Int64Var(&rq.TenantId, "tenant-id", 1, "Tenant to export.")
+	cmd.Flags().StringVar(&rq.Format, "format", catalogv1.FormatYAML, "Manifest format: yaml or json.")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the manifest to this file instead of stdout.")
+	return cmd
+}
+
+// newCatalog 根据配置创建权限目录业务实例.
+func newCatalog(opts *options.ServerOptions) (catalogv1.CatalogBiz, error) {
+	cfg, err := commandConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.NewCatalog()
+}
+
+// commandConfig 为不启动服务器的子命令初始化日志并加载应用配置.
+func commandConfig(opts *options.ServerOptions) (*apiserver.Config, error) {
+	log.Init(logOptions())
+
+	if err := viper.Unmarshal(opts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal configuration: %w", err)
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+	cfg, err := opts.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ashwinyue/one-auth/cmd/mb-apiserver/app/options"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+)
+
+// newConsistencyCommand 创建策略一致性检查子命令.
+func newConsistencyCommand(opts *options.ServerOptions) *cobra.Command {
+	rq := &apiv1.CheckConsistencyRequest{}
+
+	cmd := &cobra.Command{
+		Use:   "consistency",
+		Short: "Check casbin_rule against roles, permissions, users and tenants",
+		Example: `  # 只生成报告，发现问题时以非零状态退出
+  mb-apiserver consistency
+
+  # 删除孤立、悬空、前缀未知和重复的规则
+  mb-apiserver consistency --fix`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := commandConfig(opts)
+			if err != nil {
+				return err
+			}
+			checker, err := cfg.NewConsistency()
+			if err != nil {
+				return err
+			}
+			resp, err := checker.Check(context.Background(), rq)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for _, issue := range resp.Issues {
+				subject := issue.Rule
+				if issue.RuleId != 0 {
+					subject = fmt.Sprintf("#%d %s", issue.RuleId, issue.Rule)
+				}
+				if subject == "" {
+					subject = fmt.Sprintf("user %d", issue.UserId)
+				}
+				fmt.Fprintf(out, "%-20s %-40s %s\n", issue.Kind, subject, issue.Detail)
+			}
+			fmt.Fprintf(out, "%d rules checked, %d issues found, %d rules removed\n", resp.RulesChecked, len(resp.Issues), resp.Fixed)
+
+			if !rq.Fix && len(resp.Issues) > 0 {
+				return fmt.Errorf("%d consistency issues found, run with --fix to remove the fixable rules", len(resp.Issues))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&rq.Fix, "fix", false, "Remove rules that can be fixed automatically.")
+	return cmd
+}
@@ -14,11 +14,14 @@ import (
 
 	genericoptions "github.com/ashwinyue/one-auth/pkg/options"
 	stringsutil "github.com/ashwinyue/one-auth/pkg/util/strings"
+	"github.com/ashwinyue/one-auth/pkg/watch"
 	"github.com/spf13/pflag"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/ashwinyue/one-auth/internal/apiserver"
+	handler "github.com/ashwinyue/one-auth/internal/apiserver/handler/http"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
 )
 
 // 定义支持的服务器模式集合.
@@ -26,11 +29,12 @@ var availableServerModes = sets.New(
 	apiserver.GinServerMode,
 	apiserver.GRPCServerMode,
 	apiserver.GRPCGatewayServerMode,
+	apiserver.ExtAuthzServerMode,
 )
 
 // ServerOptions 包含服务器配置选项.
 type ServerOptions struct {
-	// ServerMode 定义服务器模式：gRPC、Gin HTTP、HTTP Reverse Proxy.
+	// ServerMode 定义服务器模式：gRPC、Gin HTTP、HTTP Reverse Proxy、Envoy 外部授权.
 	ServerMode string `json:"server-mode" mapstructure:"server-mode"`
 	// JWTKey 定义 JWT 密钥.
 	JWTKey string `json:"jwt-key" mapstructure:"jwt-key"`
@@ -38,6 +42,14 @@ type ServerOptions struct {
 	Expiration time.Duration `json:"expiration" mapstructure:"expiration"`
 	// EnableMemoryStore 指示是否启用内存数据库（用于测试或开发环境）.
 	EnableMemoryStore bool `json:"enable-memory-store" mapstructure:"enable-memory-store"`
+	// DataScopeMerge 定义用户拥有多个角色时数据权限的合并规则：widest 或 narrowest.
+	DataScopeMerge string `json:"data-scope-merge" mapstructure:"data-scope-merge"`
+	// ForwardAuthCacheTTL 定义转发认证接口 /auth/verify 缓存鉴权结果的时长，为 0 时不缓存.
+	ForwardAuthCacheTTL time.Duration `json:"forward-auth-cache-ttl" mapstructure:"forward-auth-cache-ttl"`
+	// ForwardAuthCacheSize 定义转发认证接口缓存的最大条目数.
+	ForwardAuthCacheSize int `json:"forward-auth-cache-size" mapstructure:"forward-auth-cache-size"`
+	// ForwardAuthTrustedProxies 定义可信代理的网段或 IP，转发认证接口和 Gin 服务器只信任这些代理传入的客户端IP.
+	ForwardAuthTrustedProxies []string `json:"forward-auth-trusted-proxies" mapstructure:"forward-auth-trusted-proxies"`
 	// TLSOptions 包含 TLS 配置选项.
 	TLSOptions *genericoptions.TLSOptions `json:"tls" mapstructure:"tls"`
 	// HTTPOptions 包含 HTTP 配置选项.
@@ -48,22 +60,33 @@ type ServerOptions struct {
 	MySQLOptions *genericoptions.MySQLOptions `json:"mysql" mapstructure:"mysql"`
 	// RedisOptions 包含 Redis 配置选项.
 	RedisOptions *genericoptions.RedisOptions `json:"redis" mapstructure:"redis"`
+	// SMSOptions 包含短信客户端配置选项.
+	SMSOptions *genericoptions.SMSOptions `json:"sms" mapstructure:"sms"`
+	// WatchOptions 包含后台任务调度配置选项.
+	WatchOptions *watch.Options `json:"watch" mapstructure:"watch"`
 }
 
 // NewServerOptions 创建带有默认值的 ServerOptions 实例.
 func NewServerOptions() *ServerOptions {
 	opts := &ServerOptions{
-		ServerMode:        apiserver.GRPCGatewayServerMode,
-		JWTKey:            "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
-		Expiration:        2 * time.Hour,
-		EnableMemoryStore: true,
-		TLSOptions:        genericoptions.NewTLSOptions(),
-		HTTPOptions:       genericoptions.NewHTTPOptions(),
-		GRPCOptions:       genericoptions.NewGRPCOptions(),
-		MySQLOptions:      genericoptions.NewMySQLOptions(),
-		RedisOptions:      genericoptions.NewRedisOptions(),
+		ServerMode:           apiserver.GRPCGatewayServerMode,
+		JWTKey:               "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
+		Expiration:           2 * time.Hour,
+		EnableMemoryStore:    true,
+		DataScopeMerge:       store.DataScopeMergeWidest,
+		ForwardAuthCacheTTL:  5 * time.Second,
+		ForwardAuthCacheSize: 10000,
+		TLSOptions:           genericoptions.NewTLSOptions(),
+		HTTPOptions:          genericoptions.NewHTTPOptions(),
+		GRPCOptions:          genericoptions.NewGRPCOptions(),
+		MySQLOptions:         genericoptions.NewMySQLOptions(),
+		RedisOptions:         genericoptions.NewRedisOptions(),
+		SMSOptions:           genericoptions.NewSMSOptions(),
+		WatchOptions:         watch.NewOptions(),
 	}
 	opts.HTTPOptions.Addr = ":5555"
+	opts.WatchOptions.LockName = "one-auth-apiserver-watch"
+	opts.WatchOptions.HealthzPort = 0
 	opts.GRPCOptions.Addr = ":6666"
 	return opts
 }
@@ -77,6 +100,11 @@ func (o *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	// 参数名称为 `--expiration`，默认值为 o.Expiration
 	fs.DurationVar(&o.Expiration, "expiration", o.Expiration, "The expiration duration of JWT tokens.")
 	fs.BoolVar(&o.EnableMemoryStore, "enable-memory-store", o.EnableMemoryStore, "Enable in-memory database (useful for testing or development).")
+	fs.StringVar(&o.DataScopeMerge, "data-scope-merge", o.DataScopeMerge, "How data scopes of multiple roles are merged, available options: widest, narrowest.")
+	fs.DurationVar(&o.ForwardAuthCacheTTL, "forward-auth-cache-ttl", o.ForwardAuthCacheTTL, "How long /auth/verify caches authentication and authorization results. Set to 0 to disable caching.")
+	fs.IntVar(&o.ForwardAuthCacheSize, "forward-auth-cache-size", o.ForwardAuthCacheSize, "Maximum number of results cached by /auth/verify.")
+	fs.StringSliceVar(&o.ForwardAuthTrustedProxies, "forward-auth-trusted-proxies", o.ForwardAuthTrustedProxies,
+		"CIDRs or IPs of proxies allowed to pass the client IP to /auth/verify and the gin server via X-Real-IP or X-Forwarded-For. Empty means the peer address is always used.")
 
 	// 添加子选项的命令行标志
 	o.TLSOptions.AddFlags(fs)
@@ -84,6 +112,8 @@ func (o *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	o.GRPCOptions.AddFlags(fs)
 	o.MySQLOptions.AddFlags(fs)
 	o.RedisOptions.AddFlags(fs)
+	o.SMSOptions.AddFlags(fs)
+	o.WatchOptions.AddFlags(fs)
 }
 
 // Validate 校验 ServerOptions 中的选项是否合法.
@@ -100,14 +130,29 @@ func (o *ServerOptions) Validate() error {
 		errs = append(errs, errors.New("JWTKey must be at least 6 characters long"))
 	}
 
+	// 校验数据权限合并规则
+	if o.DataScopeMerge != store.DataScopeMergeWidest && o.DataScopeMerge != store.DataScopeMergeNarrowest {
+		errs = append(errs, fmt.Errorf("invalid data scope merge: must be %s or %s", store.DataScopeMergeWidest, store.DataScopeMergeNarrowest))
+	}
+
+	// 校验转发认证缓存配置
+	if o.ForwardAuthCacheTTL < 0 || o.ForwardAuthCacheSize < 0 {
+		errs = append(errs, errors.New("forward-auth-cache-ttl and forward-auth-cache-size must not be negative"))
+	}
+	if _, err := handler.ParseTrustedProxies(o.ForwardAuthTrustedProxies); err != nil {
+		errs = append(errs, err)
+	}
+
 	// 校验子选项
 	errs = append(errs, o.TLSOptions.Validate()...)
 	errs = append(errs, o.HTTPOptions.Validate()...)
 	errs = append(errs, o.MySQLOptions.Validate()...)
 	errs = append(errs, o.RedisOptions.Validate()...)
+	errs = append(errs, o.SMSOptions.Validate()...)
+	errs = append(errs, o.WatchOptions.Validate()...)
 
-	// 如果是 gRPC 或 gRPC-Gateway 模式，校验 gRPC 配置
-	if stringsutil.StringIn(o.ServerMode, []string{apiserver.GRPCServerMode, apiserver.GRPCGatewayServerMode}) {
+	// 如果是 gRPC、gRPC-Gateway 或 Envoy 外部授权模式，校验 gRPC 配置
+	if stringsutil.StringIn(o.ServerMode, []string{apiserver.GRPCServerMode, apiserver.GRPCGatewayServerMode, apiserver.ExtAuthzServerMode}) {
 		errs = append(errs, o.GRPCOptions.Validate()...)
 	}
 
@@ -117,15 +162,26 @@ func (o *ServerOptions) Validate() error {
 
 // Config 基于 ServerOptions 构建 apiserver.Config.
 func (o *ServerOptions) Config() (*apiserver.Config, error) {
+	trustedProxies, err := handler.ParseTrustedProxies(o.ForwardAuthTrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
 	return &apiserver.Config{
-		ServerMode:        o.ServerMode,
-		JWTKey:            o.JWTKey,
-		Expiration:        o.Expiration,
-		EnableMemoryStore: o.EnableMemoryStore,
-		TLSOptions:        o.TLSOptions,
-		HTTPOptions:       o.HTTPOptions,
-		GRPCOptions:       o.GRPCOptions,
-		MySQLOptions:      o.MySQLOptions,
-		RedisOptions:      o.RedisOptions,
+		ServerMode:                o.ServerMode,
+		JWTKey:                    o.JWTKey,
+		Expiration:                o.Expiration,
+		EnableMemoryStore:         o.EnableMemoryStore,
+		DataScopeMerge:            o.DataScopeMerge,
+		ForwardAuthCacheTTL:       o.ForwardAuthCacheTTL,
+		ForwardAuthCacheSize:      o.ForwardAuthCacheSize,
+		ForwardAuthTrustedProxies: trustedProxies,
+		TLSOptions:                o.TLSOptions,
+		HTTPOptions:               o.HTTPOptions,
+		GRPCOptions:               o.GRPCOptions,
+		MySQLOptions:              o.MySQLOptions,
+		RedisOptions:              o.RedisOptions,
+		SMSOptions:                o.SMSOptions,
+		WatchOptions:              o.WatchOptions,
 	}, nil
 }
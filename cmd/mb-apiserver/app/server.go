@@ -78,6 +78,12 @@ The project features include:
 	// 添加 --version 标志
 	version.AddFlags(cmd.PersistentFlags())
 
+	// 添加权限目录导入导出子命令
+	cmd.AddCommand(newCatalogCommand(opts))
+
+	// 添加策略一致性检查子命令
+	cmd.AddCommand(newConsistencyCommand(opts))
+
 	return cmd
 }
 
@@ -66,6 +66,7 @@ CREATE TABLE `tenants` (
   `tenant_code` varchar(50) NOT NULL COMMENT '租户编码',
   `name` varchar(100) NOT NULL COMMENT '租户名称',
   `description` varchar(500) DEFAULT NULL COMMENT '描述',
+  `timezone` varchar(64) NOT NULL DEFAULT '' COMMENT '租户时区（IANA 名称，如 Asia/Shanghai），为空时使用服务器时区',
   `status` tinyint(1) NOT NULL DEFAULT '1' COMMENT '状态：1-启用，0-禁用',
   `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
   `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
@@ -76,6 +77,94 @@ CREATE TABLE `tenants` (
   KEY `idx_deleted_at` (`deleted_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='租户表';
 
+-- =====================================================
+-- 租户 LDAP 配置表 (tenant_ldap_configs)
+-- =====================================================
+
+DROP TABLE IF EXISTS `tenant_ldap_configs`;
+CREATE TABLE `tenant_ldap_configs` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `enabled` tinyint(1) NOT NULL DEFAULT '1' COMMENT '是否启用：1-启用，0-禁用',
+  `url` varchar(255) NOT NULL COMMENT '服务地址，例如 ldaps://ad.example.com:636',
+  `start_tls` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否使用 StartTLS',
+  `insecure_skip_verify` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否跳过证书校验',
+  `ca_cert` text COMMENT 'PEM 格式的 CA 证书',
+  `bind_dn` varchar(255) NOT NULL DEFAULT '' COMMENT '服务账号 DN',
+  `bind_password` varchar(255) NOT NULL DEFAULT '' COMMENT '服务账号密码',
+  `base_dn` varchar(255) NOT NULL COMMENT '用户搜索根 DN',
+  `user_filter` varchar(255) NOT NULL DEFAULT '(uid=%s)' COMMENT '用户过滤器，%s 为用户名',
+  `username_attr` varchar(64) NOT NULL DEFAULT 'uid' COMMENT '用户名属性',
+  `email_attr` varchar(64) NOT NULL DEFAULT 'mail' COMMENT '邮箱属性',
+  `display_name_attr` varchar(64) NOT NULL DEFAULT 'displayName' COMMENT '显示名属性',
+  `phone_attr` varchar(64) NOT NULL DEFAULT 'mobile' COMMENT '手机号属性',
+  `group_attr` varchar(64) NOT NULL DEFAULT 'memberOf' COMMENT '用户条目上的组属性',
+  `group_base_dn` varchar(255) NOT NULL DEFAULT '' COMMENT '组搜索根 DN，为空时不搜索组',
+  `group_filter` varchar(255) NOT NULL DEFAULT '(member=%s)' COMMENT '组过滤器，%s 为用户 DN',
+  `role_mapping` text COMMENT '组到角色的映射（JSON 对象，键为组 DN 或 CN，值为角色名数组）',
+  `pool_size` int NOT NULL DEFAULT '4' COMMENT '连接池大小',
+  `timeout_seconds` int NOT NULL DEFAULT '5' COMMENT '连接和请求超时时间（秒）',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间（软删除）',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_tenant_id` (`tenant_id`),
+  KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='租户 LDAP 配置表';
+
+-- =====================================================
+-- 租户 SAML 配置表 (tenant_saml_configs)
+-- =====================================================
+
+DROP TABLE IF EXISTS `tenant_saml_configs`;
+CREATE TABLE `tenant_saml_configs` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `enabled` tinyint(1) NOT NULL DEFAULT '1' COMMENT '是否启用：1-启用，0-禁用',
+  `base_url` varchar(255) NOT NULL COMMENT '服务对外地址，例如 https://auth.example.com，用于生成元数据和断言消费地址',
+  `sp_entity_id` varchar(255) NOT NULL DEFAULT '' COMMENT 'SP 实体ID，为空时使用元数据地址',
+  `sp_certificate` text COMMENT 'PEM 格式的 SP 证书',
+  `sp_private_key` text COMMENT 'PEM 格式的 SP 私钥',
+  `idp_entity_id` varchar(255) NOT NULL DEFAULT '' COMMENT 'IdP 实体ID',
+  `idp_metadata` mediumtext COMMENT 'IdP 元数据 XML',
+  `idp_metadata_url` varchar(512) NOT NULL DEFAULT '' COMMENT 'IdP 元数据地址',
+  `allow_idp_initiated` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否允许 IdP 发起的登录',
+  `sign_requests` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否对 AuthnRequest 签名',
+  `name_id_format` varchar(255) NOT NULL DEFAULT '' COMMENT '请求的 NameID 格式',
+  `attribute_mapping` text COMMENT '用户资料到断言属性的映射（JSON 对象，键为 username、email、nickname、phone、groups）',
+  `role_mapping` text COMMENT '组到角色的映射（JSON 对象，键为组名，值为角色名数组）',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间（软删除）',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_tenant_id` (`tenant_id`),
+  KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='租户 SAML 配置表';
+
+-- =====================================================
+-- 租户 SCIM 访问令牌表 (tenant_scim_tokens)
+-- =====================================================
+
+DROP TABLE IF EXISTS `tenant_scim_tokens`;
+CREATE TABLE `tenant_scim_tokens` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `name` varchar(100) NOT NULL DEFAULT '' COMMENT '令牌名称，例如对接的 IdP 名称',
+  `token_hash` char(64) NOT NULL COMMENT '令牌的 SHA-256 摘要（十六进制），明文只在创建时返回一次',
+  `token_prefix` varchar(16) NOT NULL DEFAULT '' COMMENT '令牌前缀，用于辨认令牌',
+  `scopes` varchar(1024) NOT NULL DEFAULT '' COMMENT '授权访问的权限编码，逗号分隔，为空表示不能访问需要权限的网关路由',
+  `expires_at` datetime DEFAULT NULL COMMENT '过期时间，为空表示永不过期',
+  `last_used_at` datetime DEFAULT NULL COMMENT '最后使用时间',
+  `created_by` bigint NOT NULL DEFAULT '0' COMMENT '创建人用户ID',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间（软删除）',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_token_hash` (`token_hash`),
+  KEY `idx_tenant_id` (`tenant_id`),
+  KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='租户 SCIM 访问令牌表';
+
 -- =====================================================
 -- 角色表 (roles)
 -- =====================================================
@@ -87,17 +176,211 @@ CREATE TABLE `roles` (
   `role_code` varchar(50) NOT NULL COMMENT '角色编码',
   `name` varchar(100) NOT NULL COMMENT '角色名称',
   `description` varchar(500) DEFAULT NULL COMMENT '描述',
+  `data_scope` varchar(16) NOT NULL DEFAULT 'tenant' COMMENT '数据权限范围：all-全部，tenant-本租户，dept-本部门及下级部门，self-仅本人，custom-自定义部门',
+  `data_scope_dept_ids` varchar(1000) DEFAULT NULL COMMENT '自定义数据权限的部门ID，逗号分隔',
   `status` tinyint(1) NOT NULL DEFAULT '1' COMMENT '状态：1-启用，0-禁用',
+  `system_role` varchar(32) DEFAULT NULL COMMENT '系统内置角色：tenant_owner,tenant_admin，为空表示普通角色',
   `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
   `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
   `deleted_at` datetime DEFAULT NULL COMMENT '删除时间（软删除）',
   PRIMARY KEY (`id`),
   UNIQUE KEY `idx_role_code_tenant` (`role_code`, `tenant_id`),
+  UNIQUE KEY `idx_system_role_tenant` (`system_role`, `tenant_id`),
   KEY `idx_tenant_id` (`tenant_id`),
   KEY `idx_status` (`status`),
   KEY `idx_deleted_at` (`deleted_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='角色表';
 
+-- =====================================================
+-- 平台运营人员表 (platform_operators)
+-- =====================================================
+
+DROP TABLE IF EXISTS `platform_operators`;
+CREATE TABLE `platform_operators` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `user_id` bigint NOT NULL COMMENT '用户ID',
+  `created_by` bigint NOT NULL DEFAULT '0' COMMENT '添加人用户ID，0 表示通过初始化脚本添加',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='平台运营人员表';
+
+-- =====================================================
+-- 平台操作审计日志表 (platform_audit_logs)
+-- =====================================================
+
+DROP TABLE IF EXISTS `platform_audit_logs`;
+CREATE TABLE `platform_audit_logs` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `operator_id` bigint NOT NULL COMMENT '操作人用户ID',
+  `method` varchar(10) NOT NULL COMMENT 'HTTP 方法',
+  `route` varchar(255) NOT NULL DEFAULT '' COMMENT '路由模板',
+  `path` varchar(500) NOT NULL DEFAULT '' COMMENT '请求路径',
+  `tenant_id` bigint NOT NULL DEFAULT '0' COMMENT '操作的租户ID，0 表示不针对租户',
+  `status_code` int NOT NULL COMMENT '响应状态码',
+  `message` varchar(1000) DEFAULT NULL COMMENT '失败原因',
+  `request_id` varchar(64) NOT NULL DEFAULT '' COMMENT '请求ID',
+  `client_ip` varchar(64) NOT NULL DEFAULT '' COMMENT '客户端IP',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_operator_id` (`operator_id`),
+  KEY `idx_tenant_id` (`tenant_id`),
+  KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='平台操作审计日志表';
+
+-- =====================================================
+-- 策略决策点服务凭证表 (service_clients)
+-- =====================================================
+
+DROP TABLE IF EXISTS `service_clients`;
+CREATE TABLE `service_clients` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `name` varchar(100) NOT NULL COMMENT '调用方服务名称',
+  `tenant_id` bigint NOT NULL DEFAULT '0' COMMENT '可以查询的租户ID，0 表示全部租户',
+  `token_hash` char(64) NOT NULL COMMENT '令牌的 SHA-256 摘要（十六进制），明文只在创建时返回一次',
+  `token_prefix` varchar(16) NOT NULL DEFAULT '' COMMENT '令牌前缀，用于辨认令牌',
+  `scopes` varchar(1024) NOT NULL DEFAULT '' COMMENT '授权访问的权限编码，逗号分隔，为空表示不能访问需要权限的网关路由',
+  `expires_at` datetime DEFAULT NULL COMMENT '过期时间，为空表示永不过期',
+  `last_used_at` datetime DEFAULT NULL COMMENT '最后使用时间',
+  `created_by` bigint NOT NULL DEFAULT '0' COMMENT '创建人用户ID',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间（软删除）',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_token_hash` (`token_hash`),
+  KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='策略决策点服务凭证表';
+
+-- =====================================================
+-- 限时角色授予表 (user_role_grants)
+-- =====================================================
+
+DROP TABLE IF EXISTS `user_role_grants`;
+CREATE TABLE `user_role_grants` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `user_id` bigint NOT NULL COMMENT '用户ID',
+  `role_id` bigint NOT NULL COMMENT '角色ID',
+  `kind` varchar(16) NOT NULL COMMENT '授予类型：scheduled 限时授予，jit 临时提权申请',
+  `status` varchar(16) NOT NULL COMMENT '状态：pending,approved,active,rejected,expired,revoked',
+  `valid_from` datetime DEFAULT NULL COMMENT '生效时间，临时提权申请在审批通过时设置',
+  `valid_until` datetime DEFAULT NULL COMMENT '失效时间',
+  `duration_seconds` bigint NOT NULL DEFAULT '0' COMMENT '授予时长（秒）',
+  `reason` varchar(500) DEFAULT NULL COMMENT '申请原因',
+  `ticket_ref` varchar(128) DEFAULT NULL COMMENT '关联的工单号',
+  `requested_by` bigint NOT NULL COMMENT '申请人或创建人用户ID',
+  `reviewed_by` bigint NOT NULL DEFAULT '0' COMMENT '审批人用户ID',
+  `review_comment` varchar(500) DEFAULT NULL COMMENT '审批意见',
+  `reviewed_at` datetime DEFAULT NULL COMMENT '审批时间',
+  `ended_at` datetime DEFAULT NULL COMMENT '过期或撤销时间',
+  `notified_at` datetime DEFAULT NULL COMMENT '通知用户授予结束的时间',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_tenant_user_role` (`tenant_id`, `user_id`, `role_id`),
+  KEY `idx_status_valid_from` (`status`, `valid_from`),
+  KEY `idx_status_valid_until` (`status`, `valid_until`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='限时角色授予表';
+
+-- =====================================================
+-- 访问申请表 (access_requests)
+-- =====================================================
+
+DROP TABLE IF EXISTS `access_requests`;
+CREATE TABLE `access_requests` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `requester_id` bigint NOT NULL COMMENT '申请人用户ID',
+  `target_type` varchar(16) NOT NULL COMMENT '申请对象类型：role,permission',
+  `target_id` bigint NOT NULL COMMENT '申请的角色或权限ID',
+  `justification` varchar(1000) NOT NULL COMMENT '申请理由',
+  `status` varchar(16) NOT NULL COMMENT '状态：pending,approved,rejected,expired,cancelled',
+  `approver_ids` varchar(1000) NOT NULL COMMENT '提交时确定的审批人用户ID，逗号分隔',
+  `quorum` int NOT NULL COMMENT '需要的批准人数',
+  `approvals` int NOT NULL DEFAULT '0' COMMENT '已批准的人数',
+  `expires_at` datetime NOT NULL COMMENT '未审批完成时的过期时间',
+  `decided_at` datetime DEFAULT NULL COMMENT '申请结束的时间',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_tenant_requester` (`tenant_id`, `requester_id`),
+  KEY `idx_status_expires_at` (`status`, `expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='访问申请表';
+
+-- =====================================================
+-- 访问申请审批记录表 (access_request_reviews)
+-- =====================================================
+
+DROP TABLE IF EXISTS `access_request_reviews`;
+CREATE TABLE `access_request_reviews` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `request_id` bigint NOT NULL COMMENT '申请ID',
+  `reviewer_id` bigint NOT NULL COMMENT '审批人用户ID',
+  `decision` varchar(16) NOT NULL COMMENT '审批结果：approve,reject',
+  `comment` varchar(500) DEFAULT NULL COMMENT '审批意见',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_request_reviewer` (`request_id`, `reviewer_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='访问申请审批记录表';
+
+-- =====================================================
+-- 访问申请审批策略表 (access_approval_policies)
+-- =====================================================
+
+DROP TABLE IF EXISTS `access_approval_policies`;
+CREATE TABLE `access_approval_policies` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `target_type` varchar(16) NOT NULL COMMENT '申请对象类型：role,permission',
+  `target_id` bigint NOT NULL COMMENT '角色或权限ID',
+  `approver_ids` varchar(1000) NOT NULL COMMENT '审批人用户ID，逗号分隔',
+  `quorum` int NOT NULL DEFAULT '1' COMMENT '需要的批准人数',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_tenant_target` (`tenant_id`, `target_type`, `target_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='访问申请审批策略表';
+
+-- =====================================================
+-- 职责分离规则表 (sod_rules)
+-- =====================================================
+
+DROP TABLE IF EXISTS `sod_rules`;
+CREATE TABLE `sod_rules` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `name` varchar(100) NOT NULL COMMENT '规则名称',
+  `kind` varchar(16) NOT NULL COMMENT '规则类型：exclusive,cardinality',
+  `role_ids` varchar(1000) NOT NULL COMMENT '约束的角色ID，逗号分隔',
+  `max_count` int NOT NULL DEFAULT '1' COMMENT '同一用户最多拥有的角色数或每个角色最多分配的用户数',
+  `description` varchar(500) DEFAULT NULL COMMENT '规则描述',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_tenant_name` (`tenant_id`, `name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='职责分离规则表';
+
+-- =====================================================
+-- 部门表 (departments)
+-- =====================================================
+
+DROP TABLE IF EXISTS `departments`;
+CREATE TABLE `departments` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '部门主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `parent_id` bigint DEFAULT NULL COMMENT '上级部门ID',
+  `name` varchar(100) NOT NULL COMMENT '部门名称',
+  `sort_order` int NOT NULL DEFAULT '0' COMMENT '排序',
+  `status` tinyint(1) NOT NULL DEFAULT '1' COMMENT '状态：1-启用，0-禁用',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间（软删除）',
+  PRIMARY KEY (`id`),
+  KEY `idx_tenant_parent` (`tenant_id`, `parent_id`),
+  KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='部门表';
+
 -- =====================================================
 -- 菜单表 (menus) - 重构版：纯UI结构
 -- =====================================================
@@ -145,6 +428,7 @@ CREATE TABLE `permissions` (
   `resource_path` varchar(255) DEFAULT NULL COMMENT 'API路径或资源标识',
   `http_method` varchar(20) DEFAULT NULL COMMENT 'HTTP方法：GET,POST,PUT,DELETE等',
   `action` varchar(50) DEFAULT NULL COMMENT '操作类型：view,create,update,delete,export等',
+  `condition` text DEFAULT NULL COMMENT '权限生效条件表达式，为空表示无条件',
   `status` tinyint(1) NOT NULL DEFAULT '1' COMMENT '状态：1-启用，0-禁用',
   `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
   `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
@@ -179,6 +463,44 @@ CREATE TABLE `menu_permissions` (
   KEY `idx_required` (`is_required`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='菜单权限关联表';
 
+-- =====================================================
+-- 权限自动分配配置表 (permission_auto_assign_configs)
+-- =====================================================
+
+DROP TABLE IF EXISTS `permission_auto_assign_configs`;
+CREATE TABLE `permission_auto_assign_configs` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `enabled` tinyint(1) NOT NULL DEFAULT '1' COMMENT '创建权限时是否自动分配：1-启用，0-禁用',
+  `admin_role_ids` varchar(1000) DEFAULT NULL COMMENT '系统管理员角色ID，逗号分隔',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_tenant_id` (`tenant_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='权限自动分配配置表';
+
+-- =====================================================
+-- 权限自动分配规则表 (permission_auto_assign_rules)
+-- =====================================================
+
+DROP TABLE IF EXISTS `permission_auto_assign_rules`;
+CREATE TABLE `permission_auto_assign_rules` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `role_ids` varchar(1000) DEFAULT NULL COMMENT '规则适用的角色ID，逗号分隔，为空时适用于全部系统管理员角色',
+  `modules` varchar(500) DEFAULT NULL COMMENT '匹配的权限模块（权限名称中冒号前的部分），逗号分隔，为空匹配全部',
+  `resource_types` varchar(100) DEFAULT NULL COMMENT '匹配的资源类型，逗号分隔，为空匹配全部',
+  `actions` varchar(255) DEFAULT NULL COMMENT '匹配的操作类型，逗号分隔，为空匹配全部',
+  `exclude_names` varchar(1000) DEFAULT NULL COMMENT '不匹配的权限名称，逗号分隔',
+  `include` tinyint(1) NOT NULL DEFAULT '1' COMMENT '规则类型：1-分配匹配的权限，0-排除匹配的权限',
+  `description` varchar(500) DEFAULT NULL COMMENT '规则描述',
+  `sort_order` int NOT NULL DEFAULT '0' COMMENT '排序',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_tenant_id` (`tenant_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='权限自动分配规则表';
+
 -- =====================================================
 -- 用户租户关联表 (user_tenants)
 -- =====================================================
@@ -188,6 +510,9 @@ CREATE TABLE `user_tenants` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
   `user_id` bigint NOT NULL COMMENT '用户ID',
   `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `department_id` bigint DEFAULT NULL COMMENT '用户在租户内所属部门ID',
+  `status` tinyint(1) NOT NULL DEFAULT '1' COMMENT '状态：1-启用，0-禁用',
+  `external_id` varchar(255) DEFAULT NULL COMMENT '外部系统中的用户ID（SCIM externalId）',
   `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
   `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
   `deleted_at` datetime DEFAULT NULL COMMENT '删除时间（软删除）',
@@ -195,6 +520,8 @@ CREATE TABLE `user_tenants` (
   UNIQUE KEY `idx_user_tenant` (`user_id`, `tenant_id`),
   KEY `idx_user_id` (`user_id`),
   KEY `idx_tenant_id` (`tenant_id`),
+  KEY `idx_tenant_external_id` (`tenant_id`, `external_id`),
+  KEY `idx_tenant_department` (`tenant_id`, `department_id`),
   KEY `idx_deleted_at` (`deleted_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户租户关联表';
 
@@ -227,7 +554,7 @@ DROP TABLE IF EXISTS `user_status`;
 CREATE TABLE `user_status` (
   `id` bigint NOT NULL AUTO_INCREMENT COMMENT '主键ID',
   `auth_id` varchar(255) NOT NULL COMMENT '认证标识符（邮箱、手机号、用户名等）',
-  `auth_type` tinyint NOT NULL COMMENT '认证类型：1-username,2-email,3-phone,4-wechat,5-qq,6-github,7-google,8-apple,9-dingtalk,10-feishu',
+  `auth_type` tinyint NOT NULL COMMENT '认证类型：1-username,2-email,3-phone,4-wechat,5-qq,6-github,7-google,8-apple,9-dingtalk,10-feishu,11-ldap,12-saml',
   `user_id` bigint unsigned NOT NULL COMMENT '用户ID（关联user表的id）',
   `tenant_id` bigint NOT NULL DEFAULT '1' COMMENT '租户ID',
   
@@ -305,12 +632,16 @@ INSERT INTO `tenants` (`tenant_code`, `name`, `description`, `status`) VALUES
 ('demo', '演示租户', '演示用租户', 1);
 
 -- 插入默认角色
-INSERT INTO `roles` (`tenant_id`, `role_code`, `name`, `description`, `status`) VALUES
-(1, 'super_admin', '超级管理员', '超级管理员角色（不可删除）', 1),
-(1, 'admin', '系统管理员', '拥有系统所有权限', 1),
-(1, 'user', '普通用户', '普通用户权限', 1),
-(2, 'super_admin', '超级管理员', '演示租户超级管理员', 1),
-(2, 'admin', '租户管理员', '租户管理员权限', 1);
+INSERT INTO `roles` (`tenant_id`, `role_code`, `name`, `description`, `data_scope`, `status`, `system_role`) VALUES
+(1, 'super_admin', '超级管理员', '租户所有者角色（不可删除）', 'all', 1, 'tenant_owner'),
+(1, 'admin', '系统管理员', '租户管理员角色（不可删除）', 'tenant', 1, 'tenant_admin'),
+(1, 'user', '普通用户', '普通用户权限', 'self', 1, NULL),
+(2, 'super_admin', '超级管理员', '演示租户所有者', 'tenant', 1, 'tenant_owner'),
+(2, 'admin', '租户管理员', '租户管理员权限', 'tenant', 1, 'tenant_admin');
+
+-- 插入平台运营人员：admin 用户可以管理所有租户
+INSERT INTO `platform_operators` (`user_id`, `created_by`) VALUES
+(1, 0);
 
 -- 插入Casbin权限规则数据（使用前缀+ID格式提高可读性）
 INSERT INTO `casbin_rule` (`ptype`, `v0`, `v1`, `v2`, `v3`, `v4`, `v5`) VALUES
@@ -319,67 +650,79 @@ INSERT INTO `casbin_rule` (`ptype`, `v0`, `v1`, `v2`, `v3`, `v4`, `v5`) VALUES
 ('g','u2','r2','t1',NULL,'',''),   -- user.id=2(user1) -> role.id=2(admin) in tenant.id=1  
 ('g','u3','r3','t1',NULL,'',''),   -- user.id=3(user2) -> role.id=3(user) in tenant.id=1
 
--- 角色权限分配（格式：p, r{role_id}, p{permission_id}, t{tenant_id}）
+-- 角色权限分配（格式：p, r{role_id}, p{permission_id}, t{tenant_id}, allow|deny），deny 规则优先于 allow 规则
 -- 超级管理员(role.id=1)拥有所有权限
-('p','r1','p1','t1',NULL,'',''),   -- role.id=1 -> permission.id=1(user:view) in tenant.id=1
-('p','r1','p2','t1',NULL,'',''),   -- role.id=1 -> permission.id=2(user:create) in tenant.id=1
-('p','r1','p3','t1',NULL,'',''),   -- role.id=1 -> permission.id=3(user:update) in tenant.id=1
-('p','r1','p4','t1',NULL,'',''),   -- role.id=1 -> permission.id=4(user:delete) in tenant.id=1
-('p','r1','p5','t1',NULL,'',''),   -- role.id=1 -> permission.id=5(user:export) in tenant.id=1
-('p','r1','p6','t1',NULL,'',''),   -- role.id=1 -> permission.id=6(role:view) in tenant.id=1
-('p','r1','p7','t1',NULL,'',''),   -- role.id=1 -> permission.id=7(role:create) in tenant.id=1
-('p','r1','p8','t1',NULL,'',''),   -- role.id=1 -> permission.id=8(role:update) in tenant.id=1
-('p','r1','p9','t1',NULL,'',''),   -- role.id=1 -> permission.id=9(role:delete) in tenant.id=1
-('p','r1','p10','t1',NULL,'',''),  -- role.id=1 -> permission.id=10(role:assign) in tenant.id=1
-('p','r1','p11','t1',NULL,'',''),  -- role.id=1 -> permission.id=11(permission:view) in tenant.id=1
-('p','r1','p12','t1',NULL,'',''),  -- role.id=1 -> permission.id=12(permission:assign) in tenant.id=1
-('p','r1','p13','t1',NULL,'',''),  -- role.id=1 -> permission.id=13(menu:view) in tenant.id=1
-('p','r1','p14','t1',NULL,'',''),  -- role.id=1 -> permission.id=14(menu:create) in tenant.id=1
-('p','r1','p15','t1',NULL,'',''),  -- role.id=1 -> permission.id=15(menu:update) in tenant.id=1
-('p','r1','p16','t1',NULL,'',''),  -- role.id=1 -> permission.id=16(menu:delete) in tenant.id=1
-('p','r1','p17','t1',NULL,'',''),  -- role.id=1 -> permission.id=17(tenant:view) in tenant.id=1
-('p','r1','p18','t1',NULL,'',''),  -- role.id=1 -> permission.id=18(tenant:switch) in tenant.id=1
-('p','r1','p19','t1',NULL,'',''),  -- role.id=1 -> permission.id=19(dashboard:view) in tenant.id=1
-('p','r1','p20','t1',NULL,'',''),  -- role.id=1 -> permission.id=20(profile:view) in tenant.id=1
-('p','r1','p21','t1',NULL,'',''),  -- role.id=1 -> permission.id=21(profile:update) in tenant.id=1
+('p','r1','p1','t1','allow','',''),   -- role.id=1 -> permission.id=1(user:view) in tenant.id=1
+('p','r1','p2','t1','allow','',''),   -- role.id=1 -> permission.id=2(user:create) in tenant.id=1
+('p','r1','p3','t1','allow','',''),   -- role.id=1 -> permission.id=3(user:update) in tenant.id=1
+('p','r1','p4','t1','allow','',''),   -- role.id=1 -> permission.id=4(user:delete) in tenant.id=1
+('p','r1','p5','t1','allow','',''),   -- role.id=1 -> permission.id=5(user:export) in tenant.id=1
+('p','r1','p6','t1','allow','',''),   -- role.id=1 -> permission.id=6(role:view) in tenant.id=1
+('p','r1','p7','t1','allow','',''),   -- role.id=1 -> permission.id=7(role:create) in tenant.id=1
+('p','r1','p8','t1','allow','',''),   -- role.id=1 -> permission.id=8(role:update) in tenant.id=1
+('p','r1','p9','t1','allow','',''),   -- role.id=1 -> permission.id=9(role:delete) in tenant.id=1
+('p','r1','p10','t1','allow','',''),  -- role.id=1 -> permission.id=10(role:assign) in tenant.id=1
+('p','r1','p11','t1','allow','',''),  -- role.id=1 -> permission.id=11(permission:view) in tenant.id=1
+('p','r1','p12','t1','allow','',''),  -- role.id=1 -> permission.id=12(permission:assign) in tenant.id=1
+('p','r1','p13','t1','allow','',''),  -- role.id=1 -> permission.id=13(menu:view) in tenant.id=1
+('p','r1','p14','t1','allow','',''),  -- role.id=1 -> permission.id=14(menu:create) in tenant.id=1
+('p','r1','p15','t1','allow','',''),  -- role.id=1 -> permission.id=15(menu:update) in tenant.id=1
+('p','r1','p16','t1','allow','',''),  -- role.id=1 -> permission.id=16(menu:delete) in tenant.id=1
+('p','r1','p17','t1','allow','',''),  -- role.id=1 -> permission.id=17(tenant:view) in tenant.id=1
+('p','r1','p18','t1','allow','',''),  -- role.id=1 -> permission.id=18(tenant:switch) in tenant.id=1
+('p','r1','p19','t1','allow','',''),  -- role.id=1 -> permission.id=19(dashboard:view) in tenant.id=1
+('p','r1','p20','t1','allow','',''),  -- role.id=1 -> permission.id=20(profile:view) in tenant.id=1
+('p','r1','p21','t1','allow','',''),  -- role.id=1 -> permission.id=21(profile:update) in tenant.id=1
+('p','r1','p22','t1','allow','',''),  -- role.id=1 -> permission.id=22(post:view) in tenant.id=1
+('p','r1','p23','t1','allow','',''),  -- role.id=1 -> permission.id=23(post:create) in tenant.id=1
+('p','r1','p24','t1','allow','',''),  -- role.id=1 -> permission.id=24(post:update) in tenant.id=1
+('p','r1','p25','t1','allow','',''),  -- role.id=1 -> permission.id=25(post:delete) in tenant.id=1
 
 -- 系统管理员(role.id=2)拥有部分权限
-('p','r2','p1','t1',NULL,'',''),   -- role.id=2 -> permission.id=1(user:view) in tenant.id=1
-('p','r2','p2','t1',NULL,'',''),   -- role.id=2 -> permission.id=2(user:create) in tenant.id=1
-('p','r2','p3','t1',NULL,'',''),   -- role.id=2 -> permission.id=3(user:update) in tenant.id=1
-('p','r2','p6','t1',NULL,'',''),   -- role.id=2 -> permission.id=6(role:view) in tenant.id=1
-('p','r2','p13','t1',NULL,'',''),  -- role.id=2 -> permission.id=13(menu:view) in tenant.id=1
-('p','r2','p19','t1',NULL,'',''),  -- role.id=2 -> permission.id=19(dashboard:view) in tenant.id=1
-('p','r2','p20','t1',NULL,'',''),  -- role.id=2 -> permission.id=20(profile:view) in tenant.id=1
-('p','r2','p21','t1',NULL,'',''),  -- role.id=2 -> permission.id=21(profile:update) in tenant.id=1
+('p','r2','p1','t1','allow','',''),   -- role.id=2 -> permission.id=1(user:view) in tenant.id=1
+('p','r2','p2','t1','allow','',''),   -- role.id=2 -> permission.id=2(user:create) in tenant.id=1
+('p','r2','p3','t1','allow','',''),   -- role.id=2 -> permission.id=3(user:update) in tenant.id=1
+('p','r2','p6','t1','allow','',''),   -- role.id=2 -> permission.id=6(role:view) in tenant.id=1
+('p','r2','p13','t1','allow','',''),  -- role.id=2 -> permission.id=13(menu:view) in tenant.id=1
+('p','r2','p19','t1','allow','',''),  -- role.id=2 -> permission.id=19(dashboard:view) in tenant.id=1
+('p','r2','p20','t1','allow','',''),  -- role.id=2 -> permission.id=20(profile:view) in tenant.id=1
+('p','r2','p21','t1','allow','',''),  -- role.id=2 -> permission.id=21(profile:update) in tenant.id=1
+('p','r2','p22','t1','allow','',''),  -- role.id=2 -> permission.id=22(post:view) in tenant.id=1
+('p','r2','p23','t1','allow','',''),  -- role.id=2 -> permission.id=23(post:create) in tenant.id=1
+('p','r2','p24','t1','allow','',''),  -- role.id=2 -> permission.id=24(post:update) in tenant.id=1
+('p','r2','p25','t1','allow','',''),  -- role.id=2 -> permission.id=25(post:delete) in tenant.id=1
 
 -- 普通用户(role.id=3)拥有基础权限
-('p','r3','p19','t1',NULL,'',''),  -- role.id=3 -> permission.id=19(dashboard:view) in tenant.id=1
-('p','r3','p20','t1',NULL,'',''),  -- role.id=3 -> permission.id=20(profile:view) in tenant.id=1
-('p','r3','p21','t1',NULL,'','');  -- role.id=3 -> permission.id=21(profile:update) in tenant.id=1
+('p','r3','p19','t1','allow','',''),  -- role.id=3 -> permission.id=19(dashboard:view) in tenant.id=1
+('p','r3','p20','t1','allow','',''),  -- role.id=3 -> permission.id=20(profile:view) in tenant.id=1
+('p','r3','p21','t1','allow','',''),  -- role.id=3 -> permission.id=21(profile:update) in tenant.id=1
+('p','r3','p22','t1','allow','',''),  -- role.id=3 -> permission.id=22(post:view) in tenant.id=1
+('p','r3','p23','t1','allow','',''),  -- role.id=3 -> permission.id=23(post:create) in tenant.id=1
+('p','r3','p24','t1','allow','',''),  -- role.id=3 -> permission.id=24(post:update) in tenant.id=1
+('p','r3','p25','t1','allow','','');  -- role.id=3 -> permission.id=25(post:delete) in tenant.id=1
 
 -- 插入默认用户数据
 INSERT INTO `user` VALUES
-(1,'admin','$2a$10$ctsFXEUAMd7rXXpmccNlO.ZRiYGYz0eOfj8EicPGWqiz64YBBgR1y','管理员','admin@example.com','13800138000','2024-12-12 03:55:25','2024-12-12 03:55:25',NULL),
-(2,'user1','$2a$10$ctsFXEUAMd7rXXpmccNlO.ZRiYGYz0eOfj8EicPGWqiz64YBBgR1y','用户1','user1@example.com','13800138001','2024-12-12 03:55:25','2024-12-12 03:55:25',NULL),
-(3,'user2','$2a$10$ctsFXEUAMd7rXXpmccNlO.ZRiYGYz0eOfj8EicPGWqiz64YBBgR1y','用户2','user2@example.com','13800138002','2024-12-12 03:55:25','2024-12-12 03:55:25',NULL);
+(1,'admin','$2a$10$ctsFXEUAMd7rXXpmccNlO.ZRiYGYz0eOfj8EicPGWqiz64YBBgR1y','管理员','admin@example.com','+8613800138000','2024-12-12 03:55:25','2024-12-12 03:55:25',NULL),
+(2,'user1','$2a$10$ctsFXEUAMd7rXXpmccNlO.ZRiYGYz0eOfj8EicPGWqiz64YBBgR1y','用户1','user1@example.com','+8613800138001','2024-12-12 03:55:25','2024-12-12 03:55:25',NULL),
+(3,'user2','$2a$10$ctsFXEUAMd7rXXpmccNlO.ZRiYGYz0eOfj8EicPGWqiz64YBBgR1y','用户2','user2@example.com','+8613800138002','2024-12-12 03:55:25','2024-12-12 03:55:25',NULL);
 
 -- 插入用户状态数据（与user表对应）
 INSERT INTO `user_status` (`auth_id`, `auth_type`, `user_id`, `tenant_id`, `status`, `is_verified`, `is_primary`) VALUES
 -- admin 用户(id=1)的多种认证方式
 ('admin', 1, 1, 1, 1, 1, 1),                         -- username, active, verified, primary
 ('admin@example.com', 2, 1, 1, 1, 1, 0),             -- email, active, verified, not primary
-('13800138000', 3, 1, 1, 1, 1, 0),                   -- phone, active, verified, not primary
+('+8613800138000', 3, 1, 1, 1, 1, 0),                -- phone, active, verified, not primary
 
 -- user1 用户(id=2)的多种认证方式
 ('user1', 1, 2, 1, 1, 1, 1),                         -- username, active, verified, primary
 ('user1@example.com', 2, 2, 1, 1, 1, 0),            -- email, active, verified, not primary
-('13800138001', 3, 2, 1, 1, 1, 0),                   -- phone, active, verified, not primary
+('+8613800138001', 3, 2, 1, 1, 1, 0),                -- phone, active, verified, not primary
 
 -- user2 用户(id=3)的多种认证方式
 ('user2', 1, 3, 1, 1, 1, 1),                         -- username, active, verified, primary
 ('user2@example.com', 2, 3, 1, 1, 0, 0),            -- email, active, not verified, not primary
-('13800138002', 3, 3, 1, 1, 0, 0);                   -- phone, active, not verified, not primary
+('+8613800138002', 3, 3, 1, 1, 0, 0);                -- phone, active, not verified, not primary
 
 -- 插入用户租户关联
 INSERT INTO `user_tenants` (`user_id`, `tenant_id`, `created_at`, `updated_at`) VALUES
@@ -442,7 +785,13 @@ INSERT INTO `permissions` (`tenant_id`, `permission_code`, `name`, `description`
 -- 系统管理权限
 (1, 'dashboard:view', '查看仪表板', '访问系统仪表板', 'menu', 'view', 1),
 (1, 'profile:view', '查看个人资料', '查看个人信息', 'menu', 'view', 1),
-(1, 'profile:update', '编辑个人资料', '修改个人信息', 'menu', 'update', 1);
+(1, 'profile:update', '编辑个人资料', '修改个人信息', 'menu', 'update', 1),
+
+-- 文章管理权限
+(1, 'post:view', '查看文章', '查看文章列表和详情', 'api', 'view', 1),
+(1, 'post:create', '创建文章', '发布新文章', 'api', 'create', 1),
+(1, 'post:update', '编辑文章', '修改文章内容', 'api', 'update', 1),
+(1, 'post:delete', '删除文章', '删除文章', 'api', 'delete', 1);
 
 -- 配置菜单权限关联（示例）
 INSERT INTO `menu_permissions` (`tenant_id`, `menu_id`, `permission_id`, `is_required`)
@@ -491,6 +840,8 @@ WHERE m.deleted_at IS NULL AND p.deleted_at IS NULL;
 -- 8  - apple      (Apple)
 -- 9  - dingtalk   (钉钉)
 -- 10 - feishu     (飞书)
+-- 11 - ldap       (LDAP / Active Directory，auth_id 为 "租户ID:用户名")
+-- 12 - saml       (SAML 2.0 SSO，auth_id 为 "租户ID:NameID")
 -- 
 -- status 用户状态映射：
 -- 1 - active      (活跃)
@@ -561,6 +912,10 @@ WHERE m.deleted_at IS NULL AND p.deleted_at IS NULL;
 -- 19 - dashboard:view   (查看仪表板)
 -- 20 - profile:view     (查看个人资料)
 -- 21 - profile:update   (编辑个人资料)
+-- 22 - post:view        (查看文章)
+-- 23 - post:create      (创建文章)
+-- 24 - post:update      (编辑文章)
+-- 25 - post:delete      (删除文章)
 -- 
 -- 优势：
 -- 1. 前缀格式增强可读性和可维护性
@@ -12,10 +12,10 @@ INSERT INTO `tenants` (`id`, `tenant_code`, `name`, `description`, `status`, `cr
 (1, 'default', '默认租户', '系统默认租户', 1, NOW(), NOW());
 
 -- 创建角色层次结构
-INSERT INTO `roles` (`id`, `tenant_id`, `role_code`, `name`, `description`, `status`, `created_at`, `updated_at`) VALUES 
-(1, 1, 'super_admin', '超级管理员', '拥有系统所有权限的超级管理员角色，绕过权限检查', 1, NOW(), NOW()),
-(2, 1, 'admin', '系统管理员', '拥有大部分管理权限的系统管理员角色，受权限控制', 1, NOW(), NOW()),
-(3, 1, 'user', '普通用户', '普通业务用户角色，只有基础权限', 1, NOW(), NOW());
+INSERT INTO `roles` (`id`, `tenant_id`, `role_code`, `name`, `description`, `data_scope`, `status`, `created_at`, `updated_at`) VALUES 
+(1, 1, 'super_admin', '超级管理员', '拥有系统所有权限的超级管理员角色，绕过权限检查', 'all', 1, NOW(), NOW()),
+(2, 1, 'admin', '系统管理员', '拥有大部分管理权限的系统管理员角色，受权限控制', 'tenant', 1, NOW(), NOW()),
+(3, 1, 'user', '普通用户', '普通业务用户角色，只有基础权限', 'self', 1, NOW(), NOW());
 
 -- 创建超级管理员用户
 INSERT INTO `user` (`id`, `username`, `nickname`, `email`, `phone`, `avatar`, `created_at`, `updated_at`) VALUES 
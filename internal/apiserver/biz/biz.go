@@ -12,10 +12,20 @@ import (
 	"github.com/ashwinyue/one-auth/pkg/authz"
 	"github.com/google/wire"
 
+	accessrequestv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/accessrequest"
+	autoassignv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/autoassign"
+	catalogv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/catalog"
+	consistencyv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/consistency"
+	extauthzv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/extauthz"
 	menuv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/menu"
+	pdpv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/pdp"
 	permissionv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/permission"
+	platformv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/platform"
 	postv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/post"
 	rolev1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/role"
+	rolegrantv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/rolegrant"
+	scimv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/scim"
+	sodv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/sod"
 	tenantv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/tenant"
 	userv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/user"
 	"github.com/ashwinyue/one-auth/internal/apiserver/cache"
@@ -52,6 +62,35 @@ type IBiz interface {
 	// MenuV1 获取菜单业务接口.
 	MenuV1() menuv1.MenuBiz
 
+	// SCIMV1 获取 SCIM 供应业务接口.
+	SCIMV1() scimv1.SCIMBiz
+
+	// AutoAssignV1 获取管理员权限自动分配业务接口.
+	AutoAssignV1() autoassignv1.AutoAssignBiz
+
+	// CatalogV1 获取权限目录导入导出业务接口.
+	CatalogV1() catalogv1.CatalogBiz
+
+	// PlatformV1 获取平台运营业务接口.
+	PlatformV1() platformv1.PlatformBiz
+
+	// RoleGrantV1 获取限时角色授予业务接口.
+	RoleGrantV1() rolegrantv1.RoleGrantBiz
+
+	// AccessRequestV1 获取访问申请业务接口.
+	AccessRequestV1() accessrequestv1.AccessRequestBiz
+
+	// SoDV1 获取职责分离规则业务接口.
+	SoDV1() sodv1.SoDBiz
+
+	// ConsistencyV1 获取策略一致性检查业务接口.
+	ConsistencyV1() consistencyv1.ConsistencyBiz
+
+	// PDPV1 获取策略决策点业务接口.
+	PDPV1() pdpv1.PDPBiz
+	// ExtAuthzV1 获取 Envoy 外部授权业务接口.
+	ExtAuthzV1() extauthzv1.ExtAuthzBiz
+
 	// PostV2 获取帖子业务接口（V2 版本）.
 	// PostV2() post.PostBiz
 }
@@ -61,22 +100,23 @@ type biz struct {
 	store store.IStore
 	authz *authz.Authz
 	cache cache.ICache
+	sms   sms.Client
 }
 
 // 确保 biz 实现了 IBiz 接口.
 var _ IBiz = (*biz)(nil)
 
 // NewBiz 创建一个 IBiz 类型的实例.
-func NewBiz(store store.IStore, authz *authz.Authz, cache cache.ICache) *biz {
-	return &biz{store: store, authz: authz, cache: cache}
+func NewBiz(store store.IStore, authz *authz.Authz, cache cache.ICache, sms sms.Client) *biz {
+	return &biz{store: store, authz: authz, cache: cache, sms: sms}
 }
 
 // UserV1 返回一个实现了 UserBiz 接口的实例.
 func (b *biz) UserV1() userv1.UserBiz {
 	sessionManager := cache.NewSessionManager(b.cache)
 	loginSecurity := cache.NewLoginSecurityManager(b.cache)
-	smsClient := sms.NewClient(nil)
-	return userv1.New(b.store, b.authz, sessionManager, loginSecurity, smsClient)
+	samlState := cache.NewSAMLStateManager(b.cache)
+	return userv1.New(b.store, b.authz, sessionManager, loginSecurity, samlState, b.sms)
 }
 
 // PostV1 返回一个实现了 PostBiz 接口的实例.
@@ -103,3 +143,53 @@ func (b *biz) TenantV1() tenantv1.TenantBiz {
 func (b *biz) MenuV1() menuv1.MenuBiz {
 	return menuv1.NewMenuBiz(b.store, b.authz)
 }
+
+// SCIMV1 返回一个实现了 SCIMBiz 接口的实例.
+func (b *biz) SCIMV1() scimv1.SCIMBiz {
+	return scimv1.New(b.store, b.authz, cache.NewSessionManager(b.cache), b.sms)
+}
+
+// AutoAssignV1 返回一个实现了 AutoAssignBiz 接口的实例.
+func (b *biz) AutoAssignV1() autoassignv1.AutoAssignBiz {
+	return autoassignv1.New(b.store, b.authz)
+}
+
+// CatalogV1 返回一个实现了 CatalogBiz 接口的实例.
+func (b *biz) CatalogV1() catalogv1.CatalogBiz {
+	return catalogv1.New(b.store, b.authz)
+}
+
+// PlatformV1 返回一个实现了 PlatformBiz 接口的实例.
+func (b *biz) PlatformV1() platformv1.PlatformBiz {
+	return platformv1.New(b.store, b.authz)
+}
+
+// RoleGrantV1 返回一个实现了 RoleGrantBiz 接口的实例.
+func (b *biz) RoleGrantV1() rolegrantv1.RoleGrantBiz {
+	return rolegrantv1.New(b.store, b.authz)
+}
+
+// AccessRequestV1 返回一个实现了 AccessRequestBiz 接口的实例.
+func (b *biz) AccessRequestV1() accessrequestv1.AccessRequestBiz {
+	return accessrequestv1.New(b.store, b.authz)
+}
+
+// SoDV1 返回一个实现了 SoDBiz 接口的实例.
+func (b *biz) SoDV1() sodv1.SoDBiz {
+	return sodv1.New(b.store, b.authz)
+}
+
+// ConsistencyV1 返回一个实现了 ConsistencyBiz 接口的实例.
+func (b *biz) ConsistencyV1() consistencyv1.ConsistencyBiz {
+	return consistencyv1.New(b.store, b.authz)
+}
+
+// PDPV1 返回一个实现了 PDPBiz 接口的实例.
+func (b *biz) PDPV1() pdpv1.PDPBiz {
+	return pdpv1.New(b.authz)
+}
+
+// ExtAuthzV1 返回一个实现了 ExtAuthzBiz 接口的实例.
+func (b *biz) ExtAuthzV1() extauthzv1.ExtAuthzBiz {
+	return extauthzv1.New(b.store, b.authz, cache.NewSessionManager(b.cache))
+}
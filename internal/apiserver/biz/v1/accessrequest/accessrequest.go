@@ -0,0 +1,659 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package accessrequest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// RequestTTL 是访问申请等待审批的最长时间，超时后申请自动过期
+var RequestTTL = 7 * 24 * time.Hour
+
+// AccessRequestBiz 定义了访问申请和审批策略的业务逻辑接口.
+type AccessRequestBiz interface {
+	// 申请人
+	Create(ctx context.Context, rq *apiv1.CreateAccessRequestRequest) (*apiv1.CreateAccessRequestResponse, error)
+	Cancel(ctx context.Context, rq *apiv1.CancelAccessRequestRequest) (*apiv1.CancelAccessRequestResponse, error)
+	ListMine(ctx context.Context, rq *apiv1.ListAccessRequestsRequest) (*apiv1.ListAccessRequestsResponse, error)
+
+	// 审批人
+	Approve(ctx context.Context, rq *apiv1.ReviewAccessRequestRequest) (*apiv1.ReviewAccessRequestResponse, error)
+	Reject(ctx context.Context, rq *apiv1.ReviewAccessRequestRequest) (*apiv1.ReviewAccessRequestResponse, error)
+	ListReviews(ctx context.Context, rq *apiv1.ListAccessRequestsRequest) (*apiv1.ListAccessRequestsResponse, error)
+
+	// 申请人和审批人都可以查看申请详情
+	Get(ctx context.Context, rq *apiv1.GetAccessRequestRequest) (*apiv1.GetAccessRequestResponse, error)
+
+	// 审批策略管理
+	ListPolicies(ctx context.Context, rq *apiv1.ListAccessApprovalPoliciesRequest) (*apiv1.ListAccessApprovalPoliciesResponse, error)
+	SetPolicy(ctx context.Context, rq *apiv1.SetAccessApprovalPolicyRequest) (*apiv1.SetAccessApprovalPolicyResponse, error)
+	DeletePolicy(ctx context.Context, rq *apiv1.DeleteAccessApprovalPolicyRequest) (*apiv1.DeleteAccessApprovalPolicyResponse, error)
+
+	AccessRequestExpansion
+}
+
+// AccessRequestExpansion 定义了供后台任务使用的扩展方法.
+type AccessRequestExpansion interface {
+	// ExpireDue 将超时未审批完成的申请标记为过期，返回过期的数量
+	ExpireDue(ctx context.Context, now time.Time) (int, error)
+}
+
+// accessRequestBiz 是 AccessRequestBiz 接口的实现.
+type accessRequestBiz struct {
+	store store.IStore
+	authz *authz.Authz
+}
+
+// 确保 accessRequestBiz 实现了 AccessRequestBiz 接口.
+var _ AccessRequestBiz = (*accessRequestBiz)(nil)
+
+// New 创建一个新的 AccessRequestBiz 实例.
+func New(store store.IStore, authz *authz.Authz) *accessRequestBiz {
+	return &accessRequestBiz{store: store, authz: authz}
+}
+
+// Create 当前用户申请角色或权限，审批人和批准人数在提交时确定
+func (b *accessRequestBiz) Create(ctx context.Context, rq *apiv1.CreateAccessRequestRequest) (*apiv1.CreateAccessRequestResponse, error) {
+	if strings.TrimSpace(rq.Justification) == "" {
+		return nil, errno.ErrInvalidArgument.WithMessage("justification cannot be empty")
+	}
+
+	tenantID := currentTenantID(ctx)
+	requesterID := contextx.UserID(ctx)
+	if err := b.checkTarget(ctx, tenantID, rq.TargetType, rq.TargetId); err != nil {
+		return nil, err
+	}
+	if err := b.checkRequestable(ctx, tenantID, requesterID, rq.TargetType, rq.TargetId); err != nil {
+		return nil, err
+	}
+
+	approverIDs, quorum, err := b.resolveApprovers(ctx, tenantID, rq.TargetType, rq.TargetId)
+	if err != nil {
+		return nil, err
+	}
+	approverIDs = slices.DeleteFunc(approverIDs, func(id int64) bool { return id == requesterID })
+	if len(approverIDs) < int(quorum) {
+		return nil, errno.ErrAccessRequestNoApprover.WithMessage("%d approvals required but only %d approvers available", quorum, len(approverIDs))
+	}
+
+	requestM := &model.AccessRequestM{
+		TenantID:      tenantID,
+		RequesterID:   requesterID,
+		TargetType:    rq.TargetType,
+		TargetID:      rq.TargetId,
+		Justification: rq.Justification,
+		Status:        model.AccessRequestStatusPending,
+		ApproverIDs:   joinIDs(approverIDs),
+		Quorum:        quorum,
+		ExpiresAt:     time.Now().Add(RequestTTL),
+	}
+	if err := b.store.AccessRequest().Create(ctx, requestM); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage(err.Error())
+	}
+
+	if err := DefaultNotifier.NotifyApprovers(ctx, requestM, approverIDs); err != nil {
+		log.W(ctx).Errorw("Failed to notify access request approvers", "request_id", requestM.ID, "err", err)
+	}
+	return &apiv1.CreateAccessRequestResponse{Request: convertAccessRequestToAPI(requestM, nil)}, nil
+}
+
+// Cancel 申请人撤回待审批的申请
+func (b *accessRequestBiz) Cancel(ctx context.Context, rq *apiv1.CancelAccessRequestRequest) (*apiv1.CancelAccessRequestResponse, error) {
+	requestM, err := b.get(ctx, rq.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	if requestM.RequesterID != contextx.UserID(ctx) {
+		return nil, errno.ErrAccessRequestNotFound
+	}
+
+	if err := b.finish(ctx, requestM, model.AccessRequestStatusCancelled, time.Now()); err != nil {
+		return nil, err
+	}
+	return &apiv1.CancelAccessRequestResponse{Request: convertAccessRequestToAPI(requestM, nil)}, nil
+}
+
+// ListMine 获取当前用户提交的申请
+func (b *accessRequestBiz) ListMine(ctx context.Context, rq *apiv1.ListAccessRequestsRequest) (*apiv1.ListAccessRequestsResponse, error) {
+	return b.list(ctx, rq, where.F("requester_id", contextx.UserID(ctx)))
+}
+
+// ListReviews 获取当前用户可以审批的申请，未指定状态时只返回尚未审批的待审批申请
+func (b *accessRequestBiz) ListReviews(ctx context.Context, rq *apiv1.ListAccessRequestsRequest) (*apiv1.ListAccessRequestsResponse, error) {
+	userID := contextx.UserID(ctx)
+	opts := where.NewWhere().Q("FIND_IN_SET(?, approver_ids) > 0", strconv.FormatInt(userID, 10))
+	if rq.Status == "" {
+		opts = opts.F("status", model.AccessRequestStatusPending).
+			Q("id NOT IN (SELECT request_id FROM access_request_reviews WHERE reviewer_id = ?)", userID)
+	}
+	return b.list(ctx, rq, opts)
+}
+
+// Get 获取申请详情和审批记录，只有申请人和审批人可以查看
+func (b *accessRequestBiz) Get(ctx context.Context, rq *apiv1.GetAccessRequestRequest) (*apiv1.GetAccessRequestResponse, error) {
+	requestM, err := b.get(ctx, rq.RequestId)
+	if err != nil {
+		return nil, err
+	}
+	userID := contextx.UserID(ctx)
+	if requestM.RequesterID != userID && !slices.Contains(requestM.GetApproverIDs(), userID) {
+		return nil, errno.ErrAccessRequestNotFound
+	}
+
+	_, reviews, err := b.store.AccessRequestReview().List(ctx, where.F("request_id", requestM.ID))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	return &apiv1.GetAccessRequestResponse{Request: convertAccessRequestToAPI(requestM, reviews)}, nil
+}
+
+// Approve 批准申请，批准人数达到要求时授予申请的角色或权限
+func (b *accessRequestBiz) Approve(ctx context.Context, rq *apiv1.ReviewAccessRequestRequest) (*apiv1.ReviewAccessRequestResponse, error) {
+	requestM, err := b.getReviewable(ctx, rq.RequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.addReview(ctx, requestM, model.AccessReviewApprove, rq.Comment); err != nil {
+			return err
+		}
+		approvals, _, err := b.store.AccessRequestReview().List(ctx, where.F("request_id", requestM.ID, "decision", model.AccessReviewApprove))
+		if err != nil {
+			return errno.ErrDBRead.WithMessage(err.Error())
+		}
+		requestM.Approvals = int32(approvals)
+		if requestM.Approvals >= requestM.Quorum {
+			now := time.Now()
+			requestM.Status = model.AccessRequestStatusApproved
+			requestM.DecidedAt = &now
+		}
+		return b.transition(ctx, requestM, model.AccessRequestStatusPending)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if requestM.Status == model.AccessRequestStatusApproved {
+		if err := b.applyGrant(ctx, requestM); err != nil {
+			return nil, err
+		}
+		b.notifyRequester(ctx, requestM)
+	}
+	return &apiv1.ReviewAccessRequestResponse{Request: convertAccessRequestToAPI(requestM, nil)}, nil
+}
+
+// Reject 拒绝申请，任一审批人拒绝后申请结束
+func (b *accessRequestBiz) Reject(ctx context.Context, rq *apiv1.ReviewAccessRequestRequest) (*apiv1.ReviewAccessRequestResponse, error) {
+	requestM, err := b.getReviewable(ctx, rq.RequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.addReview(ctx, requestM, model.AccessReviewReject, rq.Comment); err != nil {
+			return err
+		}
+		now := time.Now()
+		requestM.Status = model.AccessRequestStatusRejected
+		requestM.DecidedAt = &now
+		return b.transition(ctx, requestM, model.AccessRequestStatusPending)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b.notifyRequester(ctx, requestM)
+	return &apiv1.ReviewAccessRequestResponse{Request: convertAccessRequestToAPI(requestM, nil)}, nil
+}
+
+// ExpireDue 将超时未审批完成的申请标记为过期并通知申请人
+func (b *accessRequestBiz) ExpireDue(ctx context.Context, now time.Time) (int, error) {
+	_, requests, err := b.store.AccessRequest().List(ctx, where.F("status", model.AccessRequestStatusPending).Q("expires_at <= ?", now))
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, requestM := range requests {
+		if err := b.finish(ctx, requestM, model.AccessRequestStatusExpired, now); err != nil {
+			log.W(ctx).Errorw("Failed to expire access request", "request_id", requestM.ID, "err", err)
+			continue
+		}
+		b.notifyRequester(ctx, requestM)
+		expired++
+	}
+	return expired, nil
+}
+
+// ListPolicies 获取当前租户的审批策略
+func (b *accessRequestBiz) ListPolicies(ctx context.Context, rq *apiv1.ListAccessApprovalPoliciesRequest) (*apiv1.ListAccessApprovalPoliciesResponse, error) {
+	opts := where.F("tenant_id", currentTenantID(ctx))
+	if rq.TargetType != "" {
+		opts = opts.F("target_type", rq.TargetType)
+	}
+	_, policies, err := b.store.AccessApprovalPolicy().List(ctx, opts)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	resp := &apiv1.ListAccessApprovalPoliciesResponse{}
+	for _, policyM := range policies {
+		resp.Policies = append(resp.Policies, convertPolicyToAPI(policyM))
+	}
+	return resp, nil
+}
+
+// SetPolicy 设置角色或权限的审批人和批准人数，只影响之后提交的申请
+func (b *accessRequestBiz) SetPolicy(ctx context.Context, rq *apiv1.SetAccessApprovalPolicyRequest) (*apiv1.SetAccessApprovalPolicyResponse, error) {
+	tenantID := currentTenantID(ctx)
+	if err := b.checkTarget(ctx, tenantID, rq.TargetType, rq.TargetId); err != nil {
+		return nil, err
+	}
+
+	approverIDs := uniqueIDs(rq.ApproverIds)
+	if len(approverIDs) == 0 {
+		return nil, errno.ErrInvalidArgument.WithMessage("approver_ids cannot be empty")
+	}
+	quorum := rq.Quorum
+	if quorum == 0 {
+		quorum = 1
+	}
+	if quorum < 0 || int(quorum) > len(approverIDs) {
+		return nil, errno.ErrInvalidArgument.WithMessage("quorum must be between 1 and %d", len(approverIDs))
+	}
+	for _, approverID := range approverIDs {
+		member, err := b.store.Tenant().CheckUserTenant(ctx, strconv.FormatInt(approverID, 10), tenantID)
+		if err != nil {
+			return nil, errno.ErrDBRead.WithMessage(err.Error())
+		}
+		if !member {
+			return nil, errno.ErrInvalidArgument.WithMessage("approver %d does not belong to current tenant", approverID)
+		}
+	}
+
+	policyM, err := b.store.AccessApprovalPolicy().Get(ctx, where.F("tenant_id", tenantID, "target_type", rq.TargetType, "target_id", rq.TargetId))
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrDBRead.WithMessage(err.Error())
+		}
+		policyM = &model.AccessApprovalPolicyM{TenantID: tenantID, TargetType: rq.TargetType, TargetID: rq.TargetId}
+	}
+	policyM.ApproverIDs = joinIDs(approverIDs)
+	policyM.Quorum = quorum
+
+	if policyM.ID == 0 {
+		err = b.store.AccessApprovalPolicy().Create(ctx, policyM)
+	} else {
+		err = b.store.AccessApprovalPolicy().Update(ctx, policyM)
+	}
+	if err != nil {
+		return nil, errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	return &apiv1.SetAccessApprovalPolicyResponse{Policy: convertPolicyToAPI(policyM)}, nil
+}
+
+// DeletePolicy 删除审批策略，删除后改由租户所有者和租户管理员审批
+func (b *accessRequestBiz) DeletePolicy(ctx context.Context, rq *apiv1.DeleteAccessApprovalPolicyRequest) (*apiv1.DeleteAccessApprovalPolicyResponse, error) {
+	opts := where.F("id", rq.PolicyId, "tenant_id", currentTenantID(ctx))
+	if _, err := b.store.AccessApprovalPolicy().Get(ctx, opts); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAccessApprovalPolicyNotFound
+		}
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if err := b.store.AccessApprovalPolicy().Delete(ctx, opts); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	return &apiv1.DeleteAccessApprovalPolicyResponse{}, nil
+}
+
+// list 按过滤条件获取当前租户的申请
+func (b *accessRequestBiz) list(ctx context.Context, rq *apiv1.ListAccessRequestsRequest, opts *where.Options) (*apiv1.ListAccessRequestsResponse, error) {
+	opts = opts.F("tenant_id", currentTenantID(ctx))
+	if rq.Status != "" {
+		opts = opts.F("status", rq.Status)
+	}
+	if rq.TargetType != "" {
+		opts = opts.F("target_type", rq.TargetType)
+	}
+	if rq.Offset > 0 {
+		opts = opts.O(int(rq.Offset))
+	}
+	if rq.Limit > 0 {
+		opts = opts.L(int(rq.Limit))
+	}
+
+	count, requests, err := b.store.AccessRequest().List(ctx, opts)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	resp := &apiv1.ListAccessRequestsResponse{TotalCount: count}
+	for _, requestM := range requests {
+		resp.Requests = append(resp.Requests, convertAccessRequestToAPI(requestM, nil))
+	}
+	return resp, nil
+}
+
+// checkTarget 检查申请对象类型合法，且角色或权限属于租户并已启用
+func (b *accessRequestBiz) checkTarget(ctx context.Context, tenantID int64, targetType string, targetID int64) error {
+	ctx = store.WithoutDataScope(ctx)
+	opts := where.F("id", targetID, "tenant_id", tenantID)
+
+	switch targetType {
+	case model.AccessTargetRole:
+		roleM, err := b.store.Role().Get(ctx, opts)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.ErrRoleNotFound
+			}
+			return errno.ErrDBRead.WithMessage(err.Error())
+		}
+		if !roleM.Status {
+			return errno.ErrInvalidArgument.WithMessage("role %d is disabled", targetID)
+		}
+	case model.AccessTargetPermission:
+		permissionM, err := b.store.Permission().Get(ctx, opts)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errno.ErrInvalidArgument.WithMessage("permission %d not found in current tenant", targetID)
+			}
+			return errno.ErrDBRead.WithMessage(err.Error())
+		}
+		if !permissionM.Status {
+			return errno.ErrInvalidArgument.WithMessage("permission %d is disabled", targetID)
+		}
+	default:
+		return errno.ErrInvalidArgument.WithMessage("target_type must be %s or %s", model.AccessTargetRole, model.AccessTargetPermission)
+	}
+	return nil
+}
+
+// checkRequestable 检查用户还没有申请的角色或权限，也没有相同的待审批申请
+func (b *accessRequestBiz) checkRequestable(ctx context.Context, tenantID, userID int64, targetType string, targetID int64) error {
+	pending, _, err := b.store.AccessRequest().List(ctx, where.F(
+		"tenant_id", tenantID,
+		"requester_id", userID,
+		"target_type", targetType,
+		"target_id", targetID,
+		"status", model.AccessRequestStatusPending,
+	))
+	if err != nil {
+		return errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if pending > 0 {
+		return errno.ErrAccessRequestConflict.WithMessage("a pending request for the same %s already exists", targetType)
+	}
+
+	if targetType == model.AccessTargetRole {
+		has, err := b.authz.HasRoleForUser(userID, targetID, tenantID)
+		if err != nil {
+			return errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+		}
+		if has {
+			return errno.ErrAccessRequestConflict.WithMessage("user already has role %d", targetID)
+		}
+		// 违反职责分离规则的角色不允许申请
+		if err := b.authz.CheckRoleForUser(userID, targetID, tenantID); err != nil {
+			if authz.IsSoDViolation(err) {
+				return errno.ErrSoDViolation.WithMessage(err.Error())
+			}
+			return errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+		}
+		return nil
+	}
+
+	permissions, err := b.authz.GetEffectivePermissionsForUser(userID, tenantID)
+	if err != nil {
+		return errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+	}
+	allowed := false
+	for _, p := range permissions {
+		if p.PermissionID != targetID {
+			continue
+		}
+		if p.Effect == authz.EffectDeny {
+			return nil
+		}
+		allowed = true
+	}
+	if allowed {
+		return errno.ErrAccessRequestConflict.WithMessage("user already has permission %d", targetID)
+	}
+	return nil
+}
+
+// resolveApprovers 返回申请对象的审批人和批准人数，没有审批策略时由租户所有者和租户管理员中的任一人审批
+func (b *accessRequestBiz) resolveApprovers(ctx context.Context, tenantID int64, targetType string, targetID int64) ([]int64, int32, error) {
+	policyM, err := b.store.AccessApprovalPolicy().Get(ctx, where.F("tenant_id", tenantID, "target_type", targetType, "target_id", targetID))
+	if err == nil {
+		return policyM.GetApproverIDs(), policyM.Quorum, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	_, roles, err := b.store.Role().List(store.WithoutDataScope(ctx), where.F("tenant_id", tenantID).
+		Q("system_role IN ?", []string{authz.SystemRoleTenantOwner, authz.SystemRoleTenantAdmin}))
+	if err != nil {
+		return nil, 0, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	var approverIDs []int64
+	for _, roleM := range roles {
+		userIDs, err := b.authz.GetUserIDsForRole(roleM.ID, tenantID)
+		if err != nil {
+			return nil, 0, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+		}
+		approverIDs = append(approverIDs, userIDs...)
+	}
+	return uniqueIDs(approverIDs), 1, nil
+}
+
+// applyGrant 授予已批准申请的角色或权限，失败时申请恢复为待审批
+func (b *accessRequestBiz) applyGrant(ctx context.Context, requestM *model.AccessRequestM) error {
+	userID := strconv.FormatInt(requestM.RequesterID, 10)
+	targetID := strconv.FormatInt(requestM.TargetID, 10)
+	tenantID := strconv.FormatInt(requestM.TenantID, 10)
+
+	var err error
+	if requestM.TargetType == model.AccessTargetRole {
+		_, err = b.authz.AddRoleForUser(userID, targetID, tenantID)
+	} else {
+		_, err = b.authz.AddPermissionForUser(userID, targetID, tenantID)
+	}
+	if err == nil {
+		err = b.authz.InvalidateCache()
+	}
+	if err != nil {
+		requestM.Status = model.AccessRequestStatusPending
+		requestM.DecidedAt = nil
+		if _, rerr := b.store.AccessRequest().Transition(ctx, requestM, model.AccessRequestStatusApproved); rerr != nil {
+			log.W(ctx).Errorw("Failed to roll back access request", "request_id", requestM.ID, "err", rerr)
+		}
+		if authz.IsSoDViolation(err) {
+			return errno.ErrSoDViolation.WithMessage(err.Error())
+		}
+		return errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+	}
+
+	log.W(ctx).Infow("Access request approved and granted", "request_id", requestM.ID, "requester_id", requestM.RequesterID,
+		"target_type", requestM.TargetType, "target_id", requestM.TargetID)
+	return nil
+}
+
+// addReview 记录当前用户的审批结果
+func (b *accessRequestBiz) addReview(ctx context.Context, requestM *model.AccessRequestM, decision, comment string) error {
+	reviewM := &model.AccessRequestReviewM{
+		RequestID:  requestM.ID,
+		ReviewerID: contextx.UserID(ctx),
+		Decision:   decision,
+	}
+	if comment != "" {
+		reviewM.Comment = &comment
+	}
+	if err := b.store.AccessRequestReview().Create(ctx, reviewM); err != nil {
+		return errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	return nil
+}
+
+// finish 结束待审批的申请
+func (b *accessRequestBiz) finish(ctx context.Context, requestM *model.AccessRequestM, status string, now time.Time) error {
+	requestM.Status = status
+	requestM.DecidedAt = &now
+	return b.transition(ctx, requestM, model.AccessRequestStatusPending)
+}
+
+// transition 保存申请的修改，状态已被其他请求修改时返回 ErrAccessRequestInvalidState
+func (b *accessRequestBiz) transition(ctx context.Context, requestM *model.AccessRequestM, from string) error {
+	ok, err := b.store.AccessRequest().Transition(ctx, requestM, from)
+	if err != nil {
+		return errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	if !ok {
+		return errno.ErrAccessRequestInvalidState.WithMessage("access request %d is no longer %s", requestM.ID, from)
+	}
+	return nil
+}
+
+// get 获取当前租户的申请
+func (b *accessRequestBiz) get(ctx context.Context, requestID int64) (*model.AccessRequestM, error) {
+	requestM, err := b.store.AccessRequest().Get(ctx, where.F("id", requestID, "tenant_id", currentTenantID(ctx)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAccessRequestNotFound
+		}
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	return requestM, nil
+}
+
+// getReviewable 获取当前用户可以审批的待审批申请
+func (b *accessRequestBiz) getReviewable(ctx context.Context, requestID int64) (*model.AccessRequestM, error) {
+	requestM, err := b.get(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	userID := contextx.UserID(ctx)
+	if requestM.RequesterID == userID || !slices.Contains(requestM.GetApproverIDs(), userID) {
+		return nil, errno.ErrAccessRequestNotApprover
+	}
+	if requestM.Status != model.AccessRequestStatusPending {
+		return nil, errno.ErrAccessRequestInvalidState.WithMessage("access request is already %s", requestM.Status)
+	}
+	if !requestM.ExpiresAt.After(time.Now()) {
+		return nil, errno.ErrAccessRequestInvalidState.WithMessage("access request has expired")
+	}
+
+	reviewed, _, err := b.store.AccessRequestReview().List(ctx, where.F("request_id", requestM.ID, "reviewer_id", userID))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if reviewed > 0 {
+		return nil, errno.ErrAccessRequestAlreadyReviewed
+	}
+	return requestM, nil
+}
+
+// notifyRequester 通知申请人申请结果，通知失败只记录日志
+func (b *accessRequestBiz) notifyRequester(ctx context.Context, requestM *model.AccessRequestM) {
+	if err := DefaultNotifier.NotifyRequester(ctx, requestM); err != nil {
+		log.W(ctx).Errorw("Failed to notify access requester", "request_id", requestM.ID, "err", err)
+	}
+}
+
+// currentTenantID 返回当前请求的租户ID，未设置时使用默认租户
+func currentTenantID(ctx context.Context) int64 {
+	if tid, err := strconv.ParseInt(contextx.TenantID(ctx), 10, 64); err == nil && tid > 0 {
+		return tid
+	}
+	return 1 // 默认租户
+}
+
+// uniqueIDs 去除重复和无效的ID并排序
+func uniqueIDs(ids []int64) []int64 {
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id > 0 {
+			result = append(result, id)
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// joinIDs 将ID列表保存为逗号分隔的字符串
+func joinIDs(ids []int64) string {
+	items := make([]string, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(items, ",")
+}
+
+// convertAccessRequestToAPI 转换访问申请模型为API格式
+func convertAccessRequestToAPI(requestM *model.AccessRequestM, reviews []*model.AccessRequestReviewM) *apiv1.AccessRequest {
+	request := &apiv1.AccessRequest{
+		Id:            requestM.ID,
+		TenantId:      requestM.TenantID,
+		RequesterId:   requestM.RequesterID,
+		TargetType:    requestM.TargetType,
+		TargetId:      requestM.TargetID,
+		Justification: requestM.Justification,
+		Status:        requestM.Status,
+		ApproverIds:   requestM.GetApproverIDs(),
+		Quorum:        requestM.Quorum,
+		Approvals:     requestM.Approvals,
+		ExpiresAt:     timestamppb.New(requestM.ExpiresAt),
+		CreatedAt:     timestamppb.New(requestM.CreatedAt),
+	}
+	if requestM.DecidedAt != nil {
+		request.DecidedAt = timestamppb.New(*requestM.DecidedAt)
+	}
+	for _, reviewM := range reviews {
+		review := &apiv1.AccessRequestReview{
+			ReviewerId: reviewM.ReviewerID,
+			Decision:   reviewM.Decision,
+			CreatedAt:  timestamppb.New(reviewM.CreatedAt),
+		}
+		if reviewM.Comment != nil {
+			review.Comment = *reviewM.Comment
+		}
+		request.Reviews = append(request.Reviews, review)
+	}
+	return request
+}
+
+// convertPolicyToAPI 转换审批策略模型为API格式
+func convertPolicyToAPI(policyM *model.AccessApprovalPolicyM) *apiv1.AccessApprovalPolicy {
+	return &apiv1.AccessApprovalPolicy{
+		Id:          policyM.ID,
+		TargetType:  policyM.TargetType,
+		TargetId:    policyM.TargetID,
+		ApproverIds: policyM.GetApproverIDs(),
+		Quorum:      policyM.Quorum,
+		UpdatedAt:   timestamppb.New(policyM.UpdatedAt),
+	}
+}
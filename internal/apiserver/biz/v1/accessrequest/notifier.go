@@ -0,0 +1,53 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package accessrequest
+
+import (
+	"context"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+)
+
+// Notifier 在访问申请提交和结束时发送通知.
+type Notifier interface {
+	// NotifyApprovers 通知审批人有新的申请需要审批
+	NotifyApprovers(ctx context.Context, request *model.AccessRequestM, approverIDs []int64) error
+	// NotifyRequester 通知申请人申请已批准、拒绝或过期
+	NotifyRequester(ctx context.Context, request *model.AccessRequestM) error
+}
+
+// DefaultNotifier 是访问申请使用的通知器，默认只记录日志，可替换为邮件、短信等实现.
+var DefaultNotifier Notifier = logNotifier{}
+
+// logNotifier 将访问申请的通知写入日志.
+type logNotifier struct{}
+
+// NotifyApprovers 记录需要通知的审批人.
+func (logNotifier) NotifyApprovers(ctx context.Context, request *model.AccessRequestM, approverIDs []int64) error {
+	log.W(ctx).Infow("Access request awaiting approval",
+		"request_id", request.ID,
+		"requester_id", request.RequesterID,
+		"target_type", request.TargetType,
+		"target_id", request.TargetID,
+		"approver_ids", approverIDs,
+		"quorum", request.Quorum,
+	)
+	return nil
+}
+
+// NotifyRequester 记录申请结果.
+func (logNotifier) NotifyRequester(ctx context.Context, request *model.AccessRequestM) error {
+	log.W(ctx).Infow("Access request finished",
+		"request_id", request.ID,
+		"requester_id", request.RequesterID,
+		"target_type", request.TargetType,
+		"target_id", request.TargetID,
+		"status", request.Status,
+	)
+	return nil
+}
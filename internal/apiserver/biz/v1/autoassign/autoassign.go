@@ -0,0 +1,346 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package autoassign
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// validResourceTypes 是权限表支持的资源类型
+var validResourceTypes = map[string]bool{"api": true, "menu": true, "data": true, "feature": true}
+
+// AutoAssignBiz 定义了管理员权限自动分配相关的业务逻辑接口.
+type AutoAssignBiz interface {
+	// 自动分配配置
+	GetAutoAssignConfig(ctx context.Context, rq *apiv1.GetAutoAssignConfigRequest) (*apiv1.GetAutoAssignConfigResponse, error)
+	UpdateAutoAssignConfig(ctx context.Context, rq *apiv1.UpdateAutoAssignConfigRequest) (*apiv1.UpdateAutoAssignConfigResponse, error)
+
+	// 管理员权限同步
+	SyncAdminPermissions(ctx context.Context, rq *apiv1.SyncAdminPermissionsRequest) (*apiv1.SyncAdminPermissionsResponse, error)
+	GetAdminMissingPermissions(ctx context.Context, rq *apiv1.GetAdminMissingPermissionsRequest) (*apiv1.GetAdminMissingPermissionsResponse, error)
+
+	// 创建菜单并生成权限
+	CreateMenuWithPermissions(ctx context.Context, rq *apiv1.CreateMenuWithPermissionsRequest) (*apiv1.CreateMenuWithPermissionsResponse, error)
+
+	// AssignNewPermissions 在租户启用自动分配时，按规则将新创建的权限分配给角色，返回获得权限的角色名称
+	AssignNewPermissions(ctx context.Context, tenantID int64, permissions []*model.PermissionM) ([]string, error)
+}
+
+// autoAssignBiz 是 AutoAssignBiz 接口的实现.
+type autoAssignBiz struct {
+	store store.IStore
+	authz *authz.Authz
+}
+
+// 确保 autoAssignBiz 实现了 AutoAssignBiz 接口.
+var _ AutoAssignBiz = (*autoAssignBiz)(nil)
+
+// New 创建一个新的 AutoAssignBiz 实例.
+func New(store store.IStore, authz *authz.Authz) *autoAssignBiz {
+	return &autoAssignBiz{store: store, authz: authz}
+}
+
+// GetAutoAssignConfig 获取租户的自动分配配置，未配置时默认启用且只分配给超级管理员
+func (b *autoAssignBiz) GetAutoAssignConfig(ctx context.Context, rq *apiv1.GetAutoAssignConfigRequest) (*apiv1.GetAutoAssignConfigResponse, error) {
+	tenantID := requestTenantID(ctx, rq.TenantId)
+
+	config, err := b.getConfig(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := b.store.PermissionAutoAssignRule().ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	_, roles, err := b.store.Role().List(ctx, where.F("tenant_id", tenantID))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	admins := make(map[int64]bool)
+	for _, id := range config.GetAdminRoleIDs() {
+		admins[id] = true
+	}
+	for _, role := range roles {
+		if role.IsSystemRole(authz.SystemRoleTenantAdmin) {
+			admins[role.ID] = true
+		}
+	}
+	resp := &apiv1.GetAutoAssignConfigResponse{Enabled: config.Enabled}
+	for _, role := range roles {
+		switch {
+		case isSuperAdminRole(role):
+			resp.SuperAdminRoles = append(resp.SuperAdminRoles, convertRoleInfo(role))
+		case admins[role.ID]:
+			resp.AdminRoles = append(resp.AdminRoles, convertRoleInfo(role))
+		}
+	}
+	for _, r := range rules {
+		resp.PermissionRules = append(resp.PermissionRules, &apiv1.PermissionRule{
+			Modules:       r.GetModules(),
+			Actions:       r.GetActions(),
+			ExcludeNames:  r.GetExcludeNames(),
+			Include:       r.Include,
+			Description:   derefString(r.Description),
+			RoleIds:       r.GetRoleIDs(),
+			ResourceTypes: r.GetResourceTypes(),
+		})
+	}
+	return resp, nil
+}
+
+// UpdateAutoAssignConfig 更新租户的自动分配配置，规则整体替换
+func (b *autoAssignBiz) UpdateAutoAssignConfig(ctx context.Context, rq *apiv1.UpdateAutoAssignConfigRequest) (*apiv1.UpdateAutoAssignConfigResponse, error) {
+	tenantID := requestTenantID(ctx, rq.TenantId)
+
+	// 校验角色和资源类型
+	roleIDs := append([]int64{}, rq.AdminRoleIds...)
+	for _, r := range rq.PermissionRules {
+		roleIDs = append(roleIDs, r.RoleIds...)
+		for _, resourceType := range r.ResourceTypes {
+			if !validResourceTypes[resourceType] {
+				return nil, errno.ErrInvalidArgument.WithMessage("invalid resource type %q", resourceType)
+			}
+		}
+	}
+	if err := b.checkTenantRoles(ctx, tenantID, roleIDs); err != nil {
+		return nil, err
+	}
+
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		config, err := b.store.PermissionAutoAssignConfig().Get(ctx, where.F("tenant_id", tenantID))
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrDBRead.WithMessage(err.Error())
+		}
+		if config == nil || config.ID == 0 {
+			config = &model.PermissionAutoAssignConfigM{TenantID: tenantID}
+		}
+		config.Enabled = rq.Enabled
+		config.AdminRoleIDs = joinIDs(rq.AdminRoleIds)
+		if config.ID == 0 {
+			// enabled 字段有默认值，创建时 false 不会写入，需要再更新一次
+			err = b.store.PermissionAutoAssignConfig().Create(ctx, config)
+			if err == nil && !config.Enabled {
+				err = b.store.PermissionAutoAssignConfig().Update(ctx, config)
+			}
+		} else {
+			err = b.store.PermissionAutoAssignConfig().Update(ctx, config)
+		}
+		if err != nil {
+			return errno.ErrDBWrite.WithMessage(err.Error())
+		}
+
+		if err := b.store.PermissionAutoAssignRule().Delete(ctx, where.F("tenant_id", tenantID)); err != nil {
+			return errno.ErrDBWrite.WithMessage(err.Error())
+		}
+		// include 字段同样有默认值，排除规则创建后需要再更新一次
+		for i, r := range rq.PermissionRules {
+			ruleM := &model.PermissionAutoAssignRuleM{
+				TenantID:      tenantID,
+				RoleIDs:       joinIDs(r.RoleIds),
+				Modules:       joinList(r.Modules),
+				ResourceTypes: joinList(r.ResourceTypes),
+				Actions:       joinList(r.Actions),
+				ExcludeNames:  joinList(r.ExcludeNames),
+				Include:       r.Include,
+				SortOrder:     int32(i),
+			}
+			if r.Description != "" {
+				ruleM.Description = &r.Description
+			}
+			err := b.store.PermissionAutoAssignRule().Create(ctx, ruleM)
+			if err == nil && !ruleM.Include {
+				err = b.store.PermissionAutoAssignRule().Update(ctx, ruleM)
+			}
+			if err != nil {
+				return errno.ErrDBWrite.WithMessage(err.Error())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.W(ctx).Infow("Permission auto-assign config updated", "tenant_id", tenantID, "enabled", rq.Enabled, "rules", len(rq.PermissionRules))
+	return &apiv1.UpdateAutoAssignConfigResponse{Success: true, Message: "自动分配配置已更新"}, nil
+}
+
+// AssignNewPermissions 在租户启用自动分配时，按规则将新创建的权限分配给角色，返回获得权限的角色名称
+func (b *autoAssignBiz) AssignNewPermissions(ctx context.Context, tenantID int64, permissions []*model.PermissionM) ([]string, error) {
+	config, err := b.getConfig(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !config.Enabled || len(permissions) == 0 {
+		return nil, nil
+	}
+
+	p, roles, err := b.loadPolicy(ctx, tenantID, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var assigned []string
+	for _, role := range roles {
+		var granted bool
+		for _, permission := range permissions {
+			if ok, _ := p.expect(role.ID, permission); !ok {
+				continue
+			}
+			if _, err := b.authz.AddPermissionForRole(role.ID, permission.ID, tenantID, authz.EffectAllow); err != nil {
+				log.W(ctx).Errorw("Failed to auto assign permission", "role_id", role.ID, "permission_id", permission.ID, "err", err)
+				return nil, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+			}
+			granted = true
+		}
+		if granted {
+			assigned = append(assigned, role.Name)
+		}
+	}
+	return assigned, nil
+}
+
+// getConfig 获取租户的自动分配配置，未配置时返回默认配置
+func (b *autoAssignBiz) getConfig(ctx context.Context, tenantID int64) (*model.PermissionAutoAssignConfigM, error) {
+	config, err := b.store.PermissionAutoAssignConfig().Get(ctx, where.F("tenant_id", tenantID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &model.PermissionAutoAssignConfigM{TenantID: tenantID, Enabled: true}, nil
+		}
+		log.W(ctx).Errorw("Failed to get auto-assign config", "tenant_id", tenantID, "err", err)
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	return config, nil
+}
+
+// loadPolicy 加载租户的自动分配策略，返回策略涉及的角色
+func (b *autoAssignBiz) loadPolicy(ctx context.Context, tenantID int64, config *model.PermissionAutoAssignConfigM) (*policy, []*model.RoleM, error) {
+	rules, err := b.store.PermissionAutoAssignRule().ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	_, tenantRoles, err := b.store.Role().List(ctx, where.F("tenant_id", tenantID))
+	if err != nil {
+		return nil, nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	p := &policy{superAdmins: make(map[int64]bool), admins: make(map[int64]bool)}
+	for _, id := range config.GetAdminRoleIDs() {
+		p.admins[id] = true
+	}
+	for _, r := range rules {
+		p.rules = append(p.rules, newRule(r))
+	}
+	for _, role := range tenantRoles {
+		switch {
+		case isSuperAdminRole(role):
+			p.superAdmins[role.ID] = true
+		case role.IsSystemRole(authz.SystemRoleTenantAdmin):
+			// 租户管理员系统角色始终作为管理员角色
+			p.admins[role.ID] = true
+		}
+	}
+
+	// 只保留仍属于租户的角色
+	targets := p.roles()
+	var roles []*model.RoleM
+	for _, role := range tenantRoles {
+		if targets[role.ID] {
+			roles = append(roles, role)
+		}
+	}
+	return p, roles, nil
+}
+
+// checkTenantRoles 检查角色都属于租户
+func (b *autoAssignBiz) checkTenantRoles(ctx context.Context, tenantID int64, roleIDs []int64) error {
+	if len(roleIDs) == 0 {
+		return nil
+	}
+	_, roles, err := b.store.Role().List(ctx, where.F("tenant_id", tenantID).Q("id IN ?", roleIDs))
+	if err != nil {
+		return errno.ErrDBRead.WithMessage(err.Error())
+	}
+	found := make(map[int64]bool, len(roles))
+	for _, role := range roles {
+		found[role.ID] = true
+	}
+	for _, id := range roleIDs {
+		if !found[id] {
+			return errno.ErrInvalidArgument.WithMessage("role %d not found in current tenant", id)
+		}
+	}
+	return nil
+}
+
+// isSuperAdminRole 判断角色是否为租户所有者，与授权检查中的判断一致
+func isSuperAdminRole(role *model.RoleM) bool {
+	return role.IsSystemRole(authz.SystemRoleTenantOwner)
+}
+
+// requestTenantID 返回请求中的租户ID，为空时使用当前租户
+func requestTenantID(ctx context.Context, tenantID int64) int64 {
+	if tenantID != 0 {
+		return tenantID
+	}
+	if tid, err := strconv.ParseInt(contextx.TenantID(ctx), 10, 64); err == nil {
+		return tid
+	}
+	return 1 // 默认租户
+}
+
+// convertRoleInfo 转换角色模型为角色信息
+func convertRoleInfo(role *model.RoleM) *apiv1.RoleInfo {
+	return &apiv1.RoleInfo{Id: role.ID, Name: role.Name, Description: derefString(role.Description)}
+}
+
+// joinIDs 将ID列表保存为逗号分隔的字符串，列表为空时返回 nil
+func joinIDs(ids []int64) *string {
+	items := make([]string, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, strconv.FormatInt(id, 10))
+	}
+	return joinList(items)
+}
+
+// joinList 将字符串列表保存为逗号分隔的字符串，列表为空时返回 nil
+func joinList(items []string) *string {
+	var kept []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			kept = append(kept, item)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	joined := strings.Join(kept, ",")
+	return &joined
+}
+
+// derefString 解引用字符串指针，如果为nil则返回空字符串
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
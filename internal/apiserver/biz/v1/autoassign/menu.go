@@ -0,0 +1,166 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package autoassign
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// menuTypeMenu 表示菜单类型为菜单
+const menuTypeMenu = 2
+
+// CreateMenuWithPermissions 创建菜单，并为每个操作生成名为 {module}:{action} 的菜单权限.
+// 租户中已存在的同名权限会被复用；新创建的权限按自动分配规则分配给角色.
+// 第一个操作（有 view 时为 view）作为访问菜单的必需权限.
+func (b *autoAssignBiz) CreateMenuWithPermissions(ctx context.Context, rq *apiv1.CreateMenuWithPermissionsRequest) (*apiv1.CreateMenuWithPermissionsResponse, error) {
+	tenantID := requestTenantID(ctx, rq.TenantId)
+
+	module := strings.TrimSpace(rq.Module)
+	if module == "" && rq.RoutePath != "" {
+		module = path.Base(strings.TrimRight(rq.RoutePath, "/"))
+	}
+	if module == "" || module == "." || module == "/" {
+		return nil, errno.ErrInvalidArgument.WithMessage("module is required when route_path is empty")
+	}
+	actions := normalizeActions(rq.Actions)
+	if len(actions) == 0 {
+		return nil, errno.ErrInvalidArgument.WithMessage("actions is required")
+	}
+	required := actions[0]
+	for _, action := range actions {
+		if action == "view" {
+			required = action
+		}
+	}
+
+	if rq.ParentId > 0 {
+		if _, err := b.store.Menu().Get(ctx, where.F("id", rq.ParentId, "tenant_id", tenantID)); err != nil {
+			return nil, errno.ErrMenuNotFound.WithMessage("parent menu %d not found", rq.ParentId)
+		}
+	}
+
+	menu := &model.MenuM{
+		TenantID: tenantID,
+		MenuCode: module,
+		Title:    rq.Title,
+		MenuType: menuTypeMenu,
+		Visible:  rq.Visible,
+		Status:   true,
+	}
+	if rq.ParentId > 0 {
+		menu.ParentID = &rq.ParentId
+	}
+	if rq.RoutePath != "" {
+		menu.RoutePath = &rq.RoutePath
+	}
+	if rq.Component != "" {
+		menu.Component = &rq.Component
+	}
+	if rq.Icon != "" {
+		menu.Icon = &rq.Icon
+	}
+
+	var permissionIDs []int64
+	var created []*model.PermissionM
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		// visible 字段有默认值，隐藏菜单创建后需要再更新一次
+		err := b.store.Menu().Create(ctx, menu)
+		if err == nil && !menu.Visible {
+			err = b.store.Menu().Update(ctx, menu)
+		}
+		if err != nil {
+			return errno.ErrDBWrite.WithMessage(err.Error())
+		}
+
+		for _, action := range actions {
+			permission, isNew, err := b.getOrCreatePermission(ctx, tenantID, module, action, rq.Title)
+			if err != nil {
+				return err
+			}
+			if isNew {
+				created = append(created, permission)
+			}
+			permissionIDs = append(permissionIDs, permission.ID)
+
+			menuPermission := &model.MenuPermissionM{
+				TenantID:     tenantID,
+				MenuID:       menu.ID,
+				PermissionID: permission.ID,
+				IsRequired:   action == required,
+			}
+			if err := b.store.MenuPermission().Create(ctx, menuPermission); err != nil {
+				return errno.ErrDBWrite.WithMessage(err.Error())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.W(ctx).Errorw("Failed to create menu with permissions", "title", rq.Title, "module", module, "err", err)
+		return nil, err
+	}
+
+	// 策略变更在事务提交后进行，菜单和权限创建失败时不会留下策略
+	roles, err := b.AssignNewPermissions(ctx, tenantID, created)
+	if err != nil {
+		return nil, err
+	}
+
+	log.W(ctx).Infow("Menu with permissions created", "menu_id", menu.ID, "module", module, "permissions", len(permissionIDs), "auto_assigned_roles", roles)
+	return &apiv1.CreateMenuWithPermissionsResponse{MenuId: menu.ID, PermissionIds: permissionIDs, AutoAssignedRoles: roles}, nil
+}
+
+// getOrCreatePermission 获取租户中名为 {module}:{action} 的权限，不存在时创建
+func (b *autoAssignBiz) getOrCreatePermission(ctx context.Context, tenantID int64, module, action, title string) (*model.PermissionM, bool, error) {
+	name := module + ":" + action
+	permission, err := b.store.Permission().Get(ctx, where.F("tenant_id", tenantID, "name", name))
+	if err == nil {
+		return permission, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	description := title + " - " + action
+	permission = &model.PermissionM{
+		TenantID:     tenantID,
+		Name:         name,
+		Description:  &description,
+		ResourceType: "menu",
+		Action:       &action,
+		Status:       true,
+	}
+	if err := b.store.Permission().Create(ctx, permission); err != nil {
+		return nil, false, errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	return permission, true, nil
+}
+
+// normalizeActions 去除空白和重复的操作，保持原有顺序
+func normalizeActions(actions []string) []string {
+	seen := make(map[string]bool, len(actions))
+	var result []string
+	for _, action := range actions {
+		action = strings.ToLower(strings.TrimSpace(action))
+		if action == "" || seen[action] {
+			continue
+		}
+		seen[action] = true
+		result = append(result, action)
+	}
+	return result
+}
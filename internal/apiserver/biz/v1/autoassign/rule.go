@@ -0,0 +1,158 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package autoassign
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// 权限应分配给角色的原因
+const (
+	reasonSuperAdmin   = "super admin role is granted every permission"
+	reasonAdminDefault = "admin role without rules is granted every permission"
+)
+
+// rule 是解析后的自动分配规则
+type rule struct {
+	id            int64
+	roleIDs       map[int64]bool
+	modules       map[string]bool
+	resourceTypes map[string]bool
+	actions       map[string]bool
+	excludeNames  map[string]bool
+	include       bool
+	description   string
+}
+
+// newRule 解析规则模型
+func newRule(m *model.PermissionAutoAssignRuleM) *rule {
+	r := &rule{
+		id:            m.ID,
+		roleIDs:       make(map[int64]bool),
+		modules:       toSet(m.GetModules()),
+		resourceTypes: toSet(m.GetResourceTypes()),
+		actions:       toSet(m.GetActions()),
+		excludeNames:  toSet(m.GetExcludeNames()),
+		include:       m.Include,
+	}
+	for _, id := range m.GetRoleIDs() {
+		r.roleIDs[id] = true
+	}
+	if m.Description != nil {
+		r.description = *m.Description
+	}
+	return r
+}
+
+// appliesTo 判断规则是否适用于角色，未指定角色的规则适用于全部系统管理员角色
+func (r *rule) appliesTo(roleID int64, admins map[int64]bool) bool {
+	if len(r.roleIDs) == 0 {
+		return admins[roleID]
+	}
+	return r.roleIDs[roleID]
+}
+
+// matches 判断权限是否匹配规则，为空的条件匹配全部
+func (r *rule) matches(permission *model.PermissionM) bool {
+	if r.excludeNames[permission.Name] {
+		return false
+	}
+	if len(r.modules) > 0 && !r.modules[permissionModule(permission.Name)] {
+		return false
+	}
+	if len(r.resourceTypes) > 0 && !r.resourceTypes[permission.ResourceType] {
+		return false
+	}
+	if len(r.actions) > 0 && (permission.Action == nil || !r.actions[*permission.Action]) {
+		return false
+	}
+	return true
+}
+
+// String 返回规则的描述
+func (r *rule) String() string {
+	if r.description != "" {
+		return fmt.Sprintf("matched rule %d: %s", r.id, r.description)
+	}
+	return fmt.Sprintf("matched rule %d", r.id)
+}
+
+// policy 是租户的自动分配策略.
+// 超级管理员角色获得全部权限；系统管理员角色和规则中指定的角色按规则获得权限，
+// 排除规则优先于分配规则，没有任何规则适用的系统管理员角色获得全部权限.
+type policy struct {
+	superAdmins map[int64]bool
+	admins      map[int64]bool
+	rules       []*rule
+}
+
+// roles 返回策略涉及的全部角色
+func (p *policy) roles() map[int64]bool {
+	roles := make(map[int64]bool)
+	for id := range p.superAdmins {
+		roles[id] = true
+	}
+	for id := range p.admins {
+		roles[id] = true
+	}
+	for _, r := range p.rules {
+		for id := range r.roleIDs {
+			roles[id] = true
+		}
+	}
+	return roles
+}
+
+// expect 判断权限是否应分配给角色，返回分配原因
+func (p *policy) expect(roleID int64, permission *model.PermissionM) (bool, string) {
+	if p.superAdmins[roleID] {
+		return true, reasonSuperAdmin
+	}
+
+	var applied bool
+	var reason string
+	for _, r := range p.rules {
+		if !r.appliesTo(roleID, p.admins) {
+			continue
+		}
+		applied = true
+		if !r.matches(permission) {
+			continue
+		}
+		if !r.include {
+			return false, ""
+		}
+		if reason == "" {
+			reason = r.String()
+		}
+	}
+	if !applied && p.admins[roleID] {
+		return true, reasonAdminDefault
+	}
+	return reason != "", reason
+}
+
+// permissionModule 返回权限所属的模块，即权限名称中冒号前的部分
+func permissionModule(name string) string {
+	module, _, found := strings.Cut(name, ":")
+	if !found {
+		return ""
+	}
+	return module
+}
+
+// toSet 将字符串列表转换为集合
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
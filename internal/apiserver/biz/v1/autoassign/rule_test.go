@@ -0,0 +1,70 @@
+package autoassign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+func strPtr(s string) *string { return &s }
+
+func newPermission(id int64, name, resourceType, action string) *model.PermissionM {
+	return &model.PermissionM{ID: id, Name: name, ResourceType: resourceType, Action: strPtr(action)}
+}
+
+func TestPolicyExpect(t *testing.T) {
+	p := &policy{
+		superAdmins: map[int64]bool{1: true},
+		admins:      map[int64]bool{2: true, 3: true},
+		rules: []*rule{
+			// 系统管理员获得 user、role 模块的权限，但不包括删除
+			newRule(&model.PermissionAutoAssignRuleM{ID: 1, Modules: strPtr("user,role"), Include: true}),
+			newRule(&model.PermissionAutoAssignRuleM{ID: 2, Actions: strPtr("delete"), Include: false}),
+			// 角色 4 只获得菜单查看权限
+			newRule(&model.PermissionAutoAssignRuleM{ID: 3, RoleIDs: strPtr("4"), ResourceTypes: strPtr("menu"), Actions: strPtr("view"), Include: true}),
+			// 角色 3 额外获得 tenant 模块的权限，但排除 tenant:create
+			newRule(&model.PermissionAutoAssignRuleM{ID: 4, RoleIDs: strPtr("3"), Modules: strPtr("tenant"), ExcludeNames: strPtr("tenant:create"), Include: true}),
+		},
+	}
+
+	userView := newPermission(10, "user:view", "menu", "view")
+	userDelete := newPermission(11, "user:delete", "api", "delete")
+	tenantView := newPermission(12, "tenant:view", "menu", "view")
+	tenantCreate := newPermission(13, "tenant:create", "api", "create")
+
+	tests := []struct {
+		name       string
+		roleID     int64
+		permission *model.PermissionM
+		want       bool
+	}{
+		{"super admin gets everything", 1, userDelete, true},
+		{"admin matched by module", 2, userView, true},
+		{"exclude rule wins", 2, userDelete, false},
+		{"admin rules do not cover other modules", 2, tenantView, false},
+		{"role specific rule", 3, tenantView, true},
+		{"exclude names are skipped", 3, tenantCreate, false},
+		{"non admin role with own rule", 4, tenantView, true},
+		{"non admin role outside rule", 4, tenantCreate, false},
+		{"unrelated role", 5, userView, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := p.expect(tt.roleID, tt.permission)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want, reason != "")
+		})
+	}
+
+	assert.Equal(t, map[int64]bool{1: true, 2: true, 3: true, 4: true}, p.roles())
+}
+
+func TestPolicyExpect_AdminWithoutRules(t *testing.T) {
+	p := &policy{superAdmins: map[int64]bool{}, admins: map[int64]bool{2: true}}
+
+	ok, reason := p.expect(2, newPermission(10, "report:export", "feature", "export"))
+	assert.True(t, ok)
+	assert.Equal(t, reasonAdminDefault, reason)
+}
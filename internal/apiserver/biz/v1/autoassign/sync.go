@@ -0,0 +1,121 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package autoassign
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// GetAdminMissingPermissions 获取按自动分配规则应拥有但尚未分配的权限
+func (b *autoAssignBiz) GetAdminMissingPermissions(ctx context.Context, rq *apiv1.GetAdminMissingPermissionsRequest) (*apiv1.GetAdminMissingPermissionsResponse, error) {
+	tenantID := requestTenantID(ctx, rq.TenantId)
+
+	missing, err := b.missingPermissions(ctx, tenantID, rq.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.GetAdminMissingPermissionsResponse{MissingPermissions: missing, TotalMissing: int32(len(missing))}, nil
+}
+
+// SyncAdminPermissions 将缺失的权限分配给管理员角色，dry_run 时只报告不分配.
+// 角色对权限存在 deny 规则时不会分配.
+func (b *autoAssignBiz) SyncAdminPermissions(ctx context.Context, rq *apiv1.SyncAdminPermissionsRequest) (*apiv1.SyncAdminPermissionsResponse, error) {
+	tenantID := requestTenantID(ctx, rq.TenantId)
+
+	missing, err := b.missingPermissions(ctx, tenantID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &apiv1.SyncAdminPermissionsResponse{DryRun: rq.DryRun, MissingPermissions: missing}
+	details := make(map[int64]*apiv1.SyncDetail)
+	for _, m := range missing {
+		if !m.ShouldAssign {
+			continue
+		}
+		if !rq.DryRun {
+			if _, err := b.authz.AddPermissionForRole(m.RoleId, m.PermissionId, tenantID, authz.EffectAllow); err != nil {
+				log.W(ctx).Errorw("Failed to sync admin permission", "role_id", m.RoleId, "permission_id", m.PermissionId, "err", err)
+				return nil, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+			}
+		}
+		detail, ok := details[m.RoleId]
+		if !ok {
+			detail = &apiv1.SyncDetail{RoleId: m.RoleId, RoleName: m.RoleName}
+			details[m.RoleId] = detail
+			resp.SyncDetails = append(resp.SyncDetails, detail)
+		}
+		detail.AssignedPermissions++
+		resp.AssignedCount++
+	}
+
+	if !rq.DryRun {
+		log.W(ctx).Infow("Admin permissions synced", "tenant_id", tenantID, "assigned", resp.AssignedCount)
+	}
+	return resp, nil
+}
+
+// missingPermissions 比较自动分配规则的期望与角色已有的 allow 规则，返回缺失的权限.
+// roleID 不为 0 时只检查该角色.
+func (b *autoAssignBiz) missingPermissions(ctx context.Context, tenantID, roleID int64) ([]*apiv1.MissingPermission, error) {
+	config, err := b.getConfig(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	p, roles, err := b.loadPolicy(ctx, tenantID, config)
+	if err != nil {
+		return nil, err
+	}
+	_, permissions, err := b.store.Permission().List(ctx, where.F("tenant_id", tenantID))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	// 按权限ID升序输出，结果稳定
+	sort.Slice(permissions, func(i, j int) bool { return permissions[i].ID < permissions[j].ID })
+
+	var missing []*apiv1.MissingPermission
+	for _, role := range roles {
+		if roleID != 0 && role.ID != roleID {
+			continue
+		}
+		grants, err := b.authz.GetPermissionsForRole(role.ID, tenantID)
+		if err != nil {
+			return nil, errno.ErrInternal.WithMessage(err.Error())
+		}
+		effects := make(map[int64]string, len(grants))
+		for _, grant := range grants {
+			effects[grant.PermissionID] = grant.Effect
+		}
+
+		for _, permission := range permissions {
+			ok, reason := p.expect(role.ID, permission)
+			if !ok || effects[permission.ID] == authz.EffectAllow {
+				continue
+			}
+			shouldAssign := effects[permission.ID] != authz.EffectDeny
+			if !shouldAssign {
+				reason = "role has an explicit deny rule for this permission"
+			}
+			missing = append(missing, &apiv1.MissingPermission{
+				PermissionId:   permission.ID,
+				PermissionName: permission.Name,
+				Reason:         reason,
+				ShouldAssign:   shouldAssign,
+				RoleId:         role.ID,
+				RoleName:       role.Name,
+			})
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,288 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package catalog
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// CatalogBiz 定义了权限目录导入导出相关的业务逻辑接口.
+type CatalogBiz interface {
+	// Import 将清单同步到租户，dry_run 时只返回变更计划
+	Import(ctx context.Context, rq *apiv1.ImportPermissionCatalogRequest) (*apiv1.ImportPermissionCatalogResponse, error)
+	// Export 导出租户当前的权限目录
+	Export(ctx context.Context, rq *apiv1.ExportPermissionCatalogRequest) (*apiv1.ExportPermissionCatalogResponse, error)
+}
+
+// catalogBiz 是 CatalogBiz 接口的实现.
+type catalogBiz struct {
+	store store.IStore
+	authz *authz.Authz
+}
+
+// 确保 catalogBiz 实现了 CatalogBiz 接口.
+var _ CatalogBiz = (*catalogBiz)(nil)
+
+// New 创建一个新的 CatalogBiz 实例.
+func New(store store.IStore, authz *authz.Authz) *catalogBiz {
+	return &catalogBiz{store: store, authz: authz}
+}
+
+// Import 将清单同步到租户，dry_run 时只返回变更计划.
+// 数据库变更在一个事务中完成，角色权限规则在事务提交后写入策略.
+func (b *catalogBiz) Import(ctx context.Context, rq *apiv1.ImportPermissionCatalogRequest) (*apiv1.ImportPermissionCatalogResponse, error) {
+	tenantID := requestTenantID(ctx, rq.TenantId)
+
+	manifest, err := ParseManifest([]byte(rq.Manifest))
+	if err != nil {
+		return nil, errno.ErrInvalidArgument.WithMessage(err.Error())
+	}
+
+	// 同步针对整个租户，不受当前用户数据权限的限制
+	ctx = store.WithoutDataScope(ctx)
+	cur, err := b.loadState(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := diff(tenantID, manifest, cur, rq.Prune)
+	if err != nil {
+		return nil, errno.ErrInvalidArgument.WithMessage(err.Error())
+	}
+
+	resp := &apiv1.ImportPermissionCatalogResponse{TenantId: tenantID, DryRun: rq.DryRun, Changes: p.changes}
+	resp.Created, resp.Updated, resp.Deleted = p.counts()
+	if rq.DryRun || len(p.changes) == 0 {
+		return resp, nil
+	}
+
+	if err := b.apply(ctx, tenantID, cur, p); err != nil {
+		return nil, err
+	}
+	log.W(ctx).Infow("Permission catalog imported", "tenant_id", tenantID, "created", resp.Created, "updated", resp.Updated, "deleted", resp.Deleted)
+	return resp, nil
+}
+
+// Export 导出租户当前的权限目录
+func (b *catalogBiz) Export(ctx context.Context, rq *apiv1.ExportPermissionCatalogRequest) (*apiv1.ExportPermissionCatalogResponse, error) {
+	tenantID := requestTenantID(ctx, rq.TenantId)
+	format := rq.Format
+	if format == "" {
+		format = FormatYAML
+	}
+
+	cur, err := b.loadState(store.WithoutDataScope(ctx), tenantID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := export(cur).Marshal(format)
+	if err != nil {
+		return nil, errno.ErrInvalidArgument.WithMessage(err.Error())
+	}
+	return &apiv1.ExportPermissionCatalogResponse{Format: format, Manifest: string(data)}, nil
+}
+
+// loadState 加载租户当前的权限目录
+func (b *catalogBiz) loadState(ctx context.Context, tenantID int64) (*state, error) {
+	cur := &state{grants: make(map[int64][]authz.PermissionGrant)}
+
+	var err error
+	if _, cur.permissions, err = b.store.Permission().List(ctx, where.F("tenant_id", tenantID)); err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if _, cur.menus, err = b.store.Menu().List(ctx, where.F("tenant_id", tenantID)); err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if _, cur.links, err = b.store.MenuPermission().List(ctx, where.F("tenant_id", tenantID)); err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if _, cur.roles, err = b.store.Role().List(ctx, where.F("tenant_id", tenantID)); err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	for _, role := range cur.roles {
+		if cur.grants[role.ID], err = b.authz.GetPermissionsForRole(role.ID, tenantID); err != nil {
+			return nil, errno.ErrInternal.WithMessage(err.Error())
+		}
+	}
+	return cur, nil
+}
+
+// apply 按变更计划修改租户的权限目录
+func (b *catalogBiz) apply(ctx context.Context, tenantID int64, cur *state, p *plan) error {
+	permissionIDs := make(map[string]int64, len(cur.permissions))
+	for _, permission := range cur.permissions {
+		permissionIDs[permission.Name] = permission.ID
+	}
+	menuIDs := make(map[string]int64, len(cur.menus))
+	for _, menu := range cur.menus {
+		menuIDs[menu.MenuCode] = menu.ID
+	}
+	roleIDs := make(map[string]int64, len(cur.roles))
+	for _, role := range cur.roles {
+		roleIDs[role.Name] = role.ID
+	}
+
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		// 状态字段有默认值，创建时 false 不会写入，需要再更新一次
+		for _, permission := range p.permissions {
+			if err := b.save(permission.ID == 0, !permission.Status,
+				func() error { return b.store.Permission().Create(ctx, permission) },
+				func() error { return b.store.Permission().Update(ctx, permission) },
+			); err != nil {
+				return err
+			}
+			permissionIDs[permission.Name] = permission.ID
+		}
+		for _, role := range p.roles {
+			if err := b.save(role.ID == 0, !role.Status,
+				func() error { return b.store.Role().Create(ctx, role) },
+				func() error { return b.store.Role().Update(ctx, role) },
+			); err != nil {
+				return err
+			}
+			roleIDs[role.Name] = role.ID
+		}
+
+		// 先创建和更新菜单，再设置父菜单，父菜单可以在清单中排在子菜单之后
+		for _, op := range p.menus {
+			menu := op.menu
+			if err := b.save(menu.ID == 0, !menu.Visible || !menu.Status,
+				func() error { return b.store.Menu().Create(ctx, menu) },
+				func() error { return b.store.Menu().Update(ctx, menu) },
+			); err != nil {
+				return err
+			}
+			menuIDs[menu.MenuCode] = menu.ID
+		}
+		for _, op := range p.menus {
+			var parentID *int64
+			if op.parent != "" {
+				id := menuIDs[op.parent]
+				parentID = &id
+			}
+			if equalID(op.menu.ParentID, parentID) {
+				continue
+			}
+			op.menu.ParentID = parentID
+			if err := b.store.Menu().Update(ctx, op.menu); err != nil {
+				return errno.ErrDBWrite.WithMessage(err.Error())
+			}
+		}
+
+		for _, op := range p.links {
+			link := op.link
+			if link.ID == 0 {
+				link.MenuID, link.PermissionID = menuIDs[op.menu], permissionIDs[op.permission]
+				if err := b.store.MenuPermission().Create(ctx, link); err != nil {
+					return errno.ErrDBWrite.WithMessage(err.Error())
+				}
+				continue
+			}
+			if err := b.store.MenuPermission().Update(ctx, link); err != nil {
+				return errno.ErrDBWrite.WithMessage(err.Error())
+			}
+		}
+		for _, link := range p.deleteLinks {
+			if err := b.store.MenuPermission().Delete(ctx, where.F("id", link.ID)); err != nil {
+				return errno.ErrDBWrite.WithMessage(err.Error())
+			}
+		}
+
+		for _, menu := range p.deleteMenus {
+			if err := b.store.MenuPermission().ClearMenuPermissions(ctx, menu.ID); err != nil {
+				return errno.ErrDBWrite.WithMessage(err.Error())
+			}
+			if err := b.store.Menu().Delete(ctx, where.F("id", menu.ID)); err != nil {
+				return errno.ErrDBWrite.WithMessage(err.Error())
+			}
+		}
+		if len(p.deletePermissions) > 0 {
+			ids := make([]int64, 0, len(p.deletePermissions))
+			for _, permission := range p.deletePermissions {
+				ids = append(ids, permission.ID)
+			}
+			if err := b.store.MenuPermission().BatchDeleteByPermissionIDs(ctx, ids); err != nil {
+				return errno.ErrDBWrite.WithMessage(err.Error())
+			}
+			if err := b.store.Permission().Delete(ctx, where.NewWhere().Q("id IN ?", ids)); err != nil {
+				return errno.ErrDBWrite.WithMessage(err.Error())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.W(ctx).Errorw("Failed to import permission catalog", "tenant_id", tenantID, "err", err)
+		return err
+	}
+
+	// 策略变更
+	for _, grant := range p.grants {
+		if _, err := b.authz.AddPermissionForRole(roleIDs[grant.role], permissionIDs[grant.permission], tenantID, grant.effect); err != nil {
+			return errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+		}
+	}
+	for _, grant := range p.deleteGrants {
+		if _, err := b.authz.DeletePermissionForRole(grant.roleID, grant.permissionID, tenantID); err != nil {
+			return errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+		}
+	}
+	for _, permission := range p.deletePermissions {
+		if _, err := b.authz.DeletePermissionPolicies(permission.ID, tenantID); err != nil {
+			return errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+		}
+	}
+
+	// 权限的路径、条件以及角色和权限的名称可能发生变化
+	b.authz.InvalidateAPIRoutes()
+	b.authz.InvalidateConditions()
+	b.authz.InvalidateResolver()
+	return nil
+}
+
+// save 创建或更新对象，创建的对象有值为 false 的默认值字段时再更新一次
+func (b *catalogBiz) save(create, hasFalseDefaults bool, createFn, updateFn func() error) error {
+	var err error
+	if create {
+		err = createFn()
+		if err == nil && hasFalseDefaults {
+			err = updateFn()
+		}
+	} else {
+		err = updateFn()
+	}
+	if err != nil {
+		return errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	return nil
+}
+
+// equalID 判断两个可选ID是否相等
+func equalID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// requestTenantID 返回请求中的租户ID，为空时使用当前租户
+func requestTenantID(ctx context.Context, tenantID int64) int64 {
+	if tenantID != 0 {
+		return tenantID
+	}
+	if tid, err := strconv.ParseInt(contextx.TenantID(ctx), 10, 64); err == nil {
+		return tid
+	}
+	return 1 // 默认租户
+}
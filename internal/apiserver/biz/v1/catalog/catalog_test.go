@@ -0,0 +1,187 @@
+package catalog
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+)
+
+func TestParseManifest_StandardPermissions(t *testing.T) {
+	data, err := os.ReadFile("../../../../../configs/permissions.yaml")
+	require.NoError(t, err)
+
+	m, err := ParseManifest(data)
+	require.NoError(t, err)
+	require.NotEmpty(t, m.Permissions)
+	assert.Nil(t, m.StandardPermissions)
+
+	// 旧格式的 code 作为权限名称
+	var found bool
+	for _, p := range m.Permissions {
+		assert.Empty(t, p.Code)
+		if p.Name == "user:export" {
+			found = true
+			assert.Equal(t, "feature", p.ResourceType)
+			assert.Equal(t, "export", p.Action)
+			assert.Equal(t, "导出用户数据", p.Description)
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestParseManifest_Invalid(t *testing.T) {
+	for name, manifest := range map[string]string{
+		"duplicate permission": "permissions: [{name: a:view}, {name: a:view}]",
+		"resource type":        "permissions: [{name: a:view, resource_type: file}]",
+		"condition":            "permissions: [{name: a:view, condition: 'user_id =='}]",
+		"data scope":           "roles: [{name: admin, data_scope: world}]",
+		"effect":               "roles: [{name: admin, grants: [{permission: a:view, effect: maybe}]}]",
+		"self parent":          "menus: [{code: a, title: A, parent: a}]",
+	} {
+		_, err := ParseManifest([]byte(manifest))
+		assert.Error(t, err, name)
+	}
+}
+
+func testState() *state {
+	desc := "查看用户"
+	view, create := "view", "create"
+	return &state{
+		permissions: []*model.PermissionM{
+			{ID: 1, TenantID: 1, Name: "user:view", Description: &desc, ResourceType: "menu", Action: &view, Status: true},
+			{ID: 2, TenantID: 1, Name: "user:create", ResourceType: "menu", Action: &create, Status: true},
+			{ID: 3, TenantID: 1, Name: "legacy:view", ResourceType: "menu", Status: true},
+		},
+		menus: []*model.MenuM{
+			{ID: 10, TenantID: 1, MenuCode: "system", Title: "系统管理", MenuType: 1, Visible: true, Status: true},
+			{ID: 11, TenantID: 1, MenuCode: "user-list", ParentID: ptr(int64(10)), Title: "用户列表", MenuType: 2, Visible: true, Status: true},
+			{ID: 12, TenantID: 1, MenuCode: "legacy", Title: "旧菜单", MenuType: 2, Visible: true, Status: true},
+		},
+		links: []*model.MenuPermissionM{
+			{ID: 20, TenantID: 1, MenuID: 11, PermissionID: 1, IsRequired: true},
+			{ID: 21, TenantID: 1, MenuID: 11, PermissionID: 2},
+			{ID: 22, TenantID: 1, MenuID: 12, PermissionID: 3, IsRequired: true},
+		},
+		roles: []*model.RoleM{
+			{ID: 1, TenantID: 1, Name: "super_admin", DataScope: "all", Status: true},
+			{ID: 2, TenantID: 1, Name: "admin", DataScope: "tenant", Status: true},
+		},
+		grants: map[int64][]authz.PermissionGrant{
+			2: {{PermissionID: 1, Effect: authz.EffectAllow}, {PermissionID: 2, Effect: authz.EffectAllow}, {PermissionID: 3, Effect: authz.EffectAllow}},
+		},
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestExport_RoundTrip(t *testing.T) {
+	cur := testState()
+
+	data, err := export(cur).Marshal(FormatYAML)
+	require.NoError(t, err)
+	m, err := ParseManifest(data)
+	require.NoError(t, err)
+
+	p, err := diff(1, m, cur, true)
+	require.NoError(t, err)
+	assert.Empty(t, p.changes)
+
+	data, err = export(cur).Marshal(FormatJSON)
+	require.NoError(t, err)
+	m, err = ParseManifest(data)
+	require.NoError(t, err)
+	p, err = diff(1, m, cur, true)
+	require.NoError(t, err)
+	assert.Empty(t, p.changes)
+}
+
+func TestDiff(t *testing.T) {
+	manifest := `
+permissions:
+  - name: user:view
+    description: 查看用户列表
+    action: view
+  - name: user:create
+    action: create
+  - name: user:delete
+    action: delete
+menus:
+  - code: system
+    title: 系统管理
+    menu_type: 1
+  - code: user-list
+    parent: system
+    title: 用户列表
+    permissions:
+      - permission: user:view
+        required: true
+      - permission: user:delete
+  - code: audit
+    parent: system
+    title: 审计日志
+    visible: false
+roles:
+  - name: admin
+    grants:
+      - permission: user:create
+        effect: deny
+  - name: auditor
+    data_scope: self
+    grants:
+      - permission: user:delete
+`
+	m, err := ParseManifest([]byte(manifest))
+	require.NoError(t, err)
+
+	// 不删除时只创建和更新
+	p, err := diff(1, m, testState(), false)
+	require.NoError(t, err)
+	assert.Equal(t, []*apiv1.CatalogChange{
+		{Kind: KindPermission, Op: OpUpdate, Key: "user:view", Fields: []string{"description"}},
+		{Kind: KindPermission, Op: OpCreate, Key: "user:delete"},
+		{Kind: KindMenuPermission, Op: OpCreate, Key: "user-list/user:delete"},
+		{Kind: KindMenu, Op: OpCreate, Key: "audit"},
+		{Kind: KindGrant, Op: OpUpdate, Key: "admin/user:create", Fields: []string{"effect"}},
+		{Kind: KindRole, Op: OpCreate, Key: "auditor"},
+		{Kind: KindGrant, Op: OpCreate, Key: "auditor/user:delete"},
+	}, p.changes)
+	require.Len(t, p.menus, 1)
+	assert.Equal(t, "system", p.menus[0].parent)
+	assert.False(t, p.menus[0].menu.Visible)
+
+	// 删除清单中未声明的对象，已删除权限的关联和规则随权限一起清理
+	p, err = diff(1, m, testState(), true)
+	require.NoError(t, err)
+	created, updated, deleted := p.counts()
+	assert.Equal(t, [3]int32{5, 2, 4}, [3]int32{created, updated, deleted})
+	assert.Contains(t, p.changes, &apiv1.CatalogChange{Kind: KindPermission, Op: OpDelete, Key: "legacy:view"})
+	assert.Contains(t, p.changes, &apiv1.CatalogChange{Kind: KindMenu, Op: OpDelete, Key: "legacy"})
+	assert.Contains(t, p.changes, &apiv1.CatalogChange{Kind: KindMenuPermission, Op: OpDelete, Key: "user-list/user:create"})
+	assert.Contains(t, p.changes, &apiv1.CatalogChange{Kind: KindGrant, Op: OpDelete, Key: "admin/user:view"})
+}
+
+func TestDiff_UnknownReferences(t *testing.T) {
+	for name, manifest := range map[string]string{
+		"menu permission": "menus: [{code: a, title: A, permissions: [{permission: nope:view}]}]",
+		"grant":           "roles: [{name: admin, grants: [{permission: nope:view}]}]",
+		"parent":          "menus: [{code: a, title: A, parent: nope}]",
+		"cycle":           "menus: [{code: a, title: A, parent: b}, {code: b, title: B, parent: a}]",
+	} {
+		m, err := ParseManifest([]byte(manifest))
+		require.NoError(t, err, name)
+		_, err = diff(1, m, testState(), false)
+		assert.Error(t, err, name)
+	}
+
+	// 被删除的权限不能再被引用
+	m, err := ParseManifest([]byte("permissions: [{name: user:view}]\nroles: [{name: admin, grants: [{permission: legacy:view}]}]"))
+	require.NoError(t, err)
+	_, err = diff(1, m, testState(), true)
+	assert.Error(t, err)
+}
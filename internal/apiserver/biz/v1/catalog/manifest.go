@@ -0,0 +1,237 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+)
+
+// 清单格式
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// 清单字段的默认值，与数据库列的默认值一致
+const (
+	defaultResourceType = "menu"
+	defaultMenuType     = 2
+)
+
+// validResourceTypes 是权限表支持的资源类型
+var validResourceTypes = map[string]bool{"api": true, "menu": true, "data": true, "feature": true}
+
+// Manifest 是声明式的权限目录清单，描述租户的权限、菜单、菜单权限关联和角色权限规则.
+type Manifest struct {
+	Permissions []PermissionSpec `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+	Menus       []MenuSpec       `json:"menus,omitempty" yaml:"menus,omitempty"`
+	Roles       []RoleSpec       `json:"roles,omitempty" yaml:"roles,omitempty"`
+
+	// StandardPermissions、CustomModules 兼容 configs/permissions.yaml 中按模块分组的权限定义
+	StandardPermissions map[string][]PermissionSpec `json:"standard_permissions,omitempty" yaml:"standard_permissions,omitempty"`
+	CustomModules       map[string][]PermissionSpec `json:"custom_modules,omitempty" yaml:"custom_modules,omitempty"`
+}
+
+// PermissionSpec 描述一个权限，以 Name 作为标识.
+type PermissionSpec struct {
+	// Code 兼容按模块分组的旧格式：设置时作为权限名称，旧格式中的 name 在没有描述时作为描述
+	Code         string `json:"code,omitempty" yaml:"code,omitempty"`
+	Name         string `json:"name" yaml:"name"`
+	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
+	ResourceType string `json:"resource_type,omitempty" yaml:"resource_type,omitempty"`
+	ResourcePath string `json:"resource_path,omitempty" yaml:"resource_path,omitempty"`
+	HTTPMethod   string `json:"http_method,omitempty" yaml:"http_method,omitempty"`
+	Action       string `json:"action,omitempty" yaml:"action,omitempty"`
+	Condition    string `json:"condition,omitempty" yaml:"condition,omitempty"`
+	// Status 为空时表示启用
+	Status *bool `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// MenuSpec 描述一个菜单，以 Code 作为标识.
+type MenuSpec struct {
+	Code string `json:"code" yaml:"code"`
+	// Parent 为父菜单编码，为空表示顶级菜单
+	Parent    string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Title     string `json:"title" yaml:"title"`
+	MenuType  int32  `json:"menu_type,omitempty" yaml:"menu_type,omitempty"`
+	RoutePath string `json:"route_path,omitempty" yaml:"route_path,omitempty"`
+	Component string `json:"component,omitempty" yaml:"component,omitempty"`
+	Icon      string `json:"icon,omitempty" yaml:"icon,omitempty"`
+	SortOrder int32  `json:"sort_order,omitempty" yaml:"sort_order,omitempty"`
+	Remark    string `json:"remark,omitempty" yaml:"remark,omitempty"`
+	// Visible、Status 为空时表示可见、启用
+	Visible     *bool                `json:"visible,omitempty" yaml:"visible,omitempty"`
+	Status      *bool                `json:"status,omitempty" yaml:"status,omitempty"`
+	Permissions []MenuPermissionSpec `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+}
+
+// MenuPermissionSpec 描述菜单关联的权限.
+type MenuPermissionSpec struct {
+	Permission string `json:"permission" yaml:"permission"`
+	// Required 表示是否为访问菜单的必需权限
+	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// RoleSpec 描述一个角色及其权限规则，以 Name 作为标识.
+type RoleSpec struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// DataScope 为空时为 tenant
+	DataScope string `json:"data_scope,omitempty" yaml:"data_scope,omitempty"`
+	// Status 为空时表示启用
+	Status *bool       `json:"status,omitempty" yaml:"status,omitempty"`
+	Grants []GrantSpec `json:"grants,omitempty" yaml:"grants,omitempty"`
+}
+
+// GrantSpec 描述角色对权限的 allow 或 deny 规则.
+type GrantSpec struct {
+	Permission string `json:"permission" yaml:"permission"`
+	// Effect 为空时为 allow
+	Effect string `json:"effect,omitempty" yaml:"effect,omitempty"`
+}
+
+// ParseManifest 解析 YAML 或 JSON 格式的清单，并补齐默认值和校验.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var m Manifest
+	// JSON 是 YAML 的子集，两种格式都按 YAML 解析
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if err := m.normalize(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Marshal 将清单编码为指定格式，格式为空时为 YAML.
+func (m *Manifest) Marshal(format string) ([]byte, error) {
+	switch format {
+	case "", FormatYAML:
+		return yaml.Marshal(m)
+	case FormatJSON:
+		return json.MarshalIndent(m, "", "  ")
+	default:
+		return nil, fmt.Errorf("unsupported manifest format %q", format)
+	}
+}
+
+// normalize 合并按模块分组的权限，补齐默认值并校验清单.
+func (m *Manifest) normalize() error {
+	for _, groups := range []map[string][]PermissionSpec{m.StandardPermissions, m.CustomModules} {
+		modules := make([]string, 0, len(groups))
+		for module := range groups {
+			modules = append(modules, module)
+		}
+		sort.Strings(modules)
+		for _, module := range modules {
+			m.Permissions = append(m.Permissions, groups[module]...)
+		}
+	}
+	m.StandardPermissions, m.CustomModules = nil, nil
+
+	permissions := make(map[string]bool, len(m.Permissions))
+	for i := range m.Permissions {
+		p := &m.Permissions[i]
+		if p.Code != "" {
+			if p.Description == "" {
+				p.Description = p.Name
+			}
+			p.Name, p.Code = p.Code, ""
+		}
+		if p.Name == "" {
+			return fmt.Errorf("permission #%d: name is required", i+1)
+		}
+		if permissions[p.Name] {
+			return fmt.Errorf("permission %q is declared more than once", p.Name)
+		}
+		permissions[p.Name] = true
+
+		if p.ResourceType == "" {
+			p.ResourceType = defaultResourceType
+		}
+		if !validResourceTypes[p.ResourceType] {
+			return fmt.Errorf("permission %q: invalid resource type %q", p.Name, p.ResourceType)
+		}
+		p.HTTPMethod = strings.ToUpper(p.HTTPMethod)
+		if p.Condition != "" {
+			if err := authz.ValidateCondition(p.Condition); err != nil {
+				return fmt.Errorf("permission %q: %w", p.Name, err)
+			}
+		}
+	}
+
+	menus := make(map[string]bool, len(m.Menus))
+	for i := range m.Menus {
+		menu := &m.Menus[i]
+		if menu.Code == "" || menu.Title == "" {
+			return fmt.Errorf("menu #%d: code and title are required", i+1)
+		}
+		if menus[menu.Code] {
+			return fmt.Errorf("menu %q is declared more than once", menu.Code)
+		}
+		menus[menu.Code] = true
+		if menu.Parent == menu.Code {
+			return fmt.Errorf("menu %q cannot be its own parent", menu.Code)
+		}
+		if menu.MenuType == 0 {
+			menu.MenuType = defaultMenuType
+		}
+		linked := make(map[string]bool, len(menu.Permissions))
+		for _, link := range menu.Permissions {
+			if link.Permission == "" || linked[link.Permission] {
+				return fmt.Errorf("menu %q: empty or duplicate permission %q", menu.Code, link.Permission)
+			}
+			linked[link.Permission] = true
+		}
+	}
+
+	roles := make(map[string]bool, len(m.Roles))
+	for i := range m.Roles {
+		role := &m.Roles[i]
+		if role.Name == "" {
+			return fmt.Errorf("role #%d: name is required", i+1)
+		}
+		if roles[role.Name] {
+			return fmt.Errorf("role %q is declared more than once", role.Name)
+		}
+		roles[role.Name] = true
+		if role.DataScope == "" {
+			role.DataScope = model.DataScopeTenant
+		}
+		if !model.IsValidDataScope(role.DataScope) {
+			return fmt.Errorf("role %q: invalid data scope %q", role.Name, role.DataScope)
+		}
+		granted := make(map[string]bool, len(role.Grants))
+		for j := range role.Grants {
+			grant := &role.Grants[j]
+			if grant.Permission == "" || granted[grant.Permission] {
+				return fmt.Errorf("role %q: empty or duplicate grant %q", role.Name, grant.Permission)
+			}
+			granted[grant.Permission] = true
+			if grant.Effect == "" {
+				grant.Effect = authz.EffectAllow
+			}
+			if !authz.IsValidEffect(grant.Effect) {
+				return fmt.Errorf("role %q: invalid effect %q", role.Name, grant.Effect)
+			}
+		}
+	}
+	return nil
+}
+
+// isTrue 返回可选布尔值，为空时为 true
+func isTrue(b *bool) bool {
+	return b == nil || *b
+}
@@ -0,0 +1,588 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+)
+
+// 变更的对象类型
+const (
+	KindPermission     = "permission"
+	KindMenu           = "menu"
+	KindMenuPermission = "menu_permission"
+	KindRole           = "role"
+	KindGrant          = "grant"
+)
+
+// 变更的操作
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// errMenuCycle 表示菜单的父菜单链存在环
+var errMenuCycle = errors.New("menu hierarchy contains a cycle")
+
+// state 是租户当前的权限目录.
+type state struct {
+	permissions []*model.PermissionM
+	menus       []*model.MenuM
+	links       []*model.MenuPermissionM
+	roles       []*model.RoleM
+	// grants 为每个角色的权限规则
+	grants map[int64][]authz.PermissionGrant
+}
+
+// menuOp 是待创建或更新的菜单，parent 为父菜单编码，在应用时解析为ID
+type menuOp struct {
+	menu   *model.MenuM
+	parent string
+}
+
+// linkOp 是待创建或更新的菜单权限关联
+type linkOp struct {
+	link       *model.MenuPermissionM
+	menu       string
+	permission string
+}
+
+// grantOp 是待添加、修改或删除的角色权限规则，待删除的规则直接记录角色和权限ID
+type grantOp struct {
+	role         string
+	permission   string
+	effect       string
+	roleID       int64
+	permissionID int64
+}
+
+// plan 是将清单应用到租户所需的变更，ID 为 0 的对象表示待创建.
+type plan struct {
+	changes []*apiv1.CatalogChange
+
+	permissions []*model.PermissionM
+	roles       []*model.RoleM
+	menus       []*menuOp
+	links       []*linkOp
+	grants      []*grantOp
+
+	deletePermissions []*model.PermissionM
+	deleteMenus       []*model.MenuM
+	deleteLinks       []*model.MenuPermissionM
+	deleteGrants      []*grantOp
+}
+
+// add 记录一项变更
+func (p *plan) add(kind, op, key string, fields ...string) {
+	p.changes = append(p.changes, &apiv1.CatalogChange{Kind: kind, Op: op, Key: key, Fields: fields})
+}
+
+// counts 统计创建、更新和删除的数量
+func (p *plan) counts() (created, updated, deleted int32) {
+	for _, c := range p.changes {
+		switch c.Op {
+		case OpCreate:
+			created++
+		case OpUpdate:
+			updated++
+		case OpDelete:
+			deleted++
+		}
+	}
+	return created, updated, deleted
+}
+
+// diff 比较清单与租户当前状态，计算变更计划.
+// prune 时删除清单中未声明的对象：权限和菜单只在清单声明了对应部分时删除，
+// 菜单权限关联和角色权限规则只在清单声明的菜单和角色范围内删除；角色本身不会被删除.
+func diff(tenantID int64, m *Manifest, cur *state, prune bool) (*plan, error) {
+	p := &plan{}
+
+	// 权限
+	permissionByName := make(map[string]*model.PermissionM, len(cur.permissions))
+	permissionByID := make(map[int64]*model.PermissionM, len(cur.permissions))
+	for _, permission := range cur.permissions {
+		permissionByName[permission.Name] = permission
+		permissionByID[permission.ID] = permission
+	}
+	declared := make(map[string]bool, len(m.Permissions))
+	for _, spec := range m.Permissions {
+		declared[spec.Name] = true
+		desired := &model.PermissionM{
+			TenantID:     tenantID,
+			Name:         spec.Name,
+			Description:  optional(spec.Description),
+			ResourceType: spec.ResourceType,
+			ResourcePath: optional(spec.ResourcePath),
+			HTTPMethod:   optional(spec.HTTPMethod),
+			Action:       optional(spec.Action),
+			Condition:    optional(spec.Condition),
+			Status:       isTrue(spec.Status),
+		}
+		existing, ok := permissionByName[spec.Name]
+		if !ok {
+			p.permissions = append(p.permissions, desired)
+			p.add(KindPermission, OpCreate, spec.Name)
+			continue
+		}
+		if fields := permissionChanges(existing, desired); len(fields) > 0 {
+			updated := *existing
+			updated.Description, updated.ResourceType, updated.ResourcePath = desired.Description, desired.ResourceType, desired.ResourcePath
+			updated.HTTPMethod, updated.Action, updated.Condition, updated.Status = desired.HTTPMethod, desired.Action, desired.Condition, desired.Status
+			p.permissions = append(p.permissions, &updated)
+			p.add(KindPermission, OpUpdate, spec.Name, fields...)
+		}
+	}
+	deletedPermissions := make(map[int64]bool)
+	if prune && len(m.Permissions) > 0 {
+		for _, permission := range cur.permissions {
+			if !declared[permission.Name] {
+				deletedPermissions[permission.ID] = true
+				p.deletePermissions = append(p.deletePermissions, permission)
+				p.add(KindPermission, OpDelete, permission.Name)
+			}
+		}
+	}
+	// permissionExists 判断应用后权限是否存在
+	permissionExists := func(name string) bool {
+		if declared[name] {
+			return true
+		}
+		existing, ok := permissionByName[name]
+		return ok && !deletedPermissions[existing.ID]
+	}
+
+	// 菜单
+	menuByCode := make(map[string]*model.MenuM, len(cur.menus))
+	menuByID := make(map[int64]*model.MenuM, len(cur.menus))
+	for _, menu := range cur.menus {
+		menuByCode[menu.MenuCode] = menu
+		menuByID[menu.ID] = menu
+	}
+	menuDeclared := make(map[string]*MenuSpec, len(m.Menus))
+	for i := range m.Menus {
+		menuDeclared[m.Menus[i].Code] = &m.Menus[i]
+	}
+	deletedMenus := make(map[int64]bool)
+	if prune && len(m.Menus) > 0 {
+		for _, menu := range cur.menus {
+			if menuDeclared[menu.MenuCode] == nil {
+				deletedMenus[menu.ID] = true
+				p.deleteMenus = append(p.deleteMenus, menu)
+				p.add(KindMenu, OpDelete, menu.MenuCode)
+			}
+		}
+	}
+	for _, spec := range m.Menus {
+		if spec.Parent != "" && menuDeclared[spec.Parent] == nil {
+			parent, ok := menuByCode[spec.Parent]
+			if !ok || deletedMenus[parent.ID] {
+				return nil, fmt.Errorf("menu %q: parent menu %q not found", spec.Code, spec.Parent)
+			}
+		}
+		if err := checkMenuCycle(spec.Code, menuDeclared, menuByCode, menuByID); err != nil {
+			return nil, err
+		}
+
+		desired := &model.MenuM{
+			TenantID:  tenantID,
+			MenuCode:  spec.Code,
+			Title:     spec.Title,
+			MenuType:  spec.MenuType,
+			RoutePath: optional(spec.RoutePath),
+			Component: optional(spec.Component),
+			Icon:      optional(spec.Icon),
+			SortOrder: spec.SortOrder,
+			Visible:   isTrue(spec.Visible),
+			Status:    isTrue(spec.Status),
+			Remark:    optional(spec.Remark),
+		}
+		existing, ok := menuByCode[spec.Code]
+		if !ok {
+			p.menus = append(p.menus, &menuOp{menu: desired, parent: spec.Parent})
+			p.add(KindMenu, OpCreate, spec.Code)
+		} else {
+			fields := menuChanges(existing, desired)
+			if parentCode(existing, menuByID) != spec.Parent {
+				fields = append(fields, "parent")
+			}
+			if len(fields) > 0 {
+				updated := *existing
+				updated.Title, updated.MenuType, updated.RoutePath, updated.Component = desired.Title, desired.MenuType, desired.RoutePath, desired.Component
+				updated.Icon, updated.SortOrder, updated.Visible, updated.Status, updated.Remark = desired.Icon, desired.SortOrder, desired.Visible, desired.Status, desired.Remark
+				p.menus = append(p.menus, &menuOp{menu: &updated, parent: spec.Parent})
+				p.add(KindMenu, OpUpdate, spec.Code, fields...)
+			}
+		}
+
+		// 菜单权限关联
+		links := make(map[int64]*model.MenuPermissionM)
+		if ok {
+			for _, link := range cur.links {
+				if link.MenuID == existing.ID {
+					links[link.PermissionID] = link
+				}
+			}
+		}
+		linked := make(map[int64]bool)
+		for _, spec := range spec.Permissions {
+			if !permissionExists(spec.Permission) {
+				return nil, fmt.Errorf("menu %q: permission %q not found", desired.MenuCode, spec.Permission)
+			}
+			key := desired.MenuCode + "/" + spec.Permission
+			var link *model.MenuPermissionM
+			if permission, found := permissionByName[spec.Permission]; found {
+				link = links[permission.ID]
+				linked[permission.ID] = true
+			}
+			switch {
+			case link == nil:
+				p.links = append(p.links, &linkOp{
+					link:       &model.MenuPermissionM{TenantID: tenantID, IsRequired: spec.Required},
+					menu:       desired.MenuCode,
+					permission: spec.Permission,
+				})
+				p.add(KindMenuPermission, OpCreate, key)
+			case link.IsRequired != spec.Required:
+				updated := *link
+				updated.IsRequired = spec.Required
+				p.links = append(p.links, &linkOp{link: &updated, menu: desired.MenuCode, permission: spec.Permission})
+				p.add(KindMenuPermission, OpUpdate, key, "required")
+			}
+		}
+		if prune {
+			for _, link := range sortedLinks(links) {
+				if linked[link.PermissionID] || deletedPermissions[link.PermissionID] {
+					continue
+				}
+				p.deleteLinks = append(p.deleteLinks, link)
+				p.add(KindMenuPermission, OpDelete, desired.MenuCode+"/"+permissionName(link.PermissionID, permissionByID))
+			}
+		}
+	}
+
+	// 角色和角色权限规则
+	roleByName := make(map[string]*model.RoleM, len(cur.roles))
+	for _, role := range cur.roles {
+		roleByName[role.Name] = role
+	}
+	for _, spec := range m.Roles {
+		desired := &model.RoleM{
+			TenantID:    tenantID,
+			Name:        spec.Name,
+			Description: optional(spec.Description),
+			DataScope:   spec.DataScope,
+			Status:      isTrue(spec.Status),
+		}
+		existing, ok := roleByName[spec.Name]
+		if !ok {
+			p.roles = append(p.roles, desired)
+			p.add(KindRole, OpCreate, spec.Name)
+		} else if fields := roleChanges(existing, desired); len(fields) > 0 {
+			updated := *existing
+			updated.Description, updated.DataScope, updated.Status = desired.Description, desired.DataScope, desired.Status
+			p.roles = append(p.roles, &updated)
+			p.add(KindRole, OpUpdate, spec.Name, fields...)
+		}
+
+		effects := make(map[int64]string)
+		if ok {
+			for _, grant := range cur.grants[existing.ID] {
+				effects[grant.PermissionID] = grant.Effect
+			}
+		}
+		granted := make(map[int64]bool)
+		for _, grant := range spec.Grants {
+			if !permissionExists(grant.Permission) {
+				return nil, fmt.Errorf("role %q: permission %q not found", spec.Name, grant.Permission)
+			}
+			key := spec.Name + "/" + grant.Permission
+			effect := ""
+			if permission, found := permissionByName[grant.Permission]; found {
+				effect = effects[permission.ID]
+				granted[permission.ID] = true
+			}
+			switch effect {
+			case grant.Effect:
+				continue
+			case "":
+				p.add(KindGrant, OpCreate, key)
+			default:
+				p.add(KindGrant, OpUpdate, key, "effect")
+			}
+			p.grants = append(p.grants, &grantOp{role: spec.Name, permission: grant.Permission, effect: grant.Effect})
+		}
+		if prune && ok {
+			for _, grant := range cur.grants[existing.ID] {
+				if granted[grant.PermissionID] || deletedPermissions[grant.PermissionID] {
+					continue
+				}
+				name := permissionName(grant.PermissionID, permissionByID)
+				p.deleteGrants = append(p.deleteGrants, &grantOp{role: spec.Name, permission: name, roleID: existing.ID, permissionID: grant.PermissionID})
+				p.add(KindGrant, OpDelete, spec.Name+"/"+name)
+			}
+		}
+	}
+	return p, nil
+}
+
+// export 将租户当前状态转换为清单
+func export(cur *state) *Manifest {
+	m := &Manifest{}
+
+	permissions := append([]*model.PermissionM(nil), cur.permissions...)
+	sort.Slice(permissions, func(i, j int) bool { return permissions[i].Name < permissions[j].Name })
+	permissionByID := make(map[int64]*model.PermissionM, len(permissions))
+	for _, permission := range permissions {
+		permissionByID[permission.ID] = permission
+		m.Permissions = append(m.Permissions, PermissionSpec{
+			Name:         permission.Name,
+			Description:  deref(permission.Description),
+			ResourceType: permission.ResourceType,
+			ResourcePath: deref(permission.ResourcePath),
+			HTTPMethod:   deref(permission.HTTPMethod),
+			Action:       deref(permission.Action),
+			Condition:    deref(permission.Condition),
+			Status:       falseOnly(permission.Status),
+		})
+	}
+
+	menuByID := make(map[int64]*model.MenuM, len(cur.menus))
+	for _, menu := range cur.menus {
+		menuByID[menu.ID] = menu
+	}
+	for _, menu := range sortMenus(cur.menus, menuByID) {
+		spec := MenuSpec{
+			Code:      menu.MenuCode,
+			Parent:    parentCode(menu, menuByID),
+			Title:     menu.Title,
+			MenuType:  menu.MenuType,
+			RoutePath: deref(menu.RoutePath),
+			Component: deref(menu.Component),
+			Icon:      deref(menu.Icon),
+			SortOrder: menu.SortOrder,
+			Remark:    deref(menu.Remark),
+			Visible:   falseOnly(menu.Visible),
+			Status:    falseOnly(menu.Status),
+		}
+		for _, link := range cur.links {
+			if permission, ok := permissionByID[link.PermissionID]; ok && link.MenuID == menu.ID {
+				spec.Permissions = append(spec.Permissions, MenuPermissionSpec{Permission: permission.Name, Required: link.IsRequired})
+			}
+		}
+		sort.Slice(spec.Permissions, func(i, j int) bool { return spec.Permissions[i].Permission < spec.Permissions[j].Permission })
+		m.Menus = append(m.Menus, spec)
+	}
+
+	roles := append([]*model.RoleM(nil), cur.roles...)
+	sort.Slice(roles, func(i, j int) bool { return roles[i].ID < roles[j].ID })
+	for _, role := range roles {
+		spec := RoleSpec{
+			Name:        role.Name,
+			Description: deref(role.Description),
+			DataScope:   role.DataScope,
+			Status:      falseOnly(role.Status),
+		}
+		for _, grant := range cur.grants[role.ID] {
+			if permission, ok := permissionByID[grant.PermissionID]; ok {
+				spec.Grants = append(spec.Grants, GrantSpec{Permission: permission.Name, Effect: grant.Effect})
+			}
+		}
+		sort.Slice(spec.Grants, func(i, j int) bool { return spec.Grants[i].Permission < spec.Grants[j].Permission })
+		m.Roles = append(m.Roles, spec)
+	}
+	return m
+}
+
+// permissionChanges 返回权限发生变化的字段
+func permissionChanges(cur, desired *model.PermissionM) []string {
+	var fields []string
+	if deref(cur.Description) != deref(desired.Description) {
+		fields = append(fields, "description")
+	}
+	if cur.ResourceType != desired.ResourceType {
+		fields = append(fields, "resource_type")
+	}
+	if deref(cur.ResourcePath) != deref(desired.ResourcePath) {
+		fields = append(fields, "resource_path")
+	}
+	if deref(cur.HTTPMethod) != deref(desired.HTTPMethod) {
+		fields = append(fields, "http_method")
+	}
+	if deref(cur.Action) != deref(desired.Action) {
+		fields = append(fields, "action")
+	}
+	if deref(cur.Condition) != deref(desired.Condition) {
+		fields = append(fields, "condition")
+	}
+	if cur.Status != desired.Status {
+		fields = append(fields, "status")
+	}
+	return fields
+}
+
+// menuChanges 返回菜单发生变化的字段，不包括父菜单
+func menuChanges(cur, desired *model.MenuM) []string {
+	var fields []string
+	if cur.Title != desired.Title {
+		fields = append(fields, "title")
+	}
+	if cur.MenuType != desired.MenuType {
+		fields = append(fields, "menu_type")
+	}
+	if deref(cur.RoutePath) != deref(desired.RoutePath) {
+		fields = append(fields, "route_path")
+	}
+	if deref(cur.Component) != deref(desired.Component) {
+		fields = append(fields, "component")
+	}
+	if deref(cur.Icon) != deref(desired.Icon) {
+		fields = append(fields, "icon")
+	}
+	if cur.SortOrder != desired.SortOrder {
+		fields = append(fields, "sort_order")
+	}
+	if cur.Visible != desired.Visible {
+		fields = append(fields, "visible")
+	}
+	if cur.Status != desired.Status {
+		fields = append(fields, "status")
+	}
+	if deref(cur.Remark) != deref(desired.Remark) {
+		fields = append(fields, "remark")
+	}
+	return fields
+}
+
+// roleChanges 返回角色发生变化的字段
+func roleChanges(cur, desired *model.RoleM) []string {
+	var fields []string
+	if deref(cur.Description) != deref(desired.Description) {
+		fields = append(fields, "description")
+	}
+	if cur.DataScope != desired.DataScope {
+		fields = append(fields, "data_scope")
+	}
+	if cur.Status != desired.Status {
+		fields = append(fields, "status")
+	}
+	return fields
+}
+
+// checkMenuCycle 检查应用清单后菜单的父菜单链是否存在环
+func checkMenuCycle(code string, declared map[string]*MenuSpec, byCode map[string]*model.MenuM, byID map[int64]*model.MenuM) error {
+	seen := map[string]bool{code: true}
+	for current := code; ; {
+		var parent string
+		if spec, ok := declared[current]; ok {
+			parent = spec.Parent
+		} else if menu, ok := byCode[current]; ok {
+			parent = parentCode(menu, byID)
+		}
+		if parent == "" {
+			return nil
+		}
+		if seen[parent] {
+			return fmt.Errorf("menu %q: %w", code, errMenuCycle)
+		}
+		seen[parent] = true
+		current = parent
+	}
+}
+
+// parentCode 返回菜单的父菜单编码
+func parentCode(menu *model.MenuM, byID map[int64]*model.MenuM) string {
+	if menu.ParentID == nil {
+		return ""
+	}
+	if parent, ok := byID[*menu.ParentID]; ok {
+		return parent.MenuCode
+	}
+	return ""
+}
+
+// sortMenus 按层级排列菜单，父菜单在子菜单之前，同级按排序和ID排列
+func sortMenus(menus []*model.MenuM, byID map[int64]*model.MenuM) []*model.MenuM {
+	children := make(map[int64][]*model.MenuM)
+	var roots []*model.MenuM
+	for _, menu := range menus {
+		if menu.ParentID != nil && byID[*menu.ParentID] != nil {
+			children[*menu.ParentID] = append(children[*menu.ParentID], menu)
+		} else {
+			roots = append(roots, menu)
+		}
+	}
+	less := func(list []*model.MenuM) {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].SortOrder != list[j].SortOrder {
+				return list[i].SortOrder < list[j].SortOrder
+			}
+			return list[i].ID < list[j].ID
+		})
+	}
+
+	var result []*model.MenuM
+	var walk func(list []*model.MenuM)
+	walk = func(list []*model.MenuM) {
+		less(list)
+		for _, menu := range list {
+			result = append(result, menu)
+			walk(children[menu.ID])
+		}
+	}
+	walk(roots)
+	return result
+}
+
+// sortedLinks 按权限ID排列菜单权限关联，保证变更计划的顺序稳定
+func sortedLinks(links map[int64]*model.MenuPermissionM) []*model.MenuPermissionM {
+	result := make([]*model.MenuPermissionM, 0, len(links))
+	for _, link := range links {
+		result = append(result, link)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PermissionID < result[j].PermissionID })
+	return result
+}
+
+// permissionName 返回权限名称，权限不存在时返回策略中的权限标识
+func permissionName(id int64, byID map[int64]*model.PermissionM) string {
+	if permission, ok := byID[id]; ok {
+		return permission.Name
+	}
+	return fmt.Sprintf("p%d", id)
+}
+
+// optional 将空字符串转换为 nil
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// deref 解引用字符串指针，如果为nil则返回空字符串
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// falseOnly 只在值为 false 时返回指针，导出时省略默认值
+func falseOnly(b bool) *bool {
+	if b {
+		return nil
+	}
+	return &b
+}
@@ -0,0 +1,191 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package consistency
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+)
+
+// 一致性问题的类型
+const (
+	IssueMalformedRule     = "malformed_rule"      // 规则类型未知、字段缺失、ID 不是数字或效果不是 allow/deny
+	IssueUnknownPrefix     = "unknown_prefix"      // ID 前缀无法识别或不能出现在该字段
+	IssueUnknownTenant     = "unknown_tenant"      // 规则的租户不存在或已删除
+	IssueOrphanedRule      = "orphaned_rule"       // 规则的主体（用户或角色）不存在或不属于该租户
+	IssueDanglingRule      = "dangling_rule"       // 规则指向的角色或权限不存在或不属于该租户
+	IssueUserNotInTenant   = "user_not_in_tenant"  // 规则中的用户不是该租户的成员
+	IssueDuplicate         = "duplicate"           // 与其他规则重复
+	IssueUserWithoutTenant = "user_without_tenant" // 用户不属于任何租户
+)
+
+// policyRule 是 casbin_rule 表中的一条规则
+type policyRule struct {
+	ID     int64
+	PType  string
+	Values []string // v0 到 v5，已去掉末尾的空值
+}
+
+// String 返回与 Casbin CSV 格式一致的规则内容
+func (r *policyRule) String() string {
+	return strings.Join(append([]string{r.PType}, r.Values...), ", ")
+}
+
+// relations 保存检查规则需要的关系数据
+type relations struct {
+	tenants            map[int64]bool    // 未删除的租户
+	users              map[int64]bool    // 未删除的用户
+	roles              map[int64]int64   // 未删除的角色及其所属租户
+	permissions        map[int64]int64   // 未删除的权限及其所属租户
+	memberships        map[[2]int64]bool // 用户和租户的成员关系
+	usersWithoutTenant []int64           // 不属于任何租户的用户
+}
+
+// checker 检查规则与关系数据的一致性
+type checker struct {
+	rel         *relations
+	idConverter *authz.IDConverter
+	seen        map[string]int64
+}
+
+// analyze 检查全部规则，返回发现的问题，每条规则最多报告一个问题
+func analyze(rules []*policyRule, rel *relations) []*apiv1.ConsistencyIssue {
+	c := &checker{rel: rel, idConverter: authz.NewIDConverter(), seen: make(map[string]int64, len(rules))}
+
+	var issues []*apiv1.ConsistencyIssue
+	for _, rule := range rules {
+		if kind, detail := c.check(rule); kind != "" {
+			issues = append(issues, &apiv1.ConsistencyIssue{
+				Kind:    kind,
+				RuleId:  rule.ID,
+				Rule:    rule.String(),
+				Detail:  detail,
+				Fixable: true,
+			})
+		}
+	}
+	for _, userID := range rel.usersWithoutTenant {
+		issues = append(issues, &apiv1.ConsistencyIssue{
+			Kind:   IssueUserWithoutTenant,
+			UserId: userID,
+			Detail: fmt.Sprintf("user %d does not belong to any tenant", userID),
+		})
+	}
+	return issues
+}
+
+// check 检查一条规则，没有问题时返回空字符串
+func (c *checker) check(rule *policyRule) (string, string) {
+	if rule.PType != "g" && rule.PType != "p" {
+		return IssueMalformedRule, fmt.Sprintf("unknown ptype %q", rule.PType)
+	}
+	if len(rule.Values) < 3 {
+		return IssueMalformedRule, fmt.Sprintf("expected at least 3 fields, got %d", len(rule.Values))
+	}
+	values := rule.Values
+
+	// 租户
+	tenantID, kind, detail := c.parseID(values[2], "domain")
+	if kind != "" {
+		return kind, detail
+	}
+	if !c.rel.tenants[tenantID] {
+		return IssueUnknownTenant, fmt.Sprintf("tenant %d does not exist", tenantID)
+	}
+
+	// 主体
+	subjectType := c.idConverter.GetIDType(values[0])
+	subjectID, kind, detail := c.parseID(values[0], "user", "role")
+	if kind != "" {
+		return kind, detail
+	}
+	if subjectType == "user" && !c.rel.users[subjectID] {
+		return IssueOrphanedRule, fmt.Sprintf("user %d does not exist", subjectID)
+	}
+	if subjectType == "role" {
+		if detail := c.checkRole(subjectID, tenantID); detail != "" {
+			return IssueOrphanedRule, detail
+		}
+	}
+
+	// 对象
+	key := rule.PType + "|" + values[0] + "|"
+	if rule.PType == "g" {
+		roleID, kind, detail := c.parseID(values[1], "role")
+		if kind != "" {
+			return kind, detail
+		}
+		if detail := c.checkRole(roleID, tenantID); detail != "" {
+			return IssueDanglingRule, detail
+		}
+		key += values[1] + "|" + values[2]
+	} else {
+		permissionID, ok := authz.ParsePermissionObject(values[1])
+		if !ok {
+			return IssueUnknownPrefix, fmt.Sprintf("%q is not a permission object", values[1])
+		}
+		owner, ok := c.rel.permissions[permissionID]
+		if !ok {
+			return IssueDanglingRule, fmt.Sprintf("permission %d does not exist", permissionID)
+		}
+		if owner != tenantID {
+			return IssueDanglingRule, fmt.Sprintf("permission %d belongs to tenant %d", permissionID, owner)
+		}
+		effect := authz.EffectAllow
+		if len(values) > 3 && values[3] != "" {
+			effect = values[3]
+		}
+		if !authz.IsValidEffect(effect) {
+			return IssueMalformedRule, fmt.Sprintf("invalid effect %q", effect)
+		}
+		// p{id} 和 a{id} 指向同一权限
+		key += strconv.FormatInt(permissionID, 10) + "|" + values[2] + "|" + effect
+	}
+
+	if subjectType == "user" && !c.rel.memberships[[2]int64{subjectID, tenantID}] {
+		return IssueUserNotInTenant, fmt.Sprintf("user %d is not a member of tenant %d", subjectID, tenantID)
+	}
+
+	if first, ok := c.seen[key]; ok {
+		return IssueDuplicate, fmt.Sprintf("duplicate of rule %d", first)
+	}
+	c.seen[key] = rule.ID
+	return "", ""
+}
+
+// checkRole 检查角色存在且属于租户，有问题时返回问题说明
+func (c *checker) checkRole(roleID, tenantID int64) string {
+	owner, ok := c.rel.roles[roleID]
+	if !ok {
+		return fmt.Sprintf("role %d does not exist", roleID)
+	}
+	if owner != tenantID {
+		return fmt.Sprintf("role %d belongs to tenant %d", roleID, owner)
+	}
+	return ""
+}
+
+// parseID 按 IDConverter.GetIDType 解析带前缀的ID，types 为该字段允许的类型
+func (c *checker) parseID(value string, types ...string) (int64, string, string) {
+	idType := c.idConverter.GetIDType(value)
+	allowed := false
+	for _, t := range types {
+		allowed = allowed || t == idType
+	}
+	if !allowed {
+		return 0, IssueUnknownPrefix, fmt.Sprintf("%q is not a %s ID", value, strings.Join(types, " or "))
+	}
+	id, err := strconv.ParseInt(value[1:], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, IssueMalformedRule, fmt.Sprintf("%q has an invalid numeric ID", value)
+	}
+	return id, "", ""
+}
@@ -0,0 +1,212 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package consistency
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+)
+
+// batchSize 是按ID查询和删除时每批的数量
+const batchSize = 500
+
+// ConsistencyBiz 定义了检查 Casbin 策略与关系数据一致性的业务逻辑接口.
+type ConsistencyBiz interface {
+	// Check 检查 casbin_rule 与 roles、permissions、user、tenants 的一致性，fix 为 true 时删除可以自动处理的规则
+	Check(ctx context.Context, rq *apiv1.CheckConsistencyRequest) (*apiv1.CheckConsistencyResponse, error)
+}
+
+// consistencyBiz 是 ConsistencyBiz 接口的实现.
+type consistencyBiz struct {
+	store store.IStore
+	authz *authz.Authz
+}
+
+// 确保 consistencyBiz 实现了 ConsistencyBiz 接口.
+var _ ConsistencyBiz = (*consistencyBiz)(nil)
+
+// New 创建一个新的 ConsistencyBiz 实例.
+func New(store store.IStore, authz *authz.Authz) *consistencyBiz {
+	return &consistencyBiz{store: store, authz: authz}
+}
+
+// Check 检查全部租户的策略，修复时删除问题规则后从数据库重新加载策略并通知其他实例
+func (b *consistencyBiz) Check(ctx context.Context, rq *apiv1.CheckConsistencyRequest) (*apiv1.CheckConsistencyResponse, error) {
+	db := b.store.DB(ctx)
+	rules, err := loadRules(db)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	rel, err := loadRelations(db, rules)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	issues := analyze(rules, rel)
+	resp := &apiv1.CheckConsistencyResponse{RulesChecked: int64(len(rules)), Issues: issues}
+	if !rq.Fix {
+		return resp, nil
+	}
+
+	var ids []int64
+	for _, issue := range issues {
+		if issue.Fixable {
+			ids = append(ids, issue.RuleId)
+		}
+	}
+	if len(ids) == 0 {
+		return resp, nil
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		return inBatches(ids, func(batch []int64) error {
+			return b.store.DB(ctx).Where("id IN ?", batch).Delete(&model.CasbinRuleM{}).Error
+		})
+	})
+	if err != nil {
+		return nil, errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	if err := b.authz.Refresh(); err != nil {
+		return nil, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+	}
+	resp.Fixed = int64(len(ids))
+
+	log.W(ctx).Infow("Inconsistent Casbin rules removed", "count", len(ids), "issues", len(issues))
+	return resp, nil
+}
+
+// loadRules 按ID顺序加载全部规则，重复的规则保留ID最小的一条
+func loadRules(db *gorm.DB) ([]*policyRule, error) {
+	var rows []*model.CasbinRuleM
+	if err := db.Order("id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	rules := make([]*policyRule, 0, len(rows))
+	for _, row := range rows {
+		rule := &policyRule{ID: row.ID, PType: deref(row.PType)}
+		rule.Values = []string{deref(row.V0), deref(row.V1), deref(row.V2), deref(row.V3), deref(row.V4), deref(row.V5)}
+		for len(rule.Values) > 0 && rule.Values[len(rule.Values)-1] == "" {
+			rule.Values = rule.Values[:len(rule.Values)-1]
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// loadRelations 加载检查需要的关系数据，用户和成员关系只加载规则中出现的用户
+func loadRelations(db *gorm.DB, rules []*policyRule) (*relations, error) {
+	rel := &relations{
+		tenants:     make(map[int64]bool),
+		users:       make(map[int64]bool),
+		roles:       make(map[int64]int64),
+		permissions: make(map[int64]int64),
+		memberships: make(map[[2]int64]bool),
+	}
+
+	var tenantIDs []int64
+	if err := db.Table("tenants").Where("deleted_at IS NULL").Pluck("id", &tenantIDs).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range tenantIDs {
+		rel.tenants[id] = true
+	}
+
+	var owned []struct {
+		ID       int64 `gorm:"column:id"`
+		TenantID int64 `gorm:"column:tenant_id"`
+	}
+	if err := db.Table("roles").Select("id, tenant_id").Where("deleted_at IS NULL").Find(&owned).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range owned {
+		rel.roles[r.ID] = r.TenantID
+	}
+	owned = owned[:0]
+	if err := db.Table("permissions").Select("id, tenant_id").Where("deleted_at IS NULL").Find(&owned).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range owned {
+		rel.permissions[p.ID] = p.TenantID
+	}
+
+	// 规则中出现的用户
+	converter := authz.NewIDConverter()
+	seen := make(map[int64]bool)
+	var userIDs []int64
+	for _, rule := range rules {
+		if len(rule.Values) > 0 && strings.HasPrefix(rule.Values[0], authz.PrefixUserID) {
+			if id := converter.ToUserID(rule.Values[0]); id > 0 && !seen[id] {
+				seen[id] = true
+				userIDs = append(userIDs, id)
+			}
+		}
+	}
+	err := inBatches(userIDs, func(batch []int64) error {
+		var existing []int64
+		if err := db.Table("user").Where("id IN ? AND deleted_at IS NULL", batch).Pluck("id", &existing).Error; err != nil {
+			return err
+		}
+		for _, id := range existing {
+			rel.users[id] = true
+		}
+
+		var members []struct {
+			UserID   int64 `gorm:"column:user_id"`
+			TenantID int64 `gorm:"column:tenant_id"`
+		}
+		if err := db.Table("user_tenants").Select("user_id, tenant_id").
+			Where("user_id IN ? AND deleted_at IS NULL", batch).Find(&members).Error; err != nil {
+			return err
+		}
+		for _, m := range members {
+			rel.memberships[[2]int64{m.UserID, m.TenantID}] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Table("user").
+		Where("deleted_at IS NULL AND NOT EXISTS (?)",
+			db.Table("user_tenants").Select("1").Where("user_tenants.user_id = user.id AND user_tenants.deleted_at IS NULL")).
+		Order("id").
+		Pluck("id", &rel.usersWithoutTenant).Error
+	if err != nil {
+		return nil, err
+	}
+	return rel, nil
+}
+
+// inBatches 将ID分批交给 fn 处理
+func inBatches(ids []int64, fn func(batch []int64) error) error {
+	for start := 0; start < len(ids); start += batchSize {
+		end := min(start+batchSize, len(ids))
+		if err := fn(ids[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deref 返回字符串指针的值，nil 时返回空字符串
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
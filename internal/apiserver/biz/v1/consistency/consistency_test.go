@@ -0,0 +1,63 @@
+package consistency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rule(id int64, ptype string, values ...string) *policyRule {
+	return &policyRule{ID: id, PType: ptype, Values: values}
+}
+
+func TestAnalyze(t *testing.T) {
+	rel := &relations{
+		tenants:            map[int64]bool{1: true, 2: true},
+		users:              map[int64]bool{10: true, 11: true},
+		roles:              map[int64]int64{2: 1, 3: 1, 9: 2},
+		permissions:        map[int64]int64{30: 1, 40: 2},
+		memberships:        map[[2]int64]bool{{10, 1}: true},
+		usersWithoutTenant: []int64{11},
+	}
+	rules := []*policyRule{
+		rule(1, "g", "u10", "r2", "t1"),
+		rule(2, "p", "r2", "p30", "t1", "allow"),
+		rule(3, "p", "r2", "a30", "t1"),         // 与 2 指向同一权限
+		rule(4, "g", "u10", "r7", "t1"),         // 角色已删除
+		rule(5, "g", "u99", "r2", "t1"),         // 用户已删除
+		rule(6, "g", "u10", "r9", "t1"),         // 角色属于其他租户
+		rule(7, "p", "r8", "p30", "t1"),         // 主体角色已删除
+		rule(8, "p", "r2", "p40", "t1"),         // 权限属于其他租户
+		rule(9, "g", "x10", "r2", "t1"),         // 未知前缀
+		rule(10, "g", "u10", "r2", "t5"),        // 租户不存在
+		rule(11, "g", "u11", "r3", "t1"),        // 用户不是租户成员
+		rule(12, "p", "r2", "p30", "t1", "yes"), // 效果非法
+		rule(13, "g", "r3", "r2", "t1"),         // 角色继承
+		rule(14, "g", "u10"),
+	}
+
+	issues := analyze(rules, rel)
+	kinds := make(map[int64]string)
+	for _, issue := range issues {
+		kinds[issue.RuleId] = issue.Kind
+	}
+	assert.Equal(t, map[int64]string{
+		0:  IssueUserWithoutTenant,
+		3:  IssueDuplicate,
+		4:  IssueDanglingRule,
+		5:  IssueOrphanedRule,
+		6:  IssueDanglingRule,
+		7:  IssueOrphanedRule,
+		8:  IssueDanglingRule,
+		9:  IssueUnknownPrefix,
+		10: IssueUnknownTenant,
+		11: IssueUserNotInTenant,
+		12: IssueMalformedRule,
+		14: IssueMalformedRule,
+	}, kinds)
+
+	last := issues[len(issues)-1]
+	assert.Equal(t, int64(11), last.UserId)
+	assert.False(t, last.Fixable)
+	assert.Equal(t, "p, r2, a30, t1", issues[0].Rule)
+}
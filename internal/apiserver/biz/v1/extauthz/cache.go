@@ -0,0 +1,113 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package extauthz
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ashwinyue/one-auth/pkg/errorsx"
+)
+
+// verifyResult 是一次网关鉴权的结果，拒绝访问的结果同样缓存
+type verifyResult struct {
+	key       string
+	identity  *Identity
+	err       error
+	expiresAt time.Time
+}
+
+// VerifyCache 缓存网关鉴权结果，避免同一令牌访问同一路由时重复查询用户和租户.
+// 缓存键包含凭证、租户请求头、客户端IP、请求方法和路径，授权器内部错误不缓存.
+type VerifyCache struct {
+	size int
+	ttl  time.Duration
+
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+}
+
+// NewVerifyCache 创建鉴权结果缓存，size 或 ttl 不大于 0 时返回 nil，表示不缓存.
+func NewVerifyCache(size int, ttl time.Duration) *VerifyCache {
+	if size <= 0 || ttl <= 0 {
+		return nil
+	}
+	return &VerifyCache{size: size, ttl: ttl, order: list.New(), items: make(map[string]*list.Element, size)}
+}
+
+// Verify 优先返回缓存的鉴权结果，未命中时调用 biz 鉴权并缓存结果.
+func (c *VerifyCache) Verify(ctx context.Context, biz ExtAuthzBiz, rq *VerifyRequest) (*Identity, error) {
+	if c == nil {
+		return biz.Verify(ctx, rq)
+	}
+
+	key := verifyKey(rq)
+	if result, ok := c.get(key); ok {
+		return result.identity, result.err
+	}
+
+	id, err := biz.Verify(ctx, rq)
+	if err == nil || errorsx.Code(err) < http.StatusInternalServerError {
+		c.add(&verifyResult{key: key, identity: id, err: err, expiresAt: time.Now().Add(c.ttl)})
+	}
+	return id, err
+}
+
+// get 返回未过期的缓存结果
+func (c *VerifyCache) get(key string) (*verifyResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	result := elem.Value.(*verifyResult)
+	if time.Now().After(result.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return result, true
+}
+
+// add 写入缓存，超过容量时淘汰最久未使用的结果
+func (c *VerifyCache) add(result *verifyResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[result.key]; ok {
+		elem.Value = result
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.items[result.key] = c.order.PushFront(result)
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*verifyResult).key)
+	}
+}
+
+// verifyKey 返回鉴权结果的缓存键，使用哈希避免在内存中以明文作为键保存令牌
+func verifyKey(rq *VerifyRequest) string {
+	h := sha256.New()
+	for _, part := range []string{
+		rq.Headers["authorization"], rq.Headers[HeaderAPIKey], rq.Headers[HeaderTenantID], rq.IP, rq.Method, rq.Path,
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
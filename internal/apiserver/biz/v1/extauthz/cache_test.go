@@ -0,0 +1,80 @@
+package extauthz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+)
+
+type fakeBiz struct {
+	calls int
+	err   error
+}
+
+func (f *fakeBiz) Check(context.Context, *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeBiz) Verify(context.Context, *VerifyRequest) (*Identity, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &Identity{UserID: 1, TenantID: 2}, nil
+}
+
+func verifyRequest(token, path string) *VerifyRequest {
+	return &VerifyRequest{Method: "GET", Path: path, Headers: map[string]string{"authorization": "Bearer " + token}}
+}
+
+func TestVerifyCache(t *testing.T) {
+	ctx := context.Background()
+	b := &fakeBiz{}
+	c := NewVerifyCache(2, time.Minute)
+
+	id, err := c.Verify(ctx, b, verifyRequest("a", "/v1/orders"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id.UserID)
+	_, _ = c.Verify(ctx, b, verifyRequest("a", "/v1/orders"))
+	assert.Equal(t, 1, b.calls)
+
+	// 不同令牌或路径不共用结果，超过容量时淘汰最久未使用的结果
+	_, _ = c.Verify(ctx, b, verifyRequest("b", "/v1/orders"))
+	_, _ = c.Verify(ctx, b, verifyRequest("a", "/v1/users"))
+	_, _ = c.Verify(ctx, b, verifyRequest("a", "/v1/orders"))
+	assert.Equal(t, 4, b.calls)
+}
+
+func TestVerifyCache_Errors(t *testing.T) {
+	ctx := context.Background()
+	c := NewVerifyCache(10, time.Minute)
+
+	// 拒绝访问的结果被缓存
+	b := &fakeBiz{err: errno.ErrPermissionDenied}
+	_, _ = c.Verify(ctx, b, verifyRequest("a", "/v1/orders"))
+	_, err := c.Verify(ctx, b, verifyRequest("a", "/v1/orders"))
+	assert.ErrorIs(t, err, errno.ErrPermissionDenied)
+	assert.Equal(t, 1, b.calls)
+
+	// 内部错误不缓存
+	b = &fakeBiz{err: errno.ErrInternal}
+	_, _ = c.Verify(ctx, b, verifyRequest("b", "/v1/orders"))
+	_, _ = c.Verify(ctx, b, verifyRequest("b", "/v1/orders"))
+	assert.Equal(t, 2, b.calls)
+}
+
+func TestVerifyCache_Disabled(t *testing.T) {
+	assert.Nil(t, NewVerifyCache(0, time.Minute))
+	assert.Nil(t, NewVerifyCache(10, 0))
+
+	b := &fakeBiz{}
+	var c *VerifyCache
+	_, _ = c.Verify(context.Background(), b, verifyRequest("a", "/v1/orders"))
+	_, _ = c.Verify(context.Background(), b, verifyRequest("a", "/v1/orders"))
+	assert.Equal(t, 2, b.calls)
+}
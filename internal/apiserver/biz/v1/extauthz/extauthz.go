@@ -0,0 +1,339 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package extauthz
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/cache"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	"github.com/ashwinyue/one-auth/pkg/authn"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/core"
+	"github.com/ashwinyue/one-auth/pkg/errorsx"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+	"github.com/ashwinyue/one-auth/pkg/token"
+)
+
+// 网关转发请求时使用的请求头，名称均为小写.
+const (
+	// HeaderUserID 是注入上游的用户ID
+	HeaderUserID = "x-user-id"
+	// HeaderTenantID 是客户端选择的租户ID，也是注入上游的租户ID
+	HeaderTenantID = "x-tenant-id"
+	// HeaderRoles 是注入上游的角色标识，多个角色以逗号分隔，包括继承的角色
+	HeaderRoles = "x-roles"
+	// HeaderServiceClientID 是注入上游的服务凭证ID，只在使用 API Key 认证时注入
+	HeaderServiceClientID = "x-service-client-id"
+	// HeaderAPIKey 是客户端传入 API Key 的请求头
+	HeaderAPIKey = "x-api-key"
+)
+
+// identityHeaders 是注入上游的身份请求头
+var identityHeaders = []string{HeaderUserID, HeaderTenantID, HeaderRoles, HeaderServiceClientID}
+
+// ExtAuthzBiz 定义了网关外部授权的业务逻辑接口，供 Envoy ext_authz 和 nginx/Traefik 转发认证使用.
+type ExtAuthzBiz interface {
+	Check(ctx context.Context, rq *authv3.CheckRequest) (*authv3.CheckResponse, error)
+	Verify(ctx context.Context, rq *VerifyRequest) (*Identity, error)
+}
+
+// extAuthzBiz 是 ExtAuthzBiz 接口的实现.
+type extAuthzBiz struct {
+	store    store.IStore
+	authz    *authz.Authz
+	sessions *cache.SessionManager
+}
+
+// 确保 extAuthzBiz 实现了 ExtAuthzBiz 接口.
+var _ ExtAuthzBiz = (*extAuthzBiz)(nil)
+
+// New 创建一个新的 ExtAuthzBiz 实例.
+func New(store store.IStore, authz *authz.Authz, sessions *cache.SessionManager) *extAuthzBiz {
+	return &extAuthzBiz{store: store, authz: authz, sessions: sessions}
+}
+
+// VerifyRequest 表示网关转发的一次原始请求，不包含请求体
+type VerifyRequest struct {
+	Method  string
+	Path    string            // 请求路径，不含查询参数
+	IP      string            // 客户端IP
+	Headers map[string]string // 请求头，名称为小写
+}
+
+// Identity 是认证通过的调用方
+type Identity struct {
+	UserID          int64
+	TenantID        int64
+	Roles           []string // 角色标识，包括继承的角色
+	ServiceClientID int64    // 使用 API Key 认证时的服务凭证ID
+	Scopes          []string // 服务凭证授权访问的权限编码，不注入上游
+}
+
+// Headers 返回注入上游的身份请求头，没有值的请求头不返回.
+func (id *Identity) Headers() map[string]string {
+	headers := make(map[string]string, len(identityHeaders))
+	for key, value := range map[string]string{
+		HeaderUserID:          formatID(id.UserID),
+		HeaderTenantID:        formatID(id.TenantID),
+		HeaderRoles:           strings.Join(id.Roles, ","),
+		HeaderServiceClientID: formatID(id.ServiceClientID),
+	} {
+		if value != "" {
+			headers[key] = value
+		}
+	}
+	return headers
+}
+
+// Check 认证 Envoy 转发的请求并按路由检查访问权限.
+// 认证或授权失败时返回带 401/403 响应体的拒绝结果；授权器内部错误时返回 gRPC 错误，
+// 由 Envoy 的 failure_mode_allow 决定是否放行.
+func (b *extAuthzBiz) Check(ctx context.Context, rq *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	httpReq := rq.GetAttributes().GetRequest().GetHttp()
+	path, _, _ := strings.Cut(httpReq.GetPath(), "?")
+
+	id, err := b.Verify(ctx, &VerifyRequest{
+		Method:  httpReq.GetMethod(),
+		Path:    path,
+		IP:      rq.GetAttributes().GetSource().GetAddress().GetSocketAddress().GetAddress(),
+		Headers: httpReq.GetHeaders(),
+	})
+	if err != nil {
+		if errorsx.Code(err) >= http.StatusInternalServerError {
+			return nil, err
+		}
+		return denied(err), nil
+	}
+	return allowed(id), nil
+}
+
+// Verify 认证请求中的 Bearer 令牌或 API Key，并按路由检查用户或服务凭证的访问权限.
+// 返回的错误为 401/403 时表示拒绝访问，授权器内部错误时为 500.
+func (b *extAuthzBiz) Verify(ctx context.Context, rq *VerifyRequest) (*Identity, error) {
+	id, err := b.authenticate(ctx, rq.Headers)
+	if err != nil {
+		log.W(ctx).Debugw("Gateway authentication failed", "method", rq.Method, "path", rq.Path, "err", err)
+		return nil, err
+	}
+
+	rc := &authz.RequestContext{
+		IP:     rq.IP,
+		Method: rq.Method,
+		Path:   rq.Path,
+		Route:  rq.Path,
+		Time:   time.Now(),
+	}
+
+	// API Key 按凭证授权的权限编码检查路由权限
+	if id.ServiceClientID > 0 {
+		return b.authorizeService(ctx, id, rc)
+	}
+
+	userID := strconv.FormatInt(id.UserID, 10)
+	tenantID := strconv.FormatInt(id.TenantID, 10)
+	decision, err := b.authz.CheckRouteAccess(userID, tenantID, rc)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check route access", "user_id", id.UserID, "tenant_id", id.TenantID, "path", rq.Path, "err", err)
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+	if !decision.Allowed {
+		log.W(ctx).Infow("Gateway authorization denied", "user_id", id.UserID, "tenant_id", id.TenantID, "method", rq.Method, "path", rq.Path, "decision", decision.String())
+		return nil, errorsx.New(errno.ErrPermissionDenied.Code, errno.ErrPermissionDenied.Reason, "access denied")
+	}
+
+	id.Roles = b.authz.GetImplicitRolesForUser(userID, tenantID)
+	log.W(ctx).Debugw("Gateway authorize decision", "user_id", id.UserID, "tenant_id", id.TenantID, "path", rq.Path, "rule", decision.String())
+	return id, nil
+}
+
+// authorizeService 按服务凭证授权的权限编码检查路由的访问权限
+func (b *extAuthzBiz) authorizeService(ctx context.Context, id *Identity, rc *authz.RequestContext) (*Identity, error) {
+	var domain string
+	if id.TenantID > 0 {
+		domain = strconv.FormatInt(id.TenantID, 10)
+	}
+	decision, err := b.authz.CheckServiceRouteAccess(id.Scopes, domain, rc)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check service route access", "client_id", id.ServiceClientID, "tenant_id", id.TenantID, "path", rc.Path, "err", err)
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+	if !decision.Allowed {
+		log.W(ctx).Infow("Gateway authorization denied", "client_id", id.ServiceClientID, "tenant_id", id.TenantID, "method", rc.Method, "path", rc.Path, "decision", decision.String())
+		return nil, errorsx.New(errno.ErrPermissionDenied.Code, errno.ErrPermissionDenied.Reason, "access denied")
+	}
+
+	log.W(ctx).Debugw("Gateway authorize decision", "client_id", id.ServiceClientID, "tenant_id", id.TenantID, "path", rc.Path, "rule", decision.String())
+	return id, nil
+}
+
+// authenticate 校验 Bearer 令牌或 API Key，并确定请求使用的租户
+func (b *extAuthzBiz) authenticate(ctx context.Context, headers map[string]string) (*Identity, error) {
+	if apiKey := strings.TrimSpace(headers[HeaderAPIKey]); apiKey != "" {
+		return b.authenticateService(ctx, apiKey, headers[HeaderTenantID])
+	}
+
+	bearer, ok := strings.CutPrefix(headers["authorization"], "Bearer ")
+	bearer = strings.TrimSpace(bearer)
+	if !ok || bearer == "" {
+		return nil, errno.ErrUnauthenticated.WithMessage("missing bearer token or API key")
+	}
+	if strings.HasPrefix(bearer, authn.ServiceTokenPrefix) {
+		return b.authenticateService(ctx, bearer, headers[HeaderTenantID])
+	}
+
+	claims, err := token.ParseTokenClaims(bearer)
+	if err != nil {
+		return nil, errno.ErrTokenInvalid.WithMessage(err.Error())
+	}
+	userID := claims.Identity
+
+	// 与认证中间件一致，拒绝已撤销的令牌
+	revoked, err := b.sessions.IsTokenRevoked(ctx, userID, claims.IssuedAt)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check token revocation", "user_id", userID, "err", err)
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+	if revoked {
+		return nil, errno.ErrTokenRevoked
+	}
+
+	user, err := b.store.User().Get(ctx, where.F("id", userID))
+	if err != nil {
+		return nil, errno.ErrUnauthenticated.WithMessage("user not found")
+	}
+
+	tenantID, err := b.resolveTenant(ctx, userID, headers[HeaderTenantID])
+	if err != nil {
+		return nil, err
+	}
+
+	// 拒绝在所选租户内被停用的用户
+	active, err := b.store.User().IsUserActive(ctx, userID, tenantID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check user status", "user_id", userID, "tenant_id", tenantID, "err", err)
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+	if !active {
+		return nil, errno.ErrUnauthenticated.WithMessage("user is disabled")
+	}
+	return &Identity{UserID: user.ID, TenantID: tenantID}, nil
+}
+
+// resolveTenant 使用客户端选择的租户，未选择时使用用户的主租户
+func (b *extAuthzBiz) resolveTenant(ctx context.Context, userID, requested string) (int64, error) {
+	if requested == "" {
+		tenantID, err := b.store.User().GetUserTenantID(ctx, userID)
+		if err != nil || tenantID <= 0 {
+			return 0, errno.ErrPermissionDenied.WithMessage("user does not belong to any tenant")
+		}
+		return tenantID, nil
+	}
+
+	tenantID, err := strconv.ParseInt(requested, 10, 64)
+	if err != nil || tenantID <= 0 {
+		return 0, errno.ErrInvalidArgument.WithMessage("invalid %s header", HeaderTenantID)
+	}
+	ok, err := b.store.Tenant().CheckUserTenant(ctx, userID, tenantID)
+	if err != nil || !ok {
+		return 0, errno.ErrPermissionDenied.WithMessage("user does not belong to tenant %d", tenantID)
+	}
+	return tenantID, nil
+}
+
+// authenticateService 校验服务凭证，凭证只能访问其限定的租户
+func (b *extAuthzBiz) authenticateService(ctx context.Context, secret, requested string) (*Identity, error) {
+	client, err := b.store.ServiceClient().Get(ctx, where.F("token_hash", authn.HashServiceToken(secret)))
+	if err != nil {
+		return nil, errno.ErrUnauthenticated.WithMessage("invalid API key")
+	}
+	now := time.Now()
+	if client.ExpiresAt != nil && now.After(*client.ExpiresAt) {
+		return nil, errno.ErrUnauthenticated.WithMessage("API key expired")
+	}
+
+	// 服务凭证只能访问凭证限定的租户，不能通过请求头选择其他租户
+	id := &Identity{ServiceClientID: client.ID, TenantID: client.TenantID, Scopes: client.GetScopes()}
+	if requested != "" {
+		tenantID, err := strconv.ParseInt(requested, 10, 64)
+		if err != nil || tenantID <= 0 {
+			return nil, errno.ErrInvalidArgument.WithMessage("invalid %s header", HeaderTenantID)
+		}
+		if client.TenantID != tenantID {
+			return nil, errno.ErrPermissionDenied.WithMessage("API key cannot access tenant %d", tenantID)
+		}
+	}
+
+	// 最后使用时间最多每分钟更新一次
+	if client.LastUsedAt == nil || now.Sub(*client.LastUsedAt) > time.Minute {
+		if err := b.store.ServiceClient().TouchLastUsed(ctx, client.ID, now); err != nil {
+			log.W(ctx).Errorw("Failed to update service client last used time", "client_id", client.ID, "err", err)
+		}
+	}
+	return id, nil
+}
+
+// allowed 返回放行结果，覆盖客户端传入的同名请求头，没有值的身份请求头从请求中移除
+func allowed(id *Identity) *authv3.CheckResponse {
+	ok := &authv3.OkHttpResponse{}
+	headers := id.Headers()
+	for _, key := range identityHeaders {
+		value, found := headers[key]
+		if !found {
+			ok.HeadersToRemove = append(ok.HeadersToRemove, key)
+			continue
+		}
+		ok.Headers = append(ok.Headers, &corev3.HeaderValueOption{
+			Header:       &corev3.HeaderValue{Key: key, Value: value},
+			AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		})
+	}
+
+	return &authv3.CheckResponse{
+		Status:       &rpcstatus.Status{Code: int32(codes.OK)},
+		HttpResponse: &authv3.CheckResponse_OkResponse{OkResponse: ok},
+	}
+}
+
+// denied 返回拒绝结果，响应体与 HTTP 接口的错误响应格式相同
+func denied(err error) *authv3.CheckResponse {
+	errx := errorsx.FromError(err)
+	body, _ := json.Marshal(core.ErrorResponse{Reason: errx.Reason, Message: errx.Message, Metadata: errx.Metadata})
+
+	return &authv3.CheckResponse{
+		Status: &rpcstatus.Status{Code: int32(errx.GRPCStatus().Code()), Message: errx.Message},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{DeniedResponse: &authv3.DeniedHttpResponse{
+			Status: &typev3.HttpStatus{Code: typev3.StatusCode(errx.Code)},
+			Headers: []*corev3.HeaderValueOption{{
+				Header:       &corev3.HeaderValue{Key: "content-type", Value: "application/json"},
+				AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+			}},
+			Body: string(body),
+		}},
+	}
+}
+
+// formatID 格式化ID，为 0 时返回空字符串
+func formatID(id int64) string {
+	if id <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,207 @@
+package extauthz
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/cache"
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	_ "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authn"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/core"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+	"github.com/ashwinyue/one-auth/pkg/token"
+)
+
+func headerValues(t *testing.T, options []*corev3.HeaderValueOption) map[string]string {
+	values := make(map[string]string, len(options))
+	for _, o := range options {
+		assert.Equal(t, corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD, o.GetAppendAction())
+		values[o.GetHeader().GetKey()] = o.GetHeader().GetValue()
+	}
+	return values
+}
+
+func TestAllowed(t *testing.T) {
+	resp := allowed(&Identity{UserID: 7, TenantID: 2, Roles: []string{"admin", "user"}})
+	assert.Equal(t, int32(codes.OK), resp.GetStatus().GetCode())
+	assert.Equal(t, map[string]string{
+		HeaderUserID:   "7",
+		HeaderTenantID: "2",
+		HeaderRoles:    "admin,user",
+	}, headerValues(t, resp.GetOkResponse().GetHeaders()))
+	// 客户端伪造的服务凭证ID被移除
+	assert.Equal(t, []string{HeaderServiceClientID}, resp.GetOkResponse().GetHeadersToRemove())
+
+	resp = allowed(&Identity{ServiceClientID: 3})
+	assert.Equal(t, map[string]string{HeaderServiceClientID: "3"}, headerValues(t, resp.GetOkResponse().GetHeaders()))
+	assert.ElementsMatch(t, []string{HeaderUserID, HeaderTenantID, HeaderRoles}, resp.GetOkResponse().GetHeadersToRemove())
+}
+
+func TestDenied(t *testing.T) {
+	resp := denied(errno.ErrTokenInvalid)
+	assert.Equal(t, int32(codes.Unauthenticated), resp.GetStatus().GetCode())
+	assert.Equal(t, typev3.StatusCode_Unauthorized, resp.GetDeniedResponse().GetStatus().GetCode())
+
+	var body core.ErrorResponse
+	require.NoError(t, json.Unmarshal([]byte(resp.GetDeniedResponse().GetBody()), &body))
+	assert.Equal(t, errno.ErrTokenInvalid.Reason, body.Reason)
+
+	resp = denied(errno.ErrPermissionDenied.WithMessage("access denied"))
+	assert.Equal(t, int32(codes.PermissionDenied), resp.GetStatus().GetCode())
+	assert.Equal(t, typev3.StatusCode_Forbidden, resp.GetDeniedResponse().GetStatus().GetCode())
+}
+
+func TestCheck_MissingCredentials(t *testing.T) {
+	b := New(nil, nil, nil)
+	resp, err := b.Check(context.Background(), &authv3.CheckRequest{
+		Attributes: &authv3.AttributeContext{Request: &authv3.AttributeContext_Request{
+			Http: &authv3.AttributeContext_HttpRequest{Method: "GET", Path: "/v1/orders", Headers: map[string]string{"authorization": "Basic abc"}},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, typev3.StatusCode_Unauthorized, resp.GetDeniedResponse().GetStatus().GetCode())
+}
+
+type fakeServiceClientStore struct {
+	store.ServiceClientStore
+	clients map[string]*model.ServiceClientM
+}
+
+func (f *fakeServiceClientStore) Get(_ context.Context, opts *where.Options) (*model.ServiceClientM, error) {
+	if client, ok := f.clients[opts.Filters["token_hash"].(string)]; ok {
+		return client, nil
+	}
+	return nil, errno.ErrNotFound
+}
+
+func (f *fakeServiceClientStore) TouchLastUsed(context.Context, int64, time.Time) error {
+	return nil
+}
+
+type fakeStore struct {
+	store.IStore
+	clients *fakeServiceClientStore
+}
+
+func (f *fakeStore) ServiceClient() store.ServiceClientStore {
+	return f.clients
+}
+
+func TestVerify_ServiceClientScopes(t *testing.T) {
+	now := time.Now()
+	b := New(&fakeStore{clients: &fakeServiceClientStore{clients: map[string]*model.ServiceClientM{
+		authn.HashServiceToken("svc_reader"):   {ID: 1, TenantID: 2, Scopes: "user:view", LastUsedAt: &now},
+		authn.HashServiceToken("svc_noscope"):  {ID: 2, TenantID: 2, LastUsedAt: &now},
+		authn.HashServiceToken("svc_global"):   {ID: 3, Scopes: "user:view", LastUsedAt: &now},
+		authn.HashServiceToken("svc_unscoped"): {ID: 4, TenantID: 0, LastUsedAt: &now},
+	}}}, new(authz.Authz), nil)
+
+	verify := func(apiKey, method, path, tenantID string) error {
+		headers := map[string]string{HeaderAPIKey: apiKey}
+		if tenantID != "" {
+			headers[HeaderTenantID] = tenantID
+		}
+		_, err := b.Verify(context.Background(), &VerifyRequest{Method: method, Path: path, Headers: headers})
+		return err
+	}
+
+	// 拥有路由权限编码的凭证放行
+	assert.NoError(t, verify("svc_reader", "GET", "/v1/users", ""))
+	assert.NoError(t, verify("svc_reader", "GET", "/v1/users", "2"))
+
+	// 没有授予路由权限编码的凭证被拒绝
+	for _, apiKey := range []string{"svc_noscope", "svc_unscoped"} {
+		err := verify(apiKey, "GET", "/v1/users", "")
+		assert.ErrorIs(t, err, errno.ErrPermissionDenied, apiKey)
+	}
+	assert.ErrorIs(t, verify("svc_reader", "GET", "/v1/posts", ""), errno.ErrPermissionDenied)
+
+	// 凭证不能通过 x-tenant-id 选择其他租户，未限定租户的凭证也不能选择租户
+	assert.ErrorIs(t, verify("svc_reader", "GET", "/v1/users", "3"), errno.ErrPermissionDenied)
+	assert.ErrorIs(t, verify("svc_global", "GET", "/v1/users", "2"), errno.ErrPermissionDenied)
+}
+
+type fakeCache struct {
+	cache.ICache
+	data map[string]string
+}
+
+func (f *fakeCache) Set(_ context.Context, key string, value any, _ time.Duration) error {
+	f.data[key] = value.(string)
+	return nil
+}
+
+func (f *fakeCache) Get(_ context.Context, key string) (string, error) {
+	if value, ok := f.data[key]; ok {
+		return value, nil
+	}
+	return "", redis.Nil
+}
+
+type fakeUserStore struct {
+	store.UserStore
+	active bool
+}
+
+func (f *fakeUserStore) Get(_ context.Context, opts *where.Options) (*model.UserM, error) {
+	id, _ := strconv.ParseInt(opts.Filters["id"].(string), 10, 64)
+	return &model.UserM{ID: id}, nil
+}
+
+func (f *fakeUserStore) GetUserTenantID(context.Context, string) (int64, error) {
+	return 2, nil
+}
+
+func (f *fakeUserStore) IsUserActive(context.Context, string, int64) (bool, error) {
+	return f.active, nil
+}
+
+type fakeUserOnlyStore struct {
+	store.IStore
+	users *fakeUserStore
+}
+
+func (f *fakeUserOnlyStore) User() store.UserStore {
+	return f.users
+}
+
+func TestAuthenticate_DisabledOrRevoked(t *testing.T) {
+	ctx := context.Background()
+	users := &fakeUserStore{active: true}
+	sessions := cache.NewSessionManager(&fakeCache{data: map[string]string{}})
+	b := New(&fakeUserOnlyStore{users: users}, nil, sessions)
+
+	tokenStr, _, err := token.Sign("1")
+	require.NoError(t, err)
+	headers := map[string]string{"authorization": "Bearer " + tokenStr}
+
+	id, err := b.authenticate(ctx, headers)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), id.TenantID)
+
+	// 用户在租户内被停用后拒绝
+	users.active = false
+	_, err = b.authenticate(ctx, headers)
+	assert.ErrorIs(t, err, errno.ErrUnauthenticated)
+
+	// 令牌被撤销后拒绝
+	users.active = true
+	require.NoError(t, sessions.RevokeUserTokens(ctx, "1"))
+	_, err = b.authenticate(ctx, headers)
+	assert.ErrorIs(t, err, errno.ErrTokenRevoked)
+}
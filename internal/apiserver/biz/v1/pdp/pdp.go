@@ -0,0 +1,159 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package pdp
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	pdpv1 "github.com/ashwinyue/one-auth/pkg/api/pdp/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+)
+
+// MaxCheckItems 是一次批量检查最多包含的访问数量
+const MaxCheckItems = 100
+
+// ReasonTenantOutOfScope 表示服务凭证不能查询该租户
+const ReasonTenantOutOfScope = "tenant_out_of_scope"
+
+// PDPBiz 定义了策略决策点的业务逻辑接口，供其他微服务使用服务凭证调用.
+type PDPBiz interface {
+	Check(ctx context.Context, rq *pdpv1.CheckRequest) (*pdpv1.CheckResponse, error)
+	ListAllowedActions(ctx context.Context, rq *pdpv1.ListAllowedActionsRequest) (*pdpv1.ListAllowedActionsResponse, error)
+	ListAccessibleResources(ctx context.Context, rq *pdpv1.ListAccessibleResourcesRequest) (*pdpv1.ListAccessibleResourcesResponse, error)
+}
+
+// pdpBiz 是 PDPBiz 接口的实现.
+type pdpBiz struct {
+	authz *authz.Authz
+}
+
+// 确保 pdpBiz 实现了 PDPBiz 接口.
+var _ PDPBiz = (*pdpBiz)(nil)
+
+// New 创建一个新的 PDPBiz 实例.
+func New(authz *authz.Authz) *pdpBiz {
+	return &pdpBiz{authz: authz}
+}
+
+// Check 批量检查用户能否在租户下对资源执行操作
+func (b *pdpBiz) Check(ctx context.Context, rq *pdpv1.CheckRequest) (*pdpv1.CheckResponse, error) {
+	if len(rq.GetItems()) == 0 || len(rq.GetItems()) > MaxCheckItems {
+		return nil, errno.ErrInvalidArgument.WithMessage("items must contain 1 to %d checks", MaxCheckItems)
+	}
+	for i, item := range rq.GetItems() {
+		if err := validateSubject(item.GetUserId(), item.GetTenantId()); err != nil {
+			return nil, err
+		}
+		if item.GetResource() == "" || item.GetAction() == "" {
+			return nil, errno.ErrInvalidArgument.WithMessage("items[%d]: resource and action are required", i)
+		}
+	}
+
+	resp := &pdpv1.CheckResponse{Results: make([]*pdpv1.CheckResult, 0, len(rq.GetItems()))}
+	var minTTL time.Duration = -1
+	for _, item := range rq.GetItems() {
+		result := &pdpv1.CheckResult{Reason: ReasonTenantOutOfScope}
+		ttl := time.Duration(0)
+		if inScope(ctx, item.GetTenantId()) {
+			decision, decisionTTL, err := b.authz.DecideAction(item.GetUserId(), item.GetTenantId(), item.GetResource(), item.GetAction(), requestContext(item.GetContext()))
+			if err != nil {
+				log.W(ctx).Errorw("Failed to check access", "user_id", item.GetUserId(), "tenant_id", item.GetTenantId(),
+					"resource", item.GetResource(), "action", item.GetAction(), "err", err)
+				return nil, errno.ErrInternal.WithMessage(err.Error())
+			}
+			result = &pdpv1.CheckResult{Allowed: decision.Allowed, Reason: decision.String()}
+			ttl = decisionTTL
+		}
+		result.TtlSeconds = int64(ttl / time.Second)
+		if minTTL < 0 || ttl < minTTL {
+			minTTL = ttl
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	resp.TtlSeconds = int64(minTTL / time.Second)
+
+	log.W(ctx).Debugw("PDP check", "client_id", contextx.ServiceClientID(ctx), "items", len(rq.GetItems()))
+	return resp, nil
+}
+
+// ListAllowedActions 列出用户在租户下可以对资源执行的操作
+func (b *pdpBiz) ListAllowedActions(ctx context.Context, rq *pdpv1.ListAllowedActionsRequest) (*pdpv1.ListAllowedActionsResponse, error) {
+	if err := validateSubject(rq.GetUserId(), rq.GetTenantId()); err != nil {
+		return nil, err
+	}
+	if rq.GetResource() == "" {
+		return nil, errno.ErrInvalidArgument.WithMessage("resource is required")
+	}
+	if !inScope(ctx, rq.GetTenantId()) {
+		return nil, errno.ErrPermissionDenied.WithMessage("service client cannot query tenant %d", rq.GetTenantId())
+	}
+
+	actions, ttl, err := b.authz.ListAllowedActions(rq.GetUserId(), rq.GetTenantId(), rq.GetResource(), requestContext(rq.GetContext()))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to list allowed actions", "user_id", rq.GetUserId(), "tenant_id", rq.GetTenantId(), "resource", rq.GetResource(), "err", err)
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+	return &pdpv1.ListAllowedActionsResponse{Actions: actions, TtlSeconds: int64(ttl / time.Second)}, nil
+}
+
+// ListAccessibleResources 列出用户在租户下可以访问的资源
+func (b *pdpBiz) ListAccessibleResources(ctx context.Context, rq *pdpv1.ListAccessibleResourcesRequest) (*pdpv1.ListAccessibleResourcesResponse, error) {
+	if err := validateSubject(rq.GetUserId(), rq.GetTenantId()); err != nil {
+		return nil, err
+	}
+	if !inScope(ctx, rq.GetTenantId()) {
+		return nil, errno.ErrPermissionDenied.WithMessage("service client cannot query tenant %d", rq.GetTenantId())
+	}
+
+	resources, ttl, err := b.authz.ListAccessibleResources(rq.GetUserId(), rq.GetTenantId(), rq.GetAction(), requestContext(rq.GetContext()))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to list accessible resources", "user_id", rq.GetUserId(), "tenant_id", rq.GetTenantId(), "err", err)
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+
+	resp := &pdpv1.ListAccessibleResourcesResponse{
+		Resources:  make([]*pdpv1.AccessibleResource, 0, len(resources)),
+		TtlSeconds: int64(ttl / time.Second),
+	}
+	for resource, actions := range resources {
+		resp.Resources = append(resp.Resources, &pdpv1.AccessibleResource{Resource: resource, Actions: actions})
+	}
+	sort.Slice(resp.Resources, func(i, j int) bool { return resp.Resources[i].Resource < resp.Resources[j].Resource })
+	return resp, nil
+}
+
+// validateSubject 校验查询的用户和租户
+func validateSubject(userID, tenantID int64) error {
+	if userID <= 0 || tenantID <= 0 {
+		return errno.ErrInvalidArgument.WithMessage("user_id and tenant_id are required")
+	}
+	return nil
+}
+
+// inScope 判断服务凭证能否查询租户，限定了租户的凭证只能查询该租户
+func inScope(ctx context.Context, tenantID int64) bool {
+	scope := contextx.TenantID(ctx)
+	return scope == "" || scope == strconv.FormatInt(tenantID, 10)
+}
+
+// requestContext 转换权限条件求值使用的请求上下文
+func requestContext(c *pdpv1.Context) *authz.RequestContext {
+	rc := &authz.RequestContext{IP: c.GetIp(), Time: time.Now()}
+	if len(c.GetResource()) > 0 {
+		rc.Resource = make(map[string]any, len(c.GetResource()))
+		for k, v := range c.GetResource() {
+			rc.Resource[k] = v
+		}
+	}
+	return rc
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package permission
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// ExplainAccess 返回授权决策的完整轨迹：用户的直接和继承角色、匹配的权限、参与求值的策略规则以及最终结果.
+// 指定 decision_id 时，按授权中间件记录的请求信息重新求值，并同时返回原始决策结果.
+func (b *permissionBiz) ExplainAccess(ctx context.Context, rq *apiv1.ExplainAccessRequest) (*apiv1.ExplainAccessResponse, error) {
+	userID := rq.UserId
+	if userID == 0 {
+		userID = contextx.UserID(ctx)
+	}
+	if userID == 0 {
+		return nil, errno.ErrUnauthenticated.WithMessage("user not found in context")
+	}
+
+	tenantID := rq.TenantId
+	if tenantID == 0 {
+		tenantID = 1 // 默认租户
+		if tid, err := strconv.ParseInt(contextx.TenantID(ctx), 10, 64); err == nil {
+			tenantID = tid
+		}
+	}
+
+	rc := &authz.RequestContext{
+		IP:       contextx.ClientIP(ctx),
+		Method:   strings.ToUpper(rq.Method),
+		Path:     rq.Path,
+		Route:    rq.Route,
+		Time:     time.Now(),
+		Resource: make(map[string]any, len(rq.Resource)),
+	}
+	for k, v := range rq.Resource {
+		rc.Resource[k] = v
+	}
+
+	resp := &apiv1.ExplainAccessResponse{}
+	if rq.DecisionId != "" {
+		record, ok := b.authz.LookupDecision(rq.DecisionId)
+		if !ok {
+			return nil, errno.ErrNotFound.WithMessage("decision %s not found or expired", rq.DecisionId)
+		}
+		if userID, _ = strconv.ParseInt(record.UserID, 10, 64); userID == 0 {
+			return nil, errno.ErrInvalidArgument.WithMessage("decision %s has no user", rq.DecisionId)
+		}
+		tenantID = record.TenantID
+		recorded := record.Request
+		rc = &recorded
+		resp.RecordedDecidedBy = record.Error
+		if record.Decision != nil {
+			resp.RecordedDecidedBy = record.Decision.String()
+		}
+		resp.RecordedAt = timestamppb.New(record.Time)
+	}
+
+	subject := strconv.FormatInt(userID, 10)
+	var trace *authz.Trace
+	var err error
+	switch {
+	case rq.PermissionCode != "" && rq.DecisionId == "":
+		trace, err = b.authz.ExplainPermission(subject, tenantID, rq.PermissionCode, rc)
+	case rc.Method != "" && rc.Path != "":
+		trace, err = b.authz.ExplainAPIAccess(subject, tenantID, rc)
+	default:
+		return nil, errno.ErrInvalidArgument.WithMessage("either method and path, permission_code or decision_id is required")
+	}
+	if err != nil {
+		log.W(ctx).Errorw("Failed to explain access", "user_id", userID, "tenant_id", tenantID, "err", err)
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+
+	resp.UserId = userID
+	resp.TenantId = tenantID
+	resp.Method = trace.Method
+	resp.Path = trace.Path
+	resp.PermissionCode = trace.PermissionCode
+	resp.SuperAdmin = trace.SuperAdmin
+	resp.Allowed = trace.Decision.Allowed
+	resp.Effect = trace.Decision.Effect
+	resp.DecidedBy = trace.Decision.String()
+
+	roleIDs := make([]int64, 0, len(trace.Roles))
+	for _, role := range trace.Roles {
+		roleIDs = append(roleIDs, role.RoleID)
+	}
+	roleNames := make(map[int64]string, len(roleIDs))
+	if len(roleIDs) > 0 {
+		_, roles, err := b.store.Role().List(ctx, where.F("tenant_id", tenantID).Q("id IN ?", roleIDs))
+		if err != nil {
+			log.W(ctx).Errorw("Failed to list roles", "tenant_id", tenantID, "err", err)
+			return nil, errno.ErrDBRead.WithMessage(err.Error())
+		}
+		for _, role := range roles {
+			roleNames[role.ID] = role.Name
+		}
+	}
+	for _, role := range trace.Roles {
+		resp.Roles = append(resp.Roles, &apiv1.TraceRole{
+			RoleId:     role.RoleID,
+			Name:       roleNames[role.RoleID],
+			Inherited:  len(role.Via) > 1,
+			ViaRoleIds: role.Via,
+		})
+	}
+
+	if len(trace.PermissionIDs) > 0 {
+		_, permissions, err := b.store.Permission().List(ctx, where.F("tenant_id", tenantID).Q("id IN ?", trace.PermissionIDs))
+		if err != nil {
+			log.W(ctx).Errorw("Failed to list permissions", "permission_ids", trace.PermissionIDs, "err", err)
+			return nil, errno.ErrDBRead.WithMessage(err.Error())
+		}
+		for _, permission := range permissions {
+			resp.Permissions = append(resp.Permissions, &apiv1.TracePermission{
+				Id:           permission.ID,
+				Name:         permission.Name,
+				ResourcePath: derefString(permission.ResourcePath),
+				HttpMethod:   derefString(permission.HTTPMethod),
+			})
+		}
+	}
+
+	for _, policy := range trace.Policies {
+		resp.Policies = append(resp.Policies, &apiv1.TracePolicy{
+			PermissionId:    policy.PermissionID,
+			Rule:            "p, " + strings.Join(policy.Rule, ", "),
+			Effect:          policy.Effect,
+			Condition:       policy.Condition,
+			ConditionResult: policy.ConditionResult,
+			Applied:         policy.Applied,
+		})
+	}
+	return resp, nil
+}
@@ -10,8 +10,9 @@ package permission
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"strconv"
+	"strings"
 
 	"github.com/ashwinyue/one-auth/internal/apiserver/model"
 	"github.com/ashwinyue/one-auth/internal/apiserver/store"
@@ -22,6 +23,7 @@ import (
 	"github.com/ashwinyue/one-auth/pkg/authz"
 	"github.com/ashwinyue/one-auth/pkg/store/where"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 // PermissionBiz 定义处理权限相关请求所需的方法.
@@ -30,6 +32,11 @@ type PermissionBiz interface {
 	GetUserPermissions(ctx context.Context, rq *apiv1.GetUserPermissionsRequest) (*apiv1.GetUserPermissionsResponse, error)
 	CheckPermissions(ctx context.Context, rq *apiv1.CheckPermissionsRequest) (*apiv1.CheckPermissionsResponse, error)
 	CheckAPIAccess(ctx context.Context, rq *apiv1.CheckAPIAccessRequest) (*apiv1.CheckAPIAccessResponse, error)
+	ExplainAccess(ctx context.Context, rq *apiv1.ExplainAccessRequest) (*apiv1.ExplainAccessResponse, error)
+	PreviewAssignment(ctx context.Context, rq *apiv1.PreviewPermissionAssignmentRequest) (*apiv1.PreviewPermissionAssignmentResponse, error)
+
+	// 权限条件相关
+	UpdateCondition(ctx context.Context, rq *apiv1.UpdatePermissionConditionRequest) (*apiv1.UpdatePermissionConditionResponse, error)
 }
 
 // permissionBiz 是 PermissionBiz 接口的实现.
@@ -68,47 +75,51 @@ func (b *permissionBiz) GetUserPermissions(ctx context.Context, rq *apiv1.GetUse
 		}
 	}
 
-	// 构建用户标识符和租户标识符
-	userIdentifier := fmt.Sprintf("u%d", userID)
-	tenantIdentifier := fmt.Sprintf("t%d", tenantID)
-
-	// 从Casbin获取用户的所有权限（包括通过角色继承的权限）
-	permissions, err := b.authz.GetImplicitPermissionsForUser(userIdentifier, tenantIdentifier)
+	// 从Casbin获取用户的所有权限规则（包括通过角色继承的规则）
+	grants, err := b.authz.GetEffectivePermissionsForUser(userID, tenantID)
 	if err != nil {
-		log.W(ctx).Errorw("Failed to get user permissions", "user_id", userIdentifier, "tenant", tenantIdentifier, "err", err)
+		log.W(ctx).Errorw("Failed to get user permissions", "user_id", userID, "tenant_id", tenantID, "err", err)
 		return nil, errno.ErrDBRead.WithMessage("Failed to get user permissions")
 	}
 
+	// 解析权限规则，deny 规则优先于 allow 规则
+	allowed := make(map[int64]bool)
+	denied := make(map[int64]bool)
+	var permissionIDs []int64
+	sources := make([]*apiv1.PermissionSource, 0, len(grants))
+	for _, grant := range grants {
+		sources = append(sources, &apiv1.PermissionSource{
+			PermissionId: grant.PermissionID,
+			Effect:       grant.Effect,
+			RoleId:       grant.RoleID,
+			Inherited:    grant.Inherited(),
+			ViaRoleIds:   grant.Via,
+		})
+		if grant.Effect == authz.EffectDeny {
+			denied[grant.PermissionID] = true
+			continue
+		}
+		if !allowed[grant.PermissionID] {
+			allowed[grant.PermissionID] = true
+			permissionIDs = append(permissionIDs, grant.PermissionID)
+		}
+	}
+
 	var permissionList []*apiv1.Permission
-	permissionSet := make(map[int64]bool) // 用于去重
-
-	// 解析权限并查询详细信息
-	for _, perm := range permissions {
-		if len(perm) >= 2 {
-			// perm[1] 是权限标识符，格式为 a{id}
-			permissionCode := perm[1]
-			if len(permissionCode) > 1 && permissionCode[0] == 'a' {
-				// 解析权限ID
-				permissionIDStr := permissionCode[1:]
-				if permissionID, err := strconv.ParseInt(permissionIDStr, 10, 64); err == nil {
-					// 避免重复
-					if permissionSet[permissionID] {
-						continue
-					}
-					permissionSet[permissionID] = true
-
-					// 从数据库查询权限详情
-					permissionM, err := b.store.Permission().Get(ctx, where.F("id", permissionID))
-					if err == nil {
-						permissionList = append(permissionList, convertPermissionToAPI(permissionM))
-					}
-				}
-			}
+	for _, permissionID := range permissionIDs {
+		if denied[permissionID] {
+			continue
+		}
+		// 从数据库查询权限详情
+		permissionM, err := b.store.Permission().Get(ctx, where.F("id", permissionID))
+		if err == nil {
+			permissionList = append(permissionList, convertPermissionToAPI(permissionM))
 		}
 	}
 
 	return &apiv1.GetUserPermissionsResponse{
 		Permissions: permissionList,
+		Sources:     sources,
 	}, nil
 }
 
@@ -120,22 +131,46 @@ func (b *permissionBiz) CheckPermissions(ctx context.Context, rq *apiv1.CheckPer
 		return nil, errno.ErrUnauthenticated.WithMessage("无法获取用户信息")
 	}
 
+	// 获取租户信息
+	tenantID := rq.TenantId
+	if tenantID == 0 {
+		contextTenantID := contextx.TenantID(ctx)
+		if contextTenantID != "" {
+			if tid, err := strconv.ParseInt(contextTenantID, 10, 64); err == nil {
+				tenantID = tid
+			}
+		}
+		if tenantID == 0 {
+			tenantID = 1 // 默认租户
+		}
+	}
+	subject := strconv.FormatInt(userID, 10)
+
+	// 权限条件使用的请求上下文
+	rc := &authz.RequestContext{IP: contextx.ClientIP(ctx), Resource: make(map[string]any, len(rq.Resource))}
+	for k, v := range rq.Resource {
+		rc.Resource[k] = v
+	}
+
 	results := make(map[int64]bool)
+	decidedBy := make(map[int64]string)
 
 	// 遍历需要检查的权限ID
 	for _, permissionID := range rq.PermissionIds {
 		// 直接使用权限ID进行权限检查
-		hasPermission, err := b.authz.Enforce(fmt.Sprintf("u%d", userID), fmt.Sprintf("a%d", permissionID), "allow")
+		decision, err := b.authz.DecidePermission(subject, tenantID, permissionID, rc)
 		if err != nil {
 			log.W(ctx).Errorw("Failed to check permission", "user_id", userID, "permission_id", permissionID, "err", err)
 			results[permissionID] = false
-		} else {
-			results[permissionID] = hasPermission
+			continue
 		}
+		results[permissionID] = decision.Allowed
+		decidedBy[permissionID] = decision.String()
 	}
 
 	return &apiv1.CheckPermissionsResponse{
-		Results: results,
+		Results:   results,
+		DecidedBy: decidedBy,
 	}, nil
 }
 
@@ -161,18 +196,8 @@ func (b *permissionBiz) CheckAPIAccess(ctx context.Context, rq *apiv1.CheckAPIAc
 		}
 	}
 
-	// 构建用户标识符和租户标识符
-	userIdentifier := fmt.Sprintf("u%d", userID)
-	tenantIdentifier := fmt.Sprintf("t%d", tenantID)
-
-	// 查询API对应的权限
-	// 根据数据库模型，API路径存储在resource_path字段，HTTP方法存储在http_method字段
-	whereCondition := where.F("resource_path", rq.Path).F("tenant_id", tenantID)
-	if rq.Method != "" {
-		whereCondition = whereCondition.F("http_method", rq.Method)
-	}
-
-	_, permissions, err := b.store.Permission().List(ctx, whereCondition)
+	// 查询API对应的权限，resource_path 支持路由模板和通配规则
+	permissionIDs, err := b.authz.MatchAPIPermissions(tenantID, rq.Method, rq.Path)
 	if err != nil {
 		log.W(ctx).Errorw("Failed to get API permissions",
 			"api_path", rq.Path,
@@ -184,35 +209,67 @@ func (b *permissionBiz) CheckAPIAccess(ctx context.Context, rq *apiv1.CheckAPIAc
 	}
 
 	// 如果没有找到对应的权限配置，默认允许访问
-	if len(permissions) == 0 {
+	if len(permissionIDs) == 0 {
 		return &apiv1.CheckAPIAccessResponse{
 			HasAccess: true,
+			DecidedBy: authz.ReasonNoAPIPermission,
 		}, nil
 	}
 
-	// 检查用户是否有任一权限
-	for _, permission := range permissions {
-		permissionIdentifier := fmt.Sprintf("a%d", permission.ID)
+	// 检查用户的权限规则，任一权限命中 deny 规则即拒绝
+	decision, err := b.authz.DecideAnyPermission(strconv.FormatInt(userID, 10), tenantID, permissionIDs, &authz.RequestContext{
+		IP:     contextx.ClientIP(ctx),
+		Method: rq.Method,
+		Path:   rq.Path,
+	})
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check API permission",
+			"user_id", userID,
+			"permission_ids", permissionIDs,
+			"err", err)
+		return &apiv1.CheckAPIAccessResponse{
+			HasAccess: false,
+		}, nil
+	}
 
-		hasPermission, err := b.authz.Enforce(userIdentifier, permissionIdentifier, tenantIdentifier)
-		if err != nil {
-			log.W(ctx).Errorw("Failed to check API permission",
-				"user_id", userIdentifier,
-				"permission_id", permission.ID,
-				"err", err)
-			continue
-		}
+	return &apiv1.CheckAPIAccessResponse{
+		HasAccess: decision.Allowed,
+		DecidedBy: decision.String(),
+	}, nil
+}
+
+// UpdateCondition 更新当前租户下权限的生效条件，条件为空时清除条件
+func (b *permissionBiz) UpdateCondition(ctx context.Context, rq *apiv1.UpdatePermissionConditionRequest) (*apiv1.UpdatePermissionConditionResponse, error) {
+	if err := authz.ValidateCondition(rq.Condition); err != nil {
+		return nil, errno.ErrInvalidArgument.WithMessage(err.Error())
+	}
+
+	tenantID := int64(1) // 默认租户
+	if tid, err := strconv.ParseInt(contextx.TenantID(ctx), 10, 64); err == nil {
+		tenantID = tid
+	}
 
-		if hasPermission {
-			return &apiv1.CheckAPIAccessResponse{
-				HasAccess: true,
-			}, nil
+	permissionM, err := b.store.Permission().Get(ctx, where.F("id", rq.PermissionId, "tenant_id", tenantID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrInvalidArgument.WithMessage("permission %d not found in current tenant", rq.PermissionId)
 		}
+		log.W(ctx).Errorw("Failed to get permission", "permission_id", rq.PermissionId, "err", err)
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
 	}
 
-	return &apiv1.CheckAPIAccessResponse{
-		HasAccess: false,
-	}, nil
+	permissionM.Condition = nil
+	if condition := strings.TrimSpace(rq.Condition); condition != "" {
+		permissionM.Condition = &condition
+	}
+	if err := b.store.Permission().Update(ctx, permissionM); err != nil {
+		log.W(ctx).Errorw("Failed to update permission condition", "permission_id", rq.PermissionId, "err", err)
+		return nil, errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	b.authz.InvalidateConditions()
+
+	log.W(ctx).Infow("Permission condition updated", "permission_id", rq.PermissionId, "condition", rq.Condition)
+	return &apiv1.UpdatePermissionConditionResponse{Permission: convertPermissionToAPI(permissionM)}, nil
 }
 
 // derefString 解引用字符串指针，如果为nil则返回空字符串
@@ -235,6 +292,7 @@ func convertPermissionToAPI(permissionM *model.PermissionM) *apiv1.Permission {
 		TenantId:    permissionM.TenantID,
 		Name:        permissionM.Name,
 		Description: derefString(permissionM.Description),
+		Condition:   derefString(permissionM.Condition),
 		Status:      status,
 		CreatedAt:   timestamppb.New(permissionM.CreatedAt),
 		UpdatedAt:   timestamppb.New(permissionM.UpdatedAt),
@@ -0,0 +1,218 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package permission
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// PreviewAssignment 预览一组角色权限和用户角色变更的效果，返回每个受影响用户生效权限和可访问菜单的变化.
+// 变更在租户策略的内存副本上求值，不会修改线上策略；权限条件不参与预览.
+func (b *permissionBiz) PreviewAssignment(ctx context.Context, rq *apiv1.PreviewPermissionAssignmentRequest) (*apiv1.PreviewPermissionAssignmentResponse, error) {
+	if len(rq.Changes) == 0 {
+		return nil, errno.ErrInvalidArgument.WithMessage("changes is required")
+	}
+
+	tenantID := rq.TenantId
+	if tenantID == 0 {
+		tenantID = 1 // 默认租户
+		if tid, err := strconv.ParseInt(contextx.TenantID(ctx), 10, 64); err == nil {
+			tenantID = tid
+		}
+	}
+
+	changes := make([]authz.PolicyChange, 0, len(rq.Changes))
+	var roleIDs, permissionIDs []int64
+	for _, change := range rq.Changes {
+		changes = append(changes, authz.PolicyChange{
+			Op:           change.Op,
+			RoleID:       change.RoleId,
+			PermissionID: change.PermissionId,
+			Effect:       change.Effect,
+			UserID:       change.UserId,
+		})
+		roleIDs = append(roleIDs, change.RoleId)
+		if change.PermissionId != 0 {
+			permissionIDs = append(permissionIDs, change.PermissionId)
+		}
+	}
+	if err := b.checkTenantResources(ctx, tenantID, roleIDs, permissionIDs); err != nil {
+		return nil, err
+	}
+
+	users, err := b.authz.SimulatePolicyChanges(tenantID, changes)
+	if err != nil {
+		if errors.Is(err, authz.ErrInvalidPolicyChange) {
+			return nil, errno.ErrInvalidArgument.WithMessage(err.Error())
+		}
+		log.W(ctx).Errorw("Failed to simulate policy changes", "tenant_id", tenantID, "err", err)
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+
+	// 租户的权限和菜单，用于填充差异详情和计算菜单可见性
+	_, permissions, err := b.store.Permission().List(ctx, where.F("tenant_id", tenantID))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	permissionByID := make(map[int64]*model.PermissionM, len(permissions))
+	for _, permission := range permissions {
+		permissionByID[permission.ID] = permission
+	}
+	matrices, err := b.store.MenuPermission().GetMenuPermissionMatrix(ctx, tenantID)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	userIDs := make([]int64, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.UserID)
+	}
+	usernames := make(map[int64]string, len(userIDs))
+	if len(userIDs) > 0 {
+		_, userList, err := b.store.User().List(ctx, where.NewWhere().Q("id IN ?", userIDs))
+		if err != nil {
+			return nil, errno.ErrDBRead.WithMessage(err.Error())
+		}
+		for _, user := range userList {
+			usernames[user.ID] = user.Username
+		}
+	}
+
+	resp := &apiv1.PreviewPermissionAssignmentResponse{TenantId: tenantID}
+	for _, user := range users {
+		before := effectivePermissionSet(user.Before, user.SuperAdminBefore, permissionByID)
+		after := effectivePermissionSet(user.After, user.SuperAdminAfter, permissionByID)
+
+		diff := &apiv1.UserAccessDiff{
+			UserId:           user.UserID,
+			Username:         usernames[user.UserID],
+			SuperAdminBefore: user.SuperAdminBefore,
+			SuperAdminAfter:  user.SuperAdminAfter,
+		}
+		for _, permission := range permissions {
+			switch {
+			case after[permission.ID] && !before[permission.ID]:
+				diff.AddedPermissions = append(diff.AddedPermissions, convertPermissionToAPI(permission))
+			case before[permission.ID] && !after[permission.ID]:
+				diff.RemovedPermissions = append(diff.RemovedPermissions, convertPermissionToAPI(permission))
+			}
+		}
+		for _, matrix := range matrices {
+			visibleBefore, visibleAfter := menuAccessible(matrix, before), menuAccessible(matrix, after)
+			switch {
+			case visibleAfter && !visibleBefore:
+				diff.AddedMenus = append(diff.AddedMenus, convertMenuToAPI(matrix.Menu))
+			case visibleBefore && !visibleAfter:
+				diff.RemovedMenus = append(diff.RemovedMenus, convertMenuToAPI(matrix.Menu))
+			}
+		}
+		resp.Users = append(resp.Users, diff)
+	}
+	return resp, nil
+}
+
+// checkTenantResources 检查角色和权限都属于当前租户
+func (b *permissionBiz) checkTenantResources(ctx context.Context, tenantID int64, roleIDs, permissionIDs []int64) error {
+	if len(roleIDs) > 0 {
+		_, roles, err := b.store.Role().List(ctx, where.F("tenant_id", tenantID).Q("id IN ?", roleIDs))
+		if err != nil {
+			return errno.ErrDBRead.WithMessage(err.Error())
+		}
+		found := make(map[int64]bool, len(roles))
+		for _, role := range roles {
+			found[role.ID] = true
+		}
+		for _, id := range roleIDs {
+			if !found[id] {
+				return errno.ErrInvalidArgument.WithMessage("role %d not found in current tenant", id)
+			}
+		}
+	}
+	if len(permissionIDs) > 0 {
+		_, permissions, err := b.store.Permission().List(ctx, where.F("tenant_id", tenantID).Q("id IN ?", permissionIDs))
+		if err != nil {
+			return errno.ErrDBRead.WithMessage(err.Error())
+		}
+		found := make(map[int64]bool, len(permissions))
+		for _, permission := range permissions {
+			found[permission.ID] = true
+		}
+		for _, id := range permissionIDs {
+			if !found[id] {
+				return errno.ErrInvalidArgument.WithMessage("permission %d not found in current tenant", id)
+			}
+		}
+	}
+	return nil
+}
+
+// effectivePermissionSet 返回生效权限集合，超级管理员拥有租户的全部权限
+func effectivePermissionSet(permissionIDs []int64, superAdmin bool, all map[int64]*model.PermissionM) map[int64]bool {
+	set := make(map[int64]bool, len(permissionIDs))
+	if superAdmin {
+		for id := range all {
+			set[id] = true
+		}
+		return set
+	}
+	for _, id := range permissionIDs {
+		set[id] = true
+	}
+	return set
+}
+
+// menuAccessible 判断拥有给定权限时菜单是否可访问，与菜单访问检查一致：没有必需权限的菜单始终可访问
+func menuAccessible(matrix *model.MenuPermissionMatrix, permissions map[int64]bool) bool {
+	for _, permission := range matrix.RequiredPermissions {
+		if !permissions[permission.ID] {
+			return false
+		}
+	}
+	return true
+}
+
+// convertMenuToAPI 将菜单模型转换为API响应模型
+func convertMenuToAPI(menuM *model.MenuM) *apiv1.Menu {
+	menu := &apiv1.Menu{
+		Id:        menuM.ID,
+		TenantId:  menuM.TenantID,
+		Title:     menuM.Title,
+		MenuType:  menuM.MenuType,
+		SortOrder: menuM.SortOrder,
+		Visible:   menuM.Visible,
+		CreatedAt: timestamppb.New(menuM.CreatedAt),
+		UpdatedAt: timestamppb.New(menuM.UpdatedAt),
+	}
+	if menuM.Status {
+		menu.Status = 1
+	}
+	if menuM.ParentID != nil {
+		menu.ParentId = *menuM.ParentID
+	}
+	if menuM.RoutePath != nil {
+		menu.RoutePath = *menuM.RoutePath
+	}
+	if menuM.Component != nil {
+		menu.Component = *menuM.Component
+	}
+	if menuM.Icon != nil {
+		menu.Icon = *menuM.Icon
+	}
+	return menu
+}
@@ -0,0 +1,389 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package platform
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// PlatformBiz 定义了平台运营人员跨租户管理的业务逻辑接口.
+type PlatformBiz interface {
+	// 租户管理
+	ListTenants(ctx context.Context, rq *apiv1.ListAllTenantsRequest) (*apiv1.ListAllTenantsResponse, error)
+	CreateTenant(ctx context.Context, rq *apiv1.CreateTenantRequest) (*apiv1.CreateTenantResponse, error)
+	UpdateTenantStatus(ctx context.Context, rq *apiv1.UpdateTenantStatusRequest) (*apiv1.UpdateTenantStatusResponse, error)
+	AssignTenantOwner(ctx context.Context, rq *apiv1.AssignTenantOwnerRequest) (*apiv1.AssignTenantOwnerResponse, error)
+
+	// 平台运营人员管理
+	ListOperators(ctx context.Context, rq *apiv1.ListPlatformOperatorsRequest) (*apiv1.ListPlatformOperatorsResponse, error)
+	AddOperator(ctx context.Context, rq *apiv1.AddPlatformOperatorRequest) (*apiv1.AddPlatformOperatorResponse, error)
+	RemoveOperator(ctx context.Context, rq *apiv1.RemovePlatformOperatorRequest) (*apiv1.RemovePlatformOperatorResponse, error)
+
+	// 审计日志
+	ListAuditLogs(ctx context.Context, rq *apiv1.ListPlatformAuditLogsRequest) (*apiv1.ListPlatformAuditLogsResponse, error)
+
+	// 策略决策点服务凭证管理
+	CreateServiceClient(ctx context.Context, rq *apiv1.CreateServiceClientRequest) (*apiv1.CreateServiceClientResponse, error)
+	ListServiceClients(ctx context.Context, rq *apiv1.ListServiceClientsRequest) (*apiv1.ListServiceClientsResponse, error)
+	RevokeServiceClient(ctx context.Context, rq *apiv1.RevokeServiceClientRequest) (*apiv1.RevokeServiceClientResponse, error)
+}
+
+// platformBiz 是 PlatformBiz 接口的实现.
+type platformBiz struct {
+	store store.IStore
+	authz *authz.Authz
+}
+
+// 确保 platformBiz 实现了 PlatformBiz 接口.
+var _ PlatformBiz = (*platformBiz)(nil)
+
+// New 创建一个新的 PlatformBiz 实例.
+func New(store store.IStore, authz *authz.Authz) *platformBiz {
+	return &platformBiz{store: store, authz: authz}
+}
+
+// ListTenants 获取全部租户，包括已禁用的租户
+func (b *platformBiz) ListTenants(ctx context.Context, rq *apiv1.ListAllTenantsRequest) (*apiv1.ListAllTenantsResponse, error) {
+	opts := where.NewWhere()
+	if rq.Offset > 0 {
+		opts = opts.O(int(rq.Offset))
+	}
+	if rq.Limit > 0 {
+		opts = opts.L(int(rq.Limit))
+	}
+
+	count, tenants, err := b.store.Tenant().List(ctx, opts)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	resp := &apiv1.ListAllTenantsResponse{TotalCount: count}
+	for _, tenant := range tenants {
+		resp.Tenants = append(resp.Tenants, convertTenantToAPI(tenant))
+	}
+	return resp, nil
+}
+
+// CreateTenant 创建租户及其租户所有者、租户管理员系统角色，可同时指定租户所有者
+func (b *platformBiz) CreateTenant(ctx context.Context, rq *apiv1.CreateTenantRequest) (*apiv1.CreateTenantResponse, error) {
+	if rq.Name == "" {
+		return nil, errno.ErrInvalidArgument.WithMessage("name cannot be empty")
+	}
+	if rq.Timezone != "" {
+		if _, err := time.LoadLocation(rq.Timezone); err != nil {
+			return nil, errno.ErrInvalidArgument.WithMessage("invalid timezone: %s", rq.Timezone)
+		}
+	}
+	if rq.OwnerUserId != 0 {
+		if err := b.checkUser(ctx, rq.OwnerUserId); err != nil {
+			return nil, err
+		}
+	}
+
+	// 租户角色不属于当前租户，跳过数据权限过滤
+	ctx = store.WithoutDataScope(ctx)
+
+	tenantM := &model.TenantM{Name: rq.Name, Timezone: rq.Timezone, Status: true}
+	if rq.Description != "" {
+		tenantM.Description = &rq.Description
+	}
+	var ownerRole, adminRole *model.RoleM
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.Tenant().Create(ctx, tenantM); err != nil {
+			return errno.ErrDBWrite.WithMessage(err.Error())
+		}
+		ownerRole = newSystemRole(tenantM.ID, authz.SystemRoleTenantOwner, "租户所有者，拥有租户内的全部权限")
+		adminRole = newSystemRole(tenantM.ID, authz.SystemRoleTenantAdmin, "租户管理员，自动获得管理类权限")
+		for _, role := range []*model.RoleM{ownerRole, adminRole} {
+			if err := b.store.Role().Create(ctx, role); err != nil {
+				return errno.ErrDBWrite.WithMessage(err.Error())
+			}
+		}
+		if rq.OwnerUserId != 0 {
+			if err := b.store.Tenant().AddUserTenant(ctx, strconv.FormatInt(rq.OwnerUserId, 10), tenantM.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.authz.InvalidateSystemRoles()
+
+	if rq.OwnerUserId != 0 {
+		if err := b.grantOwner(rq.OwnerUserId, ownerRole); err != nil {
+			return nil, err
+		}
+	}
+
+	log.W(ctx).Infow("Tenant created by platform operator", "tenant_id", tenantM.ID, "owner_user_id", rq.OwnerUserId)
+	return &apiv1.CreateTenantResponse{
+		Tenant:      convertTenantToAPI(tenantM),
+		OwnerRoleId: ownerRole.ID,
+		AdminRoleId: adminRole.ID,
+	}, nil
+}
+
+// UpdateTenantStatus 启用或禁用租户
+func (b *platformBiz) UpdateTenantStatus(ctx context.Context, rq *apiv1.UpdateTenantStatusRequest) (*apiv1.UpdateTenantStatusResponse, error) {
+	tenantM, err := b.getTenant(ctx, rq.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantM.Status = rq.Status == 1
+	if err := b.store.Tenant().Update(ctx, tenantM); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	return &apiv1.UpdateTenantStatusResponse{Tenant: convertTenantToAPI(tenantM)}, nil
+}
+
+// AssignTenantOwner 将用户设为租户所有者，用户不属于租户时同时加入租户
+func (b *platformBiz) AssignTenantOwner(ctx context.Context, rq *apiv1.AssignTenantOwnerRequest) (*apiv1.AssignTenantOwnerResponse, error) {
+	if _, err := b.getTenant(ctx, rq.TenantId); err != nil {
+		return nil, err
+	}
+	if err := b.checkUser(ctx, rq.UserId); err != nil {
+		return nil, err
+	}
+
+	ctx = store.WithoutDataScope(ctx)
+	ownerRole, err := b.store.Role().Get(ctx, where.F("tenant_id", rq.TenantId, "system_role", authz.SystemRoleTenantOwner))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrSystemRoleMissing.WithMessage("tenant %d has no %s role", rq.TenantId, authz.SystemRoleTenantOwner)
+		}
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	userID := strconv.FormatInt(rq.UserId, 10)
+	member, err := b.store.Tenant().CheckUserTenant(ctx, userID, rq.TenantId)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if !member {
+		if err := b.store.Tenant().AddUserTenant(ctx, userID, rq.TenantId); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.grantOwner(rq.UserId, ownerRole); err != nil {
+		return nil, err
+	}
+
+	log.W(ctx).Infow("Tenant owner assigned by platform operator", "tenant_id", rq.TenantId, "user_id", rq.UserId)
+	return &apiv1.AssignTenantOwnerResponse{RoleId: ownerRole.ID}, nil
+}
+
+// ListOperators 获取平台运营人员列表
+func (b *platformBiz) ListOperators(ctx context.Context, rq *apiv1.ListPlatformOperatorsRequest) (*apiv1.ListPlatformOperatorsResponse, error) {
+	_, operators, err := b.store.PlatformOperator().List(ctx, where.NewWhere())
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if len(operators) == 0 {
+		return &apiv1.ListPlatformOperatorsResponse{}, nil
+	}
+
+	userIDs := make([]int64, 0, len(operators))
+	for _, op := range operators {
+		userIDs = append(userIDs, op.UserID)
+	}
+	_, users, err := b.store.User().List(ctx, where.NewWhere().Q("id IN ?", userIDs))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	usernames := make(map[int64]string, len(users))
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+
+	resp := &apiv1.ListPlatformOperatorsResponse{}
+	for _, op := range operators {
+		resp.Operators = append(resp.Operators, convertOperatorToAPI(op, usernames[op.UserID]))
+	}
+	return resp, nil
+}
+
+// AddOperator 添加平台运营人员
+func (b *platformBiz) AddOperator(ctx context.Context, rq *apiv1.AddPlatformOperatorRequest) (*apiv1.AddPlatformOperatorResponse, error) {
+	userM, err := b.store.User().Get(ctx, where.F("id", rq.UserId))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrUserNotFound
+		}
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	exists, err := b.store.PlatformOperator().IsOperator(ctx, rq.UserId)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if exists {
+		return nil, errno.ErrPlatformOperatorExists
+	}
+
+	operatorM := &model.PlatformOperatorM{UserID: rq.UserId, CreatedBy: contextx.UserID(ctx)}
+	if err := b.store.PlatformOperator().Create(ctx, operatorM); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	return &apiv1.AddPlatformOperatorResponse{Operator: convertOperatorToAPI(operatorM, userM.Username)}, nil
+}
+
+// RemoveOperator 移除平台运营人员，不能移除自己，避免平台失去最后一个运营人员
+func (b *platformBiz) RemoveOperator(ctx context.Context, rq *apiv1.RemovePlatformOperatorRequest) (*apiv1.RemovePlatformOperatorResponse, error) {
+	if rq.UserId == contextx.UserID(ctx) {
+		return nil, errno.ErrInvalidArgument.WithMessage("cannot remove yourself from platform operators")
+	}
+	if err := b.store.PlatformOperator().Delete(ctx, where.F("user_id", rq.UserId)); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	return &apiv1.RemovePlatformOperatorResponse{}, nil
+}
+
+// ListAuditLogs 获取平台操作审计日志，按时间倒序
+func (b *platformBiz) ListAuditLogs(ctx context.Context, rq *apiv1.ListPlatformAuditLogsRequest) (*apiv1.ListPlatformAuditLogsResponse, error) {
+	opts := where.NewWhere()
+	if rq.OperatorId != 0 {
+		opts = opts.F("operator_id", rq.OperatorId)
+	}
+	if rq.TenantId != 0 {
+		opts = opts.F("tenant_id", rq.TenantId)
+	}
+	if rq.Offset > 0 {
+		opts = opts.O(int(rq.Offset))
+	}
+	if rq.Limit > 0 {
+		opts = opts.L(int(rq.Limit))
+	}
+
+	count, logs, err := b.store.PlatformAuditLog().List(ctx, opts)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	resp := &apiv1.ListPlatformAuditLogsResponse{TotalCount: count}
+	for _, l := range logs {
+		resp.Logs = append(resp.Logs, convertAuditLogToAPI(l))
+	}
+	return resp, nil
+}
+
+// getTenant 获取租户，不存在时返回 ErrPlatformTenantNotFound
+func (b *platformBiz) getTenant(ctx context.Context, tenantID int64) (*model.TenantM, error) {
+	tenantM, err := b.store.Tenant().Get(ctx, where.F("id", tenantID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrPlatformTenantNotFound
+		}
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	return tenantM, nil
+}
+
+// checkUser 检查用户是否存在
+func (b *platformBiz) checkUser(ctx context.Context, userID int64) error {
+	if _, err := b.store.User().Get(ctx, where.F("id", userID)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrUserNotFound
+		}
+		return errno.ErrDBRead.WithMessage(err.Error())
+	}
+	return nil
+}
+
+// grantOwner 为用户分配租户所有者角色
+func (b *platformBiz) grantOwner(userID int64, ownerRole *model.RoleM) error {
+	if err := b.authz.AddRoleIDForUser(userID, ownerRole.ID, ownerRole.TenantID); err != nil {
+		if authz.IsSoDViolation(err) {
+			return errno.ErrSoDViolation.WithMessage(err.Error())
+		}
+		return errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+	}
+	return nil
+}
+
+// newSystemRole 创建租户的系统内置角色，名称与系统角色标识相同
+func newSystemRole(tenantID int64, systemRole, description string) *model.RoleM {
+	return &model.RoleM{
+		TenantID:    tenantID,
+		Name:        systemRole,
+		Description: &description,
+		Status:      true,
+		DataScope:   model.DataScopeTenant,
+		SystemRole:  &systemRole,
+	}
+}
+
+// convertTenantToAPI 转换租户模型为API格式
+func convertTenantToAPI(tenantM *model.TenantM) *apiv1.Tenant {
+	status := int32(0)
+	if tenantM.Status {
+		status = 1
+	}
+	description := ""
+	if tenantM.Description != nil {
+		description = *tenantM.Description
+	}
+
+	return &apiv1.Tenant{
+		Id:          tenantM.ID,
+		Name:        tenantM.Name,
+		Description: description,
+		Status:      status,
+		CreatedAt:   timestamppb.New(tenantM.CreatedAt),
+		UpdatedAt:   timestamppb.New(tenantM.UpdatedAt),
+	}
+}
+
+// convertOperatorToAPI 转换平台运营人员模型为API格式
+func convertOperatorToAPI(operatorM *model.PlatformOperatorM, username string) *apiv1.PlatformOperator {
+	return &apiv1.PlatformOperator{
+		UserId:    operatorM.UserID,
+		Username:  username,
+		CreatedBy: operatorM.CreatedBy,
+		CreatedAt: timestamppb.New(operatorM.CreatedAt),
+	}
+}
+
+// convertAuditLogToAPI 转换审计日志模型为API格式
+func convertAuditLogToAPI(logM *model.PlatformAuditLogM) *apiv1.PlatformAuditLog {
+	message := ""
+	if logM.Message != nil {
+		message = *logM.Message
+	}
+
+	return &apiv1.PlatformAuditLog{
+		Id:         logM.ID,
+		OperatorId: logM.OperatorID,
+		Method:     logM.Method,
+		Route:      logM.Route,
+		Path:       logM.Path,
+		TenantId:   logM.TenantID,
+		StatusCode: logM.StatusCode,
+		Message:    message,
+		RequestId:  logM.RequestID,
+		ClientIp:   logM.ClientIP,
+		CreatedAt:  timestamppb.New(logM.CreatedAt),
+	}
+}
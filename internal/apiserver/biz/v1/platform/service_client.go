@@ -0,0 +1,126 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package platform
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authn"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// CreateServiceClient 为调用策略决策点的服务创建凭证，明文只在响应中返回一次.
+func (b *platformBiz) CreateServiceClient(ctx context.Context, rq *apiv1.CreateServiceClientRequest) (*apiv1.CreateServiceClientResponse, error) {
+	if rq.GetName() == "" {
+		return nil, errno.ErrInvalidArgument.WithMessage("name cannot be empty")
+	}
+	if rq.GetExpiresInDays() < 0 {
+		return nil, errno.ErrInvalidArgument.WithMessage("expires_in_days must not be negative")
+	}
+	if rq.GetTenantId() != 0 {
+		if _, err := b.getTenant(ctx, rq.GetTenantId()); err != nil {
+			return nil, err
+		}
+	}
+	scopes := make([]string, 0, len(rq.GetScopes()))
+	for _, scope := range rq.GetScopes() {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || strings.Contains(scope, ",") {
+			return nil, errno.ErrInvalidArgument.WithMessage("invalid scope %q", scope)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	secret, prefix, hash, err := authn.GenerateServiceToken()
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+	clientM := &model.ServiceClientM{
+		Name:        rq.GetName(),
+		TenantID:    rq.GetTenantId(),
+		TokenHash:   hash,
+		TokenPrefix: prefix,
+		Scopes:      strings.Join(scopes, ","),
+		CreatedBy:   contextx.UserID(ctx),
+	}
+	if days := rq.GetExpiresInDays(); days > 0 {
+		expiresAt := time.Now().AddDate(0, 0, int(days))
+		clientM.ExpiresAt = &expiresAt
+	}
+	if err := b.store.ServiceClient().Create(ctx, clientM); err != nil {
+		log.W(ctx).Errorw("Failed to create service client", "name", rq.GetName(), "err", err)
+		return nil, errno.ErrDBWrite.WithMessage("Failed to create service client")
+	}
+
+	log.W(ctx).Infow("Service client created", "client_id", clientM.ID, "name", clientM.Name, "tenant_id", clientM.TenantID, "scopes", clientM.Scopes, "prefix", prefix)
+	return &apiv1.CreateServiceClientResponse{Client: convertServiceClientToAPI(clientM), Secret: secret}, nil
+}
+
+// ListServiceClients 获取服务凭证列表.
+func (b *platformBiz) ListServiceClients(ctx context.Context, rq *apiv1.ListServiceClientsRequest) (*apiv1.ListServiceClientsResponse, error) {
+	_, clients, err := b.store.ServiceClient().List(ctx, where.NewWhere())
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	resp := &apiv1.ListServiceClientsResponse{Clients: make([]*apiv1.ServiceClient, 0, len(clients))}
+	for _, c := range clients {
+		resp.Clients = append(resp.Clients, convertServiceClientToAPI(c))
+	}
+	return resp, nil
+}
+
+// RevokeServiceClient 吊销服务凭证，吊销后立即不能再调用策略决策点.
+func (b *platformBiz) RevokeServiceClient(ctx context.Context, rq *apiv1.RevokeServiceClientRequest) (*apiv1.RevokeServiceClientResponse, error) {
+	opts := where.F("id", rq.GetClientId())
+	if _, err := b.store.ServiceClient().Get(ctx, opts); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrInvalidArgument.WithMessage("service client not found")
+		}
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if err := b.store.ServiceClient().Delete(ctx, opts); err != nil {
+		log.W(ctx).Errorw("Failed to revoke service client", "client_id", rq.GetClientId(), "err", err)
+		return nil, errno.ErrDBWrite.WithMessage("Failed to revoke service client")
+	}
+
+	log.W(ctx).Infow("Service client revoked", "client_id", rq.GetClientId())
+	return &apiv1.RevokeServiceClientResponse{}, nil
+}
+
+// convertServiceClientToAPI 转换服务凭证模型为API格式
+func convertServiceClientToAPI(c *model.ServiceClientM) *apiv1.ServiceClient {
+	client := &apiv1.ServiceClient{
+		Id:          c.ID,
+		Name:        c.Name,
+		TenantId:    c.TenantID,
+		TokenPrefix: c.TokenPrefix,
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+		Scopes:      c.GetScopes(),
+	}
+	if c.ExpiresAt != nil {
+		client.ExpiresAt = timestamppb.New(*c.ExpiresAt)
+	}
+	if c.LastUsedAt != nil {
+		client.LastUsedAt = timestamppb.New(*c.LastUsedAt)
+	}
+	return client
+}
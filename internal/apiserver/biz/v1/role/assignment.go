@@ -0,0 +1,174 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package role
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// GetUserRoles 获取用户在当前租户下直接拥有的角色
+func (b *roleBiz) GetUserRoles(ctx context.Context, rq *apiv1.GetUserRolesRequest) (*apiv1.GetUserRolesResponse, error) {
+	tenantID, err := currentTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := b.tenantUser(ctx, rq.GetUserId(), tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := b.userRoles(ctx, userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.GetUserRolesResponse{Roles: roles}, nil
+}
+
+// AssignUserRoles 将用户在当前租户下的角色替换为请求中的角色
+func (b *roleBiz) AssignUserRoles(ctx context.Context, rq *apiv1.AssignUserRolesRequest) (*apiv1.AssignUserRolesResponse, error) {
+	tenantID, err := currentTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := b.tenantUser(ctx, rq.GetUserId(), tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := b.tenantRoles(ctx, tenantID, rq.GetRoleIds())
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[int64]bool, len(roles))
+	for _, role := range roles {
+		found[role.Id] = true
+	}
+	for _, id := range rq.GetRoleIds() {
+		if !found[id] {
+			return nil, errno.ErrInvalidArgument.WithMessage("role %d not found in current tenant", id)
+		}
+	}
+
+	// 生效中的限时授予由授予流程维护，替换角色时保留，到期后由授予流程移除
+	_, grants, err := b.store.UserRoleGrant().List(ctx, where.F("tenant_id", tenantID, "user_id", userID, "status", model.RoleGrantStatusActive))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	granted := make([]int64, 0, len(grants))
+	for _, grant := range grants {
+		granted = append(granted, grant.RoleID)
+	}
+
+	// Casbin 适配器不参与数据库事务，角色写入失败时由 SetRolesForUser 恢复原有的角色分配
+	if err := b.authz.SetRolesForUser(userID, tenantID, rq.GetRoleIds(), granted); err != nil {
+		if authz.IsSoDViolation(err) {
+			return nil, errno.ErrSoDViolation.WithMessage(err.Error())
+		}
+		log.W(ctx).Errorw("Failed to set user roles", "user_id", userID, "role_ids", rq.GetRoleIds(), "err", err)
+		return nil, errno.ErrAddRole.WithMessage(err.Error())
+	}
+
+	log.W(ctx).Infow("User roles assigned", "user_id", rq.GetUserId(), "tenant_id", tenantID, "role_ids", rq.GetRoleIds())
+	return &apiv1.AssignUserRolesResponse{Success: true}, nil
+}
+
+// GetRolesByUser 获取当前用户在指定租户下直接拥有的角色
+func (b *roleBiz) GetRolesByUser(ctx context.Context, rq *apiv1.GetRolesByUserRequest) (*apiv1.GetRolesByUserResponse, error) {
+	userID := contextx.UserID(ctx)
+	if userID == 0 {
+		return nil, errno.ErrUnauthenticated.WithMessage("user not found in context")
+	}
+
+	tenantID := rq.GetTenantId()
+	if tenantID == 0 {
+		var err error
+		if tenantID, err = currentTenantID(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	roles, err := b.userRoles(ctx, userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.GetRolesByUserResponse{Roles: roles}, nil
+}
+
+// CheckDeleteRole 检查角色是否可以删除
+func (b *roleBiz) CheckDeleteRole(ctx context.Context, rq *apiv1.CheckDeleteRoleRequest) (*apiv1.CheckDeleteRoleResponse, error) {
+	if _, err := b.currentTenantRole(ctx, rq.GetRoleId()); err != nil {
+		return nil, err
+	}
+
+	canDelete, reason, err := b.checkDeleteRole(ctx, rq.GetRoleId())
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.CheckDeleteRoleResponse{CanDelete: canDelete, Reason: reason}, nil
+}
+
+// RefreshPrivilegeData 从数据库重新加载全部租户的策略，用于直接修改 casbin_rule 等数据后使其生效.
+// 重新加载影响全部租户，只允许平台运营人员调用.
+func (b *roleBiz) RefreshPrivilegeData(ctx context.Context, rq *apiv1.RefreshPrivilegeDataRequest) (*apiv1.RefreshPrivilegeDataResponse, error) {
+	isOperator, err := b.store.PlatformOperator().IsOperator(ctx, contextx.UserID(ctx))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check platform operator", "err", err)
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if !isOperator {
+		return nil, errno.ErrNotPlatformOperator
+	}
+
+	if err := b.authz.Refresh(); err != nil {
+		log.W(ctx).Errorw("Failed to refresh privilege data", "err", err)
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	log.W(ctx).Infow("Privilege data refreshed", "tenant_id", rq.GetTenantId())
+	return &apiv1.RefreshPrivilegeDataResponse{Success: true}, nil
+}
+
+// tenantUser 校验用户属于租户，并返回用户ID
+func (b *roleBiz) tenantUser(ctx context.Context, userID string, tenantID int64) (int64, error) {
+	id, err := strconv.ParseInt(userID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errno.ErrInvalidArgument.WithMessage("invalid user_id: %s", userID)
+	}
+
+	var count int64
+	err = b.store.DB(ctx).Model(&model.UserTenantM{}).
+		Where("user_id = ? AND tenant_id = ?", id, tenantID).
+		Count(&count).Error
+	if err != nil {
+		log.W(ctx).Errorw("Failed to check user tenant", "user_id", id, "tenant_id", tenantID, "err", err)
+		return 0, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if count == 0 {
+		return 0, errno.ErrUserNotFound.WithMessage("user %d not found in current tenant", id)
+	}
+	return id, nil
+}
+
+// userRoles 查询用户在租户下直接拥有的角色
+func (b *roleBiz) userRoles(ctx context.Context, userID, tenantID int64) ([]*apiv1.Role, error) {
+	roleIDs, err := b.authz.GetRoleIDsForUser(userID, tenantID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get user roles from Casbin", "user_id", userID, "err", err)
+		return nil, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+	}
+	return b.tenantRoles(ctx, tenantID, roleIDs)
+}
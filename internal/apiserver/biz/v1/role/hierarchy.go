@@ -0,0 +1,102 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package role
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// GetParents 获取角色在当前租户下的父角色和子角色
+func (b *roleBiz) GetParents(ctx context.Context, rq *apiv1.GetRoleParentsRequest) (*apiv1.GetRoleParentsResponse, error) {
+	tenantID, err := b.currentTenantRole(ctx, rq.GetRoleId())
+	if err != nil {
+		return nil, err
+	}
+
+	parentIDs, err := b.authz.GetParentRoles(rq.GetRoleId(), tenantID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get parent roles from Casbin", "role_id", rq.GetRoleId(), "err", err)
+		return nil, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+	}
+	childIDs, err := b.authz.GetChildRoles(rq.GetRoleId(), tenantID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get child roles from Casbin", "role_id", rq.GetRoleId(), "err", err)
+		return nil, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+	}
+
+	resp := &apiv1.GetRoleParentsResponse{}
+	if resp.Parents, err = b.tenantRoles(ctx, tenantID, parentIDs); err != nil {
+		return nil, err
+	}
+	if resp.Children, err = b.tenantRoles(ctx, tenantID, childIDs); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetParents 设置角色在当前租户下继承的父角色
+func (b *roleBiz) SetParents(ctx context.Context, rq *apiv1.SetRoleParentsRequest) (*apiv1.SetRoleParentsResponse, error) {
+	tenantID, err := b.currentTenantRole(ctx, rq.GetRoleId())
+	if err != nil {
+		return nil, err
+	}
+
+	parents, err := b.tenantRoles(ctx, tenantID, rq.GetParentIds())
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[int64]bool, len(parents))
+	for _, parent := range parents {
+		found[parent.Id] = true
+	}
+	for _, id := range rq.GetParentIds() {
+		if !found[id] {
+			return nil, errno.ErrInvalidArgument.WithMessage("parent role %d not found in current tenant", id)
+		}
+	}
+
+	if err := b.authz.SetParentRoles(rq.GetRoleId(), tenantID, rq.GetParentIds()); err != nil {
+		switch {
+		case errors.Is(err, authz.ErrRoleInheritanceCycle):
+			return nil, errno.ErrRoleHierarchyLoop
+		case errors.Is(err, authz.ErrRoleInheritanceTooDeep):
+			return nil, errno.ErrInvalidArgument.WithMessage("role inheritance chain cannot exceed %d levels", authz.MaxRoleInheritanceDepth)
+		case authz.IsSoDViolation(err):
+			return nil, errno.ErrSoDViolation.WithMessage(err.Error())
+		}
+		log.W(ctx).Errorw("Failed to set parent roles", "role_id", rq.GetRoleId(), "parent_ids", rq.GetParentIds(), "err", err)
+		return nil, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+	}
+
+	log.W(ctx).Infow("Role parents updated", "role_id", rq.GetRoleId(), "parent_ids", rq.GetParentIds())
+	return &apiv1.SetRoleParentsResponse{Success: true}, nil
+}
+
+// tenantRoles 按ID查询租户下的角色
+func (b *roleBiz) tenantRoles(ctx context.Context, tenantID int64, ids []int64) ([]*apiv1.Role, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	_, roles, err := b.store.Role().List(ctx, where.F("tenant_id", tenantID).Q("id IN ?", ids))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to list roles", "tenant_id", tenantID, "err", err)
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	result := make([]*apiv1.Role, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, convertRoleToAPI(role))
+	}
+	return result, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package role
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+)
+
+// GetRoleMenus 获取角色可访问的菜单.
+// 角色被允许（且未被拒绝）菜单的全部必需权限时可访问该菜单，没有必需权限的菜单不属于任何角色.
+func (b *roleBiz) GetRoleMenus(ctx context.Context, rq *apiv1.GetRoleMenusRequest) (*apiv1.GetRoleMenusResponse, error) {
+	tenantID, err := b.currentTenantRole(ctx, rq.GetRoleId())
+	if err != nil {
+		return nil, err
+	}
+
+	matrices, err := b.store.MenuPermission().GetMenuPermissionMatrix(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := b.authz.GetPermissionsForRole(rq.GetRoleId(), tenantID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get role permissions from Casbin", "role_id", rq.GetRoleId(), "err", err)
+		return nil, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+	}
+	allowed := make(map[int64]bool, len(grants))
+	for _, grant := range grants {
+		allowed[grant.PermissionID] = grant.Effect == authz.EffectAllow
+	}
+
+	resp := &apiv1.GetRoleMenusResponse{}
+	for _, matrix := range matrices {
+		if len(matrix.RequiredPermissions) == 0 {
+			continue
+		}
+		granted := true
+		for _, permission := range matrix.RequiredPermissions {
+			if !allowed[permission.ID] {
+				granted = false
+				break
+			}
+		}
+		if granted {
+			resp.Menus = append(resp.Menus, convertMenuToAPI(matrix.Menu))
+		}
+	}
+	return resp, nil
+}
+
+// UpdateRoleMenus 更新角色可访问的菜单.
+// 角色被授予所选菜单的必需权限，其他菜单的必需权限被撤销；不属于任何菜单的权限规则和 deny 规则保持不变.
+func (b *roleBiz) UpdateRoleMenus(ctx context.Context, rq *apiv1.UpdateRoleMenusRequest) (*apiv1.UpdateRoleMenusResponse, error) {
+	tenantID, err := b.currentTenantRole(ctx, rq.GetRoleId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		matrices, err := b.store.MenuPermission().GetMenuPermissionMatrix(ctx, tenantID)
+		if err != nil {
+			return err
+		}
+
+		selected := make(map[int64]bool, len(rq.GetMenuIds()))
+		for _, id := range rq.GetMenuIds() {
+			selected[id] = true
+		}
+		var scope, permissionIDs []int64
+		known := make(map[int64]bool, len(matrices))
+		for _, matrix := range matrices {
+			known[matrix.Menu.ID] = true
+			for _, permission := range matrix.RequiredPermissions {
+				scope = append(scope, permission.ID)
+				if selected[matrix.Menu.ID] {
+					permissionIDs = append(permissionIDs, permission.ID)
+				}
+			}
+		}
+		for _, id := range rq.GetMenuIds() {
+			if !known[id] {
+				return errno.ErrInvalidArgument.WithMessage("menu %d not found in current tenant", id)
+			}
+		}
+
+		if err := b.authz.SetPermissionsForRole(rq.GetRoleId(), tenantID, scope, permissionIDs); err != nil {
+			log.W(ctx).Errorw("Failed to set role menu permissions", "role_id", rq.GetRoleId(), "err", err)
+			return errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.W(ctx).Infow("Role menus updated", "role_id", rq.GetRoleId(), "menu_ids", rq.GetMenuIds())
+	return &apiv1.UpdateRoleMenusResponse{Success: true}, nil
+}
+
+// convertMenuToAPI 将菜单模型转换为API响应模型
+func convertMenuToAPI(menuM *model.MenuM) *apiv1.Menu {
+	menu := &apiv1.Menu{
+		Id:        menuM.ID,
+		TenantId:  menuM.TenantID,
+		Title:     menuM.Title,
+		MenuType:  menuM.MenuType,
+		SortOrder: menuM.SortOrder,
+		Visible:   menuM.Visible,
+		CreatedAt: timestamppb.New(menuM.CreatedAt),
+		UpdatedAt: timestamppb.New(menuM.UpdatedAt),
+	}
+	if menuM.Status {
+		menu.Status = 1
+	}
+	if menuM.ParentID != nil {
+		menu.ParentId = *menuM.ParentID
+	}
+	if menuM.RoutePath != nil {
+		menu.RoutePath = *menuM.RoutePath
+	}
+	if menuM.Component != nil {
+		menu.Component = *menuM.Component
+	}
+	if menuM.Icon != nil {
+		menu.Icon = *menuM.Icon
+	}
+	return menu
+}
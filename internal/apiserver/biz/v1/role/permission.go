@@ -0,0 +1,191 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package role
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// GetPermissions 获取角色在当前租户下的权限规则
+func (b *roleBiz) GetPermissions(ctx context.Context, rq *apiv1.GetRolePermissionsRequest) (*apiv1.GetRolePermissionsResponse, error) {
+	tenantID, err := b.currentTenantRole(ctx, rq.GetRoleId())
+	if err != nil {
+		return nil, err
+	}
+
+	grants, err := b.authz.GetPermissionsForRole(rq.GetRoleId(), tenantID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get role permissions from Casbin", "role_id", rq.GetRoleId(), "err", err)
+		return nil, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+	}
+
+	ids := make([]int64, 0, len(grants))
+	for _, grant := range grants {
+		ids = append(ids, grant.PermissionID)
+	}
+	permissions, err := b.tenantPermissions(ctx, tenantID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &apiv1.GetRolePermissionsResponse{}
+	for _, grant := range grants {
+		permissionM, ok := permissions[grant.PermissionID]
+		if !ok {
+			continue
+		}
+		permission := convertPermissionToAPI(permissionM)
+		if grant.Effect == authz.EffectAllow {
+			resp.Permissions = append(resp.Permissions, permission)
+		}
+		resp.Grants = append(resp.Grants, &apiv1.RolePermissionGrant{Permission: permission, Effect: grant.Effect})
+	}
+	return resp, nil
+}
+
+// AssignPermissions 为角色添加 allow 或 deny 权限规则
+func (b *roleBiz) AssignPermissions(ctx context.Context, rq *apiv1.AssignRolePermissionsRequest) (*apiv1.AssignRolePermissionsResponse, error) {
+	effect := rq.GetEffect()
+	if effect == "" {
+		effect = authz.EffectAllow
+	}
+	if !authz.IsValidEffect(effect) {
+		return nil, errno.ErrInvalidArgument.WithMessage("effect must be allow or deny")
+	}
+	if len(rq.GetPermissionIds()) == 0 {
+		return nil, errno.ErrInvalidArgument.WithMessage("permission_ids cannot be empty")
+	}
+
+	tenantID, err := b.currentTenantRole(ctx, rq.GetRoleId())
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := b.tenantPermissions(ctx, tenantID, rq.GetPermissionIds())
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range rq.GetPermissionIds() {
+		if _, ok := permissions[id]; !ok {
+			return nil, errno.ErrInvalidArgument.WithMessage("permission %d not found in current tenant", id)
+		}
+	}
+
+	for _, id := range rq.GetPermissionIds() {
+		if _, err := b.authz.AddPermissionForRole(rq.GetRoleId(), id, tenantID, effect); err != nil {
+			log.W(ctx).Errorw("Failed to add role permission", "role_id", rq.GetRoleId(), "permission_id", id, "effect", effect, "err", err)
+			return nil, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+		}
+	}
+
+	log.W(ctx).Infow("Role permissions assigned", "role_id", rq.GetRoleId(), "permission_ids", rq.GetPermissionIds(), "effect", effect)
+	return &apiv1.AssignRolePermissionsResponse{Success: true}, nil
+}
+
+// RevokePermissions 删除角色的权限规则
+func (b *roleBiz) RevokePermissions(ctx context.Context, rq *apiv1.RevokeRolePermissionsRequest) (*apiv1.RevokeRolePermissionsResponse, error) {
+	if len(rq.GetPermissionIds()) == 0 {
+		return nil, errno.ErrInvalidArgument.WithMessage("permission_ids cannot be empty")
+	}
+
+	tenantID, err := b.currentTenantRole(ctx, rq.GetRoleId())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range rq.GetPermissionIds() {
+		if _, err := b.authz.DeletePermissionForRole(rq.GetRoleId(), id, tenantID); err != nil {
+			log.W(ctx).Errorw("Failed to delete role permission", "role_id", rq.GetRoleId(), "permission_id", id, "err", err)
+			return nil, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+		}
+	}
+
+	log.W(ctx).Infow("Role permissions revoked", "role_id", rq.GetRoleId(), "permission_ids", rq.GetPermissionIds())
+	return &apiv1.RevokeRolePermissionsResponse{Success: true}, nil
+}
+
+// currentTenantRole 校验角色属于当前租户，并返回租户ID
+func (b *roleBiz) currentTenantRole(ctx context.Context, roleID int64) (int64, error) {
+	if roleID <= 0 {
+		return 0, errno.ErrInvalidArgument.WithMessage("role_id must be greater than 0")
+	}
+
+	tenantIDInt, err := currentTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := b.store.Role().Get(ctx, where.F("id", roleID, "tenant_id", tenantIDInt)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, errno.ErrRoleNotFound.WithMessage("role not found")
+		}
+		log.W(ctx).Errorw("Failed to get role", "role_id", roleID, "err", err)
+		return 0, errno.ErrDBRead.WithMessage("Failed to get role")
+	}
+	return tenantIDInt, nil
+}
+
+// currentTenantID 返回上下文中的租户ID，未指定时使用默认租户
+func currentTenantID(ctx context.Context) (int64, error) {
+	tenantID := contextx.TenantID(ctx)
+	if tenantID == "" {
+		tenantID = "1" // 默认租户
+	}
+	tenantIDInt, err := strconv.ParseInt(tenantID, 10, 64)
+	if err != nil {
+		return 0, errno.ErrInvalidArgument.WithMessage("invalid tenant_id format")
+	}
+	return tenantIDInt, nil
+}
+
+// tenantPermissions 查询租户下的权限，返回以权限ID为键的映射
+func (b *roleBiz) tenantPermissions(ctx context.Context, tenantID int64, ids []int64) (map[int64]*model.PermissionM, error) {
+	result := make(map[int64]*model.PermissionM, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	_, permissions, err := b.store.Permission().List(ctx, where.F("tenant_id", tenantID).Q("id IN ?", ids))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to list permissions", "tenant_id", tenantID, "err", err)
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	for _, permission := range permissions {
+		result[permission.ID] = permission
+	}
+	return result, nil
+}
+
+// convertPermissionToAPI 将权限模型转换为API响应模型
+func convertPermissionToAPI(permissionM *model.PermissionM) *apiv1.Permission {
+	permission := &apiv1.Permission{
+		Id:        permissionM.ID,
+		TenantId:  permissionM.TenantID,
+		Name:      permissionM.Name,
+		CreatedAt: timestamppb.New(permissionM.CreatedAt),
+		UpdatedAt: timestamppb.New(permissionM.UpdatedAt),
+	}
+	if permissionM.Description != nil {
+		permission.Description = *permissionM.Description
+	}
+	if permissionM.Status {
+		permission.Status = 1
+	}
+	return permission
+}
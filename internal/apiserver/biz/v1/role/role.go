@@ -12,6 +12,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/ashwinyue/one-auth/internal/apiserver/model"
 	"github.com/ashwinyue/one-auth/internal/apiserver/store"
@@ -32,6 +33,28 @@ type RoleBiz interface {
 	Update(ctx context.Context, rq *apiv1.UpdateRoleRequest) (*apiv1.UpdateRoleResponse, error)
 	Delete(ctx context.Context, rq *apiv1.DeleteRoleRequest) (*apiv1.DeleteRoleResponse, error)
 	List(ctx context.Context, rq *apiv1.ListRolesRequest) (*apiv1.ListRolesResponse, error)
+
+	// 角色权限规则
+	GetPermissions(ctx context.Context, rq *apiv1.GetRolePermissionsRequest) (*apiv1.GetRolePermissionsResponse, error)
+	AssignPermissions(ctx context.Context, rq *apiv1.AssignRolePermissionsRequest) (*apiv1.AssignRolePermissionsResponse, error)
+	RevokePermissions(ctx context.Context, rq *apiv1.RevokeRolePermissionsRequest) (*apiv1.RevokeRolePermissionsResponse, error)
+
+	// 用户角色
+	GetUserRoles(ctx context.Context, rq *apiv1.GetUserRolesRequest) (*apiv1.GetUserRolesResponse, error)
+	AssignUserRoles(ctx context.Context, rq *apiv1.AssignUserRolesRequest) (*apiv1.AssignUserRolesResponse, error)
+	GetRolesByUser(ctx context.Context, rq *apiv1.GetRolesByUserRequest) (*apiv1.GetRolesByUserResponse, error)
+
+	// 角色菜单
+	GetRoleMenus(ctx context.Context, rq *apiv1.GetRoleMenusRequest) (*apiv1.GetRoleMenusResponse, error)
+	UpdateRoleMenus(ctx context.Context, rq *apiv1.UpdateRoleMenusRequest) (*apiv1.UpdateRoleMenusResponse, error)
+
+	// 删除检查和权限数据刷新
+	CheckDeleteRole(ctx context.Context, rq *apiv1.CheckDeleteRoleRequest) (*apiv1.CheckDeleteRoleResponse, error)
+	RefreshPrivilegeData(ctx context.Context, rq *apiv1.RefreshPrivilegeDataRequest) (*apiv1.RefreshPrivilegeDataResponse, error)
+
+	// 角色继承
+	GetParents(ctx context.Context, rq *apiv1.GetRoleParentsRequest) (*apiv1.GetRoleParentsResponse, error)
+	SetParents(ctx context.Context, rq *apiv1.SetRoleParentsRequest) (*apiv1.SetRoleParentsResponse, error)
 }
 
 // roleBiz 是 RoleBiz 接口的实现.
@@ -71,6 +94,15 @@ func (b *roleBiz) Create(ctx context.Context, rq *apiv1.CreateRoleRequest) (*api
 		return nil, errno.ErrInvalidArgument.WithMessage("role_name format is invalid. Must be 2-100 characters")
 	}
 
+	// 数据权限范围默认为本租户
+	dataScope := rq.DataScope
+	if dataScope == "" {
+		dataScope = model.DataScopeTenant
+	}
+	if !model.IsValidDataScope(dataScope) {
+		return nil, errno.ErrInvalidArgument.WithMessage("invalid data_scope: %s", dataScope)
+	}
+
 	// 获取当前租户ID
 	tenantID := contextx.TenantID(ctx)
 	if tenantID == "" {
@@ -102,6 +134,7 @@ func (b *roleBiz) Create(ctx context.Context, rq *apiv1.CreateRoleRequest) (*api
 			Description: &rq.Description,
 			Status:      true, // 默认启用
 		}
+		setRoleDataScope(roleM, dataScope, rq.DataScopeDeptIds)
 
 		if err := b.store.Role().Create(ctx, roleM); err != nil {
 			log.W(ctx).Errorw("Failed to create role", "name", rq.Name, "err", err)
@@ -140,6 +173,9 @@ func (b *roleBiz) Update(ctx context.Context, rq *apiv1.UpdateRoleRequest) (*api
 	if rq.Name != "" && !isValidRoleName(rq.Name) {
 		return nil, errno.ErrInvalidArgument.WithMessage("role_name format is invalid. Must be 2-100 characters")
 	}
+	if rq.DataScope != "" && !model.IsValidDataScope(rq.DataScope) {
+		return nil, errno.ErrInvalidArgument.WithMessage("invalid data_scope: %s", rq.DataScope)
+	}
 
 	// 获取现有角色
 	roleM, err := b.store.Role().Get(ctx, where.F("id", rq.RoleId))
@@ -158,11 +194,16 @@ func (b *roleBiz) Update(ctx context.Context, rq *apiv1.UpdateRoleRequest) (*api
 	if rq.Description != "" {
 		roleM.Description = &rq.Description
 	}
+	if rq.DataScope != "" {
+		setRoleDataScope(roleM, rq.DataScope, rq.DataScopeDeptIds)
+	}
 
 	if err := b.store.Role().Update(ctx, roleM); err != nil {
 		log.W(ctx).Errorw("Failed to update role", "role_id", rq.RoleId, "err", err)
 		return nil, errno.ErrDBWrite.WithMessage("Failed to update role")
 	}
+	// 角色名称可能发生变化
+	b.authz.InvalidateResolver()
 
 	log.W(ctx).Infow("Role updated successfully", "role_id", roleM.ID, "name", roleM.Name)
 
@@ -214,6 +255,14 @@ func (b *roleBiz) Delete(ctx context.Context, rq *apiv1.DeleteRoleRequest) (*api
 		roleIdentifier := fmt.Sprintf("r%d", rq.RoleId)
 		tenantIdentifier := fmt.Sprintf("t%s", tenantID)
 
+		// 子角色改为继承被删除角色的父角色，避免继承链断开
+		if tenantIDInt, err := strconv.ParseInt(tenantID, 10, 64); err == nil {
+			if err := b.authz.RemoveRoleFromHierarchy(rq.RoleId, tenantIDInt); err != nil {
+				log.W(ctx).Errorw("Failed to remove role from hierarchy", "role_id", rq.RoleId, "err", err)
+				return errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+			}
+		}
+
 		// 删除Casbin中的相关策略
 		// 删除角色的所有权限
 		_, err = b.authz.DeletePermissionsForUser(roleIdentifier)
@@ -236,6 +285,7 @@ func (b *roleBiz) Delete(ctx context.Context, rq *apiv1.DeleteRoleRequest) (*api
 	if err != nil {
 		return nil, err
 	}
+	b.authz.InvalidateResolver()
 
 	log.W(ctx).Infow("Role deleted successfully", "role_id", rq.RoleId)
 
@@ -315,8 +365,8 @@ func (b *roleBiz) checkDeleteRole(ctx context.Context, roleID int64) (bool, stri
 		return false, "", errno.ErrDBRead.WithMessage("Failed to get role")
 	}
 
-	// 检查是否是系统角色（假设角色名称以system_开头的是系统角色）
-	if len(roleM.Name) > 7 && roleM.Name[:7] == "system_" {
+	// 租户所有者、租户管理员等系统内置角色不能删除
+	if roleM.SystemRole != nil {
 		return false, "系统内置角色不能删除", nil
 	}
 
@@ -335,15 +385,38 @@ func convertRoleToAPI(roleM *model.RoleM) *apiv1.Role {
 		status = 1
 	}
 
+	systemRole := ""
+	if roleM.SystemRole != nil {
+		systemRole = *roleM.SystemRole
+	}
+
 	return &apiv1.Role{
-		Id:          roleM.ID,
-		TenantId:    roleM.TenantID,
-		Name:        roleM.Name,
-		Description: description,
-		Status:      status,
-		CreatedAt:   timestamppb.New(roleM.CreatedAt),
-		UpdatedAt:   timestamppb.New(roleM.UpdatedAt),
+		Id:               roleM.ID,
+		TenantId:         roleM.TenantID,
+		Name:             roleM.Name,
+		Description:      description,
+		Status:           status,
+		CreatedAt:        timestamppb.New(roleM.CreatedAt),
+		UpdatedAt:        timestamppb.New(roleM.UpdatedAt),
+		DataScope:        roleM.DataScope,
+		DataScopeDeptIds: roleM.GetDataScopeDeptIDs(),
+		SystemRole:       systemRole,
+	}
+}
+
+// setRoleDataScope 设置角色的数据权限范围，只有 custom 范围保存部门ID
+func setRoleDataScope(roleM *model.RoleM, dataScope string, deptIDs []int64) {
+	roleM.DataScope = dataScope
+	roleM.DataScopeDeptIDs = nil
+	if dataScope != model.DataScopeCustom {
+		return
+	}
+	ids := make([]string, 0, len(deptIDs))
+	for _, id := range deptIDs {
+		ids = append(ids, strconv.FormatInt(id, 10))
 	}
+	joined := strings.Join(ids, ",")
+	roleM.DataScopeDeptIDs = &joined
 }
 
 // isValidRoleName 验证角色名称格式
@@ -0,0 +1,430 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/scim"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// groupColumns 组过滤属性到数据库列的映射
+var groupColumns = scim.Columns{
+	"id":                "id",
+	"displayname":       "name",
+	"meta.created":      "created_at",
+	"meta.lastmodified": "updated_at",
+}
+
+// ListGroups 查询租户内的组（角色）.
+func (b *scimBiz) ListGroups(ctx context.Context, q *scim.ListQuery) (*scim.ListResponse, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	startIndex, offset, limit := q.Page()
+	// 系统内置角色（如租户所有者）不由 IdP 管理，不作为组暴露
+	opts := where.F("tenant_id", tenantID).Q("system_role IS NULL").O(offset).L(max(limit, 1))
+	if q.Filter != "" {
+		f, err := scim.ParseFilter(q.Filter)
+		if err != nil {
+			return nil, err
+		}
+		cond, args, err := scim.ToSQL(f, groupColumns)
+		if err != nil {
+			return nil, err
+		}
+		opts = opts.Q(cond, args...)
+	}
+
+	total, roles, err := b.store.Role().List(ctx, opts)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to list SCIM groups", "tenant_id", tenantID, "err", err)
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if len(roles) > limit {
+		roles = roles[:limit]
+	}
+
+	resources := make([]any, 0, len(roles))
+	for _, role := range roles {
+		resources = append(resources, b.toGroup(ctx, tenantID, role, !q.Excludes("members")))
+	}
+	return scim.NewListResponse(total, startIndex, resources), nil
+}
+
+// GetGroup 获取租户内的组.
+func (b *scimBiz) GetGroup(ctx context.Context, id string, q *scim.ListQuery) (*scim.Group, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	role, err := b.getRole(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return b.toGroup(ctx, tenantID, role, !q.Excludes("members")), nil
+}
+
+// CreateGroup 在租户内创建角色并设置成员.
+func (b *scimBiz) CreateGroup(ctx context.Context, in *scim.Group) (*scim.Group, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.DisplayName == "" {
+		return nil, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "displayName is required")
+	}
+	exists, err := b.store.Role().CheckNameExists(ctx, in.DisplayName, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, scim.NewError(http.StatusConflict, scim.ErrTypeUniqueness, "group %q already exists", in.DisplayName)
+	}
+	members, err := b.memberIDs(ctx, tenantID, in.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &model.RoleM{TenantID: tenantID, Name: in.DisplayName, Status: true}
+	if err := b.store.Role().Create(ctx, role); err != nil {
+		log.W(ctx).Errorw("Failed to create SCIM group", "name", in.DisplayName, "tenant_id", tenantID, "err", err)
+		return nil, errno.ErrDBWrite.WithMessage("Failed to create role")
+	}
+	if err := b.syncMembers(ctx, tenantID, role.ID, members); err != nil {
+		// 成员写入失败时撤销创建，避免 IdP 重试时组名冲突
+		if rollbackErr := b.removeGroup(ctx, tenantID, role); rollbackErr != nil {
+			log.W(ctx).Errorw("Failed to roll back SCIM group", "role_id", role.ID, "err", rollbackErr)
+		}
+		return nil, err
+	}
+
+	log.W(ctx).Infow("SCIM group provisioned", "role_id", role.ID, "name", role.Name, "tenant_id", tenantID)
+	return b.reloadGroup(ctx, tenantID, role.ID)
+}
+
+// ReplaceGroup 使用请求中的资源整体替换组名和成员.
+func (b *scimBiz) ReplaceGroup(ctx context.Context, id string, in *scim.Group, ifMatch string) (*scim.Group, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	role, err := b.getRole(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !scim.MatchETag(ifMatch, scim.ETag(role.UpdatedAt)) {
+		return nil, scim.ErrPreconditionFailed()
+	}
+	return b.updateGroup(ctx, tenantID, role, in)
+}
+
+// PatchGroup 对组执行 PATCH 操作，常用于增删成员.
+func (b *scimBiz) PatchGroup(ctx context.Context, id string, rq *scim.PatchRequest, ifMatch string) (*scim.Group, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	role, err := b.getRole(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !scim.MatchETag(ifMatch, scim.ETag(role.UpdatedAt)) {
+		return nil, scim.ErrPreconditionFailed()
+	}
+
+	resource, err := scim.ToMap(b.toGroup(ctx, tenantID, role, true))
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+	if err := scim.ApplyPatch(resource, rq.Operations); err != nil {
+		return nil, err
+	}
+	var in scim.Group
+	if err := scim.FromMap(resource, &in); err != nil {
+		return nil, err
+	}
+	return b.updateGroup(ctx, tenantID, role, &in)
+}
+
+// DeleteGroup 删除角色及其在 Casbin 中的成员关系和权限.
+func (b *scimBiz) DeleteGroup(ctx context.Context, id string, ifMatch string) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	role, err := b.getRole(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if !scim.MatchETag(ifMatch, scim.ETag(role.UpdatedAt)) {
+		return scim.ErrPreconditionFailed()
+	}
+
+	if err := b.removeGroup(ctx, tenantID, role); err != nil {
+		return err
+	}
+
+	log.W(ctx).Infow("SCIM group deleted", "role_id", role.ID, "tenant_id", tenantID)
+	return nil
+}
+
+// removeGroup 先移除角色在租户下的权限、成员和继承规则，再删除角色，避免留下孤立的 Casbin 规则
+func (b *scimBiz) removeGroup(ctx context.Context, tenantID int64, role *model.RoleM) error {
+	if b.authz != nil {
+		if err := b.authz.RemoveRole(role.ID, tenantID); err != nil {
+			log.W(ctx).Errorw("Failed to remove role rules from Casbin", "role_id", role.ID, "tenant_id", tenantID, "err", err)
+			return errno.ErrInternal.WithMessage(err.Error())
+		}
+		b.authz.InvalidateResolver()
+	}
+
+	if err := b.store.Role().Delete(ctx, where.F("id", role.ID)); err != nil {
+		log.W(ctx).Errorw("Failed to delete SCIM group", "role_id", role.ID, "err", err)
+		return errno.ErrDBWrite.WithMessage("Failed to delete role")
+	}
+	return nil
+}
+
+// updateGroup 更新组名并同步成员
+func (b *scimBiz) updateGroup(ctx context.Context, tenantID int64, role *model.RoleM, in *scim.Group) (*scim.Group, error) {
+	if in.DisplayName == "" {
+		return nil, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "displayName is required")
+	}
+	if in.DisplayName != role.Name {
+		exists, err := b.store.Role().CheckNameExists(ctx, in.DisplayName, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, scim.NewError(http.StatusConflict, scim.ErrTypeUniqueness, "group %q already exists", in.DisplayName)
+		}
+	}
+	members, err := b.memberIDs(ctx, tenantID, in.Members)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.checkMembers(ctx, tenantID, role.ID, members); err != nil {
+		return nil, err
+	}
+
+	// 成员关系保存在 Casbin 中，同时更新 updated_at 以刷新组的版本
+	if err := b.store.DB(ctx).Model(&model.RoleM{}).Where("id = ?", role.ID).
+		Updates(map[string]any{"name": in.DisplayName, "updated_at": time.Now()}).Error; err != nil {
+		log.W(ctx).Errorw("Failed to update SCIM group", "role_id", role.ID, "err", err)
+		return nil, errno.ErrDBWrite.WithMessage("Failed to update role")
+	}
+	if err := b.syncMembers(ctx, tenantID, role.ID, members); err != nil {
+		return nil, err
+	}
+
+	return b.reloadGroup(ctx, tenantID, role.ID)
+}
+
+// checkMembers 检查新增的成员是否违反职责分离规则，违反时不修改组
+func (b *scimBiz) checkMembers(ctx context.Context, tenantID, roleID int64, desired []int64) error {
+	if b.authz == nil {
+		return nil
+	}
+
+	current := make(map[int64]bool)
+	for _, id := range b.roleMembers(ctx, tenantID, roleID) {
+		current[id] = true
+	}
+	var rules [][]string
+	for _, id := range desired {
+		if !current[id] {
+			rules = append(rules, []string{fmt.Sprintf("u%d", id), fmt.Sprintf("r%d", roleID), fmt.Sprintf("t%d", tenantID)})
+		}
+	}
+	return membershipError(b.authz.CheckSoD(rules))
+}
+
+// membershipError 将成员变更的错误转换为 SCIM 错误，违反职责分离规则时返回 400
+func membershipError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if authz.IsSoDViolation(err) {
+		return scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "%s", err.Error())
+	}
+	return errno.ErrInternal.WithMessage(err.Error())
+}
+
+// syncMembers 将角色成员调整为指定的用户集合，任一成员写入失败时返回错误
+func (b *scimBiz) syncMembers(ctx context.Context, tenantID, roleID int64, desired []int64) error {
+	if b.authz == nil {
+		return nil
+	}
+
+	current := make(map[int64]bool)
+	for _, id := range b.roleMembers(ctx, tenantID, roleID) {
+		current[id] = true
+	}
+
+	want := make(map[int64]bool, len(desired))
+	for _, id := range desired {
+		want[id] = true
+		if current[id] {
+			continue
+		}
+		if err := b.authz.AddRoleIDForUser(id, roleID, tenantID); err != nil {
+			log.W(ctx).Errorw("Failed to add group member", "user_id", id, "role_id", roleID, "tenant_id", tenantID, "err", err)
+			return membershipError(err)
+		}
+	}
+	for id := range current {
+		if want[id] {
+			continue
+		}
+		if err := b.authz.RemoveRoleIDForUser(id, roleID, tenantID); err != nil {
+			log.W(ctx).Errorw("Failed to remove group member", "user_id", id, "role_id", roleID, "tenant_id", tenantID, "err", err)
+			return membershipError(err)
+		}
+	}
+	return nil
+}
+
+// memberIDs 校验成员都是租户内的用户，并返回用户ID
+func (b *scimBiz) memberIDs(ctx context.Context, tenantID int64, members []scim.Member) ([]int64, error) {
+	if len(members) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(members))
+	for _, m := range members {
+		if m.Type != "" && !strings.EqualFold(m.Type, "User") {
+			return nil, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "nested groups are not supported")
+		}
+		id, err := strconv.ParseInt(m.Value, 10, 64)
+		if err != nil {
+			return nil, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "member %q is not a user id", m.Value)
+		}
+		ids = append(ids, id)
+	}
+
+	var count int64
+	if err := b.store.DB(ctx).Model(&model.UserTenantM{}).
+		Where("tenant_id = ? AND user_id IN ?", tenantID, ids).
+		Distinct("user_id").Count(&count).Error; err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if int(count) != len(uniqueIDs(ids)) {
+		return nil, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "group members must be users of this tenant")
+	}
+	return ids, nil
+}
+
+// roleMembers 返回角色在租户内的成员用户ID
+func (b *scimBiz) roleMembers(ctx context.Context, tenantID, roleID int64) []int64 {
+	if b.authz == nil {
+		return nil
+	}
+
+	users, err := b.authz.GetUsersForRole(fmt.Sprintf("r%d", roleID), strconv.FormatInt(tenantID, 10))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get role members", "role_id", roleID, "tenant_id", tenantID, "err", err)
+		return nil
+	}
+	ids := make([]int64, 0, len(users))
+	for _, u := range users {
+		if id, err := strconv.ParseInt(u, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// getRole 获取租户内的普通角色
+func (b *scimBiz) getRole(ctx context.Context, tenantID int64, id string) (*model.RoleM, error) {
+	roleID, err := parseID("Group", id)
+	if err != nil {
+		return nil, err
+	}
+	role, err := b.store.Role().Get(ctx, where.F("id", roleID, "tenant_id", tenantID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, scim.ErrNotFound("Group", id)
+		}
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	// 系统内置角色不由 IdP 管理，按不存在处理，避免 IdP 删除租户所有者角色或向其添加成员
+	if role.SystemRole != nil {
+		return nil, scim.ErrNotFound("Group", id)
+	}
+	return role, nil
+}
+
+// reloadGroup 重新读取组，返回写入后的最新版本
+func (b *scimBiz) reloadGroup(ctx context.Context, tenantID, roleID int64) (*scim.Group, error) {
+	role, err := b.getRole(ctx, tenantID, strconv.FormatInt(roleID, 10))
+	if err != nil {
+		return nil, err
+	}
+	return b.toGroup(ctx, tenantID, role, true), nil
+}
+
+// toGroup 转换为 SCIM 组资源
+func (b *scimBiz) toGroup(ctx context.Context, tenantID int64, role *model.RoleM, withMembers bool) *scim.Group {
+	out := &scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          strconv.FormatInt(role.ID, 10),
+		DisplayName: role.Name,
+		Meta: &scim.Meta{
+			ResourceType: "Group",
+			Created:      &role.CreatedAt,
+			LastModified: &role.UpdatedAt,
+			Version:      scim.ETag(role.UpdatedAt),
+		},
+	}
+	if !withMembers {
+		return out
+	}
+
+	ids := b.roleMembers(ctx, tenantID, role.ID)
+	if len(ids) == 0 {
+		return out
+	}
+	var users []*model.UserM
+	if err := b.store.DB(ctx).Where("id IN ?", ids).Find(&users).Error; err != nil {
+		log.W(ctx).Errorw("Failed to load group members", "role_id", role.ID, "err", err)
+	}
+	names := make(map[int64]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Username
+	}
+	for _, id := range ids {
+		out.Members = append(out.Members, scim.Member{Value: strconv.FormatInt(id, 10), Display: names[id], Type: "User"})
+	}
+	return out
+}
+
+// uniqueIDs 去除重复的ID
+func uniqueIDs(ids []int64) map[int64]bool {
+	set := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
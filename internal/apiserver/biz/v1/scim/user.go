@@ -0,0 +1,558 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	"github.com/ashwinyue/one-auth/pkg/authn"
+	"github.com/ashwinyue/one-auth/pkg/scim"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// maxNicknameLength 用户昵称的最大长度
+const maxNicknameLength = 30
+
+// userColumns 用户过滤属性到数据库列的映射
+var userColumns = scim.Columns{
+	"id":                 "`user`.id",
+	"username":           "`user`.username",
+	"displayname":        "`user`.nickname",
+	"name.formatted":     "`user`.nickname",
+	"emails.value":       "`user`.email",
+	"phonenumbers.value": "`user`.phone",
+	"externalid":         "user_tenants.external_id",
+	"active":             "user_tenants.status",
+	"meta.created":       "`user`.created_at",
+	"meta.lastmodified":  "`user`.updated_at",
+}
+
+// tenantUser 租户内的用户及其租户关联
+type tenantUser struct {
+	user   *model.UserM
+	tenant *model.UserTenantM
+}
+
+// version 返回用户资源版本
+func (u *tenantUser) version() string {
+	return scim.ETag(u.lastModified())
+}
+
+func (u *tenantUser) lastModified() time.Time {
+	if u.tenant.UpdatedAt.After(u.user.UpdatedAt) {
+		return u.tenant.UpdatedAt
+	}
+	return u.user.UpdatedAt
+}
+
+// ListUsers 查询租户内的用户.
+func (b *scimBiz) ListUsers(ctx context.Context, q *scim.ListQuery) (*scim.ListResponse, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := b.store.DB(ctx).Model(&model.UserM{}).
+		Joins("JOIN user_tenants ON user_tenants.user_id = `user`.id AND user_tenants.deleted_at IS NULL").
+		Where("user_tenants.tenant_id = ?", tenantID)
+	if q.Filter != "" {
+		f, err := scim.ParseFilter(q.Filter)
+		if err != nil {
+			return nil, err
+		}
+		cond, args, err := scim.ToSQL(f, userColumns)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where(cond, args...)
+	}
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		log.W(ctx).Errorw("Failed to count SCIM users", "tenant_id", tenantID, "err", err)
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	startIndex, offset, limit := q.Page()
+	var ids []int64
+	if limit > 0 {
+		if err := db.Order("`user`.id").Offset(offset).Limit(limit).Pluck("`user`.id", &ids).Error; err != nil {
+			log.W(ctx).Errorw("Failed to list SCIM users", "tenant_id", tenantID, "err", err)
+			return nil, errno.ErrDBRead.WithMessage(err.Error())
+		}
+	}
+
+	users, err := b.loadUsers(ctx, tenantID, ids)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]any, 0, len(users))
+	for _, u := range users {
+		resources = append(resources, b.toUser(ctx, tenantID, u))
+	}
+	return scim.NewListResponse(total, startIndex, resources), nil
+}
+
+// GetUser 获取租户内的用户.
+func (b *scimBiz) GetUser(ctx context.Context, id string) (*scim.User, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := b.getUser(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return b.toUser(ctx, tenantID, u), nil
+}
+
+// CreateUser 在租户内创建用户，字段映射与普通用户注册一致.
+func (b *scimBiz) CreateUser(ctx context.Context, in *scim.User) (*scim.User, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.UserName == "" {
+		return nil, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "userName is required")
+	}
+
+	// 用户名全局唯一
+	if _, err := b.store.User().Get(ctx, where.F("username", in.UserName)); err == nil {
+		return nil, scim.NewError(http.StatusConflict, scim.ErrTypeUniqueness, "userName %q already exists", in.UserName)
+	}
+	phone, err := b.normalizePhone(ctx, tenantID, 0, in.PrimaryPhone())
+	if err != nil {
+		return nil, err
+	}
+
+	password := in.Password
+	if password == "" {
+		password = randomPassword()
+	}
+	userM := &model.UserM{
+		Username: in.UserName,
+		Password: password,
+		Nickname: nickname(in),
+		Email:    in.PrimaryEmail(),
+		Phone:    phone,
+	}
+	active := in.IsActive()
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.User().Create(ctx, userM); err != nil {
+			log.W(ctx).Errorw("Failed to create SCIM user", "username", userM.Username, "err", err)
+			return errno.ErrDBWrite.WithMessage("Failed to create user")
+		}
+
+		if err := b.syncAuthIDs(ctx, tenantID, userM, active); err != nil {
+			return err
+		}
+
+		userTenant := &model.UserTenantM{
+			UserID:     userM.ID,
+			TenantID:   tenantID,
+			Status:     active,
+			ExternalID: optionalString(in.ExternalID),
+		}
+		if err := b.store.DB(ctx).Create(userTenant).Error; err != nil {
+			return fmt.Errorf("failed to create user tenant relation: %w", err)
+		}
+		// Status 的零值会被 GORM 忽略而使用列默认值，停用的用户需要单独更新
+		if !active {
+			return b.store.DB(ctx).Model(userTenant).UpdateColumn("status", false).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.W(ctx).Infow("SCIM user provisioned", "user_id", userM.ID, "username", userM.Username, "tenant_id", tenantID)
+
+	u, err := b.getUser(ctx, tenantID, strconv.FormatInt(userM.ID, 10))
+	if err != nil {
+		return nil, err
+	}
+	return b.toUser(ctx, tenantID, u), nil
+}
+
+// ReplaceUser 使用请求中的资源整体替换用户.
+func (b *scimBiz) ReplaceUser(ctx context.Context, id string, in *scim.User, ifMatch string) (*scim.User, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := b.getUser(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !scim.MatchETag(ifMatch, u.version()) {
+		return nil, scim.ErrPreconditionFailed()
+	}
+	return b.updateUser(ctx, tenantID, u, in)
+}
+
+// PatchUser 对用户执行 PATCH 操作.
+func (b *scimBiz) PatchUser(ctx context.Context, id string, rq *scim.PatchRequest, ifMatch string) (*scim.User, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := b.getUser(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !scim.MatchETag(ifMatch, u.version()) {
+		return nil, scim.ErrPreconditionFailed()
+	}
+
+	resource, err := scim.ToMap(b.toUser(ctx, tenantID, u))
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+	if err := scim.ApplyPatch(resource, rq.Operations); err != nil {
+		return nil, err
+	}
+	var in scim.User
+	if err := scim.FromMap(resource, &in); err != nil {
+		return nil, err
+	}
+	return b.updateUser(ctx, tenantID, u, &in)
+}
+
+// DeleteUser 停用用户并撤销其会话.
+func (b *scimBiz) DeleteUser(ctx context.Context, id string, ifMatch string) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	u, err := b.getUser(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if !scim.MatchETag(ifMatch, u.version()) {
+		return scim.ErrPreconditionFailed()
+	}
+
+	if err := b.store.TX(ctx, func(ctx context.Context) error {
+		return b.setActive(ctx, tenantID, u.user.ID, false)
+	}); err != nil {
+		return err
+	}
+	b.kickSessions(ctx, u.user.ID)
+
+	log.W(ctx).Infow("SCIM user deprovisioned", "user_id", u.user.ID, "tenant_id", tenantID)
+	return nil
+}
+
+// updateUser 将请求中的资源写入用户，active 从 true 变为 false 时撤销会话
+func (b *scimBiz) updateUser(ctx context.Context, tenantID int64, u *tenantUser, in *scim.User) (*scim.User, error) {
+	if in.UserName == "" {
+		return nil, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "userName is required")
+	}
+	if in.UserName != u.user.Username {
+		if other, err := b.store.User().Get(ctx, where.F("username", in.UserName)); err == nil && other.ID != u.user.ID {
+			return nil, scim.NewError(http.StatusConflict, scim.ErrTypeUniqueness, "userName %q already exists", in.UserName)
+		}
+	}
+	phone, err := b.normalizePhone(ctx, tenantID, u.user.ID, in.PrimaryPhone())
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]any{
+		"username": in.UserName,
+		"nickname": nickname(in),
+		"email":    in.PrimaryEmail(),
+		"phone":    phone,
+	}
+	if in.Password != "" {
+		password, err := authn.Encrypt(in.Password)
+		if err != nil {
+			return nil, errno.ErrInternal.WithMessage(err.Error())
+		}
+		updates["password"] = password
+	}
+
+	active := in.IsActive()
+	deactivated := u.tenant.Status && !active
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.DB(ctx).Model(&model.UserM{}).Where("id = ?", u.user.ID).Updates(updates).Error; err != nil {
+			log.W(ctx).Errorw("Failed to update SCIM user", "user_id", u.user.ID, "err", err)
+			return errno.ErrDBWrite.WithMessage("Failed to update user")
+		}
+
+		userM := *u.user
+		userM.Username, userM.Email, userM.Phone = in.UserName, in.PrimaryEmail(), phone
+		if err := b.syncAuthIDs(ctx, tenantID, &userM, active); err != nil {
+			return err
+		}
+
+		if err := b.store.DB(ctx).Model(&model.UserTenantM{}).
+			Where("user_id = ? AND tenant_id = ?", u.user.ID, tenantID).
+			UpdateColumn("external_id", optionalString(in.ExternalID)).Error; err != nil {
+			return errno.ErrDBWrite.WithMessage(err.Error())
+		}
+		return b.setActive(ctx, tenantID, u.user.ID, active)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if deactivated {
+		b.kickSessions(ctx, u.user.ID)
+		log.W(ctx).Infow("SCIM user deactivated", "user_id", u.user.ID, "tenant_id", tenantID)
+	}
+
+	updated, err := b.getUser(ctx, tenantID, strconv.FormatInt(u.user.ID, 10))
+	if err != nil {
+		return nil, err
+	}
+	return b.toUser(ctx, tenantID, updated), nil
+}
+
+// setActive 启用或停用用户在租户内的关联以及该租户下的认证方式，锁定和封禁状态保持不变
+func (b *scimBiz) setActive(ctx context.Context, tenantID, userID int64, active bool) error {
+	from, to := model.UserStatusActive, model.UserStatusInactive
+	if active {
+		from, to = to, from
+	}
+
+	if err := b.store.DB(ctx).Model(&model.UserStatusM{}).
+		Where("user_id = ? AND tenant_id = ? AND status = ?", userID, tenantID, int32(from)).
+		UpdateColumn("status", int32(to)).Error; err != nil {
+		log.W(ctx).Errorw("Failed to update user status", "user_id", userID, "tenant_id", tenantID, "err", err)
+		return errno.ErrDBWrite.WithMessage("Failed to update user status")
+	}
+	if err := b.store.DB(ctx).Model(&model.UserTenantM{}).
+		Where("user_id = ? AND tenant_id = ?", userID, tenantID).
+		Updates(map[string]any{"status": active, "updated_at": time.Now()}).Error; err != nil {
+		log.W(ctx).Errorw("Failed to update user tenant status", "user_id", userID, "tenant_id", tenantID, "err", err)
+		return errno.ErrDBWrite.WithMessage("Failed to update user tenant status")
+	}
+	return nil
+}
+
+// syncAuthIDs 同步用户名、邮箱和手机号三种认证方式
+func (b *scimBiz) syncAuthIDs(ctx context.Context, tenantID int64, userM *model.UserM, active bool) error {
+	if err := b.syncAuthID(ctx, tenantID, userM.ID, model.AuthTypeUsername, userM.Username, active); err != nil {
+		return err
+	}
+	if err := b.syncAuthID(ctx, tenantID, userM.ID, model.AuthTypeEmail, userM.Email, active); err != nil {
+		return err
+	}
+	return b.syncAuthID(ctx, tenantID, userM.ID, model.AuthTypePhone, userM.Phone, active)
+}
+
+// syncAuthID 同步用户某种认证方式的标识，邮箱已被其他用户使用时不写入
+func (b *scimBiz) syncAuthID(ctx context.Context, tenantID, userID int64, authType model.AuthType, authID string, active bool) error {
+	existing, err := b.store.UserStatus().Get(ctx, where.F("user_id", userID, "auth_type", int32(authType)))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	if authID == "" {
+		if existing != nil {
+			// 物理删除，避免软删除的记录占用唯一索引
+			return b.store.DB(ctx).Unscoped().Delete(existing).Error
+		}
+		return nil
+	}
+	if existing != nil && existing.AuthID == authID {
+		return nil
+	}
+
+	if other, err := b.store.UserStatus().Get(ctx, where.F("auth_id", authID, "auth_type", int32(authType))); err == nil && other.UserID != userID {
+		if authType == model.AuthTypeEmail {
+			log.W(ctx).Warnw("Email already used by another user, skip email login", "user_id", userID, "email", authID)
+			return nil
+		}
+		return scim.NewError(http.StatusConflict, scim.ErrTypeUniqueness, "%s is already used by another user", authID)
+	}
+
+	if existing != nil {
+		return b.store.DB(ctx).Model(existing).UpdateColumn("auth_id", authID).Error
+	}
+
+	status := model.UserStatusActive
+	if !active {
+		status = model.UserStatusInactive
+	}
+	now := time.Now()
+	return b.store.UserStatus().Create(ctx, &model.UserStatusM{
+		AuthID:   authID,
+		AuthType: int32(authType),
+		UserID:   userID,
+		TenantID: tenantID,
+		Status:   int32(status),
+		// 由身份源供应的标识视为已验证
+		IsVerified: true,
+		VerifiedAt: &now,
+		IsPrimary:  authType == model.AuthTypeUsername,
+	})
+}
+
+// normalizePhone 规范化手机号，非法手机号不写入，已被其他用户使用时返回冲突
+func (b *scimBiz) normalizePhone(ctx context.Context, tenantID, userID int64, phone string) (string, error) {
+	if phone == "" || b.smsClient == nil {
+		return "", nil
+	}
+	p, err := b.smsClient.NormalizePhone(strconv.FormatInt(tenantID, 10), phone)
+	if err != nil {
+		log.W(ctx).Warnw("Invalid phone number from SCIM client, skip", "phone", phone, "err", err)
+		return "", nil
+	}
+	if other, err := b.store.User().Get(ctx, where.F("phone", p)); err == nil && other.ID != userID {
+		return "", scim.NewError(http.StatusConflict, scim.ErrTypeUniqueness, "phone number %s is already used by another user", p)
+	}
+	return p, nil
+}
+
+// getUser 获取租户内的用户
+func (b *scimBiz) getUser(ctx context.Context, tenantID int64, id string) (*tenantUser, error) {
+	userID, err := parseID("User", id)
+	if err != nil {
+		return nil, err
+	}
+	users, err := b.loadUsers(ctx, tenantID, []int64{userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, scim.ErrNotFound("User", id)
+	}
+	return users[0], nil
+}
+
+// loadUsers 按ID批量加载租户内的用户，结果按ID排序
+func (b *scimBiz) loadUsers(ctx context.Context, tenantID int64, ids []int64) ([]*tenantUser, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var userTenants []*model.UserTenantM
+	if err := b.store.DB(ctx).Where("tenant_id = ? AND user_id IN ?", tenantID, ids).Find(&userTenants).Error; err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	var users []*model.UserM
+	if err := b.store.DB(ctx).Where("id IN ?", ids).Order("id").Find(&users).Error; err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	byUser := make(map[int64]*model.UserTenantM, len(userTenants))
+	for _, ut := range userTenants {
+		byUser[ut.UserID] = ut
+	}
+	result := make([]*tenantUser, 0, len(users))
+	for _, u := range users {
+		if ut, ok := byUser[u.ID]; ok {
+			result = append(result, &tenantUser{user: u, tenant: ut})
+		}
+	}
+	return result, nil
+}
+
+// toUser 转换为 SCIM 用户资源
+func (b *scimBiz) toUser(ctx context.Context, tenantID int64, u *tenantUser) *scim.User {
+	active := u.tenant.Status
+	lastModified := u.lastModified()
+	out := &scim.User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          strconv.FormatInt(u.user.ID, 10),
+		UserName:    u.user.Username,
+		DisplayName: u.user.Nickname,
+		Active:      &active,
+		Groups:      b.userGroups(ctx, tenantID, u.user.ID),
+		Meta: &scim.Meta{
+			ResourceType: "User",
+			Created:      &u.user.CreatedAt,
+			LastModified: &lastModified,
+			Version:      u.version(),
+		},
+	}
+	if u.tenant.ExternalID != nil {
+		out.ExternalID = *u.tenant.ExternalID
+	}
+	if u.user.Email != "" {
+		out.Emails = []scim.MultiValue{{Value: u.user.Email, Type: "work", Primary: true}}
+	}
+	if u.user.Phone != "" {
+		out.PhoneNumbers = []scim.MultiValue{{Value: u.user.Phone, Type: "mobile", Primary: true}}
+	}
+	return out
+}
+
+// userGroups 返回用户在租户内的普通角色
+func (b *scimBiz) userGroups(ctx context.Context, tenantID, userID int64) []scim.MultiValue {
+	if b.authz == nil {
+		return nil
+	}
+
+	subjects, err := b.authz.SyncedCachedEnforcer.GetRolesForUser(fmt.Sprintf("u%d", userID), fmt.Sprintf("t%d", tenantID))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get user roles", "user_id", userID, "tenant_id", tenantID, "err", err)
+		return nil
+	}
+	var roleIDs []int64
+	for _, s := range subjects {
+		if id, err := strconv.ParseInt(strings.TrimPrefix(s, "r"), 10, 64); err == nil {
+			roleIDs = append(roleIDs, id)
+		}
+	}
+	if len(roleIDs) == 0 {
+		return nil
+	}
+
+	roles, err := b.store.Role().GetRolesByIDs(ctx, roleIDs)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to get roles", "role_ids", roleIDs, "err", err)
+		return nil
+	}
+	groups := make([]scim.MultiValue, 0, len(roles))
+	for _, r := range roles {
+		// 系统内置角色不作为组暴露，与组接口保持一致
+		if r.SystemRole != nil {
+			continue
+		}
+		groups = append(groups, scim.MultiValue{Value: strconv.FormatInt(r.ID, 10), Display: r.Name, Type: "direct"})
+	}
+	return groups
+}
+
+// nickname 按 displayName、name.formatted、姓名、userName 的顺序取昵称
+func nickname(in *scim.User) string {
+	var formatted, full string
+	if in.Name != nil {
+		formatted = in.Name.Formatted
+		full = strings.TrimSpace(in.Name.GivenName + " " + in.Name.FamilyName)
+	}
+	name := firstNonEmpty(in.DisplayName, formatted, full, in.UserName)
+	if r := []rune(name); len(r) > maxNicknameLength {
+		name = string(r[:maxNicknameLength])
+	}
+	return name
+}
+
+// optionalString 空字符串返回 nil
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,284 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package sod
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// SoDBiz 定义了静态职责分离规则的业务逻辑接口.
+type SoDBiz interface {
+	List(ctx context.Context, rq *apiv1.ListSoDRulesRequest) (*apiv1.ListSoDRulesResponse, error)
+	Create(ctx context.Context, rq *apiv1.CreateSoDRuleRequest) (*apiv1.CreateSoDRuleResponse, error)
+	Update(ctx context.Context, rq *apiv1.UpdateSoDRuleRequest) (*apiv1.UpdateSoDRuleResponse, error)
+	Delete(ctx context.Context, rq *apiv1.DeleteSoDRuleRequest) (*apiv1.DeleteSoDRuleResponse, error)
+
+	// ListViolations 获取当前违反职责分离规则的角色分配
+	ListViolations(ctx context.Context, rq *apiv1.ListSoDViolationsRequest) (*apiv1.ListSoDViolationsResponse, error)
+}
+
+// sodBiz 是 SoDBiz 接口的实现.
+type sodBiz struct {
+	store store.IStore
+	authz *authz.Authz
+}
+
+// 确保 sodBiz 实现了 SoDBiz 接口.
+var _ SoDBiz = (*sodBiz)(nil)
+
+// New 创建一个新的 SoDBiz 实例.
+func New(store store.IStore, authz *authz.Authz) *sodBiz {
+	return &sodBiz{store: store, authz: authz}
+}
+
+// List 获取当前租户的职责分离规则
+func (b *sodBiz) List(ctx context.Context, rq *apiv1.ListSoDRulesRequest) (*apiv1.ListSoDRulesResponse, error) {
+	_, rules, err := b.store.SoDRule().List(ctx, where.F("tenant_id", currentTenantID(ctx)))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	resp := &apiv1.ListSoDRulesResponse{}
+	for _, ruleM := range rules {
+		resp.Rules = append(resp.Rules, convertRuleToAPI(ruleM))
+	}
+	return resp, nil
+}
+
+// Create 创建职责分离规则，已存在的违规分配不会被移除，随响应一并返回
+func (b *sodBiz) Create(ctx context.Context, rq *apiv1.CreateSoDRuleRequest) (*apiv1.CreateSoDRuleResponse, error) {
+	tenantID := currentTenantID(ctx)
+	ruleM := &model.SoDRuleM{TenantID: tenantID, Kind: rq.Kind}
+	if err := b.fill(ctx, ruleM, rq.Name, rq.RoleIds, rq.MaxCount, rq.Description); err != nil {
+		return nil, err
+	}
+
+	if err := b.store.SoDRule().Create(ctx, ruleM); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	b.authz.InvalidateSoDRules()
+
+	violations, err := b.violations(tenantID, ruleM.ID)
+	if err != nil {
+		return nil, err
+	}
+	log.W(ctx).Infow("SoD rule created", "rule_id", ruleM.ID, "tenant_id", tenantID, "kind", ruleM.Kind,
+		"role_ids", ruleM.RoleIDs, "violations", len(violations))
+	return &apiv1.CreateSoDRuleResponse{Rule: convertRuleToAPI(ruleM), Violations: violations}, nil
+}
+
+// Update 更新职责分离规则的名称、角色和上限，规则类型不允许修改
+func (b *sodBiz) Update(ctx context.Context, rq *apiv1.UpdateSoDRuleRequest) (*apiv1.UpdateSoDRuleResponse, error) {
+	tenantID := currentTenantID(ctx)
+	ruleM, err := b.get(ctx, tenantID, rq.RuleId)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.fill(ctx, ruleM, rq.Name, rq.RoleIds, rq.MaxCount, rq.Description); err != nil {
+		return nil, err
+	}
+
+	if err := b.store.SoDRule().Update(ctx, ruleM); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	b.authz.InvalidateSoDRules()
+
+	violations, err := b.violations(tenantID, ruleM.ID)
+	if err != nil {
+		return nil, err
+	}
+	log.W(ctx).Infow("SoD rule updated", "rule_id", ruleM.ID, "tenant_id", tenantID, "role_ids", ruleM.RoleIDs,
+		"violations", len(violations))
+	return &apiv1.UpdateSoDRuleResponse{Rule: convertRuleToAPI(ruleM), Violations: violations}, nil
+}
+
+// Delete 删除职责分离规则
+func (b *sodBiz) Delete(ctx context.Context, rq *apiv1.DeleteSoDRuleRequest) (*apiv1.DeleteSoDRuleResponse, error) {
+	tenantID := currentTenantID(ctx)
+	if _, err := b.get(ctx, tenantID, rq.RuleId); err != nil {
+		return nil, err
+	}
+	if err := b.store.SoDRule().Delete(ctx, where.F("id", rq.RuleId, "tenant_id", tenantID)); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage(err.Error())
+	}
+	b.authz.InvalidateSoDRules()
+
+	log.W(ctx).Infow("SoD rule deleted", "rule_id", rq.RuleId, "tenant_id", tenantID)
+	return &apiv1.DeleteSoDRuleResponse{}, nil
+}
+
+// ListViolations 获取当前租户违反职责分离规则的角色分配，包括直接修改 casbin_rule 表产生的违规
+func (b *sodBiz) ListViolations(ctx context.Context, rq *apiv1.ListSoDViolationsRequest) (*apiv1.ListSoDViolationsResponse, error) {
+	tenantID := currentTenantID(ctx)
+	if rq.RuleId != 0 {
+		if _, err := b.get(ctx, tenantID, rq.RuleId); err != nil {
+			return nil, err
+		}
+	}
+
+	violations, err := b.violations(tenantID, rq.RuleId)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.ListSoDViolationsResponse{Violations: violations}, nil
+}
+
+// get 获取当前租户的职责分离规则
+func (b *sodBiz) get(ctx context.Context, tenantID, ruleID int64) (*model.SoDRuleM, error) {
+	ruleM, err := b.store.SoDRule().Get(ctx, where.F("id", ruleID, "tenant_id", tenantID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrSoDRuleNotFound
+		}
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	return ruleM, nil
+}
+
+// fill 校验并设置规则的名称、角色和上限
+func (b *sodBiz) fill(ctx context.Context, ruleM *model.SoDRuleM, name string, roleIDs []int64, maxCount int32, description string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errno.ErrInvalidArgument.WithMessage("name cannot be empty")
+	}
+	if name != ruleM.Name {
+		count, _, err := b.store.SoDRule().List(ctx, where.F("tenant_id", ruleM.TenantID, "name", name))
+		if err != nil {
+			return errno.ErrDBRead.WithMessage(err.Error())
+		}
+		if count > 0 {
+			return errno.ErrInvalidArgument.WithMessage("SoD rule %q already exists", name)
+		}
+	}
+
+	roleIDs = uniqueIDs(roleIDs)
+	if maxCount == 0 {
+		maxCount = 1
+	}
+	if maxCount < 0 {
+		return errno.ErrInvalidArgument.WithMessage("max_count must be positive")
+	}
+	switch ruleM.Kind {
+	case authz.SoDKindExclusive:
+		if len(roleIDs) < 2 {
+			return errno.ErrInvalidArgument.WithMessage("exclusive rule requires at least 2 roles")
+		}
+		if int(maxCount) >= len(roleIDs) {
+			return errno.ErrInvalidArgument.WithMessage("max_count must be less than %d", len(roleIDs))
+		}
+	case authz.SoDKindCardinality:
+		if len(roleIDs) == 0 {
+			return errno.ErrInvalidArgument.WithMessage("role_ids cannot be empty")
+		}
+	default:
+		return errno.ErrInvalidArgument.WithMessage("kind must be %s or %s", authz.SoDKindExclusive, authz.SoDKindCardinality)
+	}
+
+	count, _, err := b.store.Role().List(store.WithoutDataScope(ctx), where.F("tenant_id", ruleM.TenantID).Q("id IN ?", roleIDs))
+	if err != nil {
+		return errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if int(count) != len(roleIDs) {
+		return errno.ErrInvalidArgument.WithMessage("role_ids must be roles of current tenant")
+	}
+
+	ruleM.Name = name
+	ruleM.RoleIDs = joinIDs(roleIDs)
+	ruleM.MaxCount = maxCount
+	if description != "" {
+		ruleM.Description = &description
+	} else {
+		ruleM.Description = nil
+	}
+	return nil
+}
+
+// violations 获取租户的违规分配，ruleID 不为 0 时只返回该规则的违规
+func (b *sodBiz) violations(tenantID, ruleID int64) ([]*apiv1.SoDViolation, error) {
+	violations, err := b.authz.SoDViolations(tenantID)
+	if err != nil {
+		return nil, errno.ErrRolePermissionConfiguration.WithMessage(err.Error())
+	}
+
+	result := make([]*apiv1.SoDViolation, 0, len(violations))
+	for _, v := range violations {
+		if ruleID != 0 && v.RuleID != ruleID {
+			continue
+		}
+		result = append(result, &apiv1.SoDViolation{
+			RuleId:   v.RuleID,
+			RuleName: v.RuleName,
+			Kind:     v.Kind,
+			UserIds:  v.UserIDs,
+			RoleIds:  v.RoleIDs,
+			MaxCount: int32(v.Limit),
+		})
+	}
+	return result, nil
+}
+
+// currentTenantID 从上下文获取租户ID，默认为 1
+func currentTenantID(ctx context.Context) int64 {
+	if tid, err := strconv.ParseInt(contextx.TenantID(ctx), 10, 64); err == nil && tid > 0 {
+		return tid
+	}
+	return 1 // 默认租户
+}
+
+// uniqueIDs 去除重复和无效的ID并排序
+func uniqueIDs(ids []int64) []int64 {
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id > 0 {
+			result = append(result, id)
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// joinIDs 将ID列表转换为逗号分隔的字符串
+func joinIDs(ids []int64) string {
+	items := make([]string, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(items, ",")
+}
+
+// convertRuleToAPI 转换职责分离规则模型为API格式
+func convertRuleToAPI(ruleM *model.SoDRuleM) *apiv1.SoDRule {
+	rule := &apiv1.SoDRule{
+		Id:        ruleM.ID,
+		Name:      ruleM.Name,
+		Kind:      ruleM.Kind,
+		RoleIds:   ruleM.GetRoleIDs(),
+		MaxCount:  ruleM.MaxCount,
+		CreatedAt: timestamppb.New(ruleM.CreatedAt),
+		UpdatedAt: timestamppb.New(ruleM.UpdatedAt),
+	}
+	if ruleM.Description != nil {
+		rule.Description = *ruleM.Description
+	}
+	return rule
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/scim"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// CreateSCIMToken 为租户创建 SCIM 访问令牌，明文只在响应中返回一次.
+func (b *tenantBiz) CreateSCIMToken(ctx context.Context, rq *apiv1.CreateSCIMTokenRequest) (*apiv1.CreateSCIMTokenResponse, error) {
+	if err := checkCurrentTenant(ctx, rq.GetTenantId()); err != nil {
+		return nil, err
+	}
+	if rq.GetExpiresInDays() < 0 {
+		return nil, errno.ErrInvalidArgument.WithMessage("expires_in_days must not be negative")
+	}
+
+	secret, prefix, hash, err := scim.GenerateToken()
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+	tokenM := &model.TenantSCIMTokenM{
+		TenantID:    rq.GetTenantId(),
+		Name:        rq.GetName(),
+		TokenHash:   hash,
+		TokenPrefix: prefix,
+		CreatedBy:   contextx.UserID(ctx),
+	}
+	if days := rq.GetExpiresInDays(); days > 0 {
+		expiresAt := time.Now().AddDate(0, 0, int(days))
+		tokenM.ExpiresAt = &expiresAt
+	}
+	if err := b.store.TenantSCIMToken().Create(ctx, tokenM); err != nil {
+		log.W(ctx).Errorw("Failed to create SCIM token", "tenant_id", rq.GetTenantId(), "err", err)
+		return nil, errno.ErrDBWrite.WithMessage("Failed to create SCIM token")
+	}
+
+	log.W(ctx).Infow("SCIM token created", "tenant_id", rq.GetTenantId(), "token_id", tokenM.ID, "prefix", prefix)
+	return &apiv1.CreateSCIMTokenResponse{Token: convertSCIMTokenToAPI(tokenM), Secret: secret}, nil
+}
+
+// ListSCIMTokens 获取租户的 SCIM 访问令牌列表.
+func (b *tenantBiz) ListSCIMTokens(ctx context.Context, rq *apiv1.ListSCIMTokensRequest) (*apiv1.ListSCIMTokensResponse, error) {
+	if err := checkCurrentTenant(ctx, rq.GetTenantId()); err != nil {
+		return nil, err
+	}
+
+	_, tokens, err := b.store.TenantSCIMToken().List(ctx, where.F("tenant_id", rq.GetTenantId()))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to list SCIM tokens", "tenant_id", rq.GetTenantId(), "err", err)
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	resp := &apiv1.ListSCIMTokensResponse{Tokens: make([]*apiv1.SCIMToken, 0, len(tokens))}
+	for _, t := range tokens {
+		resp.Tokens = append(resp.Tokens, convertSCIMTokenToAPI(t))
+	}
+	return resp, nil
+}
+
+// RevokeSCIMToken 吊销租户的 SCIM 访问令牌.
+func (b *tenantBiz) RevokeSCIMToken(ctx context.Context, rq *apiv1.RevokeSCIMTokenRequest) (*apiv1.RevokeSCIMTokenResponse, error) {
+	if err := checkCurrentTenant(ctx, rq.GetTenantId()); err != nil {
+		return nil, err
+	}
+
+	opts := where.F("id", rq.GetTokenId(), "tenant_id", rq.GetTenantId())
+	if _, err := b.store.TenantSCIMToken().Get(ctx, opts); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrInvalidArgument.WithMessage("SCIM token not found")
+		}
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if err := b.store.TenantSCIMToken().Delete(ctx, opts); err != nil {
+		log.W(ctx).Errorw("Failed to revoke SCIM token", "tenant_id", rq.GetTenantId(), "token_id", rq.GetTokenId(), "err", err)
+		return nil, errno.ErrDBWrite.WithMessage("Failed to revoke SCIM token")
+	}
+
+	log.W(ctx).Infow("SCIM token revoked", "tenant_id", rq.GetTenantId(), "token_id", rq.GetTokenId())
+	return &apiv1.RevokeSCIMTokenResponse{}, nil
+}
+
+// checkCurrentTenant 只允许管理当前工作租户的配置
+func checkCurrentTenant(ctx context.Context, tenantID int64) error {
+	if contextx.TenantID(ctx) != strconv.FormatInt(tenantID, 10) {
+		return errno.ErrPermissionDenied.WithMessage("can only manage the current tenant")
+	}
+	return nil
+}
+
+// convertSCIMTokenToAPI 转换 SCIM 令牌模型为API格式
+func convertSCIMTokenToAPI(t *model.TenantSCIMTokenM) *apiv1.SCIMToken {
+	token := &apiv1.SCIMToken{
+		Id:          t.ID,
+		TenantId:    t.TenantID,
+		Name:        t.Name,
+		TokenPrefix: t.TokenPrefix,
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+	}
+	if t.ExpiresAt != nil {
+		token.ExpiresAt = timestamppb.New(*t.ExpiresAt)
+	}
+	if t.LastUsedAt != nil {
+		token.LastUsedAt = timestamppb.New(*t.LastUsedAt)
+	}
+	return token
+}
@@ -46,6 +46,11 @@ type TenantBiz interface {
 
 	// 租户管理
 	ListTenants(ctx context.Context, rq *apiv1.ListTenantsRequest) (*apiv1.ListTenantsResponse, error)
+
+	// SCIM 访问令牌管理
+	CreateSCIMToken(ctx context.Context, rq *apiv1.CreateSCIMTokenRequest) (*apiv1.CreateSCIMTokenResponse, error)
+	ListSCIMTokens(ctx context.Context, rq *apiv1.ListSCIMTokensRequest) (*apiv1.ListSCIMTokensResponse, error)
+	RevokeSCIMToken(ctx context.Context, rq *apiv1.RevokeSCIMTokenRequest) (*apiv1.RevokeSCIMTokenResponse, error)
 }
 
 // tenantBiz 是 TenantBiz 接口的实现.
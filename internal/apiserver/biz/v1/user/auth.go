@@ -24,16 +24,34 @@ import (
 	"github.com/ashwinyue/one-auth/internal/pkg/log"
 	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
 	"github.com/ashwinyue/one-auth/pkg/authn"
+	"github.com/ashwinyue/one-auth/pkg/client/sms"
 	"github.com/ashwinyue/one-auth/pkg/store/where"
 	"github.com/ashwinyue/one-auth/pkg/token"
 )
 
 // Login 实现 UserBiz 接口中的 Login 方法.
 func (b *userBiz) Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.LoginResponse, error) {
+	// 手机号统一按 E.164 格式查找，LDAP 用户按 "租户ID:用户名" 查找
+	identifier := rq.GetIdentifier()
+	authType := model.StringToAuthType(rq.GetLoginType())
+	switch authType {
+	case model.AuthTypePhone:
+		// 使用配置的默认国家码规范化，与发送验证码和注册时保存的号码格式一致
+		if phone, err := b.normalizePhone(ctx, identifier, rq.GetTenantId()); err == nil {
+			identifier = phone
+		}
+	case model.AuthTypeLDAP:
+		tenantID, err := ldapTenantID(ctx, rq)
+		if err != nil {
+			return nil, err
+		}
+		identifier = ldapAuthID(tenantID, identifier)
+	}
+
 	// 检查登录安全限制
 	if b.loginSecurity != nil {
 		clientIP := getClientIP(ctx)
-		locked, reason, err := b.loginSecurity.CheckLoginAttempts(ctx, rq.GetIdentifier(), clientIP)
+		locked, reason, err := b.loginSecurity.CheckLoginAttempts(ctx, identifier, clientIP)
 		if err != nil {
 			log.W(ctx).Errorw("Failed to check login attempts", "err", err)
 		} else if locked {
@@ -47,10 +65,16 @@ func (b *userBiz) Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.Log
 	var err error
 
 	// 通过标识符查找用户
-	userM, userStatus, err = b.findUserByIdentifier(ctx, rq.GetIdentifier(), rq.GetLoginType())
+	authenticated := false
+	userM, userStatus, err = b.findUserByIdentifier(ctx, identifier, rq.GetLoginType())
+	if err != nil && authType == model.AuthTypeLDAP {
+		// 目录用户首次登录时即时创建账号，创建前已完成目录认证
+		userM, userStatus, err = b.provisionLDAPUser(ctx, rq)
+		authenticated = err == nil
+	}
 	if err != nil {
 		// 记录登录失败（用户不存在）
-		b.recordLoginAttempt(ctx, rq.GetIdentifier(), false)
+		b.recordLoginAttempt(ctx, identifier, false)
 		return nil, err
 	}
 
@@ -65,15 +89,22 @@ func (b *userBiz) Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.Log
 	}
 
 	// 验证登录凭证
-	if err := b.validateLoginCredentials(ctx, userM, rq); err != nil {
-		// 记录登录失败
-		b.recordLoginAttempt(ctx, strconv.FormatInt(userM.ID, 10), false)
-		return nil, err
+	if !authenticated {
+		if err := b.validateLoginCredentials(ctx, userM, identifier, rq); err != nil {
+			// 记录登录失败
+			b.recordLoginAttempt(ctx, strconv.FormatInt(userM.ID, 10), false)
+			return nil, err
+		}
 	}
 
 	// 登录成功，记录成功尝试
 	b.recordLoginAttempt(ctx, strconv.FormatInt(userM.ID, 10), true)
 
+	return b.issueLoginResponse(ctx, userM, userStatus, rq)
+}
+
+// issueLoginResponse 为认证通过的用户签发令牌、创建会话并构建登录响应.
+func (b *userBiz) issueLoginResponse(ctx context.Context, userM *model.UserM, userStatus *model.UserStatusM, rq *apiv1.LoginRequest) (*apiv1.LoginResponse, error) {
 	// 更新用户状态
 	if err := b.updateLoginSuccess(ctx, strconv.FormatInt(userM.ID, 10), rq); err != nil {
 		log.W(ctx).Errorw("Failed to update login success info", "user_id", userM.ID, "err", err)
@@ -155,11 +186,14 @@ func (b *userBiz) ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRe
 
 // SendVerifyCode 发送验证码
 func (b *userBiz) SendVerifyCode(ctx context.Context, rq *apiv1.SendVerifyCodeRequest) (*apiv1.SendVerifyCodeResponse, error) {
-	// 验证目标类型和格式
+	// 验证目标类型和格式，手机号规范化为 E.164
+	target := rq.GetTarget()
 	if rq.GetTargetType() == "phone" {
-		if !b.smsClient.IsValidPhone(rq.GetTarget()) {
-			return nil, errno.ErrInvalidArgument.WithMessage("Invalid phone number format")
+		phone, err := b.normalizePhone(ctx, target, rq.GetTenantId())
+		if err != nil {
+			return nil, err
 		}
+		target = phone
 	}
 
 	// 生成验证码
@@ -167,8 +201,8 @@ func (b *userBiz) SendVerifyCode(ctx context.Context, rq *apiv1.SendVerifyCodeRe
 
 	// 存储验证码到缓存
 	if b.loginSecurity != nil {
-		if err := b.loginSecurity.StoreVerifyCode(ctx, rq.GetTarget(), rq.GetCodeType(), code); err != nil {
-			log.W(ctx).Errorw("Failed to store verify code", "target", rq.GetTarget(), "err", err)
+		if err := b.loginSecurity.StoreVerifyCode(ctx, target, rq.GetCodeType(), code); err != nil {
+			log.W(ctx).Errorw("Failed to store verify code", "target", target, "err", err)
 			return nil, errno.ErrOperationFailed.WithMessage(err.Error())
 		}
 	}
@@ -177,21 +211,21 @@ func (b *userBiz) SendVerifyCode(ctx context.Context, rq *apiv1.SendVerifyCodeRe
 	var err error
 	switch rq.GetTargetType() {
 	case "phone":
-		err = b.smsClient.SendVerifyCode(ctx, rq.GetTarget(), code, rq.GetCodeType())
+		err = b.smsClient.SendVerifyCode(ctx, target, code, rq.GetCodeType())
 	case "email":
 		// TODO: 实现邮件发送服务
-		log.Infow("邮件验证码发送功能待实现", "email", rq.GetTarget(), "code", code)
+		log.Infow("邮件验证码发送功能待实现", "email", target, "code", code)
 	default:
 		return nil, errno.ErrInvalidArgument.WithMessage("Unsupported target type")
 	}
 
 	if err != nil {
-		log.W(ctx).Errorw("Failed to send verify code", "target", rq.GetTarget(), "type", rq.GetTargetType(), "err", err)
+		log.W(ctx).Errorw("Failed to send verify code", "target", target, "type", rq.GetTargetType(), "err", err)
 		return nil, errno.ErrOperationFailed.WithMessage("Failed to send verify code")
 	}
 
 	log.Infow("验证码发送成功",
-		"target", rq.GetTarget(),
+		"target", target,
 		"code_type", rq.GetCodeType(),
 		"target_type", rq.GetTargetType(),
 	)
@@ -203,6 +237,42 @@ func (b *userBiz) SendVerifyCode(ctx context.Context, rq *apiv1.SendVerifyCodeRe
 	}, nil
 }
 
+// VerifySMSDeliveryReport 校验短信服务商回执回调的签名，回执会影响服务商健康状态和切换，未签名的回调一律拒绝
+func (b *userBiz) VerifySMSDeliveryReport(ctx context.Context, provider, timestamp, signature string, body []byte) error {
+	if err := b.smsClient.VerifyDeliveryReport(provider, timestamp, signature, body); err != nil {
+		log.W(ctx).Warnw("Rejected SMS delivery report", "provider", provider, "err", err)
+		return errno.ErrUnauthenticated.WithMessage(err.Error())
+	}
+	return nil
+}
+
+// HandleSMSDeliveryReport 处理短信服务商的回执回调
+func (b *userBiz) HandleSMSDeliveryReport(ctx context.Context, rq *apiv1.SMSDeliveryReportRequest) (*apiv1.SMSDeliveryReportResponse, error) {
+	report := &sms.DeliveryReport{
+		Provider:     rq.GetProvider(),
+		MessageID:    rq.GetMessageId(),
+		Phone:        rq.GetPhone(),
+		Status:       sms.DeliveryStatus(rq.GetStatus()),
+		ErrorCode:    rq.GetErrorCode(),
+		ErrorMessage: rq.GetErrorMessage(),
+	}
+	if err := b.smsClient.HandleDeliveryReport(ctx, report); err != nil {
+		return nil, errno.ErrInvalidArgument.WithMessage(err.Error())
+	}
+
+	if report.Status == sms.DeliveryStatusFailed {
+		log.W(ctx).Warnw("SMS delivery failed",
+			"provider", report.Provider,
+			"message_id", report.MessageID,
+			"phone", report.Phone,
+			"error_code", report.ErrorCode,
+			"error_message", report.ErrorMessage,
+		)
+	}
+
+	return &apiv1.SMSDeliveryReportResponse{Success: true}, nil
+}
+
 // Logout 用户登出
 func (b *userBiz) Logout(ctx context.Context, rq *apiv1.LogoutRequest) (*apiv1.LogoutResponse, error) {
 	userID := contextx.UserID(ctx)
@@ -316,7 +386,12 @@ func (b *userBiz) findUserByIdentifier(ctx context.Context, authID, authType str
 }
 
 // validateLoginCredentials 验证登录凭证
-func (b *userBiz) validateLoginCredentials(ctx context.Context, userM *model.UserM, rq *apiv1.LoginRequest) error {
+func (b *userBiz) validateLoginCredentials(ctx context.Context, userM *model.UserM, identifier string, rq *apiv1.LoginRequest) error {
+	// LDAP 登录，密码由目录服务校验
+	if model.StringToAuthType(rq.GetLoginType()) == model.AuthTypeLDAP {
+		return b.validateLDAPCredentials(ctx, userM, rq)
+	}
+
 	// 密码登录
 	if rq.GetPassword() != "" {
 		if err := authn.Compare(userM.Password, rq.GetPassword()); err != nil {
@@ -333,7 +408,7 @@ func (b *userBiz) validateLoginCredentials(ctx context.Context, userM *model.Use
 		}
 
 		// 验证验证码
-		if err := b.loginSecurity.ValidateVerifyCode(ctx, rq.GetIdentifier(), "login", rq.GetVerifyCode()); err != nil {
+		if err := b.loginSecurity.ValidateVerifyCode(ctx, identifier, "login", rq.GetVerifyCode()); err != nil {
 			log.W(ctx).Errorw("Failed to validate verify code", "err", err)
 			return errno.ErrPasswordInvalid.WithMessage(err.Error())
 		}
@@ -25,6 +25,15 @@ import (
 
 // Create 创建用户.
 func (b *userBiz) Create(ctx context.Context, rq *apiv1.CreateUserRequest) (*apiv1.CreateUserResponse, error) {
+	// 手机号统一存储为 E.164 格式
+	var phone string
+	if rq.GetPhone() != "" {
+		var err error
+		if phone, err = b.normalizePhone(ctx, rq.GetPhone(), 0); err != nil {
+			return nil, err
+		}
+	}
+
 	// 使用事务确保数据一致性
 	var userID int64
 
@@ -32,6 +41,7 @@ func (b *userBiz) Create(ctx context.Context, rq *apiv1.CreateUserRequest) (*api
 		// 1. 创建用户基本信息
 		var userM model.UserM
 		_ = copier.Copy(&userM, rq)
+		userM.Phone = phone
 
 		if err := b.store.User().Create(ctx, &userM); err != nil {
 			return err
@@ -135,7 +145,11 @@ func (b *userBiz) Update(ctx context.Context, rq *apiv1.UpdateUserRequest) (*api
 		userM.Email = rq.GetEmail()
 	}
 	if rq.GetPhone() != "" {
-		userM.Phone = rq.GetPhone()
+		phone, err := b.normalizePhone(ctx, rq.GetPhone(), 0)
+		if err != nil {
+			return nil, err
+		}
+		userM.Phone = phone
 	}
 
 	if err := b.store.User().Update(ctx, userM); err != nil {
@@ -156,6 +170,9 @@ func (b *userBiz) Delete(ctx context.Context, rq *apiv1.DeleteUserRequest) (*api
 	if err := b.store.User().Delete(ctx, where.F("id", userIDInt)); err != nil {
 		return nil, err
 	}
+	if b.authz != nil {
+		b.authz.InvalidateResolver()
+	}
 
 	return &apiv1.DeleteUserResponse{}, nil
 }
@@ -0,0 +1,170 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	"github.com/ashwinyue/one-auth/pkg/client/ldap"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// externalIdentity 外部身份源（LDAP、SAML 等）认证通过的用户信息
+type externalIdentity struct {
+	AuthType    model.AuthType
+	AuthID      string
+	TenantID    int64
+	Username    string
+	Email       string
+	DisplayName string
+	Phone       string
+	Groups      []string
+	// RoleMapping 组到角色名称的映射
+	RoleMapping map[string][]string
+}
+
+// provisionExternalUser 外部身份首次登录时即时创建账号.
+func (b *userBiz) provisionExternalUser(ctx context.Context, id *externalIdentity) (*model.UserM, *model.UserStatusM, error) {
+	if id.Username == "" {
+		return nil, nil, errno.ErrInvalidArgument.WithMessage("external identity has no username")
+	}
+
+	// 用户名全局唯一，与已有用户冲突时加上租户前缀
+	username := id.Username
+	if _, err := b.store.User().Get(store.WithoutDataScope(ctx), where.F("username", username)); err == nil {
+		username = fmt.Sprintf("t%d.%s", id.TenantID, id.Username)
+	}
+
+	// 手机号不合法或已被占用时不写入
+	var phone string
+	if id.Phone != "" && b.smsClient != nil {
+		if p, err := b.smsClient.NormalizePhone(strconv.FormatInt(id.TenantID, 10), id.Phone); err == nil {
+			if _, err := b.store.User().Get(store.WithoutDataScope(ctx), where.F("phone", p)); err != nil {
+				phone = p
+			}
+		}
+	}
+
+	nickname := id.DisplayName
+	if nickname == "" {
+		nickname = id.Username
+	}
+
+	userM := &model.UserM{
+		Username: username,
+		// 外部身份不使用本地密码，写入随机值（创建时会被加密）
+		Password: randomPassword(),
+		Nickname: nickname,
+		Email:    id.Email,
+		Phone:    phone,
+	}
+	now := time.Now()
+	userStatus := &model.UserStatusM{
+		AuthID:     id.AuthID,
+		AuthType:   int32(id.AuthType),
+		TenantID:   id.TenantID,
+		Status:     int32(model.UserStatusActive),
+		IsVerified: true,
+		VerifiedAt: &now,
+		IsPrimary:  true,
+	}
+
+	err := b.store.TX(ctx, func(txCtx context.Context) error {
+		if err := b.store.User().Create(txCtx, userM); err != nil {
+			log.W(txCtx).Errorw("Failed to create external user", "username", username, "err", err)
+			return errno.ErrDBWrite.WithMessage("Failed to create user")
+		}
+
+		userStatus.UserID = userM.ID
+		if err := b.store.UserStatus().Create(txCtx, userStatus); err != nil {
+			log.W(txCtx).Errorw("Failed to create external user status", "user_id", userM.ID, "err", err)
+			return errno.ErrDBWrite.WithMessage("Failed to create user status")
+		}
+
+		if err := b.store.Tenant().AddUserTenant(txCtx, strconv.FormatInt(userM.ID, 10), id.TenantID); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.W(ctx).Infow("External user provisioned", "user_id", userM.ID, "tenant_id", id.TenantID, "auth_id", id.AuthID)
+
+	b.syncExternalRoles(ctx, userM.ID, id)
+	return userM, userStatus, nil
+}
+
+// syncExternalUser 同步已存在用户的资料和角色.
+func (b *userBiz) syncExternalUser(ctx context.Context, userM *model.UserM, id *externalIdentity) {
+	updates := map[string]any{}
+	if id.Email != "" && id.Email != userM.Email {
+		updates["email"] = id.Email
+	}
+	if id.DisplayName != "" && id.DisplayName != userM.Nickname {
+		updates["nickname"] = id.DisplayName
+	}
+	if len(updates) > 0 {
+		if err := b.store.DB(ctx).Model(&model.UserM{}).Where("id = ?", userM.ID).Updates(updates).Error; err != nil {
+			log.W(ctx).Errorw("Failed to sync external user profile", "user_id", userM.ID, "err", err)
+		}
+	}
+
+	b.syncExternalRoles(ctx, userM.ID, id)
+}
+
+// syncExternalRoles 按组映射同步用户在租户内的角色，只调整映射中出现的角色.
+func (b *userBiz) syncExternalRoles(ctx context.Context, userID int64, id *externalIdentity) {
+	if b.authz == nil {
+		return
+	}
+
+	desired := make(map[string]bool)
+	for group, roles := range id.RoleMapping {
+		matched := false
+		for _, g := range id.Groups {
+			if ldap.GroupMatches(g, group) {
+				matched = true
+				break
+			}
+		}
+		for _, role := range roles {
+			desired[role] = desired[role] || matched
+		}
+	}
+
+	var err error
+	user := strconv.FormatInt(userID, 10)
+	tenant := strconv.FormatInt(id.TenantID, 10)
+	for role, want := range desired {
+		if want {
+			_, err = b.authz.AddRoleForUser(user, role, tenant)
+		} else {
+			_, err = b.authz.DeleteRoleForUser(user, role, tenant)
+		}
+		if err != nil {
+			log.W(ctx).Errorw("Failed to sync external role", "user_id", userID, "tenant_id", id.TenantID, "role", role, "err", err)
+		}
+	}
+}
+
+// randomPassword 生成不可猜测的随机密码.
+func randomPassword() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/client/ldap"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// ldapClientEntry 缓存的租户 LDAP 客户端，配置更新后重建
+type ldapClientEntry struct {
+	updatedAt time.Time
+	client    *ldap.Client
+}
+
+var (
+	ldapClientsMu sync.Mutex
+	// ldapClients 按租户缓存 LDAP 客户端，复用连接池
+	ldapClients = make(map[int64]*ldapClientEntry)
+)
+
+// ldapTenantID 获取 LDAP 登录的租户ID，优先使用请求参数.
+func ldapTenantID(ctx context.Context, rq *apiv1.LoginRequest) (int64, error) {
+	if rq.GetTenantId() > 0 {
+		return rq.GetTenantId(), nil
+	}
+	if id, err := strconv.ParseInt(contextx.TenantID(ctx), 10, 64); err == nil && id > 0 {
+		return id, nil
+	}
+	return 0, errno.ErrInvalidArgument.WithMessage("tenant_id is required for ldap login")
+}
+
+// ldapAuthID 生成 LDAP 用户的认证标识符，用户名在不同租户的目录中可能重复.
+func ldapAuthID(tenantID int64, username string) string {
+	return fmt.Sprintf("%d:%s", tenantID, strings.ToLower(strings.TrimSpace(username)))
+}
+
+// ldapClient 获取租户的 LDAP 客户端和配置.
+func (b *userBiz) ldapClient(ctx context.Context, tenantID int64) (*ldap.Client, *model.TenantLDAPConfigM, error) {
+	cfg, err := b.store.TenantLDAPConfig().Get(ctx, where.F("tenant_id", tenantID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errno.ErrInvalidArgument.WithMessage("ldap login is not configured for this tenant")
+		}
+		return nil, nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if !cfg.Enabled {
+		return nil, nil, errno.ErrInvalidArgument.WithMessage("ldap login is disabled for this tenant")
+	}
+
+	ldapClientsMu.Lock()
+	defer ldapClientsMu.Unlock()
+
+	if cached, ok := ldapClients[tenantID]; ok {
+		if cached.updatedAt.Equal(cfg.UpdatedAt) {
+			return cached.client, cfg, nil
+		}
+		cached.client.Close()
+		delete(ldapClients, tenantID)
+	}
+
+	var caCert string
+	if cfg.CaCert != nil {
+		caCert = *cfg.CaCert
+	}
+	client, err := ldap.NewClient(ldap.Config{
+		URL:                cfg.URL,
+		StartTLS:           cfg.StartTLS,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		CACert:             caCert,
+		BindDN:             cfg.BindDn,
+		BindPassword:       cfg.BindPassword,
+		BaseDN:             cfg.BaseDn,
+		UserFilter:         cfg.UserFilter,
+		UsernameAttr:       cfg.UsernameAttr,
+		EmailAttr:          cfg.EmailAttr,
+		DisplayNameAttr:    cfg.DisplayNameAttr,
+		PhoneAttr:          cfg.PhoneAttr,
+		GroupAttr:          cfg.GroupAttr,
+		GroupBaseDN:        cfg.GroupBaseDn,
+		GroupFilter:        cfg.GroupFilter,
+		PoolSize:           int(cfg.PoolSize),
+		Timeout:            time.Duration(cfg.TimeoutSeconds) * time.Second,
+	})
+	if err != nil {
+		log.W(ctx).Errorw("Invalid ldap config", "tenant_id", tenantID, "err", err)
+		return nil, nil, errno.ErrInternal.WithMessage("invalid ldap config")
+	}
+
+	ldapClients[tenantID] = &ldapClientEntry{updatedAt: cfg.UpdatedAt, client: client}
+	return client, cfg, nil
+}
+
+// authenticateLDAP 通过租户目录校验用户名和密码.
+func (b *userBiz) authenticateLDAP(ctx context.Context, tenantID int64, username, password string) (*ldap.Entry, *model.TenantLDAPConfigM, error) {
+	client, cfg, err := b.ldapClient(ctx, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry, err := client.Authenticate(ctx, username, password)
+	if err != nil {
+		switch {
+		case errors.Is(err, ldap.ErrInvalidCredentials), errors.Is(err, ldap.ErrUserNotFound), errors.Is(err, ldap.ErrMultipleUsers):
+			log.W(ctx).Infow("LDAP authentication rejected", "tenant_id", tenantID, "username", username, "err", err)
+			return nil, nil, errno.ErrPasswordInvalid
+		default:
+			log.W(ctx).Errorw("LDAP authentication failed", "tenant_id", tenantID, "username", username, "err", err)
+			return nil, nil, errno.ErrInternal.WithMessage("directory service unavailable")
+		}
+	}
+
+	return entry, cfg, nil
+}
+
+// ldapIdentity 将目录条目转换为外部身份.
+func ldapIdentity(ctx context.Context, tenantID int64, username string, cfg *model.TenantLDAPConfigM, entry *ldap.Entry) *externalIdentity {
+	id := &externalIdentity{
+		AuthType:    model.AuthTypeLDAP,
+		AuthID:      ldapAuthID(tenantID, username),
+		TenantID:    tenantID,
+		Username:    entry.Username,
+		Email:       entry.Email,
+		DisplayName: entry.DisplayName,
+		Phone:       entry.Phone,
+		Groups:      entry.Groups,
+	}
+
+	mapping, err := cfg.GetRoleMapping()
+	if err != nil {
+		log.W(ctx).Errorw("Invalid ldap role mapping", "tenant_id", tenantID, "err", err)
+	}
+	id.RoleMapping = mapping
+	return id
+}
+
+// validateLDAPCredentials 校验已存在的 LDAP 用户，并同步目录中的资料和角色.
+func (b *userBiz) validateLDAPCredentials(ctx context.Context, userM *model.UserM, rq *apiv1.LoginRequest) error {
+	tenantID, err := ldapTenantID(ctx, rq)
+	if err != nil {
+		return err
+	}
+
+	entry, cfg, err := b.authenticateLDAP(ctx, tenantID, rq.GetIdentifier(), rq.GetPassword())
+	if err != nil {
+		return err
+	}
+
+	b.syncExternalUser(ctx, userM, ldapIdentity(ctx, tenantID, rq.GetIdentifier(), cfg, entry))
+	return nil
+}
+
+// provisionLDAPUser 目录用户首次登录时即时创建账号.
+func (b *userBiz) provisionLDAPUser(ctx context.Context, rq *apiv1.LoginRequest) (*model.UserM, *model.UserStatusM, error) {
+	tenantID, err := ldapTenantID(ctx, rq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry, cfg, err := b.authenticateLDAP(ctx, tenantID, rq.GetIdentifier(), rq.GetPassword())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b.provisionExternalUser(ctx, ldapIdentity(ctx, tenantID, rq.GetIdentifier(), cfg, entry))
+}
@@ -156,12 +156,12 @@ func TestValidateLoginCredentials(t *testing.T) {
 		Password: stringPtr("password123"),
 	}
 
-	err := biz.validateLoginCredentials(context.Background(), testUser, req)
+	err := biz.validateLoginCredentials(context.Background(), testUser, req.GetIdentifier(), req)
 	assert.NoError(t, err)
 
 	// 测试错误密码
 	req.Password = stringPtr("wrongpassword")
-	err = biz.validateLoginCredentials(context.Background(), testUser, req)
+	err = biz.validateLoginCredentials(context.Background(), testUser, req.GetIdentifier(), req)
 	assert.Error(t, err)
 }
 
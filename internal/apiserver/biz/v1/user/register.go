@@ -8,6 +8,7 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -15,38 +16,42 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
 	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
 	"github.com/ashwinyue/one-auth/internal/pkg/errno"
 	"github.com/ashwinyue/one-auth/internal/pkg/log"
 	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
 	"github.com/ashwinyue/one-auth/pkg/authn"
+	"github.com/ashwinyue/one-auth/pkg/client/sms"
+	"github.com/ashwinyue/one-auth/pkg/errorsx"
 	"github.com/ashwinyue/one-auth/pkg/store/where"
 )
 
 // Register 用户注册
 func (b *userBiz) Register(ctx context.Context, rq *apiv1.RegisterRequest) (*apiv1.RegisterResponse, error) {
-	// 验证手机号格式
-	if !b.smsClient.IsValidPhone(rq.GetPhone()) {
-		return nil, errno.ErrInvalidArgument.WithMessage("Invalid phone number format")
+	// 验证手机号格式并规范化为 E.164
+	phone, err := b.normalizePhone(ctx, rq.GetPhone(), rq.GetTenantId())
+	if err != nil {
+		return nil, err
 	}
 
 	// 验证短信验证码
 	if b.loginSecurity != nil {
-		if err := b.loginSecurity.ValidateVerifyCode(ctx, rq.GetPhone(), "register", rq.GetVerifyCode()); err != nil {
-			log.W(ctx).Errorw("Failed to validate verify code", "phone", rq.GetPhone(), "err", err)
+		if err := b.loginSecurity.ValidateVerifyCode(ctx, phone, "register", rq.GetVerifyCode()); err != nil {
+			log.W(ctx).Errorw("Failed to validate verify code", "phone", phone, "err", err)
 			return nil, errno.ErrPasswordInvalid.WithMessage("验证码错误或已过期")
 		}
 	}
 
 	// 检查手机号是否已注册
-	existingStatus, err := b.store.UserStatus().Get(ctx, where.F("auth_id", rq.GetPhone(), "auth_type", int32(model.AuthTypePhone)))
+	existingStatus, err := b.store.UserStatus().Get(ctx, where.F("auth_id", phone, "auth_type", int32(model.AuthTypePhone)))
 	if err == nil && existingStatus != nil {
 		return nil, errno.ErrUserAlreadyExists.WithMessage("Phone number already registered")
 	}
 
 	// 检查用户名是否已存在
 	if rq.GetUsername() != "" {
-		existingUser, err := b.store.User().Get(ctx, where.F("username", rq.GetUsername()))
+		existingUser, err := b.store.User().Get(store.WithoutDataScope(ctx), where.F("username", rq.GetUsername()))
 		if err == nil && existingUser != nil {
 			return nil, errno.ErrUserAlreadyExists.WithMessage("Username already exists")
 		}
@@ -69,19 +74,19 @@ func (b *userBiz) Register(ctx context.Context, rq *apiv1.RegisterRequest) (*api
 			Password:  encryptedPassword,
 			Nickname:  rq.GetNickname(),
 			Email:     rq.GetEmail(),
-			Phone:     rq.GetPhone(),
+			Phone:     phone,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
 
 		// 如果用户名为空，使用手机号作为用户名
 		if userM.Username == "" {
-			userM.Username = rq.GetPhone()
+			userM.Username = phone
 		}
 
 		// 如果昵称为空，使用手机号的部分作为昵称
 		if userM.Nickname == "" {
-			userM.Nickname = fmt.Sprintf("用户%s", rq.GetPhone()[len(rq.GetPhone())-4:])
+			userM.Nickname = fmt.Sprintf("用户%s", phone[len(phone)-4:])
 		}
 
 		// 保存用户基本信息
@@ -92,7 +97,7 @@ func (b *userBiz) Register(ctx context.Context, rq *apiv1.RegisterRequest) (*api
 
 		// 创建手机号认证状态记录
 		phoneStatus := &model.UserStatusM{
-			AuthID:              rq.GetPhone(),
+			AuthID:              phone,
 			AuthType:            int32(model.AuthTypePhone),
 			UserID:              userM.ID,
 			TenantID:            1, // 默认租户ID
@@ -138,7 +143,7 @@ func (b *userBiz) Register(ctx context.Context, rq *apiv1.RegisterRequest) (*api
 		}
 
 		// 如果用户名与手机号不同，也创建一个用户名认证记录
-		if rq.GetUsername() != "" && rq.GetUsername() != rq.GetPhone() {
+		if rq.GetUsername() != "" && rq.GetUsername() != phone {
 			usernameStatus := &model.UserStatusM{
 				AuthID:              rq.GetUsername(),
 				AuthType:            int32(model.AuthTypeUsername),
@@ -163,7 +168,7 @@ func (b *userBiz) Register(ctx context.Context, rq *apiv1.RegisterRequest) (*api
 		log.Infow("用户注册成功",
 			"user_id", userM.ID,
 			"username", userM.Username,
-			"phone", rq.GetPhone(),
+			"phone", phone,
 			"email", rq.GetEmail())
 
 		responseData = &apiv1.RegisterResponse{
@@ -184,21 +189,22 @@ func (b *userBiz) Register(ctx context.Context, rq *apiv1.RegisterRequest) (*api
 
 // BindPhone 绑定手机号
 func (b *userBiz) BindPhone(ctx context.Context, rq *apiv1.BindPhoneRequest) (*apiv1.BindPhoneResponse, error) {
-	// 验证手机号格式
-	if !b.smsClient.IsValidPhone(rq.GetPhone()) {
-		return nil, errno.ErrInvalidArgument.WithMessage("Invalid phone number format")
+	// 验证手机号格式并规范化为 E.164
+	phone, err := b.normalizePhone(ctx, rq.GetPhone(), 0)
+	if err != nil {
+		return nil, err
 	}
 
 	// 验证短信验证码
 	if b.loginSecurity != nil {
-		if err := b.loginSecurity.ValidateVerifyCode(ctx, rq.GetPhone(), "bind_phone", rq.GetVerifyCode()); err != nil {
-			log.W(ctx).Errorw("Failed to validate verify code", "phone", rq.GetPhone(), "err", err)
+		if err := b.loginSecurity.ValidateVerifyCode(ctx, phone, "bind_phone", rq.GetVerifyCode()); err != nil {
+			log.W(ctx).Errorw("Failed to validate verify code", "phone", phone, "err", err)
 			return nil, errno.ErrPasswordInvalid.WithMessage("验证码错误或已过期")
 		}
 	}
 
 	// 检查手机号是否已被其他用户绑定
-	existingStatus, err := b.store.UserStatus().Get(ctx, where.F("auth_id", rq.GetPhone(), "auth_type", int32(model.AuthTypePhone)))
+	existingStatus, err := b.store.UserStatus().Get(ctx, where.F("auth_id", phone, "auth_type", int32(model.AuthTypePhone)))
 	if err == nil && existingStatus != nil {
 		return nil, errno.ErrUserAlreadyExists.WithMessage("Phone number already bound to another user")
 	}
@@ -219,7 +225,7 @@ func (b *userBiz) BindPhone(ctx context.Context, rq *apiv1.BindPhoneRequest) (*a
 
 	err = b.store.TX(ctx, func(txCtx context.Context) error {
 		// 更新用户基本信息中的手机号
-		userM.Phone = rq.GetPhone()
+		userM.Phone = phone
 		userM.UpdatedAt = time.Now()
 		if err := b.store.User().Update(txCtx, userM); err != nil {
 			log.W(txCtx).Errorw("Failed to update user phone", "user_id", currentUserID, "err", err)
@@ -228,7 +234,7 @@ func (b *userBiz) BindPhone(ctx context.Context, rq *apiv1.BindPhoneRequest) (*a
 
 		// 创建或更新手机号认证状态
 		phoneStatus := &model.UserStatusM{
-			AuthID:              rq.GetPhone(),
+			AuthID:              phone,
 			AuthType:            int32(model.AuthTypePhone),
 			UserID:              userM.ID,
 			TenantID:            1, // 默认租户ID
@@ -246,13 +252,13 @@ func (b *userBiz) BindPhone(ctx context.Context, rq *apiv1.BindPhoneRequest) (*a
 		phoneStatus.PasswordChangedAt = &now
 
 		if err := b.store.UserStatus().Create(txCtx, phoneStatus); err != nil {
-			log.W(txCtx).Errorw("Failed to create phone status", "user_id", userM.ID, "phone", rq.GetPhone(), "err", err)
+			log.W(txCtx).Errorw("Failed to create phone status", "user_id", userM.ID, "phone", phone, "err", err)
 			return errno.ErrDBWrite.WithMessage("Failed to bind phone")
 		}
 
 		log.Infow("手机号绑定成功",
 			"user_id", userM.ID,
-			"phone", rq.GetPhone())
+			"phone", phone)
 
 		responseData = &apiv1.BindPhoneResponse{
 			Success: true,
@@ -271,18 +277,19 @@ func (b *userBiz) BindPhone(ctx context.Context, rq *apiv1.BindPhoneRequest) (*a
 
 // CheckPhoneAvailable 检查手机号是否可用
 func (b *userBiz) CheckPhoneAvailable(ctx context.Context, rq *apiv1.CheckPhoneAvailableRequest) (*apiv1.CheckPhoneAvailableResponse, error) {
-	// 验证手机号格式
-	if !b.smsClient.IsValidPhone(rq.GetPhone()) {
+	// 验证手机号格式并规范化为 E.164
+	phone, err := b.normalizePhone(ctx, rq.GetPhone(), rq.GetTenantId())
+	if err != nil {
 		return &apiv1.CheckPhoneAvailableResponse{
 			Available: false,
-			Message:   "Invalid phone number format",
+			Message:   errorsx.FromError(err).Message,
 		}, nil
 	}
 
 	// 检查手机号是否已被注册
-	_, err := b.store.UserStatus().Get(ctx, where.F("auth_id", rq.GetPhone(), "auth_type", int32(model.AuthTypePhone)))
+	_, err = b.store.UserStatus().Get(ctx, where.F("auth_id", phone, "auth_type", int32(model.AuthTypePhone)))
 	if err != nil && err != gorm.ErrRecordNotFound {
-		log.W(ctx).Errorw("Failed to check phone availability", "phone", rq.GetPhone(), "err", err)
+		log.W(ctx).Errorw("Failed to check phone availability", "phone", phone, "err", err)
 		return nil, errno.ErrDBRead.WithMessage("Failed to check phone availability")
 	}
 
@@ -297,3 +304,25 @@ func (b *userBiz) CheckPhoneAvailable(ctx context.Context, rq *apiv1.CheckPhoneA
 		Message:   message,
 	}, nil
 }
+
+// normalizePhone 将手机号规范化为 E.164 格式，并校验租户的国家/地区白名单.
+// 已登录时使用上下文中的租户，否则使用请求中的租户，默认租户ID为 1.
+func (b *userBiz) normalizePhone(ctx context.Context, phone string, tenantID int64) (string, error) {
+	tid := contextx.TenantID(ctx)
+	if tid == "" {
+		if tenantID == 0 {
+			tenantID = 1
+		}
+		tid = strconv.FormatInt(tenantID, 10)
+	}
+
+	normalized, err := b.smsClient.NormalizePhone(tid, phone)
+	if errors.Is(err, sms.ErrCountryNotAllowed) {
+		return "", errno.ErrInvalidArgument.WithMessage("Phone number country is not allowed")
+	}
+	if err != nil {
+		return "", errno.ErrInvalidArgument.WithMessage("Invalid phone number format")
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,325 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/cache"
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/client/saml"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// defaultSAMLAttributeMapping 默认的用户资料到断言属性的映射
+var defaultSAMLAttributeMapping = map[string]string{
+	"username": "uid",
+	"email":    "mail",
+	"nickname": "displayName",
+	"phone":    "mobile",
+	"groups":   "groups",
+}
+
+// samlAuthID 生成 SAML 用户的认证标识符，NameID 只在 IdP 内唯一.
+func samlAuthID(tenantID int64, nameID string) string {
+	return fmt.Sprintf("%d:%s", tenantID, nameID)
+}
+
+// samlURL 生成租户 SAML 接口的对外地址.
+func samlURL(cfg *model.TenantSAMLConfigM, path string) string {
+	return fmt.Sprintf("%s/v1/saml/%d/%s", strings.TrimRight(cfg.BaseURL, "/"), cfg.TenantID, path)
+}
+
+// samlConfig 获取租户的 SAML 配置.
+func (b *userBiz) samlConfig(ctx context.Context, tenantID int64) (*model.TenantSAMLConfigM, error) {
+	cfg, err := b.store.TenantSAMLConfig().Get(ctx, where.F("tenant_id", tenantID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrInvalidArgument.WithMessage("saml login is not configured for this tenant")
+		}
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	if !cfg.Enabled {
+		return nil, errno.ErrInvalidArgument.WithMessage("saml login is disabled for this tenant")
+	}
+	return cfg, nil
+}
+
+// samlServiceProvider 根据租户配置创建 SAML 服务提供方.
+func (b *userBiz) samlServiceProvider(ctx context.Context, cfg *model.TenantSAMLConfigM) (*saml.ServiceProvider, error) {
+	if cfg.IdpMetadata == nil || *cfg.IdpMetadata == "" {
+		return nil, errno.ErrInvalidArgument.WithMessage("saml idp metadata has not been imported for this tenant")
+	}
+
+	entityID := cfg.SpEntityID
+	if entityID == "" {
+		entityID = samlURL(cfg, "metadata")
+	}
+	spCfg := saml.Config{
+		EntityID:          entityID,
+		ACSURL:            samlURL(cfg, "acs"),
+		MetadataURL:       samlURL(cfg, "metadata"),
+		IDPMetadata:       []byte(*cfg.IdpMetadata),
+		AllowIDPInitiated: cfg.AllowIdpInitiated,
+		SignRequests:      cfg.SignRequests,
+		NameIDFormat:      cfg.NameIDFormat,
+	}
+	if cfg.SpCertificate != nil && cfg.SpPrivateKey != nil {
+		spCfg.Certificate = *cfg.SpCertificate
+		spCfg.PrivateKey = *cfg.SpPrivateKey
+	}
+	if b.samlState != nil {
+		spCfg.ReplayCache = b.samlState.ReplayCache(cfg.TenantID)
+	}
+
+	sp, err := saml.NewServiceProvider(spCfg)
+	if err != nil {
+		log.W(ctx).Errorw("Invalid saml config", "tenant_id", cfg.TenantID, "err", err)
+		return nil, errno.ErrInternal.WithMessage("invalid saml config")
+	}
+	return sp, nil
+}
+
+// GetSAMLMetadata 生成租户的 SP 元数据.
+func (b *userBiz) GetSAMLMetadata(ctx context.Context, rq *apiv1.GetSAMLMetadataRequest) (*apiv1.GetSAMLMetadataResponse, error) {
+	cfg, err := b.samlConfig(ctx, rq.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	sp, err := b.samlServiceProvider(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := sp.Metadata()
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage(err.Error())
+	}
+	return &apiv1.GetSAMLMetadataResponse{Metadata: string(metadata)}, nil
+}
+
+// SAMLLogin 生成 SP 发起的认证请求，并以 RelayState 保存请求ID.
+func (b *userBiz) SAMLLogin(ctx context.Context, rq *apiv1.SAMLLoginRequest) (*apiv1.SAMLLoginResponse, error) {
+	binding := rq.GetBinding()
+	if binding == "" {
+		binding = saml.BindingRedirect
+	}
+	if binding != saml.BindingRedirect && binding != saml.BindingPost {
+		return nil, errno.ErrInvalidArgument.WithMessage("invalid binding, must be one of: redirect, post")
+	}
+	if b.samlState == nil {
+		return nil, errno.ErrInternal.WithMessage("saml state manager not available")
+	}
+
+	cfg, err := b.samlConfig(ctx, rq.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	sp, err := b.samlServiceProvider(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	relayState := randomRelayState()
+	authn, err := sp.MakeAuthnRequest(binding, relayState)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to make saml authn request", "tenant_id", cfg.TenantID, "err", err)
+		return nil, errno.ErrInternal.WithMessage("failed to make saml authn request")
+	}
+
+	state := &cache.SAMLRequestState{TenantID: cfg.TenantID, RequestID: authn.ID, CreatedAt: time.Now()}
+	if err := b.samlState.SaveRequest(ctx, relayState, state); err != nil {
+		log.W(ctx).Errorw("Failed to save saml request state", "tenant_id", cfg.TenantID, "err", err)
+		return nil, errno.ErrOperationFailed.WithMessage("failed to save saml request state")
+	}
+
+	return &apiv1.SAMLLoginResponse{
+		Binding:     authn.Binding,
+		RedirectUrl: authn.URL,
+		FormHtml:    string(authn.PostForm),
+	}, nil
+}
+
+// SAMLAssertion 校验 IdP 返回的断言，按需创建账号并完成登录.
+func (b *userBiz) SAMLAssertion(ctx context.Context, rq *apiv1.SAMLAssertionRequest) (*apiv1.LoginResponse, error) {
+	if rq.GetSamlResponse() == "" {
+		return nil, errno.ErrInvalidArgument.WithMessage("SAMLResponse cannot be empty")
+	}
+
+	cfg, err := b.samlConfig(ctx, rq.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	sp, err := b.samlServiceProvider(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// SP 发起的登录需要匹配请求ID，找不到请求时按 IdP 发起的登录处理
+	var possibleRequestIDs []string
+	if rq.GetRelayState() != "" && b.samlState != nil {
+		state, err := b.samlState.TakeRequest(ctx, rq.GetRelayState())
+		if err != nil {
+			log.W(ctx).Errorw("Failed to load saml request state", "tenant_id", cfg.TenantID, "err", err)
+		}
+		if state != nil {
+			if state.TenantID != cfg.TenantID {
+				return nil, errno.ErrInvalidArgument.WithMessage("RelayState does not belong to this tenant")
+			}
+			possibleRequestIDs = []string{state.RequestID}
+		}
+	}
+
+	assertion, err := sp.ParseResponse(ctx, rq.GetSamlResponse(), possibleRequestIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, saml.ErrReplayedAssertion):
+			log.W(ctx).Warnw("SAML assertion replayed", "tenant_id", cfg.TenantID)
+			return nil, errno.ErrUnauthenticated.WithMessage("saml assertion has already been used")
+		case errors.Is(err, saml.ErrInvalidResponse):
+			log.W(ctx).Infow("SAML assertion rejected", "tenant_id", cfg.TenantID, "err", err)
+			return nil, errno.ErrUnauthenticated.WithMessage("invalid saml response")
+		default:
+			log.W(ctx).Errorw("Failed to parse saml response", "tenant_id", cfg.TenantID, "err", err)
+			return nil, errno.ErrInternal.WithMessage("failed to validate saml response")
+		}
+	}
+
+	id := b.samlIdentity(ctx, cfg, assertion)
+	loginRq := &apiv1.LoginRequest{
+		LoginType:  "saml",
+		Identifier: id.AuthID,
+		ClientType: rq.ClientType,
+		DeviceId:   rq.DeviceId,
+		TenantId:   &cfg.TenantID,
+	}
+
+	userM, userStatus, err := b.findUserByIdentifier(ctx, id.AuthID, loginRq.GetLoginType())
+	if err != nil {
+		userM, userStatus, err = b.provisionExternalUser(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if !userStatus.CanLogin() {
+			if userStatus.IsLocked() {
+				return nil, errno.ErrUserLocked.WithMessage("User account is locked")
+			}
+			return nil, errno.ErrUserInactive.WithMessage("User account is inactive")
+		}
+		b.syncExternalUser(ctx, userM, id)
+	}
+
+	log.W(ctx).Infow("SAML login succeeded", "user_id", userM.ID, "tenant_id", cfg.TenantID, "idp", assertion.Issuer)
+	return b.issueLoginResponse(ctx, userM, userStatus, loginRq)
+}
+
+// ImportSAMLIDPMetadata 导入租户的 IdP 元数据，支持直接上传或从地址下载.
+func (b *userBiz) ImportSAMLIDPMetadata(ctx context.Context, rq *apiv1.ImportSAMLIDPMetadataRequest) (*apiv1.ImportSAMLIDPMetadataResponse, error) {
+	if err := checkSAMLTenant(ctx, rq.GetTenantId()); err != nil {
+		return nil, err
+	}
+
+	cfg, err := b.store.TenantSAMLConfig().Get(ctx, where.F("tenant_id", rq.GetTenantId()))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrInvalidArgument.WithMessage("saml login is not configured for this tenant")
+		}
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	var metadata []byte
+	switch {
+	case rq.GetMetadataXml() != "":
+		metadata = []byte(rq.GetMetadataXml())
+	case rq.GetMetadataUrl() != "":
+		metadata, err = saml.FetchIDPMetadata(ctx, nil, rq.GetMetadataUrl())
+		if err != nil {
+			return nil, errno.ErrInvalidArgument.WithMessage(err.Error())
+		}
+		cfg.IdpMetadataURL = rq.GetMetadataUrl()
+	default:
+		return nil, errno.ErrInvalidArgument.WithMessage("metadata_xml or metadata_url is required")
+	}
+
+	idp, err := saml.ParseIDPMetadata(metadata)
+	if err != nil {
+		return nil, errno.ErrInvalidArgument.WithMessage(err.Error())
+	}
+
+	metadataXML := string(metadata)
+	cfg.IdpMetadata = &metadataXML
+	cfg.IdpEntityID = idp.EntityID
+	if err := b.store.TenantSAMLConfig().Update(ctx, cfg); err != nil {
+		log.W(ctx).Errorw("Failed to save saml idp metadata", "tenant_id", cfg.TenantID, "err", err)
+		return nil, errno.ErrDBWrite.WithMessage("failed to save saml idp metadata")
+	}
+
+	log.W(ctx).Infow("SAML idp metadata imported", "tenant_id", cfg.TenantID, "idp", idp.EntityID)
+	return &apiv1.ImportSAMLIDPMetadataResponse{IdpEntityId: idp.EntityID}, nil
+}
+
+// samlIdentity 按属性映射将断言转换为外部身份.
+func (b *userBiz) samlIdentity(ctx context.Context, cfg *model.TenantSAMLConfigM, assertion *saml.Assertion) *externalIdentity {
+	attrs := make(map[string]string, len(defaultSAMLAttributeMapping))
+	for k, v := range defaultSAMLAttributeMapping {
+		attrs[k] = v
+	}
+	mapping, err := cfg.GetAttributeMapping()
+	if err != nil {
+		log.W(ctx).Errorw("Invalid saml attribute mapping", "tenant_id", cfg.TenantID, "err", err)
+	}
+	for k, v := range mapping {
+		attrs[k] = v
+	}
+
+	id := &externalIdentity{
+		AuthType:    model.AuthTypeSAML,
+		AuthID:      samlAuthID(cfg.TenantID, assertion.NameID),
+		TenantID:    cfg.TenantID,
+		Username:    assertion.Attr(attrs["username"]),
+		Email:       assertion.Attr(attrs["email"]),
+		DisplayName: assertion.Attr(attrs["nickname"]),
+		Phone:       assertion.Attr(attrs["phone"]),
+		Groups:      assertion.Attributes[attrs["groups"]],
+	}
+	// 没有用户名属性时使用 NameID，邮箱格式的 NameID 取本地部分
+	if id.Username == "" {
+		id.Username = assertion.NameID
+		if at := strings.Index(id.Username, "@"); at > 0 {
+			if id.Email == "" {
+				id.Email = id.Username
+			}
+			id.Username = id.Username[:at]
+		}
+	}
+
+	roleMapping, err := cfg.GetRoleMapping()
+	if err != nil {
+		log.W(ctx).Errorw("Invalid saml role mapping", "tenant_id", cfg.TenantID, "err", err)
+	}
+	id.RoleMapping = roleMapping
+	return id
+}
+
+// randomRelayState 生成不可猜测的 RelayState.
+func randomRelayState() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
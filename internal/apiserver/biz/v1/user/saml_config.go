@@ -0,0 +1,237 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// checkSAMLTenant 校验请求的租户与当前租户一致，只能管理当前租户的 SAML 配置.
+func checkSAMLTenant(ctx context.Context, tenantID int64) error {
+	if contextx.TenantID(ctx) != strconv.FormatInt(tenantID, 10) {
+		return errno.ErrPermissionDenied.WithMessage("cannot manage saml config for another tenant")
+	}
+	return nil
+}
+
+// GetSAMLConfig 获取当前租户的 SAML 配置.
+func (b *userBiz) GetSAMLConfig(ctx context.Context, rq *apiv1.GetSAMLConfigRequest) (*apiv1.GetSAMLConfigResponse, error) {
+	if err := checkSAMLTenant(ctx, rq.GetTenantId()); err != nil {
+		return nil, err
+	}
+
+	cfg, err := b.tenantSAMLConfig(ctx, rq.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.GetSAMLConfigResponse{Config: toSAMLConfig(cfg)}, nil
+}
+
+// CreateSAMLConfig 创建当前租户的 SAML 配置，IdP 元数据需要随后导入.
+func (b *userBiz) CreateSAMLConfig(ctx context.Context, rq *apiv1.CreateSAMLConfigRequest) (*apiv1.CreateSAMLConfigResponse, error) {
+	if err := checkSAMLTenant(ctx, rq.GetTenantId()); err != nil {
+		return nil, err
+	}
+
+	_, err := b.store.TenantSAMLConfig().Get(ctx, where.F("tenant_id", rq.GetTenantId()))
+	if err == nil {
+		return nil, errno.ErrSAMLConfigExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+
+	cfg := &model.TenantSAMLConfigM{
+		TenantID:          rq.GetTenantId(),
+		Enabled:           rq.Enabled == nil || rq.GetEnabled(),
+		BaseURL:           rq.GetBaseUrl(),
+		SpEntityID:        rq.GetSpEntityId(),
+		AllowIdpInitiated: rq.GetAllowIdpInitiated(),
+		SignRequests:      rq.GetSignRequests(),
+		NameIDFormat:      rq.GetNameIdFormat(),
+	}
+	setSAMLKeyPair(cfg, rq.GetSpCertificate(), rq.GetSpPrivateKey())
+	if err := setSAMLMappings(cfg, rq.GetAttributeMapping(), rq.GetRoleMapping()); err != nil {
+		return nil, err
+	}
+	if err := validateSAMLConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := b.store.TenantSAMLConfig().Create(ctx, cfg); err != nil {
+		log.W(ctx).Errorw("Failed to create saml config", "tenant_id", cfg.TenantID, "err", err)
+		return nil, errno.ErrDBWrite.WithMessage("failed to create saml config")
+	}
+
+	log.W(ctx).Infow("SAML config created", "tenant_id", cfg.TenantID, "enabled", cfg.Enabled)
+	return &apiv1.CreateSAMLConfigResponse{Config: toSAMLConfig(cfg)}, nil
+}
+
+// UpdateSAMLConfig 更新当前租户的 SAML 配置，未设置的字段不修改.
+func (b *userBiz) UpdateSAMLConfig(ctx context.Context, rq *apiv1.UpdateSAMLConfigRequest) (*apiv1.UpdateSAMLConfigResponse, error) {
+	if err := checkSAMLTenant(ctx, rq.GetTenantId()); err != nil {
+		return nil, err
+	}
+
+	cfg, err := b.tenantSAMLConfig(ctx, rq.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+
+	if rq.Enabled != nil {
+		cfg.Enabled = rq.GetEnabled()
+	}
+	if rq.BaseUrl != nil {
+		cfg.BaseURL = rq.GetBaseUrl()
+	}
+	if rq.SpEntityId != nil {
+		cfg.SpEntityID = rq.GetSpEntityId()
+	}
+	if rq.SpCertificate != nil || rq.SpPrivateKey != nil {
+		setSAMLKeyPair(cfg, rq.GetSpCertificate(), rq.GetSpPrivateKey())
+	}
+	if rq.AllowIdpInitiated != nil {
+		cfg.AllowIdpInitiated = rq.GetAllowIdpInitiated()
+	}
+	if rq.SignRequests != nil {
+		cfg.SignRequests = rq.GetSignRequests()
+	}
+	if rq.NameIdFormat != nil {
+		cfg.NameIDFormat = rq.GetNameIdFormat()
+	}
+	if err := setSAMLMappings(cfg, rq.GetAttributeMapping(), rq.GetRoleMapping()); err != nil {
+		return nil, err
+	}
+	if err := validateSAMLConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := b.store.TenantSAMLConfig().Update(ctx, cfg); err != nil {
+		log.W(ctx).Errorw("Failed to update saml config", "tenant_id", cfg.TenantID, "err", err)
+		return nil, errno.ErrDBWrite.WithMessage("failed to update saml config")
+	}
+
+	log.W(ctx).Infow("SAML config updated", "tenant_id", cfg.TenantID, "enabled", cfg.Enabled)
+	return &apiv1.UpdateSAMLConfigResponse{Config: toSAMLConfig(cfg)}, nil
+}
+
+// tenantSAMLConfig 获取租户的 SAML 配置，不要求已启用.
+func (b *userBiz) tenantSAMLConfig(ctx context.Context, tenantID int64) (*model.TenantSAMLConfigM, error) {
+	cfg, err := b.store.TenantSAMLConfig().Get(ctx, where.F("tenant_id", tenantID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrSAMLConfigNotFound
+		}
+		return nil, errno.ErrDBRead.WithMessage(err.Error())
+	}
+	return cfg, nil
+}
+
+// setSAMLKeyPair 设置 SP 证书和私钥，均为空时清除.
+func setSAMLKeyPair(cfg *model.TenantSAMLConfigM, certificate, privateKey string) {
+	cfg.SpCertificate, cfg.SpPrivateKey = nil, nil
+	if certificate != "" {
+		cfg.SpCertificate = &certificate
+	}
+	if privateKey != "" {
+		cfg.SpPrivateKey = &privateKey
+	}
+}
+
+// setSAMLMappings 设置属性映射和角色映射，为空的映射不修改.
+func setSAMLMappings(cfg *model.TenantSAMLConfigM, attributes map[string]string, roles map[string]*apiv1.SAMLRoleNames) error {
+	if len(attributes) > 0 {
+		for key := range attributes {
+			if _, ok := defaultSAMLAttributeMapping[key]; !ok {
+				return errno.ErrInvalidArgument.WithMessage("unknown saml attribute mapping key: %s", key)
+			}
+		}
+		data, _ := json.Marshal(attributes)
+		mapping := string(data)
+		cfg.AttributeMapping = &mapping
+	}
+	if len(roles) > 0 {
+		names := make(map[string][]string, len(roles))
+		for group, role := range roles {
+			if len(role.GetRoles()) == 0 {
+				return errno.ErrInvalidArgument.WithMessage("saml role mapping for group %s is empty", group)
+			}
+			names[group] = role.GetRoles()
+		}
+		data, _ := json.Marshal(names)
+		mapping := string(data)
+		cfg.RoleMapping = &mapping
+	}
+	return nil
+}
+
+// validateSAMLConfig 校验 SAML 配置，SP 证书和私钥必须成对配置.
+func validateSAMLConfig(cfg *model.TenantSAMLConfigM) error {
+	u, err := url.Parse(cfg.BaseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errno.ErrInvalidArgument.WithMessage("base_url must be an absolute http or https url")
+	}
+
+	if (cfg.SpCertificate == nil) != (cfg.SpPrivateKey == nil) {
+		return errno.ErrInvalidArgument.WithMessage("sp_certificate and sp_private_key must be configured together")
+	}
+	if cfg.SpCertificate != nil {
+		if _, err := tls.X509KeyPair([]byte(*cfg.SpCertificate), []byte(*cfg.SpPrivateKey)); err != nil {
+			return errno.ErrInvalidArgument.WithMessage("invalid sp key pair: %v", err)
+		}
+	} else if cfg.SignRequests {
+		return errno.ErrInvalidArgument.WithMessage("sign_requests requires sp_certificate and sp_private_key")
+	}
+	return nil
+}
+
+// toSAMLConfig 将 SAML 配置转换为响应，不返回 SP 私钥和 IdP 元数据.
+func toSAMLConfig(cfg *model.TenantSAMLConfigM) *apiv1.SAMLConfig {
+	attributes, _ := cfg.GetAttributeMapping()
+	roleMapping, _ := cfg.GetRoleMapping()
+	roles := make(map[string]*apiv1.SAMLRoleNames, len(roleMapping))
+	for group, names := range roleMapping {
+		roles[group] = &apiv1.SAMLRoleNames{Roles: names}
+	}
+
+	config := &apiv1.SAMLConfig{
+		TenantId:            cfg.TenantID,
+		Enabled:             cfg.Enabled,
+		BaseUrl:             cfg.BaseURL,
+		SpEntityId:          cfg.SpEntityID,
+		HasSpPrivateKey:     cfg.SpPrivateKey != nil && *cfg.SpPrivateKey != "",
+		IdpEntityId:         cfg.IdpEntityID,
+		IdpMetadataUrl:      cfg.IdpMetadataURL,
+		IdpMetadataImported: cfg.IdpMetadata != nil && *cfg.IdpMetadata != "",
+		AllowIdpInitiated:   cfg.AllowIdpInitiated,
+		SignRequests:        cfg.SignRequests,
+		NameIdFormat:        cfg.NameIDFormat,
+		AttributeMapping:    attributes,
+		RoleMapping:         roles,
+		CreatedAt:           timestamppb.New(cfg.CreatedAt),
+		UpdatedAt:           timestamppb.New(cfg.UpdatedAt),
+	}
+	if cfg.SpCertificate != nil {
+		config.SpCertificate = *cfg.SpCertificate
+	}
+	return config
+}
@@ -40,6 +40,15 @@ type UserExpansion interface {
 	Register(ctx context.Context, rq *apiv1.RegisterRequest) (*apiv1.RegisterResponse, error)
 	BindPhone(ctx context.Context, rq *apiv1.BindPhoneRequest) (*apiv1.BindPhoneResponse, error)
 	CheckPhoneAvailable(ctx context.Context, rq *apiv1.CheckPhoneAvailableRequest) (*apiv1.CheckPhoneAvailableResponse, error)
+	VerifySMSDeliveryReport(ctx context.Context, provider, timestamp, signature string, body []byte) error
+	HandleSMSDeliveryReport(ctx context.Context, rq *apiv1.SMSDeliveryReportRequest) (*apiv1.SMSDeliveryReportResponse, error)
+	GetSAMLMetadata(ctx context.Context, rq *apiv1.GetSAMLMetadataRequest) (*apiv1.GetSAMLMetadataResponse, error)
+	SAMLLogin(ctx context.Context, rq *apiv1.SAMLLoginRequest) (*apiv1.SAMLLoginResponse, error)
+	SAMLAssertion(ctx context.Context, rq *apiv1.SAMLAssertionRequest) (*apiv1.LoginResponse, error)
+	ImportSAMLIDPMetadata(ctx context.Context, rq *apiv1.ImportSAMLIDPMetadataRequest) (*apiv1.ImportSAMLIDPMetadataResponse, error)
+	GetSAMLConfig(ctx context.Context, rq *apiv1.GetSAMLConfigRequest) (*apiv1.GetSAMLConfigResponse, error)
+	CreateSAMLConfig(ctx context.Context, rq *apiv1.CreateSAMLConfigRequest) (*apiv1.CreateSAMLConfigResponse, error)
+	UpdateSAMLConfig(ctx context.Context, rq *apiv1.UpdateSAMLConfigRequest) (*apiv1.UpdateSAMLConfigResponse, error)
 }
 
 // userBiz 是 UserBiz 接口的实现.
@@ -48,6 +57,7 @@ type userBiz struct {
 	authz          *authz.Authz
 	loginSecurity  *cache.LoginSecurityManager
 	sessionManager *cache.SessionManager
+	samlState      *cache.SAMLStateManager
 	smsClient      sms.Client
 }
 
@@ -55,12 +65,13 @@ type userBiz struct {
 var _ UserBiz = (*userBiz)(nil)
 
 // New 创建一个 UserBiz 实例.
-func New(store store.IStore, authz *authz.Authz, sessionManager *cache.SessionManager, loginSecurity *cache.LoginSecurityManager, smsClient sms.Client) *userBiz {
+func New(store store.IStore, authz *authz.Authz, sessionManager *cache.SessionManager, loginSecurity *cache.LoginSecurityManager, samlState *cache.SAMLStateManager, smsClient sms.Client) *userBiz {
 	return &userBiz{
 		store:          store,
 		authz:          authz,
 		loginSecurity:  loginSecurity,
 		sessionManager: sessionManager,
+		samlState:      samlState,
 		smsClient:      smsClient,
 	}
 }
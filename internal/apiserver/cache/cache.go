@@ -23,6 +23,7 @@ var ProviderSet = wire.NewSet(
 	NewCache,
 	NewSessionManager,
 	NewLoginSecurityManager,
+	NewSAMLStateManager,
 	wire.Bind(new(ICache), new(*dataCache)),
 )
 
@@ -40,6 +41,7 @@ type ICache interface {
 	Del(ctx context.Context, keys ...string) error
 	Exists(ctx context.Context, key string) (bool, error)
 	Expire(ctx context.Context, key string, expiration time.Duration) error
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
 
 	// 业务缓存接口
 	User() UserCache
@@ -105,6 +107,11 @@ func (c *dataCache) Expire(ctx context.Context, key string, expiration time.Dura
 	return c.client.Expire(ctx, key, expiration).Err()
 }
 
+// SetNX 仅在key不存在时设置缓存值，返回是否设置成功.
+func (c *dataCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return c.client.SetNX(ctx, key, value, expiration).Result()
+}
+
 // User 返回一个实现了 UserCache 接口的实例.
 func (c *dataCache) User() UserCache {
 	return newUserCache(c)
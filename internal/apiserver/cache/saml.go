@@ -0,0 +1,90 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SAMLRequestExpiration SP 发起的认证请求有效期
+const SAMLRequestExpiration = 10 * time.Minute
+
+// SAMLRequestState SP 发起的认证请求状态，以 RelayState 为key保存
+type SAMLRequestState struct {
+	TenantID    int64     `json:"tenant_id"`
+	RequestID   string    `json:"request_id"`
+	RedirectURL string    `json:"redirect_url,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SAMLStateManager SAML 认证请求状态和断言重放管理器
+type SAMLStateManager struct {
+	cache ICache
+}
+
+// NewSAMLStateManager 创建 SAML 状态管理器
+func NewSAMLStateManager(cache ICache) *SAMLStateManager {
+	return &SAMLStateManager{cache: cache}
+}
+
+// requestKey 生成认证请求状态缓存key
+func (m *SAMLStateManager) requestKey(relayState string) string {
+	return fmt.Sprintf("saml_request:%s", relayState)
+}
+
+// assertionKey 生成已使用断言缓存key
+func (m *SAMLStateManager) assertionKey(tenantID int64, assertionID string) string {
+	return fmt.Sprintf("saml_assertion:%d:%s", tenantID, assertionID)
+}
+
+// SaveRequest 保存认证请求状态
+func (m *SAMLStateManager) SaveRequest(ctx context.Context, relayState string, state *SAMLRequestState) error {
+	return m.cache.Set(ctx, m.requestKey(relayState), state, SAMLRequestExpiration)
+}
+
+// TakeRequest 取出并删除认证请求状态，每个请求只能使用一次，不存在时返回 nil
+func (m *SAMLStateManager) TakeRequest(ctx context.Context, relayState string) (*SAMLRequestState, error) {
+	key := m.requestKey(relayState)
+	data, err := m.cache.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := m.cache.Del(ctx, key); err != nil {
+		return nil, err
+	}
+
+	var state SAMLRequestState
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// ReplayCache 返回租户的断言重放缓存
+func (m *SAMLStateManager) ReplayCache(tenantID int64) *SAMLReplayCache {
+	return &SAMLReplayCache{manager: m, tenantID: tenantID}
+}
+
+// SAMLReplayCache 基于 Redis 的断言重放缓存
+type SAMLReplayCache struct {
+	manager  *SAMLStateManager
+	tenantID int64
+}
+
+// Reserve 占用断言ID，ID 已被使用时返回 false
+func (c *SAMLReplayCache) Reserve(ctx context.Context, assertionID string, ttl time.Duration) (bool, error) {
+	return c.manager.cache.SetNX(ctx, c.manager.assertionKey(c.tenantID, assertionID), time.Now().Unix(), ttl)
+}
@@ -9,10 +9,13 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+
 	"github.com/ashwinyue/one-auth/internal/apiserver/model"
 )
 
@@ -38,6 +41,9 @@ var SessionValidDuration = map[ClientType]time.Duration{
 	ClientTypeOp:          12 * time.Hour,           // 运营端 12小时
 }
 
+// TokenRevocationTTL 用户令牌撤销记录的保留时间，不短于刷新令牌的有效期
+const TokenRevocationTTL = 7 * 24 * time.Hour
+
 // UserSession 用户会话信息
 type UserSession struct {
 	UserID     string     `json:"user_id"`
@@ -78,6 +84,11 @@ func (sm *SessionManager) deviceSessionKey(deviceID string) string {
 	return fmt.Sprintf("device_session:%s", deviceID)
 }
 
+// tokenRevokedKey 生成用户令牌撤销时间的缓存key
+func (sm *SessionManager) tokenRevokedKey(userID string) string {
+	return fmt.Sprintf("token_revoked:%s", userID)
+}
+
 // CreateSession 创建用户会话
 func (sm *SessionManager) CreateSession(ctx context.Context, session *UserSession) error {
 	// 设置会话过期时间
@@ -253,3 +264,26 @@ func (sm *SessionManager) CleanExpiredSessions(ctx context.Context) error {
 	// 目前依赖Redis的TTL机制自动清理
 	return nil
 }
+
+// RevokeUserTokens 撤销用户在当前时间之前签发的所有令牌
+func (sm *SessionManager) RevokeUserTokens(ctx context.Context, userID string) error {
+	return sm.cache.Set(ctx, sm.tokenRevokedKey(userID), strconv.FormatInt(time.Now().Unix(), 10), TokenRevocationTTL)
+}
+
+// IsTokenRevoked 检查用户在 issuedAt 签发的令牌是否已被撤销
+func (sm *SessionManager) IsTokenRevoked(ctx context.Context, userID string, issuedAt time.Time) (bool, error) {
+	data, err := sm.cache.Get(ctx, sm.tokenRevokedKey(userID))
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	revokedAt, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid token revocation time: %w", err)
+	}
+	// iat 精度为秒，与撤销同一秒签发的令牌同样视为已撤销
+	return issuedAt.Unix() <= revokedAt, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package apiserver
+
+import (
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"gorm.io/gorm"
+
+	catalogv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/catalog"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+)
+
+// NewCatalog 创建权限目录导入导出业务实例，供命令行在不启动服务器的情况下使用.
+func (cfg *Config) NewCatalog() (catalogv1.CatalogBiz, error) {
+	db, err := cfg.NewDB()
+	if err != nil {
+		return nil, err
+	}
+	authorizer, err := cfg.newCommandAuthz(db)
+	if err != nil {
+		return nil, err
+	}
+	return catalogv1.New(store.NewStore(db), authorizer), nil
+}
+
+// newCommandAuthz 创建命令行使用的授权器.
+// 通过 Redis 通知运行中的实例，Redis 不可用时由实例定期全量加载策略.
+func (cfg *Config) newCommandAuthz(db *gorm.DB) (*authz.Authz, error) {
+	opts := authz.DefaultOptions()
+	if rdb, err := cfg.NewRedis(); err != nil {
+		log.Warnw("Policy changes will not be pushed to running instances", "err", err)
+	} else if opts, err = ProvideAuthzOptions(rdb); err != nil {
+		log.Warnw("Policy changes will not be pushed to running instances", "err", err)
+		opts = authz.DefaultOptions()
+	}
+	return authz.NewAuthz(db, opts...)
+}
@@ -0,0 +1,25 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package apiserver
+
+import (
+	consistencyv1 "github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/consistency"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+)
+
+// NewConsistency 创建策略一致性检查业务实例，供命令行在不启动服务器的情况下使用.
+func (cfg *Config) NewConsistency() (consistencyv1.ConsistencyBiz, error) {
+	db, err := cfg.NewDB()
+	if err != nil {
+		return nil, err
+	}
+	authorizer, err := cfg.newCommandAuthz(db)
+	if err != nil {
+		return nil, err
+	}
+	return consistencyv1.New(store.NewStore(db), authorizer), nil
+}
@@ -8,17 +8,22 @@ package apiserver
 
 import (
 	"context"
+	"net/http"
 
 	genericvalidation "github.com/ashwinyue/one-auth/pkg/validation"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/selector"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 
 	handler "github.com/ashwinyue/one-auth/internal/apiserver/handler/grpc"
 	mw "github.com/ashwinyue/one-auth/internal/pkg/middleware/grpc"
 	"github.com/ashwinyue/one-auth/internal/pkg/server"
 	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/api/oneauth"
+	pdpv1 "github.com/ashwinyue/one-auth/pkg/api/pdp/v1"
 )
 
 // grpcServer 定义一个 gRPC 服务器.
@@ -46,9 +51,11 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 			// 请求 ID 拦截器
 			mw.RequestIDInterceptor(),
 			// 认证拦截器
-			selector.UnaryServerInterceptor(mw.AuthnInterceptor(c.store.User()), NewAuthnWhiteListMatcher()),
-			// 授权拦截器
-			selector.UnaryServerInterceptor(mw.AuthzInterceptor(c.authz), NewAuthzWhiteListMatcher()),
+			selector.UnaryServerInterceptor(mw.AuthnInterceptor(c.store.User(), c.sessions), NewAuthnWhiteListMatcher()),
+			// 服务凭证认证拦截器，只用于声明了 (oneauth.service) 的策略决策点方法
+			selector.UnaryServerInterceptor(mw.ServiceAuthnInterceptor(c.store.ServiceClient()), NewServiceMethodMatcher()),
+			// 授权拦截器，按方法在 proto 中声明的权限编码授权，声明为 public 的方法不检查权限
+			selector.UnaryServerInterceptor(mw.AuthzInterceptor(c.authz), NewAuthzMatcher()),
 			// 请求默认值设置拦截器
 			mw.DefaulterInterceptor(),
 			// 数据校验拦截器
@@ -63,6 +70,10 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 		serverOptions,
 		func(s grpc.ServiceRegistrar) {
 			apiv1.RegisterMiniBlogServer(s, handler.NewHandler(c.biz))
+			pdpv1.RegisterAuthorizationServer(s, handler.NewPDPHandler(c.biz))
+			if c.cfg.ServerMode == ExtAuthzServerMode {
+				authv3.RegisterAuthorizationServer(s, handler.NewExtAuthzHandler(c.biz))
+			}
 		},
 	)
 	if err != nil {
@@ -86,7 +97,16 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 		c.cfg.GRPCOptions,
 		c.cfg.TLSOptions,
 		func(mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-			return apiv1.RegisterMiniBlogHandler(context.Background(), mux, conn)
+			if err := apiv1.RegisterMiniBlogHandler(context.Background(), mux, conn); err != nil {
+				return err
+			}
+			if err := pdpv1.RegisterAuthorizationHandler(context.Background(), mux, conn); err != nil {
+				return err
+			}
+			if err := installMetrics(mux); err != nil {
+				return err
+			}
+			return installForwardAuth(mux, c.NewForwardAuthHandler())
 		},
 	)
 	if err != nil {
@@ -112,28 +132,62 @@ func (s *grpcServer) GracefulStop(ctx context.Context) {
 	s.stop(ctx)
 }
 
-// NewAuthnWhiteListMatcher 创建认证白名单匹配器.
+// installMetrics 在 HTTP 反向代理服务器上挂载 Prometheus 指标接口，与 Gin 服务器的 /metrics 一致.
+func installMetrics(mux *runtime.ServeMux) error {
+	metrics := promhttp.Handler()
+	return mux.HandlePath(http.MethodGet, "/metrics", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		metrics.ServeHTTP(w, r)
+	})
+}
+
+// installForwardAuth 在 HTTP 反向代理服务器上挂载转发认证接口，nginx 子请求沿用原始请求的方法，因此接受常用的全部方法.
+func installForwardAuth(mux *runtime.ServeMux, h http.Handler) error {
+	methods := []string{
+		http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions,
+	}
+	for _, method := range methods {
+		err := mux.HandlePath(method, "/auth/verify", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+			h.ServeHTTP(w, r)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewAuthnWhiteListMatcher 创建认证白名单匹配器，白名单中的方法和使用服务凭证认证的方法不需要用户认证.
+// Envoy 外部授权方法自行认证被转发请求中的令牌.
 func NewAuthnWhiteListMatcher() selector.Matcher {
 	whitelist := map[string]struct{}{
-		apiv1.MiniBlog_Healthz_FullMethodName:    {},
-		apiv1.MiniBlog_CreateUser_FullMethodName: {},
-		apiv1.MiniBlog_Login_FullMethodName:      {},
+		apiv1.MiniBlog_Healthz_FullMethodName:     {},
+		apiv1.MiniBlog_CreateUser_FullMethodName:  {},
+		apiv1.MiniBlog_Login_FullMethodName:       {},
+		authv3.Authorization_Check_FullMethodName: {},
 	}
 	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
 		_, ok := whitelist[call.FullMethod()]
-		return !ok
+		return !ok && !isServiceMethod(call.FullMethod())
 	})
 }
 
-// NewAuthzWhiteListMatcher 创建授权白名单匹配器.
-func NewAuthzWhiteListMatcher() selector.Matcher {
-	whitelist := map[string]struct{}{
-		apiv1.MiniBlog_Healthz_FullMethodName:    {},
-		apiv1.MiniBlog_CreateUser_FullMethodName: {},
-		apiv1.MiniBlog_Login_FullMethodName:      {},
-	}
+// NewAuthzMatcher 创建授权匹配器，使用服务凭证认证的方法和 Envoy 外部授权方法不检查用户权限.
+func NewAuthzMatcher() selector.Matcher {
 	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
-		_, ok := whitelist[call.FullMethod()]
-		return !ok
+		return call.FullMethod() != authv3.Authorization_Check_FullMethodName && !isServiceMethod(call.FullMethod())
+	})
+}
+
+// NewServiceMethodMatcher 创建服务方法匹配器，匹配声明了 (oneauth.service) 的方法.
+func NewServiceMethodMatcher() selector.Matcher {
+	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
+		return isServiceMethod(call.FullMethod())
 	})
 }
+
+// isServiceMethod 判断方法是否使用服务凭证认证
+func isServiceMethod(fullMethod string) bool {
+	rule, ok := oneauth.ForMethod(fullMethod)
+	return ok && rule.Service
+}
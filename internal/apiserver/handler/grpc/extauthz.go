@@ -0,0 +1,34 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/biz"
+)
+
+// ExtAuthzHandler 负责处理 Envoy 外部授权请求.
+type ExtAuthzHandler struct {
+	authv3.UnimplementedAuthorizationServer
+
+	biz biz.IBiz
+}
+
+// NewExtAuthzHandler 创建一个新的 ExtAuthzHandler 实例.
+func NewExtAuthzHandler(biz biz.IBiz) *ExtAuthzHandler {
+	return &ExtAuthzHandler{
+		biz: biz,
+	}
+}
+
+// Check 认证并授权 Envoy 转发的请求.
+func (h *ExtAuthzHandler) Check(ctx context.Context, rq *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	return h.biz.ExtAuthzV1().Check(ctx, rq)
+}
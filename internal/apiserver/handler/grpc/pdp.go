@@ -0,0 +1,43 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/biz"
+	pdpv1 "github.com/ashwinyue/one-auth/pkg/api/pdp/v1"
+)
+
+// PDPHandler 负责处理策略决策点的请求.
+type PDPHandler struct {
+	pdpv1.UnimplementedAuthorizationServer
+
+	biz biz.IBiz
+}
+
+// NewPDPHandler 创建一个新的 PDPHandler 实例.
+func NewPDPHandler(biz biz.IBiz) *PDPHandler {
+	return &PDPHandler{
+		biz: biz,
+	}
+}
+
+// Check 批量检查用户能否对资源执行操作.
+func (h *PDPHandler) Check(ctx context.Context, rq *pdpv1.CheckRequest) (*pdpv1.CheckResponse, error) {
+	return h.biz.PDPV1().Check(ctx, rq)
+}
+
+// ListAllowedActions 列出用户可以对资源执行的操作.
+func (h *PDPHandler) ListAllowedActions(ctx context.Context, rq *pdpv1.ListAllowedActionsRequest) (*pdpv1.ListAllowedActionsResponse, error) {
+	return h.biz.PDPV1().ListAllowedActions(ctx, rq)
+}
+
+// ListAccessibleResources 列出用户可以访问的资源.
+func (h *PDPHandler) ListAccessibleResources(ctx context.Context, rq *pdpv1.ListAccessibleResourcesRequest) (*pdpv1.ListAccessibleResourcesResponse, error) {
+	return h.biz.PDPV1().ListAccessibleResources(ctx, rq)
+}
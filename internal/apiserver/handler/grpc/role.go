@@ -0,0 +1,58 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+)
+
+// GetUserRoles 获取用户在当前租户下的角色.
+func (h *Handler) GetUserRoles(ctx context.Context, rq *apiv1.GetUserRolesRequest) (*apiv1.GetUserRolesResponse, error) {
+	return h.biz.RoleV1().GetUserRoles(ctx, rq)
+}
+
+// AssignUserRoles 替换用户在当前租户下的角色.
+func (h *Handler) AssignUserRoles(ctx context.Context, rq *apiv1.AssignUserRolesRequest) (*apiv1.AssignUserRolesResponse, error) {
+	return h.biz.RoleV1().AssignUserRoles(ctx, rq)
+}
+
+// GetRolesByUser 获取当前用户的角色.
+func (h *Handler) GetRolesByUser(ctx context.Context, rq *apiv1.GetRolesByUserRequest) (*apiv1.GetRolesByUserResponse, error) {
+	return h.biz.RoleV1().GetRolesByUser(ctx, rq)
+}
+
+// GetRolePermissions 获取角色的权限规则.
+func (h *Handler) GetRolePermissions(ctx context.Context, rq *apiv1.GetRolePermissionsRequest) (*apiv1.GetRolePermissionsResponse, error) {
+	return h.biz.RoleV1().GetPermissions(ctx, rq)
+}
+
+// AssignRolePermissions 为角色添加权限规则.
+func (h *Handler) AssignRolePermissions(ctx context.Context, rq *apiv1.AssignRolePermissionsRequest) (*apiv1.AssignRolePermissionsResponse, error) {
+	return h.biz.RoleV1().AssignPermissions(ctx, rq)
+}
+
+// GetRoleMenus 获取角色可访问的菜单.
+func (h *Handler) GetRoleMenus(ctx context.Context, rq *apiv1.GetRoleMenusRequest) (*apiv1.GetRoleMenusResponse, error) {
+	return h.biz.RoleV1().GetRoleMenus(ctx, rq)
+}
+
+// UpdateRoleMenus 更新角色可访问的菜单.
+func (h *Handler) UpdateRoleMenus(ctx context.Context, rq *apiv1.UpdateRoleMenusRequest) (*apiv1.UpdateRoleMenusResponse, error) {
+	return h.biz.RoleV1().UpdateRoleMenus(ctx, rq)
+}
+
+// CheckDeleteRole 检查角色是否可以删除.
+func (h *Handler) CheckDeleteRole(ctx context.Context, rq *apiv1.CheckDeleteRoleRequest) (*apiv1.CheckDeleteRoleResponse, error) {
+	return h.biz.RoleV1().CheckDeleteRole(ctx, rq)
+}
+
+// RefreshPrivilegeData 从数据库重新加载权限数据.
+func (h *Handler) RefreshPrivilegeData(ctx context.Context, rq *apiv1.RefreshPrivilegeDataRequest) (*apiv1.RefreshPrivilegeDataResponse, error) {
+	return h.biz.RoleV1().RefreshPrivilegeData(ctx, rq)
+}
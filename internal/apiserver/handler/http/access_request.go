@@ -0,0 +1,63 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ashwinyue/one-auth/pkg/core"
+)
+
+// CreateAccessRequest 当前用户申请角色或权限
+func (h *Handler) CreateAccessRequest(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.AccessRequestV1().Create)
+}
+
+// ListMyAccessRequests 获取当前用户提交的申请
+func (h *Handler) ListMyAccessRequests(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.AccessRequestV1().ListMine)
+}
+
+// ListAccessRequestReviews 获取当前用户可以审批的申请
+func (h *Handler) ListAccessRequestReviews(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.AccessRequestV1().ListReviews)
+}
+
+// GetAccessRequest 获取申请详情
+func (h *Handler) GetAccessRequest(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.AccessRequestV1().Get)
+}
+
+// ApproveAccessRequest 批准申请
+func (h *Handler) ApproveAccessRequest(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.AccessRequestV1().Approve)
+}
+
+// RejectAccessRequest 拒绝申请
+func (h *Handler) RejectAccessRequest(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.AccessRequestV1().Reject)
+}
+
+// CancelAccessRequest 撤回申请
+func (h *Handler) CancelAccessRequest(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.AccessRequestV1().Cancel)
+}
+
+// ListAccessApprovalPolicies 获取审批策略列表
+func (h *Handler) ListAccessApprovalPolicies(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.AccessRequestV1().ListPolicies)
+}
+
+// SetAccessApprovalPolicy 设置审批策略
+func (h *Handler) SetAccessApprovalPolicy(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.AccessRequestV1().SetPolicy)
+}
+
+// DeleteAccessApprovalPolicy 删除审批策略
+func (h *Handler) DeleteAccessApprovalPolicy(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.AccessRequestV1().DeletePolicy)
+}
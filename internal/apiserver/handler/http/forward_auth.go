@@ -0,0 +1,170 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/biz"
+	"github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/extauthz"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	"github.com/ashwinyue/one-auth/pkg/core"
+	"github.com/ashwinyue/one-auth/pkg/errorsx"
+)
+
+// ForwardAuthHandler 处理 nginx auth_request 和 Traefik ForwardAuth 的鉴权子请求.
+// 基于 net/http 实现，Gin 服务器和 gRPC-Gateway 服务器都可以挂载.
+type ForwardAuthHandler struct {
+	biz            biz.IBiz
+	cache          *extauthz.VerifyCache
+	trustedProxies []*net.IPNet
+}
+
+// NewForwardAuthHandler 创建一个新的 ForwardAuthHandler 实例，cache 为 nil 时不缓存鉴权结果.
+// trustedProxies 为可信代理的网段或 IP，只有来自可信代理的请求才使用代理传入的客户端IP.
+func NewForwardAuthHandler(biz biz.IBiz, cache *extauthz.VerifyCache, trustedProxies []*net.IPNet) *ForwardAuthHandler {
+	return &ForwardAuthHandler{biz: biz, cache: cache, trustedProxies: trustedProxies}
+}
+
+// ParseTrustedProxies 解析可信代理列表，元素可以是网段或单个 IP.
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy: %s", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %s", proxy)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// ServeHTTP 认证原始请求并检查路由权限，允许时返回 200 和身份请求头，否则返回 401/403.
+// 只读取请求头，不读取请求体.
+func (h *ForwardAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rq, err := h.forwardedRequest(r)
+	if err == nil {
+		var id *extauthz.Identity
+		if id, err = h.cache.Verify(r.Context(), h.biz.ExtAuthzV1(), rq); err == nil {
+			for key, value := range id.Headers() {
+				w.Header().Set(key, value)
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	errx := errorsx.FromError(err)
+	if errx.Code >= http.StatusInternalServerError {
+		log.W(r.Context()).Errorw("Forward auth failed", "err", err)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(errx.Code)
+	_ = json.NewEncoder(w).Encode(core.ErrorResponse{Reason: errx.Reason, Message: errx.Message, Metadata: errx.Metadata})
+}
+
+// forwardedRequest 从代理设置的请求头中还原原始请求.
+// nginx 使用 X-Original-Method/X-Original-URI，Traefik 使用 X-Forwarded-Method/X-Forwarded-Uri.
+func (h *ForwardAuthHandler) forwardedRequest(r *http.Request) (*extauthz.VerifyRequest, error) {
+	rawURI := firstHeader(r, "X-Original-URI", "X-Forwarded-Uri")
+	if rawURI == "" {
+		return nil, errno.ErrInvalidArgument.WithMessage("missing X-Original-URI or X-Forwarded-Uri header")
+	}
+	uri, err := url.ParseRequestURI(rawURI)
+	if err != nil {
+		return nil, errno.ErrInvalidArgument.WithMessage("invalid original URI: %s", rawURI)
+	}
+
+	method := firstHeader(r, "X-Original-Method", "X-Forwarded-Method")
+	if method == "" {
+		method = r.Method
+	}
+
+	return &extauthz.VerifyRequest{
+		Method: strings.ToUpper(method),
+		Path:   uri.Path,
+		IP:     h.clientIP(r),
+		Headers: map[string]string{
+			"authorization":         r.Header.Get("Authorization"),
+			extauthz.HeaderAPIKey:   r.Header.Get(extauthz.HeaderAPIKey),
+			extauthz.HeaderTenantID: r.Header.Get(extauthz.HeaderTenantID),
+		},
+	}, nil
+}
+
+// clientIP 返回原始请求的客户端IP.
+// 客户端可以伪造 X-Forwarded-For，因此只有直接对端是可信代理时才读取代理传入的请求头：
+// 优先使用代理覆盖写入的 X-Real-IP，否则从右向左跳过可信代理，取 X-Forwarded-For 中第一个不可信的地址.
+// 没有配置可信代理时直接使用对端地址.
+func (h *ForwardAuthHandler) clientIP(r *http.Request) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if !h.trusted(remote) {
+		return remote
+	}
+
+	if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(ip) != nil {
+		return ip
+	}
+	ip := remote
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		ip = hop
+		if !h.trusted(hop) {
+			break
+		}
+	}
+	return ip
+}
+
+// trusted 判断 IP 是否属于可信代理
+func (h *ForwardAuthHandler) trusted(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range h.trustedProxies {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstHeader 返回第一个不为空的请求头
+func firstHeader(r *http.Request, keys ...string) string {
+	for _, key := range keys {
+		if value := r.Header.Get(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
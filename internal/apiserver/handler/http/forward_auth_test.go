@@ -0,0 +1,94 @@
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/biz"
+	"github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/extauthz"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+)
+
+type fakeExtAuthzBiz struct {
+	rq *extauthz.VerifyRequest
+}
+
+func (f *fakeExtAuthzBiz) Check(context.Context, *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeExtAuthzBiz) Verify(_ context.Context, rq *extauthz.VerifyRequest) (*extauthz.Identity, error) {
+	f.rq = rq
+	return &extauthz.Identity{UserID: 1, TenantID: 2}, nil
+}
+
+type fakeBiz struct {
+	biz.IBiz
+	extAuthz *fakeExtAuthzBiz
+}
+
+func (f *fakeBiz) ExtAuthzV1() extauthz.ExtAuthzBiz {
+	return f.extAuthz
+}
+
+// verifiedIP 调用转发认证接口，返回传给鉴权逻辑的客户端IP
+func verifiedIP(t *testing.T, trustedProxies []string, remoteAddr string, headers map[string]string) string {
+	networks, err := ParseTrustedProxies(trustedProxies)
+	require.NoError(t, err)
+	b := &fakeBiz{extAuthz: &fakeExtAuthzBiz{}}
+	h := NewForwardAuthHandler(b, nil, networks)
+
+	r := httptest.NewRequest(http.MethodGet, "/auth/verify", nil)
+	r.RemoteAddr = remoteAddr
+	r.Header.Set("X-Original-URI", "/v1/orders")
+	for key, value := range headers {
+		r.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	return b.extAuthz.rq.IP
+}
+
+func TestForwardAuth_SpoofedForwardedFor(t *testing.T) {
+	cond, err := authz.CompileCondition(`cidrMatch(request.ip, "10.0.0.0/8")`)
+	require.NoError(t, err)
+	matches := func(ip string) bool {
+		ok, err := cond.Evaluate(map[string]any{"request": map[string]any{"ip": ip}})
+		require.NoError(t, err)
+		return ok
+	}
+
+	// 未配置可信代理时忽略客户端传入的转发请求头
+	ip := verifiedIP(t, nil, "203.0.113.7:4321", map[string]string{"X-Forwarded-For": "10.0.0.1", "X-Real-IP": "10.0.0.1"})
+	assert.Equal(t, "203.0.113.7", ip)
+	assert.False(t, matches(ip))
+
+	// 可信代理追加的最右侧地址才是客户端IP，客户端伪造的最左侧地址不生效
+	ip = verifiedIP(t, []string{"192.168.0.0/16"}, "192.168.1.1:4321", map[string]string{"X-Forwarded-For": "10.0.0.1, 203.0.113.7, 192.168.1.2"})
+	assert.Equal(t, "203.0.113.7", ip)
+	assert.False(t, matches(ip))
+
+	// 可信代理覆盖写入的 X-Real-IP 优先
+	ip = verifiedIP(t, []string{"192.168.1.1"}, "192.168.1.1:4321", map[string]string{"X-Forwarded-For": "203.0.113.7", "X-Real-IP": "10.1.2.3"})
+	assert.Equal(t, "10.1.2.3", ip)
+	assert.True(t, matches(ip))
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	networks, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "::1"})
+	require.NoError(t, err)
+	require.Len(t, networks, 3)
+	assert.True(t, networks[1].Contains(net.ParseIP("192.168.1.1")))
+	assert.False(t, networks[1].Contains(net.ParseIP("192.168.1.2")))
+
+	_, err = ParseTrustedProxies([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,28 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ashwinyue/one-auth/pkg/core"
+)
+
+// PDPCheck 批量检查用户能否对资源执行操作
+func (h *Handler) PDPCheck(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PDPV1().Check)
+}
+
+// PDPListAllowedActions 列出用户可以对资源执行的操作
+func (h *Handler) PDPListAllowedActions(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PDPV1().ListAllowedActions)
+}
+
+// PDPListAccessibleResources 列出用户可以访问的资源
+func (h *Handler) PDPListAccessibleResources(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PDPV1().ListAccessibleResources)
+}
@@ -25,3 +25,53 @@ func (h *Handler) CheckPermissions(c *gin.Context) {
 func (h *Handler) CheckAPIAccess(c *gin.Context) {
 	core.HandleQueryRequest(c, h.biz.PermissionV1().CheckAPIAccess)
 }
+
+// ExplainAccess 查询授权决策轨迹
+func (h *Handler) ExplainAccess(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PermissionV1().ExplainAccess)
+}
+
+// PreviewPermissionAssignment 预览权限分配变更的效果
+func (h *Handler) PreviewPermissionAssignment(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PermissionV1().PreviewAssignment)
+}
+
+// UpdatePermissionCondition 更新权限生效条件
+func (h *Handler) UpdatePermissionCondition(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.PermissionV1().UpdateCondition)
+}
+
+// GetAutoAssignConfig 获取管理员权限自动分配配置
+func (h *Handler) GetAutoAssignConfig(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.AutoAssignV1().GetAutoAssignConfig)
+}
+
+// UpdateAutoAssignConfig 更新管理员权限自动分配配置
+func (h *Handler) UpdateAutoAssignConfig(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.AutoAssignV1().UpdateAutoAssignConfig)
+}
+
+// SyncAdminPermissions 同步管理员缺失的权限
+func (h *Handler) SyncAdminPermissions(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.AutoAssignV1().SyncAdminPermissions)
+}
+
+// GetAdminMissingPermissions 获取管理员缺失的权限
+func (h *Handler) GetAdminMissingPermissions(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.AutoAssignV1().GetAdminMissingPermissions)
+}
+
+// CreateMenuWithPermissions 创建菜单并生成菜单权限
+func (h *Handler) CreateMenuWithPermissions(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.AutoAssignV1().CreateMenuWithPermissions)
+}
+
+// ImportPermissionCatalog 导入权限目录清单
+func (h *Handler) ImportPermissionCatalog(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.CatalogV1().Import)
+}
+
+// ExportPermissionCatalog 导出权限目录清单
+func (h *Handler) ExportPermissionCatalog(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.CatalogV1().Export)
+}
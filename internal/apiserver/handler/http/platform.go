@@ -0,0 +1,87 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package http
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/core"
+)
+
+// ListAllTenants 平台运营人员获取全部租户
+func (h *Handler) ListAllTenants(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PlatformV1().ListTenants)
+}
+
+// CreateTenant 平台运营人员创建租户
+func (h *Handler) CreateTenant(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PlatformV1().CreateTenant)
+}
+
+// UpdateTenantStatus 平台运营人员启用或禁用租户
+func (h *Handler) UpdateTenantStatus(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.PlatformV1().UpdateTenantStatus)
+}
+
+// AssignTenantOwner 平台运营人员指定租户所有者
+func (h *Handler) AssignTenantOwner(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.PlatformV1().AssignTenantOwner)
+}
+
+// ListPlatformOperators 获取平台运营人员列表
+func (h *Handler) ListPlatformOperators(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PlatformV1().ListOperators)
+}
+
+// AddPlatformOperator 添加平台运营人员
+func (h *Handler) AddPlatformOperator(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PlatformV1().AddOperator)
+}
+
+// RemovePlatformOperator 移除平台运营人员
+func (h *Handler) RemovePlatformOperator(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.PlatformV1().RemoveOperator)
+}
+
+// ListPlatformAuditLogs 获取平台操作审计日志
+func (h *Handler) ListPlatformAuditLogs(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PlatformV1().ListAuditLogs)
+}
+
+// CheckConsistency 检查 Casbin 策略与关系数据的一致性，只生成报告
+func (h *Handler) CheckConsistency(c *gin.Context) {
+	core.HandleQueryRequest(c, func(ctx context.Context, rq *apiv1.CheckConsistencyRequest) (*apiv1.CheckConsistencyResponse, error) {
+		rq.Fix = false
+		return h.biz.ConsistencyV1().Check(ctx, rq)
+	})
+}
+
+// RepairConsistency 检查一致性并删除可以自动处理的问题规则
+func (h *Handler) RepairConsistency(c *gin.Context) {
+	core.HandleQueryRequest(c, func(ctx context.Context, rq *apiv1.CheckConsistencyRequest) (*apiv1.CheckConsistencyResponse, error) {
+		rq.Fix = true
+		return h.biz.ConsistencyV1().Check(ctx, rq)
+	})
+}
+
+// CreateServiceClient 创建调用策略决策点的服务凭证
+func (h *Handler) CreateServiceClient(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PlatformV1().CreateServiceClient)
+}
+
+// ListServiceClients 获取服务凭证列表
+func (h *Handler) ListServiceClients(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PlatformV1().ListServiceClients)
+}
+
+// RevokeServiceClient 吊销服务凭证
+func (h *Handler) RevokeServiceClient(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.PlatformV1().RevokeServiceClient)
+}
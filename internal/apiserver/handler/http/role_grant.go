@@ -0,0 +1,43 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ashwinyue/one-auth/pkg/core"
+)
+
+// ListRoleGrants 获取限时角色授予列表
+func (h *Handler) ListRoleGrants(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.RoleGrantV1().List)
+}
+
+// CreateRoleGrant 为用户创建限时角色授予
+func (h *Handler) CreateRoleGrant(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.RoleGrantV1().Create)
+}
+
+// RequestRoleGrant 当前用户申请临时提权
+func (h *Handler) RequestRoleGrant(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.RoleGrantV1().Request)
+}
+
+// ApproveRoleGrant 审批通过临时提权申请
+func (h *Handler) ApproveRoleGrant(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.RoleGrantV1().Approve)
+}
+
+// RejectRoleGrant 驳回临时提权申请
+func (h *Handler) RejectRoleGrant(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.RoleGrantV1().Reject)
+}
+
+// RevokeRoleGrant 撤销限时角色授予
+func (h *Handler) RevokeRoleGrant(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.RoleGrantV1().Revoke)
+}
@@ -30,3 +30,63 @@ func (h *Handler) DeleteRole(c *gin.Context) {
 func (h *Handler) ListRoles(c *gin.Context) {
 	core.HandleQueryRequest(c, h.biz.RoleV1().List)
 }
+
+// GetRolePermissions 获取角色的权限规则
+func (h *Handler) GetRolePermissions(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.RoleV1().GetPermissions)
+}
+
+// AssignRolePermissions 为角色添加 allow 或 deny 权限规则
+func (h *Handler) AssignRolePermissions(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.RoleV1().AssignPermissions)
+}
+
+// RevokeRolePermissions 删除角色的权限规则
+func (h *Handler) RevokeRolePermissions(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.RoleV1().RevokePermissions)
+}
+
+// GetRoleParents 获取角色的父角色和子角色
+func (h *Handler) GetRoleParents(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.RoleV1().GetParents)
+}
+
+// SetRoleParents 设置角色继承的父角色
+func (h *Handler) SetRoleParents(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.RoleV1().SetParents)
+}
+
+// GetRoleMenus 获取角色可访问的菜单
+func (h *Handler) GetRoleMenus(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.RoleV1().GetRoleMenus)
+}
+
+// UpdateRoleMenus 更新角色可访问的菜单
+func (h *Handler) UpdateRoleMenus(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.RoleV1().UpdateRoleMenus)
+}
+
+// CheckDeleteRole 检查角色是否可以删除
+func (h *Handler) CheckDeleteRole(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.RoleV1().CheckDeleteRole)
+}
+
+// RefreshPrivilegeData 从数据库重新加载权限数据
+func (h *Handler) RefreshPrivilegeData(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.RoleV1().RefreshPrivilegeData)
+}
+
+// GetUserRoles 获取用户在当前租户下的角色
+func (h *Handler) GetUserRoles(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.RoleV1().GetUserRoles)
+}
+
+// AssignUserRoles 替换用户在当前租户下的角色
+func (h *Handler) AssignUserRoles(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.RoleV1().AssignUserRoles)
+}
+
+// GetRolesByUser 获取当前用户的角色
+func (h *Handler) GetRolesByUser(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.RoleV1().GetRolesByUser)
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/ashwinyue/one-auth/pkg/core"
+	"github.com/gin-gonic/gin"
+
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/client/saml"
+)
+
+// SAMLMetadata 返回租户的 SP 元数据 XML.
+func (h *Handler) SAMLMetadata(c *gin.Context) {
+	var req apiv1.GetSAMLMetadataRequest
+	if err := core.ShouldBindUri(c, &req); err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+
+	resp, err := h.biz.UserV1().GetSAMLMetadata(c.Request.Context(), &req)
+	if err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/samlmetadata+xml", []byte(resp.GetMetadata()))
+}
+
+// SAMLLogin 发起 SP 登录，重定向或自动提交表单到 IdP.
+func (h *Handler) SAMLLogin(c *gin.Context) {
+	var req apiv1.SAMLLoginRequest
+	if err := core.ReadRequest(c, &req, bindUriAnd(c, c.ShouldBindQuery)); err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+
+	resp, err := h.biz.UserV1().SAMLLogin(c.Request.Context(), &req)
+	if err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+
+	if resp.GetBinding() == saml.BindingPost {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(resp.GetFormHtml()))
+		return
+	}
+	c.Redirect(http.StatusFound, resp.GetRedirectUrl())
+}
+
+// SAMLAssertion 断言消费服务，校验 IdP 通过 HTTP-POST 绑定提交的断言并完成登录.
+func (h *Handler) SAMLAssertion(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBind), h.biz.UserV1().SAMLAssertion)
+}
+
+// ImportSAMLIDPMetadata 导入租户的 IdP 元数据.
+func (h *Handler) ImportSAMLIDPMetadata(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.UserV1().ImportSAMLIDPMetadata)
+}
+
+// GetSAMLConfig 获取租户的 SAML 配置.
+func (h *Handler) GetSAMLConfig(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().GetSAMLConfig)
+}
+
+// CreateSAMLConfig 创建租户的 SAML 配置.
+func (h *Handler) CreateSAMLConfig(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.UserV1().CreateSAMLConfig)
+}
+
+// UpdateSAMLConfig 更新租户的 SAML 配置.
+func (h *Handler) UpdateSAMLConfig(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.UserV1().UpdateSAMLConfig)
+}
+
+// bindUriAnd 先绑定路径参数，再使用指定的绑定函数绑定其余参数.
+func bindUriAnd(c *gin.Context, binder core.Binder) core.Binder {
+	return func(obj any) error {
+		if err := c.ShouldBindUri(obj); err != nil {
+			return err
+		}
+		return binder(obj)
+	}
+}
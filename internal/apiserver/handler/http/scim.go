@@ -0,0 +1,333 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/ashwinyue/one-auth/pkg/core"
+	"github.com/ashwinyue/one-auth/pkg/errorsx"
+	"github.com/gin-gonic/gin"
+
+	"github.com/ashwinyue/one-auth/pkg/scim"
+)
+
+// SCIM 发现端点返回的静态内容
+var (
+	scimServiceProviderConfig = map[string]any{
+		"schemas":        []string{scim.SchemaServiceProviderConfig},
+		"patch":          map[string]any{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": scim.MaxCount},
+		"changePassword": map[string]any{"supported": true},
+		"sort":           map[string]any{"supported": false},
+		"etag":           map[string]any{"supported": true},
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "Tenant scoped SCIM bearer token",
+			"primary":     true,
+		}},
+	}
+
+	scimResourceTypes = []map[string]any{
+		{
+			"schemas":  []string{scim.SchemaResourceType},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   scim.SchemaUser,
+		},
+		{
+			"schemas":  []string{scim.SchemaResourceType},
+			"id":       "Group",
+			"name":     "Group",
+			"endpoint": "/Groups",
+			"schema":   scim.SchemaGroup,
+		},
+	}
+)
+
+// SCIMServiceProviderConfig 返回 SCIM 服务能力说明.
+func (h *Handler) SCIMServiceProviderConfig(c *gin.Context) {
+	writeSCIM(c, http.StatusOK, scimServiceProviderConfig)
+}
+
+// SCIMResourceTypes 返回支持的 SCIM 资源类型.
+func (h *Handler) SCIMResourceTypes(c *gin.Context) {
+	resources := make([]any, 0, len(scimResourceTypes))
+	for _, r := range scimResourceTypes {
+		resources = append(resources, r)
+	}
+	writeSCIM(c, http.StatusOK, scim.NewListResponse(int64(len(resources)), 1, resources))
+}
+
+// ListSCIMUsers 查询用户.
+func (h *Handler) ListSCIMUsers(c *gin.Context) {
+	var q scim.ListQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		writeSCIMError(c, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "%v", err))
+		return
+	}
+
+	resp, err := h.biz.SCIMV1().ListUsers(c.Request.Context(), &q)
+	if err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	for _, r := range resp.Resources {
+		setSCIMLocation(c, r.(*scim.User).Meta, "Users", r.(*scim.User).ID)
+	}
+	writeSCIM(c, http.StatusOK, resp)
+}
+
+// GetSCIMUser 获取用户.
+func (h *Handler) GetSCIMUser(c *gin.Context) {
+	user, err := h.biz.SCIMV1().GetUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	writeSCIMResource(c, http.StatusOK, user, user.Meta, "Users", user.ID)
+}
+
+// CreateSCIMUser 创建用户.
+func (h *Handler) CreateSCIMUser(c *gin.Context) {
+	var in scim.User
+	if err := readSCIMBody(c, &in); err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+
+	user, err := h.biz.SCIMV1().CreateUser(c.Request.Context(), &in)
+	if err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	writeSCIMResource(c, http.StatusCreated, user, user.Meta, "Users", user.ID)
+}
+
+// ReplaceSCIMUser 整体替换用户.
+func (h *Handler) ReplaceSCIMUser(c *gin.Context) {
+	var in scim.User
+	if err := readSCIMBody(c, &in); err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+
+	user, err := h.biz.SCIMV1().ReplaceUser(c.Request.Context(), c.Param("id"), &in, c.GetHeader("If-Match"))
+	if err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	writeSCIMResource(c, http.StatusOK, user, user.Meta, "Users", user.ID)
+}
+
+// PatchSCIMUser 部分更新用户.
+func (h *Handler) PatchSCIMUser(c *gin.Context) {
+	var rq scim.PatchRequest
+	if err := readSCIMBody(c, &rq); err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+
+	user, err := h.biz.SCIMV1().PatchUser(c.Request.Context(), c.Param("id"), &rq, c.GetHeader("If-Match"))
+	if err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	writeSCIMResource(c, http.StatusOK, user, user.Meta, "Users", user.ID)
+}
+
+// DeleteSCIMUser 停用用户并撤销其会话.
+func (h *Handler) DeleteSCIMUser(c *gin.Context) {
+	if err := h.biz.SCIMV1().DeleteUser(c.Request.Context(), c.Param("id"), c.GetHeader("If-Match")); err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListSCIMGroups 查询组.
+func (h *Handler) ListSCIMGroups(c *gin.Context) {
+	var q scim.ListQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		writeSCIMError(c, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "%v", err))
+		return
+	}
+
+	resp, err := h.biz.SCIMV1().ListGroups(c.Request.Context(), &q)
+	if err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	for _, r := range resp.Resources {
+		setSCIMLocation(c, r.(*scim.Group).Meta, "Groups", r.(*scim.Group).ID)
+	}
+	writeSCIM(c, http.StatusOK, resp)
+}
+
+// GetSCIMGroup 获取组.
+func (h *Handler) GetSCIMGroup(c *gin.Context) {
+	var q scim.ListQuery
+	_ = c.ShouldBindQuery(&q)
+
+	group, err := h.biz.SCIMV1().GetGroup(c.Request.Context(), c.Param("id"), &q)
+	if err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	writeSCIMResource(c, http.StatusOK, group, group.Meta, "Groups", group.ID)
+}
+
+// CreateSCIMGroup 创建组.
+func (h *Handler) CreateSCIMGroup(c *gin.Context) {
+	var in scim.Group
+	if err := readSCIMBody(c, &in); err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+
+	group, err := h.biz.SCIMV1().CreateGroup(c.Request.Context(), &in)
+	if err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	writeSCIMResource(c, http.StatusCreated, group, group.Meta, "Groups", group.ID)
+}
+
+// ReplaceSCIMGroup 整体替换组.
+func (h *Handler) ReplaceSCIMGroup(c *gin.Context) {
+	var in scim.Group
+	if err := readSCIMBody(c, &in); err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+
+	group, err := h.biz.SCIMV1().ReplaceGroup(c.Request.Context(), c.Param("id"), &in, c.GetHeader("If-Match"))
+	if err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	writeSCIMResource(c, http.StatusOK, group, group.Meta, "Groups", group.ID)
+}
+
+// PatchSCIMGroup 部分更新组.
+func (h *Handler) PatchSCIMGroup(c *gin.Context) {
+	var rq scim.PatchRequest
+	if err := readSCIMBody(c, &rq); err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+
+	group, err := h.biz.SCIMV1().PatchGroup(c.Request.Context(), c.Param("id"), &rq, c.GetHeader("If-Match"))
+	if err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	writeSCIMResource(c, http.StatusOK, group, group.Meta, "Groups", group.ID)
+}
+
+// DeleteSCIMGroup 删除组.
+func (h *Handler) DeleteSCIMGroup(c *gin.Context) {
+	if err := h.biz.SCIMV1().DeleteGroup(c.Request.Context(), c.Param("id"), c.GetHeader("If-Match")); err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateSCIMToken 为租户创建 SCIM 访问令牌.
+func (h *Handler) CreateSCIMToken(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.TenantV1().CreateSCIMToken)
+}
+
+// ListSCIMTokens 获取租户的 SCIM 访问令牌列表.
+func (h *Handler) ListSCIMTokens(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.TenantV1().ListSCIMTokens)
+}
+
+// RevokeSCIMToken 吊销租户的 SCIM 访问令牌.
+func (h *Handler) RevokeSCIMToken(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.TenantV1().RevokeSCIMToken)
+}
+
+// readSCIMBody 读取并解析 SCIM 请求体
+func readSCIMBody(c *gin.Context, v any) error {
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidSyntax, "failed to read body: %v", err)
+	}
+	if rq, ok := v.(*scim.PatchRequest); ok {
+		if err := json.Unmarshal(data, rq); err != nil {
+			return scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidSyntax, "invalid JSON body: %v", err)
+		}
+		return nil
+	}
+	return scim.Decode(data, v)
+}
+
+// writeSCIMResource 输出单个资源，同时设置 Location 和 ETag 头.
+// GET 请求的 If-None-Match 与资源版本一致时返回 304.
+func writeSCIMResource(c *gin.Context, status int, resource any, meta *scim.Meta, endpoint, id string) {
+	setSCIMLocation(c, meta, endpoint, id)
+	if meta != nil && meta.Version != "" {
+		c.Header("ETag", meta.Version)
+		if c.Request.Method == http.MethodGet && c.GetHeader("If-None-Match") != "" &&
+			scim.MatchETag(c.GetHeader("If-None-Match"), meta.Version) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+	if status == http.StatusCreated && meta != nil {
+		c.Header("Location", meta.Location)
+	}
+	writeSCIM(c, status, resource)
+}
+
+// setSCIMLocation 根据请求地址设置资源的 meta.location
+func setSCIMLocation(c *gin.Context, meta *scim.Meta, endpoint, id string) {
+	if meta == nil {
+		return
+	}
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	host := c.Request.Host
+	if fwd := c.GetHeader("X-Forwarded-Host"); fwd != "" {
+		host = fwd
+	}
+	meta.Location = scheme + "://" + host + "/scim/v2/" + endpoint + "/" + id
+}
+
+// writeSCIM 以 application/scim+json 输出响应
+func writeSCIM(c *gin.Context, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeSCIMError(c, err)
+		return
+	}
+	c.Data(status, scim.ContentType, data)
+}
+
+// writeSCIMError 以 SCIM 错误格式输出错误，非 SCIM 错误按错误码转换
+func writeSCIMError(c *gin.Context, err error) {
+	var scimErr *scim.Error
+	if !errors.As(err, &scimErr) {
+		errx := errorsx.FromError(err)
+		scimErr = scim.NewError(errx.Code, "", "%s", errx.Message)
+	}
+	data, _ := json.Marshal(scimErr)
+	c.Data(scimErr.StatusCode(), scim.ContentType, data)
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ashwinyue/one-auth/pkg/core"
+)
+
+// ListSoDRules 获取职责分离规则列表
+func (h *Handler) ListSoDRules(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.SoDV1().List)
+}
+
+// CreateSoDRule 创建职责分离规则
+func (h *Handler) CreateSoDRule(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.SoDV1().Create)
+}
+
+// UpdateSoDRule 更新职责分离规则
+func (h *Handler) UpdateSoDRule(c *gin.Context) {
+	core.HandleRequest(c, bindUriAnd(c, c.ShouldBindJSON), h.biz.SoDV1().Update)
+}
+
+// DeleteSoDRule 删除职责分离规则
+func (h *Handler) DeleteSoDRule(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.SoDV1().Delete)
+}
+
+// ListSoDViolations 获取当前违反职责分离规则的角色分配
+func (h *Handler) ListSoDViolations(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.SoDV1().ListViolations)
+}
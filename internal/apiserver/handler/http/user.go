@@ -7,10 +7,20 @@
 package http
 
 import (
+	"bytes"
+	"io"
+
+	"github.com/ashwinyue/one-auth/pkg/client/sms"
 	"github.com/ashwinyue/one-auth/pkg/core"
 	"github.com/gin-gonic/gin"
+
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
 )
 
+// maxSMSDeliveryReportSize 短信回执回调请求体的最大字节数
+const maxSMSDeliveryReportSize = 64 << 10
+
 // Login 用户登录并返回 JWT Token.
 func (h *Handler) Login(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.UserV1().Login, h.val.ValidateLoginRequest)
@@ -56,6 +66,32 @@ func (h *Handler) SendVerifyCode(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.UserV1().SendVerifyCode, h.val.ValidateSendVerifyCodeRequest)
 }
 
+// SMSDeliveryReport 接收短信服务商的回执回调，签名基于原始请求体计算，校验通过后再解析.
+func (h *Handler) SMSDeliveryReport(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxSMSDeliveryReportSize))
+	if err != nil {
+		core.WriteResponse(c, nil, errno.ErrBind.WithMessage(err.Error()))
+		return
+	}
+	provider := c.Param("provider")
+	timestamp, signature := c.GetHeader(sms.HeaderCallbackTimestamp), c.GetHeader(sms.HeaderCallbackSignature)
+	if err := h.biz.UserV1().VerifySMSDeliveryReport(c, provider, timestamp, signature, body); err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+
+	var req apiv1.SMSDeliveryReportRequest
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.WriteResponse(c, nil, errno.ErrBind.WithMessage(err.Error()))
+		return
+	}
+	req.Provider = provider
+
+	resp, err := h.biz.UserV1().HandleSMSDeliveryReport(c, &req)
+	core.WriteResponse(c, resp, err)
+}
+
 // Logout 用户登出.
 func (h *Handler) Logout(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.UserV1().Logout, h.val.ValidateLogoutRequest)
@@ -8,14 +8,19 @@ package apiserver
 
 import (
 	"context"
+	"net"
+	"net/http"
 
 	"github.com/ashwinyue/one-auth/pkg/core"
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/ashwinyue/one-auth/internal/apiserver/biz/v1/extauthz"
 	handler "github.com/ashwinyue/one-auth/internal/apiserver/handler/http"
 	"github.com/ashwinyue/one-auth/internal/apiserver/routes"
 	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
 	mw "github.com/ashwinyue/one-auth/internal/pkg/middleware/gin"
 	"github.com/ashwinyue/one-auth/internal/pkg/server"
 )
@@ -31,7 +36,7 @@ var _ server.Server = (*ginServer)(nil)
 // NewGinServer 初始化一个新的 Gin 服务器实例.
 func (c *ServerConfig) NewGinServer() server.Server {
 	// 创建 Gin 引擎
-	engine := gin.New()
+	engine := newGinEngine(c.cfg.ForwardAuthTrustedProxies)
 
 	// 注册全局中间件，用于恢复 panic、设置 HTTP 头、添加请求 ID 等
 	engine.Use(gin.Recovery(), mw.NoCache, mw.Cors, mw.Secure, mw.RequestIDMiddleware())
@@ -44,6 +49,22 @@ func (c *ServerConfig) NewGinServer() server.Server {
 	return &ginServer{srv: httpsrv}
 }
 
+// newGinEngine 创建 Gin 引擎，只信任可信代理传入的 X-Forwarded-For/X-Real-IP，
+// 未配置可信代理时客户端IP即连接的对端地址，权限条件中的 request.ip 不受客户端伪造的请求头影响.
+func newGinEngine(trustedProxies []*net.IPNet) *gin.Engine {
+	engine := gin.New()
+
+	proxies := make([]string, 0, len(trustedProxies))
+	for _, network := range trustedProxies {
+		proxies = append(proxies, network.String())
+	}
+	if err := engine.SetTrustedProxies(proxies); err != nil {
+		// 可信代理在加载配置时已校验，这里不会出错
+		log.Errorw("Failed to set trusted proxies", "proxies", proxies, "err", err)
+	}
+	return engine
+}
+
 // InstallRESTAPI 注册 API 路由。路由的路径和 HTTP 方法，严格遵循 REST 规范.
 func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 	// 注册业务无关的 API 接口
@@ -55,15 +76,20 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 	// 注册健康检查接口
 	engine.GET("/healthz", h.Healthz)
 
+	// 转发认证接口，供 nginx auth_request 和 Traefik ForwardAuth 调用。nginx 子请求沿用原始请求的方法，因此接受任意方法
+	engine.Any("/auth/verify", gin.WrapH(c.NewForwardAuthHandler()))
+
 	// 注册用户登录和令牌刷新接口。这2个接口比较简单，所以没有 API 版本
 	engine.POST("/login", h.Login)
 	engine.POST("/send-verify-code", h.SendVerifyCode) // 发送验证码不需要认证
+	// 短信服务商回执回调，由服务商直接调用，不需要认证
+	engine.POST("/sms/callbacks/:provider", h.SMSDeliveryReport)
 	// 注意：认证中间件要在 handler.RefreshToken 之前加载
-	engine.PUT("/refresh-token", mw.AuthnMiddleware(c.store.User()), h.RefreshToken)
-	engine.POST("/logout", mw.AuthnMiddleware(c.store.User()), h.Logout) // 登出需要认证
+	engine.PUT("/refresh-token", mw.AuthnMiddleware(c.store.User(), c.sessions), h.RefreshToken)
+	engine.POST("/logout", mw.AuthnMiddleware(c.store.User(), c.sessions), h.Logout) // 登出需要认证
 
 	// 认证和授权中间件
-	authMiddlewares := []gin.HandlerFunc{mw.AuthnMiddleware(c.store.User()), mw.AuthzMiddleware(c.authz)}
+	authMiddlewares := []gin.HandlerFunc{mw.AuthnMiddleware(c.store.User(), c.sessions), mw.AuthzMiddleware(c.authz, c.resourceLoaders())}
 
 	// 注册 v1 版本 API 路由分组
 	v1 := engine.Group("/v1")
@@ -75,13 +101,35 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 	routes.InstallPermissionRoutes(v1, h, authMiddlewares...)
 	routes.InstallTenantRoutes(v1, h, authMiddlewares...)
 	routes.InstallMenuRoutes(v1, h, authMiddlewares...)
+	routes.InstallSAMLRoutes(v1, h, authMiddlewares...)
+	routes.InstallRoleGrantRoutes(v1, h, authMiddlewares...)
+	routes.InstallAccessRequestRoutes(v1, h, authMiddlewares...)
+	routes.InstallSoDRoutes(v1, h, authMiddlewares...)
+
+	// 平台运营接口，只允许平台运营人员访问，所有请求记录审计日志
+	routes.InstallPlatformRoutes(v1, h, mw.AuthnMiddleware(c.store.User(), c.sessions), mw.PlatformOperatorMiddleware(c.store.PlatformOperator(), c.store.PlatformAuditLog()))
+
+	// 策略决策点接口，供其他微服务使用服务凭证查询用户的访问权限
+	routes.InstallPDPRoutes(v1, h, mw.ServiceAuthnMiddleware(c.store.ServiceClient()))
+
+	// SCIM 2.0 供应接口，使用租户级 Bearer 令牌认证
+	routes.InstallSCIMRoutes(engine, h, mw.SCIMAuthnMiddleware(c.store.TenantSCIMToken()))
 }
 
-// InstallGenericAPI 注册业务无关的路由，例如 pprof、404 处理等.
+// NewForwardAuthHandler 创建转发认证处理器，按配置缓存鉴权结果.
+func (c *ServerConfig) NewForwardAuthHandler() http.Handler {
+	cache := extauthz.NewVerifyCache(c.cfg.ForwardAuthCacheSize, c.cfg.ForwardAuthCacheTTL)
+	return handler.NewForwardAuthHandler(c.biz, cache, c.cfg.ForwardAuthTrustedProxies)
+}
+
+// InstallGenericAPI 注册业务无关的路由，例如 pprof、metrics、404 处理等.
 func InstallGenericAPI(engine *gin.Engine) {
 	// 注册 pprof 路由
 	pprof.Register(engine)
 
+	// 注册 Prometheus 指标路由
+	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
 	// 注册 404 路由处理
 	engine.NoRoute(func(c *gin.Context) {
 		core.WriteResponse(c, errno.ErrPageNotFound, nil)
@@ -0,0 +1,85 @@
+package apiserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	mw "github.com/ashwinyue/one-auth/internal/pkg/middleware/gin"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+)
+
+// ipAuthorizer 只允许满足IP条件的请求，并记录求值使用的请求上下文
+type ipAuthorizer struct {
+	cond *authz.Condition
+	rc   *authz.RequestContext
+}
+
+func (a *ipAuthorizer) AuthorizeWithDomain(string, string, string, string) (bool, error) {
+	return false, nil
+}
+
+func (a *ipAuthorizer) CheckRouteAccess(_, _ string, rc *authz.RequestContext) (*authz.Decision, error) {
+	a.rc = rc
+	ok, err := a.cond.Evaluate(map[string]any{"request": map[string]any{"ip": rc.IP}})
+	return &authz.Decision{Allowed: ok}, err
+}
+
+func TestGinEngine_SpoofedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cond, err := authz.CompileCondition(`cidrMatch(request.ip, "10.0.0.0/8")`)
+	require.NoError(t, err)
+
+	serve := func(trustedProxies []string, remoteAddr, forwardedFor string) (int, *authz.RequestContext) {
+		var networks []*net.IPNet
+		for _, cidr := range trustedProxies {
+			_, network, err := net.ParseCIDR(cidr)
+			require.NoError(t, err)
+			networks = append(networks, network)
+		}
+		authorizer := &ipAuthorizer{cond: cond}
+		loaders := map[string]mw.ResourceLoader{
+			"/v1/posts/:postID": func(context.Context, gin.Params) (map[string]any, error) {
+				return map[string]any{"owner_id": int64(7)}, nil
+			},
+		}
+
+		engine := newGinEngine(networks)
+		engine.GET("/v1/posts/:postID", func(c *gin.Context) {
+			c.Request = c.Request.WithContext(contextx.WithTenantID(c.Request.Context(), "1"))
+		}, mw.AuthzMiddleware(authorizer, loaders), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "/v1/posts/post-1", nil)
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, r)
+		return w.Code, authorizer.rc
+	}
+
+	// 未配置可信代理时客户端伪造的 X-Forwarded-For 不满足IP条件
+	code, rc := serve(nil, "203.0.113.7:4321", "10.0.0.1")
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Equal(t, "203.0.113.7", rc.IP)
+
+	// 可信代理之前的客户端伪造地址不生效
+	code, rc = serve([]string{"192.168.0.0/16"}, "192.168.1.1:4321", "10.0.0.1, 203.0.113.7")
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Equal(t, "203.0.113.7", rc.IP)
+
+	// 可信代理传入的客户端IP满足条件，资源属性来自加载的资源
+	code, rc = serve([]string{"192.168.0.0/16"}, "192.168.1.1:4321", "10.1.2.3")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "10.1.2.3", rc.IP)
+	assert.Equal(t, int64(7), rc.Resource["owner_id"])
+	assert.Equal(t, "post-1", rc.Resource["postID"])
+}
@@ -1,5 +1,11 @@
 package model
 
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
 // MenuPermissionConfig 菜单权限配置结构
 type MenuPermissionConfig struct {
 	PermissionID   int64  `json:"permission_id,omitempty"`   // 权限ID（优先使用）
@@ -61,6 +67,8 @@ const (
 	AuthTypeApple    AuthType = 8  // Apple
 	AuthTypeDingtalk AuthType = 9  // 钉钉
 	AuthTypeFeishu   AuthType = 10 // 飞书
+	AuthTypeLDAP     AuthType = 11 // LDAP / Active Directory
+	AuthTypeSAML     AuthType = 12 // SAML 2.0 SSO
 )
 
 // UserStatus 用户状态枚举
@@ -73,6 +81,66 @@ const (
 	UserStatusBanned   UserStatus = 4 // 封禁
 )
 
+// 角色的数据权限范围
+const (
+	DataScopeAll    = "all"    // 全部数据
+	DataScopeTenant = "tenant" // 本租户数据
+	DataScopeDept   = "dept"   // 本部门及下级部门数据
+	DataScopeSelf   = "self"   // 仅本人数据
+	DataScopeCustom = "custom" // 自定义部门数据
+)
+
+// 限时角色授予的方式
+const (
+	RoleGrantKindScheduled = "scheduled" // 管理员直接授予
+	RoleGrantKindJIT       = "jit"       // 用户申请后审批
+)
+
+// 限时角色授予的状态
+const (
+	RoleGrantStatusPending  = "pending"  // 等待审批
+	RoleGrantStatusApproved = "approved" // 已批准，等待生效
+	RoleGrantStatusActive   = "active"   // 生效中
+	RoleGrantStatusRejected = "rejected" // 已拒绝
+	RoleGrantStatusExpired  = "expired"  // 已到期
+	RoleGrantStatusRevoked  = "revoked"  // 已撤销
+)
+
+// 访问申请的对象类型
+const (
+	AccessTargetRole       = "role"       // 申请角色
+	AccessTargetPermission = "permission" // 申请权限
+)
+
+// 访问申请的状态
+const (
+	AccessRequestStatusPending   = "pending"   // 等待审批
+	AccessRequestStatusApproved  = "approved"  // 已批准并授予
+	AccessRequestStatusRejected  = "rejected"  // 已拒绝
+	AccessRequestStatusExpired   = "expired"   // 超时未审批
+	AccessRequestStatusCancelled = "cancelled" // 申请人已撤回
+)
+
+// 访问申请的审批结果
+const (
+	AccessReviewApprove = "approve" // 批准
+	AccessReviewReject  = "reject"  // 拒绝
+)
+
+// IsValidDataScope 检查数据权限范围是否合法
+func IsValidDataScope(scope string) bool {
+	switch scope {
+	case DataScopeAll, DataScopeTenant, DataScopeDept, DataScopeSelf, DataScopeCustom:
+		return true
+	}
+	return false
+}
+
+// IsSystemRole 检查角色是否为指定的系统内置角色
+func (m *RoleM) IsSystemRole(systemRole string) bool {
+	return m.SystemRole != nil && *m.SystemRole == systemRole
+}
+
 // StringToAuthType 将字符串转换为认证类型
 func StringToAuthType(s string) AuthType {
 	switch s {
@@ -96,11 +164,140 @@ func StringToAuthType(s string) AuthType {
 		return AuthTypeDingtalk
 	case "feishu":
 		return AuthTypeFeishu
+	case "ldap":
+		return AuthTypeLDAP
+	case "saml":
+		return AuthTypeSAML
 	default:
 		return AuthTypeUsername
 	}
 }
 
+// GetRoleMapping 解析 LDAP 组到角色名称的映射
+func (c *TenantLDAPConfigM) GetRoleMapping() (map[string][]string, error) {
+	mapping := make(map[string][]string)
+	if c.RoleMapping == nil || *c.RoleMapping == "" {
+		return mapping, nil
+	}
+	if err := json.Unmarshal([]byte(*c.RoleMapping), &mapping); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// GetAttributeMapping 解析用户资料到 SAML 断言属性的映射
+func (c *TenantSAMLConfigM) GetAttributeMapping() (map[string]string, error) {
+	mapping := make(map[string]string)
+	if c.AttributeMapping == nil || *c.AttributeMapping == "" {
+		return mapping, nil
+	}
+	if err := json.Unmarshal([]byte(*c.AttributeMapping), &mapping); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// GetRoleMapping 解析 SAML 组到角色名称的映射
+func (c *TenantSAMLConfigM) GetRoleMapping() (map[string][]string, error) {
+	mapping := make(map[string][]string)
+	if c.RoleMapping == nil || *c.RoleMapping == "" {
+		return mapping, nil
+	}
+	if err := json.Unmarshal([]byte(*c.RoleMapping), &mapping); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// GetDataScopeDeptIDs 解析自定义数据权限的部门ID
+func (r *RoleM) GetDataScopeDeptIDs() []int64 {
+	var ids []int64
+	if r.DataScopeDeptIDs == nil {
+		return ids
+	}
+	for _, s := range strings.Split(*r.DataScopeDeptIDs, ",") {
+		if id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// GetAdminRoleIDs 解析系统管理员角色ID
+func (c *PermissionAutoAssignConfigM) GetAdminRoleIDs() []int64 {
+	return splitIDs(c.AdminRoleIDs)
+}
+
+// GetRoleIDs 解析规则适用的角色ID
+func (r *PermissionAutoAssignRuleM) GetRoleIDs() []int64 {
+	return splitIDs(r.RoleIDs)
+}
+
+// GetModules 解析规则匹配的权限模块
+func (r *PermissionAutoAssignRuleM) GetModules() []string {
+	return splitList(r.Modules)
+}
+
+// GetResourceTypes 解析规则匹配的资源类型
+func (r *PermissionAutoAssignRuleM) GetResourceTypes() []string {
+	return splitList(r.ResourceTypes)
+}
+
+// GetActions 解析规则匹配的操作类型
+func (r *PermissionAutoAssignRuleM) GetActions() []string {
+	return splitList(r.Actions)
+}
+
+// GetExcludeNames 解析规则排除的权限名称
+func (r *PermissionAutoAssignRuleM) GetExcludeNames() []string {
+	return splitList(r.ExcludeNames)
+}
+
+// GetApproverIDs 解析申请的审批人用户ID
+func (r *AccessRequestM) GetApproverIDs() []int64 {
+	return splitIDs(&r.ApproverIDs)
+}
+
+// GetApproverIDs 解析策略的审批人用户ID
+func (p *AccessApprovalPolicyM) GetApproverIDs() []int64 {
+	return splitIDs(&p.ApproverIDs)
+}
+
+// GetRoleIDs 解析职责分离规则约束的角色ID
+func (r *SoDRuleM) GetRoleIDs() []int64 {
+	return splitIDs(&r.RoleIDs)
+}
+
+// GetScopes 解析服务凭证授权访问的权限编码
+func (c *ServiceClientM) GetScopes() []string {
+	return splitList(&c.Scopes)
+}
+
+// splitIDs 解析逗号分隔的ID列表
+func splitIDs(s *string) []int64 {
+	var ids []int64
+	for _, item := range splitList(s) {
+		if id, err := strconv.ParseInt(item, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// splitList 解析逗号分隔的字符串列表，忽略空项
+func splitList(s *string) []string {
+	var items []string
+	if s == nil {
+		return items
+	}
+	for _, item := range strings.Split(*s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // GetUserByAuthID 根据认证ID获取用户（临时实现）
 func GetUserByAuthID(authID string, authType AuthType) (*UserM, error) {
 	// 这是一个占位函数，实际应该从数据库查询
@@ -8,6 +8,7 @@ package model
 
 import (
 	"github.com/ashwinyue/one-auth/pkg/authn"
+	"github.com/ashwinyue/one-auth/pkg/authz"
 	"gorm.io/gorm"
 
 	"github.com/ashwinyue/one-auth/internal/pkg/rid"
@@ -31,3 +32,11 @@ func (m *UserM) BeforeCreate(tx *gorm.DB) error {
 
 	return nil
 }
+
+// BeforeSave 在保存权限之前校验条件表达式.
+func (m *PermissionM) BeforeSave(tx *gorm.DB) error {
+	if m.Condition == nil {
+		return nil
+	}
+	return authz.ValidateCondition(*m.Condition)
+}
@@ -22,6 +22,7 @@ type PermissionM struct {
 	ResourcePath *string        `gorm:"column:resource_path;comment:API路径或资源标识" json:"resource_path"`                        // API路径或资源标识
 	HTTPMethod   *string        `gorm:"column:http_method;comment:HTTP方法：GET,POST,PUT,DELETE等" json:"http_method"`           // HTTP方法：GET,POST,PUT,DELETE等
 	Action       *string        `gorm:"column:action;comment:操作类型：view,create,update,delete,export等" json:"action"`          // 操作类型：view,create,update,delete,export等
+	Condition    *string        `gorm:"column:condition;comment:权限生效条件表达式" json:"condition"`                                 // 权限生效条件表达式
 	Status       bool           `gorm:"column:status;not null;default:1;comment:状态：1-启用，0-禁用" json:"status"`                 // 状态：1-启用，0-禁用
 	CreatedAt    time.Time      `gorm:"column:created_at;not null;default:current_timestamp;comment:创建时间" json:"created_at"` // 创建时间
 	UpdatedAt    time.Time      `gorm:"column:updated_at;not null;default:current_timestamp;comment:更新时间" json:"updated_at"` // 更新时间
@@ -14,14 +14,17 @@ const TableNameRoleM = "roles"
 
 // RoleM mapped from table <roles>
 type RoleM struct {
-	ID          int64          `gorm:"column:id;primaryKey;autoIncrement:true;comment:角色主键ID" json:"id"`                    // 角色主键ID
-	TenantID    int64          `gorm:"column:tenant_id;not null;comment:租户ID" json:"tenant_id"`                             // 租户ID
-	Name        string         `gorm:"column:name;not null;uniqueIndex:idx_name_tenant;comment:角色名称" json:"name"`           // 角色名称
-	Description *string        `gorm:"column:description;comment:描述" json:"description"`                                    // 描述
-	Status      bool           `gorm:"column:status;not null;default:1;comment:状态：1-启用，0-禁用" json:"status"`                 // 状态：1-启用，0-禁用
-	CreatedAt   time.Time      `gorm:"column:created_at;not null;default:current_timestamp;comment:创建时间" json:"created_at"` // 创建时间
-	UpdatedAt   time.Time      `gorm:"column:updated_at;not null;default:current_timestamp;comment:更新时间" json:"updated_at"` // 更新时间
-	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index;comment:删除时间（软删除）" json:"deleted_at"`                         // 删除时间（软删除）
+	ID               int64          `gorm:"column:id;primaryKey;autoIncrement:true;comment:角色主键ID" json:"id"`                                                           // 角色主键ID
+	TenantID         int64          `gorm:"column:tenant_id;not null;comment:租户ID" json:"tenant_id"`                                                                    // 租户ID
+	Name             string         `gorm:"column:name;not null;uniqueIndex:idx_name_tenant;comment:角色名称" json:"name"`                                                  // 角色名称
+	Description      *string        `gorm:"column:description;comment:描述" json:"description"`                                                                           // 描述
+	DataScope        string         `gorm:"column:data_scope;not null;default:tenant;comment:数据权限范围：all,tenant,dept,self,custom" json:"data_scope"`                     // 数据权限范围：all,tenant,dept,self,custom
+	DataScopeDeptIDs *string        `gorm:"column:data_scope_dept_ids;comment:自定义数据权限的部门ID，逗号分隔" json:"data_scope_dept_ids"`                                            // 自定义数据权限的部门ID，逗号分隔
+	SystemRole       *string        `gorm:"column:system_role;uniqueIndex:idx_system_role_tenant;comment:系统内置角色：tenant_owner,tenant_admin，为空表示普通角色" json:"system_role"` // 系统内置角色：tenant_owner,tenant_admin，为空表示普通角色
+	Status           bool           `gorm:"column:status;not null;default:1;comment:状态：1-启用，0-禁用" json:"status"`                                                        // 状态：1-启用，0-禁用
+	CreatedAt        time.Time      `gorm:"column:created_at;not null;default:current_timestamp;comment:创建时间" json:"created_at"`                                        // 创建时间
+	UpdatedAt        time.Time      `gorm:"column:updated_at;not null;default:current_timestamp;comment:更新时间" json:"updated_at"`                                        // 更新时间
+	DeletedAt        gorm.DeletedAt `gorm:"column:deleted_at;index;comment:删除时间（软删除）" json:"deleted_at"`                                                                // 删除时间（软删除）
 }
 
 // TableName RoleM's table name
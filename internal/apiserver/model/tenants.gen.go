@@ -17,6 +17,7 @@ type TenantM struct {
 	ID          int64          `gorm:"column:id;primaryKey;autoIncrement:true;comment:租户主键ID" json:"id"`                    // 租户主键ID
 	Name        string         `gorm:"column:name;not null;comment:租户名称" json:"name"`                                       // 租户名称
 	Description *string        `gorm:"column:description;comment:描述" json:"description"`                                    // 描述
+	Timezone    string         `gorm:"column:timezone;not null;comment:租户时区，为空时使用服务器时区" json:"timezone"`                    // 租户时区，为空时使用服务器时区
 	Status      bool           `gorm:"column:status;not null;default:1;comment:状态：1-启用，0-禁用" json:"status"`                 // 状态：1-启用，0-禁用
 	CreatedAt   time.Time      `gorm:"column:created_at;not null;default:current_timestamp;comment:创建时间" json:"created_at"` // 创建时间
 	UpdatedAt   time.Time      `gorm:"column:updated_at;not null;default:current_timestamp;comment:更新时间" json:"updated_at"` // 更新时间
@@ -52,7 +52,7 @@ func (v *Validator) ValidateUserRules() genericvalidation.Rules {
 			return isValidEmail(value.(string))
 		},
 		"Phone": func(value any) error {
-			return isValidPhone(value.(string))
+			return v.isValidPhone(value.(string))
 		},
 		"Limit": func(value any) error {
 			limit := value.(int64)
@@ -77,9 +77,10 @@ func (v *Validator) ValidateUserRules() genericvalidation.Rules {
 				"username": true,
 				"email":    true,
 				"phone":    true,
+				"ldap":     true,
 			}
 			if !validTypes[loginType] {
-				return errno.ErrInvalidArgument.WithMessage("invalid login_type, must be one of: username, email, phone")
+				return errno.ErrInvalidArgument.WithMessage("invalid login_type, must be one of: username, email, phone, ldap")
 			}
 			return nil
 		},
@@ -181,6 +182,11 @@ func (v *Validator) ValidateLoginRequest(ctx context.Context, rq *apiv1.LoginReq
 		return errno.ErrInvalidArgument.WithMessage("Password or verify_code is required")
 	}
 
+	// LDAP 登录只支持目录密码
+	if rq.GetLoginType() == "ldap" && rq.GetPassword() == "" {
+		return errno.ErrInvalidArgument.WithMessage("Password is required for ldap login")
+	}
+
 	return nil
 }
 
@@ -13,6 +13,8 @@ import (
 
 	"github.com/ashwinyue/one-auth/internal/apiserver/store"
 	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/pkg/client/sms"
+	genericoptions "github.com/ashwinyue/one-auth/pkg/options"
 )
 
 // Validator 是验证逻辑的实现结构体.
@@ -21,6 +23,8 @@ type Validator struct {
 	// 这里只是一个举例，如果验证时，有其他依赖的客户端/服务/资源等，
 	// 都可以一并注入进来
 	store store.IStore
+	// defaultCountryCode 是不带国家码的手机号使用的默认呼叫代码，与短信客户端的配置一致，为空时使用 86
+	defaultCountryCode string
 }
 
 // 使用预编译的全局正则表达式，避免重复创建和编译.
@@ -30,7 +34,6 @@ var (
 	letterRegex = regexp.MustCompile(`[A-Za-z]`)                                         // 至少包含一个字母
 	numberRegex = regexp.MustCompile(`\d`)                                               // 至少包含一个数字
 	emailRegex  = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`) // 邮箱格式
-	phoneRegex  = regexp.MustCompile(`^1[3-9]\d{9}$`)                                    // 中国手机号
 )
 
 // ProviderSet 是一个 Wire 的 Provider 集合，用于声明依赖注入的规则.
@@ -38,8 +41,12 @@ var (
 var ProviderSet = wire.NewSet(New)
 
 // New 创建一个新的 Validator 实例.
-func New(store store.IStore) *Validator {
-	return &Validator{store: store}
+func New(store store.IStore, smsOptions *genericoptions.SMSOptions) *Validator {
+	v := &Validator{store: store}
+	if smsOptions != nil {
+		v.defaultCountryCode = smsOptions.DefaultCountryCode
+	}
+	return v
 }
 
 // isValidUsername 校验用户名是否合法.
@@ -90,14 +97,14 @@ func isValidEmail(email string) error {
 }
 
 // isValidPhone 判断手机号码是否合法.
-func isValidPhone(phone string) error {
+func (v *Validator) isValidPhone(phone string) error {
 	// 检查手机号码格式
 	if phone == "" {
 		return errno.ErrInvalidArgument.WithMessage("phone cannot be empty")
 	}
 
-	// 使用正则表达式校验手机号码格式（假设是中国手机号，11 位数字）
-	if !phoneRegex.MatchString(phone) {
+	// 校验 E.164 国际号码格式，不带国家码时按配置的默认国家码校验
+	if _, err := sms.ParsePhone(phone, v.defaultCountryCode); err != nil {
 		return errno.ErrInvalidArgument.WithMessage("invalid phone format")
 	}
 
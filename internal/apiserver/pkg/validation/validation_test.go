@@ -8,6 +8,10 @@ package validation
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	genericoptions "github.com/ashwinyue/one-auth/pkg/options"
 )
 
 // 性能测试用例
@@ -32,3 +36,14 @@ func BenchmarkIsValidUsername(b *testing.B) {
 		}
 	}
 }
+
+func TestIsValidPhone_DefaultCountryCode(t *testing.T) {
+	// 不带国家码的号码按配置的默认国家码校验
+	us := New(nil, &genericoptions.SMSOptions{DefaultCountryCode: "1"})
+	assert.NoError(t, us.isValidPhone("2025550123"))
+	assert.Error(t, us.isValidPhone("13800138000"))
+
+	cn := New(nil, nil)
+	assert.NoError(t, cn.isValidPhone("13800138000"))
+	assert.NoError(t, cn.isValidPhone("+12025550123"))
+}
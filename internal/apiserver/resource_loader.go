@@ -0,0 +1,54 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package apiserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	mw "github.com/ashwinyue/one-auth/internal/pkg/middleware/gin"
+)
+
+// resourceLoaders 返回权限条件求值时按路由加载资源的函数，资源的所属用户作为 owner_id.
+func (c *ServerConfig) resourceLoaders() map[string]mw.ResourceLoader {
+	return map[string]mw.ResourceLoader{
+		"/v1/posts/:postID":                 c.loadPost,
+		"/v1/users/:userID":                 c.loadUser,
+		"/v1/users/:userID/change-password": c.loadUser,
+	}
+}
+
+// loadPost 加载路径中的博客，所属用户为博客作者
+func (c *ServerConfig) loadPost(ctx context.Context, params gin.Params) (map[string]any, error) {
+	postM, err := c.store.Post().Get(store.WithoutDataScope(ctx), where.T(ctx).F("post_id", params.ByName("postID")))
+	if err != nil {
+		return nil, ignoreNotFound(err)
+	}
+	return map[string]any{"owner_id": postM.UserID}, nil
+}
+
+// loadUser 加载路径中的用户，所属用户为用户本人
+func (c *ServerConfig) loadUser(ctx context.Context, params gin.Params) (map[string]any, error) {
+	userM, err := c.store.User().Get(store.WithoutDataScope(ctx), where.F("id", params.ByName("userID")))
+	if err != nil {
+		return nil, ignoreNotFound(err)
+	}
+	return map[string]any{"owner_id": userM.ID}, nil
+}
+
+// ignoreNotFound 资源不存在时不返回错误，由处理器返回 404
+func ignoreNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,34 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	handler "github.com/ashwinyue/one-auth/internal/apiserver/handler/http"
+)
+
+// InstallAccessRequestRoutes 安装访问申请和审批策略相关的路由.
+func InstallAccessRequestRoutes(v1 *gin.RouterGroup, h *handler.Handler, authMiddlewares ...gin.HandlerFunc) {
+	requestGroup := v1.Group("/access-requests", authMiddlewares...)
+	{
+		requestGroup.POST("", h.CreateAccessRequest)                    // 提交申请
+		requestGroup.GET("", h.ListMyAccessRequests)                    // 我提交的申请
+		requestGroup.GET("/reviews", h.ListAccessRequestReviews)        // 待我审批的申请
+		requestGroup.GET("/:requestID", h.GetAccessRequest)             // 申请详情
+		requestGroup.PUT("/:requestID/approve", h.ApproveAccessRequest) // 批准
+		requestGroup.PUT("/:requestID/reject", h.RejectAccessRequest)   // 拒绝
+		requestGroup.PUT("/:requestID/cancel", h.CancelAccessRequest)   // 撤回
+	}
+
+	policyGroup := v1.Group("/access-approval-policies", authMiddlewares...)
+	{
+		policyGroup.GET("", h.ListAccessApprovalPolicies)              // 获取审批策略
+		policyGroup.PUT("", h.SetAccessApprovalPolicy)                 // 设置审批策略
+		policyGroup.DELETE("/:policyID", h.DeleteAccessApprovalPolicy) // 删除审批策略
+	}
+}
@@ -37,6 +37,31 @@ func InstallRoleRoutes(v1 *gin.RouterGroup, h *handler.Handler, authMiddlewares
 		roleGroup.POST("", h.CreateRole)           // 创建角色
 		roleGroup.PUT("/:roleID", h.UpdateRole)    // 更新角色
 		roleGroup.DELETE("/:roleID", h.DeleteRole) // 删除角色
+
+		roleGroup.GET("/:roleID/permissions", h.GetRolePermissions)       // 获取角色权限规则
+		roleGroup.POST("/:roleID/permissions", h.AssignRolePermissions)   // 添加 allow/deny 权限规则
+		roleGroup.DELETE("/:roleID/permissions", h.RevokeRolePermissions) // 删除权限规则
+
+		roleGroup.GET("/:roleID/parents", h.GetRoleParents) // 获取父角色和子角色
+		roleGroup.PUT("/:roleID/parents", h.SetRoleParents) // 设置继承的父角色
+
+		roleGroup.GET("/:roleID/menus", h.GetRoleMenus)           // 获取角色可访问的菜单
+		roleGroup.PUT("/:roleID/menus", h.UpdateRoleMenus)        // 更新角色可访问的菜单
+		roleGroup.GET("/:roleID/check-delete", h.CheckDeleteRole) // 检查角色是否可以删除
+		roleGroup.POST("/refresh", h.RefreshPrivilegeData)        // 重新加载权限数据
+	}
+
+	// 用户角色分配路由
+	userRoleGroup := v1.Group("/users", authMiddlewares...)
+	{
+		userRoleGroup.GET("/:userID/roles", h.GetUserRoles)    // 获取用户角色
+		userRoleGroup.PUT("/:userID/roles", h.AssignUserRoles) // 替换用户角色
+	}
+
+	// 当前用户角色路由
+	currentUserGroup := v1.Group("/user", authMiddlewares...)
+	{
+		currentUserGroup.GET("/roles", h.GetRolesByUser) // 获取当前用户的角色
 	}
 }
 
@@ -45,7 +70,16 @@ func InstallPermissionRoutes(v1 *gin.RouterGroup, h *handler.Handler, authMiddle
 	// 权限检查路由
 	permissionGroup := v1.Group("/permissions", authMiddlewares...)
 	{
-		permissionGroup.POST("/check", h.CheckPermissions) // 批量检查权限
+		permissionGroup.POST("/check", h.CheckPermissions)                           // 批量检查权限
+		permissionGroup.POST("/explain", h.ExplainAccess)                            // 查询授权决策轨迹
+		permissionGroup.POST("/preview", h.PreviewPermissionAssignment)              // 预览权限分配变更
+		permissionGroup.GET("/auto-assign", h.GetAutoAssignConfig)                   // 获取自动分配配置
+		permissionGroup.PUT("/auto-assign", h.UpdateAutoAssignConfig)                // 更新自动分配配置
+		permissionGroup.POST("/admin-sync", h.SyncAdminPermissions)                  // 同步管理员权限，支持 dry_run
+		permissionGroup.GET("/admin-missing", h.GetAdminMissingPermissions)          // 获取管理员缺失的权限
+		permissionGroup.POST("/catalog/import", h.ImportPermissionCatalog)           // 导入权限目录，支持 dry_run 和 prune
+		permissionGroup.GET("/catalog/export", h.ExportPermissionCatalog)            // 导出权限目录
+		permissionGroup.PUT("/:permissionID/condition", h.UpdatePermissionCondition) // 更新权限生效条件
 	}
 
 	// 当前用户权限相关路由
@@ -32,4 +32,7 @@ func RegisterMenuRoutes(v1 *gin.RouterGroup, h *http.Handler, authMiddlewares ..
 	menuRoutes.PUT("/sort", h.UpdateMenuSort) // 批量更新排序
 	menuRoutes.POST("/copy", h.CopyMenu)      // 复制菜单
 	menuRoutes.PUT("/move", h.MoveMenu)       // 移动菜单
+
+	// 创建菜单并生成权限，新权限按自动分配规则分配给管理员角色
+	menuRoutes.POST("/with-permissions", h.CreateMenuWithPermissions)
 }
@@ -0,0 +1,23 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	handler "github.com/ashwinyue/one-auth/internal/apiserver/handler/http"
+)
+
+// InstallPDPRoutes 安装策略决策点相关的路由，使用服务凭证认证，不检查用户权限.
+func InstallPDPRoutes(v1 *gin.RouterGroup, h *handler.Handler, serviceAuthn gin.HandlerFunc) {
+	pdpGroup := v1.Group("/pdp", serviceAuthn)
+	{
+		pdpGroup.POST("/check", h.PDPCheck)
+		pdpGroup.POST("/allowed-actions", h.PDPListAllowedActions)
+		pdpGroup.POST("/accessible-resources", h.PDPListAccessibleResources)
+	}
+}
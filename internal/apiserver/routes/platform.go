@@ -0,0 +1,44 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	handler "github.com/ashwinyue/one-auth/internal/apiserver/handler/http"
+)
+
+// InstallPlatformRoutes 安装平台运营相关的路由.
+// 平台接口跨租户操作，不经过租户内的权限检查，由平台运营人员中间件校验身份并记录审计日志.
+func InstallPlatformRoutes(v1 *gin.RouterGroup, h *handler.Handler, platformMiddlewares ...gin.HandlerFunc) {
+	platformGroup := v1.Group("/platform")
+	platformGroup.Use(platformMiddlewares...)
+	{
+		// 租户管理
+		platformGroup.GET("/tenants", h.ListAllTenants)
+		platformGroup.POST("/tenants", h.CreateTenant)
+		platformGroup.PUT("/tenants/:tenantID/status", h.UpdateTenantStatus)
+		platformGroup.PUT("/tenants/:tenantID/owner", h.AssignTenantOwner)
+
+		// 平台运营人员管理
+		platformGroup.GET("/operators", h.ListPlatformOperators)
+		platformGroup.POST("/operators", h.AddPlatformOperator)
+		platformGroup.DELETE("/operators/:userID", h.RemovePlatformOperator)
+
+		// 审计日志
+		platformGroup.GET("/audit-logs", h.ListPlatformAuditLogs)
+
+		// 策略一致性检查
+		platformGroup.GET("/consistency", h.CheckConsistency)
+		platformGroup.POST("/consistency/repair", h.RepairConsistency)
+
+		// 策略决策点服务凭证
+		platformGroup.GET("/service-clients", h.ListServiceClients)
+		platformGroup.POST("/service-clients", h.CreateServiceClient)
+		platformGroup.DELETE("/service-clients/:clientID", h.RevokeServiceClient)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	handler "github.com/ashwinyue/one-auth/internal/apiserver/handler/http"
+)
+
+// InstallRoleGrantRoutes 安装限时角色授予和临时提权相关的路由.
+func InstallRoleGrantRoutes(v1 *gin.RouterGroup, h *handler.Handler, authMiddlewares ...gin.HandlerFunc) {
+	grantGroup := v1.Group("/role-grants", authMiddlewares...)
+	{
+		grantGroup.GET("", h.ListRoleGrants)                    // 获取授予列表
+		grantGroup.POST("", h.CreateRoleGrant)                  // 创建限时授予
+		grantGroup.POST("/requests", h.RequestRoleGrant)        // 申请临时提权
+		grantGroup.PUT("/:grantID/approve", h.ApproveRoleGrant) // 审批通过
+		grantGroup.PUT("/:grantID/reject", h.RejectRoleGrant)   // 驳回申请
+		grantGroup.DELETE("/:grantID", h.RevokeRoleGrant)       // 撤销授予
+	}
+}
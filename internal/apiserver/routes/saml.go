@@ -0,0 +1,31 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	handler "github.com/ashwinyue/one-auth/internal/apiserver/handler/http"
+)
+
+// InstallSAMLRoutes 安装租户 SAML 单点登录相关的路由.
+func InstallSAMLRoutes(v1 *gin.RouterGroup, h *handler.Handler, authMiddlewares ...gin.HandlerFunc) {
+	samlGroup := v1.Group("/saml/:tenantID")
+	{
+		// SP 元数据、登录入口和断言消费服务由浏览器或 IdP 直接访问，不需要认证
+		samlGroup.GET("/metadata", h.SAMLMetadata)
+		samlGroup.GET("/login", h.SAMLLogin)
+		samlGroup.POST("/acs", h.SAMLAssertion)
+
+		// 管理 SAML 配置和导入 IdP 元数据需要认证和授权
+		adminGroup := samlGroup.Group("", authMiddlewares...)
+		adminGroup.GET("/config", h.GetSAMLConfig)
+		adminGroup.POST("/config", h.CreateSAMLConfig)
+		adminGroup.PUT("/config", h.UpdateSAMLConfig)
+		adminGroup.PUT("/idp-metadata", h.ImportSAMLIDPMetadata)
+	}
+}
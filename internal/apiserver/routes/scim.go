@@ -0,0 +1,39 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	handler "github.com/ashwinyue/one-auth/internal/apiserver/handler/http"
+)
+
+// InstallSCIMRoutes 安装 SCIM 2.0 供应相关的路由，使用租户级 Bearer 令牌认证.
+func InstallSCIMRoutes(engine *gin.Engine, h *handler.Handler, scimAuthn gin.HandlerFunc) {
+	scimGroup := engine.Group("/scim/v2", scimAuthn)
+	{
+		// 服务发现
+		scimGroup.GET("/ServiceProviderConfig", h.SCIMServiceProviderConfig)
+		scimGroup.GET("/ResourceTypes", h.SCIMResourceTypes)
+
+		// 用户
+		scimGroup.GET("/Users", h.ListSCIMUsers)
+		scimGroup.POST("/Users", h.CreateSCIMUser)
+		scimGroup.GET("/Users/:id", h.GetSCIMUser)
+		scimGroup.PUT("/Users/:id", h.ReplaceSCIMUser)
+		scimGroup.PATCH("/Users/:id", h.PatchSCIMUser)
+		scimGroup.DELETE("/Users/:id", h.DeleteSCIMUser)
+
+		// 组，对应租户内的角色
+		scimGroup.GET("/Groups", h.ListSCIMGroups)
+		scimGroup.POST("/Groups", h.CreateSCIMGroup)
+		scimGroup.GET("/Groups/:id", h.GetSCIMGroup)
+		scimGroup.PUT("/Groups/:id", h.ReplaceSCIMGroup)
+		scimGroup.PATCH("/Groups/:id", h.PatchSCIMGroup)
+		scimGroup.DELETE("/Groups/:id", h.DeleteSCIMGroup)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	handler "github.com/ashwinyue/one-auth/internal/apiserver/handler/http"
+)
+
+// InstallSoDRoutes 安装职责分离规则相关的路由.
+func InstallSoDRoutes(v1 *gin.RouterGroup, h *handler.Handler, authMiddlewares ...gin.HandlerFunc) {
+	ruleGroup := v1.Group("/sod-rules", authMiddlewares...)
+	{
+		ruleGroup.GET("", h.ListSoDRules)                 // 获取规则列表
+		ruleGroup.POST("", h.CreateSoDRule)               // 创建规则
+		ruleGroup.GET("/violations", h.ListSoDViolations) // 当前违规报告
+		ruleGroup.PUT("/:ruleID", h.UpdateSoDRule)        // 更新规则
+		ruleGroup.DELETE("/:ruleID", h.DeleteSoDRule)     // 删除规则
+	}
+}
@@ -39,5 +39,10 @@ func InstallTenantRoutes(v1 *gin.RouterGroup, h *handler.Handler, authMiddleware
 	{
 		// 获取租户列表
 		tenantsGroup.GET("", h.ListTenants)
+
+		// SCIM 访问令牌管理
+		tenantsGroup.POST("/:tenantID/scim-tokens", h.CreateSCIMToken)
+		tenantsGroup.GET("/:tenantID/scim-tokens", h.ListSCIMTokens)
+		tenantsGroup.DELETE("/:tenantID/scim-tokens/:tokenID", h.RevokeSCIMToken)
 	}
 }
@@ -8,22 +8,28 @@ package apiserver
 
 import (
 	"context"
+	"net"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/client/sms"
 	genericoptions "github.com/ashwinyue/one-auth/pkg/options"
 	"github.com/ashwinyue/one-auth/pkg/token"
+	"github.com/ashwinyue/one-auth/pkg/watch"
 	"github.com/redis/go-redis/v9"
 
 	//"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
 	"github.com/ashwinyue/one-auth/internal/apiserver/biz"
+	"github.com/ashwinyue/one-auth/internal/apiserver/cache"
 	"github.com/ashwinyue/one-auth/internal/apiserver/pkg/validation"
 	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/apiserver/watcher"
 	"github.com/ashwinyue/one-auth/internal/pkg/known"
 	"github.com/ashwinyue/one-auth/internal/pkg/log"
 	"github.com/ashwinyue/one-auth/internal/pkg/server"
@@ -36,6 +42,9 @@ const (
 	// GRPCGatewayServerMode 定义 gRPC + HTTP 服务模式.
 	// 使用 gRPC 框架启动一个 gRPC 服务器 + HTTP 反向代理服务器.
 	GRPCGatewayServerMode = "grpc-gateway"
+	// ExtAuthzServerMode 定义 gRPC + HTTP + Envoy 外部授权服务模式.
+	// 在 grpc-gateway 模式的基础上，gRPC 服务器同时提供 envoy.service.auth.v3.Authorization 服务.
+	ExtAuthzServerMode = "ext-authz"
 	// GinServerMode 定义 Gin 服务模式.
 	// 使用 Gin Web 框架启动一个 HTTP 服务器.
 	GinServerMode = "gin"
@@ -48,11 +57,19 @@ type Config struct {
 	JWTKey            string
 	Expiration        time.Duration
 	EnableMemoryStore bool
-	TLSOptions        *genericoptions.TLSOptions
-	HTTPOptions       *genericoptions.HTTPOptions
-	GRPCOptions       *genericoptions.GRPCOptions
-	MySQLOptions      *genericoptions.MySQLOptions
-	RedisOptions      *genericoptions.RedisOptions
+	DataScopeMerge    string
+	// ForwardAuthCacheTTL 和 ForwardAuthCacheSize 控制转发认证接口的鉴权结果缓存
+	ForwardAuthCacheTTL  time.Duration
+	ForwardAuthCacheSize int
+	// ForwardAuthTrustedProxies 为可信代理网段，转发认证接口和 Gin 服务器只信任来自这些地址的 X-Real-IP/X-Forwarded-For
+	ForwardAuthTrustedProxies []*net.IPNet
+	TLSOptions                *genericoptions.TLSOptions
+	HTTPOptions               *genericoptions.HTTPOptions
+	GRPCOptions               *genericoptions.GRPCOptions
+	MySQLOptions              *genericoptions.MySQLOptions
+	RedisOptions              *genericoptions.RedisOptions
+	SMSOptions                *genericoptions.SMSOptions
+	WatchOptions              *watch.Options
 }
 
 // UnionServer 定义一个联合服务器. 根据 ServerMode 决定要启动的服务器类型.
@@ -76,6 +93,9 @@ type ServerConfig struct {
 	val   *validation.Validator
 	store store.IStore
 	authz *authz.Authz
+	watch *watch.Watch
+	// sessions 用于认证时检查令牌是否已被撤销
+	sessions *cache.SessionManager
 }
 
 // NewUnionServer 根据配置创建联合服务器.
@@ -83,6 +103,9 @@ func (cfg *Config) NewUnionServer() (*UnionServer, error) {
 	// 初始化 token 包的签名密钥、认证 Key 及 Token 默认过期时间
 	token.Init(cfg.JWTKey, known.XUserID, cfg.Expiration)
 
+	// 设置多个角色的数据权限合并规则
+	store.RegisterDataScopeMerge(cfg.DataScopeMerge)
+
 	log.Infow("Initializing federation server", "server-mode", cfg.ServerMode, "enable-memory-store", cfg.EnableMemoryStore)
 
 	// 创建服务配置，这些配置可用来创建服务器
@@ -199,15 +222,72 @@ func ProvideRedis(cfg *Config) (*redis.Client, error) {
 	return cfg.NewRedis()
 }
 
+// ProvideAuthzOptions 提供授权器选项，通过 Redis 发布订阅在实例之间同步策略变更。
+func ProvideAuthzOptions(rdb *redis.Client) ([]authz.Option, error) {
+	watcher, err := authz.NewRedisWatcher(rdb, authz.DefaultPolicyChannel)
+	if err != nil {
+		return nil, err
+	}
+	return append(authz.DefaultOptions(), authz.WithWatcher(watcher)), nil
+}
+
+// ProvideSMS 根据配置提供一个短信客户端实例。
+func ProvideSMS(cfg *Config) (sms.Client, error) {
+	if cfg.SMSOptions == nil {
+		return sms.NewClient(nil)
+	}
+	return cfg.SMSOptions.NewClient()
+}
+
+// ProvideWatch 根据配置提供后台任务调度器，未配置时不启动后台任务。
+func ProvideWatch(cfg *Config, db *gorm.DB, store store.IStore, biz biz.IBiz) (*watch.Watch, error) {
+	if cfg.WatchOptions == nil || db == nil {
+		return nil, nil
+	}
+	return watch.NewWatch(cfg.WatchOptions, db, watch.WithInitialize(watcher.NewInitializer(store, biz)), watch.WithLogger(watcher.NewLogger()))
+}
+
 func NewWebServer(serverMode string, serverConfig *ServerConfig) (server.Server, error) {
 	// 根据服务模式创建对应的服务实例
 	// 实际企业开发中，可以根据需要只选择一种服务器模式.
 	// 这里为了方便给你展示，通过 cfg.ServerMode 同时支持了 Gin 和 GRPC 2 种服务器模式.
 	// 默认为 gRPC 服务器模式.
+	var srv server.Server
+	var err error
 	switch serverMode {
 	case GinServerMode:
-		return serverConfig.NewGinServer(), nil
+		srv = serverConfig.NewGinServer()
 	default:
-		return serverConfig.NewGRPCServerOr()
+		srv, err = serverConfig.NewGRPCServerOr()
+	}
+	if err != nil || serverConfig.watch == nil {
+		return srv, err
+	}
+	return &watchServer{Server: srv, watch: serverConfig.watch, stopCh: make(chan struct{})}, nil
+}
+
+// watchServer 在运行服务器的同时运行后台任务.
+type watchServer struct {
+	server.Server
+	watch   *watch.Watch
+	stopCh  chan struct{}
+	started atomic.Bool
+}
+
+// RunOrDie 启动后台任务和服务器。后台任务在获得分布式锁后才会开始执行.
+func (s *watchServer) RunOrDie() {
+	go func() {
+		s.watch.Start(s.stopCh)
+		s.started.Store(true)
+	}()
+	s.Server.RunOrDie()
+}
+
+// GracefulStop 停止后台任务后再关停服务器.
+func (s *watchServer) GracefulStop(ctx context.Context) {
+	close(s.stopCh)
+	if s.started.Load() {
+		s.watch.Stop()
 	}
+	s.Server.GracefulStop(ctx)
 }
@@ -0,0 +1,40 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// AccessApprovalPolicyStore 定义了访问申请审批策略存储层方法
+type AccessApprovalPolicyStore interface {
+	Create(ctx context.Context, obj *model.AccessApprovalPolicyM) error
+	Update(ctx context.Context, obj *model.AccessApprovalPolicyM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.AccessApprovalPolicyM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AccessApprovalPolicyM, error)
+}
+
+// accessApprovalPolicyStore 是 AccessApprovalPolicyStore 接口的实现
+type accessApprovalPolicyStore struct {
+	*genericstore.Store[model.AccessApprovalPolicyM]
+}
+
+// 确保 accessApprovalPolicyStore 实现了 AccessApprovalPolicyStore 接口
+var _ AccessApprovalPolicyStore = (*accessApprovalPolicyStore)(nil)
+
+// newAccessApprovalPolicyStore 创建 accessApprovalPolicyStore 的实例
+func newAccessApprovalPolicyStore(store *datastore) *accessApprovalPolicyStore {
+	return &accessApprovalPolicyStore{
+		Store: genericstore.NewStore[model.AccessApprovalPolicyM](store, NewLogger()),
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// AccessRequestStore 定义了访问申请存储层方法
+type AccessRequestStore interface {
+	Create(ctx context.Context, obj *model.AccessRequestM) error
+	Update(ctx context.Context, obj *model.AccessRequestM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.AccessRequestM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AccessRequestM, error)
+
+	// Transition 只在申请仍处于 from 状态时保存修改，返回是否保存成功，用于避免并发的审批互相覆盖
+	Transition(ctx context.Context, obj *model.AccessRequestM, from string) (bool, error)
+}
+
+// accessRequestStore 是 AccessRequestStore 接口的实现
+type accessRequestStore struct {
+	*genericstore.Store[model.AccessRequestM]
+	store *datastore
+}
+
+// 确保 accessRequestStore 实现了 AccessRequestStore 接口
+var _ AccessRequestStore = (*accessRequestStore)(nil)
+
+// newAccessRequestStore 创建 accessRequestStore 的实例
+func newAccessRequestStore(store *datastore) *accessRequestStore {
+	return &accessRequestStore{
+		Store: genericstore.NewStore[model.AccessRequestM](store, NewLogger()),
+		store: store,
+	}
+}
+
+// Transition 只在申请仍处于 from 状态时保存修改
+func (s *accessRequestStore) Transition(ctx context.Context, obj *model.AccessRequestM, from string) (bool, error) {
+	result := s.store.DB(ctx).Model(obj).Where("status = ?", from).Select("*").Updates(obj)
+	return result.RowsAffected > 0, result.Error
+}
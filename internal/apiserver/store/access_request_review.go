@@ -0,0 +1,40 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// AccessRequestReviewStore 定义了访问申请审批记录存储层方法
+type AccessRequestReviewStore interface {
+	Create(ctx context.Context, obj *model.AccessRequestReviewM) error
+	Update(ctx context.Context, obj *model.AccessRequestReviewM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.AccessRequestReviewM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AccessRequestReviewM, error)
+}
+
+// accessRequestReviewStore 是 AccessRequestReviewStore 接口的实现
+type accessRequestReviewStore struct {
+	*genericstore.Store[model.AccessRequestReviewM]
+}
+
+// 确保 accessRequestReviewStore 实现了 AccessRequestReviewStore 接口
+var _ AccessRequestReviewStore = (*accessRequestReviewStore)(nil)
+
+// newAccessRequestReviewStore 创建 accessRequestReviewStore 的实例
+func newAccessRequestReviewStore(store *datastore) *accessRequestReviewStore {
+	return &accessRequestReviewStore{
+		Store: genericstore.NewStore[model.AccessRequestReviewM](store, NewLogger()),
+	}
+}
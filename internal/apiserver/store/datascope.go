@@ -0,0 +1,305 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+)
+
+// 多个角色的数据权限合并规则
+const (
+	// DataScopeMergeWidest 取各角色中最宽的范围，部门范围取并集
+	DataScopeMergeWidest = "widest"
+	// DataScopeMergeNarrowest 取各角色中最窄的范围，部门范围取交集
+	DataScopeMergeNarrowest = "narrowest"
+)
+
+// dataScopeMerge 是当前使用的合并规则
+var dataScopeMerge = DataScopeMergeWidest
+
+// RegisterDataScopeMerge 设置多个角色的数据权限合并规则，非法值会被忽略.
+func RegisterDataScopeMerge(rule string) {
+	if rule == DataScopeMergeWidest || rule == DataScopeMergeNarrowest {
+		dataScopeMerge = rule
+	}
+}
+
+// skipDataScopeKey 用于在 context.Context 中标记跳过数据权限.
+type skipDataScopeKey struct{}
+
+// WithoutDataScope 返回跳过数据权限过滤的上下文，用于系统内部的查询.
+func WithoutDataScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipDataScopeKey{}, true)
+}
+
+// 数据权限范围的宽窄等级，值越大范围越宽
+const (
+	scopeLevelSelf = iota
+	scopeLevelDept
+	scopeLevelTenant
+	scopeLevelAll
+)
+
+// dataScope 是当前用户合并后的数据权限.
+type dataScope struct {
+	userID   int64
+	tenantID int64
+	level    int
+	deptIDs  []int64 // level 为 scopeLevelDept 时可见的部门
+}
+
+// dataScopeTarget 描述数据权限作用的表.
+type dataScopeTarget struct {
+	// ownerColumn 为数据所属用户的列，为空表示该表没有所属用户
+	ownerColumn string
+	// tenantColumn 为租户列，为空时通过 user_tenants 按所属用户判断租户
+	tenantColumn string
+}
+
+// 支持数据权限的表
+var (
+	userDataScope = dataScopeTarget{ownerColumn: "id"}
+	postDataScope = dataScopeTarget{ownerColumn: "user_id"}
+	roleDataScope = dataScopeTarget{tenantColumn: "tenant_id"}
+	menuDataScope = dataScopeTarget{tenantColumn: "tenant_id"}
+)
+
+// scopedStore 在通用 Store 的查询和删除上应用数据权限.
+type scopedStore[T any] struct {
+	*genericstore.Store[T]
+	ds     *datastore
+	target dataScopeTarget
+}
+
+// newScopedStore 创建应用数据权限的通用 Store
+func newScopedStore[T any](store *datastore, target dataScopeTarget) *scopedStore[T] {
+	return &scopedStore[T]{
+		Store:  genericstore.NewStore[T](store, NewLogger()),
+		ds:     store,
+		target: target,
+	}
+}
+
+// Get 在数据权限范围内查询单条记录.
+func (s *scopedStore[T]) Get(ctx context.Context, opts *where.Options) (*T, error) {
+	opts, err := s.ds.applyDataScope(ctx, opts, s.target)
+	if err != nil {
+		return nil, err
+	}
+	return s.Store.Get(ctx, opts)
+}
+
+// List 在数据权限范围内查询记录列表.
+func (s *scopedStore[T]) List(ctx context.Context, opts *where.Options) (int64, []*T, error) {
+	opts, err := s.ds.applyDataScope(ctx, opts, s.target)
+	if err != nil {
+		return 0, nil, err
+	}
+	return s.Store.List(ctx, opts)
+}
+
+// Delete 在数据权限范围内删除记录.
+func (s *scopedStore[T]) Delete(ctx context.Context, opts *where.Options) error {
+	opts, err := s.ds.applyDataScope(ctx, opts, s.target)
+	if err != nil {
+		return err
+	}
+	return s.Store.Delete(ctx, opts)
+}
+
+// applyDataScope 将当前用户的数据权限作为查询条件追加到 opts 中.
+// 上下文中没有登录用户或标记了 WithoutDataScope 时不做过滤.
+func (store *datastore) applyDataScope(ctx context.Context, opts *where.Options, target dataScopeTarget) (*where.Options, error) {
+	if opts == nil {
+		opts = where.NewWhere()
+	}
+	if skip, _ := ctx.Value(skipDataScopeKey{}).(bool); skip {
+		return opts, nil
+	}
+
+	scope, err := store.resolveDataScope(ctx)
+	if err != nil || scope == nil {
+		return opts, err
+	}
+	if query, args := scope.condition(target); query != "" {
+		opts = opts.Q(query, args...)
+	}
+	return opts, nil
+}
+
+// condition 返回数据权限对应的查询条件.
+// 没有所属用户的表（角色、菜单）在部门和本人范围下按本租户过滤.
+func (s *dataScope) condition(target dataScopeTarget) (string, []any) {
+	if s.level == scopeLevelAll {
+		return "", nil
+	}
+
+	if target.ownerColumn == "" || s.level == scopeLevelTenant {
+		if target.tenantColumn != "" {
+			return target.tenantColumn + " = ?", []any{s.tenantID}
+		}
+		return target.ownerColumn + " IN (SELECT user_id FROM user_tenants WHERE tenant_id = ? AND deleted_at IS NULL)", []any{s.tenantID}
+	}
+
+	// 本人的数据始终可见
+	if s.level == scopeLevelDept && len(s.deptIDs) > 0 {
+		return fmt.Sprintf("(%s IN (SELECT user_id FROM user_tenants WHERE tenant_id = ? AND department_id IN ? AND deleted_at IS NULL) OR %s = ?)",
+			target.ownerColumn, target.ownerColumn), []any{s.tenantID, s.deptIDs, s.userID}
+	}
+	return target.ownerColumn + " = ?", []any{s.userID}
+}
+
+// resolveDataScope 根据用户在当前租户下的角色计算数据权限
+func (store *datastore) resolveDataScope(ctx context.Context) (*dataScope, error) {
+	userID := contextx.UserID(ctx)
+	if userID == 0 {
+		return nil, nil
+	}
+	tenantID := int64(1) // 默认租户
+	if tid, err := strconv.ParseInt(contextx.TenantID(ctx), 10, 64); err == nil {
+		tenantID = tid
+	}
+
+	db := store.DB(ctx)
+
+	// 用户在租户下的角色
+	var subjects []string
+	err := db.Table("casbin_rule").
+		Where("ptype = 'g' AND v0 = ? AND v2 = ?", fmt.Sprintf("u%d", userID), fmt.Sprintf("t%d", tenantID)).
+		Pluck("v1", &subjects).Error
+	if err != nil {
+		return nil, err
+	}
+	var roleIDs []int64
+	for _, subject := range subjects {
+		if id, err := strconv.ParseInt(strings.TrimPrefix(subject, "r"), 10, 64); err == nil {
+			roleIDs = append(roleIDs, id)
+		}
+	}
+
+	scope := &dataScope{userID: userID, tenantID: tenantID, level: scopeLevelSelf}
+	if len(roleIDs) == 0 {
+		return scope, nil
+	}
+
+	var roles []*model.RoleM
+	if err := db.Where("id IN ? AND status = 1", roleIDs).Find(&roles).Error; err != nil {
+		return nil, err
+	}
+
+	var deptIDs []int64 // 本部门及下级部门，按需加载
+	var loaded bool
+	merged := false
+	for _, role := range roles {
+		level, ids := scopeLevelSelf, []int64(nil)
+		switch role.DataScope {
+		case model.DataScopeAll:
+			level = scopeLevelAll
+		case model.DataScopeTenant, "":
+			level = scopeLevelTenant
+		case model.DataScopeDept:
+			if !loaded {
+				if deptIDs, err = store.userDepartmentTree(ctx, userID, tenantID); err != nil {
+					return nil, err
+				}
+				loaded = true
+			}
+			level, ids = scopeLevelDept, deptIDs
+		case model.DataScopeCustom:
+			level, ids = scopeLevelDept, role.GetDataScopeDeptIDs()
+		}
+
+		if !merged {
+			scope.level, scope.deptIDs, merged = level, ids, true
+			continue
+		}
+		scope.merge(level, ids)
+	}
+	return scope, nil
+}
+
+// merge 按配置的合并规则合并一个角色的数据权限
+func (s *dataScope) merge(level int, deptIDs []int64) {
+	widest := dataScopeMerge == DataScopeMergeWidest
+	switch {
+	case level == s.level && level == scopeLevelDept:
+		if widest {
+			s.deptIDs = unionIDs(s.deptIDs, deptIDs)
+		} else {
+			s.deptIDs = intersectIDs(s.deptIDs, deptIDs)
+		}
+	case (widest && level > s.level) || (!widest && level < s.level):
+		s.level, s.deptIDs = level, deptIDs
+	}
+}
+
+// userDepartmentTree 返回用户在租户下所属部门及其全部下级部门
+func (store *datastore) userDepartmentTree(ctx context.Context, userID, tenantID int64) ([]int64, error) {
+	var userTenant model.UserTenantM
+	err := store.DB(ctx).Where("user_id = ? AND tenant_id = ?", userID, tenantID).Limit(1).Find(&userTenant).Error
+	if err != nil || userTenant.DepartmentID == nil {
+		return nil, err
+	}
+
+	var departments []*model.DepartmentM
+	if err := store.DB(ctx).Where("tenant_id = ?", tenantID).Find(&departments).Error; err != nil {
+		return nil, err
+	}
+	children := make(map[int64][]int64)
+	for _, d := range departments {
+		if d.ParentID != nil {
+			children[*d.ParentID] = append(children[*d.ParentID], d.ID)
+		}
+	}
+
+	ids := []int64{*userTenant.DepartmentID}
+	seen := map[int64]bool{*userTenant.DepartmentID: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids, nil
+}
+
+func unionIDs(a, b []int64) []int64 {
+	seen := make(map[int64]bool, len(a)+len(b))
+	var result []int64
+	for _, id := range append(append([]int64{}, a...), b...) {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func intersectIDs(a, b []int64) []int64 {
+	set := make(map[int64]bool, len(b))
+	for _, id := range b {
+		set[id] = true
+	}
+	var result []int64
+	for _, id := range a {
+		if set[id] {
+			result = append(result, id)
+			delete(set, id)
+		}
+	}
+	return result
+}
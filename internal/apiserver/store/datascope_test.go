@@ -0,0 +1,62 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataScope_Merge(t *testing.T) {
+	defer RegisterDataScopeMerge(DataScopeMergeWidest)
+
+	RegisterDataScopeMerge(DataScopeMergeWidest)
+	s := &dataScope{level: scopeLevelDept, deptIDs: []int64{1, 2}}
+	s.merge(scopeLevelDept, []int64{2, 3})
+	assert.Equal(t, []int64{1, 2, 3}, s.deptIDs)
+	s.merge(scopeLevelSelf, nil)
+	assert.Equal(t, scopeLevelDept, s.level)
+	s.merge(scopeLevelTenant, nil)
+	assert.Equal(t, scopeLevelTenant, s.level)
+
+	RegisterDataScopeMerge(DataScopeMergeNarrowest)
+	s = &dataScope{level: scopeLevelDept, deptIDs: []int64{1, 2}}
+	s.merge(scopeLevelDept, []int64{2, 3})
+	assert.Equal(t, []int64{2}, s.deptIDs)
+	s.merge(scopeLevelAll, nil)
+	assert.Equal(t, scopeLevelDept, s.level)
+	s.merge(scopeLevelSelf, nil)
+	assert.Equal(t, scopeLevelSelf, s.level)
+
+	// 非法值被忽略
+	RegisterDataScopeMerge("random")
+	assert.Equal(t, DataScopeMergeNarrowest, dataScopeMerge)
+}
+
+func TestDataScope_Condition(t *testing.T) {
+	s := &dataScope{userID: 7, tenantID: 2, level: scopeLevelAll}
+	query, _ := s.condition(userDataScope)
+	assert.Empty(t, query)
+
+	s.level = scopeLevelTenant
+	query, args := s.condition(roleDataScope)
+	assert.Equal(t, "tenant_id = ?", query)
+	assert.Equal(t, []any{int64(2)}, args)
+	query, _ = s.condition(postDataScope)
+	assert.Contains(t, query, "user_id IN (SELECT user_id FROM user_tenants")
+
+	s.level, s.deptIDs = scopeLevelDept, []int64{5, 6}
+	query, args = s.condition(userDataScope)
+	assert.Contains(t, query, "department_id IN ?")
+	assert.Equal(t, []any{int64(2), []int64{5, 6}, int64(7)}, args)
+
+	// 没有可见部门时只能看到本人数据
+	s.deptIDs = nil
+	query, args = s.condition(postDataScope)
+	assert.Equal(t, "user_id = ?", query)
+	assert.Equal(t, []any{int64(7)}, args)
+
+	// 角色和菜单没有所属用户，按本租户过滤
+	s.level = scopeLevelSelf
+	query, _ = s.condition(menuDataScope)
+	assert.Equal(t, "tenant_id = ?", query)
+}
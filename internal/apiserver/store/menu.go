@@ -9,7 +9,6 @@ package store
 import (
 	"context"
 
-	genericstore "github.com/ashwinyue/one-auth/pkg/store"
 	"github.com/ashwinyue/one-auth/pkg/store/where"
 
 	"github.com/ashwinyue/one-auth/internal/apiserver/model"
@@ -55,7 +54,7 @@ type MenuExpansion interface {
 
 // menuStore 是 MenuStore 接口的实现.
 type menuStore struct {
-	*genericstore.Store[model.MenuM]
+	*scopedStore[model.MenuM]
 }
 
 // 确保 menuStore 实现了 MenuStore 接口.
@@ -64,7 +63,7 @@ var _ MenuStore = (*menuStore)(nil)
 // newMenuStore 创建 menuStore 的实例.
 func newMenuStore(store *datastore) *menuStore {
 	return &menuStore{
-		Store: genericstore.NewStore[model.MenuM](store, NewLogger()),
+		scopedStore: newScopedStore[model.MenuM](store, menuDataScope),
 	}
 }
 
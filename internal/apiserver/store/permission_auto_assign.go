@@ -0,0 +1,76 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// PermissionAutoAssignConfigStore 定义了权限自动分配配置存储层方法
+type PermissionAutoAssignConfigStore interface {
+	Create(ctx context.Context, obj *model.PermissionAutoAssignConfigM) error
+	Update(ctx context.Context, obj *model.PermissionAutoAssignConfigM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.PermissionAutoAssignConfigM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.PermissionAutoAssignConfigM, error)
+}
+
+// permissionAutoAssignConfigStore 是 PermissionAutoAssignConfigStore 接口的实现
+type permissionAutoAssignConfigStore struct {
+	*genericstore.Store[model.PermissionAutoAssignConfigM]
+}
+
+// 确保 permissionAutoAssignConfigStore 实现了 PermissionAutoAssignConfigStore 接口
+var _ PermissionAutoAssignConfigStore = (*permissionAutoAssignConfigStore)(nil)
+
+// newPermissionAutoAssignConfigStore 创建 permissionAutoAssignConfigStore 的实例
+func newPermissionAutoAssignConfigStore(store *datastore) *permissionAutoAssignConfigStore {
+	return &permissionAutoAssignConfigStore{
+		Store: genericstore.NewStore[model.PermissionAutoAssignConfigM](store, NewLogger()),
+	}
+}
+
+// PermissionAutoAssignRuleStore 定义了权限自动分配规则存储层方法
+type PermissionAutoAssignRuleStore interface {
+	Create(ctx context.Context, obj *model.PermissionAutoAssignRuleM) error
+	Update(ctx context.Context, obj *model.PermissionAutoAssignRuleM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.PermissionAutoAssignRuleM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.PermissionAutoAssignRuleM, error)
+
+	// ListByTenant 按排序获取租户的全部规则
+	ListByTenant(ctx context.Context, tenantID int64) ([]*model.PermissionAutoAssignRuleM, error)
+}
+
+// permissionAutoAssignRuleStore 是 PermissionAutoAssignRuleStore 接口的实现
+type permissionAutoAssignRuleStore struct {
+	*genericstore.Store[model.PermissionAutoAssignRuleM]
+	store *datastore
+}
+
+// 确保 permissionAutoAssignRuleStore 实现了 PermissionAutoAssignRuleStore 接口
+var _ PermissionAutoAssignRuleStore = (*permissionAutoAssignRuleStore)(nil)
+
+// newPermissionAutoAssignRuleStore 创建 permissionAutoAssignRuleStore 的实例
+func newPermissionAutoAssignRuleStore(store *datastore) *permissionAutoAssignRuleStore {
+	return &permissionAutoAssignRuleStore{
+		Store: genericstore.NewStore[model.PermissionAutoAssignRuleM](store, NewLogger()),
+		store: store,
+	}
+}
+
+// ListByTenant 按排序获取租户的全部规则
+func (s *permissionAutoAssignRuleStore) ListByTenant(ctx context.Context, tenantID int64) ([]*model.PermissionAutoAssignRuleM, error) {
+	var rules []*model.PermissionAutoAssignRuleM
+	err := s.store.DB(ctx).Where("tenant_id = ?", tenantID).Order("sort_order ASC, id ASC").Find(&rules).Error
+	return rules, err
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// PlatformAuditLogStore 定义了平台操作审计日志存储层方法
+type PlatformAuditLogStore interface {
+	Create(ctx context.Context, obj *model.PlatformAuditLogM) error
+	Get(ctx context.Context, opts *where.Options) (*model.PlatformAuditLogM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.PlatformAuditLogM, error)
+}
+
+// platformAuditLogStore 是 PlatformAuditLogStore 接口的实现，审计日志只能追加
+type platformAuditLogStore struct {
+	*genericstore.Store[model.PlatformAuditLogM]
+}
+
+// 确保 platformAuditLogStore 实现了 PlatformAuditLogStore 接口
+var _ PlatformAuditLogStore = (*platformAuditLogStore)(nil)
+
+// newPlatformAuditLogStore 创建 platformAuditLogStore 的实例
+func newPlatformAuditLogStore(store *datastore) *platformAuditLogStore {
+	return &platformAuditLogStore{
+		Store: genericstore.NewStore[model.PlatformAuditLogM](store, NewLogger()),
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// PlatformOperatorStore 定义了平台运营人员存储层方法
+type PlatformOperatorStore interface {
+	Create(ctx context.Context, obj *model.PlatformOperatorM) error
+	Update(ctx context.Context, obj *model.PlatformOperatorM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.PlatformOperatorM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.PlatformOperatorM, error)
+
+	// IsOperator 检查用户是否为平台运营人员
+	IsOperator(ctx context.Context, userID int64) (bool, error)
+}
+
+// platformOperatorStore 是 PlatformOperatorStore 接口的实现
+type platformOperatorStore struct {
+	*genericstore.Store[model.PlatformOperatorM]
+	store *datastore
+}
+
+// 确保 platformOperatorStore 实现了 PlatformOperatorStore 接口
+var _ PlatformOperatorStore = (*platformOperatorStore)(nil)
+
+// newPlatformOperatorStore 创建 platformOperatorStore 的实例
+func newPlatformOperatorStore(store *datastore) *platformOperatorStore {
+	return &platformOperatorStore{
+		Store: genericstore.NewStore[model.PlatformOperatorM](store, NewLogger()),
+		store: store,
+	}
+}
+
+// IsOperator 检查用户是否为平台运营人员
+func (s *platformOperatorStore) IsOperator(ctx context.Context, userID int64) (bool, error) {
+	var count int64
+	err := s.store.DB(ctx).Model(&model.PlatformOperatorM{}).Where("user_id = ?", userID).Count(&count).Error
+	return count > 0, err
+}
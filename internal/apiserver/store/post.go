@@ -10,7 +10,6 @@ package store
 import (
 	"context"
 
-	genericstore "github.com/ashwinyue/one-auth/pkg/store"
 	"github.com/ashwinyue/one-auth/pkg/store/where"
 
 	"github.com/ashwinyue/one-auth/internal/apiserver/model"
@@ -32,7 +31,7 @@ type PostExpansion interface{}
 
 // postStore 是 PostStore 接口的实现.
 type postStore struct {
-	*genericstore.Store[model.PostM]
+	*scopedStore[model.PostM]
 }
 
 // 确保 postStore 实现了 PostStore 接口.
@@ -41,6 +40,6 @@ var _ PostStore = (*postStore)(nil)
 // newPostStore 创建 postStore 的实例.
 func newPostStore(store *datastore) *postStore {
 	return &postStore{
-		Store: genericstore.NewStore[model.PostM](store, NewLogger()),
+		scopedStore: newScopedStore[model.PostM](store, postDataScope),
 	}
 }
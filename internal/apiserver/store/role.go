@@ -9,7 +9,6 @@ package store
 import (
 	"context"
 
-	genericstore "github.com/ashwinyue/one-auth/pkg/store"
 	"github.com/ashwinyue/one-auth/pkg/store/where"
 
 	"github.com/ashwinyue/one-auth/internal/apiserver/model"
@@ -43,7 +42,7 @@ type RoleExpansion interface {
 
 // roleStore 是 RoleStore 接口的实现.
 type roleStore struct {
-	*genericstore.Store[model.RoleM]
+	*scopedStore[model.RoleM]
 }
 
 // 确保 roleStore 实现了 RoleStore 接口.
@@ -52,7 +51,7 @@ var _ RoleStore = (*roleStore)(nil)
 // newRoleStore 创建 roleStore 的实例.
 func newRoleStore(store *datastore) *roleStore {
 	return &roleStore{
-		Store: genericstore.NewStore[model.RoleM](store, NewLogger()),
+		scopedStore: newScopedStore[model.RoleM](store, roleDataScope),
 	}
 }
 
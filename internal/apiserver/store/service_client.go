@@ -0,0 +1,51 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// ServiceClientStore 定义了策略决策点服务凭证存储层方法
+type ServiceClientStore interface {
+	Create(ctx context.Context, obj *model.ServiceClientM) error
+	Update(ctx context.Context, obj *model.ServiceClientM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.ServiceClientM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.ServiceClientM, error)
+
+	// TouchLastUsed 更新凭证的最后使用时间
+	TouchLastUsed(ctx context.Context, id int64, at time.Time) error
+}
+
+// serviceClientStore 是 ServiceClientStore 接口的实现
+type serviceClientStore struct {
+	*genericstore.Store[model.ServiceClientM]
+	store *datastore
+}
+
+// 确保 serviceClientStore 实现了 ServiceClientStore 接口
+var _ ServiceClientStore = (*serviceClientStore)(nil)
+
+// newServiceClientStore 创建 serviceClientStore 的实例
+func newServiceClientStore(store *datastore) *serviceClientStore {
+	return &serviceClientStore{
+		Store: genericstore.NewStore[model.ServiceClientM](store, NewLogger()),
+		store: store,
+	}
+}
+
+// TouchLastUsed 更新凭证的最后使用时间
+func (s *serviceClientStore) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+	return s.store.DB(ctx).Model(&model.ServiceClientM{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// SoDRuleStore 定义了职责分离规则存储层方法
+type SoDRuleStore interface {
+	Create(ctx context.Context, obj *model.SoDRuleM) error
+	Update(ctx context.Context, obj *model.SoDRuleM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.SoDRuleM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.SoDRuleM, error)
+}
+
+// sodRuleStore 是 SoDRuleStore 接口的实现
+type sodRuleStore struct {
+	*genericstore.Store[model.SoDRuleM]
+}
+
+// 确保 sodRuleStore 实现了 SoDRuleStore 接口
+var _ SoDRuleStore = (*sodRuleStore)(nil)
+
+// newSoDRuleStore 创建 sodRuleStore 的实例
+func newSoDRuleStore(store *datastore) *sodRuleStore {
+	return &sodRuleStore{
+		Store: genericstore.NewStore[model.SoDRuleM](store, NewLogger()),
+	}
+}
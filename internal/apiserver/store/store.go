@@ -44,10 +44,25 @@ type IStore interface {
 
 	// RBAC相关的store接口
 	Tenant() TenantStore
+	TenantLDAPConfig() TenantLDAPConfigStore
+	TenantSAMLConfig() TenantSAMLConfigStore
+	TenantSCIMToken() TenantSCIMTokenStore
 	Role() RoleStore
 	Permission() PermissionStore
 	Menu() MenuStore
 	MenuPermission() MenuPermissionStore
+	PermissionAutoAssignConfig() PermissionAutoAssignConfigStore
+	PermissionAutoAssignRule() PermissionAutoAssignRuleStore
+	UserRoleGrant() UserRoleGrantStore
+	AccessRequest() AccessRequestStore
+	AccessRequestReview() AccessRequestReviewStore
+	AccessApprovalPolicy() AccessApprovalPolicyStore
+	SoDRule() SoDRuleStore
+
+	// 平台运营相关的store接口
+	PlatformOperator() PlatformOperatorStore
+	PlatformAuditLog() PlatformAuditLogStore
+	ServiceClient() ServiceClientStore
 }
 
 // transactionKey 用于在 context.Context 中存储事务上下文的键.
@@ -129,6 +144,21 @@ func (store *datastore) Tenant() TenantStore {
 	return newTenantStore(store)
 }
 
+// TenantLDAPConfig 返回一个实现了 TenantLDAPConfigStore 接口的实例.
+func (store *datastore) TenantLDAPConfig() TenantLDAPConfigStore {
+	return newTenantLDAPConfigStore(store)
+}
+
+// TenantSAMLConfig 返回一个实现了 TenantSAMLConfigStore 接口的实例.
+func (store *datastore) TenantSAMLConfig() TenantSAMLConfigStore {
+	return newTenantSAMLConfigStore(store)
+}
+
+// TenantSCIMToken 返回一个实现了 TenantSCIMTokenStore 接口的实例.
+func (store *datastore) TenantSCIMToken() TenantSCIMTokenStore {
+	return newTenantSCIMTokenStore(store)
+}
+
 // Role 返回一个实现了 RoleStore 接口的实例.
 func (store *datastore) Role() RoleStore {
 	return newRoleStore(store)
@@ -144,7 +174,57 @@ func (store *datastore) Permission() PermissionStore {
 	return newPermissionStore(store)
 }
 
+// PermissionAutoAssignConfig 返回一个实现了 PermissionAutoAssignConfigStore 接口的实例.
+func (store *datastore) PermissionAutoAssignConfig() PermissionAutoAssignConfigStore {
+	return newPermissionAutoAssignConfigStore(store)
+}
+
+// PermissionAutoAssignRule 返回一个实现了 PermissionAutoAssignRuleStore 接口的实例.
+func (store *datastore) PermissionAutoAssignRule() PermissionAutoAssignRuleStore {
+	return newPermissionAutoAssignRuleStore(store)
+}
+
 // Menu 返回一个实现了 MenuStore 接口的实例.
 func (store *datastore) Menu() MenuStore {
 	return newMenuStore(store)
 }
+
+// UserRoleGrant 返回一个实现了 UserRoleGrantStore 接口的实例.
+func (store *datastore) UserRoleGrant() UserRoleGrantStore {
+	return newUserRoleGrantStore(store)
+}
+
+// AccessRequest 返回一个实现了 AccessRequestStore 接口的实例.
+func (store *datastore) AccessRequest() AccessRequestStore {
+	return newAccessRequestStore(store)
+}
+
+// AccessRequestReview 返回一个实现了 AccessRequestReviewStore 接口的实例.
+func (store *datastore) AccessRequestReview() AccessRequestReviewStore {
+	return newAccessRequestReviewStore(store)
+}
+
+// AccessApprovalPolicy 返回一个实现了 AccessApprovalPolicyStore 接口的实例.
+func (store *datastore) AccessApprovalPolicy() AccessApprovalPolicyStore {
+	return newAccessApprovalPolicyStore(store)
+}
+
+// SoDRule 返回一个实现了 SoDRuleStore 接口的实例.
+func (store *datastore) SoDRule() SoDRuleStore {
+	return newSoDRuleStore(store)
+}
+
+// PlatformOperator 返回一个实现了 PlatformOperatorStore 接口的实例.
+func (store *datastore) PlatformOperator() PlatformOperatorStore {
+	return newPlatformOperatorStore(store)
+}
+
+// PlatformAuditLog 返回一个实现了 PlatformAuditLogStore 接口的实例.
+func (store *datastore) PlatformAuditLog() PlatformAuditLogStore {
+	return newPlatformAuditLogStore(store)
+}
+
+// ServiceClient 返回一个实现了 ServiceClientStore 接口的实例.
+func (store *datastore) ServiceClient() ServiceClientStore {
+	return newServiceClientStore(store)
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// TenantLDAPConfigStore 定义了租户 LDAP 配置存储层方法
+type TenantLDAPConfigStore interface {
+	Create(ctx context.Context, obj *model.TenantLDAPConfigM) error
+	Update(ctx context.Context, obj *model.TenantLDAPConfigM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.TenantLDAPConfigM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.TenantLDAPConfigM, error)
+}
+
+// tenantLDAPConfigStore 是 TenantLDAPConfigStore 接口的实现
+type tenantLDAPConfigStore struct {
+	*genericstore.Store[model.TenantLDAPConfigM]
+}
+
+// 确保 tenantLDAPConfigStore 实现了 TenantLDAPConfigStore 接口
+var _ TenantLDAPConfigStore = (*tenantLDAPConfigStore)(nil)
+
+// newTenantLDAPConfigStore 创建 tenantLDAPConfigStore 的实例
+func newTenantLDAPConfigStore(store *datastore) *tenantLDAPConfigStore {
+	return &tenantLDAPConfigStore{
+		Store: genericstore.NewStore[model.TenantLDAPConfigM](store, NewLogger()),
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// TenantSAMLConfigStore 定义了租户 SAML 配置存储层方法
+type TenantSAMLConfigStore interface {
+	Create(ctx context.Context, obj *model.TenantSAMLConfigM) error
+	Update(ctx context.Context, obj *model.TenantSAMLConfigM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.TenantSAMLConfigM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.TenantSAMLConfigM, error)
+}
+
+// tenantSAMLConfigStore 是 TenantSAMLConfigStore 接口的实现
+type tenantSAMLConfigStore struct {
+	*genericstore.Store[model.TenantSAMLConfigM]
+}
+
+// 确保 tenantSAMLConfigStore 实现了 TenantSAMLConfigStore 接口
+var _ TenantSAMLConfigStore = (*tenantSAMLConfigStore)(nil)
+
+// newTenantSAMLConfigStore 创建 tenantSAMLConfigStore 的实例
+func newTenantSAMLConfigStore(store *datastore) *tenantSAMLConfigStore {
+	return &tenantSAMLConfigStore{
+		Store: genericstore.NewStore[model.TenantSAMLConfigM](store, NewLogger()),
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// TenantSCIMTokenStore 定义了租户 SCIM 访问令牌存储层方法
+type TenantSCIMTokenStore interface {
+	Create(ctx context.Context, obj *model.TenantSCIMTokenM) error
+	Update(ctx context.Context, obj *model.TenantSCIMTokenM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.TenantSCIMTokenM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.TenantSCIMTokenM, error)
+
+	// TouchLastUsed 更新令牌的最后使用时间
+	TouchLastUsed(ctx context.Context, id int64, at time.Time) error
+}
+
+// tenantSCIMTokenStore 是 TenantSCIMTokenStore 接口的实现
+type tenantSCIMTokenStore struct {
+	*genericstore.Store[model.TenantSCIMTokenM]
+	store *datastore
+}
+
+// 确保 tenantSCIMTokenStore 实现了 TenantSCIMTokenStore 接口
+var _ TenantSCIMTokenStore = (*tenantSCIMTokenStore)(nil)
+
+// newTenantSCIMTokenStore 创建 tenantSCIMTokenStore 的实例
+func newTenantSCIMTokenStore(store *datastore) *tenantSCIMTokenStore {
+	return &tenantSCIMTokenStore{
+		Store: genericstore.NewStore[model.TenantSCIMTokenM](store, NewLogger()),
+		store: store,
+	}
+}
+
+// TouchLastUsed 更新令牌的最后使用时间
+func (s *tenantSCIMTokenStore) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+	return s.store.DB(ctx).Model(&model.TenantSCIMTokenM{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+}
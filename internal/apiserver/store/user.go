@@ -10,7 +10,6 @@ package store
 import (
 	"context"
 
-	genericstore "github.com/ashwinyue/one-auth/pkg/store"
 	"github.com/ashwinyue/one-auth/pkg/store/where"
 
 	"github.com/ashwinyue/one-auth/internal/apiserver/model"
@@ -32,8 +31,8 @@ type UserExpansion interface {
 	// 根据用户名或邮箱获取用户（用于登录认证）
 	GetByUsernameOrEmail(ctx context.Context, identifier string) (*model.UserM, error)
 
-	// 检查用户是否激活
-	IsUserActive(ctx context.Context, userID string) (bool, error)
+	// 检查用户在租户内是否处于启用状态（用于认证中间件）
+	IsUserActive(ctx context.Context, userID string, tenantID int64) (bool, error)
 
 	// 更新用户最后登录时间
 	UpdateLastLoginTime(ctx context.Context, userID string) error
@@ -53,7 +52,7 @@ type UserExpansion interface {
 
 // userStore 是 UserStore 接口的实现.
 type userStore struct {
-	*genericstore.Store[model.UserM]
+	*scopedStore[model.UserM]
 	ds *datastore
 }
 
@@ -63,33 +62,43 @@ var _ UserStore = (*userStore)(nil)
 // newUserStore 创建 userStore 的实例.
 func newUserStore(store *datastore) *userStore {
 	return &userStore{
-		Store: genericstore.NewStore[model.UserM](store, NewLogger()),
-		ds:    store,
+		scopedStore: newScopedStore[model.UserM](store, userDataScope),
+		ds:          store,
 	}
 }
 
 // GetByUsernameOrEmail 根据用户名或邮箱获取用户
 func (s *userStore) GetByUsernameOrEmail(ctx context.Context, identifier string) (*model.UserM, error) {
-	// 使用通用的Get方法，通过where条件查询
-	return s.Get(ctx, where.NewWhere().Q("(username = ? OR email = ?) AND deleted_at IS NULL", identifier, identifier))
+	// 登录和唯一性校验不受数据权限限制
+	return s.Get(WithoutDataScope(ctx), where.NewWhere().Q("(username = ? OR email = ?) AND deleted_at IS NULL", identifier, identifier))
 }
 
-// IsUserActive 检查用户是否激活
-func (s *userStore) IsUserActive(ctx context.Context, userID string) (bool, error) {
-	// 用户状态由UserStatusM模型管理，这里暂时返回true
-	// 实际应该查询user_status表
-	user, err := s.Get(ctx, where.F("user_id", userID))
+// IsUserActive 检查用户在租户内是否处于启用状态：租户关联被禁用，或主认证方式未激活、被锁定或封禁时视为停用
+func (s *userStore) IsUserActive(ctx context.Context, userID string, tenantID int64) (bool, error) {
+	var disabled int64
+	err := s.ds.DB(ctx).Model(&model.UserTenantM{}).
+		Where("user_id = ? AND tenant_id = ? AND status = ?", userID, tenantID, false).
+		Count(&disabled).Error
 	if err != nil {
 		return false, err
 	}
-	// 简单检查用户是否存在且未被软删除
-	return user != nil, nil
+	if disabled > 0 {
+		return false, nil
+	}
+
+	err = s.ds.DB(ctx).Model(&model.UserStatusM{}).
+		Where("user_id = ? AND tenant_id = ? AND is_primary = ? AND status <> ?", userID, tenantID, true, int32(model.UserStatusActive)).
+		Count(&disabled).Error
+	if err != nil {
+		return false, err
+	}
+	return disabled == 0, nil
 }
 
 // UpdateLastLoginTime 更新用户最后登录时间
 func (s *userStore) UpdateLastLoginTime(ctx context.Context, userID string) error {
 	// 可以使用通用的Update方法，先Get再Update
-	user, err := s.Get(ctx, where.F("user_id", userID))
+	user, err := s.Get(WithoutDataScope(ctx), where.F("user_id", userID))
 	if err != nil {
 		return err
 	}
@@ -102,7 +111,7 @@ func (s *userStore) UpdateLastLoginTime(ctx context.Context, userID string) erro
 // IsUsernameExists 检查用户名是否已存在
 func (s *userStore) IsUsernameExists(ctx context.Context, username string) (bool, error) {
 	// 使用通用的Get方法检查是否存在
-	_, err := s.Get(ctx, where.F("username", username))
+	_, err := s.Get(WithoutDataScope(ctx), where.F("username", username))
 	if err != nil {
 		// 如果是记录不存在错误，返回false
 		return false, nil
@@ -113,7 +122,7 @@ func (s *userStore) IsUsernameExists(ctx context.Context, username string) (bool
 // IsEmailExists 检查邮箱是否已存在
 func (s *userStore) IsEmailExists(ctx context.Context, email string) (bool, error) {
 	// 使用通用的Get方法检查是否存在
-	_, err := s.Get(ctx, where.F("email", email))
+	_, err := s.Get(WithoutDataScope(ctx), where.F("email", email))
 	if err != nil {
 		// 如果是记录不存在错误，返回false
 		return false, nil
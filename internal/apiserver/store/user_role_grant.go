@@ -0,0 +1,51 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	genericstore "github.com/ashwinyue/one-auth/pkg/store"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+)
+
+// UserRoleGrantStore 定义了限时角色授予存储层方法
+type UserRoleGrantStore interface {
+	Create(ctx context.Context, obj *model.UserRoleGrantM) error
+	Update(ctx context.Context, obj *model.UserRoleGrantM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.UserRoleGrantM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.UserRoleGrantM, error)
+
+	// Transition 只在授予仍处于 from 状态时保存修改，返回是否保存成功，用于避免并发的状态变更互相覆盖
+	Transition(ctx context.Context, obj *model.UserRoleGrantM, from string) (bool, error)
+}
+
+// userRoleGrantStore 是 UserRoleGrantStore 接口的实现
+type userRoleGrantStore struct {
+	*genericstore.Store[model.UserRoleGrantM]
+	store *datastore
+}
+
+// 确保 userRoleGrantStore 实现了 UserRoleGrantStore 接口
+var _ UserRoleGrantStore = (*userRoleGrantStore)(nil)
+
+// newUserRoleGrantStore 创建 userRoleGrantStore 的实例
+func newUserRoleGrantStore(store *datastore) *userRoleGrantStore {
+	return &userRoleGrantStore{
+		Store: genericstore.NewStore[model.UserRoleGrantM](store, NewLogger()),
+		store: store,
+	}
+}
+
+// Transition 只在授予仍处于 from 状态时保存修改
+func (s *userRoleGrantStore) Transition(ctx context.Context, obj *model.UserRoleGrantM, from string) (bool, error) {
+	result := s.store.DB(ctx).Model(obj).Where("status = ?", from).Select("*").Updates(obj)
+	return result.RowsAffected > 0, result.Error
+}
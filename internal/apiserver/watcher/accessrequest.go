@@ -0,0 +1,54 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package watcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/ashwinyue/one-auth/pkg/watch/registry"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/biz"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+)
+
+// accessRequestWatcher 定时将超时未审批完成的访问申请标记为过期.
+type accessRequestWatcher struct {
+	biz biz.IBiz
+}
+
+// 确保 accessRequestWatcher 实现了所需的接口.
+var (
+	_ registry.ISpec = (*accessRequestWatcher)(nil)
+	_ WantsBiz       = (*accessRequestWatcher)(nil)
+)
+
+// Run 执行一次访问申请的过期处理.
+func (w *accessRequestWatcher) Run() {
+	n, err := w.biz.AccessRequestV1().ExpireDue(context.Background(), time.Now())
+	if err != nil {
+		log.Errorw("Failed to expire access requests", "err", err)
+		return
+	}
+	if n > 0 {
+		log.Infow("Expired access requests", "count", n)
+	}
+}
+
+// Spec 返回任务的执行周期.
+func (w *accessRequestWatcher) Spec() string {
+	return "@every 1m"
+}
+
+// SetBiz 设置业务层.
+func (w *accessRequestWatcher) SetBiz(biz biz.IBiz) {
+	w.biz = biz
+}
+
+func init() {
+	registry.Register("accessrequest", &accessRequestWatcher{})
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package watcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+	"github.com/ashwinyue/one-auth/pkg/watch/registry"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/biz"
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+)
+
+// notifyBatchSize 是每次最多发送的授予结束通知数量
+const notifyBatchSize = 100
+
+// RoleGrantNotifier 在限时角色授予结束（过期或被撤销）时通知用户.
+type RoleGrantNotifier interface {
+	NotifyRoleGrantEnded(ctx context.Context, user *model.UserM, grant *model.UserRoleGrantM) error
+}
+
+// DefaultRoleGrantNotifier 是授予结束时使用的通知器，默认只记录日志，可替换为邮件、短信等实现.
+var DefaultRoleGrantNotifier RoleGrantNotifier = logNotifier{}
+
+// roleGrantWatcher 定时生效到期的限时角色授予、回收已过期的授予并通知用户.
+type roleGrantWatcher struct {
+	store store.IStore
+	biz   biz.IBiz
+}
+
+// 确保 roleGrantWatcher 实现了所需的接口.
+var (
+	_ registry.ISpec = (*roleGrantWatcher)(nil)
+	_ WantsStore     = (*roleGrantWatcher)(nil)
+	_ WantsBiz       = (*roleGrantWatcher)(nil)
+)
+
+// Run 执行一次授予的生效、回收和通知.
+func (w *roleGrantWatcher) Run() {
+	ctx := context.Background()
+	now := time.Now()
+
+	if n, err := w.biz.RoleGrantV1().ActivateDue(ctx, now); err != nil {
+		log.Errorw("Failed to activate due role grants", "err", err)
+	} else if n > 0 {
+		log.Infow("Activated role grants", "count", n)
+	}
+
+	if n, err := w.biz.RoleGrantV1().ExpireDue(ctx, now); err != nil {
+		log.Errorw("Failed to expire due role grants", "err", err)
+	} else if n > 0 {
+		log.Infow("Expired role grants", "count", n)
+	}
+
+	w.notifyEnded(ctx, now)
+}
+
+// notifyEnded 通知用户已结束且尚未通知的授予，通知成功后记录通知时间
+func (w *roleGrantWatcher) notifyEnded(ctx context.Context, now time.Time) {
+	opts := where.NewWhere().
+		Q("status IN ? AND notified_at IS NULL", []string{model.RoleGrantStatusExpired, model.RoleGrantStatusRevoked}).
+		L(notifyBatchSize)
+	_, grants, err := w.store.UserRoleGrant().List(ctx, opts)
+	if err != nil {
+		log.Errorw("Failed to list ended role grants", "err", err)
+		return
+	}
+
+	for _, grant := range grants {
+		user, err := w.store.User().Get(store.WithoutDataScope(ctx), where.F("id", grant.UserID))
+		if err != nil {
+			log.Errorw("Failed to get user of role grant", "grant_id", grant.ID, "err", err)
+			continue
+		}
+		if err := DefaultRoleGrantNotifier.NotifyRoleGrantEnded(ctx, user, grant); err != nil {
+			log.Errorw("Failed to notify role grant ended", "grant_id", grant.ID, "err", err)
+			continue
+		}
+
+		grant.NotifiedAt = &now
+		if err := w.store.UserRoleGrant().Update(ctx, grant); err != nil {
+			log.Errorw("Failed to mark role grant notified", "grant_id", grant.ID, "err", err)
+		}
+	}
+}
+
+// Spec 返回任务的执行周期.
+func (w *roleGrantWatcher) Spec() string {
+	return "@every 30s"
+}
+
+// SetStore 设置存储层.
+func (w *roleGrantWatcher) SetStore(store store.IStore) {
+	w.store = store
+}
+
+// SetBiz 设置业务层.
+func (w *roleGrantWatcher) SetBiz(biz biz.IBiz) {
+	w.biz = biz
+}
+
+// logNotifier 将授予结束的通知写入日志.
+type logNotifier struct{}
+
+// NotifyRoleGrantEnded 记录授予结束的日志.
+func (logNotifier) NotifyRoleGrantEnded(ctx context.Context, user *model.UserM, grant *model.UserRoleGrantM) error {
+	log.Infow("Role grant ended",
+		"user_id", user.ID,
+		"username", user.Username,
+		"email", user.Email,
+		"grant_id", grant.ID,
+		"tenant_id", grant.TenantID,
+		"role_id", grant.RoleID,
+		"status", grant.Status,
+	)
+	return nil
+}
+
+func init() {
+	registry.Register("rolegrant", &roleGrantWatcher{})
+}
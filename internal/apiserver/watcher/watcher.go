@@ -0,0 +1,77 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package watcher 包含 apiserver 的后台定时任务，任务通过 pkg/watch 调度，
+// 多实例部署时只有获得分布式锁的实例会执行.
+package watcher
+
+import (
+	"github.com/ashwinyue/one-auth/pkg/watch/initializer"
+	"github.com/ashwinyue/one-auth/pkg/watch/registry"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/biz"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+)
+
+// WantsStore 定义了需要存储层的任务.
+type WantsStore interface {
+	registry.Watcher
+	SetStore(store store.IStore)
+}
+
+// WantsBiz 定义了需要业务层的任务.
+type WantsBiz interface {
+	registry.Watcher
+	SetBiz(biz biz.IBiz)
+}
+
+// watcherInitializer 为任务注入 apiserver 的依赖.
+type watcherInitializer struct {
+	store store.IStore
+	biz   biz.IBiz
+}
+
+// 确保 watcherInitializer 实现了 initializer.WatcherInitializer 接口.
+var _ initializer.WatcherInitializer = (*watcherInitializer)(nil)
+
+// NewInitializer 创建为任务注入存储层和业务层依赖的初始化器.
+func NewInitializer(store store.IStore, biz biz.IBiz) initializer.WatcherInitializer {
+	return &watcherInitializer{store: store, biz: biz}
+}
+
+// Initialize 按任务实现的 Wants* 接口注入依赖.
+func (i *watcherInitializer) Initialize(wc registry.Watcher) {
+	if wants, ok := wc.(WantsStore); ok {
+		wants.SetStore(i.store)
+	}
+	if wants, ok := wc.(WantsBiz); ok {
+		wants.SetBiz(i.biz)
+	}
+}
+
+// cronLogger 将 cron 的日志输出到 apiserver 的日志中.
+type cronLogger struct{}
+
+// NewLogger 创建一个输出到 apiserver 日志的 cron 日志记录器.
+func NewLogger() *cronLogger {
+	return &cronLogger{}
+}
+
+// Debug 输出 debug 级别的日志.
+func (l *cronLogger) Debug(msg string, kvs ...any) {
+	log.Debugw(msg, kvs...)
+}
+
+// Info 输出 info 级别的日志.
+func (l *cronLogger) Info(msg string, kvs ...any) {
+	log.Infow(msg, kvs...)
+}
+
+// Error 输出 error 级别的日志.
+func (l *cronLogger) Error(err error, msg string, kvs ...any) {
+	log.Errorw(msg, append(kvs, "err", err)...)
+}
@@ -22,13 +22,16 @@ import (
 
 func InitializeWebServer(*Config) (server.Server, error) {
 	wire.Build(
-		wire.NewSet(NewWebServer, wire.FieldsOf(new(*Config), "ServerMode")),
+		wire.NewSet(NewWebServer, wire.FieldsOf(new(*Config), "ServerMode", "SMSOptions")),
 		wire.Struct(new(ServerConfig), "*"), // * 表示注入全部字段
 		wire.NewSet(store.ProviderSet, biz.ProviderSet, cache.ProviderSet),
 		ProvideDB,    // 提供数据库实例
 		ProvideRedis, // 提供Redis实例
+		ProvideSMS,   // 提供短信客户端实例
+		ProvideWatch, // 提供后台任务调度器
 		validation.ProviderSet,
-		authz.ProviderSet,
+		authz.NewAuthz,
+		ProvideAuthzOptions, // 提供授权器选项
 	)
 	return nil, nil
 }
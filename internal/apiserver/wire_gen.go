@@ -24,24 +24,39 @@ func InitializeWebServer(config *Config) (server.Server, error) {
 		return nil, err
 	}
 	datastore := store.NewStore(db)
-	v := authz.DefaultOptions()
-	authzAuthz, err := authz.NewAuthz(db, v...)
+	client, err := ProvideRedis(config)
 	if err != nil {
 		return nil, err
 	}
-	client, err := ProvideRedis(config)
+	v, err := ProvideAuthzOptions(client)
+	if err != nil {
+		return nil, err
+	}
+	authzAuthz, err := authz.NewAuthz(db, v...)
 	if err != nil {
 		return nil, err
 	}
 	dataCache := cache.NewCache(client)
-	bizBiz := biz.NewBiz(datastore, authzAuthz, dataCache)
-	validator := validation.New(datastore)
+	smsClient, err := ProvideSMS(config)
+	if err != nil {
+		return nil, err
+	}
+	bizBiz := biz.NewBiz(datastore, authzAuthz, dataCache, smsClient)
+	smsOptions := config.SMSOptions
+	validator := validation.New(datastore, smsOptions)
+	watchWatch, err := ProvideWatch(config, db, datastore, bizBiz)
+	if err != nil {
+		return nil, err
+	}
+	sessionManager := cache.NewSessionManager(dataCache)
 	serverConfig := &ServerConfig{
-		cfg:   config,
-		biz:   bizBiz,
-		val:   validator,
-		store: datastore,
-		authz: authzAuthz,
+		cfg:      config,
+		biz:      bizBiz,
+		val:      validator,
+		store:    datastore,
+		authz:    authzAuthz,
+		watch:    watchWatch,
+		sessions: sessionManager,
 	}
 	serverServer, err := NewWebServer(string2, serverConfig)
 	if err != nil {
@@ -22,6 +22,10 @@ type (
 	requestIDKey struct{}
 	// tenantIDKey 定义租户 ID 的上下文键.
 	tenantIDKey struct{}
+	// clientIPKey 定义客户端 IP 的上下文键.
+	clientIPKey struct{}
+	// serviceClientIDKey 定义服务凭证 ID 的上下文键.
+	serviceClientIDKey struct{}
 )
 
 // WithUserID 将用户 ID 存放到上下文中.
@@ -89,3 +93,25 @@ func TenantID(ctx context.Context) string {
 	tenantID, _ := ctx.Value(tenantIDKey{}).(string)
 	return tenantID
 }
+
+// WithClientIP 将客户端 IP 存放到上下文中.
+func WithClientIP(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, clientIP)
+}
+
+// ClientIP 从上下文中提取客户端 IP.
+func ClientIP(ctx context.Context) string {
+	clientIP, _ := ctx.Value(clientIPKey{}).(string)
+	return clientIP
+}
+
+// WithServiceClientID 将调用方的服务凭证 ID 存放到上下文中.
+func WithServiceClientID(ctx context.Context, clientID int64) context.Context {
+	return context.WithValue(ctx, serviceClientIDKey{}, clientID)
+}
+
+// ServiceClientID 从上下文中提取调用方的服务凭证 ID.
+func ServiceClientID(ctx context.Context) int64 {
+	clientID, _ := ctx.Value(serviceClientIDKey{}).(int64)
+	return clientID
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package errno
+
+import (
+	"net/http"
+
+	"github.com/ashwinyue/one-auth/pkg/errorsx"
+)
+
+var (
+	// 访问申请相关错误
+
+	// ErrAccessRequestNotFound 表示访问申请未找到.
+	ErrAccessRequestNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.AccessRequestNotFound", Message: "Access request not found."}
+
+	// ErrAccessRequestInvalidState 表示访问申请的状态不允许当前操作.
+	ErrAccessRequestInvalidState = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "Conflict.AccessRequestInvalidState", Message: "Access request is not in a valid state for this operation."}
+
+	// ErrAccessRequestConflict 表示用户已经拥有申请的角色或权限，或已有相同的待审批申请.
+	ErrAccessRequestConflict = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "Conflict.AccessRequestConflict", Message: "User already has the access or a pending request for it."}
+
+	// ErrAccessRequestNotApprover 表示当前用户不是该申请的审批人.
+	ErrAccessRequestNotApprover = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "Forbidden.AccessRequestNotApprover", Message: "You are not an approver of this access request."}
+
+	// ErrAccessRequestAlreadyReviewed 表示当前用户已经审批过该申请.
+	ErrAccessRequestAlreadyReviewed = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "Conflict.AccessRequestAlreadyReviewed", Message: "You have already reviewed this access request."}
+
+	// ErrAccessRequestNoApprover 表示申请的角色或权限没有可用的审批人.
+	ErrAccessRequestNoApprover = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "BadRequest.AccessRequestNoApprover", Message: "No approver is available for the requested access."}
+
+	// ErrAccessApprovalPolicyNotFound 表示审批策略未找到.
+	ErrAccessApprovalPolicyNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.AccessApprovalPolicyNotFound", Message: "Access approval policy not found."}
+)
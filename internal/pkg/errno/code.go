@@ -46,6 +46,9 @@ var (
 	// ErrTokenInvalid 表示 JWT Token 格式无效.
 	ErrTokenInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TokenInvalid", Message: "Token was invalid."}
 
+	// ErrTokenRevoked 表示 JWT Token 已被撤销，例如用户被停用或会话被踢出.
+	ErrTokenRevoked = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TokenRevoked", Message: "Token has been revoked."}
+
 	// ErrDBRead 表示数据库读取失败.
 	ErrDBRead = &errorsx.ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError.DBRead", Message: "Database read failure."}
 
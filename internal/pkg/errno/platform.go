@@ -0,0 +1,29 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package errno
+
+import (
+	"net/http"
+
+	"github.com/ashwinyue/one-auth/pkg/errorsx"
+)
+
+var (
+	// 平台运营相关错误
+
+	// ErrNotPlatformOperator 表示用户不是平台运营人员.
+	ErrNotPlatformOperator = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "Forbidden.NotPlatformOperator", Message: "Platform operator privileges required."}
+
+	// ErrPlatformOperatorExists 表示用户已经是平台运营人员.
+	ErrPlatformOperatorExists = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "Conflict.PlatformOperatorExists", Message: "User is already a platform operator."}
+
+	// ErrPlatformTenantNotFound 表示平台运营人员操作的租户不存在.
+	ErrPlatformTenantNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.TenantNotFound", Message: "Tenant not found."}
+
+	// ErrSystemRoleMissing 表示租户缺少系统内置角色.
+	ErrSystemRoleMissing = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "Conflict.SystemRoleMissing", Message: "Tenant system role is missing."}
+)
@@ -0,0 +1,29 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package errno
+
+import (
+	"net/http"
+
+	"github.com/ashwinyue/one-auth/pkg/errorsx"
+)
+
+var (
+	// 限时角色授予相关错误
+
+	// ErrRoleGrantNotFound 表示限时角色授予未找到.
+	ErrRoleGrantNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.RoleGrantNotFound", Message: "Role grant not found."}
+
+	// ErrRoleGrantInvalidState 表示限时角色授予的状态不允许当前操作.
+	ErrRoleGrantInvalidState = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "Conflict.RoleGrantInvalidState", Message: "Role grant is not in a valid state for this operation."}
+
+	// ErrRoleGrantConflict 表示用户已经拥有该角色或已有相同的申请.
+	ErrRoleGrantConflict = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "Conflict.RoleGrantConflict", Message: "User already has the role or a pending request for it."}
+
+	// ErrRoleGrantSelfReview 表示不能审批自己的申请.
+	ErrRoleGrantSelfReview = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "Forbidden.RoleGrantSelfReview", Message: "Role grant requests cannot be reviewed by the requester."}
+)
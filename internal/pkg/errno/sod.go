@@ -0,0 +1,23 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package errno
+
+import (
+	"net/http"
+
+	"github.com/ashwinyue/one-auth/pkg/errorsx"
+)
+
+var (
+	// 职责分离相关错误
+
+	// ErrSoDRuleNotFound 表示职责分离规则未找到.
+	ErrSoDRuleNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.SoDRuleNotFound", Message: "Separation of duties rule not found."}
+
+	// ErrSoDViolation 表示角色分配违反了职责分离规则.
+	ErrSoDViolation = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "Conflict.SoDViolation", Message: "Role assignment violates a separation of duties rule."}
+)
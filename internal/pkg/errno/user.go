@@ -39,4 +39,9 @@ var (
 	ErrUserInactive = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "Forbidden.UserInactive", Message: "User account is inactive"}
 	// ErrUserBanned 表示用户账户被封禁.
 	ErrUserBanned = &errorsx.ErrorX{Code: http.StatusForbidden, Reason: "Forbidden.UserBanned", Message: "User account is banned"}
+
+	// ErrSAMLConfigNotFound 表示租户没有 SAML 配置.
+	ErrSAMLConfigNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.SAMLConfigNotFound", Message: "SAML config not found."}
+	// ErrSAMLConfigExists 表示租户已有 SAML 配置.
+	ErrSAMLConfigExists = &errorsx.ErrorX{Code: http.StatusConflict, Reason: "Conflict.SAMLConfigExists", Message: "SAML config already exists for this tenant."}
 )
@@ -14,6 +14,7 @@ import (
 	"github.com/ashwinyue/one-auth/pkg/token"
 	"github.com/gin-gonic/gin"
 
+	"github.com/ashwinyue/one-auth/internal/apiserver/cache"
 	"github.com/ashwinyue/one-auth/internal/apiserver/store"
 	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
 	"github.com/ashwinyue/one-auth/internal/pkg/errno"
@@ -21,22 +22,38 @@ import (
 )
 
 // AuthnMiddleware 是一个认证中间件，用于从 gin.Context 中提取 token 并验证 token 是否合法.
-func AuthnMiddleware(userStore store.UserStore) gin.HandlerFunc {
+// 已被撤销的 token 和在租户内被停用的用户会被拒绝.
+func AuthnMiddleware(userStore store.UserStore, sessions *cache.SessionManager) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 解析 JWT Token
-		userID, err := token.ParseRequest(c)
+		claims, err := token.ParseRequestClaims(c)
 		if err != nil {
-			core.WriteResponse(c, errno.ErrTokenInvalid, nil)
+			core.WriteResponse(c, nil, errno.ErrTokenInvalid)
 			c.Abort()
 			return
 		}
+		userID := claims.Identity
 
 		log.Debugw("Token parsing successful", "userID", userID)
 
+		// 检查 token 是否已被撤销
+		revoked, err := sessions.IsTokenRevoked(c.Request.Context(), userID, claims.IssuedAt)
+		if err != nil {
+			log.Errorw("Failed to check token revocation", "userID", userID, "err", err)
+			core.WriteResponse(c, nil, errno.ErrInternal)
+			c.Abort()
+			return
+		}
+		if revoked {
+			core.WriteResponse(c, nil, errno.ErrTokenRevoked)
+			c.Abort()
+			return
+		}
+
 		// 获取用户信息
 		user, err := userStore.Get(c.Request.Context(), where.F("id", userID))
 		if err != nil {
-			core.WriteResponse(c, errno.ErrUnauthenticated, nil)
+			core.WriteResponse(c, nil, errno.ErrUnauthenticated)
 			c.Abort()
 			return
 		}
@@ -49,6 +66,22 @@ func AuthnMiddleware(userStore store.UserStore) gin.HandlerFunc {
 			tenantID = 0
 		}
 
+		// 检查用户在租户内是否被停用
+		if tenantID > 0 {
+			active, err := userStore.IsUserActive(c.Request.Context(), userID, tenantID)
+			if err != nil {
+				log.Errorw("Failed to check user status", "userID", userID, "tenantID", tenantID, "err", err)
+				core.WriteResponse(c, nil, errno.ErrInternal)
+				c.Abort()
+				return
+			}
+			if !active {
+				core.WriteResponse(c, nil, errno.ErrUnauthenticated.WithMessage("user is disabled"))
+				c.Abort()
+				return
+			}
+		}
+
 		// 将用户信息存入上下文
 		c.Set("userID", userID)
 		c.Set("username", user.Username)
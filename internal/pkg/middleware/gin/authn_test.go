@@ -0,0 +1,101 @@
+package gin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+	"github.com/ashwinyue/one-auth/pkg/token"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/cache"
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+)
+
+type fakeCache struct {
+	cache.ICache
+	data map[string]string
+}
+
+func (f *fakeCache) Set(_ context.Context, key string, value any, _ time.Duration) error {
+	f.data[key] = value.(string)
+	return nil
+}
+
+func (f *fakeCache) Get(_ context.Context, key string) (string, error) {
+	if value, ok := f.data[key]; ok {
+		return value, nil
+	}
+	return "", redis.Nil
+}
+
+type fakeUserStore struct {
+	store.UserStore
+	active bool
+}
+
+func (f *fakeUserStore) Get(_ context.Context, opts *where.Options) (*model.UserM, error) {
+	id, _ := strconv.ParseInt(opts.Filters["id"].(string), 10, 64)
+	return &model.UserM{ID: id, Username: "alice"}, nil
+}
+
+func (f *fakeUserStore) GetUserTenantID(context.Context, string) (int64, error) {
+	return 2, nil
+}
+
+func (f *fakeUserStore) IsUserActive(context.Context, string, int64) (bool, error) {
+	return f.active, nil
+}
+
+func TestAuthnMiddleware_Deprovisioned(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	users := &fakeUserStore{active: true}
+	sessions := cache.NewSessionManager(&fakeCache{data: map[string]string{}})
+
+	engine := gin.New()
+	engine.GET("/v1/users", AuthnMiddleware(users, sessions), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tokenStr, _, err := token.Sign("1")
+	require.NoError(t, err)
+	do := func() *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+		r.Header.Set("Authorization", "Bearer "+tokenStr)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, r)
+		return w
+	}
+	assert.Equal(t, http.StatusOK, do().Code)
+
+	// SCIM 停用用户后旧令牌被拒绝
+	users.active = false
+	w := do()
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), errno.ErrUnauthenticated.Reason)
+
+	// SCIM 删除用户时撤销的令牌被拒绝
+	users.active = true
+	require.NoError(t, sessions.RevokeUserTokens(context.Background(), "1"))
+	w = do()
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), errno.ErrTokenRevoked.Reason)
+
+	// 其他用户的令牌不受影响
+	other, _, err := token.Sign("3")
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+	r.Header.Set("Authorization", "Bearer "+other)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -7,11 +7,16 @@
 package gin
 
 import (
+	"context"
+	"maps"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/ashwinyue/one-auth/pkg/authz"
 	"github.com/ashwinyue/one-auth/pkg/core"
+	"github.com/ashwinyue/one-auth/pkg/errorsx"
 
 	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
 	"github.com/ashwinyue/one-auth/internal/pkg/errno"
@@ -30,19 +35,53 @@ type APIAuthorizer interface {
 	CheckAPIAccess(subject, domain, object, action string) (bool, error)
 }
 
+// RouteAuthorizer 是基于 gin 路由模板和请求上下文的权限检查接口
+type RouteAuthorizer interface {
+	// 使用路由模板进行授权检查，返回决定结果的规则
+	CheckRouteAccess(subject, domain string, rc *authz.RequestContext) (*authz.Decision, error)
+}
+
+// DecisionRecorder 用于记录授权决策，返回的决策ID随拒绝响应返回，便于之后查询决策轨迹
+type DecisionRecorder interface {
+	RecordDecision(subject, domain string, rc *authz.RequestContext, decision *authz.Decision, err error) string
+}
+
+// ResourceLoader 根据路径参数加载被访问的资源，返回权限条件求值使用的资源属性（例如 owner_id），资源不存在时返回 nil
+type ResourceLoader func(ctx context.Context, params gin.Params) (map[string]any, error)
+
+// authzDecisionKey 是授权决策在 gin.Context 中的键
+const authzDecisionKey = "authz.decision"
+
+// AuthzDecision 返回中间件记录的授权决策.
+func AuthzDecision(c *gin.Context) *authz.Decision {
+	if v, ok := c.Get(authzDecisionKey); ok {
+		if decision, ok := v.(*authz.Decision); ok {
+			return decision
+		}
+	}
+	return nil
+}
+
 // AuthzMiddleware 是一个 Gin 中间件，用于进行请求授权.
-func AuthzMiddleware(authorizer Authorizer) gin.HandlerFunc {
+// loaders 按路由模板加载被访问的资源，加载到的属性覆盖同名的路径参数，供权限条件求值.
+func AuthzMiddleware(authorizer Authorizer, loaders map[string]ResourceLoader) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		subject := strconv.FormatInt(contextx.UserID(c.Request.Context()), 10)
 		domain := contextx.TenantID(c.Request.Context()) // 获取租户ID作为domain
-		object := c.Request.URL.Path
+		// 使用路由模板（如 /v1/users/:userID）进行授权，未匹配到路由时退回请求路径
+		object := c.FullPath()
+		if object == "" {
+			object = c.Request.URL.Path
+		}
 		action := c.Request.Method
 
 		// 权限检查不再跳过任何路径，所有通过认证的接口都需要权限验证
 
-		// 如果没有租户ID，使用默认租户
+		// 无法确定租户时拒绝访问，不退回到默认租户
 		if domain == "" {
-			domain = "default"
+			core.WriteResponse(c, nil, errno.ErrPermissionDenied.WithMessage("tenant could not be resolved"))
+			c.Abort()
+			return
 		}
 
 		// 记录授权上下文信息
@@ -52,19 +91,45 @@ func AuthzMiddleware(authorizer Authorizer) gin.HandlerFunc {
 			"object", object,
 			"action", action)
 
-		// 首先检查是否是基于API路径的权限检查
-		if apiAuthorizer, ok := authorizer.(APIAuthorizer); ok {
+		// 优先使用基于路由模板的权限检查
+		if routeAuthorizer, ok := authorizer.(RouteAuthorizer); ok {
+			rc := requestContext(c, object)
+			if load, ok := loaders[object]; ok {
+				attrs, err := load(c.Request.Context(), c.Params)
+				if err != nil {
+					log.Errorw("Failed to load resource for authorization", "object", object, "err", err)
+					core.WriteResponse(c, nil, errno.ErrInternal)
+					c.Abort()
+					return
+				}
+				maps.Copy(rc.Resource, attrs)
+			}
+			decision, err := routeAuthorizer.CheckRouteAccess(subject, domain, rc)
+			if err != nil || !decision.Allowed {
+				denied := accessDenied()
+				// 附带决策ID，可通过 explain 接口查询本次决策的轨迹
+				var decisionID string
+				if recorder, ok := authorizer.(DecisionRecorder); ok {
+					if decisionID = recorder.RecordDecision(subject, domain, rc, decision, err); decisionID != "" {
+						denied.KV("decision_id", decisionID)
+					}
+				}
+				log.Warnw("Authorization denied", "subject", subject, "domain", domain, "object", object, "action", action,
+					"decision", decision.String(), "decision_id", decisionID, "err", err)
+				core.WriteResponse(c, nil, denied)
+				c.Abort()
+				return
+			}
+
+			// 记录决定结果的规则，便于排查授权问题
+			c.Set(authzDecisionKey, decision)
+			log.Debugw("Authorize decision", "subject", subject, "object", object, "action", action, "rule", decision.String())
+		} else if apiAuthorizer, ok := authorizer.(APIAuthorizer); ok {
 			// 使用API权限检查
 			allowed, err := apiAuthorizer.CheckAPIAccess(subject, domain, object, action)
 			if err != nil || !allowed {
-				core.WriteResponse(c, nil, errno.ErrPermissionDenied.WithMessage(
-					"access denied: subject=%s, domain=%s, object=%s, action=%s, reason=%v",
-					subject,
-					domain,
-					object,
-					action,
-					err,
-				))
+				log.Warnw("Authorization denied", "subject", subject, "domain", domain, "object", object, "action", action, "err", err)
+				core.WriteResponse(c, nil, accessDenied())
 				c.Abort()
 				return
 			}
@@ -72,14 +137,8 @@ func AuthzMiddleware(authorizer Authorizer) gin.HandlerFunc {
 			// 使用传统的domain授权检查
 			allowed, err := authorizer.AuthorizeWithDomain(subject, domain, object, action)
 			if err != nil || !allowed {
-				core.WriteResponse(c, nil, errno.ErrPermissionDenied.WithMessage(
-					"access denied: subject=%s, domain=%s, object=%s, action=%s, reason=%v",
-					subject,
-					domain,
-					object,
-					action,
-					err,
-				))
+				log.Warnw("Authorization denied", "subject", subject, "domain", domain, "object", object, "action", action, "err", err)
+				core.WriteResponse(c, nil, accessDenied())
 				c.Abort()
 				return
 			}
@@ -88,3 +147,29 @@ func AuthzMiddleware(authorizer Authorizer) gin.HandlerFunc {
 		c.Next() // 继续处理请求
 	}
 }
+
+// accessDenied 返回不包含决策依据的拒绝错误，决策依据只记录在服务端日志中
+func accessDenied() *errorsx.ErrorX {
+	return errorsx.New(errno.ErrPermissionDenied.Code, errno.ErrPermissionDenied.Reason, "access denied")
+}
+
+// requestContext 构建权限条件求值使用的请求上下文，路径参数作为资源属性
+func requestContext(c *gin.Context, route string) *authz.RequestContext {
+	resource := make(map[string]any, len(c.Params))
+	for _, param := range c.Params {
+		if n, err := strconv.ParseInt(param.Value, 10, 64); err == nil {
+			resource[param.Key] = n
+			continue
+		}
+		resource[param.Key] = param.Value
+	}
+
+	return &authz.RequestContext{
+		IP:       c.ClientIP(),
+		Method:   c.Request.Method,
+		Path:     c.Request.URL.Path,
+		Route:    route,
+		Time:     time.Now(),
+		Resource: resource,
+	}
+}
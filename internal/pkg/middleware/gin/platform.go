@@ -0,0 +1,99 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package gin
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ashwinyue/one-auth/pkg/core"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+)
+
+// maxAuditBodySize 是审计时读取错误响应的最大长度
+const maxAuditBodySize = 4096
+
+// PlatformOperatorMiddleware 只允许平台运营人员访问，并为每个请求（包括被拒绝的请求）记录审计日志.
+// 需要在认证中间件之后使用.
+func PlatformOperatorMiddleware(operators store.PlatformOperatorStore, auditLogs store.PlatformAuditLogStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writer := &auditResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer recordPlatformAudit(c, auditLogs, writer)
+
+		ctx := c.Request.Context()
+		isOperator, err := operators.IsOperator(ctx, contextx.UserID(ctx))
+		if err != nil {
+			core.WriteResponse(c, nil, errno.ErrDBRead.WithMessage(err.Error()))
+			c.Abort()
+			return
+		}
+		if !isOperator {
+			core.WriteResponse(c, nil, errno.ErrNotPlatformOperator)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// recordPlatformAudit 写入一条平台操作审计日志，写入失败只记录日志，不影响响应
+func recordPlatformAudit(c *gin.Context, auditLogs store.PlatformAuditLogStore, writer *auditResponseWriter) {
+	ctx := c.Request.Context()
+	logM := &model.PlatformAuditLogM{
+		OperatorID: contextx.UserID(ctx),
+		Method:     c.Request.Method,
+		Route:      c.FullPath(),
+		Path:       c.Request.URL.Path,
+		StatusCode: int32(writer.Status()),
+		RequestID:  contextx.RequestID(ctx),
+		ClientIP:   c.ClientIP(),
+	}
+	logM.TenantID, _ = strconv.ParseInt(c.Param("tenantID"), 10, 64)
+	if message := writer.errorMessage(); message != "" {
+		logM.Message = &message
+	}
+
+	if err := auditLogs.Create(ctx, logM); err != nil {
+		log.W(ctx).Errorw("Failed to write platform audit log", "route", logM.Route, "err", err)
+	}
+}
+
+// auditResponseWriter 保留错误响应的内容，用于在审计日志中记录失败原因
+type auditResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write 写入响应，状态码为错误时同时保留响应内容
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	if w.Status() >= 400 && w.body.Len() < maxAuditBodySize {
+		w.body.Write(data[:min(len(data), maxAuditBodySize-w.body.Len())])
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// errorMessage 返回错误响应中的错误信息
+func (w *auditResponseWriter) errorMessage() string {
+	if w.body.Len() == 0 {
+		return ""
+	}
+	var resp core.ErrorResponse
+	if err := json.Unmarshal(w.body.Bytes(), &resp); err != nil {
+		return ""
+	}
+	return resp.Message
+}
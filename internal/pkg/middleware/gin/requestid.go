@@ -27,6 +27,8 @@ func RequestIDMiddleware() gin.HandlerFunc {
 
 		// 将 RequestID 保存到 context.Context 中，以便后续程序使用
 		ctx := contextx.WithRequestID(c.Request.Context(), requestID)
+		// 同时保存客户端 IP，供权限条件等业务逻辑使用
+		ctx = contextx.WithClientIP(ctx, c.ClientIP())
 		c.Request = c.Request.WithContext(ctx)
 
 		// 将 RequestID 保存到 HTTP 返回头中，Header 的键为 `x-request-id`
@@ -0,0 +1,65 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashwinyue/one-auth/pkg/scim"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+	"github.com/gin-gonic/gin"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+)
+
+// SCIMAuthnMiddleware 校验 SCIM 客户端的租户级 Bearer 令牌，并将令牌所属租户存入上下文.
+func SCIMAuthnMiddleware(tokenStore store.TenantSCIMTokenStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		secret, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || secret == "" {
+			abortSCIM(c, "missing bearer token")
+			return
+		}
+
+		ctx := c.Request.Context()
+		tokenM, err := tokenStore.Get(ctx, where.F("token_hash", scim.HashToken(strings.TrimSpace(secret))))
+		if err != nil {
+			abortSCIM(c, "invalid bearer token")
+			return
+		}
+		now := time.Now()
+		if tokenM.ExpiresAt != nil && now.After(*tokenM.ExpiresAt) {
+			abortSCIM(c, "bearer token expired")
+			return
+		}
+
+		if err := tokenStore.TouchLastUsed(ctx, tokenM.ID, now); err != nil {
+			log.W(ctx).Errorw("Failed to update SCIM token last used time", "token_id", tokenM.ID, "err", err)
+		}
+
+		tenantID := strconv.FormatInt(tokenM.TenantID, 10)
+		c.Set("tenantID", tenantID)
+		c.Request = c.Request.WithContext(contextx.WithTenantID(ctx, tenantID))
+
+		c.Next()
+	}
+}
+
+// abortSCIM 以 SCIM 错误格式返回 401
+func abortSCIM(c *gin.Context, detail string) {
+	data, _ := json.Marshal(scim.NewError(http.StatusUnauthorized, "", "%s", detail))
+	c.Header("WWW-Authenticate", `Bearer realm="scim"`)
+	c.Data(http.StatusUnauthorized, scim.ContentType, data)
+	c.Abort()
+}
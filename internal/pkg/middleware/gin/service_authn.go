@@ -0,0 +1,64 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package gin
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashwinyue/one-auth/pkg/authn"
+	"github.com/ashwinyue/one-auth/pkg/core"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+	"github.com/gin-gonic/gin"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+)
+
+// ServiceAuthnMiddleware 校验其他微服务的服务凭证，并将凭证ID和限定的租户存入上下文.
+func ServiceAuthnMiddleware(clientStore store.ServiceClientStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || strings.TrimSpace(secret) == "" {
+			core.WriteResponse(c, nil, errno.ErrUnauthenticated.WithMessage("missing service token"))
+			c.Abort()
+			return
+		}
+
+		ctx := c.Request.Context()
+		client, err := clientStore.Get(ctx, where.F("token_hash", authn.HashServiceToken(strings.TrimSpace(secret))))
+		if err != nil {
+			core.WriteResponse(c, nil, errno.ErrUnauthenticated.WithMessage("invalid service token"))
+			c.Abort()
+			return
+		}
+		now := time.Now()
+		if client.ExpiresAt != nil && now.After(*client.ExpiresAt) {
+			core.WriteResponse(c, nil, errno.ErrUnauthenticated.WithMessage("service token expired"))
+			c.Abort()
+			return
+		}
+
+		// 策略决策点调用频繁，最后使用时间最多每分钟更新一次
+		if client.LastUsedAt == nil || now.Sub(*client.LastUsedAt) > time.Minute {
+			if err := clientStore.TouchLastUsed(ctx, client.ID, now); err != nil {
+				log.W(ctx).Errorw("Failed to update service client last used time", "client_id", client.ID, "err", err)
+			}
+		}
+
+		ctx = contextx.WithServiceClientID(ctx, client.ID)
+		if client.TenantID > 0 {
+			ctx = contextx.WithTenantID(ctx, strconv.FormatInt(client.TenantID, 10))
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,64 @@
+package gin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ashwinyue/one-auth/pkg/authn"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/model"
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+)
+
+type fakeServiceClientStore struct {
+	store.ServiceClientStore
+	clients map[string]*model.ServiceClientM
+}
+
+func (f *fakeServiceClientStore) Get(_ context.Context, opts *where.Options) (*model.ServiceClientM, error) {
+	if client, ok := f.clients[opts.Filters["token_hash"].(string)]; ok {
+		return client, nil
+	}
+	return nil, errno.ErrNotFound
+}
+
+func (f *fakeServiceClientStore) TouchLastUsed(context.Context, int64, time.Time) error {
+	return nil
+}
+
+func TestServiceAuthnMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	expired := time.Now().Add(-time.Hour)
+	clientStore := &fakeServiceClientStore{clients: map[string]*model.ServiceClientM{
+		authn.HashServiceToken("svc_valid"):   {ID: 1},
+		authn.HashServiceToken("svc_expired"): {ID: 2, ExpiresAt: &expired},
+	}}
+
+	engine := gin.New()
+	engine.POST("/v1/pdp/check", ServiceAuthnMiddleware(clientStore), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for authorization, code := range map[string]int{
+		"":                   http.StatusUnauthorized,
+		"Bearer svc_invalid": http.StatusUnauthorized,
+		"Bearer svc_expired": http.StatusUnauthorized,
+		"Bearer svc_valid":   http.StatusOK,
+	} {
+		r := httptest.NewRequest(http.MethodPost, "/v1/pdp/check", nil)
+		r.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, r)
+		assert.Equal(t, code, w.Code, authorization)
+		if code != http.StatusOK {
+			assert.Contains(t, w.Body.String(), errno.ErrUnauthenticated.Reason, authorization)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ashwinyue/one-auth/pkg/token"
 	"google.golang.org/grpc"
 
+	"github.com/ashwinyue/one-auth/internal/apiserver/cache"
 	"github.com/ashwinyue/one-auth/internal/apiserver/store"
 	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
 	"github.com/ashwinyue/one-auth/internal/pkg/errno"
@@ -22,17 +23,29 @@ import (
 )
 
 // AuthnInterceptor 是一个 gRPC 拦截器，用于进行认证.
-func AuthnInterceptor(userStore store.UserStore) grpc.UnaryServerInterceptor {
+// 已被撤销的 token 和在租户内被停用的用户会被拒绝.
+func AuthnInterceptor(userStore store.UserStore, sessions *cache.SessionManager) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		// 解析 JWT Token
-		userID, err := token.ParseRequest(ctx)
+		claims, err := token.ParseRequestClaims(ctx)
 		if err != nil {
 			log.Errorw("Failed to parse request", "err", err)
 			return nil, errno.ErrTokenInvalid.WithMessage(err.Error())
 		}
+		userID := claims.Identity
 
 		log.Debugw("Token parsing successful", "userID", userID)
 
+		// 检查 token 是否已被撤销
+		revoked, err := sessions.IsTokenRevoked(ctx, userID, claims.IssuedAt)
+		if err != nil {
+			log.Errorw("Failed to check token revocation", "userID", userID, "err", err)
+			return nil, errno.ErrInternal
+		}
+		if revoked {
+			return nil, errno.ErrTokenRevoked
+		}
+
 		// 获取用户信息
 		user, err := userStore.Get(ctx, where.F("id", userID))
 		if err != nil {
@@ -41,10 +54,19 @@ func AuthnInterceptor(userStore store.UserStore) grpc.UnaryServerInterceptor {
 
 		// 获取用户的租户ID
 		tenantID, err := userStore.GetUserTenantID(ctx, userID)
-		if err != nil {
+		if err != nil || tenantID <= 0 {
 			log.Errorw("Failed to get user tenant ID", "userID", userID, "err", err)
-			// 租户ID获取失败不阻止认证，但需要记录日志
-			tenantID = 0
+			return nil, errno.ErrUnauthenticated.WithMessage("user does not belong to any tenant")
+		}
+
+		// 检查用户在租户内是否被停用
+		active, err := userStore.IsUserActive(ctx, userID, tenantID)
+		if err != nil {
+			log.Errorw("Failed to check user status", "userID", userID, "tenantID", tenantID, "err", err)
+			return nil, errno.ErrInternal
+		}
+		if !active {
+			return nil, errno.ErrUnauthenticated.WithMessage("user is disabled")
 		}
 
 		// 将用户信息存入上下文
@@ -56,9 +78,7 @@ func AuthnInterceptor(userStore store.UserStore) grpc.UnaryServerInterceptor {
 		// 供 log 和 contextx 使用
 		ctx = contextx.WithUserID(ctx, user.ID)
 		ctx = contextx.WithUsername(ctx, user.Username)
-		if tenantID > 0 {
-			ctx = contextx.WithTenantID(ctx, strconv.FormatInt(tenantID, 10))
-		}
+		ctx = contextx.WithTenantID(ctx, strconv.FormatInt(tenantID, 10))
 
 		// 继续处理请求
 		return handler(ctx, req)
@@ -8,59 +8,88 @@ package grpc
 
 import (
 	"context"
-	"fmt"
+	"net"
+	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
 
 	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
 	"github.com/ashwinyue/one-auth/internal/pkg/errno"
 	"github.com/ashwinyue/one-auth/internal/pkg/log"
+	"github.com/ashwinyue/one-auth/pkg/authz"
+	"github.com/ashwinyue/one-auth/pkg/errorsx"
 )
 
-// Authorizer 用于定义授权接口的实现.
-type Authorizer interface {
-	// 使用domain进行授权检查
-	AuthorizeWithDomain(subject, domain, object, action string) (bool, error)
+// MethodAuthorizer 按 proto 中 (oneauth.permission) 声明的权限编码进行授权.
+type MethodAuthorizer interface {
+	// CheckMethodAccess 检查 gRPC 方法的访问权限，返回决定结果的规则
+	CheckMethodAccess(subject, domain, fullMethod string, rc *authz.RequestContext) (*authz.Decision, error)
+}
+
+// DecisionRecorder 用于记录授权决策，返回的决策ID随拒绝响应返回，便于之后查询决策轨迹
+type DecisionRecorder interface {
+	RecordDecision(subject, domain string, rc *authz.RequestContext, decision *authz.Decision, err error) string
 }
 
 // AuthzInterceptor 是一个 gRPC 拦截器，用于进行请求授权.
-func AuthzInterceptor(authorizer Authorizer) grpc.UnaryServerInterceptor {
+// 与 HTTP 接口使用相同的权限编码，未声明权限编码的方法一律拒绝.
+func AuthzInterceptor(authorizer MethodAuthorizer) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		userID := contextx.UserID(ctx)   // 获取用户ID
-		domain := contextx.TenantID(ctx) // 获取租户ID作为domain
-		object := info.FullMethod        // 获取请求资源
-		action := "CALL"                 // 默认操作
-
-		// 构建用户标识符
-		subject := fmt.Sprintf("u%d", userID)
+		subject := strconv.FormatInt(contextx.UserID(ctx), 10) // 获取用户ID
+		domain := contextx.TenantID(ctx)                       // 获取租户ID作为domain
+		object := info.FullMethod                              // 获取请求资源
 
-		// 如果没有租户ID，使用默认租户
+		// 无法确定租户时拒绝访问，不退回到默认租户
 		if domain == "" {
-			domain = "default"
+			return nil, errno.ErrPermissionDenied.WithMessage("tenant could not be resolved")
 		}
 
 		// 记录授权上下文信息
 		log.Debugw("Build authorize context",
 			"subject", subject,
 			"domain", domain,
-			"object", object,
-			"action", action)
+			"object", object)
 
-		// 使用domain进行授权检查
-		allowed, err := authorizer.AuthorizeWithDomain(subject, domain, object, action)
-
-		if err != nil || !allowed {
-			return nil, errno.ErrPermissionDenied.WithMessage(
-				"access denied: subject=%s, domain=%s, object=%s, action=%s, reason=%v",
-				subject,
-				domain,
-				object,
-				action,
-				err,
-			)
+		rc := &authz.RequestContext{
+			IP:     peerIP(ctx),
+			Method: "CALL",
+			Path:   object,
+			Route:  object,
+			Time:   time.Now(),
+		}
+		decision, err := authorizer.CheckMethodAccess(subject, domain, object, rc)
+		if err != nil || !decision.Allowed {
+			// 决策依据只记录在服务端日志中，响应只附带决策ID，可通过 explain 接口查询本次决策的轨迹
+			denied := errorsx.New(errno.ErrPermissionDenied.Code, errno.ErrPermissionDenied.Reason, "access denied")
+			var decisionID string
+			if recorder, ok := authorizer.(DecisionRecorder); ok {
+				if decisionID = recorder.RecordDecision(subject, domain, rc, decision, err); decisionID != "" {
+					denied.KV("decision_id", decisionID)
+				}
+			}
+			log.Warnw("Authorization denied", "subject", subject, "domain", domain, "object", object,
+				"decision", decision.String(), "decision_id", decisionID, "err", err)
+			return nil, denied
 		}
 
+		log.Debugw("Authorize decision", "subject", subject, "object", object, "rule", decision.String())
+
 		// 继续处理请求
 		return handler(ctx, req)
 	}
 }
+
+// peerIP 返回客户端IP，用于权限条件求值
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashwinyue/one-auth/pkg/authn"
+	"github.com/ashwinyue/one-auth/pkg/store/where"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ashwinyue/one-auth/internal/apiserver/store"
+	"github.com/ashwinyue/one-auth/internal/pkg/contextx"
+	"github.com/ashwinyue/one-auth/internal/pkg/errno"
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+)
+
+// ServiceAuthnInterceptor 是一个 gRPC 拦截器，校验其他微服务的服务凭证.
+// 凭证从 authorization 元数据中读取，格式为 Bearer <token>.
+func ServiceAuthnInterceptor(clientStore store.ServiceClientStore) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var secret string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				secret, _ = strings.CutPrefix(values[0], "Bearer ")
+			}
+		}
+		if strings.TrimSpace(secret) == "" {
+			return nil, errno.ErrUnauthenticated.WithMessage("missing service token")
+		}
+
+		client, err := clientStore.Get(ctx, where.F("token_hash", authn.HashServiceToken(strings.TrimSpace(secret))))
+		if err != nil {
+			return nil, errno.ErrUnauthenticated.WithMessage("invalid service token")
+		}
+		now := time.Now()
+		if client.ExpiresAt != nil && now.After(*client.ExpiresAt) {
+			return nil, errno.ErrUnauthenticated.WithMessage("service token expired")
+		}
+
+		// 策略决策点调用频繁，最后使用时间最多每分钟更新一次
+		if client.LastUsedAt == nil || now.Sub(*client.LastUsedAt) > time.Minute {
+			if err := clientStore.TouchLastUsed(ctx, client.ID, now); err != nil {
+				log.W(ctx).Errorw("Failed to update service client last used time", "client_id", client.ID, "err", err)
+			}
+		}
+
+		ctx = contextx.WithServiceClientID(ctx, client.ID)
+		if client.TenantID > 0 {
+			ctx = contextx.WithTenantID(ctx, strconv.FormatInt(client.TenantID, 10))
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,80 @@
+// 访问申请和审批策略 API 定义
+
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Code generated by protoc-gen-defaults. DO NOT EDIT.
+
+package v1
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	_ *timestamppb.Timestamp
+	_ *durationpb.Duration
+	_ *wrapperspb.BoolValue
+)
+
+func (x *AccessRequest) Default() {
+}
+
+func (x *AccessRequestReview) Default() {
+}
+
+func (x *CreateAccessRequestRequest) Default() {
+}
+
+func (x *CreateAccessRequestResponse) Default() {
+}
+
+func (x *GetAccessRequestRequest) Default() {
+}
+
+func (x *GetAccessRequestResponse) Default() {
+}
+
+func (x *ListAccessRequestsRequest) Default() {
+}
+
+func (x *ListAccessRequestsResponse) Default() {
+}
+
+func (x *ReviewAccessRequestRequest) Default() {
+}
+
+func (x *ReviewAccessRequestResponse) Default() {
+}
+
+func (x *CancelAccessRequestRequest) Default() {
+}
+
+func (x *CancelAccessRequestResponse) Default() {
+}
+
+func (x *AccessApprovalPolicy) Default() {
+}
+
+func (x *ListAccessApprovalPoliciesRequest) Default() {
+}
+
+func (x *ListAccessApprovalPoliciesResponse) Default() {
+}
+
+func (x *SetAccessApprovalPolicyRequest) Default() {
+}
+
+func (x *SetAccessApprovalPolicyResponse) Default() {
+}
+
+func (x *DeleteAccessApprovalPolicyRequest) Default() {
+}
+
+func (x *DeleteAccessApprovalPolicyResponse) Default() {
+}
@@ -15,6 +15,7 @@
 package v1
 
 import (
+	_ "github.com/ashwinyue/one-auth/pkg/api/oneauth"
 	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
@@ -43,174 +44,317 @@ var file_apiserver_v1_apiserver_proto_rawDesc = []byte{
 	0x74, 0x68, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x61, 0x70, 0x69, 0x73, 0x65,
 	0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x1a, 0x17, 0x61, 0x70, 0x69, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31,
-	0x2f, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76,
-	0x32, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xa0, 0x0e, 0x0a, 0x08,
-	0x4d, 0x69, 0x6e, 0x69, 0x42, 0x6c, 0x6f, 0x67, 0x12, 0x76, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x6c,
-	0x74, 0x68, 0x7a, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x76, 0x31,
-	0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x7a, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x3e, 0x92, 0x41, 0x2b, 0x0a, 0x0c, 0xe6, 0x9c, 0x8d, 0xe5, 0x8a, 0xa1, 0xe6, 0xb2, 0xbb,
-	0xe7, 0x90, 0x86, 0x12, 0x12, 0xe6, 0x9c, 0x8d, 0xe5, 0x8a, 0xa1, 0xe5, 0x81, 0xa5, 0xe5, 0xba,
-	0xb7, 0xe6, 0xa3, 0x80, 0xe6, 0x9f, 0xa5, 0x2a, 0x07, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x7a,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0a, 0x12, 0x08, 0x2f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x7a,
-	0x12, 0x65, 0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x10, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
-	0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x31,
-	0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x37,
-	0x92, 0x41, 0x23, 0x0a, 0x0c, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90,
-	0x86, 0x12, 0x0c, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0x99, 0xbb, 0xe5, 0xbd, 0x95, 0x2a,
-	0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x3a, 0x01, 0x2a, 0x22,
-	0x06, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x89, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x66, 0x72,
-	0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
-	0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x46, 0x92, 0x41, 0x2a,
-	0x0a, 0x0c, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x0c,
-	0xe5, 0x88, 0xb7, 0xe6, 0x96, 0xb0, 0xe4, 0xbb, 0xa4, 0xe7, 0x89, 0x8c, 0x2a, 0x0c, 0x52, 0x65,
-	0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13,
+	0x2f, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x61, 0x70, 0x69,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e,
+	0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x32, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x6f, 0x6e, 0x65, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x61, 0x6e,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32,
+	0xc5, 0x1d, 0x0a, 0x08, 0x4d, 0x69, 0x6e, 0x69, 0x42, 0x6c, 0x6f, 0x67, 0x12, 0x7a, 0x0a, 0x07,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x7a, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x13, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x7a, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x42, 0x92, 0x41, 0x2b, 0x0a, 0x0c, 0xe6, 0x9c, 0x8d, 0xe5, 0x8a,
+	0xa1, 0xe6, 0xb2, 0xbb, 0xe7, 0x90, 0x86, 0x12, 0x12, 0xe6, 0x9c, 0x8d, 0xe5, 0x8a, 0xa1, 0xe5,
+	0x81, 0xa5, 0xe5, 0xba, 0xb7, 0xe6, 0xa3, 0x80, 0xe6, 0x9f, 0xa5, 0x2a, 0x07, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x7a, 0xd0, 0xf3, 0x18, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0a, 0x12, 0x08,
+	0x2f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x7a, 0x12, 0x69, 0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x69,
+	0x6e, 0x12, 0x10, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3b, 0x92, 0x41, 0x23, 0x0a, 0x0c, 0xe7, 0x94, 0xa8,
+	0xe6, 0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x0c, 0xe7, 0x94, 0xa8, 0xe6, 0x88,
+	0xb7, 0xe7, 0x99, 0xbb, 0xe5, 0xbd, 0x95, 0x2a, 0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0xd0, 0xf3,
+	0x18, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x3a, 0x01, 0x2a, 0x22, 0x06, 0x2f, 0x6c, 0x6f,
+	0x67, 0x69, 0x6e, 0x12, 0x8d, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73,
+	0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4a, 0x92, 0x41, 0x2a, 0x0a, 0x0c, 0xe7, 0x94,
+	0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x0c, 0xe5, 0x88, 0xb7, 0xe6,
+	0x96, 0xb0, 0xe4, 0xbb, 0xa4, 0xe7, 0x89, 0x8c, 0x2a, 0x0c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73,
+	0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0xd0, 0xf3, 0x18, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13,
 	0x3a, 0x01, 0x2a, 0x1a, 0x0e, 0x2f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x2d, 0x74, 0x6f,
-	0x6b, 0x65, 0x6e, 0x12, 0xa5, 0x01, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61,
+	0x6b, 0x65, 0x6e, 0x12, 0xb7, 0x01, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61,
 	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6e,
 	0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x1a, 0x1a, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73,
-	0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5c, 0x92,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6e, 0x92,
 	0x41, 0x2c, 0x0a, 0x0c, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86,
 	0x12, 0x0c, 0xe4, 0xbf, 0xae, 0xe6, 0x94, 0xb9, 0xe5, 0xaf, 0x86, 0xe7, 0xa0, 0x81, 0x2a, 0x0e,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x27, 0x3a, 0x01, 0x2a, 0x1a, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65,
-	0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x7d, 0x2f, 0x63, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x2d, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x7c, 0x0a, 0x0a, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3f, 0x92, 0x41, 0x28, 0x0a, 0x0c, 0xe7,
-	0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x0c, 0xe5, 0x88, 0x9b,
-	0xe5, 0xbb, 0xba, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0x2a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x55, 0x73, 0x65, 0x72, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x3a, 0x01, 0x2a, 0x22, 0x09,
-	0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x8b, 0x01, 0x0a, 0x0a, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x16, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4e, 0x92, 0x41, 0x2e, 0x0a, 0x0c, 0xe7, 0x94,
-	0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x12, 0xe6, 0x9b, 0xb4, 0xe6,
-	0x96, 0xb0, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe4, 0xbf, 0xa1, 0xe6, 0x81, 0xaf, 0x2a, 0x0a,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17,
-	0x3a, 0x01, 0x2a, 0x1a, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x2f, 0x7b,
-	0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x7d, 0x12, 0x82, 0x01, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
-	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x45, 0x92, 0x41, 0x28, 0x0a, 0x0c, 0xe7, 0x94, 0xa8, 0xe6,
-	0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x0c, 0xe5, 0x88, 0xa0, 0xe9, 0x99, 0xa4,
-	0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0x2a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73,
-	0x65, 0x72, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x2a, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73,
-	0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x7d, 0x12, 0x7c, 0x0a, 0x07,
-	0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
-	0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x76, 0x31,
-	0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x48, 0x92, 0x41, 0x2b, 0x0a, 0x0c, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0xae, 0xa1,
-	0xe7, 0x90, 0x86, 0x12, 0x12, 0xe8, 0x8e, 0xb7, 0xe5, 0x8f, 0x96, 0xe7, 0x94, 0xa8, 0xe6, 0x88,
-	0xb7, 0xe4, 0xbf, 0xa1, 0xe6, 0x81, 0xaf, 0x2a, 0x07, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72,
-	0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x7d, 0x12, 0x77, 0x0a, 0x08, 0x4c, 0x69,
-	0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x76, 0x31,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x40, 0x92, 0x41, 0x2c, 0x0a, 0x0c, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0xae,
-	0xa1, 0xe7, 0x90, 0x86, 0x12, 0x12, 0xe5, 0x88, 0x97, 0xe5, 0x87, 0xba, 0xe6, 0x89, 0x80, 0xe6,
-	0x9c, 0x89, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0x2a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73,
-	0x65, 0x72, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73,
-	0x65, 0x72, 0x73, 0x12, 0x7c, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73,
-	0x74, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x3f, 0x92, 0x41, 0x28, 0x0a, 0x0c, 0xe5, 0x8d, 0x9a, 0xe5, 0xae, 0xa2, 0xe7, 0xae, 0xa1,
-	0xe7, 0x90, 0x86, 0x12, 0x0c, 0xe5, 0x88, 0x9b, 0xe5, 0xbb, 0xba, 0xe6, 0x96, 0x87, 0xe7, 0xab,
-	0xa0, 0x2a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x0e, 0x3a, 0x01, 0x2a, 0x22, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6f, 0x73, 0x74,
-	0x73, 0x12, 0x85, 0x01, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74,
-	0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x48, 0x92, 0x41, 0x28, 0x0a, 0x0c, 0xe5, 0x8d, 0x9a, 0xe5, 0xae, 0xa2, 0xe7, 0xae, 0xa1, 0xe7,
-	0x90, 0x86, 0x12, 0x0c, 0xe6, 0x9b, 0xb4, 0xe6, 0x96, 0xb0, 0xe6, 0x96, 0x87, 0xe7, 0xab, 0xa0,
-	0x2a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x82, 0xd3, 0xe4, 0x93,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0xca, 0xf3,
+	0x18, 0x0e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x3a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x3a, 0x01, 0x2a, 0x1a, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x75,
+	0x73, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x7d, 0x2f, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x2d, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x80, 0x01,
+	0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x15, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55,
+	0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x43, 0x92, 0x41, 0x28,
+	0x0a, 0x0c, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x0c,
+	0xe5, 0x88, 0x9b, 0xe5, 0xbb, 0xba, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0x2a, 0x0a, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0xd0, 0xf3, 0x18, 0x01, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x0e, 0x3a, 0x01, 0x2a, 0x22, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x73,
+	0x12, 0x9a, 0x01, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12,
+	0x15, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5d,
+	0x92, 0x41, 0x2e, 0x0a, 0x0c, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90,
+	0x86, 0x12, 0x12, 0xe6, 0x9b, 0xb4, 0xe6, 0x96, 0xb0, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe4,
+	0xbf, 0xa1, 0xe6, 0x81, 0xaf, 0x2a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0xca, 0xf3, 0x18, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x3a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x3a, 0x01, 0x2a, 0x1a, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x75,
+	0x73, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x7d, 0x12, 0x91, 0x01,
+	0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x15, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55,
+	0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x54, 0x92, 0x41, 0x28,
+	0x0a, 0x0c, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x0c,
+	0xe5, 0x88, 0xa0, 0xe9, 0x99, 0xa4, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0x2a, 0x0a, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0xca, 0xf3, 0x18, 0x0b, 0x75, 0x73, 0x65, 0x72,
+	0x3a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x2a, 0x12, 0x2f,
+	0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44,
+	0x7d, 0x12, 0x89, 0x01, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x12, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x55, 0x92, 0x41, 0x2b, 0x0a, 0x0c, 0xe7, 0x94, 0xa8,
+	0xe6, 0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x12, 0xe8, 0x8e, 0xb7, 0xe5, 0x8f,
+	0x96, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe4, 0xbf, 0xa1, 0xe6, 0x81, 0xaf, 0x2a, 0x07, 0x47,
+	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0xca, 0xf3, 0x18, 0x09, 0x75, 0x73, 0x65, 0x72, 0x3a, 0x76,
+	0x69, 0x65, 0x77, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x75,
+	0x73, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x7d, 0x12, 0x84, 0x01,
+	0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x13, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x14, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4d, 0x92, 0x41, 0x2c, 0x0a, 0x0c, 0xe7, 0x94, 0xa8, 0xe6,
+	0x88, 0xb7, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x12, 0xe5, 0x88, 0x97, 0xe5, 0x87, 0xba,
+	0xe6, 0x89, 0x80, 0xe6, 0x9c, 0x89, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0x2a, 0x08, 0x4c, 0x69,
+	0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0xca, 0xf3, 0x18, 0x09, 0x75, 0x73, 0x65, 0x72, 0x3a, 0x76,
+	0x69, 0x65, 0x77, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x75,
+	0x73, 0x65, 0x72, 0x73, 0x12, 0x8b, 0x01, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50,
+	0x6f, 0x73, 0x74, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50,
+	0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x4e, 0x92, 0x41, 0x28, 0x0a, 0x0c, 0xe5, 0x8d, 0x9a, 0xe5, 0xae, 0xa2, 0xe7,
+	0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x0c, 0xe5, 0x88, 0x9b, 0xe5, 0xbb, 0xba, 0xe6, 0x96, 0x87,
+	0xe7, 0xab, 0xa0, 0x2a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0xca,
+	0xf3, 0x18, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x3a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x0e, 0x3a, 0x01, 0x2a, 0x22, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6f, 0x73,
+	0x74, 0x73, 0x12, 0x94, 0x01, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73,
+	0x74, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x57, 0x92, 0x41, 0x28, 0x0a, 0x0c, 0xe5, 0x8d, 0x9a, 0xe5, 0xae, 0xa2, 0xe7, 0xae, 0xa1,
+	0xe7, 0x90, 0x86, 0x12, 0x0c, 0xe6, 0x9b, 0xb4, 0xe6, 0x96, 0xb0, 0xe6, 0x96, 0x87, 0xe7, 0xab,
+	0xa0, 0x2a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0xca, 0xf3, 0x18,
+	0x0b, 0x70, 0x6f, 0x73, 0x74, 0x3a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x82, 0xd3, 0xe4, 0x93,
 	0x02, 0x17, 0x3a, 0x01, 0x2a, 0x1a, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x73,
-	0x2f, 0x7b, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x7d, 0x12, 0x7c, 0x0a, 0x0a, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
-	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3f, 0x92, 0x41, 0x28, 0x0a, 0x0c, 0xe5, 0x8d, 0x9a,
-	0xe5, 0xae, 0xa2, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x0c, 0xe5, 0x88, 0xa0, 0xe9, 0x99,
-	0xa4, 0xe6, 0x96, 0x87, 0xe7, 0xab, 0xa0, 0x2a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50,
-	0x6f, 0x73, 0x74, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x3a, 0x01, 0x2a, 0x2a, 0x09, 0x2f, 0x76,
-	0x31, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x7c, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x50, 0x6f,
-	0x73, 0x74, 0x12, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50,
-	0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x48, 0x92, 0x41, 0x2b,
+	0x2f, 0x7b, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x7d, 0x12, 0x8b, 0x01, 0x0a, 0x0a, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4e, 0x92, 0x41, 0x28, 0x0a, 0x0c, 0xe5, 0x8d,
+	0x9a, 0xe5, 0xae, 0xa2, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x0c, 0xe5, 0x88, 0xa0, 0xe9,
+	0x99, 0xa4, 0xe6, 0x96, 0x87, 0xe7, 0xab, 0xa0, 0x2a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x50, 0x6f, 0x73, 0x74, 0xca, 0xf3, 0x18, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x3a, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x3a, 0x01, 0x2a, 0x2a, 0x09, 0x2f, 0x76,
+	0x31, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x89, 0x01, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x50,
+	0x6f, 0x73, 0x74, 0x12, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x55, 0x92, 0x41,
+	0x2b, 0x0a, 0x0c, 0xe5, 0x8d, 0x9a, 0xe5, 0xae, 0xa2, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12,
+	0x12, 0xe8, 0x8e, 0xb7, 0xe5, 0x8f, 0x96, 0xe6, 0x96, 0x87, 0xe7, 0xab, 0xa0, 0xe4, 0xbf, 0xa1,
+	0xe6, 0x81, 0xaf, 0x2a, 0x07, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0xca, 0xf3, 0x18, 0x09,
+	0x70, 0x6f, 0x73, 0x74, 0x3a, 0x76, 0x69, 0x65, 0x77, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12,
+	0x12, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x2f, 0x7b, 0x70, 0x6f, 0x73, 0x74,
+	0x49, 0x44, 0x7d, 0x12, 0x84, 0x01, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74,
+	0x12, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4d, 0x92, 0x41, 0x2c,
 	0x0a, 0x0c, 0xe5, 0x8d, 0x9a, 0xe5, 0xae, 0xa2, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x12,
-	0xe8, 0x8e, 0xb7, 0xe5, 0x8f, 0x96, 0xe6, 0x96, 0x87, 0xe7, 0xab, 0xa0, 0xe4, 0xbf, 0xa1, 0xe6,
-	0x81, 0xaf, 0x2a, 0x07, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x82, 0xd3, 0xe4, 0x93, 0x02,
-	0x14, 0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x2f, 0x7b, 0x70, 0x6f,
-	0x73, 0x74, 0x49, 0x44, 0x7d, 0x12, 0x77, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73,
-	0x74, 0x12, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x40, 0x92, 0x41,
-	0x2c, 0x0a, 0x0c, 0xe5, 0x8d, 0x9a, 0xe5, 0xae, 0xa2, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12,
-	0x12, 0xe5, 0x88, 0x97, 0xe5, 0x87, 0xba, 0xe6, 0x89, 0x80, 0xe6, 0x9c, 0x89, 0xe6, 0x96, 0x87,
-	0xe7, 0xab, 0xa0, 0x2a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x0b, 0x12, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x42, 0x9b,
-	0x02, 0x92, 0x41, 0xe0, 0x01, 0x12, 0xb6, 0x01, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x69, 0x62, 0x6c,
-	0x6f, 0x67, 0x20, 0x41, 0x50, 0x49, 0x22, 0x57, 0x0a, 0x18, 0xe5, 0xb0, 0x8f, 0xe8, 0x80, 0x8c,
-	0xe7, 0xbe, 0x8e, 0xe7, 0x9a, 0x84, 0xe5, 0x8d, 0x9a, 0xe5, 0xae, 0xa2, 0xe9, 0xa1, 0xb9, 0xe7,
-	0x9b, 0xae, 0x12, 0x25, 0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x73, 0x68, 0x77, 0x69, 0x6e, 0x79, 0x75, 0x65,
-	0x2f, 0x6f, 0x6e, 0x65, 0x2d, 0x61, 0x75, 0x74, 0x68, 0x1a, 0x14, 0x63, 0x6f, 0x6c, 0x69, 0x6e,
-	0x34, 0x30, 0x34, 0x40, 0x66, 0x6f, 0x78, 0x6d, 0x61, 0x69, 0x6c, 0x2e, 0x63, 0x6f, 0x6d, 0x2a,
-	0x48, 0x0a, 0x0b, 0x4d, 0x49, 0x54, 0x20, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x39,
-	0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x61, 0x73, 0x68, 0x77, 0x69, 0x6e, 0x79, 0x75, 0x65, 0x2f, 0x6f, 0x6e, 0x65,
-	0x2d, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x62, 0x6c, 0x6f, 0x62, 0x2f, 0x6d, 0x61, 0x73, 0x74, 0x65,
-	0x72, 0x2f, 0x4c, 0x49, 0x43, 0x45, 0x4e, 0x53, 0x45, 0x32, 0x03, 0x31, 0x2e, 0x30, 0x2a, 0x01,
-	0x02, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a,
-	0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x61, 0x73, 0x68, 0x77, 0x69, 0x6e, 0x79, 0x75, 0x65, 0x2f, 0x6f, 0x6e, 0x65, 0x2d,
-	0x61, 0x75, 0x74, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x70, 0x69,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0xe5, 0x88, 0x97, 0xe5, 0x87, 0xba, 0xe6, 0x89, 0x80, 0xe6, 0x9c, 0x89, 0xe6, 0x96, 0x87, 0xe7,
+	0xab, 0xa0, 0x2a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x73, 0x74, 0xca, 0xf3, 0x18, 0x09,
+	0x70, 0x6f, 0x73, 0x74, 0x3a, 0x76, 0x69, 0x65, 0x77, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12,
+	0x09, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x12, 0xb9, 0x01, 0x0a, 0x0c, 0x47,
+	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x17, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x76,
+	0x92, 0x41, 0x45, 0x0a, 0x0c, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0xe7, 0xae, 0xa1, 0xe7, 0x90,
+	0x86, 0x12, 0x27, 0xe8, 0x8e, 0xb7, 0xe5, 0x8f, 0x96, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe5,
+	0x9c, 0xa8, 0xe5, 0xbd, 0x93, 0xe5, 0x89, 0x8d, 0xe7, 0xa7, 0x9f, 0xe6, 0x88, 0xb7, 0xe4, 0xb8,
+	0x8b, 0xe7, 0x9a, 0x84, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0x2a, 0x0c, 0x47, 0x65, 0x74, 0x55,
+	0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0xca, 0xf3, 0x18, 0x09, 0x72, 0x6f, 0x6c, 0x65,
+	0x3a, 0x76, 0x69, 0x65, 0x77, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x76, 0x31,
+	0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d,
+	0x2f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0xca, 0x01, 0x0a, 0x0f, 0x41, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x7e, 0x92, 0x41, 0x48, 0x0a, 0x0c, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2,
+	0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x27, 0xe6, 0x9b, 0xbf, 0xe6, 0x8d, 0xa2, 0xe7, 0x94,
+	0xa8, 0xe6, 0x88, 0xb7, 0xe5, 0x9c, 0xa8, 0xe5, 0xbd, 0x93, 0xe5, 0x89, 0x8d, 0xe7, 0xa7, 0x9f,
+	0xe6, 0x88, 0xb7, 0xe4, 0xb8, 0x8b, 0xe7, 0x9a, 0x84, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0x2a,
+	0x0f, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73,
+	0xca, 0xf3, 0x18, 0x0b, 0x72, 0x6f, 0x6c, 0x65, 0x3a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x3a, 0x01, 0x2a, 0x1a, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x6f,
+	0x6c, 0x65, 0x73, 0x12, 0xad, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73,
+	0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52,
+	0x6f, 0x6c, 0x65, 0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x42,
+	0x79, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x64, 0x92,
+	0x41, 0x3b, 0x0a, 0x0c, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86,
+	0x12, 0x1b, 0xe8, 0x8e, 0xb7, 0xe5, 0x8f, 0x96, 0xe5, 0xbd, 0x93, 0xe5, 0x89, 0x8d, 0xe7, 0x94,
+	0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0x2a, 0x0e, 0x47,
+	0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0xca, 0xf3, 0x18,
+	0x0c, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x3a, 0x76, 0x69, 0x65, 0x77, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x72, 0x6f,
+	0x6c, 0x65, 0x73, 0x12, 0xcb, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x50,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x76, 0x92, 0x41, 0x3f, 0x0a, 0x0c,
+	0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x1b, 0xe8, 0x8e,
+	0xb7, 0xe5, 0x8f, 0x96, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0xe7, 0x9a, 0x84, 0xe6, 0x9d, 0x83,
+	0xe9, 0x99, 0x90, 0xe8, 0xa7, 0x84, 0xe5, 0x88, 0x99, 0x2a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x6f,
+	0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0xca, 0xf3, 0x18,
+	0x09, 0x72, 0x6f, 0x6c, 0x65, 0x3a, 0x76, 0x69, 0x65, 0x77, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21,
+	0x12, 0x1f, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x2f, 0x7b, 0x72, 0x6f, 0x6c,
+	0x65, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0xe3, 0x01, 0x0a, 0x15, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x6c, 0x65,
+	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x84, 0x01, 0x92, 0x41, 0x42, 0x0a, 0x0c, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0xe7, 0xae,
+	0xa1, 0xe7, 0x90, 0x86, 0x12, 0x1b, 0xe4, 0xb8, 0xba, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0xe6,
+	0xb7, 0xbb, 0xe5, 0x8a, 0xa0, 0xe6, 0x9d, 0x83, 0xe9, 0x99, 0x90, 0xe8, 0xa7, 0x84, 0xe5, 0x88,
+	0x99, 0x2a, 0x15, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0xca, 0xf3, 0x18, 0x11, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x3a, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x24, 0x3a, 0x01, 0x2a, 0x22, 0x1f, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x6c, 0x65,
+	0x73, 0x2f, 0x7b, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0xb0, 0x01, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x52,
+	0x6f, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0x12, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x65,
+	0x6e, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6d, 0x92, 0x41, 0x3c,
+	0x0a, 0x0c, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x1e,
+	0xe8, 0x8e, 0xb7, 0xe5, 0x8f, 0x96, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0xe5, 0x8f, 0xaf, 0xe8,
+	0xae, 0xbf, 0xe9, 0x97, 0xae, 0xe7, 0x9a, 0x84, 0xe8, 0x8f, 0x9c, 0xe5, 0x8d, 0x95, 0x2a, 0x0c,
+	0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0xca, 0xf3, 0x18, 0x09,
+	0x72, 0x6f, 0x6c, 0x65, 0x3a, 0x76, 0x69, 0x65, 0x77, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12,
+	0x19, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x2f, 0x7b, 0x72, 0x6f, 0x6c, 0x65,
+	0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x6d, 0x65, 0x6e, 0x75, 0x73, 0x12, 0xc1, 0x01, 0x0a, 0x0f, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0x12, 0x1a,
+	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x65,
+	0x6e, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x75, 0x92, 0x41, 0x3f, 0x0a, 0x0c, 0xe8, 0xa7,
+	0x92, 0xe8, 0x89, 0xb2, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x1e, 0xe6, 0x9b, 0xb4, 0xe6,
+	0x96, 0xb0, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0xe5, 0x8f, 0xaf, 0xe8, 0xae, 0xbf, 0xe9, 0x97,
+	0xae, 0xe7, 0x9a, 0x84, 0xe8, 0x8f, 0x9c, 0xe5, 0x8d, 0x95, 0x2a, 0x0f, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0xca, 0xf3, 0x18, 0x0b, 0x72,
+	0x6f, 0x6c, 0x65, 0x3a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e,
+	0x3a, 0x01, 0x2a, 0x1a, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x2f, 0x7b,
+	0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x6d, 0x65, 0x6e, 0x75, 0x73, 0x12, 0xc3,
+	0x01, 0x0a, 0x0f, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f,
+	0x6c, 0x65, 0x12, 0x1a, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x77, 0x92, 0x41, 0x3f,
+	0x0a, 0x0c, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0xe7, 0xae, 0xa1, 0xe7, 0x90, 0x86, 0x12, 0x1e,
+	0xe6, 0xa3, 0x80, 0xe6, 0x9f, 0xa5, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0xe6, 0x98, 0xaf, 0xe5,
+	0x90, 0xa6, 0xe5, 0x8f, 0xaf, 0xe4, 0xbb, 0xa5, 0xe5, 0x88, 0xa0, 0xe9, 0x99, 0xa4, 0x2a, 0x0f,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0xca,
+	0xf3, 0x18, 0x09, 0x72, 0x6f, 0x6c, 0x65, 0x3a, 0x76, 0x69, 0x65, 0x77, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x22, 0x12, 0x20, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x2f, 0x7b, 0x72,
+	0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2d, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x12, 0xd0, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c,
+	0x65, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x50, 0x72, 0x69, 0x76, 0x69,
+	0x6c, 0x65, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x75, 0x92, 0x41, 0x4a, 0x0a, 0x0c, 0xe8, 0xa7, 0x92, 0xe8, 0x89, 0xb2, 0xe7, 0xae, 0xa1,
+	0xe7, 0x90, 0x86, 0x12, 0x24, 0xe4, 0xbb, 0x8e, 0xe6, 0x95, 0xb0, 0xe6, 0x8d, 0xae, 0xe5, 0xba,
+	0x93, 0xe9, 0x87, 0x8d, 0xe6, 0x96, 0xb0, 0xe5, 0x8a, 0xa0, 0xe8, 0xbd, 0xbd, 0xe6, 0x9d, 0x83,
+	0xe9, 0x99, 0x90, 0xe6, 0x95, 0xb0, 0xe6, 0x8d, 0xae, 0x2a, 0x14, 0x52, 0x65, 0x66, 0x72, 0x65,
+	0x73, 0x68, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0xca,
+	0xf3, 0x18, 0x0b, 0x72, 0x6f, 0x6c, 0x65, 0x3a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x13, 0x22, 0x11, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x2f,
+	0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x42, 0x9b, 0x02, 0x92, 0x41, 0xe0, 0x01, 0x12, 0xb6,
+	0x01, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x69, 0x62, 0x6c, 0x6f, 0x67, 0x20, 0x41, 0x50, 0x49, 0x22,
+	0x57, 0x0a, 0x18, 0xe5, 0xb0, 0x8f, 0xe8, 0x80, 0x8c, 0xe7, 0xbe, 0x8e, 0xe7, 0x9a, 0x84, 0xe5,
+	0x8d, 0x9a, 0xe5, 0xae, 0xa2, 0xe9, 0xa1, 0xb9, 0xe7, 0x9b, 0xae, 0x12, 0x25, 0x68, 0x74, 0x74,
+	0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x61, 0x73, 0x68, 0x77, 0x69, 0x6e, 0x79, 0x75, 0x65, 0x2f, 0x6f, 0x6e, 0x65, 0x2d, 0x61, 0x75,
+	0x74, 0x68, 0x1a, 0x14, 0x63, 0x6f, 0x6c, 0x69, 0x6e, 0x34, 0x30, 0x34, 0x40, 0x66, 0x6f, 0x78,
+	0x6d, 0x61, 0x69, 0x6c, 0x2e, 0x63, 0x6f, 0x6d, 0x2a, 0x48, 0x0a, 0x0b, 0x4d, 0x49, 0x54, 0x20,
+	0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f,
+	0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x73, 0x68, 0x77,
+	0x69, 0x6e, 0x79, 0x75, 0x65, 0x2f, 0x6f, 0x6e, 0x65, 0x2d, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x62,
+	0x6c, 0x6f, 0x62, 0x2f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x2f, 0x4c, 0x49, 0x43, 0x45, 0x4e,
+	0x53, 0x45, 0x32, 0x03, 0x31, 0x2e, 0x30, 0x2a, 0x01, 0x02, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x5a, 0x35,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x73, 0x68, 0x77, 0x69,
+	0x6e, 0x79, 0x75, 0x65, 0x2f, 0x6f, 0x6e, 0x65, 0x2d, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f,
+	0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_apiserver_v1_apiserver_proto_goTypes = []any{
-	(*emptypb.Empty)(nil),          // 0: google.protobuf.Empty
-	(*LoginRequest)(nil),           // 1: v1.LoginRequest
-	(*RefreshTokenRequest)(nil),    // 2: v1.RefreshTokenRequest
-	(*ChangePasswordRequest)(nil),  // 3: v1.ChangePasswordRequest
-	(*CreateUserRequest)(nil),      // 4: v1.CreateUserRequest
-	(*UpdateUserRequest)(nil),      // 5: v1.UpdateUserRequest
-	(*DeleteUserRequest)(nil),      // 6: v1.DeleteUserRequest
-	(*GetUserRequest)(nil),         // 7: v1.GetUserRequest
-	(*ListUserRequest)(nil),        // 8: v1.ListUserRequest
-	(*CreatePostRequest)(nil),      // 9: v1.CreatePostRequest
-	(*UpdatePostRequest)(nil),      // 10: v1.UpdatePostRequest
-	(*DeletePostRequest)(nil),      // 11: v1.DeletePostRequest
-	(*GetPostRequest)(nil),         // 12: v1.GetPostRequest
-	(*ListPostRequest)(nil),        // 13: v1.ListPostRequest
-	(*HealthzResponse)(nil),        // 14: v1.HealthzResponse
-	(*LoginResponse)(nil),          // 15: v1.LoginResponse
-	(*RefreshTokenResponse)(nil),   // 16: v1.RefreshTokenResponse
-	(*ChangePasswordResponse)(nil), // 17: v1.ChangePasswordResponse
-	(*CreateUserResponse)(nil),     // 18: v1.CreateUserResponse
-	(*UpdateUserResponse)(nil),     // 19: v1.UpdateUserResponse
-	(*DeleteUserResponse)(nil),     // 20: v1.DeleteUserResponse
-	(*GetUserResponse)(nil),        // 21: v1.GetUserResponse
-	(*ListUserResponse)(nil),       // 22: v1.ListUserResponse
-	(*CreatePostResponse)(nil),     // 23: v1.CreatePostResponse
-	(*UpdatePostResponse)(nil),     // 24: v1.UpdatePostResponse
-	(*DeletePostResponse)(nil),     // 25: v1.DeletePostResponse
-	(*GetPostResponse)(nil),        // 26: v1.GetPostResponse
-	(*ListPostResponse)(nil),       // 27: v1.ListPostResponse
+	(*emptypb.Empty)(nil),                 // 0: google.protobuf.Empty
+	(*LoginRequest)(nil),                  // 1: v1.LoginRequest
+	(*RefreshTokenRequest)(nil),           // 2: v1.RefreshTokenRequest
+	(*ChangePasswordRequest)(nil),         // 3: v1.ChangePasswordRequest
+	(*CreateUserRequest)(nil),             // 4: v1.CreateUserRequest
+	(*UpdateUserRequest)(nil),             // 5: v1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),             // 6: v1.DeleteUserRequest
+	(*GetUserRequest)(nil),                // 7: v1.GetUserRequest
+	(*ListUserRequest)(nil),               // 8: v1.ListUserRequest
+	(*CreatePostRequest)(nil),             // 9: v1.CreatePostRequest
+	(*UpdatePostRequest)(nil),             // 10: v1.UpdatePostRequest
+	(*DeletePostRequest)(nil),             // 11: v1.DeletePostRequest
+	(*GetPostRequest)(nil),                // 12: v1.GetPostRequest
+	(*ListPostRequest)(nil),               // 13: v1.ListPostRequest
+	(*GetUserRolesRequest)(nil),           // 14: v1.GetUserRolesRequest
+	(*AssignUserRolesRequest)(nil),        // 15: v1.AssignUserRolesRequest
+	(*GetRolesByUserRequest)(nil),         // 16: v1.GetRolesByUserRequest
+	(*GetRolePermissionsRequest)(nil),     // 17: v1.GetRolePermissionsRequest
+	(*AssignRolePermissionsRequest)(nil),  // 18: v1.AssignRolePermissionsRequest
+	(*GetRoleMenusRequest)(nil),           // 19: v1.GetRoleMenusRequest
+	(*UpdateRoleMenusRequest)(nil),        // 20: v1.UpdateRoleMenusRequest
+	(*CheckDeleteRoleRequest)(nil),        // 21: v1.CheckDeleteRoleRequest
+	(*RefreshPrivilegeDataRequest)(nil),   // 22: v1.RefreshPrivilegeDataRequest
+	(*HealthzResponse)(nil),               // 23: v1.HealthzResponse
+	(*LoginResponse)(nil),                 // 24: v1.LoginResponse
+	(*RefreshTokenResponse)(nil),          // 25: v1.RefreshTokenResponse
+	(*ChangePasswordResponse)(nil),        // 26: v1.ChangePasswordResponse
+	(*CreateUserResponse)(nil),            // 27: v1.CreateUserResponse
+	(*UpdateUserResponse)(nil),            // 28: v1.UpdateUserResponse
+	(*DeleteUserResponse)(nil),            // 29: v1.DeleteUserResponse
+	(*GetUserResponse)(nil),               // 30: v1.GetUserResponse
+	(*ListUserResponse)(nil),              // 31: v1.ListUserResponse
+	(*CreatePostResponse)(nil),            // 32: v1.CreatePostResponse
+	(*UpdatePostResponse)(nil),            // 33: v1.UpdatePostResponse
+	(*DeletePostResponse)(nil),            // 34: v1.DeletePostResponse
+	(*GetPostResponse)(nil),               // 35: v1.GetPostResponse
+	(*ListPostResponse)(nil),              // 36: v1.ListPostResponse
+	(*GetUserRolesResponse)(nil),          // 37: v1.GetUserRolesResponse
+	(*AssignUserRolesResponse)(nil),       // 38: v1.AssignUserRolesResponse
+	(*GetRolesByUserResponse)(nil),        // 39: v1.GetRolesByUserResponse
+	(*GetRolePermissionsResponse)(nil),    // 40: v1.GetRolePermissionsResponse
+	(*AssignRolePermissionsResponse)(nil), // 41: v1.AssignRolePermissionsResponse
+	(*GetRoleMenusResponse)(nil),          // 42: v1.GetRoleMenusResponse
+	(*UpdateRoleMenusResponse)(nil),       // 43: v1.UpdateRoleMenusResponse
+	(*CheckDeleteRoleResponse)(nil),       // 44: v1.CheckDeleteRoleResponse
+	(*RefreshPrivilegeDataResponse)(nil),  // 45: v1.RefreshPrivilegeDataResponse
 }
 var file_apiserver_v1_apiserver_proto_depIdxs = []int32{
 	0,  // 0: v1.MiniBlog.Healthz:input_type -> google.protobuf.Empty
@@ -227,22 +371,40 @@ var file_apiserver_v1_apiserver_proto_depIdxs = []int32{
 	11, // 11: v1.MiniBlog.DeletePost:input_type -> v1.DeletePostRequest
 	12, // 12: v1.MiniBlog.GetPost:input_type -> v1.GetPostRequest
 	13, // 13: v1.MiniBlog.ListPost:input_type -> v1.ListPostRequest
-	14, // 14: v1.MiniBlog.Healthz:output_type -> v1.HealthzResponse
-	15, // 15: v1.MiniBlog.Login:output_type -> v1.LoginResponse
-	16, // 16: v1.MiniBlog.RefreshToken:output_type -> v1.RefreshTokenResponse
-	17, // 17: v1.MiniBlog.ChangePassword:output_type -> v1.ChangePasswordResponse
-	18, // 18: v1.MiniBlog.CreateUser:output_type -> v1.CreateUserResponse
-	19, // 19: v1.MiniBlog.UpdateUser:output_type -> v1.UpdateUserResponse
-	20, // 20: v1.MiniBlog.DeleteUser:output_type -> v1.DeleteUserResponse
-	21, // 21: v1.MiniBlog.GetUser:output_type -> v1.GetUserResponse
-	22, // 22: v1.MiniBlog.ListUser:output_type -> v1.ListUserResponse
-	23, // 23: v1.MiniBlog.CreatePost:output_type -> v1.CreatePostResponse
-	24, // 24: v1.MiniBlog.UpdatePost:output_type -> v1.UpdatePostResponse
-	25, // 25: v1.MiniBlog.DeletePost:output_type -> v1.DeletePostResponse
-	26, // 26: v1.MiniBlog.GetPost:output_type -> v1.GetPostResponse
-	27, // 27: v1.MiniBlog.ListPost:output_type -> v1.ListPostResponse
-	14, // [14:28] is the sub-list for method output_type
-	0,  // [0:14] is the sub-list for method input_type
+	14, // 14: v1.MiniBlog.GetUserRoles:input_type -> v1.GetUserRolesRequest
+	15, // 15: v1.MiniBlog.AssignUserRoles:input_type -> v1.AssignUserRolesRequest
+	16, // 16: v1.MiniBlog.GetRolesByUser:input_type -> v1.GetRolesByUserRequest
+	17, // 17: v1.MiniBlog.GetRolePermissions:input_type -> v1.GetRolePermissionsRequest
+	18, // 18: v1.MiniBlog.AssignRolePermissions:input_type -> v1.AssignRolePermissionsRequest
+	19, // 19: v1.MiniBlog.GetRoleMenus:input_type -> v1.GetRoleMenusRequest
+	20, // 20: v1.MiniBlog.UpdateRoleMenus:input_type -> v1.UpdateRoleMenusRequest
+	21, // 21: v1.MiniBlog.CheckDeleteRole:input_type -> v1.CheckDeleteRoleRequest
+	22, // 22: v1.MiniBlog.RefreshPrivilegeData:input_type -> v1.RefreshPrivilegeDataRequest
+	23, // 23: v1.MiniBlog.Healthz:output_type -> v1.HealthzResponse
+	24, // 24: v1.MiniBlog.Login:output_type -> v1.LoginResponse
+	25, // 25: v1.MiniBlog.RefreshToken:output_type -> v1.RefreshTokenResponse
+	26, // 26: v1.MiniBlog.ChangePassword:output_type -> v1.ChangePasswordResponse
+	27, // 27: v1.MiniBlog.CreateUser:output_type -> v1.CreateUserResponse
+	28, // 28: v1.MiniBlog.UpdateUser:output_type -> v1.UpdateUserResponse
+	29, // 29: v1.MiniBlog.DeleteUser:output_type -> v1.DeleteUserResponse
+	30, // 30: v1.MiniBlog.GetUser:output_type -> v1.GetUserResponse
+	31, // 31: v1.MiniBlog.ListUser:output_type -> v1.ListUserResponse
+	32, // 32: v1.MiniBlog.CreatePost:output_type -> v1.CreatePostResponse
+	33, // 33: v1.MiniBlog.UpdatePost:output_type -> v1.UpdatePostResponse
+	34, // 34: v1.MiniBlog.DeletePost:output_type -> v1.DeletePostResponse
+	35, // 35: v1.MiniBlog.GetPost:output_type -> v1.GetPostResponse
+	36, // 36: v1.MiniBlog.ListPost:output_type -> v1.ListPostResponse
+	37, // 37: v1.MiniBlog.GetUserRoles:output_type -> v1.GetUserRolesResponse
+	38, // 38: v1.MiniBlog.AssignUserRoles:output_type -> v1.AssignUserRolesResponse
+	39, // 39: v1.MiniBlog.GetRolesByUser:output_type -> v1.GetRolesByUserResponse
+	40, // 40: v1.MiniBlog.GetRolePermissions:output_type -> v1.GetRolePermissionsResponse
+	41, // 41: v1.MiniBlog.AssignRolePermissions:output_type -> v1.AssignRolePermissionsResponse
+	42, // 42: v1.MiniBlog.GetRoleMenus:output_type -> v1.GetRoleMenusResponse
+	43, // 43: v1.MiniBlog.UpdateRoleMenus:output_type -> v1.UpdateRoleMenusResponse
+	44, // 44: v1.MiniBlog.CheckDeleteRole:output_type -> v1.CheckDeleteRoleResponse
+	45, // 45: v1.MiniBlog.RefreshPrivilegeData:output_type -> v1.RefreshPrivilegeDataResponse
+	23, // [23:46] is the sub-list for method output_type
+	0,  // [0:23] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -256,6 +418,7 @@ func file_apiserver_v1_apiserver_proto_init() {
 	file_apiserver_v1_healthz_proto_init()
 	file_apiserver_v1_post_proto_init()
 	file_apiserver_v1_user_proto_init()
+	file_apiserver_v1_role_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
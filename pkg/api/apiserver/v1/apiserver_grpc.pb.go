@@ -28,20 +28,29 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MiniBlog_Healthz_FullMethodName        = "/v1.MiniBlog/Healthz"
-	MiniBlog_Login_FullMethodName          = "/v1.MiniBlog/Login"
-	MiniBlog_RefreshToken_FullMethodName   = "/v1.MiniBlog/RefreshToken"
-	MiniBlog_ChangePassword_FullMethodName = "/v1.MiniBlog/ChangePassword"
-	MiniBlog_CreateUser_FullMethodName     = "/v1.MiniBlog/CreateUser"
-	MiniBlog_UpdateUser_FullMethodName     = "/v1.MiniBlog/UpdateUser"
-	MiniBlog_DeleteUser_FullMethodName     = "/v1.MiniBlog/DeleteUser"
-	MiniBlog_GetUser_FullMethodName        = "/v1.MiniBlog/GetUser"
-	MiniBlog_ListUser_FullMethodName       = "/v1.MiniBlog/ListUser"
-	MiniBlog_CreatePost_FullMethodName     = "/v1.MiniBlog/CreatePost"
-	MiniBlog_UpdatePost_FullMethodName     = "/v1.MiniBlog/UpdatePost"
-	MiniBlog_DeletePost_FullMethodName     = "/v1.MiniBlog/DeletePost"
-	MiniBlog_GetPost_FullMethodName        = "/v1.MiniBlog/GetPost"
-	MiniBlog_ListPost_FullMethodName       = "/v1.MiniBlog/ListPost"
+	MiniBlog_Healthz_FullMethodName               = "/v1.MiniBlog/Healthz"
+	MiniBlog_Login_FullMethodName                 = "/v1.MiniBlog/Login"
+	MiniBlog_RefreshToken_FullMethodName          = "/v1.MiniBlog/RefreshToken"
+	MiniBlog_ChangePassword_FullMethodName        = "/v1.MiniBlog/ChangePassword"
+	MiniBlog_CreateUser_FullMethodName            = "/v1.MiniBlog/CreateUser"
+	MiniBlog_UpdateUser_FullMethodName            = "/v1.MiniBlog/UpdateUser"
+	MiniBlog_DeleteUser_FullMethodName            = "/v1.MiniBlog/DeleteUser"
+	MiniBlog_GetUser_FullMethodName               = "/v1.MiniBlog/GetUser"
+	MiniBlog_ListUser_FullMethodName              = "/v1.MiniBlog/ListUser"
+	MiniBlog_CreatePost_FullMethodName            = "/v1.MiniBlog/CreatePost"
+	MiniBlog_UpdatePost_FullMethodName            = "/v1.MiniBlog/UpdatePost"
+	MiniBlog_DeletePost_FullMethodName            = "/v1.MiniBlog/DeletePost"
+	MiniBlog_GetPost_FullMethodName               = "/v1.MiniBlog/GetPost"
+	MiniBlog_ListPost_FullMethodName              = "/v1.MiniBlog/ListPost"
+	MiniBlog_GetUserRoles_FullMethodName          = "/v1.MiniBlog/GetUserRoles"
+	MiniBlog_AssignUserRoles_FullMethodName       = "/v1.MiniBlog/AssignUserRoles"
+	MiniBlog_GetRolesByUser_FullMethodName        = "/v1.MiniBlog/GetRolesByUser"
+	MiniBlog_GetRolePermissions_FullMethodName    = "/v1.MiniBlog/GetRolePermissions"
+	MiniBlog_AssignRolePermissions_FullMethodName = "/v1.MiniBlog/AssignRolePermissions"
+	MiniBlog_GetRoleMenus_FullMethodName          = "/v1.MiniBlog/GetRoleMenus"
+	MiniBlog_UpdateRoleMenus_FullMethodName       = "/v1.MiniBlog/UpdateRoleMenus"
+	MiniBlog_CheckDeleteRole_FullMethodName       = "/v1.MiniBlog/CheckDeleteRole"
+	MiniBlog_RefreshPrivilegeData_FullMethodName  = "/v1.MiniBlog/RefreshPrivilegeData"
 )
 
 // MiniBlogClient is the client API for MiniBlog service.
@@ -78,6 +87,24 @@ type MiniBlogClient interface {
 	GetPost(ctx context.Context, in *GetPostRequest, opts ...grpc.CallOption) (*GetPostResponse, error)
 	// ListPost 列出所有文章
 	ListPost(ctx context.Context, in *ListPostRequest, opts ...grpc.CallOption) (*ListPostResponse, error)
+	// GetUserRoles 获取用户在当前租户下的角色
+	GetUserRoles(ctx context.Context, in *GetUserRolesRequest, opts ...grpc.CallOption) (*GetUserRolesResponse, error)
+	// AssignUserRoles 替换用户在当前租户下的角色
+	AssignUserRoles(ctx context.Context, in *AssignUserRolesRequest, opts ...grpc.CallOption) (*AssignUserRolesResponse, error)
+	// GetRolesByUser 获取当前用户的角色
+	GetRolesByUser(ctx context.Context, in *GetRolesByUserRequest, opts ...grpc.CallOption) (*GetRolesByUserResponse, error)
+	// GetRolePermissions 获取角色的权限规则
+	GetRolePermissions(ctx context.Context, in *GetRolePermissionsRequest, opts ...grpc.CallOption) (*GetRolePermissionsResponse, error)
+	// AssignRolePermissions 为角色添加权限规则
+	AssignRolePermissions(ctx context.Context, in *AssignRolePermissionsRequest, opts ...grpc.CallOption) (*AssignRolePermissionsResponse, error)
+	// GetRoleMenus 获取角色可访问的菜单
+	GetRoleMenus(ctx context.Context, in *GetRoleMenusRequest, opts ...grpc.CallOption) (*GetRoleMenusResponse, error)
+	// UpdateRoleMenus 更新角色可访问的菜单
+	UpdateRoleMenus(ctx context.Context, in *UpdateRoleMenusRequest, opts ...grpc.CallOption) (*UpdateRoleMenusResponse, error)
+	// CheckDeleteRole 检查角色是否可以删除
+	CheckDeleteRole(ctx context.Context, in *CheckDeleteRoleRequest, opts ...grpc.CallOption) (*CheckDeleteRoleResponse, error)
+	// RefreshPrivilegeData 从数据库重新加载权限数据
+	RefreshPrivilegeData(ctx context.Context, in *RefreshPrivilegeDataRequest, opts ...grpc.CallOption) (*RefreshPrivilegeDataResponse, error)
 }
 
 type miniBlogClient struct {
@@ -228,6 +255,96 @@ func (c *miniBlogClient) ListPost(ctx context.Context, in *ListPostRequest, opts
 	return out, nil
 }
 
+func (c *miniBlogClient) GetUserRoles(ctx context.Context, in *GetUserRolesRequest, opts ...grpc.CallOption) (*GetUserRolesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserRolesResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_GetUserRoles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) AssignUserRoles(ctx context.Context, in *AssignUserRolesRequest, opts ...grpc.CallOption) (*AssignUserRolesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignUserRolesResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_AssignUserRoles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) GetRolesByUser(ctx context.Context, in *GetRolesByUserRequest, opts ...grpc.CallOption) (*GetRolesByUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRolesByUserResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_GetRolesByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) GetRolePermissions(ctx context.Context, in *GetRolePermissionsRequest, opts ...grpc.CallOption) (*GetRolePermissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRolePermissionsResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_GetRolePermissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) AssignRolePermissions(ctx context.Context, in *AssignRolePermissionsRequest, opts ...grpc.CallOption) (*AssignRolePermissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignRolePermissionsResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_AssignRolePermissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) GetRoleMenus(ctx context.Context, in *GetRoleMenusRequest, opts ...grpc.CallOption) (*GetRoleMenusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoleMenusResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_GetRoleMenus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) UpdateRoleMenus(ctx context.Context, in *UpdateRoleMenusRequest, opts ...grpc.CallOption) (*UpdateRoleMenusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateRoleMenusResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_UpdateRoleMenus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) CheckDeleteRole(ctx context.Context, in *CheckDeleteRoleRequest, opts ...grpc.CallOption) (*CheckDeleteRoleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckDeleteRoleResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_CheckDeleteRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) RefreshPrivilegeData(ctx context.Context, in *RefreshPrivilegeDataRequest, opts ...grpc.CallOption) (*RefreshPrivilegeDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshPrivilegeDataResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_RefreshPrivilegeData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MiniBlogServer is the server API for MiniBlog service.
 // All implementations must embed UnimplementedMiniBlogServer
 // for forward compatibility.
@@ -262,6 +379,24 @@ type MiniBlogServer interface {
 	GetPost(context.Context, *GetPostRequest) (*GetPostResponse, error)
 	// ListPost 列出所有文章
 	ListPost(context.Context, *ListPostRequest) (*ListPostResponse, error)
+	// GetUserRoles 获取用户在当前租户下的角色
+	GetUserRoles(context.Context, *GetUserRolesRequest) (*GetUserRolesResponse, error)
+	// AssignUserRoles 替换用户在当前租户下的角色
+	AssignUserRoles(context.Context, *AssignUserRolesRequest) (*AssignUserRolesResponse, error)
+	// GetRolesByUser 获取当前用户的角色
+	GetRolesByUser(context.Context, *GetRolesByUserRequest) (*GetRolesByUserResponse, error)
+	// GetRolePermissions 获取角色的权限规则
+	GetRolePermissions(context.Context, *GetRolePermissionsRequest) (*GetRolePermissionsResponse, error)
+	// AssignRolePermissions 为角色添加权限规则
+	AssignRolePermissions(context.Context, *AssignRolePermissionsRequest) (*AssignRolePermissionsResponse, error)
+	// GetRoleMenus 获取角色可访问的菜单
+	GetRoleMenus(context.Context, *GetRoleMenusRequest) (*GetRoleMenusResponse, error)
+	// UpdateRoleMenus 更新角色可访问的菜单
+	UpdateRoleMenus(context.Context, *UpdateRoleMenusRequest) (*UpdateRoleMenusResponse, error)
+	// CheckDeleteRole 检查角色是否可以删除
+	CheckDeleteRole(context.Context, *CheckDeleteRoleRequest) (*CheckDeleteRoleResponse, error)
+	// RefreshPrivilegeData 从数据库重新加载权限数据
+	RefreshPrivilegeData(context.Context, *RefreshPrivilegeDataRequest) (*RefreshPrivilegeDataResponse, error)
 	mustEmbedUnimplementedMiniBlogServer()
 }
 
@@ -314,6 +449,33 @@ func (UnimplementedMiniBlogServer) GetPost(context.Context, *GetPostRequest) (*G
 func (UnimplementedMiniBlogServer) ListPost(context.Context, *ListPostRequest) (*ListPostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPost not implemented")
 }
+func (UnimplementedMiniBlogServer) GetUserRoles(context.Context, *GetUserRolesRequest) (*GetUserRolesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserRoles not implemented")
+}
+func (UnimplementedMiniBlogServer) AssignUserRoles(context.Context, *AssignUserRolesRequest) (*AssignUserRolesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignUserRoles not implemented")
+}
+func (UnimplementedMiniBlogServer) GetRolesByUser(context.Context, *GetRolesByUserRequest) (*GetRolesByUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRolesByUser not implemented")
+}
+func (UnimplementedMiniBlogServer) GetRolePermissions(context.Context, *GetRolePermissionsRequest) (*GetRolePermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRolePermissions not implemented")
+}
+func (UnimplementedMiniBlogServer) AssignRolePermissions(context.Context, *AssignRolePermissionsRequest) (*AssignRolePermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignRolePermissions not implemented")
+}
+func (UnimplementedMiniBlogServer) GetRoleMenus(context.Context, *GetRoleMenusRequest) (*GetRoleMenusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoleMenus not implemented")
+}
+func (UnimplementedMiniBlogServer) UpdateRoleMenus(context.Context, *UpdateRoleMenusRequest) (*UpdateRoleMenusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoleMenus not implemented")
+}
+func (UnimplementedMiniBlogServer) CheckDeleteRole(context.Context, *CheckDeleteRoleRequest) (*CheckDeleteRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckDeleteRole not implemented")
+}
+func (UnimplementedMiniBlogServer) RefreshPrivilegeData(context.Context, *RefreshPrivilegeDataRequest) (*RefreshPrivilegeDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshPrivilegeData not implemented")
+}
 func (UnimplementedMiniBlogServer) mustEmbedUnimplementedMiniBlogServer() {}
 func (UnimplementedMiniBlogServer) testEmbeddedByValue()                  {}
 
@@ -587,6 +749,168 @@ func _MiniBlog_ListPost_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MiniBlog_GetUserRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRolesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).GetUserRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_GetUserRoles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).GetUserRoles(ctx, req.(*GetUserRolesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_AssignUserRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignUserRolesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).AssignUserRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_AssignUserRoles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).AssignUserRoles(ctx, req.(*AssignUserRolesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_GetRolesByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRolesByUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).GetRolesByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_GetRolesByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).GetRolesByUser(ctx, req.(*GetRolesByUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_GetRolePermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRolePermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).GetRolePermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_GetRolePermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).GetRolePermissions(ctx, req.(*GetRolePermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_AssignRolePermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignRolePermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).AssignRolePermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_AssignRolePermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).AssignRolePermissions(ctx, req.(*AssignRolePermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_GetRoleMenus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoleMenusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).GetRoleMenus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_GetRoleMenus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).GetRoleMenus(ctx, req.(*GetRoleMenusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_UpdateRoleMenus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoleMenusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).UpdateRoleMenus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_UpdateRoleMenus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).UpdateRoleMenus(ctx, req.(*UpdateRoleMenusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_CheckDeleteRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDeleteRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).CheckDeleteRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_CheckDeleteRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).CheckDeleteRole(ctx, req.(*CheckDeleteRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_RefreshPrivilegeData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshPrivilegeDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).RefreshPrivilegeData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_RefreshPrivilegeData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).RefreshPrivilegeData(ctx, req.(*RefreshPrivilegeDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MiniBlog_ServiceDesc is the grpc.ServiceDesc for MiniBlog service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -650,6 +974,42 @@ var MiniBlog_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListPost",
 			Handler:    _MiniBlog_ListPost_Handler,
 		},
+		{
+			MethodName: "GetUserRoles",
+			Handler:    _MiniBlog_GetUserRoles_Handler,
+		},
+		{
+			MethodName: "AssignUserRoles",
+			Handler:    _MiniBlog_AssignUserRoles_Handler,
+		},
+		{
+			MethodName: "GetRolesByUser",
+			Handler:    _MiniBlog_GetRolesByUser_Handler,
+		},
+		{
+			MethodName: "GetRolePermissions",
+			Handler:    _MiniBlog_GetRolePermissions_Handler,
+		},
+		{
+			MethodName: "AssignRolePermissions",
+			Handler:    _MiniBlog_AssignRolePermissions_Handler,
+		},
+		{
+			MethodName: "GetRoleMenus",
+			Handler:    _MiniBlog_GetRoleMenus_Handler,
+		},
+		{
+			MethodName: "UpdateRoleMenus",
+			Handler:    _MiniBlog_UpdateRoleMenus_Handler,
+		},
+		{
+			MethodName: "CheckDeleteRole",
+			Handler:    _MiniBlog_CheckDeleteRole_Handler,
+		},
+		{
+			MethodName: "RefreshPrivilegeData",
+			Handler:    _MiniBlog_RefreshPrivilegeData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "apiserver/v1/apiserver.proto",
@@ -0,0 +1,320 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Casbin 策略与关系数据一致性检查 API 定义
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v4.25.1
+// source: apiserver/v1/consistency.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ConsistencyIssue 表示一处 Casbin 策略与关系数据不一致的问题
+type ConsistencyIssue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// kind 表示问题类型：invalid_id、invalid_effect、unknown_tenant、orphaned_rule、dangling_rule、
+	// user_not_in_tenant、duplicate、user_without_tenant
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// rule_id 表示 casbin_rule 表中的记录ID，与规则无关的问题为 0
+	RuleId int64 `protobuf:"varint,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// rule 表示规则内容，例如 g, u1, r2, t1
+	Rule string `protobuf:"bytes,3,opt,name=rule,proto3" json:"rule,omitempty"`
+	// user_id 表示没有租户的用户ID
+	UserId int64 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// detail 表示问题说明
+	Detail string `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	// fixable 表示修复模式下是否会自动处理，自动处理的规则会被删除
+	Fixable bool `protobuf:"varint,6,opt,name=fixable,proto3" json:"fixable,omitempty"`
+}
+
+func (x *ConsistencyIssue) Reset() {
+	*x = ConsistencyIssue{}
+	mi := &file_apiserver_v1_consistency_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsistencyIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsistencyIssue) ProtoMessage() {}
+
+func (x *ConsistencyIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_consistency_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsistencyIssue.ProtoReflect.Descriptor instead.
+func (*ConsistencyIssue) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_consistency_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConsistencyIssue) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ConsistencyIssue) GetRuleId() int64 {
+	if x != nil {
+		return x.RuleId
+	}
+	return 0
+}
+
+func (x *ConsistencyIssue) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *ConsistencyIssue) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ConsistencyIssue) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *ConsistencyIssue) GetFixable() bool {
+	if x != nil {
+		return x.Fixable
+	}
+	return false
+}
+
+// CheckConsistencyRequest 表示一致性检查请求
+type CheckConsistencyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// fix 表示是否删除可以自动处理的规则，为 false 时只生成报告
+	// @gotags: form:"fix"
+	Fix bool `protobuf:"varint,1,opt,name=fix,proto3" json:"fix,omitempty" form:"fix"`
+}
+
+func (x *CheckConsistencyRequest) Reset() {
+	*x = CheckConsistencyRequest{}
+	mi := &file_apiserver_v1_consistency_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckConsistencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckConsistencyRequest) ProtoMessage() {}
+
+func (x *CheckConsistencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_consistency_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckConsistencyRequest.ProtoReflect.Descriptor instead.
+func (*CheckConsistencyRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_consistency_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CheckConsistencyRequest) GetFix() bool {
+	if x != nil {
+		return x.Fix
+	}
+	return false
+}
+
+// CheckConsistencyResponse 表示一致性检查响应
+type CheckConsistencyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// rules_checked 表示检查的规则数量
+	RulesChecked int64 `protobuf:"varint,1,opt,name=rules_checked,json=rulesChecked,proto3" json:"rules_checked,omitempty"`
+	// issues 表示发现的问题
+	Issues []*ConsistencyIssue `protobuf:"bytes,2,rep,name=issues,proto3" json:"issues,omitempty"`
+	// fixed 表示修复模式下删除的规则数量
+	Fixed int64 `protobuf:"varint,3,opt,name=fixed,proto3" json:"fixed,omitempty"`
+}
+
+func (x *CheckConsistencyResponse) Reset() {
+	*x = CheckConsistencyResponse{}
+	mi := &file_apiserver_v1_consistency_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckConsistencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckConsistencyResponse) ProtoMessage() {}
+
+func (x *CheckConsistencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_consistency_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckConsistencyResponse.ProtoReflect.Descriptor instead.
+func (*CheckConsistencyResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_consistency_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckConsistencyResponse) GetRulesChecked() int64 {
+	if x != nil {
+		return x.RulesChecked
+	}
+	return 0
+}
+
+func (x *CheckConsistencyResponse) GetIssues() []*ConsistencyIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *CheckConsistencyResponse) GetFixed() int64 {
+	if x != nil {
+		return x.Fixed
+	}
+	return 0
+}
+
+var File_apiserver_v1_consistency_proto protoreflect.FileDescriptor
+
+var file_apiserver_v1_consistency_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x61, 0x70, 0x69, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x63,
+	0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x02, 0x76, 0x31, 0x22, 0x9e, 0x01, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x49, 0x73, 0x73, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x17, 0x0a,
+	0x07, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x72, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x66,
+	0x69, 0x78, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x66, 0x69,
+	0x78, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x2b, 0x0a, 0x17, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f,
+	0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x66,
+	0x69, 0x78, 0x22, 0x83, 0x01, 0x0a, 0x18, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x73,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x49, 0x73, 0x73, 0x75, 0x65, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75,
+	0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x78, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x66, 0x69, 0x78, 0x65, 0x64, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x73, 0x68, 0x77, 0x69, 0x6e, 0x79, 0x75, 0x65,
+	0x2f, 0x6f, 0x6e, 0x65, 0x2d, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_apiserver_v1_consistency_proto_rawDescOnce sync.Once
+	file_apiserver_v1_consistency_proto_rawDescData = file_apiserver_v1_consistency_proto_rawDesc
+)
+
+func file_apiserver_v1_consistency_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_consistency_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_consistency_proto_rawDescData = protoimpl.X.CompressGZIP(file_apiserver_v1_consistency_proto_rawDescData)
+	})
+	return file_apiserver_v1_consistency_proto_rawDescData
+}
+
+var file_apiserver_v1_consistency_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_apiserver_v1_consistency_proto_goTypes = []any{
+	(*ConsistencyIssue)(nil),         // 0: v1.ConsistencyIssue
+	(*CheckConsistencyRequest)(nil),  // 1: v1.CheckConsistencyRequest
+	(*CheckConsistencyResponse)(nil), // 2: v1.CheckConsistencyResponse
+}
+var file_apiserver_v1_consistency_proto_depIdxs = []int32{
+	0, // 0: v1.CheckConsistencyResponse.issues:type_name -> v1.ConsistencyIssue
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_consistency_proto_init() }
+func file_apiserver_v1_consistency_proto_init() {
+	if File_apiserver_v1_consistency_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_apiserver_v1_consistency_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_consistency_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_consistency_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_consistency_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_consistency_proto = out.File
+	file_apiserver_v1_consistency_proto_rawDesc = nil
+	file_apiserver_v1_consistency_proto_goTypes = nil
+	file_apiserver_v1_consistency_proto_depIdxs = nil
+}
@@ -28,6 +28,9 @@ func (x *Permission) Default() {
 func (x *GetUserPermissionsRequest) Default() {
 }
 
+func (x *PermissionSource) Default() {
+}
+
 func (x *GetUserPermissionsResponse) Default() {
 }
 
@@ -42,3 +45,24 @@ func (x *CheckAPIAccessRequest) Default() {
 
 func (x *CheckAPIAccessResponse) Default() {
 }
+
+func (x *UpdatePermissionConditionRequest) Default() {
+}
+
+func (x *UpdatePermissionConditionResponse) Default() {
+}
+
+func (x *ExplainAccessRequest) Default() {
+}
+
+func (x *TraceRole) Default() {
+}
+
+func (x *TracePermission) Default() {
+}
+
+func (x *TracePolicy) Default() {
+}
+
+func (x *ExplainAccessResponse) Default() {
+}
@@ -28,9 +28,15 @@ func (x *CreateMenuWithPermissionsRequest) Default() {
 func (x *CreateMenuWithPermissionsResponse) Default() {
 }
 
+func (x *PermissionAssignmentChange) Default() {
+}
+
 func (x *PreviewPermissionAssignmentRequest) Default() {
 }
 
+func (x *UserAccessDiff) Default() {
+}
+
 func (x *PreviewPermissionAssignmentResponse) Default() {
 }
 
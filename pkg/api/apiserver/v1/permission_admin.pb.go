@@ -48,6 +48,10 @@ type CreateMenuWithPermissionsRequest struct {
 	Actions []string `protobuf:"bytes,6,rep,name=actions,proto3" json:"actions,omitempty"`
 	// visible 表示是否可见
 	Visible bool `protobuf:"varint,7,opt,name=visible,proto3" json:"visible,omitempty"`
+	// component 表示前端组件路径
+	Component string `protobuf:"bytes,8,opt,name=component,proto3" json:"component,omitempty"`
+	// module 表示权限模块，生成的权限名称为 {module}:{action}，为空时取路由路径的最后一段
+	Module string `protobuf:"bytes,9,opt,name=module,proto3" json:"module,omitempty"`
 }
 
 func (x *CreateMenuWithPermissionsRequest) Reset() {
@@ -129,6 +133,20 @@ func (x *CreateMenuWithPermissionsRequest) GetVisible() bool {
 	return false
 }
 
+func (x *CreateMenuWithPermissionsRequest) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *CreateMenuWithPermissionsRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
 // CreateMenuWithPermissionsResponse 创建菜单并自动生成权限响应
 type CreateMenuWithPermissionsResponse struct {
 	state         protoimpl.MessageState
@@ -194,19 +212,104 @@ func (x *CreateMenuWithPermissionsResponse) GetAutoAssignedRoles() []string {
 	return nil
 }
 
+// PermissionAssignmentChange 表示一项待预览的权限分配变更
+type PermissionAssignmentChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// op 表示变更类型：grant_permission、revoke_permission、add_user_role、remove_user_role
+	Op string `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	// role_id 表示角色ID
+	RoleId int64 `protobuf:"varint,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	// permission_id 表示权限ID，用于授予和撤销权限
+	PermissionId int64 `protobuf:"varint,3,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`
+	// effect 表示授予的规则效果：allow 或 deny，默认为 allow
+	Effect string `protobuf:"bytes,4,opt,name=effect,proto3" json:"effect,omitempty"`
+	// user_id 表示用户ID，用于添加和移除用户角色
+	UserId int64 `protobuf:"varint,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *PermissionAssignmentChange) Reset() {
+	*x = PermissionAssignmentChange{}
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PermissionAssignmentChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PermissionAssignmentChange) ProtoMessage() {}
+
+func (x *PermissionAssignmentChange) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PermissionAssignmentChange.ProtoReflect.Descriptor instead.
+func (*PermissionAssignmentChange) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PermissionAssignmentChange) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *PermissionAssignmentChange) GetRoleId() int64 {
+	if x != nil {
+		return x.RoleId
+	}
+	return 0
+}
+
+func (x *PermissionAssignmentChange) GetPermissionId() int64 {
+	if x != nil {
+		return x.PermissionId
+	}
+	return 0
+}
+
+func (x *PermissionAssignmentChange) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+func (x *PermissionAssignmentChange) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
 // PreviewPermissionAssignmentRequest 预览权限分配请求
 type PreviewPermissionAssignmentRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// permission_name 表示权限名称
-	PermissionName string `protobuf:"bytes,1,opt,name=permission_name,json=permissionName,proto3" json:"permission_name,omitempty"`
+	// tenant_id 表示租户ID
+	TenantId int64 `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// changes 表示按顺序应用的变更
+	Changes []*PermissionAssignmentChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
 }
 
 func (x *PreviewPermissionAssignmentRequest) Reset() {
 	*x = PreviewPermissionAssignmentRequest{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[2]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -218,7 +321,7 @@ func (x *PreviewPermissionAssignmentRequest) String() string {
 func (*PreviewPermissionAssignmentRequest) ProtoMessage() {}
 
 func (x *PreviewPermissionAssignmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[2]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -231,35 +334,147 @@ func (x *PreviewPermissionAssignmentRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use PreviewPermissionAssignmentRequest.ProtoReflect.Descriptor instead.
 func (*PreviewPermissionAssignmentRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{2}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *PreviewPermissionAssignmentRequest) GetPermissionName() string {
+func (x *PreviewPermissionAssignmentRequest) GetTenantId() int64 {
 	if x != nil {
-		return x.PermissionName
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *PreviewPermissionAssignmentRequest) GetChanges() []*PermissionAssignmentChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// UserAccessDiff 表示一个受影响用户的生效权限和可访问菜单的变化
+type UserAccessDiff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// user_id 表示用户ID
+	UserId int64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// username 表示用户名
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// super_admin_before、super_admin_after 表示变更前后是否为超级管理员
+	SuperAdminBefore bool `protobuf:"varint,3,opt,name=super_admin_before,json=superAdminBefore,proto3" json:"super_admin_before,omitempty"`
+	SuperAdminAfter  bool `protobuf:"varint,4,opt,name=super_admin_after,json=superAdminAfter,proto3" json:"super_admin_after,omitempty"`
+	// added_permissions 表示变更后新增的生效权限
+	AddedPermissions []*Permission `protobuf:"bytes,5,rep,name=added_permissions,json=addedPermissions,proto3" json:"added_permissions,omitempty"`
+	// removed_permissions 表示变更后失去的生效权限
+	RemovedPermissions []*Permission `protobuf:"bytes,6,rep,name=removed_permissions,json=removedPermissions,proto3" json:"removed_permissions,omitempty"`
+	// added_menus 表示变更后新增的可访问菜单
+	AddedMenus []*Menu `protobuf:"bytes,7,rep,name=added_menus,json=addedMenus,proto3" json:"added_menus,omitempty"`
+	// removed_menus 表示变更后失去的可访问菜单
+	RemovedMenus []*Menu `protobuf:"bytes,8,rep,name=removed_menus,json=removedMenus,proto3" json:"removed_menus,omitempty"`
+}
+
+func (x *UserAccessDiff) Reset() {
+	*x = UserAccessDiff{}
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserAccessDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserAccessDiff) ProtoMessage() {}
+
+func (x *UserAccessDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserAccessDiff.ProtoReflect.Descriptor instead.
+func (*UserAccessDiff) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UserAccessDiff) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserAccessDiff) GetUsername() string {
+	if x != nil {
+		return x.Username
 	}
 	return ""
 }
 
+func (x *UserAccessDiff) GetSuperAdminBefore() bool {
+	if x != nil {
+		return x.SuperAdminBefore
+	}
+	return false
+}
+
+func (x *UserAccessDiff) GetSuperAdminAfter() bool {
+	if x != nil {
+		return x.SuperAdminAfter
+	}
+	return false
+}
+
+func (x *UserAccessDiff) GetAddedPermissions() []*Permission {
+	if x != nil {
+		return x.AddedPermissions
+	}
+	return nil
+}
+
+func (x *UserAccessDiff) GetRemovedPermissions() []*Permission {
+	if x != nil {
+		return x.RemovedPermissions
+	}
+	return nil
+}
+
+func (x *UserAccessDiff) GetAddedMenus() []*Menu {
+	if x != nil {
+		return x.AddedMenus
+	}
+	return nil
+}
+
+func (x *UserAccessDiff) GetRemovedMenus() []*Menu {
+	if x != nil {
+		return x.RemovedMenus
+	}
+	return nil
+}
+
 // PreviewPermissionAssignmentResponse 预览权限分配响应
 type PreviewPermissionAssignmentResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// permission_name 表示权限名称
-	PermissionName string `protobuf:"bytes,1,opt,name=permission_name,json=permissionName,proto3" json:"permission_name,omitempty"`
-	// will_assign_to_super_admin 表示是否会分配给超级管理员
-	WillAssignToSuperAdmin bool `protobuf:"varint,2,opt,name=will_assign_to_super_admin,json=willAssignToSuperAdmin,proto3" json:"will_assign_to_super_admin,omitempty"`
-	// will_assign_to_admin 表示是否会分配给系统管理员
-	WillAssignToAdmin bool `protobuf:"varint,3,opt,name=will_assign_to_admin,json=willAssignToAdmin,proto3" json:"will_assign_to_admin,omitempty"`
-	// admin_assign_reason 表示管理员分配原因
-	AdminAssignReason string `protobuf:"bytes,4,opt,name=admin_assign_reason,json=adminAssignReason,proto3" json:"admin_assign_reason,omitempty"`
+	// tenant_id 表示租户ID
+	TenantId int64 `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// users 表示受影响的用户，没有变化的用户差异为空
+	Users []*UserAccessDiff `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`
 }
 
 func (x *PreviewPermissionAssignmentResponse) Reset() {
 	*x = PreviewPermissionAssignmentResponse{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[3]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -271,7 +486,7 @@ func (x *PreviewPermissionAssignmentResponse) String() string {
 func (*PreviewPermissionAssignmentResponse) ProtoMessage() {}
 
 func (x *PreviewPermissionAssignmentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[3]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -284,35 +499,21 @@ func (x *PreviewPermissionAssignmentResponse) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use PreviewPermissionAssignmentResponse.ProtoReflect.Descriptor instead.
 func (*PreviewPermissionAssignmentResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *PreviewPermissionAssignmentResponse) GetPermissionName() string {
-	if x != nil {
-		return x.PermissionName
-	}
-	return ""
-}
-
-func (x *PreviewPermissionAssignmentResponse) GetWillAssignToSuperAdmin() bool {
-	if x != nil {
-		return x.WillAssignToSuperAdmin
-	}
-	return false
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *PreviewPermissionAssignmentResponse) GetWillAssignToAdmin() bool {
+func (x *PreviewPermissionAssignmentResponse) GetTenantId() int64 {
 	if x != nil {
-		return x.WillAssignToAdmin
+		return x.TenantId
 	}
-	return false
+	return 0
 }
 
-func (x *PreviewPermissionAssignmentResponse) GetAdminAssignReason() string {
+func (x *PreviewPermissionAssignmentResponse) GetUsers() []*UserAccessDiff {
 	if x != nil {
-		return x.AdminAssignReason
+		return x.Users
 	}
-	return ""
+	return nil
 }
 
 // SyncAdminPermissionsRequest 同步管理员权限请求
@@ -323,11 +524,13 @@ type SyncAdminPermissionsRequest struct {
 
 	// tenant_id 表示租户ID
 	TenantId int64 `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// dry_run 表示只报告缺失的权限，不进行分配
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 }
 
 func (x *SyncAdminPermissionsRequest) Reset() {
 	*x = SyncAdminPermissionsRequest{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[4]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -339,7 +542,7 @@ func (x *SyncAdminPermissionsRequest) String() string {
 func (*SyncAdminPermissionsRequest) ProtoMessage() {}
 
 func (x *SyncAdminPermissionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[4]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,7 +555,7 @@ func (x *SyncAdminPermissionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncAdminPermissionsRequest.ProtoReflect.Descriptor instead.
 func (*SyncAdminPermissionsRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{4}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SyncAdminPermissionsRequest) GetTenantId() int64 {
@@ -362,21 +565,32 @@ func (x *SyncAdminPermissionsRequest) GetTenantId() int64 {
 	return 0
 }
 
+func (x *SyncAdminPermissionsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 // SyncAdminPermissionsResponse 同步管理员权限响应
 type SyncAdminPermissionsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// assigned_count 表示分配的权限数量
+	// assigned_count 表示分配的权限数量，dry_run 时为将要分配的数量
 	AssignedCount int32 `protobuf:"varint,1,opt,name=assigned_count,json=assignedCount,proto3" json:"assigned_count,omitempty"`
 	// sync_details 表示同步详情
 	SyncDetails []*SyncDetail `protobuf:"bytes,2,rep,name=sync_details,json=syncDetails,proto3" json:"sync_details,omitempty"`
+	// dry_run 表示本次是否只做了检查
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// missing_permissions 表示同步前缺失的权限
+	MissingPermissions []*MissingPermission `protobuf:"bytes,4,rep,name=missing_permissions,json=missingPermissions,proto3" json:"missing_permissions,omitempty"`
 }
 
 func (x *SyncAdminPermissionsResponse) Reset() {
 	*x = SyncAdminPermissionsResponse{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[5]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -388,7 +602,7 @@ func (x *SyncAdminPermissionsResponse) String() string {
 func (*SyncAdminPermissionsResponse) ProtoMessage() {}
 
 func (x *SyncAdminPermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[5]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -401,7 +615,7 @@ func (x *SyncAdminPermissionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncAdminPermissionsResponse.ProtoReflect.Descriptor instead.
 func (*SyncAdminPermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{5}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SyncAdminPermissionsResponse) GetAssignedCount() int32 {
@@ -418,6 +632,20 @@ func (x *SyncAdminPermissionsResponse) GetSyncDetails() []*SyncDetail {
 	return nil
 }
 
+func (x *SyncAdminPermissionsResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *SyncAdminPermissionsResponse) GetMissingPermissions() []*MissingPermission {
+	if x != nil {
+		return x.MissingPermissions
+	}
+	return nil
+}
+
 // SyncDetail 同步详情
 type SyncDetail struct {
 	state         protoimpl.MessageState
@@ -428,11 +656,13 @@ type SyncDetail struct {
 	RoleName string `protobuf:"bytes,1,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
 	// assigned_permissions 表示分配的权限数量
 	AssignedPermissions int32 `protobuf:"varint,2,opt,name=assigned_permissions,json=assignedPermissions,proto3" json:"assigned_permissions,omitempty"`
+	// role_id 表示角色ID
+	RoleId int64 `protobuf:"varint,3,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
 }
 
 func (x *SyncDetail) Reset() {
 	*x = SyncDetail{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[6]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -444,7 +674,7 @@ func (x *SyncDetail) String() string {
 func (*SyncDetail) ProtoMessage() {}
 
 func (x *SyncDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[6]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,7 +687,7 @@ func (x *SyncDetail) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncDetail.ProtoReflect.Descriptor instead.
 func (*SyncDetail) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{6}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SyncDetail) GetRoleName() string {
@@ -474,6 +704,13 @@ func (x *SyncDetail) GetAssignedPermissions() int32 {
 	return 0
 }
 
+func (x *SyncDetail) GetRoleId() int64 {
+	if x != nil {
+		return x.RoleId
+	}
+	return 0
+}
+
 // GetAutoAssignConfigRequest 获取自动分配配置请求
 type GetAutoAssignConfigRequest struct {
 	state         protoimpl.MessageState
@@ -481,12 +718,13 @@ type GetAutoAssignConfigRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// tenant_id 表示租户ID
-	TenantId int64 `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// @gotags: form:"tenant_id"
+	TenantId int64 `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty" form:"tenant_id"`
 }
 
 func (x *GetAutoAssignConfigRequest) Reset() {
 	*x = GetAutoAssignConfigRequest{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[7]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -498,7 +736,7 @@ func (x *GetAutoAssignConfigRequest) String() string {
 func (*GetAutoAssignConfigRequest) ProtoMessage() {}
 
 func (x *GetAutoAssignConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[7]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -511,7 +749,7 @@ func (x *GetAutoAssignConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAutoAssignConfigRequest.ProtoReflect.Descriptor instead.
 func (*GetAutoAssignConfigRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{7}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetAutoAssignConfigRequest) GetTenantId() int64 {
@@ -539,7 +777,7 @@ type GetAutoAssignConfigResponse struct {
 
 func (x *GetAutoAssignConfigResponse) Reset() {
 	*x = GetAutoAssignConfigResponse{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[8]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -551,7 +789,7 @@ func (x *GetAutoAssignConfigResponse) String() string {
 func (*GetAutoAssignConfigResponse) ProtoMessage() {}
 
 func (x *GetAutoAssignConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[8]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,7 +802,7 @@ func (x *GetAutoAssignConfigResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAutoAssignConfigResponse.ProtoReflect.Descriptor instead.
 func (*GetAutoAssignConfigResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{8}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetAutoAssignConfigResponse) GetEnabled() bool {
@@ -613,7 +851,7 @@ type UpdateAutoAssignConfigRequest struct {
 
 func (x *UpdateAutoAssignConfigRequest) Reset() {
 	*x = UpdateAutoAssignConfigRequest{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[9]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -625,7 +863,7 @@ func (x *UpdateAutoAssignConfigRequest) String() string {
 func (*UpdateAutoAssignConfigRequest) ProtoMessage() {}
 
 func (x *UpdateAutoAssignConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[9]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -638,7 +876,7 @@ func (x *UpdateAutoAssignConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateAutoAssignConfigRequest.ProtoReflect.Descriptor instead.
 func (*UpdateAutoAssignConfigRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{9}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateAutoAssignConfigRequest) GetTenantId() int64 {
@@ -683,7 +921,7 @@ type UpdateAutoAssignConfigResponse struct {
 
 func (x *UpdateAutoAssignConfigResponse) Reset() {
 	*x = UpdateAutoAssignConfigResponse{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[10]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -695,7 +933,7 @@ func (x *UpdateAutoAssignConfigResponse) String() string {
 func (*UpdateAutoAssignConfigResponse) ProtoMessage() {}
 
 func (x *UpdateAutoAssignConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[10]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -708,7 +946,7 @@ func (x *UpdateAutoAssignConfigResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateAutoAssignConfigResponse.ProtoReflect.Descriptor instead.
 func (*UpdateAutoAssignConfigResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{10}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UpdateAutoAssignConfigResponse) GetSuccess() bool {
@@ -741,7 +979,7 @@ type RoleInfo struct {
 
 func (x *RoleInfo) Reset() {
 	*x = RoleInfo{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[11]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -753,7 +991,7 @@ func (x *RoleInfo) String() string {
 func (*RoleInfo) ProtoMessage() {}
 
 func (x *RoleInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[11]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -766,7 +1004,7 @@ func (x *RoleInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoleInfo.ProtoReflect.Descriptor instead.
 func (*RoleInfo) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{11}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *RoleInfo) GetId() int64 {
@@ -806,11 +1044,15 @@ type PermissionRule struct {
 	Include bool `protobuf:"varint,4,opt,name=include,proto3" json:"include,omitempty"`
 	// description 表示规则描述
 	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// role_ids 表示规则适用的角色，为空时适用于全部系统管理员角色
+	RoleIds []int64 `protobuf:"varint,6,rep,packed,name=role_ids,json=roleIds,proto3" json:"role_ids,omitempty"`
+	// resource_types 表示匹配的资源类型：api、menu、data、feature
+	ResourceTypes []string `protobuf:"bytes,7,rep,name=resource_types,json=resourceTypes,proto3" json:"resource_types,omitempty"`
 }
 
 func (x *PermissionRule) Reset() {
 	*x = PermissionRule{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[12]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -822,7 +1064,7 @@ func (x *PermissionRule) String() string {
 func (*PermissionRule) ProtoMessage() {}
 
 func (x *PermissionRule) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[12]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -835,7 +1077,7 @@ func (x *PermissionRule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PermissionRule.ProtoReflect.Descriptor instead.
 func (*PermissionRule) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{12}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PermissionRule) GetModules() []string {
@@ -873,6 +1115,20 @@ func (x *PermissionRule) GetDescription() string {
 	return ""
 }
 
+func (x *PermissionRule) GetRoleIds() []int64 {
+	if x != nil {
+		return x.RoleIds
+	}
+	return nil
+}
+
+func (x *PermissionRule) GetResourceTypes() []string {
+	if x != nil {
+		return x.ResourceTypes
+	}
+	return nil
+}
+
 // GetAdminMissingPermissionsRequest 获取管理员缺失权限请求
 type GetAdminMissingPermissionsRequest struct {
 	state         protoimpl.MessageState
@@ -880,14 +1136,16 @@ type GetAdminMissingPermissionsRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// tenant_id 表示租户ID
-	TenantId int64 `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// @gotags: form:"tenant_id"
+	TenantId int64 `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty" form:"tenant_id"`
 	// role_id 表示角色ID（可选，默认获取所有管理员角色）
-	RoleId int64 `protobuf:"varint,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	// @gotags: form:"role_id"
+	RoleId int64 `protobuf:"varint,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty" form:"role_id"`
 }
 
 func (x *GetAdminMissingPermissionsRequest) Reset() {
 	*x = GetAdminMissingPermissionsRequest{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[13]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -899,7 +1157,7 @@ func (x *GetAdminMissingPermissionsRequest) String() string {
 func (*GetAdminMissingPermissionsRequest) ProtoMessage() {}
 
 func (x *GetAdminMissingPermissionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[13]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -912,7 +1170,7 @@ func (x *GetAdminMissingPermissionsRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use GetAdminMissingPermissionsRequest.ProtoReflect.Descriptor instead.
 func (*GetAdminMissingPermissionsRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{13}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetAdminMissingPermissionsRequest) GetTenantId() int64 {
@@ -943,7 +1201,7 @@ type GetAdminMissingPermissionsResponse struct {
 
 func (x *GetAdminMissingPermissionsResponse) Reset() {
 	*x = GetAdminMissingPermissionsResponse{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[14]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -955,7 +1213,7 @@ func (x *GetAdminMissingPermissionsResponse) String() string {
 func (*GetAdminMissingPermissionsResponse) ProtoMessage() {}
 
 func (x *GetAdminMissingPermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[14]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -968,7 +1226,7 @@ func (x *GetAdminMissingPermissionsResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use GetAdminMissingPermissionsResponse.ProtoReflect.Descriptor instead.
 func (*GetAdminMissingPermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{14}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetAdminMissingPermissionsResponse) GetMissingPermissions() []*MissingPermission {
@@ -997,13 +1255,17 @@ type MissingPermission struct {
 	PermissionName string `protobuf:"bytes,2,opt,name=permission_name,json=permissionName,proto3" json:"permission_name,omitempty"`
 	// reason 表示缺失原因
 	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
-	// should_assign 表示是否应该分配
+	// should_assign 表示是否应该分配，角色对该权限有 deny 规则时为 false
 	ShouldAssign bool `protobuf:"varint,4,opt,name=should_assign,json=shouldAssign,proto3" json:"should_assign,omitempty"`
+	// role_id 表示缺失该权限的角色ID
+	RoleId int64 `protobuf:"varint,5,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	// role_name 表示缺失该权限的角色名称
+	RoleName string `protobuf:"bytes,6,opt,name=role_name,json=roleName,proto3" json:"role_name,omitempty"`
 }
 
 func (x *MissingPermission) Reset() {
 	*x = MissingPermission{}
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[15]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1015,7 +1277,7 @@ func (x *MissingPermission) String() string {
 func (*MissingPermission) ProtoMessage() {}
 
 func (x *MissingPermission) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[15]
+	mi := &file_apiserver_v1_permission_admin_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1028,7 +1290,7 @@ func (x *MissingPermission) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MissingPermission.ProtoReflect.Descriptor instead.
 func (*MissingPermission) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{15}
+	return file_apiserver_v1_permission_admin_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *MissingPermission) GetPermissionId() int64 {
@@ -1059,148 +1321,214 @@ func (x *MissingPermission) GetShouldAssign() bool {
 	return false
 }
 
+func (x *MissingPermission) GetRoleId() int64 {
+	if x != nil {
+		return x.RoleId
+	}
+	return 0
+}
+
+func (x *MissingPermission) GetRoleName() string {
+	if x != nil {
+		return x.RoleName
+	}
+	return ""
+}
+
 var File_apiserver_v1_permission_admin_proto protoreflect.FileDescriptor
 
 var file_apiserver_v1_permission_admin_proto_rawDesc = []byte{
 	0x0a, 0x23, 0x61, 0x70, 0x69, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x70,
 	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x22, 0xd9, 0x01, 0x0a, 0x20, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x57, 0x69, 0x74, 0x68, 0x50, 0x65, 0x72, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
-	0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74,
-	0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c,
-	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x50, 0x61, 0x74, 0x68,
-	0x12, 0x12, 0x0a, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x69, 0x63, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49,
-	0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76,
-	0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x76, 0x69,
-	0x73, 0x69, 0x62, 0x6c, 0x65, 0x22, 0x93, 0x01, 0x0a, 0x21, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x4d, 0x65, 0x6e, 0x75, 0x57, 0x69, 0x74, 0x68, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d,
-	0x65, 0x6e, 0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x65,
-	0x6e, 0x75, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0d, 0x70, 0x65,
-	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x61,
-	0x75, 0x74, 0x6f, 0x5f, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x6c,
-	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x61, 0x75, 0x74, 0x6f, 0x41, 0x73,
-	0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0x4d, 0x0a, 0x22, 0x50,
-	0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x65, 0x72, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xeb, 0x01, 0x0a, 0x23, 0x50,
-	0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x65, 0x72,
-	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x1a, 0x77,
-	0x69, 0x6c, 0x6c, 0x5f, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x5f, 0x74, 0x6f, 0x5f, 0x73, 0x75,
-	0x70, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x16, 0x77, 0x69, 0x6c, 0x6c, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x6f, 0x53, 0x75, 0x70,
-	0x65, 0x72, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x2f, 0x0a, 0x14, 0x77, 0x69, 0x6c, 0x6c, 0x5f,
-	0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x5f, 0x74, 0x6f, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x77, 0x69, 0x6c, 0x6c, 0x41, 0x73, 0x73, 0x69, 0x67,
-	0x6e, 0x54, 0x6f, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x64, 0x6d, 0x69,
-	0x6e, 0x5f, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x41, 0x73, 0x73, 0x69,
-	0x67, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x3a, 0x0a, 0x1b, 0x53, 0x79, 0x6e, 0x63,
-	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e,
-	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61,
-	0x6e, 0x74, 0x49, 0x64, 0x22, 0x78, 0x0a, 0x1c, 0x53, 0x79, 0x6e, 0x63, 0x41, 0x64, 0x6d, 0x69,
-	0x6e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
-	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x61, 0x73,
-	0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x0c, 0x73,
-	0x79, 0x6e, 0x63, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x44, 0x65, 0x74, 0x61, 0x69,
-	0x6c, 0x52, 0x0b, 0x73, 0x79, 0x6e, 0x63, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0x5c,
-	0x0a, 0x0a, 0x53, 0x79, 0x6e, 0x63, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x1b, 0x0a, 0x09,
-	0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x72, 0x6f, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x14, 0x61, 0x73, 0x73,
-	0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65,
-	0x64, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x39, 0x0a, 0x1a,
-	0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x6f, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65,
-	0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74,
-	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x22, 0xdf, 0x01, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x41,
-	0x75, 0x74, 0x6f, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
-	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
-	0x64, 0x12, 0x38, 0x0a, 0x11, 0x73, 0x75, 0x70, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e,
-	0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76,
-	0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0f, 0x73, 0x75, 0x70, 0x65,
-	0x72, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x0b, 0x61,
-	0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a,
-	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x10, 0x70, 0x65,
-	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x22, 0xbb, 0x01, 0x0a, 0x1d, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x6f, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x1a, 0x17, 0x61, 0x70, 0x69, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x65, 0x6e, 0x75, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1d, 0x61, 0x70, 0x69, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31,
+	0x2f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x8f, 0x02, 0x0a, 0x20, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x6e, 0x75,
+	0x57, 0x69, 0x74, 0x68, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x63, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x76, 0x69, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x6f, 0x64,
+	0x75, 0x6c, 0x65, 0x22, 0x93, 0x01, 0x0a, 0x21, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x65,
+	0x6e, 0x75, 0x57, 0x69, 0x74, 0x68, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x65, 0x6e,
+	0x75, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x65, 0x6e, 0x75,
+	0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0d, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x75, 0x74,
+	0x6f, 0x5f, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x61, 0x75, 0x74, 0x6f, 0x41, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0x9b, 0x01, 0x0a, 0x1a, 0x50, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65,
+	0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49,
+	0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x7b, 0x0a, 0x22, 0x50, 0x72, 0x65, 0x76, 0x69,
+	0x65, 0x77, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x22, 0xf7, 0x02, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x44, 0x69, 0x66, 0x66, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12,
+	0x73, 0x75, 0x70, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x65, 0x66, 0x6f,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x73, 0x75, 0x70, 0x65, 0x72, 0x41,
+	0x64, 0x6d, 0x69, 0x6e, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x75,
+	0x70, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x73, 0x75, 0x70, 0x65, 0x72, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x11, 0x61, 0x64, 0x64, 0x65, 0x64, 0x5f,
+	0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x10, 0x61, 0x64, 0x64, 0x65, 0x64, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x3f, 0x0a, 0x13, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x5f, 0x70,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x12, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x6d, 0x65,
+	0x6e, 0x75, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x65, 0x6e, 0x75, 0x52, 0x0a, 0x61, 0x64, 0x64, 0x65, 0x64, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0x12,
+	0x2d, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x5f, 0x6d, 0x65, 0x6e, 0x75, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6e, 0x75,
+	0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0x22, 0x6c,
+	0x0a, 0x23, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x28, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x44, 0x69, 0x66, 0x66, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x22, 0x53, 0x0a, 0x1b,
+	0x53, 0x79, 0x6e, 0x63, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74,
 	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
-	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62,
-	0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
-	0x65, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x6f, 0x6c, 0x65,
-	0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0c, 0x61, 0x64, 0x6d, 0x69,
-	0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x73, 0x12, 0x3d, 0x0a, 0x10, 0x70, 0x65, 0x72, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x54, 0x0a, 0x1e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x41, 0x75, 0x74, 0x6f, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63,
-	0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x50, 0x0a,
-	0x08, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a,
-	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22,
-	0xa5, 0x01, 0x0a, 0x0e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x75,
-	0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
-	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65,
-	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x69,
-	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x6e,
-	0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x59, 0x0a, 0x21, 0x47, 0x65, 0x74, 0x41, 0x64,
-	0x6d, 0x69, 0x6e, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
-	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c,
-	0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65,
-	0x49, 0x64, 0x22, 0x91, 0x01, 0x0a, 0x22, 0x47, 0x65, 0x74, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4d,
-	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x13, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x73, 0x73,
-	0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4d,
-	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x22, 0x9e, 0x01, 0x0a, 0x11, 0x4d, 0x69, 0x73, 0x73, 0x69,
-	0x6e, 0x67, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
-	0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
-	0x64, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x65, 0x72, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
-	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
-	0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x5f, 0x61, 0x73, 0x73,
-	0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x68, 0x6f, 0x75, 0x6c,
-	0x64, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f,
+	0x72, 0x75, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75,
+	0x6e, 0x22, 0xd9, 0x01, 0x0a, 0x1c, 0x53, 0x79, 0x6e, 0x63, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x50,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x61, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x0c, 0x73, 0x79, 0x6e,
+	0x63, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52,
+	0x0b, 0x73, 0x79, 0x6e, 0x63, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x17, 0x0a, 0x07,
+	0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64,
+	0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x46, 0x0a, 0x13, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67,
+	0x5f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x50,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6e, 0x67, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x75, 0x0a,
+	0x0a, 0x53, 0x79, 0x6e, 0x63, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x72,
+	0x6f, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x72, 0x6f, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x14, 0x61, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x72,
+	0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f,
+	0x6c, 0x65, 0x49, 0x64, 0x22, 0x39, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x6f, 0x41,
+	0x73, 0x73, 0x69, 0x67, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x22,
+	0xdf, 0x01, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x41, 0x75, 0x74, 0x6f, 0x41, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x38, 0x0a, 0x11, 0x73, 0x75, 0x70,
+	0x65, 0x72, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x0f, 0x73, 0x75, 0x70, 0x65, 0x72, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x6f,
+	0x6c, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x6f, 0x6c,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f,
+	0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x6f, 0x6c,
+	0x65, 0x73, 0x12, 0x3d, 0x0a, 0x10, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65,
+	0x52, 0x0f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65,
+	0x73, 0x22, 0xbb, 0x01, 0x0a, 0x1d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x6f,
+	0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x03, 0x52, 0x0c, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x73,
+	0x12, 0x3d, 0x0a, 0x10, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0f,
+	0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x22,
+	0x54, 0x0a, 0x1e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x6f, 0x41, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x50, 0x0a, 0x08, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xe7, 0x01, 0x0a, 0x0e, 0x50, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f,
+	0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x6f, 0x64,
+	0x75, 0x6c, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x19, 0x0a, 0x08, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x73, 0x22, 0x59, 0x0a, 0x21, 0x47, 0x65, 0x74, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4d, 0x69, 0x73,
+	0x73, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x91, 0x01, 0x0a,
+	0x22, 0x47, 0x65, 0x74, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67,
+	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x13, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x70,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67,
+	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67,
+	0x22, 0xd4, 0x01, 0x0a, 0x11, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x70,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
+	0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x5f, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x41, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x6f,
+	0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
+	0x6f, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75,
 	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x73, 0x68, 0x77, 0x69, 0x6e, 0x79, 0x75, 0x65, 0x2f,
 	0x6f, 0x6e, 0x65, 0x2d, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69,
 	0x2f, 0x61, 0x70, 0x69, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31,
@@ -1219,37 +1547,48 @@ func file_apiserver_v1_permission_admin_proto_rawDescGZIP() []byte {
 	return file_apiserver_v1_permission_admin_proto_rawDescData
 }
 
-var file_apiserver_v1_permission_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_apiserver_v1_permission_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_apiserver_v1_permission_admin_proto_goTypes = []any{
 	(*CreateMenuWithPermissionsRequest)(nil),    // 0: v1.CreateMenuWithPermissionsRequest
 	(*CreateMenuWithPermissionsResponse)(nil),   // 1: v1.CreateMenuWithPermissionsResponse
-	(*PreviewPermissionAssignmentRequest)(nil),  // 2: v1.PreviewPermissionAssignmentRequest
-	(*PreviewPermissionAssignmentResponse)(nil), // 3: v1.PreviewPermissionAssignmentResponse
-	(*SyncAdminPermissionsRequest)(nil),         // 4: v1.SyncAdminPermissionsRequest
-	(*SyncAdminPermissionsResponse)(nil),        // 5: v1.SyncAdminPermissionsResponse
-	(*SyncDetail)(nil),                          // 6: v1.SyncDetail
-	(*GetAutoAssignConfigRequest)(nil),          // 7: v1.GetAutoAssignConfigRequest
-	(*GetAutoAssignConfigResponse)(nil),         // 8: v1.GetAutoAssignConfigResponse
-	(*UpdateAutoAssignConfigRequest)(nil),       // 9: v1.UpdateAutoAssignConfigRequest
-	(*UpdateAutoAssignConfigResponse)(nil),      // 10: v1.UpdateAutoAssignConfigResponse
-	(*RoleInfo)(nil),                            // 11: v1.RoleInfo
-	(*PermissionRule)(nil),                      // 12: v1.PermissionRule
-	(*GetAdminMissingPermissionsRequest)(nil),   // 13: v1.GetAdminMissingPermissionsRequest
-	(*GetAdminMissingPermissionsResponse)(nil),  // 14: v1.GetAdminMissingPermissionsResponse
-	(*MissingPermission)(nil),                   // 15: v1.MissingPermission
+	(*PermissionAssignmentChange)(nil),          // 2: v1.PermissionAssignmentChange
+	(*PreviewPermissionAssignmentRequest)(nil),  // 3: v1.PreviewPermissionAssignmentRequest
+	(*UserAccessDiff)(nil),                      // 4: v1.UserAccessDiff
+	(*PreviewPermissionAssignmentResponse)(nil), // 5: v1.PreviewPermissionAssignmentResponse
+	(*SyncAdminPermissionsRequest)(nil),         // 6: v1.SyncAdminPermissionsRequest
+	(*SyncAdminPermissionsResponse)(nil),        // 7: v1.SyncAdminPermissionsResponse
+	(*SyncDetail)(nil),                          // 8: v1.SyncDetail
+	(*GetAutoAssignConfigRequest)(nil),          // 9: v1.GetAutoAssignConfigRequest
+	(*GetAutoAssignConfigResponse)(nil),         // 10: v1.GetAutoAssignConfigResponse
+	(*UpdateAutoAssignConfigRequest)(nil),       // 11: v1.UpdateAutoAssignConfigRequest
+	(*UpdateAutoAssignConfigResponse)(nil),      // 12: v1.UpdateAutoAssignConfigResponse
+	(*RoleInfo)(nil),                            // 13: v1.RoleInfo
+	(*PermissionRule)(nil),                      // 14: v1.PermissionRule
+	(*GetAdminMissingPermissionsRequest)(nil),   // 15: v1.GetAdminMissingPermissionsRequest
+	(*GetAdminMissingPermissionsResponse)(nil),  // 16: v1.GetAdminMissingPermissionsResponse
+	(*MissingPermission)(nil),                   // 17: v1.MissingPermission
+	(*Permission)(nil),                          // 18: v1.Permission
+	(*Menu)(nil),                                // 19: v1.Menu
 }
 var file_apiserver_v1_permission_admin_proto_depIdxs = []int32{
-	6,  // 0: v1.SyncAdminPermissionsResponse.sync_details:type_name -> v1.SyncDetail
-	11, // 1: v1.GetAutoAssignConfigResponse.super_admin_roles:type_name -> v1.RoleInfo
-	11, // 2: v1.GetAutoAssignConfigResponse.admin_roles:type_name -> v1.RoleInfo
-	12, // 3: v1.GetAutoAssignConfigResponse.permission_rules:type_name -> v1.PermissionRule
-	12, // 4: v1.UpdateAutoAssignConfigRequest.permission_rules:type_name -> v1.PermissionRule
-	15, // 5: v1.GetAdminMissingPermissionsResponse.missing_permissions:type_name -> v1.MissingPermission
-	6,  // [6:6] is the sub-list for method output_type
-	6,  // [6:6] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	2,  // 0: v1.PreviewPermissionAssignmentRequest.changes:type_name -> v1.PermissionAssignmentChange
+	18, // 1: v1.UserAccessDiff.added_permissions:type_name -> v1.Permission
+	18, // 2: v1.UserAccessDiff.removed_permissions:type_name -> v1.Permission
+	19, // 3: v1.UserAccessDiff.added_menus:type_name -> v1.Menu
+	19, // 4: v1.UserAccessDiff.removed_menus:type_name -> v1.Menu
+	4,  // 5: v1.PreviewPermissionAssignmentResponse.users:type_name -> v1.UserAccessDiff
+	8,  // 6: v1.SyncAdminPermissionsResponse.sync_details:type_name -> v1.SyncDetail
+	17, // 7: v1.SyncAdminPermissionsResponse.missing_permissions:type_name -> v1.MissingPermission
+	13, // 8: v1.GetAutoAssignConfigResponse.super_admin_roles:type_name -> v1.RoleInfo
+	13, // 9: v1.GetAutoAssignConfigResponse.admin_roles:type_name -> v1.RoleInfo
+	14, // 10: v1.GetAutoAssignConfigResponse.permission_rules:type_name -> v1.PermissionRule
+	14, // 11: v1.UpdateAutoAssignConfigRequest.permission_rules:type_name -> v1.PermissionRule
+	17, // 12: v1.GetAdminMissingPermissionsResponse.missing_permissions:type_name -> v1.MissingPermission
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_apiserver_v1_permission_admin_proto_init() }
@@ -1257,13 +1596,15 @@ func file_apiserver_v1_permission_admin_proto_init() {
 	if File_apiserver_v1_permission_admin_proto != nil {
 		return
 	}
+	file_apiserver_v1_menu_proto_init()
+	file_apiserver_v1_permission_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_apiserver_v1_permission_admin_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,98 @@
+// 平台运营 API 定义
+
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Code generated by protoc-gen-defaults. DO NOT EDIT.
+
+package v1
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	_ *timestamppb.Timestamp
+	_ *durationpb.Duration
+	_ *wrapperspb.BoolValue
+)
+
+func (x *ListAllTenantsRequest) Default() {
+}
+
+func (x *ListAllTenantsResponse) Default() {
+}
+
+func (x *CreateTenantRequest) Default() {
+}
+
+func (x *CreateTenantResponse) Default() {
+}
+
+func (x *UpdateTenantStatusRequest) Default() {
+}
+
+func (x *UpdateTenantStatusResponse) Default() {
+}
+
+func (x *AssignTenantOwnerRequest) Default() {
+}
+
+func (x *AssignTenantOwnerResponse) Default() {
+}
+
+func (x *PlatformOperator) Default() {
+}
+
+func (x *ListPlatformOperatorsRequest) Default() {
+}
+
+func (x *ListPlatformOperatorsResponse) Default() {
+}
+
+func (x *AddPlatformOperatorRequest) Default() {
+}
+
+func (x *AddPlatformOperatorResponse) Default() {
+}
+
+func (x *RemovePlatformOperatorRequest) Default() {
+}
+
+func (x *RemovePlatformOperatorResponse) Default() {
+}
+
+func (x *PlatformAuditLog) Default() {
+}
+
+func (x *ListPlatformAuditLogsRequest) Default() {
+}
+
+func (x *ListPlatformAuditLogsResponse) Default() {
+}
+
+func (x *ServiceClient) Default() {
+}
+
+func (x *CreateServiceClientRequest) Default() {
+}
+
+func (x *CreateServiceClientResponse) Default() {
+}
+
+func (x *ListServiceClientsRequest) Default() {
+}
+
+func (x *ListServiceClientsResponse) Default() {
+}
+
+func (x *RevokeServiceClientRequest) Default() {
+}
+
+func (x *RevokeServiceClientResponse) Default() {
+}
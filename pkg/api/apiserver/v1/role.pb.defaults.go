@@ -40,6 +40,9 @@ func (x *GetRoleResponse) Default() {
 func (x *GetRolePermissionsRequest) Default() {
 }
 
+func (x *RolePermissionGrant) Default() {
+}
+
 func (x *GetRolePermissionsResponse) Default() {
 }
 
@@ -49,6 +52,24 @@ func (x *AssignRolePermissionsRequest) Default() {
 func (x *AssignRolePermissionsResponse) Default() {
 }
 
+func (x *RevokeRolePermissionsRequest) Default() {
+}
+
+func (x *RevokeRolePermissionsResponse) Default() {
+}
+
+func (x *GetRoleParentsRequest) Default() {
+}
+
+func (x *GetRoleParentsResponse) Default() {
+}
+
+func (x *SetRoleParentsRequest) Default() {
+}
+
+func (x *SetRoleParentsResponse) Default() {
+}
+
 func (x *GetUserRolesRequest) Default() {
 }
 
@@ -49,6 +49,12 @@ type Role struct {
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	// updated_at 表示更新时间
 	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// data_scope 表示数据权限范围：all、tenant、dept、self、custom
+	DataScope string `protobuf:"bytes,8,opt,name=data_scope,json=dataScope,proto3" json:"data_scope,omitempty"`
+	// data_scope_dept_ids 表示 custom 数据权限可见的部门ID列表
+	DataScopeDeptIds []int64 `protobuf:"varint,9,rep,packed,name=data_scope_dept_ids,json=dataScopeDeptIds,proto3" json:"data_scope_dept_ids,omitempty"`
+	// system_role 表示系统内置角色：tenant_owner、tenant_admin，为空表示普通角色
+	SystemRole string `protobuf:"bytes,10,opt,name=system_role,json=systemRole,proto3" json:"system_role,omitempty"`
 }
 
 func (x *Role) Reset() {
@@ -130,6 +136,27 @@ func (x *Role) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Role) GetDataScope() string {
+	if x != nil {
+		return x.DataScope
+	}
+	return ""
+}
+
+func (x *Role) GetDataScopeDeptIds() []int64 {
+	if x != nil {
+		return x.DataScopeDeptIds
+	}
+	return nil
+}
+
+func (x *Role) GetSystemRole() string {
+	if x != nil {
+		return x.SystemRole
+	}
+	return ""
+}
+
 // ListRolesRequest 表示角色列表请求
 type ListRolesRequest struct {
 	state         protoimpl.MessageState
@@ -355,7 +382,8 @@ type GetRolePermissionsRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// role_id 表示角色ID
-	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	// @gotags: uri:"roleID"
+	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty" uri:"roleID"`
 }
 
 func (x *GetRolePermissionsRequest) Reset() {
@@ -395,6 +423,62 @@ func (x *GetRolePermissionsRequest) GetRoleId() int64 {
 	return 0
 }
 
+// RolePermissionGrant 表示角色的一条权限规则
+type RolePermissionGrant struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// permission 表示权限信息
+	Permission *Permission `protobuf:"bytes,1,opt,name=permission,proto3" json:"permission,omitempty"`
+	// effect 表示规则效果：allow 或 deny
+	Effect string `protobuf:"bytes,2,opt,name=effect,proto3" json:"effect,omitempty"`
+}
+
+func (x *RolePermissionGrant) Reset() {
+	*x = RolePermissionGrant{}
+	mi := &file_apiserver_v1_role_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RolePermissionGrant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RolePermissionGrant) ProtoMessage() {}
+
+func (x *RolePermissionGrant) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_role_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RolePermissionGrant.ProtoReflect.Descriptor instead.
+func (*RolePermissionGrant) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RolePermissionGrant) GetPermission() *Permission {
+	if x != nil {
+		return x.Permission
+	}
+	return nil
+}
+
+func (x *RolePermissionGrant) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
 // GetRolePermissionsResponse 表示获取角色权限响应
 type GetRolePermissionsResponse struct {
 	state         protoimpl.MessageState
@@ -403,11 +487,13 @@ type GetRolePermissionsResponse struct {
 
 	// permissions 表示权限列表
 	Permissions []*Permission `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// grants 表示带规则效果的权限列表
+	Grants []*RolePermissionGrant `protobuf:"bytes,2,rep,name=grants,proto3" json:"grants,omitempty"`
 }
 
 func (x *GetRolePermissionsResponse) Reset() {
 	*x = GetRolePermissionsResponse{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[6]
+	mi := &file_apiserver_v1_role_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -419,7 +505,7 @@ func (x *GetRolePermissionsResponse) String() string {
 func (*GetRolePermissionsResponse) ProtoMessage() {}
 
 func (x *GetRolePermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[6]
+	mi := &file_apiserver_v1_role_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -432,7 +518,7 @@ func (x *GetRolePermissionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRolePermissionsResponse.ProtoReflect.Descriptor instead.
 func (*GetRolePermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{6}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetRolePermissionsResponse) GetPermissions() []*Permission {
@@ -442,6 +528,13 @@ func (x *GetRolePermissionsResponse) GetPermissions() []*Permission {
 	return nil
 }
 
+func (x *GetRolePermissionsResponse) GetGrants() []*RolePermissionGrant {
+	if x != nil {
+		return x.Grants
+	}
+	return nil
+}
+
 // AssignRolePermissionsRequest 表示分配角色权限请求
 type AssignRolePermissionsRequest struct {
 	state         protoimpl.MessageState
@@ -449,14 +542,17 @@ type AssignRolePermissionsRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// role_id 表示角色ID
-	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	// @gotags: uri:"roleID"
+	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty" uri:"roleID"`
 	// permission_ids 表示权限ID列表
 	PermissionIds []int64 `protobuf:"varint,2,rep,packed,name=permission_ids,json=permissionIds,proto3" json:"permission_ids,omitempty"`
+	// effect 表示规则效果：allow（默认）或 deny，同一权限已有的相反规则会被替换
+	Effect string `protobuf:"bytes,3,opt,name=effect,proto3" json:"effect,omitempty"`
 }
 
 func (x *AssignRolePermissionsRequest) Reset() {
 	*x = AssignRolePermissionsRequest{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[7]
+	mi := &file_apiserver_v1_role_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -468,7 +564,7 @@ func (x *AssignRolePermissionsRequest) String() string {
 func (*AssignRolePermissionsRequest) ProtoMessage() {}
 
 func (x *AssignRolePermissionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[7]
+	mi := &file_apiserver_v1_role_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -481,7 +577,7 @@ func (x *AssignRolePermissionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignRolePermissionsRequest.ProtoReflect.Descriptor instead.
 func (*AssignRolePermissionsRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{7}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AssignRolePermissionsRequest) GetRoleId() int64 {
@@ -498,6 +594,13 @@ func (x *AssignRolePermissionsRequest) GetPermissionIds() []int64 {
 	return nil
 }
 
+func (x *AssignRolePermissionsRequest) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
 // AssignRolePermissionsResponse 表示分配角色权限响应
 type AssignRolePermissionsResponse struct {
 	state         protoimpl.MessageState
@@ -510,7 +613,7 @@ type AssignRolePermissionsResponse struct {
 
 func (x *AssignRolePermissionsResponse) Reset() {
 	*x = AssignRolePermissionsResponse{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[8]
+	mi := &file_apiserver_v1_role_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -522,7 +625,7 @@ func (x *AssignRolePermissionsResponse) String() string {
 func (*AssignRolePermissionsResponse) ProtoMessage() {}
 
 func (x *AssignRolePermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[8]
+	mi := &file_apiserver_v1_role_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -535,7 +638,7 @@ func (x *AssignRolePermissionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignRolePermissionsResponse.ProtoReflect.Descriptor instead.
 func (*AssignRolePermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{8}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AssignRolePermissionsResponse) GetSuccess() bool {
@@ -545,6 +648,318 @@ func (x *AssignRolePermissionsResponse) GetSuccess() bool {
 	return false
 }
 
+// RevokeRolePermissionsRequest 表示撤销角色权限请求
+type RevokeRolePermissionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// role_id 表示角色ID
+	// @gotags: uri:"roleID"
+	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty" uri:"roleID"`
+	// permission_ids 表示权限ID列表，allow 和 deny 规则都会被删除
+	PermissionIds []int64 `protobuf:"varint,2,rep,packed,name=permission_ids,json=permissionIds,proto3" json:"permission_ids,omitempty"`
+}
+
+func (x *RevokeRolePermissionsRequest) Reset() {
+	*x = RevokeRolePermissionsRequest{}
+	mi := &file_apiserver_v1_role_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRolePermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRolePermissionsRequest) ProtoMessage() {}
+
+func (x *RevokeRolePermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_role_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRolePermissionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeRolePermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RevokeRolePermissionsRequest) GetRoleId() int64 {
+	if x != nil {
+		return x.RoleId
+	}
+	return 0
+}
+
+func (x *RevokeRolePermissionsRequest) GetPermissionIds() []int64 {
+	if x != nil {
+		return x.PermissionIds
+	}
+	return nil
+}
+
+// RevokeRolePermissionsResponse 表示撤销角色权限响应
+type RevokeRolePermissionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// success 表示是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+}
+
+func (x *RevokeRolePermissionsResponse) Reset() {
+	*x = RevokeRolePermissionsResponse{}
+	mi := &file_apiserver_v1_role_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRolePermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRolePermissionsResponse) ProtoMessage() {}
+
+func (x *RevokeRolePermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_role_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRolePermissionsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeRolePermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RevokeRolePermissionsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// GetRoleParentsRequest 表示获取角色继承关系请求
+type GetRoleParentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// role_id 表示角色ID
+	// @gotags: uri:"roleID"
+	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty" uri:"roleID"`
+}
+
+func (x *GetRoleParentsRequest) Reset() {
+	*x = GetRoleParentsRequest{}
+	mi := &file_apiserver_v1_role_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoleParentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoleParentsRequest) ProtoMessage() {}
+
+func (x *GetRoleParentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_role_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoleParentsRequest.ProtoReflect.Descriptor instead.
+func (*GetRoleParentsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetRoleParentsRequest) GetRoleId() int64 {
+	if x != nil {
+		return x.RoleId
+	}
+	return 0
+}
+
+// GetRoleParentsResponse 表示获取角色继承关系响应
+type GetRoleParentsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// parents 表示直接继承的父角色
+	Parents []*Role `protobuf:"bytes,1,rep,name=parents,proto3" json:"parents,omitempty"`
+	// children 表示直接继承该角色的子角色
+	Children []*Role `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+}
+
+func (x *GetRoleParentsResponse) Reset() {
+	*x = GetRoleParentsResponse{}
+	mi := &file_apiserver_v1_role_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoleParentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoleParentsResponse) ProtoMessage() {}
+
+func (x *GetRoleParentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_role_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoleParentsResponse.ProtoReflect.Descriptor instead.
+func (*GetRoleParentsResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetRoleParentsResponse) GetParents() []*Role {
+	if x != nil {
+		return x.Parents
+	}
+	return nil
+}
+
+func (x *GetRoleParentsResponse) GetChildren() []*Role {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// SetRoleParentsRequest 表示设置父角色请求
+type SetRoleParentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// role_id 表示角色ID
+	// @gotags: uri:"roleID"
+	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty" uri:"roleID"`
+	// parent_ids 表示父角色ID列表，为空时清除继承关系
+	ParentIds []int64 `protobuf:"varint,2,rep,packed,name=parent_ids,json=parentIds,proto3" json:"parent_ids,omitempty"`
+}
+
+func (x *SetRoleParentsRequest) Reset() {
+	*x = SetRoleParentsRequest{}
+	mi := &file_apiserver_v1_role_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRoleParentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRoleParentsRequest) ProtoMessage() {}
+
+func (x *SetRoleParentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_role_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRoleParentsRequest.ProtoReflect.Descriptor instead.
+func (*SetRoleParentsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetRoleParentsRequest) GetRoleId() int64 {
+	if x != nil {
+		return x.RoleId
+	}
+	return 0
+}
+
+func (x *SetRoleParentsRequest) GetParentIds() []int64 {
+	if x != nil {
+		return x.ParentIds
+	}
+	return nil
+}
+
+// SetRoleParentsResponse 表示设置父角色响应
+type SetRoleParentsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// success 表示是否成功
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+}
+
+func (x *SetRoleParentsResponse) Reset() {
+	*x = SetRoleParentsResponse{}
+	mi := &file_apiserver_v1_role_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRoleParentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRoleParentsResponse) ProtoMessage() {}
+
+func (x *SetRoleParentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_role_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRoleParentsResponse.ProtoReflect.Descriptor instead.
+func (*SetRoleParentsResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetRoleParentsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 // GetUserRolesRequest 表示获取用户角色请求
 type GetUserRolesRequest struct {
 	state         protoimpl.MessageState
@@ -552,12 +967,13 @@ type GetUserRolesRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// user_id 表示用户ID
-	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// @gotags: uri:"userID"
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty" uri:"userID"`
 }
 
 func (x *GetUserRolesRequest) Reset() {
 	*x = GetUserRolesRequest{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[9]
+	mi := &file_apiserver_v1_role_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -569,7 +985,7 @@ func (x *GetUserRolesRequest) String() string {
 func (*GetUserRolesRequest) ProtoMessage() {}
 
 func (x *GetUserRolesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[9]
+	mi := &file_apiserver_v1_role_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,7 +998,7 @@ func (x *GetUserRolesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRolesRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRolesRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{9}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetUserRolesRequest) GetUserId() string {
@@ -604,7 +1020,7 @@ type GetUserRolesResponse struct {
 
 func (x *GetUserRolesResponse) Reset() {
 	*x = GetUserRolesResponse{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[10]
+	mi := &file_apiserver_v1_role_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -616,7 +1032,7 @@ func (x *GetUserRolesResponse) String() string {
 func (*GetUserRolesResponse) ProtoMessage() {}
 
 func (x *GetUserRolesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[10]
+	mi := &file_apiserver_v1_role_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -629,7 +1045,7 @@ func (x *GetUserRolesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRolesResponse.ProtoReflect.Descriptor instead.
 func (*GetUserRolesResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{10}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetUserRolesResponse) GetRoles() []*Role {
@@ -646,14 +1062,15 @@ type AssignUserRolesRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// user_id 表示用户ID
-	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	// role_ids 表示角色ID列表
+	// @gotags: uri:"userID"
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty" uri:"userID"`
+	// role_ids 表示角色ID列表，替换用户在当前租户下的全部角色，为空时移除全部角色
 	RoleIds []int64 `protobuf:"varint,2,rep,packed,name=role_ids,json=roleIds,proto3" json:"role_ids,omitempty"`
 }
 
 func (x *AssignUserRolesRequest) Reset() {
 	*x = AssignUserRolesRequest{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[11]
+	mi := &file_apiserver_v1_role_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -665,7 +1082,7 @@ func (x *AssignUserRolesRequest) String() string {
 func (*AssignUserRolesRequest) ProtoMessage() {}
 
 func (x *AssignUserRolesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[11]
+	mi := &file_apiserver_v1_role_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -678,7 +1095,7 @@ func (x *AssignUserRolesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignUserRolesRequest.ProtoReflect.Descriptor instead.
 func (*AssignUserRolesRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{11}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *AssignUserRolesRequest) GetUserId() string {
@@ -707,7 +1124,7 @@ type AssignUserRolesResponse struct {
 
 func (x *AssignUserRolesResponse) Reset() {
 	*x = AssignUserRolesResponse{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[12]
+	mi := &file_apiserver_v1_role_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -719,7 +1136,7 @@ func (x *AssignUserRolesResponse) String() string {
 func (*AssignUserRolesResponse) ProtoMessage() {}
 
 func (x *AssignUserRolesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[12]
+	mi := &file_apiserver_v1_role_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -732,7 +1149,7 @@ func (x *AssignUserRolesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignUserRolesResponse.ProtoReflect.Descriptor instead.
 func (*AssignUserRolesResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{12}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *AssignUserRolesResponse) GetSuccess() bool {
@@ -754,11 +1171,15 @@ type CreateRoleRequest struct {
 	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	// description 表示角色描述
 	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// data_scope 表示数据权限范围：all、tenant（默认）、dept、self、custom
+	DataScope string `protobuf:"bytes,4,opt,name=data_scope,json=dataScope,proto3" json:"data_scope,omitempty"`
+	// data_scope_dept_ids 表示 custom 数据权限可见的部门ID列表
+	DataScopeDeptIds []int64 `protobuf:"varint,5,rep,packed,name=data_scope_dept_ids,json=dataScopeDeptIds,proto3" json:"data_scope_dept_ids,omitempty"`
 }
 
 func (x *CreateRoleRequest) Reset() {
 	*x = CreateRoleRequest{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[13]
+	mi := &file_apiserver_v1_role_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -770,7 +1191,7 @@ func (x *CreateRoleRequest) String() string {
 func (*CreateRoleRequest) ProtoMessage() {}
 
 func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[13]
+	mi := &file_apiserver_v1_role_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -783,7 +1204,7 @@ func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRoleRequest.ProtoReflect.Descriptor instead.
 func (*CreateRoleRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{13}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *CreateRoleRequest) GetTenantId() int64 {
@@ -807,6 +1228,20 @@ func (x *CreateRoleRequest) GetDescription() string {
 	return ""
 }
 
+func (x *CreateRoleRequest) GetDataScope() string {
+	if x != nil {
+		return x.DataScope
+	}
+	return ""
+}
+
+func (x *CreateRoleRequest) GetDataScopeDeptIds() []int64 {
+	if x != nil {
+		return x.DataScopeDeptIds
+	}
+	return nil
+}
+
 // CreateRoleResponse 表示创建角色响应
 type CreateRoleResponse struct {
 	state         protoimpl.MessageState
@@ -819,7 +1254,7 @@ type CreateRoleResponse struct {
 
 func (x *CreateRoleResponse) Reset() {
 	*x = CreateRoleResponse{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[14]
+	mi := &file_apiserver_v1_role_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -831,7 +1266,7 @@ func (x *CreateRoleResponse) String() string {
 func (*CreateRoleResponse) ProtoMessage() {}
 
 func (x *CreateRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[14]
+	mi := &file_apiserver_v1_role_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -844,7 +1279,7 @@ func (x *CreateRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRoleResponse.ProtoReflect.Descriptor instead.
 func (*CreateRoleResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{14}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *CreateRoleResponse) GetRole() *Role {
@@ -866,11 +1301,15 @@ type UpdateRoleRequest struct {
 	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	// description 表示角色描述
 	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// data_scope 表示数据权限范围，为空时不修改
+	DataScope string `protobuf:"bytes,4,opt,name=data_scope,json=dataScope,proto3" json:"data_scope,omitempty"`
+	// data_scope_dept_ids 表示 custom 数据权限可见的部门ID列表，data_scope 为 custom 时生效
+	DataScopeDeptIds []int64 `protobuf:"varint,5,rep,packed,name=data_scope_dept_ids,json=dataScopeDeptIds,proto3" json:"data_scope_dept_ids,omitempty"`
 }
 
 func (x *UpdateRoleRequest) Reset() {
 	*x = UpdateRoleRequest{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[15]
+	mi := &file_apiserver_v1_role_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -882,7 +1321,7 @@ func (x *UpdateRoleRequest) String() string {
 func (*UpdateRoleRequest) ProtoMessage() {}
 
 func (x *UpdateRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[15]
+	mi := &file_apiserver_v1_role_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -895,7 +1334,7 @@ func (x *UpdateRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRoleRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRoleRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{15}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *UpdateRoleRequest) GetRoleId() int64 {
@@ -919,6 +1358,20 @@ func (x *UpdateRoleRequest) GetDescription() string {
 	return ""
 }
 
+func (x *UpdateRoleRequest) GetDataScope() string {
+	if x != nil {
+		return x.DataScope
+	}
+	return ""
+}
+
+func (x *UpdateRoleRequest) GetDataScopeDeptIds() []int64 {
+	if x != nil {
+		return x.DataScopeDeptIds
+	}
+	return nil
+}
+
 // UpdateRoleResponse 表示更新角色响应
 type UpdateRoleResponse struct {
 	state         protoimpl.MessageState
@@ -931,7 +1384,7 @@ type UpdateRoleResponse struct {
 
 func (x *UpdateRoleResponse) Reset() {
 	*x = UpdateRoleResponse{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[16]
+	mi := &file_apiserver_v1_role_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -943,7 +1396,7 @@ func (x *UpdateRoleResponse) String() string {
 func (*UpdateRoleResponse) ProtoMessage() {}
 
 func (x *UpdateRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[16]
+	mi := &file_apiserver_v1_role_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -956,7 +1409,7 @@ func (x *UpdateRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRoleResponse.ProtoReflect.Descriptor instead.
 func (*UpdateRoleResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{16}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *UpdateRoleResponse) GetRole() *Role {
@@ -978,7 +1431,7 @@ type DeleteRoleRequest struct {
 
 func (x *DeleteRoleRequest) Reset() {
 	*x = DeleteRoleRequest{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[17]
+	mi := &file_apiserver_v1_role_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -990,7 +1443,7 @@ func (x *DeleteRoleRequest) String() string {
 func (*DeleteRoleRequest) ProtoMessage() {}
 
 func (x *DeleteRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[17]
+	mi := &file_apiserver_v1_role_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1003,7 +1456,7 @@ func (x *DeleteRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRoleRequest.ProtoReflect.Descriptor instead.
 func (*DeleteRoleRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{17}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *DeleteRoleRequest) GetRoleId() int64 {
@@ -1025,7 +1478,7 @@ type DeleteRoleResponse struct {
 
 func (x *DeleteRoleResponse) Reset() {
 	*x = DeleteRoleResponse{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[18]
+	mi := &file_apiserver_v1_role_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1037,7 +1490,7 @@ func (x *DeleteRoleResponse) String() string {
 func (*DeleteRoleResponse) ProtoMessage() {}
 
 func (x *DeleteRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[18]
+	mi := &file_apiserver_v1_role_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1050,7 +1503,7 @@ func (x *DeleteRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRoleResponse.ProtoReflect.Descriptor instead.
 func (*DeleteRoleResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{18}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *DeleteRoleResponse) GetSuccess() bool {
@@ -1067,12 +1520,13 @@ type CheckDeleteRoleRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// role_id 表示角色ID
-	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	// @gotags: uri:"roleID"
+	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty" uri:"roleID"`
 }
 
 func (x *CheckDeleteRoleRequest) Reset() {
 	*x = CheckDeleteRoleRequest{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[19]
+	mi := &file_apiserver_v1_role_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1084,7 +1538,7 @@ func (x *CheckDeleteRoleRequest) String() string {
 func (*CheckDeleteRoleRequest) ProtoMessage() {}
 
 func (x *CheckDeleteRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[19]
+	mi := &file_apiserver_v1_role_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1097,7 +1551,7 @@ func (x *CheckDeleteRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckDeleteRoleRequest.ProtoReflect.Descriptor instead.
 func (*CheckDeleteRoleRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{19}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *CheckDeleteRoleRequest) GetRoleId() int64 {
@@ -1121,7 +1575,7 @@ type CheckDeleteRoleResponse struct {
 
 func (x *CheckDeleteRoleResponse) Reset() {
 	*x = CheckDeleteRoleResponse{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[20]
+	mi := &file_apiserver_v1_role_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1133,7 +1587,7 @@ func (x *CheckDeleteRoleResponse) String() string {
 func (*CheckDeleteRoleResponse) ProtoMessage() {}
 
 func (x *CheckDeleteRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[20]
+	mi := &file_apiserver_v1_role_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1146,7 +1600,7 @@ func (x *CheckDeleteRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckDeleteRoleResponse.ProtoReflect.Descriptor instead.
 func (*CheckDeleteRoleResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{20}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *CheckDeleteRoleResponse) GetCanDelete() bool {
@@ -1170,12 +1624,13 @@ type GetRoleMenusRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// role_id 表示角色ID
-	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	// @gotags: uri:"roleID"
+	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty" uri:"roleID"`
 }
 
 func (x *GetRoleMenusRequest) Reset() {
 	*x = GetRoleMenusRequest{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[21]
+	mi := &file_apiserver_v1_role_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1187,7 +1642,7 @@ func (x *GetRoleMenusRequest) String() string {
 func (*GetRoleMenusRequest) ProtoMessage() {}
 
 func (x *GetRoleMenusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[21]
+	mi := &file_apiserver_v1_role_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1200,7 +1655,7 @@ func (x *GetRoleMenusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRoleMenusRequest.ProtoReflect.Descriptor instead.
 func (*GetRoleMenusRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{21}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *GetRoleMenusRequest) GetRoleId() int64 {
@@ -1222,7 +1677,7 @@ type GetRoleMenusResponse struct {
 
 func (x *GetRoleMenusResponse) Reset() {
 	*x = GetRoleMenusResponse{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[22]
+	mi := &file_apiserver_v1_role_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1234,7 +1689,7 @@ func (x *GetRoleMenusResponse) String() string {
 func (*GetRoleMenusResponse) ProtoMessage() {}
 
 func (x *GetRoleMenusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[22]
+	mi := &file_apiserver_v1_role_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1247,7 +1702,7 @@ func (x *GetRoleMenusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRoleMenusResponse.ProtoReflect.Descriptor instead.
 func (*GetRoleMenusResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{22}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *GetRoleMenusResponse) GetMenus() []*Menu {
@@ -1264,14 +1719,15 @@ type UpdateRoleMenusRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// role_id 表示角色ID
-	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
-	// menu_ids 表示菜单ID列表
+	// @gotags: uri:"roleID"
+	RoleId int64 `protobuf:"varint,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty" uri:"roleID"`
+	// menu_ids 表示菜单ID列表，角色被授予这些菜单的必需权限，其他菜单的必需权限被撤销
 	MenuIds []int64 `protobuf:"varint,2,rep,packed,name=menu_ids,json=menuIds,proto3" json:"menu_ids,omitempty"`
 }
 
 func (x *UpdateRoleMenusRequest) Reset() {
 	*x = UpdateRoleMenusRequest{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[23]
+	mi := &file_apiserver_v1_role_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1283,7 +1739,7 @@ func (x *UpdateRoleMenusRequest) String() string {
 func (*UpdateRoleMenusRequest) ProtoMessage() {}
 
 func (x *UpdateRoleMenusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[23]
+	mi := &file_apiserver_v1_role_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1296,7 +1752,7 @@ func (x *UpdateRoleMenusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRoleMenusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRoleMenusRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{23}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *UpdateRoleMenusRequest) GetRoleId() int64 {
@@ -1325,7 +1781,7 @@ type UpdateRoleMenusResponse struct {
 
 func (x *UpdateRoleMenusResponse) Reset() {
 	*x = UpdateRoleMenusResponse{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[24]
+	mi := &file_apiserver_v1_role_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1337,7 +1793,7 @@ func (x *UpdateRoleMenusResponse) String() string {
 func (*UpdateRoleMenusResponse) ProtoMessage() {}
 
 func (x *UpdateRoleMenusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[24]
+	mi := &file_apiserver_v1_role_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1350,7 +1806,7 @@ func (x *UpdateRoleMenusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRoleMenusResponse.ProtoReflect.Descriptor instead.
 func (*UpdateRoleMenusResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{24}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *UpdateRoleMenusResponse) GetSuccess() bool {
@@ -1373,7 +1829,7 @@ type GetRolesByUserRequest struct {
 
 func (x *GetRolesByUserRequest) Reset() {
 	*x = GetRolesByUserRequest{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[25]
+	mi := &file_apiserver_v1_role_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1385,7 +1841,7 @@ func (x *GetRolesByUserRequest) String() string {
 func (*GetRolesByUserRequest) ProtoMessage() {}
 
 func (x *GetRolesByUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[25]
+	mi := &file_apiserver_v1_role_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1398,7 +1854,7 @@ func (x *GetRolesByUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRolesByUserRequest.ProtoReflect.Descriptor instead.
 func (*GetRolesByUserRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{25}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *GetRolesByUserRequest) GetTenantId() int64 {
@@ -1420,7 +1876,7 @@ type GetRolesByUserResponse struct {
 
 func (x *GetRolesByUserResponse) Reset() {
 	*x = GetRolesByUserResponse{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[26]
+	mi := &file_apiserver_v1_role_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1432,7 +1888,7 @@ func (x *GetRolesByUserResponse) String() string {
 func (*GetRolesByUserResponse) ProtoMessage() {}
 
 func (x *GetRolesByUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[26]
+	mi := &file_apiserver_v1_role_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1445,7 +1901,7 @@ func (x *GetRolesByUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRolesByUserResponse.ProtoReflect.Descriptor instead.
 func (*GetRolesByUserResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{26}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *GetRolesByUserResponse) GetRoles() []*Role {
@@ -1468,7 +1924,7 @@ type RefreshPrivilegeDataRequest struct {
 
 func (x *RefreshPrivilegeDataRequest) Reset() {
 	*x = RefreshPrivilegeDataRequest{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[27]
+	mi := &file_apiserver_v1_role_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1480,7 +1936,7 @@ func (x *RefreshPrivilegeDataRequest) String() string {
 func (*RefreshPrivilegeDataRequest) ProtoMessage() {}
 
 func (x *RefreshPrivilegeDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[27]
+	mi := &file_apiserver_v1_role_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1493,7 +1949,7 @@ func (x *RefreshPrivilegeDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshPrivilegeDataRequest.ProtoReflect.Descriptor instead.
 func (*RefreshPrivilegeDataRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{27}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *RefreshPrivilegeDataRequest) GetTenantId() int64 {
@@ -1515,7 +1971,7 @@ type RefreshPrivilegeDataResponse struct {
 
 func (x *RefreshPrivilegeDataResponse) Reset() {
 	*x = RefreshPrivilegeDataResponse{}
-	mi := &file_apiserver_v1_role_proto_msgTypes[28]
+	mi := &file_apiserver_v1_role_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1527,7 +1983,7 @@ func (x *RefreshPrivilegeDataResponse) String() string {
 func (*RefreshPrivilegeDataResponse) ProtoMessage() {}
 
 func (x *RefreshPrivilegeDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_role_proto_msgTypes[28]
+	mi := &file_apiserver_v1_role_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1540,7 +1996,7 @@ func (x *RefreshPrivilegeDataResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshPrivilegeDataResponse.ProtoReflect.Descriptor instead.
 func (*RefreshPrivilegeDataResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{28}
+	return file_apiserver_v1_role_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *RefreshPrivilegeDataResponse) GetSuccess() bool {
@@ -1560,7 +2016,7 @@ var file_apiserver_v1_role_proto_rawDesc = []byte{
 	0x72, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf7, 0x01, 0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe6, 0x02, 0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12,
 	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12,
 	0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
@@ -1576,123 +2032,178 @@ var file_apiserver_v1_role_proto_rawDesc = []byte{
 	0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
 	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
-	0x22, 0x5d, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49,
-	0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22,
-	0x54, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72,
-	0x6f, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x29, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64,
-	0x22, 0x2f, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c,
-	0x65, 0x22, 0x34, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
-	0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x4e, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x52, 0x6f,
-	0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e,
-	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x5e, 0x0a, 0x1c, 0x41, 0x73, 0x73, 0x69, 0x67,
-	0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64,
-	0x12, 0x25, 0x0a, 0x0e, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
-	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x22, 0x39, 0x0a, 0x1d, 0x41, 0x73, 0x73, 0x69, 0x67,
-	0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x22, 0x2e, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c,
-	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
-	0x49, 0x64, 0x22, 0x36, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c,
-	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x05, 0x72, 0x6f,
-	0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x52,
-	0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0x4c, 0x0a, 0x16, 0x41, 0x73,
-	0x73, 0x69, 0x67, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x19, 0x0a,
-	0x08, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52,
-	0x07, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x73, 0x22, 0x33, 0x0a, 0x17, 0x41, 0x73, 0x73, 0x69,
-	0x67, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x66, 0x0a,
-	0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x32, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
-	0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x72,
-	0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x52,
-	0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x22, 0x62, 0x0a, 0x11, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
-	0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
-	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x32, 0x0a,
-	0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c,
-	0x65, 0x22, 0x2c, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52,
+	0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12,
+	0x2d, 0x0a, 0x13, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f, 0x64, 0x65,
+	0x70, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x03, 0x52, 0x10, 0x64, 0x61,
+	0x74, 0x61, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x44, 0x65, 0x70, 0x74, 0x49, 0x64, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x6f, 0x6c, 0x65, 0x22,
+	0x5d, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x54,
+	0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x6f,
+	0x6c, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x29, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69,
 	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x22,
-	0x2e, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22,
-	0x31, 0x0a, 0x16, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f,
-	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c,
-	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65,
-	0x49, 0x64, 0x22, 0x50, 0x0a, 0x17, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a,
-	0x0a, 0x63, 0x61, 0x6e, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x09, 0x63, 0x61, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
-	0x61, 0x73, 0x6f, 0x6e, 0x22, 0x2e, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x4d,
-	0x65, 0x6e, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72,
-	0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f,
-	0x6c, 0x65, 0x49, 0x64, 0x22, 0x36, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x4d,
-	0x65, 0x6e, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x05,
-	0x6d, 0x65, 0x6e, 0x75, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31,
-	0x2e, 0x4d, 0x65, 0x6e, 0x75, 0x52, 0x05, 0x6d, 0x65, 0x6e, 0x75, 0x73, 0x22, 0x4c, 0x0a, 0x16,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0x52,
+	0x2f, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65,
+	0x22, 0x34, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x5d, 0x0a, 0x13, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x12, 0x2e, 0x0a,
+	0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x22, 0x7f, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65,
+	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x50,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x52, 0x06,
+	0x67, 0x72, 0x61, 0x6e, 0x74, 0x73, 0x22, 0x76, 0x0a, 0x1c, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69,
 	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12,
-	0x19, 0x0a, 0x08, 0x6d, 0x65, 0x6e, 0x75, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x03, 0x52, 0x07, 0x6d, 0x65, 0x6e, 0x75, 0x49, 0x64, 0x73, 0x22, 0x33, 0x0a, 0x17, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x25, 0x0a, 0x0e, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x22, 0x39,
+	0x0a, 0x1d, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x5e, 0x0a, 0x1c, 0x52, 0x65, 0x76,
+	0x6f, 0x6b, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65,
+	0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0d, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x22, 0x39, 0x0a, 0x1d, 0x52, 0x65, 0x76,
+	0x6f, 0x6b, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x22, 0x30, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x50,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x62, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c,
+	0x65, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x22, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65,
+	0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x22, 0x4f, 0x0a, 0x15, 0x53, 0x65,
+	0x74, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03,
+	0x52, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x73, 0x22, 0x32, 0x0a, 0x16, 0x53,
+	0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73,
 	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22,
-	0x34, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x42, 0x79, 0x55, 0x73, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61,
-	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e,
-	0x61, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x38, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65,
-	0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x1e, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08,
-	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x22,
-	0x3a, 0x0a, 0x1b, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c,
-	0x65, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
-	0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x38, 0x0a, 0x1c, 0x52,
-	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x44,
-	0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73,
-	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x73, 0x68, 0x77, 0x69, 0x6e, 0x79, 0x75, 0x65, 0x2f, 0x6f, 0x6e,
-	0x65, 0x2d, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61,
-	0x70, 0x69, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22,
+	0x36, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65,
+	0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0x4c, 0x0a, 0x16, 0x41, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x6f,
+	0x6c, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x72, 0x6f,
+	0x6c, 0x65, 0x49, 0x64, 0x73, 0x22, 0x33, 0x0a, 0x17, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x55,
+	0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0xb4, 0x01, 0x0a, 0x11, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x63, 0x6f, 0x70,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x53, 0x63, 0x6f,
+	0x70, 0x65, 0x12, 0x2d, 0x0a, 0x13, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65,
+	0x5f, 0x64, 0x65, 0x70, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x03, 0x52,
+	0x10, 0x64, 0x61, 0x74, 0x61, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x44, 0x65, 0x70, 0x74, 0x49, 0x64,
+	0x73, 0x22, 0x32, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52,
+	0x04, 0x72, 0x6f, 0x6c, 0x65, 0x22, 0xb0, 0x01, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72,
+	0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f,
+	0x6c, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61,
+	0x74, 0x61, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x64, 0x61, 0x74, 0x61, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x2d, 0x0a, 0x13, 0x64, 0x61, 0x74,
+	0x61, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x03, 0x52, 0x10, 0x64, 0x61, 0x74, 0x61, 0x53, 0x63, 0x6f, 0x70,
+	0x65, 0x44, 0x65, 0x70, 0x74, 0x49, 0x64, 0x73, 0x22, 0x32, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c,
+	0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x22, 0x2c, 0x0a, 0x11,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x2e, 0x0a, 0x12, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x31, 0x0a, 0x16, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x50, 0x0a,
+	0x17, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x5f,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x61,
+	0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x2e, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x22,
+	0x36, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x05, 0x6d, 0x65, 0x6e, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6e, 0x75,
+	0x52, 0x05, 0x6d, 0x65, 0x6e, 0x75, 0x73, 0x22, 0x4c, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x6f, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x65,
+	0x6e, 0x75, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x65,
+	0x6e, 0x75, 0x49, 0x64, 0x73, 0x22, 0x33, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x6f, 0x6c, 0x65, 0x4d, 0x65, 0x6e, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x34, 0x0a, 0x15, 0x47, 0x65,
+	0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x42, 0x79, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64,
+	0x22, 0x38, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x42, 0x79, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x05, 0x72, 0x6f,
+	0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0x3a, 0x0a, 0x1b, 0x52, 0x65,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65,
+	0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x38, 0x0a, 0x1c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73,
+	0x68, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
+	0x73, 0x68, 0x77, 0x69, 0x6e, 0x79, 0x75, 0x65, 0x2f, 0x6f, 0x6e, 0x65, 0x2d, 0x61, 0x75, 0x74,
+	0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -1707,7 +2218,7 @@ func file_apiserver_v1_role_proto_rawDescGZIP() []byte {
 	return file_apiserver_v1_role_proto_rawDescData
 }
 
-var file_apiserver_v1_role_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_apiserver_v1_role_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_apiserver_v1_role_proto_goTypes = []any{
 	(*Role)(nil),                          // 0: v1.Role
 	(*ListRolesRequest)(nil),              // 1: v1.ListRolesRequest
@@ -1715,49 +2226,60 @@ var file_apiserver_v1_role_proto_goTypes = []any{
 	(*GetRoleRequest)(nil),                // 3: v1.GetRoleRequest
 	(*GetRoleResponse)(nil),               // 4: v1.GetRoleResponse
 	(*GetRolePermissionsRequest)(nil),     // 5: v1.GetRolePermissionsRequest
-	(*GetRolePermissionsResponse)(nil),    // 6: v1.GetRolePermissionsResponse
-	(*AssignRolePermissionsRequest)(nil),  // 7: v1.AssignRolePermissionsRequest
-	(*AssignRolePermissionsResponse)(nil), // 8: v1.AssignRolePermissionsResponse
-	(*GetUserRolesRequest)(nil),           // 9: v1.GetUserRolesRequest
-	(*GetUserRolesResponse)(nil),          // 10: v1.GetUserRolesResponse
-	(*AssignUserRolesRequest)(nil),        // 11: v1.AssignUserRolesRequest
-	(*AssignUserRolesResponse)(nil),       // 12: v1.AssignUserRolesResponse
-	(*CreateRoleRequest)(nil),             // 13: v1.CreateRoleRequest
-	(*CreateRoleResponse)(nil),            // 14: v1.CreateRoleResponse
-	(*UpdateRoleRequest)(nil),             // 15: v1.UpdateRoleRequest
-	(*UpdateRoleResponse)(nil),            // 16: v1.UpdateRoleResponse
-	(*DeleteRoleRequest)(nil),             // 17: v1.DeleteRoleRequest
-	(*DeleteRoleResponse)(nil),            // 18: v1.DeleteRoleResponse
-	(*CheckDeleteRoleRequest)(nil),        // 19: v1.CheckDeleteRoleRequest
-	(*CheckDeleteRoleResponse)(nil),       // 20: v1.CheckDeleteRoleResponse
-	(*GetRoleMenusRequest)(nil),           // 21: v1.GetRoleMenusRequest
-	(*GetRoleMenusResponse)(nil),          // 22: v1.GetRoleMenusResponse
-	(*UpdateRoleMenusRequest)(nil),        // 23: v1.UpdateRoleMenusRequest
-	(*UpdateRoleMenusResponse)(nil),       // 24: v1.UpdateRoleMenusResponse
-	(*GetRolesByUserRequest)(nil),         // 25: v1.GetRolesByUserRequest
-	(*GetRolesByUserResponse)(nil),        // 26: v1.GetRolesByUserResponse
-	(*RefreshPrivilegeDataRequest)(nil),   // 27: v1.RefreshPrivilegeDataRequest
-	(*RefreshPrivilegeDataResponse)(nil),  // 28: v1.RefreshPrivilegeDataResponse
-	(*timestamppb.Timestamp)(nil),         // 29: google.protobuf.Timestamp
-	(*Permission)(nil),                    // 30: v1.Permission
-	(*Menu)(nil),                          // 31: v1.Menu
+	(*RolePermissionGrant)(nil),           // 6: v1.RolePermissionGrant
+	(*GetRolePermissionsResponse)(nil),    // 7: v1.GetRolePermissionsResponse
+	(*AssignRolePermissionsRequest)(nil),  // 8: v1.AssignRolePermissionsRequest
+	(*AssignRolePermissionsResponse)(nil), // 9: v1.AssignRolePermissionsResponse
+	(*RevokeRolePermissionsRequest)(nil),  // 10: v1.RevokeRolePermissionsRequest
+	(*RevokeRolePermissionsResponse)(nil), // 11: v1.RevokeRolePermissionsResponse
+	(*GetRoleParentsRequest)(nil),         // 12: v1.GetRoleParentsRequest
+	(*GetRoleParentsResponse)(nil),        // 13: v1.GetRoleParentsResponse
+	(*SetRoleParentsRequest)(nil),         // 14: v1.SetRoleParentsRequest
+	(*SetRoleParentsResponse)(nil),        // 15: v1.SetRoleParentsResponse
+	(*GetUserRolesRequest)(nil),           // 16: v1.GetUserRolesRequest
+	(*GetUserRolesResponse)(nil),          // 17: v1.GetUserRolesResponse
+	(*AssignUserRolesRequest)(nil),        // 18: v1.AssignUserRolesRequest
+	(*AssignUserRolesResponse)(nil),       // 19: v1.AssignUserRolesResponse
+	(*CreateRoleRequest)(nil),             // 20: v1.CreateRoleRequest
+	(*CreateRoleResponse)(nil),            // 21: v1.CreateRoleResponse
+	(*UpdateRoleRequest)(nil),             // 22: v1.UpdateRoleRequest
+	(*UpdateRoleResponse)(nil),            // 23: v1.UpdateRoleResponse
+	(*DeleteRoleRequest)(nil),             // 24: v1.DeleteRoleRequest
+	(*DeleteRoleResponse)(nil),            // 25: v1.DeleteRoleResponse
+	(*CheckDeleteRoleRequest)(nil),        // 26: v1.CheckDeleteRoleRequest
+	(*CheckDeleteRoleResponse)(nil),       // 27: v1.CheckDeleteRoleResponse
+	(*GetRoleMenusRequest)(nil),           // 28: v1.GetRoleMenusRequest
+	(*GetRoleMenusResponse)(nil),          // 29: v1.GetRoleMenusResponse
+	(*UpdateRoleMenusRequest)(nil),        // 30: v1.UpdateRoleMenusRequest
+	(*UpdateRoleMenusResponse)(nil),       // 31: v1.UpdateRoleMenusResponse
+	(*GetRolesByUserRequest)(nil),         // 32: v1.GetRolesByUserRequest
+	(*GetRolesByUserResponse)(nil),        // 33: v1.GetRolesByUserResponse
+	(*RefreshPrivilegeDataRequest)(nil),   // 34: v1.RefreshPrivilegeDataRequest
+	(*RefreshPrivilegeDataResponse)(nil),  // 35: v1.RefreshPrivilegeDataResponse
+	(*timestamppb.Timestamp)(nil),         // 36: google.protobuf.Timestamp
+	(*Permission)(nil),                    // 37: v1.Permission
+	(*Menu)(nil),                          // 38: v1.Menu
 }
 var file_apiserver_v1_role_proto_depIdxs = []int32{
-	29, // 0: v1.Role.created_at:type_name -> google.protobuf.Timestamp
-	29, // 1: v1.Role.updated_at:type_name -> google.protobuf.Timestamp
+	36, // 0: v1.Role.created_at:type_name -> google.protobuf.Timestamp
+	36, // 1: v1.Role.updated_at:type_name -> google.protobuf.Timestamp
 	0,  // 2: v1.ListRolesResponse.roles:type_name -> v1.Role
 	0,  // 3: v1.GetRoleResponse.role:type_name -> v1.Role
-	30, // 4: v1.GetRolePermissionsResponse.permissions:type_name -> v1.Permission
-	0,  // 5: v1.GetUserRolesResponse.roles:type_name -> v1.Role
-	0,  // 6: v1.CreateRoleResponse.role:type_name -> v1.Role
-	0,  // 7: v1.UpdateRoleResponse.role:type_name -> v1.Role
-	31, // 8: v1.GetRoleMenusResponse.menus:type_name -> v1.Menu
-	0,  // 9: v1.GetRolesByUserResponse.roles:type_name -> v1.Role
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	37, // 4: v1.RolePermissionGrant.permission:type_name -> v1.Permission
+	37, // 5: v1.GetRolePermissionsResponse.permissions:type_name -> v1.Permission
+	6,  // 6: v1.GetRolePermissionsResponse.grants:type_name -> v1.RolePermissionGrant
+	0,  // 7: v1.GetRoleParentsResponse.parents:type_name -> v1.Role
+	0,  // 8: v1.GetRoleParentsResponse.children:type_name -> v1.Role
+	0,  // 9: v1.GetUserRolesResponse.roles:type_name -> v1.Role
+	0,  // 10: v1.CreateRoleResponse.role:type_name -> v1.Role
+	0,  // 11: v1.UpdateRoleResponse.role:type_name -> v1.Role
+	38, // 12: v1.GetRoleMenusResponse.menus:type_name -> v1.Menu
+	0,  // 13: v1.GetRolesByUserResponse.roles:type_name -> v1.Role
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_apiserver_v1_role_proto_init() }
@@ -1773,7 +2295,7 @@ func file_apiserver_v1_role_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_apiserver_v1_role_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   29,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
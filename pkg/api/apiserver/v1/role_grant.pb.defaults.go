@@ -0,0 +1,56 @@
+// 限时角色授予 API 定义
+
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Code generated by protoc-gen-defaults. DO NOT EDIT.
+
+package v1
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	_ *timestamppb.Timestamp
+	_ *durationpb.Duration
+	_ *wrapperspb.BoolValue
+)
+
+func (x *RoleGrant) Default() {
+}
+
+func (x *CreateRoleGrantRequest) Default() {
+}
+
+func (x *CreateRoleGrantResponse) Default() {
+}
+
+func (x *RequestRoleGrantRequest) Default() {
+}
+
+func (x *RequestRoleGrantResponse) Default() {
+}
+
+func (x *ReviewRoleGrantRequest) Default() {
+}
+
+func (x *ReviewRoleGrantResponse) Default() {
+}
+
+func (x *RevokeRoleGrantRequest) Default() {
+}
+
+func (x *RevokeRoleGrantResponse) Default() {
+}
+
+func (x *ListRoleGrantsRequest) Default() {
+}
+
+func (x *ListRoleGrantsResponse) Default() {
+}
@@ -0,0 +1,59 @@
+// 静态职责分离规则 API 定义
+
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Code generated by protoc-gen-defaults. DO NOT EDIT.
+
+package v1
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	_ *timestamppb.Timestamp
+	_ *durationpb.Duration
+	_ *wrapperspb.BoolValue
+)
+
+func (x *SoDRule) Default() {
+}
+
+func (x *SoDViolation) Default() {
+}
+
+func (x *ListSoDRulesRequest) Default() {
+}
+
+func (x *ListSoDRulesResponse) Default() {
+}
+
+func (x *CreateSoDRuleRequest) Default() {
+}
+
+func (x *CreateSoDRuleResponse) Default() {
+}
+
+func (x *UpdateSoDRuleRequest) Default() {
+}
+
+func (x *UpdateSoDRuleResponse) Default() {
+}
+
+func (x *DeleteSoDRuleRequest) Default() {
+}
+
+func (x *DeleteSoDRuleResponse) Default() {
+}
+
+func (x *ListSoDViolationsRequest) Default() {
+}
+
+func (x *ListSoDViolationsResponse) Default() {
+}
@@ -51,3 +51,24 @@ func (x *ListTenantsRequest) Default() {
 
 func (x *ListTenantsResponse) Default() {
 }
+
+func (x *SCIMToken) Default() {
+}
+
+func (x *CreateSCIMTokenRequest) Default() {
+}
+
+func (x *CreateSCIMTokenResponse) Default() {
+}
+
+func (x *ListSCIMTokensRequest) Default() {
+}
+
+func (x *ListSCIMTokensResponse) Default() {
+}
+
+func (x *RevokeSCIMTokenRequest) Default() {
+}
+
+func (x *RevokeSCIMTokenResponse) Default() {
+}
@@ -636,6 +636,419 @@ func (x *ListTenantsResponse) GetTenants() []*Tenant {
 	return nil
 }
 
+// SCIMToken 表示租户的 SCIM 访问令牌（不包含明文）
+type SCIMToken struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id 表示令牌ID
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// tenant_id 表示租户ID
+	TenantId int64 `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// name 表示令牌名称
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// token_prefix 表示令牌前缀，用于辨认令牌
+	TokenPrefix string `protobuf:"bytes,4,opt,name=token_prefix,json=tokenPrefix,proto3" json:"token_prefix,omitempty"`
+	// expires_at 表示过期时间，为空表示永不过期
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// last_used_at 表示最后使用时间
+	LastUsedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	// created_at 表示创建时间
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *SCIMToken) Reset() {
+	*x = SCIMToken{}
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCIMToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCIMToken) ProtoMessage() {}
+
+func (x *SCIMToken) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCIMToken.ProtoReflect.Descriptor instead.
+func (*SCIMToken) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_tenant_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SCIMToken) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SCIMToken) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *SCIMToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SCIMToken) GetTokenPrefix() string {
+	if x != nil {
+		return x.TokenPrefix
+	}
+	return ""
+}
+
+func (x *SCIMToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *SCIMToken) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *SCIMToken) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// CreateSCIMTokenRequest 表示创建 SCIM 访问令牌请求
+type CreateSCIMTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// tenant_id 表示租户ID
+	// @gotags: uri:"tenantID"
+	TenantId int64 `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty" uri:"tenantID"`
+	// name 表示令牌名称
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// expires_in_days 表示有效天数，0 表示永不过期
+	ExpiresInDays int32 `protobuf:"varint,3,opt,name=expires_in_days,json=expiresInDays,proto3" json:"expires_in_days,omitempty"`
+}
+
+func (x *CreateSCIMTokenRequest) Reset() {
+	*x = CreateSCIMTokenRequest{}
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSCIMTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSCIMTokenRequest) ProtoMessage() {}
+
+func (x *CreateSCIMTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSCIMTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateSCIMTokenRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_tenant_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateSCIMTokenRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *CreateSCIMTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSCIMTokenRequest) GetExpiresInDays() int32 {
+	if x != nil {
+		return x.ExpiresInDays
+	}
+	return 0
+}
+
+// CreateSCIMTokenResponse 表示创建 SCIM 访问令牌响应
+type CreateSCIMTokenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// token 表示令牌信息
+	Token *SCIMToken `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// secret 表示令牌明文，只在创建时返回一次
+	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+}
+
+func (x *CreateSCIMTokenResponse) Reset() {
+	*x = CreateSCIMTokenResponse{}
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSCIMTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSCIMTokenResponse) ProtoMessage() {}
+
+func (x *CreateSCIMTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSCIMTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateSCIMTokenResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_tenant_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateSCIMTokenResponse) GetToken() *SCIMToken {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *CreateSCIMTokenResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+// ListSCIMTokensRequest 表示获取 SCIM 访问令牌列表请求
+type ListSCIMTokensRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// tenant_id 表示租户ID
+	// @gotags: uri:"tenantID"
+	TenantId int64 `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty" uri:"tenantID"`
+}
+
+func (x *ListSCIMTokensRequest) Reset() {
+	*x = ListSCIMTokensRequest{}
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSCIMTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSCIMTokensRequest) ProtoMessage() {}
+
+func (x *ListSCIMTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSCIMTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListSCIMTokensRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_tenant_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListSCIMTokensRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+// ListSCIMTokensResponse 表示获取 SCIM 访问令牌列表响应
+type ListSCIMTokensResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// tokens 表示令牌列表
+	Tokens []*SCIMToken `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+}
+
+func (x *ListSCIMTokensResponse) Reset() {
+	*x = ListSCIMTokensResponse{}
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSCIMTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSCIMTokensResponse) ProtoMessage() {}
+
+func (x *ListSCIMTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSCIMTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListSCIMTokensResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_tenant_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListSCIMTokensResponse) GetTokens() []*SCIMToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+// RevokeSCIMTokenRequest 表示吊销 SCIM 访问令牌请求
+type RevokeSCIMTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// tenant_id 表示租户ID
+	// @gotags: uri:"tenantID"
+	TenantId int64 `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty" uri:"tenantID"`
+	// token_id 表示令牌ID
+	// @gotags: uri:"tokenID"
+	TokenId int64 `protobuf:"varint,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty" uri:"tokenID"`
+}
+
+func (x *RevokeSCIMTokenRequest) Reset() {
+	*x = RevokeSCIMTokenRequest{}
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSCIMTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSCIMTokenRequest) ProtoMessage() {}
+
+func (x *RevokeSCIMTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSCIMTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSCIMTokenRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_tenant_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RevokeSCIMTokenRequest) GetTenantId() int64 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *RevokeSCIMTokenRequest) GetTokenId() int64 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+// RevokeSCIMTokenResponse 表示吊销 SCIM 访问令牌响应
+type RevokeSCIMTokenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RevokeSCIMTokenResponse) Reset() {
+	*x = RevokeSCIMTokenResponse{}
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSCIMTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSCIMTokenResponse) ProtoMessage() {}
+
+func (x *RevokeSCIMTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_tenant_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSCIMTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSCIMTokenResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_tenant_proto_rawDescGZIP(), []int{16}
+}
+
 var File_apiserver_v1_tenant_proto protoreflect.FileDescriptor
 
 var file_apiserver_v1_tenant_proto_rawDesc = []byte{
@@ -709,11 +1122,56 @@ var file_apiserver_v1_tenant_proto_rawDesc = []byte{
 	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74,
 	0x12, 0x24, 0x0a, 0x07, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
 	0x0b, 0x32, 0x0a, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x74,
-	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x73, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x73, 0x68, 0x77, 0x69, 0x6e, 0x79, 0x75, 0x65, 0x2f, 0x6f,
-	0x6e, 0x65, 0x2d, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f,
-	0x61, 0x70, 0x69, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x73, 0x22, 0xa3, 0x02, 0x0a, 0x09, 0x53, 0x43, 0x49, 0x4d, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x41, 0x74, 0x12, 0x3c, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x71, 0x0a, 0x16,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x43, 0x49, 0x4d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x5f, 0x69, 0x6e, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0d, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49, 0x6e, 0x44, 0x61, 0x79, 0x73, 0x22,
+	0x56, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x43, 0x49, 0x4d, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x43, 0x49, 0x4d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0x34, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x43, 0x49, 0x4d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x3f, 0x0a,
+	0x16, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x43, 0x49, 0x4d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x43, 0x49,
+	0x4d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x22, 0x50,
+	0x0a, 0x16, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x43, 0x49, 0x4d, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64,
+	0x22, 0x19, 0x0a, 0x17, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x43, 0x49, 0x4d, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x37, 0x5a, 0x35, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x73, 0x68, 0x77, 0x69, 0x6e,
+	0x79, 0x75, 0x65, 0x2f, 0x6f, 0x6e, 0x65, 0x2d, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76,
+	0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -728,36 +1186,48 @@ func file_apiserver_v1_tenant_proto_rawDescGZIP() []byte {
 	return file_apiserver_v1_tenant_proto_rawDescData
 }
 
-var file_apiserver_v1_tenant_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_apiserver_v1_tenant_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_apiserver_v1_tenant_proto_goTypes = []any{
-	(*Tenant)(nil),                 // 0: v1.Tenant
-	(*UserProfile)(nil),            // 1: v1.UserProfile
-	(*GetUserTenantsRequest)(nil),  // 2: v1.GetUserTenantsRequest
-	(*GetUserTenantsResponse)(nil), // 3: v1.GetUserTenantsResponse
-	(*SwitchTenantRequest)(nil),    // 4: v1.SwitchTenantRequest
-	(*SwitchTenantResponse)(nil),   // 5: v1.SwitchTenantResponse
-	(*GetUserProfileRequest)(nil),  // 6: v1.GetUserProfileRequest
-	(*GetUserProfileResponse)(nil), // 7: v1.GetUserProfileResponse
-	(*ListTenantsRequest)(nil),     // 8: v1.ListTenantsRequest
-	(*ListTenantsResponse)(nil),    // 9: v1.ListTenantsResponse
-	(*timestamppb.Timestamp)(nil),  // 10: google.protobuf.Timestamp
-	(*Permission)(nil),             // 11: v1.Permission
-	(*Menu)(nil),                   // 12: v1.Menu
+	(*Tenant)(nil),                  // 0: v1.Tenant
+	(*UserProfile)(nil),             // 1: v1.UserProfile
+	(*GetUserTenantsRequest)(nil),   // 2: v1.GetUserTenantsRequest
+	(*GetUserTenantsResponse)(nil),  // 3: v1.GetUserTenantsResponse
+	(*SwitchTenantRequest)(nil),     // 4: v1.SwitchTenantRequest
+	(*SwitchTenantResponse)(nil),    // 5: v1.SwitchTenantResponse
+	(*GetUserProfileRequest)(nil),   // 6: v1.GetUserProfileRequest
+	(*GetUserProfileResponse)(nil),  // 7: v1.GetUserProfileResponse
+	(*ListTenantsRequest)(nil),      // 8: v1.ListTenantsRequest
+	(*ListTenantsResponse)(nil),     // 9: v1.ListTenantsResponse
+	(*SCIMToken)(nil),               // 10: v1.SCIMToken
+	(*CreateSCIMTokenRequest)(nil),  // 11: v1.CreateSCIMTokenRequest
+	(*CreateSCIMTokenResponse)(nil), // 12: v1.CreateSCIMTokenResponse
+	(*ListSCIMTokensRequest)(nil),   // 13: v1.ListSCIMTokensRequest
+	(*ListSCIMTokensResponse)(nil),  // 14: v1.ListSCIMTokensResponse
+	(*RevokeSCIMTokenRequest)(nil),  // 15: v1.RevokeSCIMTokenRequest
+	(*RevokeSCIMTokenResponse)(nil), // 16: v1.RevokeSCIMTokenResponse
+	(*timestamppb.Timestamp)(nil),   // 17: google.protobuf.Timestamp
+	(*Permission)(nil),              // 18: v1.Permission
+	(*Menu)(nil),                    // 19: v1.Menu
 }
 var file_apiserver_v1_tenant_proto_depIdxs = []int32{
-	10, // 0: v1.Tenant.created_at:type_name -> google.protobuf.Timestamp
-	10, // 1: v1.Tenant.updated_at:type_name -> google.protobuf.Timestamp
+	17, // 0: v1.Tenant.created_at:type_name -> google.protobuf.Timestamp
+	17, // 1: v1.Tenant.updated_at:type_name -> google.protobuf.Timestamp
 	0,  // 2: v1.UserProfile.current_tenant:type_name -> v1.Tenant
 	0,  // 3: v1.GetUserTenantsResponse.tenants:type_name -> v1.Tenant
 	1,  // 4: v1.GetUserProfileResponse.user:type_name -> v1.UserProfile
-	11, // 5: v1.GetUserProfileResponse.permissions:type_name -> v1.Permission
-	12, // 6: v1.GetUserProfileResponse.menus:type_name -> v1.Menu
+	18, // 5: v1.GetUserProfileResponse.permissions:type_name -> v1.Permission
+	19, // 6: v1.GetUserProfileResponse.menus:type_name -> v1.Menu
 	0,  // 7: v1.ListTenantsResponse.tenants:type_name -> v1.Tenant
-	8,  // [8:8] is the sub-list for method output_type
-	8,  // [8:8] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	17, // 8: v1.SCIMToken.expires_at:type_name -> google.protobuf.Timestamp
+	17, // 9: v1.SCIMToken.last_used_at:type_name -> google.protobuf.Timestamp
+	17, // 10: v1.SCIMToken.created_at:type_name -> google.protobuf.Timestamp
+	10, // 11: v1.CreateSCIMTokenResponse.token:type_name -> v1.SCIMToken
+	10, // 12: v1.ListSCIMTokensResponse.tokens:type_name -> v1.SCIMToken
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_apiserver_v1_tenant_proto_init() }
@@ -773,7 +1243,7 @@ func file_apiserver_v1_tenant_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_apiserver_v1_tenant_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
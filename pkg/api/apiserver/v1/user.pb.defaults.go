@@ -109,3 +109,54 @@ func (x *CheckPhoneAvailableRequest) Default() {
 
 func (x *CheckPhoneAvailableResponse) Default() {
 }
+
+func (x *SMSDeliveryReportRequest) Default() {
+}
+
+func (x *SMSDeliveryReportResponse) Default() {
+}
+
+func (x *GetSAMLMetadataRequest) Default() {
+}
+
+func (x *GetSAMLMetadataResponse) Default() {
+}
+
+func (x *SAMLLoginRequest) Default() {
+}
+
+func (x *SAMLLoginResponse) Default() {
+}
+
+func (x *SAMLAssertionRequest) Default() {
+}
+
+func (x *ImportSAMLIDPMetadataRequest) Default() {
+}
+
+func (x *ImportSAMLIDPMetadataResponse) Default() {
+}
+
+func (x *SAMLRoleNames) Default() {
+}
+
+func (x *SAMLConfig) Default() {
+}
+
+func (x *GetSAMLConfigRequest) Default() {
+}
+
+func (x *GetSAMLConfigResponse) Default() {
+}
+
+func (x *CreateSAMLConfigRequest) Default() {
+}
+
+func (x *CreateSAMLConfigResponse) Default() {
+}
+
+func (x *UpdateSAMLConfigRequest) Default() {
+}
+
+func (x *UpdateSAMLConfigResponse) Default() {
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package oneauth 提供 one-auth 自定义的 proto 选项，以及按选项生成的方法权限注册表.
+package oneauth
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// MethodRule 描述一个 RPC 方法的授权要求，以及 google.api.http 声明的 HTTP 路由.
+type MethodRule struct {
+	// FullMethod 为 gRPC 方法全名，例如 /v1.MiniBlog/GetUser
+	FullMethod string
+	// Permission 为方法需要的权限编码，Public 或 Service 为 true 时为空
+	Permission string
+	// Public 表示方法不需要权限检查
+	Public bool
+	// Service 表示方法使用服务凭证认证，不检查用户权限
+	Service bool
+	// Routes 为方法对应的 HTTP 路由，格式为 "GET /v1/users/{userID}"
+	Routes []string
+}
+
+// Annotated 判断方法是否声明了授权要求，未声明的方法一律拒绝.
+func (r *MethodRule) Annotated() bool {
+	return r.Public || r.Service || r.Permission != ""
+}
+
+// registry 是方法权限注册表
+type registry struct {
+	methods map[string]*MethodRule // 以 FullMethod 为键
+	routes  map[string]*MethodRule // 以 routeKey 为键
+}
+
+var (
+	once    sync.Once
+	current *registry
+)
+
+// load 返回方法权限注册表，首次调用时从已链接的 proto 描述中构建.
+// 调用方需要导入定义服务的 Go 包，其描述才会注册到 protoregistry.GlobalFiles.
+func load() *registry {
+	once.Do(func() {
+		current = build(protoregistry.GlobalFiles)
+	})
+	return current
+}
+
+// ForMethod 返回 gRPC 方法的授权要求，方法不存在时返回 false.
+func ForMethod(fullMethod string) (*MethodRule, bool) {
+	rule, ok := load().methods[fullMethod]
+	return rule, ok
+}
+
+// ForRoute 返回 HTTP 路由对应方法的授权要求.
+// route 可以是 gin 路由模板（/v1/users/:userID）或 google.api.http 模板（/v1/users/{userID}），只比较路径结构.
+func ForRoute(httpMethod, route string) (*MethodRule, bool) {
+	rule, ok := load().routes[routeKey(httpMethod, route)]
+	return rule, ok
+}
+
+// Rules 返回全部方法的授权要求，按 FullMethod 排序.
+func Rules() []*MethodRule {
+	reg := load()
+	rules := make([]*MethodRule, 0, len(reg.methods))
+	for _, rule := range reg.methods {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].FullMethod < rules[j].FullMethod })
+	return rules
+}
+
+// build 遍历 files 中的全部服务方法，读取授权选项和 HTTP 路由
+func build(files *protoregistry.Files) *registry {
+	reg := &registry{methods: make(map[string]*MethodRule), routes: make(map[string]*MethodRule)}
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				rule := newMethodRule(methods.Get(j))
+				reg.methods[rule.FullMethod] = rule
+				for _, route := range rule.Routes {
+					httpMethod, path, _ := strings.Cut(route, " ")
+					reg.routes[routeKey(httpMethod, path)] = rule
+				}
+			}
+		}
+		return true
+	})
+	return reg
+}
+
+// newMethodRule 读取方法的授权选项和 HTTP 路由
+func newMethodRule(md protoreflect.MethodDescriptor) *MethodRule {
+	rule := &MethodRule{FullMethod: fmt.Sprintf("/%s/%s", md.Parent().FullName(), md.Name())}
+	opts, ok := md.Options().(*descriptorpb.MethodOptions)
+	if !ok || opts == nil {
+		return rule
+	}
+
+	rule.Permission, _ = proto.GetExtension(opts, E_Permission).(string)
+	rule.Public, _ = proto.GetExtension(opts, E_Public).(bool)
+	rule.Service, _ = proto.GetExtension(opts, E_Service).(bool)
+	if rule.Public || rule.Service {
+		rule.Permission = ""
+	}
+
+	if http, ok := proto.GetExtension(opts, annotations.E_Http).(*annotations.HttpRule); ok && http != nil {
+		rules := append([]*annotations.HttpRule{http}, http.GetAdditionalBindings()...)
+		for _, r := range rules {
+			if method, path := httpPattern(r); path != "" {
+				rule.Routes = append(rule.Routes, method+" "+path)
+			}
+		}
+	}
+	return rule
+}
+
+// httpPattern 返回 HttpRule 的方法和路径模板
+func httpPattern(r *annotations.HttpRule) (string, string) {
+	switch p := r.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return "GET", p.Get
+	case *annotations.HttpRule_Put:
+		return "PUT", p.Put
+	case *annotations.HttpRule_Post:
+		return "POST", p.Post
+	case *annotations.HttpRule_Delete:
+		return "DELETE", p.Delete
+	case *annotations.HttpRule_Patch:
+		return "PATCH", p.Patch
+	case *annotations.HttpRule_Custom:
+		return strings.ToUpper(p.Custom.GetKind()), p.Custom.GetPath()
+	}
+	return "", ""
+}
+
+// routeKey 将路由模板中的路径参数统一替换为 {}，使 gin 和 google.api.http 的模板可以比较
+func routeKey(httpMethod, route string) string {
+	segments := strings.Split(strings.TrimSuffix(route, "/"), "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") || (strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}")) {
+			segments[i] = "{}"
+		}
+	}
+	return strings.ToUpper(httpMethod) + " " + strings.Join(segments, "/")
+}
@@ -0,0 +1,46 @@
+package oneauth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/ashwinyue/one-auth/pkg/api/apiserver/v1"
+	"github.com/ashwinyue/one-auth/pkg/api/oneauth"
+	pdpv1 "github.com/ashwinyue/one-auth/pkg/api/pdp/v1"
+)
+
+func TestRegistry(t *testing.T) {
+	rule, ok := oneauth.ForMethod(apiv1.MiniBlog_GetUser_FullMethodName)
+	require.True(t, ok)
+	assert.Equal(t, "user:view", rule.Permission)
+	assert.Equal(t, []string{"GET /v1/users/{userID}"}, rule.Routes)
+
+	// gin 路由模板与 google.api.http 模板的参数名不同也能匹配
+	rule, ok = oneauth.ForRoute("PUT", "/v1/users/:userID/roles")
+	require.True(t, ok)
+	assert.Equal(t, apiv1.MiniBlog_AssignUserRoles_FullMethodName, rule.FullMethod)
+
+	rule, ok = oneauth.ForMethod(apiv1.MiniBlog_Login_FullMethodName)
+	require.True(t, ok)
+	assert.True(t, rule.Public)
+
+	// 策略决策点使用服务凭证认证，不检查用户权限
+	rule, ok = oneauth.ForRoute("POST", "/v1/pdp/check")
+	require.True(t, ok)
+	assert.Equal(t, pdpv1.Authorization_Check_FullMethodName, rule.FullMethod)
+	assert.True(t, rule.Service)
+	assert.Empty(t, rule.Permission)
+
+	_, ok = oneauth.ForRoute("GET", "/v1/tenants")
+	assert.False(t, ok)
+
+	// apiserver 的每个方法都必须声明授权要求
+	for _, rule := range oneauth.Rules() {
+		if strings.HasPrefix(rule.FullMethod, "/v1.") || strings.HasPrefix(rule.FullMethod, "/pdp.v1.") {
+			assert.True(t, rule.Annotated(), rule.FullMethod)
+		}
+	}
+}
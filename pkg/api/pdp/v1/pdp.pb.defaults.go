@@ -0,0 +1,53 @@
+// 策略决策点（PDP）API 定义，供其他微服务查询用户在租户下的访问权限
+
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Code generated by protoc-gen-defaults. DO NOT EDIT.
+
+package v1
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	_ *timestamppb.Timestamp
+	_ *durationpb.Duration
+	_ *wrapperspb.BoolValue
+)
+
+func (x *Context) Default() {
+}
+
+func (x *CheckItem) Default() {
+}
+
+func (x *CheckResult) Default() {
+}
+
+func (x *CheckRequest) Default() {
+}
+
+func (x *CheckResponse) Default() {
+}
+
+func (x *ListAllowedActionsRequest) Default() {
+}
+
+func (x *ListAllowedActionsResponse) Default() {
+}
+
+func (x *ListAccessibleResourcesRequest) Default() {
+}
+
+func (x *AccessibleResource) Default() {
+}
+
+func (x *ListAccessibleResourcesResponse) Default() {
+}
@@ -0,0 +1,217 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// 策略决策点（PDP）API 定义，供其他微服务查询用户在租户下的访问权限
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v4.25.1
+// source: pdp/v1/pdp.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Authorization_Check_FullMethodName                   = "/pdp.v1.Authorization/Check"
+	Authorization_ListAllowedActions_FullMethodName      = "/pdp.v1.Authorization/ListAllowedActions"
+	Authorization_ListAccessibleResources_FullMethodName = "/pdp.v1.Authorization/ListAccessibleResources"
+)
+
+// AuthorizationClient is the client API for Authorization service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Authorization 是策略决策点服务，回答“用户能否在租户下对资源执行操作”.
+// 资源和操作对应 permissions 表的权限编码 resource:action，例如 user:view.
+type AuthorizationClient interface {
+	// Check 批量检查用户能否对资源执行操作
+	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+	// ListAllowedActions 列出用户可以对资源执行的操作
+	ListAllowedActions(ctx context.Context, in *ListAllowedActionsRequest, opts ...grpc.CallOption) (*ListAllowedActionsResponse, error)
+	// ListAccessibleResources 列出用户可以访问的资源
+	ListAccessibleResources(ctx context.Context, in *ListAccessibleResourcesRequest, opts ...grpc.CallOption) (*ListAccessibleResourcesResponse, error)
+}
+
+type authorizationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthorizationClient(cc grpc.ClientConnInterface) AuthorizationClient {
+	return &authorizationClient{cc}
+}
+
+func (c *authorizationClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckResponse)
+	err := c.cc.Invoke(ctx, Authorization_Check_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) ListAllowedActions(ctx context.Context, in *ListAllowedActionsRequest, opts ...grpc.CallOption) (*ListAllowedActionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAllowedActionsResponse)
+	err := c.cc.Invoke(ctx, Authorization_ListAllowedActions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationClient) ListAccessibleResources(ctx context.Context, in *ListAccessibleResourcesRequest, opts ...grpc.CallOption) (*ListAccessibleResourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccessibleResourcesResponse)
+	err := c.cc.Invoke(ctx, Authorization_ListAccessibleResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthorizationServer is the server API for Authorization service.
+// All implementations must embed UnimplementedAuthorizationServer
+// for forward compatibility.
+//
+// Authorization 是策略决策点服务，回答“用户能否在租户下对资源执行操作”.
+// 资源和操作对应 permissions 表的权限编码 resource:action，例如 user:view.
+type AuthorizationServer interface {
+	// Check 批量检查用户能否对资源执行操作
+	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	// ListAllowedActions 列出用户可以对资源执行的操作
+	ListAllowedActions(context.Context, *ListAllowedActionsRequest) (*ListAllowedActionsResponse, error)
+	// ListAccessibleResources 列出用户可以访问的资源
+	ListAccessibleResources(context.Context, *ListAccessibleResourcesRequest) (*ListAccessibleResourcesResponse, error)
+	mustEmbedUnimplementedAuthorizationServer()
+}
+
+// UnimplementedAuthorizationServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuthorizationServer struct{}
+
+func (UnimplementedAuthorizationServer) Check(context.Context, *CheckRequest) (*CheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
+}
+func (UnimplementedAuthorizationServer) ListAllowedActions(context.Context, *ListAllowedActionsRequest) (*ListAllowedActionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAllowedActions not implemented")
+}
+func (UnimplementedAuthorizationServer) ListAccessibleResources(context.Context, *ListAccessibleResourcesRequest) (*ListAccessibleResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessibleResources not implemented")
+}
+func (UnimplementedAuthorizationServer) mustEmbedUnimplementedAuthorizationServer() {}
+func (UnimplementedAuthorizationServer) testEmbeddedByValue()                       {}
+
+// UnsafeAuthorizationServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthorizationServer will
+// result in compilation errors.
+type UnsafeAuthorizationServer interface {
+	mustEmbedUnimplementedAuthorizationServer()
+}
+
+func RegisterAuthorizationServer(s grpc.ServiceRegistrar, srv AuthorizationServer) {
+	// If the following call pancis, it indicates UnimplementedAuthorizationServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Authorization_ServiceDesc, srv)
+}
+
+func _Authorization_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authorization_Check_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).Check(ctx, req.(*CheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_ListAllowedActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllowedActionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).ListAllowedActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authorization_ListAllowedActions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).ListAllowedActions(ctx, req.(*ListAllowedActionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authorization_ListAccessibleResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessibleResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServer).ListAccessibleResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authorization_ListAccessibleResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServer).ListAccessibleResources(ctx, req.(*ListAccessibleResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Authorization_ServiceDesc is the grpc.ServiceDesc for Authorization service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Authorization_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pdp.v1.Authorization",
+	HandlerType: (*AuthorizationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Check",
+			Handler:    _Authorization_Check_Handler,
+		},
+		{
+			MethodName: "ListAllowedActions",
+			Handler:    _Authorization_ListAllowedActions_Handler,
+		},
+		{
+			MethodName: "ListAccessibleResources",
+			Handler:    _Authorization_ListAccessibleResources_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pdp/v1/pdp.proto",
+}
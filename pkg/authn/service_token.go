@@ -0,0 +1,33 @@
+// Copyright 2022 Lingfei Kong <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/onex.
+//
+
+package authn
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ServiceTokenPrefix is the plain text prefix of service tokens, used to recognize them in logs and secret scanning.
+const ServiceTokenPrefix = "svc_"
+
+// GenerateServiceToken generates a new service token, returns the plain text,
+// the prefix used for display and the hash used for storage.
+func GenerateServiceToken() (token, prefix, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	token = ServiceTokenPrefix + hex.EncodeToString(b)
+	return token, token[:len(ServiceTokenPrefix)+8], HashServiceToken(token), nil
+}
+
+// HashServiceToken returns the SHA-256 hash (hex) of the service token.
+func HashServiceToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
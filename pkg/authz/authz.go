@@ -15,33 +15,39 @@
 package authz
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	casbin "github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
 	adapter "github.com/casbin/gorm-adapter/v3"
 	"github.com/google/wire"
 	"gorm.io/gorm"
+
+	"github.com/ashwinyue/one-auth/pkg/api/oneauth"
 )
 
 const (
 	// 默认的 Casbin RBAC with Domains 访问控制模型，支持多租户.
 	// 参考官方文档: https://casbin.org/zh/docs/rbac-with-domains
 	// 修正为与旧项目一致的标准格式：obj 在 dom 之前
+	// p 规则带 eft 字段，采用拒绝优先：命中任一 deny 规则即拒绝，否则需命中 allow 规则
 	defaultRBACWithDomainsModel = `[request_definition]
 r = sub, obj, dom
 
 [policy_definition]
-p = sub, obj, dom
+p = sub, obj, dom, eft
 
 [role_definition]
 g = _, _, _
 
 [policy_effect]
-e = some(where (p.eft == allow))
+e = some(where (p.eft == allow)) && !some(where (p.eft == deny))
 
 [matchers]
 m = g(r.sub, p.sub, r.dom) && r.obj == p.obj && r.dom == p.dom`
@@ -197,16 +203,23 @@ func (r *DefaultTenantResolver) GetPermissionID(permissionIdentifier, tenantIden
 		tenantID = 1 // 使用默认租户
 	}
 
-	// 从数据库查询，使用权限名称而不是permission_code
+	// 从数据库查询，优先匹配权限编码，兼容使用权限名称的旧调用
 	var permission struct {
 		ID int64 `gorm:"column:id"`
 	}
 
 	err = r.db.Table("permissions").
 		Select("id").
-		Where("name = ? AND tenant_id = ? AND deleted_at IS NULL",
+		Where("permission_code = ? AND tenant_id = ? AND deleted_at IS NULL",
 			permissionIdentifier, tenantID).
 		First(&permission).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = r.db.Table("permissions").
+			Select("id").
+			Where("name = ? AND tenant_id = ? AND deleted_at IS NULL",
+				permissionIdentifier, tenantID).
+			First(&permission).Error
+	}
 
 	if err != nil {
 		return 0, err
@@ -235,9 +248,20 @@ func (r *DefaultTenantResolver) GetPermissionIdentifier(permissionID int64) (str
 
 // Authz 定义了一个授权器，提供授权功能.
 type Authz struct {
-	*casbin.SyncedCachedEnforcer                // 使用 Casbin 的同步缓存授权器（参考旧项目）
-	tenantResolver               TenantResolver // 租户解析器
-	idConverter                  *IDConverter   // ID转换器（参考旧项目实现，统一使用）
+	*casbin.SyncedCachedEnforcer                                   // 使用 Casbin 的同步缓存授权器（参考旧项目）
+	db                           *gorm.DB                          // 查询用户、菜单等关系数据
+	tenantResolver               TenantResolver                    // 租户解析器
+	idConverter                  *IDConverter                      // ID转换器（参考旧项目实现，统一使用）
+	routeIndex                   *RouteIndex                       // API权限路由索引
+	conditions                   *snapshot[conditionTable]         // 权限条件和租户时区缓存
+	permissions                  *snapshot[permissionCatalog]      // 各租户已启用的权限编码缓存
+	systemRoles                  *snapshot[systemRoleTable]        // 租户所有者角色缓存
+	sodRules                     *snapshot[sodTable]               // 职责分离规则缓存
+	sodMu                        sync.Mutex                        // 串行化用户角色分配的检查和写入
+	decisions                    *decisionLog                      // 最近的授权决策记录
+	decisionCache                *lruCache[decisionKey, *Decision] // 授权决策缓存，策略或权限目录变更时清空
+	decisionTTL                  time.Duration                     // 授权决策缓存的有效期，也是策略决策点返回给调用方的缓存时长
+	watcher                      persist.WatcherEx                 // 在实例之间同步策略变更，未配置时为 nil
 }
 
 // Option 定义了一个函数选项类型，用于自定义 NewAuthz 的行为.
@@ -245,8 +269,12 @@ type Option func(*authzConfig)
 
 // authzConfig 是授权器的配置结构.
 type authzConfig struct {
-	model              string        // Casbin 的模型字符串（统一字段名）
-	autoLoadPolicyTime time.Duration // 自动加载策略的时间间隔
+	model              string            // Casbin 的模型字符串（统一字段名）
+	autoLoadPolicyTime time.Duration     // 自动加载策略的时间间隔
+	watcher            persist.WatcherEx // 策略变更的 Watcher
+	reconcileInterval  time.Duration     // 配置 Watcher 后全量加载策略的时间间隔
+	decisionCacheSize  int               // 授权决策缓存的容量，为 0 时不缓存
+	decisionCacheTTL   time.Duration     // 授权决策缓存的有效期
 }
 
 // ProviderSet 是一个 Wire 的 Provider 集合，用于声明依赖注入的规则。
@@ -258,6 +286,9 @@ func defaultAuthzConfig() *authzConfig {
 	return &authzConfig{
 		model:              defaultRBACWithDomainsModel,
 		autoLoadPolicyTime: 10 * time.Second,
+		reconcileInterval:  DefaultReconcileInterval,
+		decisionCacheSize:  DefaultDecisionCacheSize,
+		decisionCacheTTL:   DefaultDecisionCacheTTL,
 	}
 }
 
@@ -291,6 +322,29 @@ func WithAutoLoadPolicyTime(interval time.Duration) Option {
 	}
 }
 
+// WithWatcher 设置在实例之间同步策略增量变更的 Watcher.
+// 配置后按 reconcileInterval 全量加载策略作为兜底，不再按 autoLoadPolicyTime 轮询数据库.
+func WithWatcher(watcher persist.WatcherEx) Option {
+	return func(cfg *authzConfig) {
+		cfg.watcher = watcher
+	}
+}
+
+// WithReconcileInterval 允许通过选项自定义配置 Watcher 后全量加载策略的时间间隔.
+func WithReconcileInterval(interval time.Duration) Option {
+	return func(cfg *authzConfig) {
+		cfg.reconcileInterval = interval
+	}
+}
+
+// WithDecisionCache 允许通过选项自定义授权决策缓存的容量和有效期，size 为 0 时不缓存.
+func WithDecisionCache(size int, ttl time.Duration) Option {
+	return func(cfg *authzConfig) {
+		cfg.decisionCacheSize = size
+		cfg.decisionCacheTTL = ttl
+	}
+}
+
 // NewAuthz 创建一个使用 Casbin 完成授权的授权器，通过函数选项模式支持自定义配置.
 func NewAuthz(db *gorm.DB, opts ...Option) (*Authz, error) {
 	// 初始化默认配置
@@ -310,6 +364,11 @@ func NewAuthz(db *gorm.DB, opts ...Option) (*Authz, error) {
 	// 从配置中加载 Casbin 模型
 	m, _ := model.NewModelFromString(cfg.model)
 
+	// 为没有 eft 字段的旧 p 规则补齐 allow
+	if err := migrateLegacyPolicies(db, m); err != nil {
+		return nil, err
+	}
+
 	// 初始化授权器（使用缓存版本，参考旧项目）
 	enforcer, err := casbin.NewSyncedCachedEnforcer(m, adapter)
 	if err != nil {
@@ -321,19 +380,62 @@ func NewAuthz(db *gorm.DB, opts ...Option) (*Authz, error) {
 		return nil, err // 返回错误
 	}
 
-	// 启动自动加载策略，使用配置的时间间隔
-	enforcer.StartAutoLoadPolicy(cfg.autoLoadPolicyTime)
-
-	// 创建默认租户解析器和ID转换器
-	tenantResolver := NewDefaultTenantResolver(db)
+	// 创建带缓存的默认租户解析器和ID转换器
+	tenantResolver := &cachedResolver{
+		next:  NewDefaultTenantResolver(db),
+		cache: newLRUCache[resolverKey, resolved](cacheResolver, resolverCacheSize, cfg.autoLoadPolicyTime),
+	}
 	idConverter := NewIDConverter()
 
-	// 返回新的授权器实例
-	return &Authz{
+	a := &Authz{
 		SyncedCachedEnforcer: enforcer,
+		db:                   db,
 		tenantResolver:       tenantResolver,
 		idConverter:          idConverter,
-	}, nil
+		routeIndex:           NewRouteIndex(db, cfg.autoLoadPolicyTime),
+		conditions: newSnapshot(cfg.autoLoadPolicyTime, func() (*conditionTable, error) {
+			return loadConditionTable(db)
+		}),
+		permissions: newSnapshot(cfg.autoLoadPolicyTime, func() (*permissionCatalog, error) {
+			return loadPermissionCatalog(db)
+		}),
+		systemRoles: newSnapshot(cfg.autoLoadPolicyTime, func() (*systemRoleTable, error) {
+			return loadSystemRoleTable(db)
+		}),
+		sodRules: newSnapshot(cfg.autoLoadPolicyTime, func() (*sodTable, error) {
+			return loadSoDTable(db)
+		}),
+		decisions:     newDecisionLog(decisionLogSize),
+		decisionCache: newLRUCache[decisionKey, *Decision](cacheDecision, cfg.decisionCacheSize, cfg.decisionCacheTTL),
+		decisionTTL:   cfg.decisionCacheTTL,
+	}
+
+	// 本实例的策略变更需要清空决策缓存，未配置 Watcher 时同样安装
+	if err := a.setWatcher(cfg.watcher); err != nil {
+		return nil, err
+	}
+
+	// 配置 Watcher 时增量同步变更，只需定期全量加载兜底
+	if cfg.watcher != nil {
+		a.startReconcile(cfg.reconcileInterval)
+		return a, nil
+	}
+
+	// 启动自动加载策略，使用配置的时间间隔
+	enforcer.StartAutoLoadPolicy(cfg.autoLoadPolicyTime)
+
+	return a, nil
+}
+
+// migrateLegacyPolicies 在模型包含 eft 字段时，将 eft 为空的 p 规则更新为 allow
+func migrateLegacyPolicies(db *gorm.DB, m model.Model) error {
+	assertion, ok := m["p"]["p"]
+	if !ok || len(assertion.Tokens) != 4 || assertion.Tokens[3] != "p_eft" {
+		return nil
+	}
+	return db.Table("casbin_rule").
+		Where("ptype = ? AND (v3 IS NULL OR v3 = '')", "p").
+		Update("v3", EffectAllow).Error
 }
 
 // resolveTenantDomain 将租户标识符转换为租户ID字符串用于Casbin domain
@@ -362,35 +464,29 @@ func (a *Authz) Authorize(sub, obj, act string) (bool, error) {
 	return a.AuthorizeWithDomain(sub, "default", obj, act)
 }
 
-// AuthorizeWithDomain 使用domain进行授权检查
+// AuthorizeWithDomain 使用domain进行授权检查，结果使用授权决策缓存
 func (a *Authz) AuthorizeWithDomain(sub, tenantIdentifier, obj, act string) (bool, error) {
-	// 将租户标识符转换为租户ID
-	domain := a.resolveTenantDomain(tenantIdentifier)
-
-	// 根据用户名查找实际的用户ID
-	var result struct {
-		ID int64 `gorm:"column:id"`
-	}
-	err := a.tenantResolver.(*DefaultTenantResolver).db.Table("user").
-		Select("id").
-		Where("username = ? AND deleted_at IS NULL", sub).
-		First(&result).Error
+	decision, err := a.cachedDecision(decisionKey{subject: sub, domain: tenantIdentifier, object: obj, action: act}, func() (*Decision, bool, error) {
+		// 将租户标识符转换为租户ID
+		domain := a.resolveTenantDomain(tenantIdentifier)
 
-	userID := result.ID
+		// 根据用户名查找实际的用户ID
+		userID, err := a.resolveUser(sub)
+		if err != nil {
+			return nil, false, err
+		}
 
-	if err != nil {
-		// 如果找不到用户，尝试解析为数字ID
-		if id, parseErr := strconv.ParseInt(sub, 10, 64); parseErr == nil {
-			userID = id
-		} else {
-			return false, fmt.Errorf("user not found: %s", sub)
+		// 调用 Enforce 方法进行授权检查，修正参数顺序为：sub, obj, dom
+		allowed, err := a.Enforce(a.idConverter.ToDUserID(userID), obj, domain)
+		if err != nil {
+			return nil, false, err
 		}
+		return &Decision{Allowed: allowed}, true, nil
+	})
+	if err != nil {
+		return false, err
 	}
-
-	// 格式化用户ID
-	formattedUserID := a.idConverter.ToDUserID(userID)
-	// 调用 Enforce 方法进行授权检查，修正参数顺序为：sub, obj, dom
-	return a.Enforce(formattedUserID, obj, domain)
+	return decision.Allowed, nil
 }
 
 // AddRoleForUser 为用户在指定domain中添加角色
@@ -398,23 +494,9 @@ func (a *Authz) AddRoleForUser(user, roleIdentifier, tenantIdentifier string) (b
 	domain := a.resolveTenantDomain(tenantIdentifier)
 
 	// 获取实际的用户ID
-	var userResult struct {
-		ID int64 `gorm:"column:id"`
-	}
-	err := a.tenantResolver.(*DefaultTenantResolver).db.Table("user").
-		Select("id").
-		Where("username = ? AND deleted_at IS NULL", user).
-		First(&userResult).Error
-
-	userID := userResult.ID
-
+	userID, err := a.resolveUser(user)
 	if err != nil {
-		// 如果找不到用户，尝试解析为数字ID
-		if id, parseErr := strconv.ParseInt(user, 10, 64); parseErr == nil {
-			userID = id
-		} else {
-			return false, fmt.Errorf("user not found: %s", user)
-		}
+		return false, err
 	}
 
 	// 获取实际的角色ID
@@ -546,7 +628,7 @@ func (a *Authz) DeleteRole(roleIdentifier, tenantIdentifier string) bool {
 	domain := a.resolveTenantDomain(tenantIdentifier)
 	role := a.resolveRoleSubject(roleIdentifier, tenantIdentifier)
 	// 删除角色的所有权限
-	res1, _ := a.RemoveFilteredPolicy(0, role, domain)
+	res1, _ := a.RemoveFilteredPolicy(0, role, "", domain)
 	// 删除所有用户与该角色的关联
 	res2, _ := a.RemoveFilteredGroupingPolicy(1, role, domain)
 	return res1 || res2
@@ -558,7 +640,7 @@ func (a *Authz) AddPermissionForUser(user, permission, tenant string) (bool, err
 	userID := a.idConverter.ToDUserID(a.parseStringToInt64(user))
 	permissionID := a.idConverter.ToDResourceID(a.parseStringToInt64(permission))
 
-	return a.AddPolicy(userID, permissionID, domain)
+	return a.AddPolicy(userID, permissionID, domain, EffectAllow)
 }
 
 // DeletePermissionForUser 删除用户在指定租户下的权限（参考旧项目API）
@@ -567,7 +649,7 @@ func (a *Authz) DeletePermissionForUser(user, permission, tenant string) (bool,
 	userID := a.idConverter.ToDUserID(a.parseStringToInt64(user))
 	permissionID := a.idConverter.ToDResourceID(a.parseStringToInt64(permission))
 
-	return a.RemovePolicy(userID, permissionID, domain)
+	return a.RemoveFilteredPolicy(0, userID, permissionID, domain)
 }
 
 // GetPermissionsForUser 获取用户在指定租户下的权限列表
@@ -585,7 +667,7 @@ func (a *Authz) HasPermissionForUser(user, permission, tenant string) bool {
 	userID := a.idConverter.ToDUserID(a.parseStringToInt64(user))
 	permissionID := a.idConverter.ToDResourceID(a.parseStringToInt64(permission))
 
-	has, _ := a.HasPolicy(userID, permissionID, domain)
+	has, _ := a.HasPolicy(userID, permissionID, domain, EffectAllow)
 	return has
 }
 
@@ -645,63 +727,40 @@ func (a *Authz) parseStringToInt64(s string) int64 {
 	return 0
 }
 
-// CheckPermission 检查用户是否具有指定权限
-func (a *Authz) CheckPermission(userID, tenantIdentifier, permissionCode string) (bool, error) {
+// CheckPermission 检查用户是否具有指定权限，返回决定结果的规则，rc 用于权限条件求值
+func (a *Authz) CheckPermission(userID, tenantIdentifier, permissionCode string, rc *RequestContext) (*Decision, error) {
 	// 首先检查用户是否为超级管理员
 	isSuperAdmin, err := a.isSuperAdmin(userID, tenantIdentifier)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// 超级管理员拥有所有权限，直接返回true
 	if isSuperAdmin {
-		return true, nil
+		return &Decision{Allowed: true, Effect: EffectAllow, Reason: ReasonSuperAdmin}, nil
 	}
 
 	// 普通用户需要检查具体权限
-	return a.checkSpecificPermission(userID, tenantIdentifier, permissionCode)
+	return a.checkSpecificPermission(userID, tenantIdentifier, permissionCode, rc)
 }
 
 // checkSpecificPermission 检查普通用户的具体权限
-func (a *Authz) checkSpecificPermission(userID, tenantIdentifier, permissionCode string) (bool, error) {
+func (a *Authz) checkSpecificPermission(userID, tenantIdentifier, permissionCode string, rc *RequestContext) (*Decision, error) {
 	// 将租户标识符转换为租户ID
 	tenantID, err := a.tenantResolver.GetTenantID(tenantIdentifier)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// 查询权限ID
 	permissionID, err := a.tenantResolver.GetPermissionID(permissionCode, tenantIdentifier)
 	if err != nil {
 		// 如果权限不存在，普通用户没有该权限
-		return false, nil
+		return &Decision{Reason: ReasonNoMatchedRule}, nil
 	}
 
-	// 构建用户、权限和租户标识符
-	userIdentifier := fmt.Sprintf("u%s", userID)
-	permissionIdentifier := fmt.Sprintf("p%d", permissionID)
-	domain := fmt.Sprintf("t%d", tenantID)
-
 	// 使用Casbin进行权限检查
-	return a.Enforce(userIdentifier, permissionIdentifier, domain)
-}
-
-// isSuperAdmin 检查用户是否为超级管理员
-func (a *Authz) isSuperAdmin(userID, tenantIdentifier string) (bool, error) {
-	// 获取用户在指定租户下的角色
-	roles, err := a.GetRolesForUser(userID, tenantIdentifier)
-	if err != nil {
-		return false, err
-	}
-
-	// 检查是否包含超级管理员角色
-	for _, role := range roles {
-		if role == "super_admin" || role == "r1" {
-			return true, nil
-		}
-	}
-
-	return false, nil
+	return a.DecidePermission(userID, tenantID, permissionID, rc)
 }
 
 // CheckMenuAccess 检查菜单访问权限（也支持超级管理员免检）
@@ -723,7 +782,7 @@ func (a *Authz) checkMenuAccessForRegularUser(userID, tenantIdentifier string, m
 		IsRequired     bool   `gorm:"column:is_required"`
 	}
 
-	err := a.tenantResolver.(*DefaultTenantResolver).db.Table("menu_permissions mp").
+	err := a.db.Table("menu_permissions mp").
 		Select("p.permission_code, mp.is_required").
 		Joins("JOIN permissions p ON p.id = mp.permission_id").
 		Where("mp.menu_id = ? AND p.deleted_at IS NULL", menuID).
@@ -741,8 +800,8 @@ func (a *Authz) checkMenuAccessForRegularUser(userID, tenantIdentifier string, m
 	// 检查必需权限
 	for _, result := range results {
 		if result.IsRequired {
-			hasPermission, err := a.CheckPermission(userID, tenantIdentifier, result.PermissionCode)
-			if err != nil || !hasPermission {
+			decision, err := a.CheckPermission(userID, tenantIdentifier, result.PermissionCode, nil)
+			if err != nil || !decision.Allowed {
 				return false, err
 			}
 		}
@@ -753,62 +812,98 @@ func (a *Authz) checkMenuAccessForRegularUser(userID, tenantIdentifier string, m
 
 // CheckAPIPermission 检查API访问权限（也支持超级管理员免检）
 func (a *Authz) CheckAPIPermission(userID, tenantIdentifier, accessPath, httpMethod string) (bool, error) {
-	// 超级管理员可以访问所有API
-	if isSuperAdmin, _ := a.isSuperAdmin(userID, tenantIdentifier); isSuperAdmin {
-		return true, nil
+	decision, err := a.decideAPIAccess(userID, tenantIdentifier, &RequestContext{Method: httpMethod, Path: accessPath})
+	if err != nil {
+		return false, err
 	}
-
-	// 普通用户检查API权限
-	return a.checkAPIAccessForRegularUser(userID, tenantIdentifier, accessPath, httpMethod)
+	return decision.Allowed, nil
 }
 
 // CheckAPIAccess 实现APIAuthorizer接口（适配中间件）
 func (a *Authz) CheckAPIAccess(subject, domain, object, action string) (bool, error) {
-	// 从domain中解析租户标识符
-	tenantIdentifier := domain
-	if domain == "default" || domain == "" {
-		tenantIdentifier = "t1" // 默认租户
-	}
-
 	// 调用原有的API权限检查方法
-	return a.CheckAPIPermission(subject, tenantIdentifier, object, action)
+	return a.CheckAPIPermission(subject, apiTenantIdentifier(domain), object, action)
 }
 
-// checkAPIAccessForRegularUser 检查普通用户的API访问权限
-func (a *Authz) checkAPIAccessForRegularUser(userID, tenantIdentifier, accessPath, httpMethod string) (bool, error) {
-	// 查询API对应的权限
-	var permissions []struct {
-		PermissionCode string `gorm:"column:permission_code"`
+// CheckRouteAccess 按请求上下文检查API访问权限.
+// rc.Route 为路由模板，rc.Path 为实际请求路径，用于匹配正则等规则；rc 同时用于权限条件求值.
+func (a *Authz) CheckRouteAccess(subject, domain string, rc *RequestContext) (*Decision, error) {
+	return a.decideAPIAccess(subject, apiTenantIdentifier(domain), rc)
+}
+
+// decideAPIAccess 检查API访问权限并返回决定结果的规则.
+// 路由在 proto 中有对应方法时按方法的权限编码授权，否则按 permissions 表中的 API 规则授权.
+// 路由模板和实际路径一起作为缓存键，权限配置了条件时决策依赖请求上下文，不缓存.
+func (a *Authz) decideAPIAccess(userID, tenantIdentifier string, rc *RequestContext) (*Decision, error) {
+	key := decisionKey{subject: userID, domain: tenantIdentifier, object: rc.Route + " " + rc.Path, action: rc.Method}
+	return a.cachedDecision(key, func() (*Decision, bool, error) {
+		// 路由对应 proto 中的方法时，与 gRPC 一样按方法声明的权限编码授权
+		if rule, ok := oneauth.ForRoute(rc.Method, rc.Route); ok {
+			return a.decideMethod(userID, tenantIdentifier, rule, rc)
+		}
+
+		// 超级管理员可以访问所有API
+		if isSuperAdmin, _ := a.isSuperAdmin(userID, tenantIdentifier); isSuperAdmin {
+			return &Decision{Allowed: true, Effect: EffectAllow, Reason: ReasonSuperAdmin}, true, nil
+		}
+
+		// 普通用户检查API权限
+		return a.checkAPIAccessForRegularUser(userID, tenantIdentifier, rc)
+	})
+}
+
+// MatchAPIPermissions 返回租户下与API路径匹配的权限ID，method 为空时匹配任意方法
+func (a *Authz) MatchAPIPermissions(tenantID int64, method string, paths ...string) ([]int64, error) {
+	return a.routeIndex.Match(tenantID, method, paths...)
+}
+
+// InvalidateAPIRoutes 在API权限变更后使路由索引和授权决策缓存失效
+func (a *Authz) InvalidateAPIRoutes() {
+	if a.routeIndex != nil {
+		a.routeIndex.Invalidate()
 	}
+	a.decisionCache.purge()
+}
 
-	err := a.tenantResolver.(*DefaultTenantResolver).db.Table("permissions").
-		Select("permission_code").
-		Where("resource_type = 'api' AND resource_path = ? AND http_method = ? AND deleted_at IS NULL",
-			accessPath, httpMethod).
-		Find(&permissions).Error
+// InvalidateConditions 在权限条件或租户时区变更后使条件缓存和授权决策缓存失效
+func (a *Authz) InvalidateConditions() {
+	if a.conditions != nil {
+		a.conditions.invalidate()
+	}
+	a.decisionCache.purge()
+}
 
+// apiTenantIdentifier 从domain中解析租户标识符
+func apiTenantIdentifier(domain string) string {
+	if domain == "default" || domain == "" {
+		return "t1" // 默认租户
+	}
+	return domain
+}
+
+// checkAPIAccessForRegularUser 检查普通用户的API访问权限，同时返回决策是否可以缓存
+func (a *Authz) checkAPIAccessForRegularUser(userID, tenantIdentifier string, rc *RequestContext) (*Decision, bool, error) {
+	tenantID, err := a.tenantResolver.GetTenantID(tenantIdentifier)
 	if err != nil {
-		return false, err
+		return nil, false, err
 	}
 
-	// 如果API没有配置权限，根据默认策略决定
-	if len(permissions) == 0 {
-		// 可以配置为默认拒绝或默认允许
-		return false, nil // 默认拒绝未配置权限的API
+	// 从路由索引中查询API对应的权限
+	permissionIDs, err := a.routeIndex.Match(tenantID, rc.Method, rc.Route, rc.Path)
+	if err != nil {
+		return nil, false, err
 	}
 
-	// 检查用户是否拥有其中任一权限
-	for _, perm := range permissions {
-		hasPermission, err := a.CheckPermission(userID, tenantIdentifier, perm.PermissionCode)
-		if err != nil {
-			continue
-		}
-		if hasPermission {
-			return true, nil
-		}
+	// 如果API没有配置权限，默认拒绝
+	if len(permissionIDs) == 0 {
+		return &Decision{Reason: ReasonNoAPIPermission}, true, nil
 	}
 
-	return false, nil
+	decision, err := a.DecideAnyPermission(userID, tenantID, permissionIDs, rc)
+	if err != nil {
+		return nil, false, err
+	}
+	return decision, !a.hasConditions(permissionIDs), nil
 }
 
 // CheckMenuPermissionByCode 通过权限编码检查用户权限
@@ -843,7 +938,7 @@ func (a *Authz) GetUserAccessibleMenus(userID, tenantIdentifier string) ([]map[s
 
 	// 查询用户可访问的菜单
 	var menus []map[string]interface{}
-	err = a.tenantResolver.(*DefaultTenantResolver).db.Table("v_user_accessible_menus").
+	err = a.db.Table("v_user_accessible_menus").
 		Where("user_id = ? AND tenant_id = ?", formattedUserID, formattedTenantID).
 		Find(&menus).Error
 
@@ -853,7 +948,7 @@ func (a *Authz) GetUserAccessibleMenus(userID, tenantIdentifier string) ([]map[s
 // GetMenuPermissions 获取菜单的所有权限
 func (a *Authz) GetMenuPermissions(menuID int64) ([]map[string]interface{}, error) {
 	var permissions []map[string]interface{}
-	err := a.tenantResolver.(*DefaultTenantResolver).db.Table("permissions").
+	err := a.db.Table("permissions").
 		Where("menu_id = ? AND status = 1 AND deleted_at IS NULL", menuID).
 		Find(&permissions).Error
 
@@ -864,7 +959,7 @@ func (a *Authz) GetMenuPermissions(menuID int64) ([]map[string]interface{}, erro
 func (a *Authz) AddMenuPermission(menuID int64, actionType, permissionName, description string) error {
 	// 首先获取菜单的租户ID
 	var tenantID int64
-	err := a.tenantResolver.(*DefaultTenantResolver).db.Table("menus").
+	err := a.db.Table("menus").
 		Select("tenant_id").
 		Where("id = ? AND deleted_at IS NULL", menuID).
 		First(&tenantID).Error
@@ -884,13 +979,13 @@ func (a *Authz) AddMenuPermission(menuID int64, actionType, permissionName, desc
 		"status":          1,
 	}
 
-	return a.tenantResolver.(*DefaultTenantResolver).db.Table("permissions").Create(permission).Error
+	return a.db.Table("permissions").Create(permission).Error
 }
 
 // RemoveMenuPermission 移除菜单权限
 func (a *Authz) RemoveMenuPermission(menuID int64, actionType string) error {
 	permissionCode := fmt.Sprintf("menu_%d_%s", menuID, actionType)
-	return a.tenantResolver.(*DefaultTenantResolver).db.Table("permissions").
+	return a.db.Table("permissions").
 		Where("menu_id = ? AND permission_code = ?", menuID, permissionCode).
 		Update("deleted_at", time.Now()).Error
 }
@@ -903,7 +998,7 @@ func (a *Authz) UpdateMenuAccessConfig(menuID int64, apiPath, httpMethods string
 		"require_auth": requireAuth,
 	}
 
-	return a.tenantResolver.(*DefaultTenantResolver).db.Table("menus").
+	return a.db.Table("menus").
 		Where("id = ? AND deleted_at IS NULL", menuID).
 		Updates(updates).Error
 }
@@ -0,0 +1,136 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// 缓存名称，用作监控指标的 cache 标签
+const (
+	cacheDecision = "decision" // 授权决策
+	cacheResolver = "resolver" // 租户、角色、权限标识符和用户名解析
+)
+
+// cacheRequests 统计各缓存的命中和未命中次数
+var cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "one_auth",
+	Subsystem: "authz",
+	Name:      "cache_requests_total",
+	Help:      "Number of authz cache lookups partitioned by cache and result (hit or miss).",
+}, []string{"cache", "result"})
+
+// lruCache 是有容量上限和有效期的 LRU 缓存，并发安全.
+// 清空缓存时递增代数，清空前开始计算的结果不会再写入缓存.
+type lruCache[K comparable, V any] struct {
+	name  string
+	size  int
+	ttl   time.Duration
+	mu    sync.Mutex
+	gen   uint64
+	order *list.List
+	items map[K]*list.Element
+	hit   prometheus.Counter
+	miss  prometheus.Counter
+}
+
+type lruEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// newLRUCache 创建 LRU 缓存，size 不大于 0 时返回 nil，表示不缓存
+func newLRUCache[K comparable, V any](name string, size int, ttl time.Duration) *lruCache[K, V] {
+	if size <= 0 {
+		return nil
+	}
+	return &lruCache[K, V]{
+		name:  name,
+		size:  size,
+		ttl:   ttl,
+		order: list.New(),
+		items: make(map[K]*list.Element, size),
+		hit:   cacheRequests.WithLabelValues(name, "hit"),
+		miss:  cacheRequests.WithLabelValues(name, "miss"),
+	}
+}
+
+// get 返回未过期的缓存值，同时返回当前代数供 add 使用
+func (c *lruCache[K, V]) get(key K) (V, uint64, bool) {
+	var zero V
+	if c == nil {
+		return zero, 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*lruEntry[K, V])
+		if c.ttl <= 0 || time.Now().Before(entry.expiresAt) {
+			c.order.MoveToFront(elem)
+			c.hit.Inc()
+			return entry.value, c.gen, true
+		}
+		c.order.Remove(elem)
+		delete(c.items, key)
+	}
+	c.miss.Inc()
+	return zero, c.gen, false
+}
+
+// add 写入缓存，gen 与当前代数不一致时说明期间发生过变更，丢弃该值
+func (c *lruCache[K, V]) add(key K, value V, gen uint64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen {
+		return
+	}
+
+	entry := &lruEntry[K, V]{key: key, value: value, expiresAt: time.Now().Add(c.ttl)}
+	if elem, ok := c.items[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.items[key] = c.order.PushFront(entry)
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+// purge 清空缓存
+func (c *lruCache[K, V]) purge() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.order.Init()
+	clear(c.items)
+}
+
+// len 返回缓存的条目数
+func (c *lruCache[K, V]) len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
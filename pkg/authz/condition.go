@@ -0,0 +1,455 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/casbin/govaluate"
+	"gorm.io/gorm"
+)
+
+// 条件表达式中可用的变量.
+//
+//	subject.id、subject.tenant_id
+//	request.ip、request.method、request.path、request.route
+//	request.time（Unix 秒，可与 "2025-01-01" 这类日期字面量比较）
+//	request.clock（"15:04"）、request.hour、request.minute、request.weekday（0 表示周日），均为租户时区
+//	resource.<name>（资源属性，整数统一按数字处理）
+//
+// 可用的函数：
+//
+//	cidrMatch(request.ip, "10.0.0.0/8", "192.168.1.10")
+//	timeBetween(request.clock, "09:00", "18:00")（结束时间小于开始时间时表示跨越零点）
+var conditionFields = map[string]map[string]bool{
+	"subject": {"id": true, "tenant_id": true},
+	"request": {
+		"ip": true, "method": true, "path": true, "route": true,
+		"time": true, "clock": true, "hour": true, "minute": true, "weekday": true,
+	},
+	"resource": nil,
+}
+
+// conditionFunctions 是条件表达式中可用的函数
+var conditionFunctions = map[string]govaluate.ExpressionFunction{
+	"cidrMatch":   cidrMatch,
+	"timeBetween": timeBetween,
+}
+
+// 条件未满足时的决策原因
+const (
+	ReasonConditionNotMet = "condition_not_met"
+	ReasonConditionError  = "condition_error"
+)
+
+// RequestContext 是条件表达式求值时的请求上下文.
+type RequestContext struct {
+	IP     string
+	Method string
+	Path   string
+	Route  string
+	// Time 为请求时间，零值时使用当前时间
+	Time time.Time
+	// Resource 为被访问资源的属性，例如 owner
+	Resource map[string]any
+}
+
+// Condition 是编译后的权限条件表达式.
+type Condition struct {
+	source string
+	expr   *govaluate.EvaluableExpression
+	err    error
+}
+
+// CompileCondition 编译并校验条件表达式.
+func CompileCondition(source string) (*Condition, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return nil, errors.New("condition is empty")
+	}
+	expr, err := govaluate.NewEvaluableExpressionWithFunctions(source, conditionFunctions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition: %w", err)
+	}
+	if err := checkConditionTokens(expr.Tokens()); err != nil {
+		return nil, fmt.Errorf("invalid condition: %w", err)
+	}
+	return &Condition{source: source, expr: expr}, nil
+}
+
+// ValidateCondition 校验条件表达式，空表达式表示无条件.
+func ValidateCondition(source string) error {
+	if strings.TrimSpace(source) == "" {
+		return nil
+	}
+	_, err := CompileCondition(source)
+	return err
+}
+
+// String 返回条件表达式原文.
+func (c *Condition) String() string {
+	return c.source
+}
+
+// Evaluate 使用给定参数对条件求值，结果必须为布尔值.
+func (c *Condition) Evaluate(params map[string]any) (bool, error) {
+	if c.err != nil {
+		return false, c.err
+	}
+	result, err := c.expr.Evaluate(params)
+	if err != nil {
+		return false, err
+	}
+	ok, isBool := result.(bool)
+	if !isBool {
+		return false, fmt.Errorf("condition must evaluate to a boolean, got %T", result)
+	}
+	return ok, nil
+}
+
+// parameters 构建条件表达式的参数
+func (rc *RequestContext) parameters(userID string, tenantID int64, loc *time.Location) map[string]any {
+	if rc == nil {
+		rc = &RequestContext{}
+	}
+	now := rc.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	local := now.In(loc)
+
+	id, _ := strconv.ParseInt(userID, 10, 64)
+	resource := make(map[string]any, len(rc.Resource))
+	for k, v := range rc.Resource {
+		resource[k] = normalizeAttribute(v)
+	}
+
+	return map[string]any{
+		"subject": map[string]any{
+			"id":        float64(id),
+			"tenant_id": float64(tenantID),
+		},
+		"request": map[string]any{
+			"ip":      rc.IP,
+			"method":  rc.Method,
+			"path":    rc.Path,
+			"route":   rc.Route,
+			"time":    float64(now.Unix()),
+			"clock":   local.Format("15:04"),
+			"hour":    float64(local.Hour()),
+			"minute":  float64(local.Minute()),
+			"weekday": float64(local.Weekday()),
+		},
+		"resource": resource,
+	}
+}
+
+// applyCondition 对命中规则的决策应用权限条件：条件不满足时该权限的规则都不生效.
+// 条件求值出错时 allow 规则不生效，deny 规则仍然生效.
+func (a *Authz) applyCondition(decision *Decision, userID string, tenantID, permissionID int64, rc *RequestContext) (*Decision, error) {
+	if len(decision.Rule) == 0 || a.conditions == nil {
+		return decision, nil
+	}
+	table, err := a.conditions.get()
+	if err != nil {
+		return nil, err
+	}
+	cond, ok := table.conditions[permissionID]
+	if !ok {
+		return decision, nil
+	}
+
+	met, err := cond.Evaluate(rc.parameters(userID, tenantID, table.location(tenantID)))
+	if err != nil {
+		if decision.Effect == EffectDeny {
+			decision.Condition = cond.String()
+			return decision, nil
+		}
+		return &Decision{Reason: fmt.Sprintf("%s: %s: %v", ReasonConditionError, cond, err)}, nil
+	}
+	if !met {
+		return &Decision{Reason: fmt.Sprintf("%s: %s", ReasonConditionNotMet, cond)}, nil
+	}
+	decision.Condition = cond.String()
+	return decision, nil
+}
+
+// conditionTable 是权限条件和租户时区的只读快照
+type conditionTable struct {
+	conditions map[int64]*Condition
+	locations  map[int64]*time.Location
+}
+
+// location 返回租户时区，未配置时使用服务器时区
+func (t *conditionTable) location(tenantID int64) *time.Location {
+	if loc, ok := t.locations[tenantID]; ok {
+		return loc
+	}
+	return time.Local
+}
+
+// loadConditionTable 从数据库加载带条件的权限和租户时区
+func loadConditionTable(db *gorm.DB) (*conditionTable, error) {
+	var permissions []struct {
+		ID        int64  `gorm:"column:id"`
+		Condition string `gorm:"column:condition"`
+	}
+	err := db.Table("permissions").
+		Select("id, `condition`").
+		Where("`condition` IS NOT NULL AND `condition` <> '' AND deleted_at IS NULL").
+		Find(&permissions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var tenants []struct {
+		ID       int64  `gorm:"column:id"`
+		Timezone string `gorm:"column:timezone"`
+	}
+	err = db.Table("tenants").
+		Select("id, timezone").
+		Where("timezone <> '' AND deleted_at IS NULL").
+		Find(&tenants).Error
+	if err != nil {
+		return nil, err
+	}
+
+	table := &conditionTable{
+		conditions: make(map[int64]*Condition, len(permissions)),
+		locations:  make(map[int64]*time.Location, len(tenants)),
+	}
+	for _, p := range permissions {
+		cond, err := CompileCondition(p.Condition)
+		if err != nil {
+			// 保存时已校验，这里仍保留无法编译的条件，使其按求值失败处理
+			cond = &Condition{source: p.Condition, err: err}
+		}
+		table.conditions[p.ID] = cond
+	}
+	for _, t := range tenants {
+		if loc, err := time.LoadLocation(t.Timezone); err == nil {
+			table.locations[t.ID] = loc
+		}
+	}
+	return table, nil
+}
+
+// checkConditionTokens 校验表达式中的变量和函数参数
+func checkConditionTokens(tokens []govaluate.ExpressionToken) error {
+	for i, token := range tokens {
+		switch token.Kind {
+		case govaluate.VARIABLE:
+			return fmt.Errorf("unknown variable %q", token.Value)
+		case govaluate.ACCESSOR:
+			path, _ := token.Value.([]string)
+			fields, ok := conditionFields[path[0]]
+			if !ok {
+				return fmt.Errorf("unknown variable %q", strings.Join(path, "."))
+			}
+			if fields != nil && (len(path) != 2 || !fields[path[1]]) {
+				return fmt.Errorf("unknown field %q", strings.Join(path, "."))
+			}
+		case govaluate.FUNCTION:
+			if err := checkFunctionArgs(token.Value, functionArgs(tokens[i+1:])); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// functionArgs 返回函数调用的参数，每个参数为其包含的 token
+func functionArgs(tokens []govaluate.ExpressionToken) [][]govaluate.ExpressionToken {
+	var args [][]govaluate.ExpressionToken
+	var current []govaluate.ExpressionToken
+	depth := 0
+	for _, token := range tokens {
+		switch token.Kind {
+		case govaluate.CLAUSE:
+			depth++
+			if depth == 1 {
+				continue
+			}
+		case govaluate.CLAUSE_CLOSE:
+			depth--
+			if depth == 0 {
+				if len(current) > 0 {
+					args = append(args, current)
+				}
+				return args
+			}
+		case govaluate.SEPARATOR:
+			if depth == 1 {
+				args = append(args, current)
+				current = nil
+				continue
+			}
+		}
+		current = append(current, token)
+	}
+	return args
+}
+
+// checkFunctionArgs 校验函数的参数个数和字符串字面量参数
+func checkFunctionArgs(fn any, args [][]govaluate.ExpressionToken) error {
+	literal := func(arg []govaluate.ExpressionToken) (string, bool) {
+		if len(arg) == 1 && arg[0].Kind == govaluate.STRING {
+			s, ok := arg[0].Value.(string)
+			return s, ok
+		}
+		return "", false
+	}
+
+	switch reflect.ValueOf(fn).Pointer() {
+	case reflect.ValueOf(cidrMatch).Pointer():
+		if len(args) < 2 {
+			return errors.New("cidrMatch requires an IP and at least one CIDR")
+		}
+		for _, arg := range args[1:] {
+			if s, ok := literal(arg); ok {
+				if _, err := parseCIDR(s); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.ValueOf(timeBetween).Pointer():
+		if len(args) != 3 {
+			return errors.New("timeBetween requires a clock, a start time and an end time")
+		}
+		for _, arg := range args[1:] {
+			if s, ok := literal(arg); ok {
+				if _, err := parseClock(s); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// cidrMatch 判断 IP 是否属于任一网段，网段也可以是单个 IP
+func cidrMatch(args ...any) (any, error) {
+	if len(args) < 2 {
+		return nil, errors.New("cidrMatch requires an IP and at least one CIDR")
+	}
+	ipStr, _ := args[0].(string)
+	ip := net.ParseIP(ipStr)
+	for _, arg := range args[1:] {
+		s, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("cidrMatch: CIDR must be a string, got %T", arg)
+		}
+		network, err := parseCIDR(s)
+		if err != nil {
+			return nil, err
+		}
+		if ip != nil && network.Contains(ip) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// timeBetween 判断 "15:04" 格式的时刻是否位于 [start, end) 区间内
+func timeBetween(args ...any) (any, error) {
+	if len(args) != 3 {
+		return nil, errors.New("timeBetween requires a clock, a start time and an end time")
+	}
+	var minutes [3]int
+	for i, arg := range args {
+		s, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("timeBetween: argument %d must be a string, got %T", i+1, arg)
+		}
+		m, err := parseClock(s)
+		if err != nil {
+			return nil, err
+		}
+		minutes[i] = m
+	}
+	now, start, end := minutes[0], minutes[1], minutes[2]
+	if start <= end {
+		return now >= start && now < end, nil
+	}
+	return now >= start || now < end, nil
+}
+
+// parseCIDR 解析网段，单个 IP 视为主机网段
+func parseCIDR(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP %q", s)
+		}
+		bits := 128
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 32
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, network, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q", s)
+	}
+	return network, nil
+}
+
+// parseClock 解析 "15:04" 格式的时刻，返回自零点起的分钟数
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// normalizeAttribute 将整数等类型统一为表达式使用的 float64
+func normalizeAttribute(v any) any {
+	switch x := v.(type) {
+	case int:
+		return float64(x)
+	case int8:
+		return float64(x)
+	case int16:
+		return float64(x)
+	case int32:
+		return float64(x)
+	case int64:
+		return float64(x)
+	case uint:
+		return float64(x)
+	case uint8:
+		return float64(x)
+	case uint16:
+		return float64(x)
+	case uint32:
+		return float64(x)
+	case uint64:
+		return float64(x)
+	case float32:
+		return float64(x)
+	case map[string]any:
+		m := make(map[string]any, len(x))
+		for k, val := range x {
+			m[k] = normalizeAttribute(val)
+		}
+		return m
+	case map[string]string:
+		m := make(map[string]any, len(x))
+		for k, val := range x {
+			m[k] = val
+		}
+		return m
+	}
+	return v
+}
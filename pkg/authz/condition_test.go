@@ -0,0 +1,108 @@
+package authz
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileCondition(t *testing.T) {
+	valid := []string{
+		`cidrMatch(request.ip, "10.0.0.0/8", "192.168.1.10")`,
+		`timeBetween(request.clock, "22:00", "06:00") && request.weekday != 0`,
+		`resource.owner_id == subject.id`,
+		`request.time < "2030-01-01"`,
+	}
+	for _, source := range valid {
+		_, err := CompileCondition(source)
+		assert.NoError(t, err, source)
+	}
+
+	invalid := []string{
+		`user.id == 1`,
+		`request.host == "a"`,
+		`cidrMatch(request.ip, "10.0.0.0/33")`,
+		`timeBetween(request.clock, "9am", "18:00")`,
+		`timeBetween(request.clock, "09:00")`,
+		`resource.owner_id ==`,
+	}
+	for _, source := range invalid {
+		_, err := CompileCondition(source)
+		assert.Error(t, err, source)
+	}
+
+	assert.NoError(t, ValidateCondition(" "))
+}
+
+func TestDecision_Condition(t *testing.T) {
+	a := newTestAuthz(t)
+	shanghai := time.FixedZone("CST", 8*3600)
+	a.conditions = newSnapshot(0, func() (*conditionTable, error) {
+		table := &conditionTable{
+			conditions: map[int64]*Condition{},
+			locations:  map[int64]*time.Location{1: shanghai},
+		}
+		for id, source := range map[int64]string{
+			30: `cidrMatch(request.ip, "10.0.0.0/8") && timeBetween(request.clock, "09:00", "18:00")`,
+			31: `resource.owner_id == subject.id`,
+			32: `request.ip == "10.0.0.1"`,
+		} {
+			cond, err := CompileCondition(source)
+			require.NoError(t, err)
+			table.conditions[id] = cond
+		}
+		return table, nil
+	})
+
+	_, err := a.AddGroupingPolicy("u10", "r2", "t1")
+	require.NoError(t, err)
+	for _, id := range []int64{30, 31} {
+		_, err = a.AddPermissionForRole(2, id, 1, EffectAllow)
+		require.NoError(t, err)
+	}
+	_, err = a.AddPermissionForRole(2, 32, 1, EffectDeny)
+	require.NoError(t, err)
+
+	// 02:00 UTC 为上海时间 10:00
+	morning := time.Date(2025, 6, 2, 2, 0, 0, 0, time.UTC)
+	d, err := a.DecidePermission("10", 1, 30, &RequestContext{IP: "10.1.2.3", Time: morning})
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+	assert.Contains(t, d.String(), " when cidrMatch")
+
+	d, err = a.DecidePermission("10", 1, 30, &RequestContext{IP: "172.16.0.1", Time: morning})
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+	assert.Contains(t, d.Reason, ReasonConditionNotMet)
+
+	d, err = a.DecidePermission("10", 1, 30, &RequestContext{IP: "10.1.2.3", Time: morning.Add(12 * time.Hour)})
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+
+	d, err = a.DecidePermission("10", 1, 31, &RequestContext{Resource: map[string]any{"owner_id": 10}})
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+
+	d, err = a.DecidePermission("10", 1, 31, &RequestContext{Resource: map[string]any{"owner_id": 11}})
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+
+	// 缺少资源属性时求值失败，不授予 allow
+	d, err = a.DecidePermission("10", 1, 31, nil)
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+	assert.Contains(t, d.Reason, ReasonConditionError)
+
+	// deny 规则只在条件满足时生效
+	rc := &RequestContext{IP: "10.0.0.1", Time: morning, Resource: map[string]any{"owner_id": "x"}}
+	d, err = a.DecideAnyPermission("10", 1, []int64{30, 32}, rc)
+	require.NoError(t, err)
+	assert.Equal(t, EffectDeny, d.Effect)
+
+	rc.IP = "10.0.0.2"
+	d, err = a.DecideAnyPermission("10", 1, []int64{30, 32}, rc)
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+}
@@ -0,0 +1,205 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 策略效果，对应 p 规则的 eft 字段
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
+// 没有命中任何策略规则时的决策原因
+const (
+	ReasonSuperAdmin      = "super_admin"
+	ReasonNoMatchedRule   = "no_matched_rule"
+	ReasonNoAPIPermission = "no_api_permission"
+)
+
+// Decision 描述一次授权决策以及决定结果的策略规则.
+type Decision struct {
+	Allowed bool
+	// Effect 为决定结果的规则效果，未命中规则时为空
+	Effect string
+	// Rule 为决定结果的 p 规则（sub, obj, dom, eft）
+	Rule []string
+	// Reason 在未命中规则时说明决策原因
+	Reason string
+	// Condition 为命中规则所属权限的条件表达式
+	Condition string
+}
+
+// String 返回决策依据的可读描述.
+func (d *Decision) String() string {
+	if d == nil {
+		return ""
+	}
+	if len(d.Rule) > 0 {
+		if d.Condition != "" {
+			return "p, " + strings.Join(d.Rule, ", ") + " when " + d.Condition
+		}
+		return "p, " + strings.Join(d.Rule, ", ")
+	}
+	return d.Reason
+}
+
+// PermissionGrant 表示角色在租户下的一条权限规则.
+type PermissionGrant struct {
+	PermissionID int64
+	Effect       string
+}
+
+// IsValidEffect 判断是否为支持的策略效果.
+func IsValidEffect(effect string) bool {
+	return effect == EffectAllow || effect == EffectDeny
+}
+
+// DecidePermission 检查用户在租户下对指定权限的访问，返回决定结果的规则.
+// 权限配置了条件时使用 rc 求值，条件不满足时该权限的规则不生效.
+func (a *Authz) DecidePermission(userID string, tenantID, permissionID int64, rc *RequestContext) (*Decision, error) {
+	decision, err := a.enforceDecision(
+		a.idConverter.ToDUserID(a.parseStringToInt64(userID)),
+		permissionObject(permissionID),
+		a.idConverter.ToDDomainID(tenantID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return a.applyCondition(decision, userID, tenantID, permissionID, rc)
+}
+
+// DecideAnyPermission 按拒绝优先合并多条权限的决策：任一权限命中 deny 规则即拒绝，否则命中任一 allow 规则即允许
+func (a *Authz) DecideAnyPermission(userID string, tenantID int64, permissionIDs []int64, rc *RequestContext) (*Decision, error) {
+	var allowed, rejected *Decision
+	for _, permissionID := range permissionIDs {
+		decision, err := a.DecidePermission(userID, tenantID, permissionID, rc)
+		if err != nil {
+			return nil, err
+		}
+		if decision.Effect == EffectDeny {
+			return decision, nil
+		}
+		if decision.Allowed && allowed == nil {
+			allowed = decision
+		}
+		if !decision.Allowed && decision.Reason != ReasonNoMatchedRule && rejected == nil {
+			rejected = decision
+		}
+	}
+	if allowed != nil {
+		return allowed, nil
+	}
+	if rejected != nil {
+		return rejected, nil
+	}
+	return &Decision{Reason: ReasonNoMatchedRule}, nil
+}
+
+// AddPermissionForRole 为角色在租户下添加 allow 或 deny 规则，同一权限已有的相反规则会被替换
+func (a *Authz) AddPermissionForRole(roleID, permissionID, tenantID int64, effect string) (bool, error) {
+	if !IsValidEffect(effect) {
+		return false, fmt.Errorf("invalid effect: %s", effect)
+	}
+	role := a.idConverter.ToDRoleID(roleID)
+	obj := permissionObject(permissionID)
+	domain := a.idConverter.ToDDomainID(tenantID)
+
+	if has, _ := a.HasPolicy(role, obj, domain, effect); has {
+		return false, nil
+	}
+	if _, err := a.RemoveFilteredPolicy(0, role, obj, domain); err != nil {
+		return false, err
+	}
+	ok, err := a.AddPolicy(role, obj, domain, effect)
+	if err != nil {
+		return false, err
+	}
+	return ok, a.InvalidateCache()
+}
+
+// DeletePermissionForRole 删除角色在租户下对指定权限的规则
+func (a *Authz) DeletePermissionForRole(roleID, permissionID, tenantID int64) (bool, error) {
+	ok, err := a.RemoveFilteredPolicy(0,
+		a.idConverter.ToDRoleID(roleID),
+		permissionObject(permissionID),
+		a.idConverter.ToDDomainID(tenantID),
+	)
+	if err != nil {
+		return false, err
+	}
+	return ok, a.InvalidateCache()
+}
+
+// DeletePermissionPolicies 删除租户下全部角色对指定权限的规则，用于删除权限时清理策略
+func (a *Authz) DeletePermissionPolicies(permissionID, tenantID int64) (bool, error) {
+	ok, err := a.RemoveFilteredPolicy(1, permissionObject(permissionID), a.idConverter.ToDDomainID(tenantID))
+	if err != nil {
+		return false, err
+	}
+	return ok, a.InvalidateCache()
+}
+
+// GetPermissionsForRole 获取角色在租户下的权限规则
+func (a *Authz) GetPermissionsForRole(roleID, tenantID int64) ([]PermissionGrant, error) {
+	policies, err := a.GetFilteredPolicy(0, a.idConverter.ToDRoleID(roleID), "", a.idConverter.ToDDomainID(tenantID))
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make([]PermissionGrant, 0, len(policies))
+	for _, policy := range policies {
+		permissionID, ok := ParsePermissionObject(policy[1])
+		if !ok {
+			continue
+		}
+		grants = append(grants, PermissionGrant{PermissionID: permissionID, Effect: policyEffect(policy)})
+	}
+	return grants, nil
+}
+
+// enforceDecision 执行授权检查并返回决定结果的规则
+func (a *Authz) enforceDecision(sub, obj, dom string) (*Decision, error) {
+	allowed, explain, err := a.EnforceEx(sub, obj, dom)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &Decision{Allowed: allowed, Rule: explain}
+	if len(explain) > 0 {
+		decision.Effect = policyEffect(explain)
+	} else {
+		decision.Reason = ReasonNoMatchedRule
+	}
+	return decision, nil
+}
+
+// permissionObject 返回权限在 Casbin 中的对象标识，与 casbin_rule 种子数据保持一致
+func permissionObject(permissionID int64) string {
+	return "p" + strconv.FormatInt(permissionID, 10)
+}
+
+// ParsePermissionObject 解析 Casbin 中的权限对象标识，兼容 p{id} 和 a{id} 两种格式
+func ParsePermissionObject(obj string) (int64, bool) {
+	if len(obj) < 2 || (obj[0] != 'p' && obj[0] != PrefixResourceID[0]) {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(obj[1:], 10, 64)
+	return id, err == nil
+}
+
+// policyEffect 返回 p 规则的效果，旧数据没有 eft 字段时视为 allow
+func policyEffect(policy []string) string {
+	if len(policy) > 3 && policy[3] != "" {
+		return policy[3]
+	}
+	return EffectAllow
+}
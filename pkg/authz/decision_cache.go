@@ -0,0 +1,164 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"time"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+)
+
+const (
+	// DefaultDecisionCacheSize 是授权决策缓存的默认容量
+	DefaultDecisionCacheSize = 10000
+	// DefaultDecisionCacheTTL 是授权决策缓存的默认有效期，与策略自动加载间隔一致
+	DefaultDecisionCacheTTL = 10 * time.Second
+)
+
+// decisionKey 是授权决策缓存的键
+type decisionKey struct {
+	subject string
+	domain  string
+	object  string
+	action  string
+}
+
+// cacheWatcher 在本实例修改策略后清空授权缓存，配置了 Watcher 时再转发给它广播到其他实例.
+// Casbin 只在设置了 Watcher 时通知策略变更，因此未配置 Watcher 时也会安装 cacheWatcher.
+type cacheWatcher struct {
+	next  persist.WatcherEx // 配置的 Watcher，未配置时为 nil
+	purge func()
+}
+
+// 确保 cacheWatcher 实现了 Casbin 的增量 Watcher 接口.
+var (
+	_ persist.WatcherEx        = (*cacheWatcher)(nil)
+	_ persist.UpdatableWatcher = (*cacheWatcher)(nil)
+)
+
+// SetUpdateCallback 设置收到其他实例的策略变更时的回调函数.
+func (w *cacheWatcher) SetUpdateCallback(callback func(string)) error {
+	if w.next == nil {
+		return nil
+	}
+	return w.next.SetUpdateCallback(callback)
+}
+
+// Update 通知其他实例从数据库全量重新加载策略.
+func (w *cacheWatcher) Update() error {
+	return w.forward(func(next persist.WatcherEx) error { return next.Update() })
+}
+
+// Close 停止 Watcher.
+func (w *cacheWatcher) Close() {
+	if w.next != nil {
+		w.next.Close()
+	}
+}
+
+// UpdateForAddPolicy 转发添加一条规则.
+func (w *cacheWatcher) UpdateForAddPolicy(sec, ptype string, params ...string) error {
+	return w.forward(func(next persist.WatcherEx) error { return next.UpdateForAddPolicy(sec, ptype, params...) })
+}
+
+// UpdateForRemovePolicy 转发删除一条规则.
+func (w *cacheWatcher) UpdateForRemovePolicy(sec, ptype string, params ...string) error {
+	return w.forward(func(next persist.WatcherEx) error { return next.UpdateForRemovePolicy(sec, ptype, params...) })
+}
+
+// UpdateForRemoveFilteredPolicy 转发按字段删除规则.
+func (w *cacheWatcher) UpdateForRemoveFilteredPolicy(sec, ptype string, fieldIndex int, fieldValues ...string) error {
+	return w.forward(func(next persist.WatcherEx) error {
+		return next.UpdateForRemoveFilteredPolicy(sec, ptype, fieldIndex, fieldValues...)
+	})
+}
+
+// UpdateForSavePolicy 转发保存全部策略.
+func (w *cacheWatcher) UpdateForSavePolicy(m model.Model) error {
+	return w.forward(func(next persist.WatcherEx) error { return next.UpdateForSavePolicy(m) })
+}
+
+// UpdateForAddPolicies 转发批量添加规则.
+func (w *cacheWatcher) UpdateForAddPolicies(sec, ptype string, rules ...[]string) error {
+	return w.forward(func(next persist.WatcherEx) error { return next.UpdateForAddPolicies(sec, ptype, rules...) })
+}
+
+// UpdateForRemovePolicies 转发批量删除规则.
+func (w *cacheWatcher) UpdateForRemovePolicies(sec, ptype string, rules ...[]string) error {
+	return w.forward(func(next persist.WatcherEx) error { return next.UpdateForRemovePolicies(sec, ptype, rules...) })
+}
+
+// UpdateForUpdatePolicy 转发修改一条规则，配置的 Watcher 不支持修改时通知全量重新加载.
+func (w *cacheWatcher) UpdateForUpdatePolicy(sec, ptype string, oldRule, newRule []string) error {
+	return w.forward(func(next persist.WatcherEx) error {
+		if updatable, ok := next.(persist.UpdatableWatcher); ok {
+			return updatable.UpdateForUpdatePolicy(sec, ptype, oldRule, newRule)
+		}
+		return next.Update()
+	})
+}
+
+// UpdateForUpdatePolicies 转发批量修改规则，配置的 Watcher 不支持修改时通知全量重新加载.
+func (w *cacheWatcher) UpdateForUpdatePolicies(sec, ptype string, oldRules, newRules [][]string) error {
+	return w.forward(func(next persist.WatcherEx) error {
+		if updatable, ok := next.(persist.UpdatableWatcher); ok {
+			return updatable.UpdateForUpdatePolicies(sec, ptype, oldRules, newRules)
+		}
+		return next.Update()
+	})
+}
+
+// forward 清空授权缓存后转发给配置的 Watcher
+func (w *cacheWatcher) forward(fn func(next persist.WatcherEx) error) error {
+	w.purge()
+	if w.next == nil {
+		return nil
+	}
+	return fn(w.next)
+}
+
+// InvalidateCache 清空 Casbin 的授权缓存和授权决策缓存
+func (a *Authz) InvalidateCache() error {
+	a.decisionCache.purge()
+	return a.SyncedCachedEnforcer.InvalidateCache()
+}
+
+// cachedDecision 从授权决策缓存中查询，未命中时调用 decide 并在结果可缓存时写入.
+// 缓存的决策被多个请求共享，调用方不能修改.
+func (a *Authz) cachedDecision(key decisionKey, decide func() (*Decision, bool, error)) (*Decision, error) {
+	decision, gen, ok := a.decisionCache.get(key)
+	if ok {
+		return decision, nil
+	}
+
+	decision, cacheable, err := decide()
+	if err != nil {
+		return nil, err
+	}
+	if cacheable {
+		a.decisionCache.add(key, decision, gen)
+	}
+	return decision, nil
+}
+
+// hasConditions 判断权限中是否有配置了条件的权限，这类决策依赖请求上下文，不能缓存
+func (a *Authz) hasConditions(permissionIDs []int64) bool {
+	if a.conditions == nil {
+		return false
+	}
+	table, err := a.conditions.get()
+	if err != nil {
+		return true
+	}
+	for _, id := range permissionIDs {
+		if _, ok := table.conditions[id]; ok {
+			return true
+		}
+	}
+	return false
+}
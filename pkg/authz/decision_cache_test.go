@@ -0,0 +1,91 @@
+package authz
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := newLRUCache[string, int]("test", 2, time.Minute)
+	_, gen, ok := c.get("a")
+	require.False(t, ok)
+	c.add("a", 1, gen)
+	c.add("b", 2, gen)
+	_, _, ok = c.get("a")
+	require.True(t, ok)
+
+	// 超出容量时淘汰最久未使用的条目
+	c.add("c", 3, gen)
+	_, _, ok = c.get("b")
+	assert.False(t, ok)
+	assert.Equal(t, 2, c.len())
+
+	// 清空前开始计算的结果不会写入
+	_, gen, _ = c.get("d")
+	c.purge()
+	c.add("d", 4, gen)
+	_, _, ok = c.get("d")
+	assert.False(t, ok)
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.hit))
+	assert.Equal(t, float64(4), testutil.ToFloat64(c.miss))
+
+	// 过期的条目不再命中
+	c = newLRUCache[string, int]("test_ttl", 2, time.Nanosecond)
+	_, gen, _ = c.get("a")
+	c.add("a", 1, gen)
+	time.Sleep(time.Millisecond)
+	_, _, ok = c.get("a")
+	assert.False(t, ok)
+}
+
+func TestDecisionCache_PurgedOnPolicyChange(t *testing.T) {
+	a := newTestAuthz(t)
+	a.decisionCache = newLRUCache[decisionKey, *Decision]("test_decision", 10, time.Minute)
+	require.NoError(t, a.setWatcher(nil))
+
+	calls := 0
+	decide := func() (*Decision, bool, error) {
+		calls++
+		allowed, err := a.Enforce("u10", "p30", "t1")
+		return &Decision{Allowed: allowed}, true, err
+	}
+	key := decisionKey{subject: "10", domain: "t1", object: "p30"}
+
+	d, err := a.cachedDecision(key, decide)
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+	_, _ = a.cachedDecision(key, decide)
+	assert.Equal(t, 1, calls)
+
+	// 通过 Casbin 直接修改策略也会清空缓存
+	_, err = a.AddGroupingPolicy("u10", "r2", "t1")
+	require.NoError(t, err)
+	_, err = a.AddPolicy("r2", "p30", "t1", EffectAllow)
+	require.NoError(t, err)
+	d, err = a.cachedDecision(key, decide)
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+	assert.Equal(t, 2, calls)
+
+	_, err = a.RemoveFilteredPolicy(0, "r2")
+	require.NoError(t, err)
+	d, err = a.cachedDecision(key, decide)
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+
+	// 不可缓存的决策每次都重新计算
+	_, err = a.cachedDecision(decisionKey{subject: "11"}, func() (*Decision, bool, error) {
+		calls++
+		return &Decision{}, false, nil
+	})
+	require.NoError(t, err)
+	_, _ = a.cachedDecision(decisionKey{subject: "11"}, func() (*Decision, bool, error) {
+		calls++
+		return &Decision{}, false, nil
+	})
+	assert.Equal(t, 5, calls)
+}
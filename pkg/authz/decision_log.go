@@ -0,0 +1,111 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// decisionLogSize 是内存中保留的授权决策记录数，超出后覆盖最早的记录
+const decisionLogSize = 4096
+
+// DecisionRecord 是一次授权决策的记录，用于通过决策ID查询决策轨迹.
+type DecisionRecord struct {
+	ID       string
+	UserID   string
+	TenantID int64
+	Request  RequestContext
+	Decision *Decision
+	// Error 为授权检查出错时的错误信息
+	Error string
+	Time  time.Time
+}
+
+// decisionLog 是固定容量的授权决策记录环形缓冲区.
+type decisionLog struct {
+	mu      sync.Mutex
+	records []*DecisionRecord
+	index   map[string]*DecisionRecord
+	next    int
+}
+
+// newDecisionLog 创建容量为 size 的决策记录缓冲区
+func newDecisionLog(size int) *decisionLog {
+	return &decisionLog{
+		records: make([]*DecisionRecord, size),
+		index:   make(map[string]*DecisionRecord, size),
+	}
+}
+
+// add 写入一条记录，缓冲区已满时覆盖最早的记录
+func (l *decisionLog) add(record *DecisionRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if old := l.records[l.next]; old != nil {
+		delete(l.index, old.ID)
+	}
+	l.records[l.next] = record
+	l.index[record.ID] = record
+	l.next = (l.next + 1) % len(l.records)
+}
+
+// get 按决策ID查询记录
+func (l *decisionLog) get(id string) (*DecisionRecord, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	record, ok := l.index[id]
+	return record, ok
+}
+
+// RecordDecision 记录一次授权决策并返回决策ID，授权中间件在拒绝响应中返回该ID，
+// 之后可以通过 LookupDecision 取回请求信息并重新生成决策轨迹.
+func (a *Authz) RecordDecision(subject, domain string, rc *RequestContext, decision *Decision, err error) string {
+	if a.decisions == nil {
+		return ""
+	}
+
+	record := &DecisionRecord{
+		ID:       newDecisionID(),
+		UserID:   subject,
+		TenantID: 1, // 默认租户
+		Decision: decision,
+		Time:     time.Now(),
+	}
+	if a.tenantResolver != nil {
+		if tenantID, err := a.tenantResolver.GetTenantID(apiTenantIdentifier(domain)); err == nil {
+			record.TenantID = tenantID
+		}
+	}
+	if rc != nil {
+		record.Request = *rc
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	a.decisions.add(record)
+	return record.ID
+}
+
+// LookupDecision 按决策ID查询授权决策记录，记录只保存在当前实例的内存中
+func (a *Authz) LookupDecision(id string) (*DecisionRecord, bool) {
+	if a.decisions == nil {
+		return nil, false
+	}
+	return a.decisions.get(id)
+}
+
+// newDecisionID 生成 12 位十六进制的决策ID
+func newDecisionID() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,67 @@
+package authz
+
+import (
+	"testing"
+
+	casbin "github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAuthz(t *testing.T) *Authz {
+	m, err := model.NewModelFromString(defaultRBACWithDomainsModel)
+	require.NoError(t, err)
+	enforcer, err := casbin.NewSyncedCachedEnforcer(m)
+	require.NoError(t, err)
+	return &Authz{SyncedCachedEnforcer: enforcer, idConverter: NewIDConverter()}
+}
+
+func TestDecision_DenyOverridesAllow(t *testing.T) {
+	a := newTestAuthz(t)
+	_, err := a.AddGroupingPolicy("u10", "r2", "t1")
+	require.NoError(t, err)
+	_, err = a.AddGroupingPolicy("u10", "r3", "t1")
+	require.NoError(t, err)
+
+	_, err = a.AddPermissionForRole(2, 30, 1, EffectAllow)
+	require.NoError(t, err)
+	_, err = a.AddPermissionForRole(2, 31, 1, EffectAllow)
+	require.NoError(t, err)
+	_, err = a.AddPermissionForRole(3, 31, 1, EffectDeny)
+	require.NoError(t, err)
+
+	d, err := a.DecidePermission("10", 1, 30, nil)
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+	assert.Equal(t, "p, r2, p30, t1, allow", d.String())
+
+	d, err = a.DecidePermission("10", 1, 31, nil)
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, []string{"r3", "p31", "t1", "deny"}, d.Rule)
+
+	d, err = a.DecideAnyPermission("10", 1, []int64{30, 31}, nil)
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, EffectDeny, d.Effect)
+
+	d, err = a.DecidePermission("10", 2, 30, nil)
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, ReasonNoMatchedRule, d.String())
+
+	// 相反的规则会被替换
+	_, err = a.AddPermissionForRole(3, 31, 1, EffectAllow)
+	require.NoError(t, err)
+	grants, err := a.GetPermissionsForRole(3, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []PermissionGrant{{PermissionID: 31, Effect: EffectAllow}}, grants)
+
+	d, err = a.DecideAnyPermission("10", 1, []int64{30, 31}, nil)
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+
+	_, err = a.AddPermissionForRole(3, 31, 1, "block")
+	assert.Error(t, err)
+}
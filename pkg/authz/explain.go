@@ -0,0 +1,194 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"fmt"
+)
+
+// 权限条件在决策轨迹中的求值结果
+const (
+	ConditionMet    = "met"
+	ConditionNotMet = "not_met"
+	ConditionError  = "error"
+)
+
+// Trace 是一次授权决策的完整轨迹.
+type Trace struct {
+	UserID   string
+	TenantID int64
+	// Method、Path、Route 为 API 访问检查的请求信息
+	Method string
+	Path   string
+	Route  string
+	// PermissionCode 为按权限编码检查时的权限编码
+	PermissionCode string
+	// Roles 为用户直接拥有和继承的角色
+	Roles []TraceRole
+	// SuperAdmin 表示用户是超级管理员，此时跳过策略检查
+	SuperAdmin bool
+	// PermissionIDs 为与请求匹配的权限
+	PermissionIDs []int64
+	// Policies 为参与求值的 p 规则
+	Policies []TracePolicy
+	// Decision 为最终决策，与授权中间件的结果一致
+	Decision *Decision
+}
+
+// TraceRole 表示用户拥有的一个角色.
+type TraceRole struct {
+	RoleID int64
+	// Via 为从用户直接拥有的角色到 RoleID 的继承链，长度为 1 时为直接角色
+	Via []int64
+}
+
+// TracePolicy 表示一条参与求值的 p 规则.
+type TracePolicy struct {
+	PermissionID int64
+	// Rule 为 p 规则（sub, obj, dom, eft）
+	Rule   []string
+	Effect string
+	// Condition 为权限的条件表达式，ConditionResult 为其求值结果
+	Condition       string
+	ConditionResult string
+	// Applied 表示该规则是否参与最终的 deny 优先合并
+	Applied bool
+}
+
+// ExplainAPIAccess 返回 API 访问决策的轨迹，最终决策与 CheckRouteAccess 相同
+func (a *Authz) ExplainAPIAccess(userID string, tenantID int64, rc *RequestContext) (*Trace, error) {
+	trace, err := a.newTrace(userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if rc != nil {
+		trace.Method, trace.Path, trace.Route = rc.Method, rc.Path, rc.Route
+	}
+
+	if !trace.SuperAdmin && rc != nil && a.routeIndex != nil {
+		if trace.PermissionIDs, err = a.routeIndex.Match(tenantID, rc.Method, rc.Route, rc.Path); err != nil {
+			return nil, err
+		}
+		if err := a.tracePolicies(trace, rc); err != nil {
+			return nil, err
+		}
+	}
+
+	if trace.Decision, err = a.decideAPIAccess(userID, a.idConverter.ToDDomainID(tenantID), rc); err != nil {
+		return nil, err
+	}
+	return trace, nil
+}
+
+// ExplainPermission 返回按权限编码检查的决策轨迹，最终决策与 CheckPermission 相同
+func (a *Authz) ExplainPermission(userID string, tenantID int64, permissionCode string, rc *RequestContext) (*Trace, error) {
+	trace, err := a.newTrace(userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	trace.PermissionCode = permissionCode
+
+	tenant := a.idConverter.ToDDomainID(tenantID)
+	if !trace.SuperAdmin {
+		if permissionID, err := a.tenantResolver.GetPermissionID(permissionCode, tenant); err == nil {
+			trace.PermissionIDs = []int64{permissionID}
+			if err := a.tracePolicies(trace, rc); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if trace.Decision, err = a.CheckPermission(userID, tenant, permissionCode, rc); err != nil {
+		return nil, err
+	}
+	return trace, nil
+}
+
+// newTrace 创建决策轨迹并填充用户的角色
+func (a *Authz) newTrace(userID string, tenantID int64) (*Trace, error) {
+	trace := &Trace{UserID: userID, TenantID: tenantID}
+
+	nodes, err := a.userRoleNodes(a.idConverter.ToDUserID(a.parseStringToInt64(userID)), a.idConverter.ToDDomainID(tenantID))
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		trace.Roles = append(trace.Roles, TraceRole{RoleID: n.via[len(n.via)-1], Via: n.via})
+	}
+
+	if trace.SuperAdmin, err = a.isSuperAdmin(userID, a.idConverter.ToDDomainID(tenantID)); err != nil {
+		return nil, err
+	}
+	return trace, nil
+}
+
+// tracePolicies 收集用户及其角色在匹配权限上的 p 规则，并对权限条件求值
+func (a *Authz) tracePolicies(trace *Trace, rc *RequestContext) error {
+	domain := a.idConverter.ToDDomainID(trace.TenantID)
+	subjects := map[string]bool{a.idConverter.ToDUserID(a.parseStringToInt64(trace.UserID)): true}
+	for _, role := range trace.Roles {
+		subjects[a.idConverter.ToDRoleID(role.RoleID)] = true
+	}
+
+	for _, permissionID := range trace.PermissionIDs {
+		policies, err := a.GetFilteredPolicy(1, permissionObject(permissionID), domain)
+		if err != nil {
+			return err
+		}
+
+		var cond, result string
+		var evaluated bool
+		for _, policy := range policies {
+			if !subjects[policy[0]] {
+				continue
+			}
+			if !evaluated {
+				if cond, result, err = a.conditionResult(trace.UserID, trace.TenantID, permissionID, rc); err != nil {
+					return err
+				}
+				evaluated = true
+			}
+
+			effect := policyEffect(policy)
+			trace.Policies = append(trace.Policies, TracePolicy{
+				PermissionID:    permissionID,
+				Rule:            policy,
+				Effect:          effect,
+				Condition:       cond,
+				ConditionResult: result,
+				// 条件求值出错时 allow 规则不生效，deny 规则仍然生效
+				Applied: result == "" || result == ConditionMet || (result != ConditionNotMet && effect == EffectDeny),
+			})
+		}
+	}
+	return nil
+}
+
+// conditionResult 对权限条件求值，权限没有条件时返回空字符串
+func (a *Authz) conditionResult(userID string, tenantID, permissionID int64, rc *RequestContext) (string, string, error) {
+	if a.conditions == nil {
+		return "", "", nil
+	}
+	table, err := a.conditions.get()
+	if err != nil {
+		return "", "", err
+	}
+	cond, ok := table.conditions[permissionID]
+	if !ok {
+		return "", "", nil
+	}
+
+	met, err := cond.Evaluate(rc.parameters(userID, tenantID, table.location(tenantID)))
+	switch {
+	case err != nil:
+		return cond.String(), fmt.Sprintf("%s: %v", ConditionError, err), nil
+	case met:
+		return cond.String(), ConditionMet, nil
+	default:
+		return cond.String(), ConditionNotMet, nil
+	}
+}
@@ -0,0 +1,137 @@
+package authz
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTenantResolver 按 t{id}、p{id} 格式解析标识符，不访问数据库
+type stubTenantResolver struct{}
+
+func (stubTenantResolver) GetTenantID(tenantIdentifier string) (int64, error) {
+	return strconv.ParseInt(strings.TrimPrefix(tenantIdentifier, "t"), 10, 64)
+}
+
+func (stubTenantResolver) GetTenantIdentifier(tenantID int64) (string, error) {
+	return fmt.Sprintf("t%d", tenantID), nil
+}
+
+func (stubTenantResolver) GetRoleID(roleIdentifier, tenantIdentifier string) (int64, error) {
+	return strconv.ParseInt(strings.TrimPrefix(roleIdentifier, "r"), 10, 64)
+}
+
+func (stubTenantResolver) GetRoleIdentifier(roleID int64) (string, error) {
+	if roleID == 1 {
+		return "super_admin", nil
+	}
+	return fmt.Sprintf("role%d", roleID), nil
+}
+
+func (stubTenantResolver) GetPermissionID(permissionIdentifier, tenantIdentifier string) (int64, error) {
+	return strconv.ParseInt(strings.TrimPrefix(permissionIdentifier, "p"), 10, 64)
+}
+
+func (stubTenantResolver) GetPermissionIdentifier(permissionID int64) (string, error) {
+	return fmt.Sprintf("p%d", permissionID), nil
+}
+
+func newExplainTestAuthz(t *testing.T) *Authz {
+	a := newTestAuthz(t)
+	a.tenantResolver = stubTenantResolver{}
+	a.routeIndex = &RouteIndex{cache: newSnapshot(0, func() (*routeTable, error) {
+		table := &routeTable{exact: make(map[string][]int64), patterns: make(map[string][]*routeRule)}
+		for id, pattern := range map[int64]string{30: "/v1/posts/:postID", 31: "/v1/posts/*"} {
+			rule, err := compileRouteRule(id, pattern)
+			if err != nil {
+				return nil, err
+			}
+			table.add(1, "GET", rule)
+		}
+		return table, nil
+	})}
+	a.systemRoles = newSnapshot(0, func() (*systemRoleTable, error) {
+		return &systemRoleTable{owners: map[int64]int64{1: 1}}, nil
+	})
+	a.decisions = newDecisionLog(2)
+	return a
+}
+
+func TestExplain_APIAccess(t *testing.T) {
+	a := newExplainTestAuthz(t)
+	_, err := a.AddGroupingPolicy("u10", "r2", "t1")
+	require.NoError(t, err)
+	_, err = a.AddGroupingPolicy("r2", "r3", "t1")
+	require.NoError(t, err)
+	_, err = a.AddPermissionForRole(3, 30, 1, EffectAllow)
+	require.NoError(t, err)
+	_, err = a.AddPermissionForRole(2, 31, 1, EffectDeny)
+	require.NoError(t, err)
+
+	trace, err := a.ExplainAPIAccess("10", 1, &RequestContext{Method: "GET", Route: "/v1/posts/:postID", Path: "/v1/posts/7"})
+	require.NoError(t, err)
+	assert.False(t, trace.SuperAdmin)
+	assert.Equal(t, []TraceRole{{RoleID: 2, Via: []int64{2}}, {RoleID: 3, Via: []int64{2, 3}}}, trace.Roles)
+	assert.ElementsMatch(t, []int64{30, 31}, trace.PermissionIDs)
+	require.Len(t, trace.Policies, 2)
+	for _, p := range trace.Policies {
+		assert.True(t, p.Applied)
+	}
+	assert.False(t, trace.Decision.Allowed)
+	assert.Equal(t, EffectDeny, trace.Decision.Effect)
+
+	// 租户所有者跳过策略检查
+	_, err = a.AddGroupingPolicy("u11", "r1", "t1")
+	require.NoError(t, err)
+	trace, err = a.ExplainAPIAccess("11", 1, &RequestContext{Method: "GET", Route: "/v1/posts/:postID", Path: "/v1/posts/7"})
+	require.NoError(t, err)
+	assert.True(t, trace.SuperAdmin)
+	assert.Empty(t, trace.Policies)
+	assert.Equal(t, ReasonSuperAdmin, trace.Decision.Reason)
+
+	// 其他租户的所有者角色不生效
+	_, err = a.AddGroupingPolicy("u12", "r1", "t2")
+	require.NoError(t, err)
+	trace, err = a.ExplainAPIAccess("12", 2, &RequestContext{Method: "GET", Route: "/v1/posts/:postID", Path: "/v1/posts/7"})
+	require.NoError(t, err)
+	assert.False(t, trace.SuperAdmin)
+}
+
+func TestExplain_Permission(t *testing.T) {
+	a := newExplainTestAuthz(t)
+	_, err := a.AddGroupingPolicy("u10", "r2", "t1")
+	require.NoError(t, err)
+	_, err = a.AddPermissionForRole(2, 30, 1, EffectAllow)
+	require.NoError(t, err)
+
+	trace, err := a.ExplainPermission("10", 1, "p30", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{30}, trace.PermissionIDs)
+	require.Len(t, trace.Policies, 1)
+	assert.Equal(t, []string{"r2", "p30", "t1", "allow"}, trace.Policies[0].Rule)
+	assert.True(t, trace.Decision.Allowed)
+}
+
+func TestDecisionLog_RecordAndLookup(t *testing.T) {
+	a := newExplainTestAuthz(t)
+	rc := &RequestContext{Method: "GET", Path: "/v1/posts/7"}
+	decision := &Decision{Reason: ReasonNoAPIPermission}
+
+	first := a.RecordDecision("10", "2", rc, decision, nil)
+	assert.Len(t, first, 12)
+	record, ok := a.LookupDecision(first)
+	require.True(t, ok)
+	assert.Equal(t, "10", record.UserID)
+	assert.Equal(t, int64(2), record.TenantID)
+	assert.Equal(t, "/v1/posts/7", record.Request.Path)
+
+	// 超出容量后最早的记录被覆盖
+	a.RecordDecision("10", "2", rc, decision, nil)
+	a.RecordDecision("10", "2", rc, decision, nil)
+	_, ok = a.LookupDecision(first)
+	assert.False(t, ok)
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// pdpAction 是策略决策点查询在授权决策缓存中的操作
+const pdpAction = "CHECK"
+
+// permissionCatalog 是各租户已启用权限编码的只读快照
+type permissionCatalog struct {
+	codes map[int64]map[string]int64 // 租户ID -> 权限编码 -> 权限ID
+}
+
+// loadPermissionCatalog 从数据库加载各租户已启用的权限编码
+func loadPermissionCatalog(db *gorm.DB) (*permissionCatalog, error) {
+	var permissions []struct {
+		ID             int64  `gorm:"column:id"`
+		TenantID       int64  `gorm:"column:tenant_id"`
+		PermissionCode string `gorm:"column:permission_code"`
+	}
+	err := db.Table("permissions").
+		Select("id, tenant_id, permission_code").
+		Where("status = 1 AND deleted_at IS NULL").
+		Find(&permissions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &permissionCatalog{codes: make(map[int64]map[string]int64)}
+	for _, p := range permissions {
+		if catalog.codes[p.TenantID] == nil {
+			catalog.codes[p.TenantID] = make(map[string]int64)
+		}
+		catalog.codes[p.TenantID][p.PermissionCode] = p.ID
+	}
+	return catalog, nil
+}
+
+// PermissionCode 将资源和操作拼接为权限编码，例如 user 和 view 拼接为 user:view
+func PermissionCode(resource, action string) string {
+	return resource + ":" + action
+}
+
+// DecideAction 检查用户在租户下能否对资源执行操作，即是否拥有权限编码 resource:action.
+// 同时返回调用方可以缓存该决策的时长，决策依赖请求上下文时为 0.
+func (a *Authz) DecideAction(userID, tenantID int64, resource, action string, rc *RequestContext) (*Decision, time.Duration, error) {
+	subject := strconv.FormatInt(userID, 10)
+	tenantIdentifier := a.idConverter.ToDDomainID(tenantID)
+	code := PermissionCode(resource, action)
+
+	cacheable := true
+	key := decisionKey{subject: subject, domain: tenantIdentifier, object: code, action: pdpAction}
+	decision, err := a.cachedDecision(key, func() (*Decision, bool, error) {
+		var d *Decision
+		var err error
+		d, cacheable, err = a.decideAction(subject, tenantID, code, rc)
+		return d, cacheable, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if !cacheable {
+		return decision, 0, nil
+	}
+	return decision, a.decisionTTL, nil
+}
+
+// decideAction 检查权限编码的访问权限，同时返回决策是否可以缓存
+func (a *Authz) decideAction(userID string, tenantID int64, code string, rc *RequestContext) (*Decision, bool, error) {
+	// 租户所有者拥有租户内的全部权限
+	if isSuperAdmin, _ := a.isSuperAdmin(userID, a.idConverter.ToDDomainID(tenantID)); isSuperAdmin {
+		return &Decision{Allowed: true, Effect: EffectAllow, Reason: ReasonSuperAdmin}, true, nil
+	}
+
+	codes, err := a.tenantPermissionCodes(tenantID)
+	if err != nil {
+		return nil, false, err
+	}
+	permissionID, ok := codes[code]
+	if !ok {
+		// 租户下没有该权限，普通用户没有访问权限
+		return &Decision{Reason: ReasonNoMatchedRule}, true, nil
+	}
+
+	decision, err := a.DecidePermission(userID, tenantID, permissionID, rc)
+	if err != nil {
+		return nil, false, err
+	}
+	return decision, !a.hasConditions([]int64{permissionID}), nil
+}
+
+// ListAllowedActions 返回用户在租户下可以对资源执行的操作，按名称排序.
+// 同时返回调用方可以缓存结果的时长，任一决策依赖请求上下文时为 0.
+func (a *Authz) ListAllowedActions(userID, tenantID int64, resource string, rc *RequestContext) ([]string, time.Duration, error) {
+	resources, ttl, err := a.listAllowed(userID, tenantID, func(r, _ string) bool { return r == resource }, rc)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resources[resource], ttl, nil
+}
+
+// ListAccessibleResources 返回用户在租户下可以访问的资源及允许的操作.
+// action 不为空时只返回允许该操作的资源.
+func (a *Authz) ListAccessibleResources(userID, tenantID int64, action string, rc *RequestContext) (map[string][]string, time.Duration, error) {
+	return a.listAllowed(userID, tenantID, func(_, act string) bool { return action == "" || act == action }, rc)
+}
+
+// listAllowed 逐个检查租户下符合 match 的权限编码，返回允许的资源及操作
+func (a *Authz) listAllowed(userID, tenantID int64, match func(resource, action string) bool, rc *RequestContext) (map[string][]string, time.Duration, error) {
+	codes, err := a.tenantPermissionCodes(tenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ttl := a.decisionTTL
+	allowed := make(map[string][]string)
+	for code := range codes {
+		resource, action, ok := strings.Cut(code, ":")
+		if !ok || !match(resource, action) {
+			continue
+		}
+		decision, codeTTL, err := a.DecideAction(userID, tenantID, resource, action, rc)
+		if err != nil {
+			return nil, 0, err
+		}
+		ttl = min(ttl, codeTTL)
+		if decision.Allowed {
+			allowed[resource] = append(allowed[resource], action)
+		}
+	}
+	for _, actions := range allowed {
+		sort.Strings(actions)
+	}
+	return allowed, ttl, nil
+}
+
+// tenantPermissionCodes 返回租户下已启用的权限编码
+func (a *Authz) tenantPermissionCodes(tenantID int64) (map[string]int64, error) {
+	if a.permissions == nil {
+		return nil, nil
+	}
+	catalog, err := a.permissions.get()
+	if err != nil {
+		return nil, err
+	}
+	return catalog.codes[tenantID], nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/casbin/casbin/v2/persist"
+)
+
+// setWatcher 设置 Watcher，其他实例的策略变更通过 applyPolicyUpdate 应用到本实例.
+// 本实例的策略变更经过 cacheWatcher 清空授权缓存，watcher 为 nil 时只安装 cacheWatcher.
+// Casbin 修改 g 规则时不会清空 Enforce 的缓存，因此同时清空 Casbin 的缓存.
+func (a *Authz) setWatcher(watcher persist.WatcherEx) error {
+	purge := func() { _ = a.InvalidateCache() }
+	if err := a.SetWatcher(&cacheWatcher{next: watcher, purge: purge}); err != nil {
+		return err
+	}
+	if watcher == nil {
+		return nil
+	}
+	// SetWatcher 不会为 WatcherEx 设置回调
+	if err := watcher.SetUpdateCallback(a.applyPolicyUpdate); err != nil {
+		return err
+	}
+	a.watcher = watcher
+	return nil
+}
+
+// applyPolicyUpdate 应用其他实例广播的策略变更并清空授权缓存及由策略派生的缓存.
+// 无法识别或应用失败的变更改为从数据库全量重新加载，重新加载失败时等待定期全量加载.
+func (a *Authz) applyPolicyUpdate(payload string) {
+	var update PolicyUpdate
+	if err := json.Unmarshal([]byte(payload), &update); err != nil || update.Op == PolicyOpReload {
+		_ = a.reload()
+		return
+	}
+	if err := a.applyIncremental(&update); err != nil {
+		_ = a.reload()
+		return
+	}
+	// 接口路由、权限条件、权限编码和系统角色的缓存由策略派生，与全量加载一样使其失效
+	a.invalidateSnapshots()
+	_ = a.InvalidateCache()
+}
+
+// applyIncremental 在授权器的锁内修改内存中的策略.
+// 变更已由发布方写入数据库，应用期间关闭自动保存，避免重复写入.
+func (a *Authz) applyIncremental(update *PolicyUpdate) error {
+	lock := a.GetLock()
+	lock.Lock()
+	defer lock.Unlock()
+
+	e := a.SyncedCachedEnforcer.SyncedEnforcer.Enforcer
+	e.EnableAutoSave(false)
+	defer e.EnableAutoSave(true)
+
+	// 逐条应用，已存在的规则不重复添加，不存在的规则跳过删除
+	switch update.Op {
+	case PolicyOpAdd:
+		for _, rule := range update.Rules {
+			if _, err := e.SelfAddPolicy(update.Sec, update.Ptype, rule); err != nil {
+				return err
+			}
+		}
+	case PolicyOpRemove:
+		for _, rule := range update.Rules {
+			if _, err := e.SelfRemovePolicy(update.Sec, update.Ptype, rule); err != nil {
+				return err
+			}
+		}
+	case PolicyOpRemoveFiltered:
+		if _, err := e.SelfRemoveFilteredPolicy(update.Sec, update.Ptype, update.FieldIndex, update.FieldValues...); err != nil {
+			return err
+		}
+	case PolicyOpUpdate:
+		if len(update.Rules) != len(update.NewRules) {
+			return fmt.Errorf("policy update has %d old rules and %d new rules", len(update.Rules), len(update.NewRules))
+		}
+		for i := range update.Rules {
+			if _, err := e.SelfRemovePolicy(update.Sec, update.Ptype, update.Rules[i]); err != nil {
+				return err
+			}
+			if _, err := e.SelfAddPolicy(update.Sec, update.Ptype, update.NewRules[i]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unknown policy update op: %s", update.Op)
+	}
+	return nil
+}
+
+// startReconcile 定期从数据库全量加载策略，弥补订阅断开期间丢失的变更.
+// 与 Casbin 的自动加载不同，重新加载后同时清空授权缓存.
+func (a *Authz) startReconcile(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			_ = a.reload()
+		}
+	}()
+}
+
+// reload 从数据库重新加载策略，并清空接口路由、权限条件、权限编码和系统角色的缓存
+func (a *Authz) reload() error {
+	if err := a.LoadPolicy(); err != nil {
+		return err
+	}
+	a.invalidateSnapshots()
+	return a.InvalidateCache()
+}
+
+// invalidateSnapshots 使接口路由、权限条件、权限编码和系统角色的缓存失效
+func (a *Authz) invalidateSnapshots() {
+	if a.permissions != nil {
+		a.permissions.invalidate()
+	}
+	a.InvalidateAPIRoutes()
+	a.InvalidateConditions()
+	a.InvalidateSystemRoles()
+}
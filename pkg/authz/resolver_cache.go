@@ -0,0 +1,156 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// resolverCacheSize 是标识符解析缓存的容量
+const resolverCacheSize = 10000
+
+// 标识符解析缓存的键类型
+const (
+	resolveTenantID       = "tenant_id"
+	resolveRoleID         = "role_id"
+	resolveRoleName       = "role_name"
+	resolvePermissionID   = "permission_id"
+	resolvePermissionName = "permission_name"
+	resolveUserID         = "user_id"
+)
+
+// resolverKey 是标识符解析缓存的键
+type resolverKey struct {
+	kind       string
+	identifier string
+	tenant     string
+}
+
+// resolved 是一次解析的结果，记录不存在的结果以免反复查询数据库
+type resolved struct {
+	id   int64
+	name string
+	err  error
+}
+
+// cachedResolver 缓存 TenantResolver 的解析结果.
+// 只缓存成功和记录不存在的结果，数据库错误不缓存.
+type cachedResolver struct {
+	next  TenantResolver
+	cache *lruCache[resolverKey, resolved]
+}
+
+// 确保 cachedResolver 实现了 TenantResolver 接口.
+var _ TenantResolver = (*cachedResolver)(nil)
+
+// GetTenantID 根据租户标识符获取租户ID
+func (r *cachedResolver) GetTenantID(tenantIdentifier string) (int64, error) {
+	res := r.lookup(resolverKey{kind: resolveTenantID, identifier: tenantIdentifier}, func() resolved {
+		id, err := r.next.GetTenantID(tenantIdentifier)
+		return resolved{id: id, err: err}
+	})
+	return res.id, res.err
+}
+
+// GetTenantIdentifier 根据租户ID获取租户标识符，不需要查询数据库
+func (r *cachedResolver) GetTenantIdentifier(tenantID int64) (string, error) {
+	return r.next.GetTenantIdentifier(tenantID)
+}
+
+// GetRoleID 根据角色标识符和租户获取角色ID
+func (r *cachedResolver) GetRoleID(roleIdentifier, tenantIdentifier string) (int64, error) {
+	res := r.lookup(resolverKey{kind: resolveRoleID, identifier: roleIdentifier, tenant: tenantIdentifier}, func() resolved {
+		id, err := r.next.GetRoleID(roleIdentifier, tenantIdentifier)
+		return resolved{id: id, err: err}
+	})
+	return res.id, res.err
+}
+
+// GetRoleIdentifier 根据角色ID获取角色标识符
+func (r *cachedResolver) GetRoleIdentifier(roleID int64) (string, error) {
+	res := r.lookup(resolverKey{kind: resolveRoleName, identifier: strconv.FormatInt(roleID, 10)}, func() resolved {
+		name, err := r.next.GetRoleIdentifier(roleID)
+		return resolved{name: name, err: err}
+	})
+	return res.name, res.err
+}
+
+// GetPermissionID 根据权限标识符和租户获取权限ID
+func (r *cachedResolver) GetPermissionID(permissionIdentifier, tenantIdentifier string) (int64, error) {
+	res := r.lookup(resolverKey{kind: resolvePermissionID, identifier: permissionIdentifier, tenant: tenantIdentifier}, func() resolved {
+		id, err := r.next.GetPermissionID(permissionIdentifier, tenantIdentifier)
+		return resolved{id: id, err: err}
+	})
+	return res.id, res.err
+}
+
+// GetPermissionIdentifier 根据权限ID获取权限标识符
+func (r *cachedResolver) GetPermissionIdentifier(permissionID int64) (string, error) {
+	res := r.lookup(resolverKey{kind: resolvePermissionName, identifier: strconv.FormatInt(permissionID, 10)}, func() resolved {
+		name, err := r.next.GetPermissionIdentifier(permissionID)
+		return resolved{name: name, err: err}
+	})
+	return res.name, res.err
+}
+
+// lookup 查询缓存，未命中时调用 load 并缓存可以缓存的结果
+func (r *cachedResolver) lookup(key resolverKey, load func() resolved) resolved {
+	res, gen, ok := r.cache.get(key)
+	if ok {
+		return res
+	}
+	res = load()
+	if res.err == nil || errors.Is(res.err, gorm.ErrRecordNotFound) {
+		r.cache.add(key, res, gen)
+	}
+	return res
+}
+
+// resolveUser 将用户名解析为用户ID，找不到用户名时将其作为数字ID解析
+func (a *Authz) resolveUser(user string) (int64, error) {
+	load := func() resolved {
+		var result struct {
+			ID int64 `gorm:"column:id"`
+		}
+		err := a.db.Table("user").
+			Select("id").
+			Where("username = ? AND deleted_at IS NULL", user).
+			First(&result).Error
+		return resolved{id: result.ID, err: err}
+	}
+
+	var res resolved
+	if r, ok := a.tenantResolver.(*cachedResolver); ok {
+		res = r.lookup(resolverKey{kind: resolveUserID, identifier: user}, load)
+	} else {
+		res = load()
+	}
+	if res.err == nil {
+		return res.id, nil
+	}
+
+	// 如果找不到用户，尝试解析为数字ID
+	if id, err := strconv.ParseInt(user, 10, 64); err == nil {
+		return id, nil
+	}
+	return 0, fmt.Errorf("user not found: %s", user)
+}
+
+// InvalidateResolver 在租户、角色、权限或用户的名称变更或被删除后清空标识符解析缓存、权限编码缓存和授权决策缓存
+func (a *Authz) InvalidateResolver() {
+	if r, ok := a.tenantResolver.(*cachedResolver); ok {
+		r.cache.purge()
+	}
+	if a.permissions != nil {
+		a.permissions.invalidate()
+	}
+	a.decisionCache.purge()
+}
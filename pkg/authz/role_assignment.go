@@ -0,0 +1,171 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+// GetRoleIDsForUser 获取用户在租户下直接拥有的角色
+func (a *Authz) GetRoleIDsForUser(userID, tenantID int64) ([]int64, error) {
+	rules, err := a.GetFilteredGroupingPolicy(0, a.idConverter.ToDUserID(userID), "", a.idConverter.ToDDomainID(tenantID))
+	if err != nil {
+		return nil, err
+	}
+	return a.roleIDsAt(rules, 1), nil
+}
+
+// SetRolesForUser 将用户在租户下直接拥有的角色替换为 roleIDs，为空时移除用户在该租户下的全部角色.
+// managedRoleIDs 为由其他机制（如限时授予）维护的角色，不会被移除，也不会重复添加.
+// 先添加缺少的角色再移除多余的角色，写入失败时恢复原有的角色分配.
+func (a *Authz) SetRolesForUser(userID, tenantID int64, roleIDs, managedRoleIDs []int64) error {
+	user := a.idConverter.ToDUserID(userID)
+	domain := a.idConverter.ToDDomainID(tenantID)
+	current, err := a.GetFilteredGroupingPolicy(0, user, "", domain)
+	if err != nil {
+		return err
+	}
+
+	have := make(map[string]bool, len(current))
+	for _, rule := range current {
+		have[rule[1]] = true
+	}
+	keep := make(map[string]bool, len(roleIDs)+len(managedRoleIDs))
+	for _, roleID := range managedRoleIDs {
+		keep[a.idConverter.ToDRoleID(roleID)] = true
+	}
+	var added, removed [][]string
+	for _, roleID := range roleIDs {
+		role := a.idConverter.ToDRoleID(roleID)
+		if !have[role] && !keep[role] {
+			added = append(added, []string{user, role, domain})
+		}
+		keep[role] = true
+	}
+	for _, rule := range current {
+		if !keep[rule[1]] {
+			removed = append(removed, rule)
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	a.sodMu.Lock()
+	defer a.sodMu.Unlock()
+	// 按替换后的角色检查职责分离规则，检查通过后直接写入，避免先添加时与待移除的角色冲突
+	if err := a.checkSoD("g", added, removed...); err != nil {
+		return err
+	}
+	if len(added) > 0 {
+		if _, err := a.SyncedCachedEnforcer.AddGroupingPolicies(added); err != nil {
+			return err
+		}
+	}
+	if len(removed) > 0 {
+		if _, err := a.RemoveGroupingPolicies(removed); err != nil {
+			// 适配器不参与数据库事务，移除失败时撤销已添加的角色，恢复原有的角色分配
+			if len(added) > 0 {
+				_, _ = a.RemoveGroupingPolicies(added)
+			}
+			return err
+		}
+	}
+	return a.InvalidateCache()
+}
+
+// GetUserIDsForRole 获取在租户下直接拥有角色的用户
+func (a *Authz) GetUserIDsForRole(roleID, tenantID int64) ([]int64, error) {
+	rules, err := a.GetFilteredGroupingPolicy(1, a.idConverter.ToDRoleID(roleID), a.idConverter.ToDDomainID(tenantID))
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]int64, 0, len(rules))
+	for _, rule := range rules {
+		if id := a.idConverter.ToUserID(rule[0]); id != 0 {
+			userIDs = append(userIDs, id)
+		}
+	}
+	return userIDs, nil
+}
+
+// HasRoleForUser 检查用户在租户下是否直接拥有角色
+func (a *Authz) HasRoleForUser(userID, roleID, tenantID int64) (bool, error) {
+	return a.HasGroupingPolicy(a.idConverter.ToDUserID(userID), a.idConverter.ToDRoleID(roleID), a.idConverter.ToDDomainID(tenantID))
+}
+
+// AddRoleIDForUser 为用户在租户下添加角色，已拥有时不做修改
+func (a *Authz) AddRoleIDForUser(userID, roleID, tenantID int64) error {
+	if _, err := a.AddGroupingPolicy(a.idConverter.ToDUserID(userID), a.idConverter.ToDRoleID(roleID), a.idConverter.ToDDomainID(tenantID)); err != nil {
+		return err
+	}
+	return a.InvalidateCache()
+}
+
+// RemoveRoleIDForUser 移除用户在租户下直接拥有的角色
+func (a *Authz) RemoveRoleIDForUser(userID, roleID, tenantID int64) error {
+	if _, err := a.RemoveGroupingPolicy(a.idConverter.ToDUserID(userID), a.idConverter.ToDRoleID(roleID), a.idConverter.ToDDomainID(tenantID)); err != nil {
+		return err
+	}
+	return a.InvalidateCache()
+}
+
+// RemoveRole 移除角色在租户下的全部规则，包括权限规则、成员关系和继承关系，删除角色前调用.
+// 子角色改为继承被删除角色的父角色，其他租户的规则不受影响.
+func (a *Authz) RemoveRole(roleID, tenantID int64) error {
+	if err := a.RemoveRoleFromHierarchy(roleID, tenantID); err != nil {
+		return err
+	}
+
+	role := a.idConverter.ToDRoleID(roleID)
+	domain := a.idConverter.ToDDomainID(tenantID)
+	if _, err := a.RemoveFilteredPolicy(0, role, "", domain); err != nil {
+		return err
+	}
+	if _, err := a.RemoveFilteredGroupingPolicy(1, role, domain); err != nil {
+		return err
+	}
+	return a.InvalidateCache()
+}
+
+// SetPermissionsForRole 将角色在租户下对 scope 中权限的 allow 规则替换为 permissionIDs.
+// scope 以外的规则和 deny 规则保持不变，permissionIDs 中已有的 deny 规则会被替换为 allow.
+func (a *Authz) SetPermissionsForRole(roleID, tenantID int64, scope, permissionIDs []int64) error {
+	keep := make(map[int64]bool, len(permissionIDs))
+	for _, id := range permissionIDs {
+		keep[id] = true
+	}
+
+	grants, err := a.GetPermissionsForRole(roleID, tenantID)
+	if err != nil {
+		return err
+	}
+	inScope := make(map[int64]bool, len(scope))
+	for _, id := range scope {
+		inScope[id] = true
+	}
+	for _, grant := range grants {
+		if grant.Effect == EffectAllow && inScope[grant.PermissionID] && !keep[grant.PermissionID] {
+			if _, err := a.DeletePermissionForRole(roleID, grant.PermissionID, tenantID); err != nil {
+				return err
+			}
+		}
+	}
+	for id := range keep {
+		if _, err := a.AddPermissionForRole(roleID, id, tenantID, EffectAllow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Refresh 从数据库重新加载策略，并清空接口路由和权限条件的缓存，配置 Watcher 时通知其他实例一起重新加载
+func (a *Authz) Refresh() error {
+	if err := a.reload(); err != nil {
+		return err
+	}
+	if a.watcher != nil {
+		return a.watcher.Update()
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleAssignment_SetRolesForUser(t *testing.T) {
+	a := newTestAuthz(t)
+	_, err := a.AddGroupingPolicy("u10", "r9", "t2")
+	require.NoError(t, err)
+
+	require.NoError(t, a.SetRolesForUser(10, 1, []int64{2, 3, 2}, nil))
+	roles, err := a.GetRoleIDsForUser(10, 1)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{2, 3}, roles)
+
+	require.NoError(t, a.SetRolesForUser(10, 1, []int64{3}, nil))
+	roles, err = a.GetRoleIDsForUser(10, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, roles)
+
+	require.NoError(t, a.SetRolesForUser(10, 1, nil, nil))
+	roles, err = a.GetRoleIDsForUser(10, 1)
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+
+	// 其他租户的角色不受影响
+	roles, err = a.GetRoleIDsForUser(10, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{9}, roles)
+}
+
+func TestRoleAssignment_SetRolesForUser_Managed(t *testing.T) {
+	a := newTestAuthz(t)
+	require.NoError(t, a.AddRoleIDForUser(10, 2, 1))
+	// 角色 5 由限时授予添加
+	require.NoError(t, a.AddRoleIDForUser(10, 5, 1))
+
+	require.NoError(t, a.SetRolesForUser(10, 1, []int64{3}, []int64{5}))
+	roles, err := a.GetRoleIDsForUser(10, 1)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{3, 5}, roles)
+
+	require.NoError(t, a.SetRolesForUser(10, 1, nil, []int64{5}))
+	roles, err = a.GetRoleIDsForUser(10, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5}, roles)
+}
+
+func TestRoleAssignment_SetPermissionsForRole(t *testing.T) {
+	a := newTestAuthz(t)
+	for _, id := range []int64{30, 31, 40} {
+		_, err := a.AddPermissionForRole(2, id, 1, EffectAllow)
+		require.NoError(t, err)
+	}
+	_, err := a.AddPermissionForRole(2, 32, 1, EffectDeny)
+	require.NoError(t, err)
+
+	// 30、31、32、33 属于菜单，40 不属于任何菜单
+	require.NoError(t, a.SetPermissionsForRole(2, 1, []int64{30, 31, 32, 33}, []int64{31, 33}))
+	grants, err := a.GetPermissionsForRole(2, 1)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []PermissionGrant{
+		{PermissionID: 31, Effect: EffectAllow},
+		{PermissionID: 32, Effect: EffectDeny},
+		{PermissionID: 33, Effect: EffectAllow},
+		{PermissionID: 40, Effect: EffectAllow},
+	}, grants)
+}
+
+func TestRoleAssignment_GetUserIDsForRole(t *testing.T) {
+	a := newTestAuthz(t)
+	require.NoError(t, a.AddRoleIDForUser(10, 2, 1))
+	require.NoError(t, a.AddRoleIDForUser(11, 2, 1))
+	require.NoError(t, a.AddRoleIDForUser(12, 2, 2))
+
+	userIDs, err := a.GetUserIDsForRole(2, 1)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{10, 11}, userIDs)
+
+	require.NoError(t, a.RemoveRoleIDForUser(10, 2, 1))
+	has, err := a.HasRoleForUser(10, 2, 1)
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestRoleAssignment_RemoveRole(t *testing.T) {
+	a := newTestAuthz(t)
+	require.NoError(t, a.AddRoleIDForUser(10, 2, 1))
+	require.NoError(t, a.AddRoleIDForUser(10, 2, 2))
+	require.NoError(t, a.SetParentRoles(3, 1, []int64{2}))
+	_, err := a.AddPermissionForRole(2, 30, 1, EffectAllow)
+	require.NoError(t, err)
+	_, err = a.AddPermissionForRole(2, 30, 2, EffectAllow)
+	require.NoError(t, err)
+
+	require.NoError(t, a.RemoveRole(2, 1))
+	policies, err := a.GetFilteredPolicy(0, "r2", "", "t1")
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+	rules, err := a.GetFilteredGroupingPolicy(1, "r2", "t1")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	// 其他租户的规则不受影响
+	has, err := a.HasRoleForUser(10, 2, 2)
+	require.NoError(t, err)
+	assert.True(t, has)
+	grants, err := a.GetPermissionsForRole(2, 2)
+	require.NoError(t, err)
+	assert.Len(t, grants, 1)
+}
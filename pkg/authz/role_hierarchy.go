@@ -0,0 +1,294 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"errors"
+	"strings"
+)
+
+// MaxRoleInheritanceDepth 是角色继承链的最大层数，与 Casbin 角色管理器的默认层级上限一致
+const MaxRoleInheritanceDepth = 10
+
+var (
+	// ErrRoleInheritanceCycle 表示设置父角色后会形成循环继承
+	ErrRoleInheritanceCycle = errors.New("role inheritance cycle detected")
+	// ErrRoleInheritanceTooDeep 表示设置父角色后继承链超过最大层数
+	ErrRoleInheritanceTooDeep = errors.New("role inheritance chain is too deep")
+)
+
+// EffectivePermission 表示用户的一条生效权限规则及其来源.
+type EffectivePermission struct {
+	PermissionID int64
+	Effect       string
+	// RoleID 为定义该规则的角色，直接授予用户的规则为 0
+	RoleID int64
+	// Via 为从用户直接拥有的角色到 RoleID 的继承链
+	Via []int64
+}
+
+// Inherited 返回该规则是否来自继承的角色
+func (p *EffectivePermission) Inherited() bool {
+	return len(p.Via) > 1
+}
+
+// SetParentRoles 设置角色在租户下的父角色，角色继承父角色的全部权限规则.
+// 父角色为空时清除继承关系，受影响的用户违反职责分离规则时拒绝设置.
+func (a *Authz) SetParentRoles(roleID, tenantID int64, parentIDs []int64) error {
+	role := a.idConverter.ToDRoleID(roleID)
+	domain := a.idConverter.ToDDomainID(tenantID)
+
+	var rules [][]string
+	seen := make(map[int64]bool, len(parentIDs))
+	depth := a.roleDepth(role, domain, 1)
+	for _, parentID := range parentIDs {
+		if seen[parentID] {
+			continue
+		}
+		seen[parentID] = true
+
+		parent := a.idConverter.ToDRoleID(parentID)
+		if parentID == roleID || a.inheritsRole(parent, role, domain) {
+			return ErrRoleInheritanceCycle
+		}
+		if depth+a.roleDepth(parent, domain, 0) > MaxRoleInheritanceDepth {
+			return ErrRoleInheritanceTooDeep
+		}
+		rules = append(rules, []string{role, parent, domain})
+	}
+
+	// 继承关系变更后拥有该角色及其子角色的用户都会获得新的父角色，需要重新检查职责分离规则
+	current, err := a.GetFilteredGroupingPolicy(0, role, "", domain)
+	if err != nil {
+		return err
+	}
+	if err := a.checkSoD("g", rules, current...); err != nil {
+		return err
+	}
+
+	if _, err := a.RemoveFilteredGroupingPolicy(0, role, "", domain); err != nil {
+		return err
+	}
+	if len(rules) > 0 {
+		if _, err := a.AddGroupingPolicies(rules); err != nil {
+			return err
+		}
+	}
+	return a.InvalidateCache()
+}
+
+// GetParentRoles 获取角色在租户下直接继承的父角色
+func (a *Authz) GetParentRoles(roleID, tenantID int64) ([]int64, error) {
+	rules, err := a.GetFilteredGroupingPolicy(0, a.idConverter.ToDRoleID(roleID), "", a.idConverter.ToDDomainID(tenantID))
+	if err != nil {
+		return nil, err
+	}
+	return a.roleIDsAt(rules, 1), nil
+}
+
+// GetChildRoles 获取在租户下直接继承该角色的子角色
+func (a *Authz) GetChildRoles(roleID, tenantID int64) ([]int64, error) {
+	rules, err := a.GetFilteredGroupingPolicy(1, a.idConverter.ToDRoleID(roleID), a.idConverter.ToDDomainID(tenantID))
+	if err != nil {
+		return nil, err
+	}
+	return a.roleIDsAt(rules, 0), nil
+}
+
+// RemoveRoleFromHierarchy 将角色从租户的继承关系中移除.
+// 子角色改为直接继承被移除角色的父角色，从而保留原本经由它继承的权限.
+func (a *Authz) RemoveRoleFromHierarchy(roleID, tenantID int64) error {
+	children, err := a.GetChildRoles(roleID, tenantID)
+	if err != nil {
+		return err
+	}
+	parents, err := a.GetParentRoles(roleID, tenantID)
+	if err != nil {
+		return err
+	}
+
+	role := a.idConverter.ToDRoleID(roleID)
+	domain := a.idConverter.ToDDomainID(tenantID)
+	if _, err := a.RemoveFilteredGroupingPolicy(0, role, "", domain); err != nil {
+		return err
+	}
+	for _, childID := range children {
+		child := a.idConverter.ToDRoleID(childID)
+		if _, err := a.RemoveGroupingPolicy(child, role, domain); err != nil {
+			return err
+		}
+		for _, parentID := range parents {
+			parent := a.idConverter.ToDRoleID(parentID)
+			if has, _ := a.HasGroupingPolicy(child, parent, domain); has {
+				continue
+			}
+			if _, err := a.AddGroupingPolicy(child, parent, domain); err != nil {
+				return err
+			}
+		}
+	}
+	return a.InvalidateCache()
+}
+
+// GetEffectivePermissionsForUser 获取用户在租户下的全部权限规则，包括通过角色继承得到的规则及其来源.
+// 同一角色经由多条继承链可达时，只按最短的继承链记录一次.
+func (a *Authz) GetEffectivePermissionsForUser(userID, tenantID int64) ([]EffectivePermission, error) {
+	user := a.idConverter.ToDUserID(userID)
+	domain := a.idConverter.ToDDomainID(tenantID)
+
+	var result []EffectivePermission
+	collect := func(sub string, roleID int64, via []int64) error {
+		policies, err := a.GetFilteredPolicy(0, sub, "", domain)
+		if err != nil {
+			return err
+		}
+		for _, policy := range policies {
+			permissionID, ok := ParsePermissionObject(policy[1])
+			if !ok {
+				continue
+			}
+			result = append(result, EffectivePermission{
+				PermissionID: permissionID,
+				Effect:       policyEffect(policy),
+				RoleID:       roleID,
+				Via:          via,
+			})
+		}
+		return nil
+	}
+	if err := collect(user, 0, nil); err != nil {
+		return nil, err
+	}
+
+	nodes, err := a.userRoleNodes(user, domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if err := collect(n.role, n.via[len(n.via)-1], n.via); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// roleNode 表示用户拥有的一个角色及从直接角色到它的继承链
+type roleNode struct {
+	role string
+	via  []int64
+}
+
+// userRoleNodes 按广度优先遍历用户在域中直接拥有和继承的角色，每个角色只按最短的继承链记录一次
+func (a *Authz) userRoleNodes(user, domain string) ([]roleNode, error) {
+	rules, err := a.GetFilteredGroupingPolicy(0, user, "", domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []roleNode
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if isRoleSubject(rule[1]) && !seen[rule[1]] {
+			seen[rule[1]] = true
+			nodes = append(nodes, roleNode{role: rule[1], via: []int64{a.idConverter.ToRoleID(rule[1])}})
+		}
+	}
+	for i := 0; i < len(nodes); i++ {
+		current := nodes[i]
+		if len(current.via) >= MaxRoleInheritanceDepth {
+			continue
+		}
+		for _, parent := range a.parentSubjects(current.role, domain) {
+			if seen[parent] {
+				continue
+			}
+			seen[parent] = true
+			via := append(append([]int64{}, current.via...), a.idConverter.ToRoleID(parent))
+			nodes = append(nodes, roleNode{role: parent, via: via})
+		}
+	}
+	return nodes, nil
+}
+
+// inheritsRole 判断 role 是否直接或间接继承了 ancestor
+func (a *Authz) inheritsRole(role, ancestor, domain string) bool {
+	seen := map[string]bool{role: true}
+	queue := []string{role}
+	for i := 0; i < len(queue); i++ {
+		for _, parent := range a.parentSubjects(queue[i], domain) {
+			if parent == ancestor {
+				return true
+			}
+			if !seen[parent] {
+				seen[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return false
+}
+
+// roleDepth 返回从 role 出发的最长继承链层数.
+// direction 为 0 时沿父角色方向计算，为 1 时沿子角色方向计算，结果包含 role 自身.
+func (a *Authz) roleDepth(role, domain string, direction int) int {
+	var walk func(role string, seen map[string]bool) int
+	walk = func(role string, seen map[string]bool) int {
+		var next []string
+		if direction == 0 {
+			next = a.parentSubjects(role, domain)
+		} else {
+			rules, _ := a.GetFilteredGroupingPolicy(1, role, domain)
+			for _, rule := range rules {
+				if isRoleSubject(rule[0]) {
+					next = append(next, rule[0])
+				}
+			}
+		}
+
+		deepest := 0
+		seen[role] = true
+		for _, r := range next {
+			if seen[r] {
+				continue
+			}
+			if d := walk(r, seen); d > deepest {
+				deepest = d
+			}
+		}
+		delete(seen, role)
+		return deepest + 1
+	}
+	return walk(role, map[string]bool{})
+}
+
+// parentSubjects 返回角色在域中直接继承的父角色标识
+func (a *Authz) parentSubjects(role, domain string) []string {
+	rules, _ := a.GetFilteredGroupingPolicy(0, role, "", domain)
+	parents := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		if isRoleSubject(rule[1]) {
+			parents = append(parents, rule[1])
+		}
+	}
+	return parents
+}
+
+// roleIDsAt 从 g 规则的指定位置解析角色ID，忽略非角色主体
+func (a *Authz) roleIDsAt(rules [][]string, index int) []int64 {
+	ids := make([]int64, 0, len(rules))
+	for _, rule := range rules {
+		if isRoleSubject(rule[index]) {
+			ids = append(ids, a.idConverter.ToRoleID(rule[index]))
+		}
+	}
+	return ids
+}
+
+// isRoleSubject 判断 Casbin 主体是否为 r{id} 格式的角色
+func isRoleSubject(sub string) bool {
+	return len(sub) > 1 && strings.HasPrefix(sub, PrefixRoleID) && strings.Trim(sub[1:], "0123456789") == ""
+}
@@ -0,0 +1,84 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleHierarchy_Inheritance(t *testing.T) {
+	a := newTestAuthz(t)
+	_, err := a.AddGroupingPolicy("u10", "r3", "t1")
+	require.NoError(t, err)
+	_, err = a.AddPermissionForRole(2, 30, 1, EffectAllow)
+	require.NoError(t, err)
+	_, err = a.AddPermissionForRole(3, 31, 1, EffectAllow)
+	require.NoError(t, err)
+
+	// auditor(r3) 继承 viewer(r2)
+	require.NoError(t, a.SetParentRoles(3, 1, []int64{2}))
+	d, err := a.DecidePermission("10", 1, 30, nil)
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+
+	parents, err := a.GetParentRoles(3, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, parents)
+	children, err := a.GetChildRoles(2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, children)
+
+	grants, err := a.GetEffectivePermissionsForUser(10, 1)
+	require.NoError(t, err)
+	require.Len(t, grants, 2)
+	assert.Equal(t, EffectivePermission{PermissionID: 31, Effect: EffectAllow, RoleID: 3, Via: []int64{3}}, grants[0])
+	assert.Equal(t, EffectivePermission{PermissionID: 30, Effect: EffectAllow, RoleID: 2, Via: []int64{3, 2}}, grants[1])
+	assert.True(t, grants[1].Inherited())
+
+	// 循环继承被拒绝，原有关系保持不变
+	assert.ErrorIs(t, a.SetParentRoles(2, 1, []int64{3}), ErrRoleInheritanceCycle)
+	assert.ErrorIs(t, a.SetParentRoles(2, 1, []int64{2}), ErrRoleInheritanceCycle)
+	parents, err = a.GetParentRoles(2, 1)
+	require.NoError(t, err)
+	assert.Empty(t, parents)
+
+	// 其他租户不受影响
+	d, err = a.DecidePermission("10", 2, 30, nil)
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+}
+
+func TestRoleHierarchy_RemoveRole(t *testing.T) {
+	a := newTestAuthz(t)
+	_, err := a.AddGroupingPolicy("u10", "r4", "t1")
+	require.NoError(t, err)
+	_, err = a.AddPermissionForRole(2, 30, 1, EffectAllow)
+	require.NoError(t, err)
+
+	// r4 -> r3 -> r2
+	require.NoError(t, a.SetParentRoles(3, 1, []int64{2}))
+	require.NoError(t, a.SetParentRoles(4, 1, []int64{3}))
+
+	require.NoError(t, a.RemoveRoleFromHierarchy(3, 1))
+	parents, err := a.GetParentRoles(4, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, parents)
+	children, err := a.GetChildRoles(2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4}, children)
+
+	d, err := a.DecidePermission("10", 1, 30, nil)
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+}
+
+func TestRoleHierarchy_Depth(t *testing.T) {
+	a := newTestAuthz(t)
+	for id := int64(2); id <= MaxRoleInheritanceDepth; id++ {
+		require.NoError(t, a.SetParentRoles(id, 1, []int64{id - 1}))
+	}
+	assert.ErrorIs(t, a.SetParentRoles(1, 1, []int64{100}), ErrRoleInheritanceTooDeep)
+	assert.ErrorIs(t, a.SetParentRoles(100, 1, []int64{MaxRoleInheritanceDepth}), ErrRoleInheritanceTooDeep)
+	assert.NoError(t, a.SetParentRoles(100, 1, []int64{MaxRoleInheritanceDepth - 1}))
+}
@@ -0,0 +1,290 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// API 权限 resource_path 支持的模式前缀
+const (
+	routePatternRegex = "regex:" // 正则表达式，例如 regex:^/v1/users/[0-9]+$
+	routePatternGlob  = "glob:"  // path.Match 风格的通配，例如 glob:/v1/*/profile
+)
+
+// anyMethod 表示匹配任意 HTTP 方法
+const anyMethod = "*"
+
+// routeRule 是一条编译后的 API 权限规则.
+type routeRule struct {
+	permissionID int64
+	pattern      string
+	match        func(string) bool // 为 nil 时表示精确匹配
+}
+
+// routeTable 是某一时刻 API 权限规则的只读快照.
+type routeTable struct {
+	// exact 以 租户/方法/路径 为键存放不含通配符的规则
+	exact map[string][]int64
+	// patterns 以 租户/方法 为键存放需要逐条匹配的规则
+	patterns map[string][]*routeRule
+}
+
+// RouteIndex 缓存 permissions 表中 resource_type 为 api 的规则，避免每次请求都查询数据库.
+//
+// resource_path 支持以下写法：
+//   - 精确路径或 gin 路由模板：/v1/users、/v1/users/:userID
+//   - keyMatch2/keyMatch5 风格：/v1/users/:id、/v1/users/{id}、/v1/users/*
+//   - glob:/v1/*/profile（path.Match 语义，* 不跨越 /）
+//   - regex:^/v1/users/[0-9]+$
+//
+// http_method 为空或 * 时匹配任意方法，多个方法用逗号分隔.
+type RouteIndex struct {
+	db    *gorm.DB
+	cache *snapshot[routeTable]
+}
+
+// NewRouteIndex 创建 API 权限路由索引，ttl 为快照的有效期.
+func NewRouteIndex(db *gorm.DB, ttl time.Duration) *RouteIndex {
+	idx := &RouteIndex{db: db}
+	idx.cache = newSnapshot(ttl, idx.load)
+	return idx
+}
+
+// Match 返回租户下与请求方法和任一路径匹配的权限ID，method 为空时匹配任意方法.
+func (idx *RouteIndex) Match(tenantID int64, method string, paths ...string) ([]int64, error) {
+	table, err := idx.cache.get()
+	if err != nil {
+		return nil, err
+	}
+	return table.match(tenantID, method, paths...), nil
+}
+
+// Reload 立即从数据库重新加载 API 权限规则.
+func (idx *RouteIndex) Reload() error {
+	return idx.cache.reload()
+}
+
+// Invalidate 使当前快照失效，下一次匹配时重新加载.
+func (idx *RouteIndex) Invalidate() {
+	idx.cache.invalidate()
+}
+
+// load 从数据库加载 API 权限规则
+func (idx *RouteIndex) load() (*routeTable, error) {
+	var rows []struct {
+		ID           int64   `gorm:"column:id"`
+		TenantID     int64   `gorm:"column:tenant_id"`
+		ResourcePath string  `gorm:"column:resource_path"`
+		HTTPMethod   *string `gorm:"column:http_method"`
+	}
+	err := idx.db.Table("permissions").
+		Select("id, tenant_id, resource_path, http_method").
+		Where("resource_type = 'api' AND status = 1 AND resource_path IS NOT NULL AND resource_path <> '' AND deleted_at IS NULL").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	table := &routeTable{
+		exact:    make(map[string][]int64),
+		patterns: make(map[string][]*routeRule),
+	}
+	for _, row := range rows {
+		rule, err := compileRouteRule(row.ID, row.ResourcePath)
+		if err != nil {
+			// 非法规则不影响其他规则的加载
+			continue
+		}
+		var methods string
+		if row.HTTPMethod != nil {
+			methods = *row.HTTPMethod
+		}
+		table.add(row.TenantID, methods, rule)
+	}
+	return table, nil
+}
+
+// add 将规则按方法拆分后加入快照
+func (t *routeTable) add(tenantID int64, methods string, rule *routeRule) {
+	for _, method := range splitMethods(methods) {
+		if rule.match == nil {
+			key := exactKey(tenantID, method, rule.pattern)
+			t.exact[key] = append(t.exact[key], rule.permissionID)
+			continue
+		}
+		key := patternKey(tenantID, method)
+		t.patterns[key] = append(t.patterns[key], rule)
+	}
+}
+
+// match 在快照中查找匹配的权限ID
+func (t *routeTable) match(tenantID int64, method string, paths ...string) []int64 {
+	methods := []string{anyMethod}
+	if method != "" {
+		methods = append(methods, strings.ToUpper(method))
+	} else {
+		methods = t.methods(tenantID)
+	}
+
+	seen := make(map[int64]struct{})
+	var ids []int64
+	collect := func(id int64) {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	for _, m := range methods {
+		for _, p := range paths {
+			if p == "" {
+				continue
+			}
+			for _, id := range t.exact[exactKey(tenantID, m, p)] {
+				collect(id)
+			}
+		}
+		for _, rule := range t.patterns[patternKey(tenantID, m)] {
+			for _, p := range paths {
+				if p != "" && rule.match(p) {
+					collect(rule.permissionID)
+					break
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// methods 返回租户下出现过的全部方法，用于不限定方法的查询
+func (t *routeTable) methods(tenantID int64) []string {
+	prefix := fmt.Sprintf("%d|", tenantID)
+	set := map[string]struct{}{}
+	for key := range t.patterns {
+		if m, ok := strings.CutPrefix(key, prefix); ok {
+			set[m] = struct{}{}
+		}
+	}
+	for key := range t.exact {
+		if rest, ok := strings.CutPrefix(key, prefix); ok {
+			m, _, _ := strings.Cut(rest, "|")
+			set[m] = struct{}{}
+		}
+	}
+	methods := make([]string, 0, len(set))
+	for m := range set {
+		methods = append(methods, m)
+	}
+	return methods
+}
+
+// compileRouteRule 将 resource_path 编译为匹配规则
+func compileRouteRule(permissionID int64, pattern string) (*routeRule, error) {
+	pattern = strings.TrimSpace(pattern)
+	rule := &routeRule{permissionID: permissionID, pattern: pattern}
+
+	switch {
+	case strings.HasPrefix(pattern, routePatternRegex):
+		re, err := regexp.Compile(strings.TrimPrefix(pattern, routePatternRegex))
+		if err != nil {
+			return nil, err
+		}
+		rule.match = re.MatchString
+	case strings.HasPrefix(pattern, routePatternGlob):
+		glob := strings.TrimPrefix(pattern, routePatternGlob)
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, err
+		}
+		rule.match = func(p string) bool {
+			ok, _ := path.Match(glob, p)
+			return ok
+		}
+	case strings.ContainsAny(pattern, ":*{"):
+		re, err := compileKeyPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		// 路由模板本身也可以精确命中，例如 /v1/users/:userID
+		rule.match = func(p string) bool {
+			return p == pattern || re.MatchString(p)
+		}
+	}
+	return rule, nil
+}
+
+// compileKeyPattern 将 keyMatch2/keyMatch5 风格的路径编译为正则表达式.
+// :name 和 {name} 匹配单个路径段，* 匹配任意剩余字符.
+func compileKeyPattern(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); {
+		switch c := pattern[i]; {
+		case c == '*':
+			b.WriteString(".*")
+			i++
+		case c == ':' && (i == 0 || pattern[i-1] == '/'):
+			j := i + 1
+			for j < len(pattern) && pattern[j] != '/' {
+				j++
+			}
+			b.WriteString("[^/]+")
+			i = j
+		case c == '{' && strings.IndexByte(pattern[i:], '}') > 1:
+			b.WriteString("[^/]+")
+			i += strings.IndexByte(pattern[i:], '}') + 1
+		default:
+			j := i + 1
+			for j < len(pattern) && !isKeySpecial(pattern, j) {
+				j++
+			}
+			b.WriteString(regexp.QuoteMeta(pattern[i:j]))
+			i = j
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// isKeySpecial 判断 pattern[i] 是否为参数或通配符的起始字符
+func isKeySpecial(pattern string, i int) bool {
+	switch pattern[i] {
+	case '*', '{':
+		return true
+	case ':':
+		return pattern[i-1] == '/'
+	}
+	return false
+}
+
+// splitMethods 解析 http_method 字段，空值表示任意方法
+func splitMethods(methods string) []string {
+	var result []string
+	for _, m := range strings.Split(methods, ",") {
+		if m = strings.ToUpper(strings.TrimSpace(m)); m != "" {
+			result = append(result, m)
+		}
+	}
+	if len(result) == 0 {
+		return []string{anyMethod}
+	}
+	return result
+}
+
+func exactKey(tenantID int64, method, p string) string {
+	return fmt.Sprintf("%d|%s|%s", tenantID, method, p)
+}
+
+func patternKey(tenantID int64, method string) string {
+	return fmt.Sprintf("%d|%s", tenantID, method)
+}
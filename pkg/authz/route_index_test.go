@@ -0,0 +1,60 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteTable_Match(t *testing.T) {
+	table := &routeTable{exact: map[string][]int64{}, patterns: map[string][]*routeRule{}}
+	rules := []struct {
+		id      int64
+		tenant  int64
+		pattern string
+		methods string
+	}{
+		{1, 1, "/v1/users", "GET"},
+		{2, 1, "/v1/users/:id", "GET,PUT"},
+		{3, 1, "/v1/roles/{roleID}/permissions", "post"},
+		{4, 1, "/v1/menus/*", ""},
+		{5, 1, "glob:/v1/*/profile", "GET"},
+		{6, 1, "regex:^/v1/tenants/[0-9]+$", "DELETE"},
+		{7, 2, "/v1/users", "GET"},
+		{8, 1, "/v1/users:batchGet", "POST"},
+	}
+	for _, r := range rules {
+		rule, err := compileRouteRule(r.id, r.pattern)
+		require.NoError(t, err, r.pattern)
+		table.add(r.tenant, r.methods, rule)
+	}
+
+	tests := []struct {
+		method string
+		paths  []string
+		want   []int64
+	}{
+		{"GET", []string{"/v1/users"}, []int64{1}},
+		{"GET", []string{"/v1/users/:userID", "/v1/users/42"}, []int64{2}},
+		{"PUT", []string{"/v1/users/42"}, []int64{2}},
+		{"DELETE", []string{"/v1/users/42"}, nil},
+		{"GET", []string{"/v1/users/42/roles"}, nil},
+		{"POST", []string{"/v1/roles/:roleID/permissions"}, []int64{3}},
+		{"PATCH", []string{"/v1/menus/3/children"}, []int64{4}},
+		{"GET", []string{"/v1/users/profile"}, []int64{2, 5}},
+		{"DELETE", []string{"/v1/tenants/:tenantID", "/v1/tenants/12"}, []int64{6}},
+		{"DELETE", []string{"/v1/tenants/:tenantID"}, nil},
+		{"POST", []string{"/v1/users:batchGet"}, []int64{8}},
+		{"POST", []string{"/v1/usersXbatchGet"}, nil},
+	}
+	for _, tt := range tests {
+		assert.ElementsMatch(t, tt.want, table.match(1, tt.method, tt.paths...), "%s %v", tt.method, tt.paths)
+	}
+
+	assert.Equal(t, []int64{7}, table.match(2, "GET", "/v1/users"))
+	assert.ElementsMatch(t, []int64{2}, table.match(1, "", "/v1/users/7"))
+
+	_, err := compileRouteRule(9, "regex:([")
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"slices"
+
+	"github.com/ashwinyue/one-auth/pkg/api/oneauth"
+)
+
+// 按服务凭证授权的权限编码授权时的决策原因
+const (
+	ReasonServiceScope   = "service_scope"    // 服务凭证拥有路由要求的权限编码
+	ReasonNoServiceScope = "no_service_scope" // 服务凭证没有路由要求的权限编码，默认拒绝
+)
+
+// CheckServiceRouteAccess 按服务凭证授权的权限编码 scopes 检查路由的访问权限.
+// 路由对应 proto 中的方法时要求凭证拥有方法声明的权限编码，否则要求凭证拥有租户下与路由匹配的任一权限.
+// 服务凭证不属于任何角色，不继承角色权限，也不求值权限条件；domain 为空时只能访问 proto 中声明的路由.
+func (a *Authz) CheckServiceRouteAccess(scopes []string, domain string, rc *RequestContext) (*Decision, error) {
+	if rule, ok := oneauth.ForRoute(rc.Method, rc.Route); ok {
+		switch {
+		case rule.Public:
+			return &Decision{Allowed: true, Reason: ReasonPublicMethod}, nil
+		case rule.Service:
+			return &Decision{Allowed: true, Reason: ReasonServiceMethod}, nil
+		case rule.Permission != "" && slices.Contains(scopes, rule.Permission):
+			return &Decision{Allowed: true, Reason: ReasonServiceScope}, nil
+		}
+		return &Decision{Reason: ReasonNoServiceScope}, nil
+	}
+
+	if domain == "" || len(scopes) == 0 {
+		return &Decision{Reason: ReasonNoServiceScope}, nil
+	}
+	tenantID, err := a.tenantResolver.GetTenantID(domain)
+	if err != nil {
+		return nil, err
+	}
+	permissionIDs, err := a.routeIndex.Match(tenantID, rc.Method, rc.Route, rc.Path)
+	if err != nil {
+		return nil, err
+	}
+	if len(permissionIDs) == 0 {
+		return &Decision{Reason: ReasonNoAPIPermission}, nil
+	}
+
+	for _, scope := range scopes {
+		permissionID, err := a.tenantResolver.GetPermissionID(scope, domain)
+		if err != nil {
+			// 租户下没有该权限编码
+			continue
+		}
+		if slices.Contains(permissionIDs, permissionID) {
+			return &Decision{Allowed: true, Reason: ReasonServiceScope}, nil
+		}
+	}
+	return &Decision{Reason: ReasonNoServiceScope}, nil
+}
@@ -0,0 +1,256 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	casbin "github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+)
+
+// 预览支持的策略变更类型
+const (
+	ChangeGrantPermission  = "grant_permission"
+	ChangeRevokePermission = "revoke_permission"
+	ChangeAddUserRole      = "add_user_role"
+	ChangeRemoveUserRole   = "remove_user_role"
+)
+
+// ErrInvalidPolicyChange 表示待预览的策略变更不合法
+var ErrInvalidPolicyChange = errors.New("invalid policy change")
+
+// PolicyChange 表示一项待预览的策略变更.
+type PolicyChange struct {
+	Op     string
+	RoleID int64
+	// PermissionID、Effect 用于授予和撤销权限，Effect 为空时为 allow
+	PermissionID int64
+	Effect       string
+	// UserID 用于添加和移除用户角色
+	UserID int64
+}
+
+// SimulatedUser 表示一个受变更影响的用户在变更前后的生效权限.
+type SimulatedUser struct {
+	UserID int64
+	// Before、After 为变更前后被允许且未被拒绝的权限ID，不考虑权限条件
+	Before []int64
+	After  []int64
+	// SuperAdminBefore、SuperAdminAfter 表示变更前后是否为超级管理员
+	SuperAdminBefore bool
+	SuperAdminAfter  bool
+}
+
+// SimulatePolicyChanges 在租户策略的内存副本上应用变更，返回受影响用户变更前后的生效权限.
+// 受影响用户为变更中直接指定的用户，以及变更前后直接或通过继承拥有被修改角色的用户；线上策略不会被修改.
+func (a *Authz) SimulatePolicyChanges(tenantID int64, changes []PolicyChange) ([]SimulatedUser, error) {
+	changes = append([]PolicyChange(nil), changes...)
+	for i := range changes {
+		if err := validatePolicyChange(&changes[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	shadow, err := a.tenantCopy(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 租户下的全部用户，以及变更中指定的用户
+	domain := a.idConverter.ToDDomainID(tenantID)
+	rules, err := shadow.GetFilteredGroupingPolicy(2, domain)
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[int64]bool)
+	for _, rule := range rules {
+		if strings.HasPrefix(rule[0], PrefixUserID) {
+			users[a.idConverter.ToUserID(rule[0])] = false
+		}
+	}
+	changedRoles := make(map[int64]bool)
+	for _, change := range changes {
+		switch change.Op {
+		case ChangeAddUserRole, ChangeRemoveUserRole:
+			users[change.UserID] = true
+		default:
+			changedRoles[change.RoleID] = true
+		}
+	}
+
+	before := make(map[int64]*simulatedAccess, len(users))
+	for userID := range users {
+		if before[userID], err = shadow.simulatedAccess(userID, tenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := shadow.applyPolicyChanges(tenantID, changes); err != nil {
+		return nil, err
+	}
+
+	var result []SimulatedUser
+	for userID, named := range users {
+		after, err := shadow.simulatedAccess(userID, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if !named && !before[userID].hasAnyRole(changedRoles) && !after.hasAnyRole(changedRoles) {
+			continue
+		}
+		result = append(result, SimulatedUser{
+			UserID:           userID,
+			Before:           before[userID].permissionIDs,
+			After:            after.permissionIDs,
+			SuperAdminBefore: before[userID].superAdmin,
+			SuperAdminAfter:  after.superAdmin,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].UserID < result[j].UserID })
+	return result, nil
+}
+
+// simulatedAccess 是用户在某一时刻的角色和生效权限
+type simulatedAccess struct {
+	roles         map[int64]bool
+	permissionIDs []int64
+	superAdmin    bool
+}
+
+// hasAnyRole 判断用户是否拥有任一指定角色
+func (s *simulatedAccess) hasAnyRole(roles map[int64]bool) bool {
+	for roleID := range s.roles {
+		if roles[roleID] {
+			return true
+		}
+	}
+	return false
+}
+
+// simulatedAccess 计算用户在租户下的角色和生效权限，deny 规则优先于 allow 规则
+func (a *Authz) simulatedAccess(userID, tenantID int64) (*simulatedAccess, error) {
+	access := &simulatedAccess{roles: make(map[int64]bool)}
+
+	nodes, err := a.userRoleNodes(a.idConverter.ToDUserID(userID), a.idConverter.ToDDomainID(tenantID))
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		access.roles[n.via[len(n.via)-1]] = true
+	}
+
+	grants, err := a.GetEffectivePermissionsForUser(userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	allowed := make(map[int64]bool)
+	denied := make(map[int64]bool)
+	for _, grant := range grants {
+		if grant.Effect == EffectDeny {
+			denied[grant.PermissionID] = true
+		} else {
+			allowed[grant.PermissionID] = true
+		}
+	}
+	for permissionID := range allowed {
+		if !denied[permissionID] {
+			access.permissionIDs = append(access.permissionIDs, permissionID)
+		}
+	}
+	sort.Slice(access.permissionIDs, func(i, j int) bool { return access.permissionIDs[i] < access.permissionIDs[j] })
+
+	if access.superAdmin, err = a.isSuperAdmin(fmt.Sprint(userID), a.idConverter.ToDDomainID(tenantID)); err != nil {
+		return nil, err
+	}
+	return access, nil
+}
+
+// applyPolicyChanges 按顺序应用策略变更
+func (a *Authz) applyPolicyChanges(tenantID int64, changes []PolicyChange) error {
+	domain := a.idConverter.ToDDomainID(tenantID)
+	for _, change := range changes {
+		var err error
+		switch change.Op {
+		case ChangeGrantPermission:
+			_, err = a.AddPermissionForRole(change.RoleID, change.PermissionID, tenantID, change.Effect)
+		case ChangeRevokePermission:
+			_, err = a.DeletePermissionForRole(change.RoleID, change.PermissionID, tenantID)
+		case ChangeAddUserRole:
+			_, err = a.AddGroupingPolicy(a.idConverter.ToDUserID(change.UserID), a.idConverter.ToDRoleID(change.RoleID), domain)
+		case ChangeRemoveUserRole:
+			_, err = a.RemoveGroupingPolicy(a.idConverter.ToDUserID(change.UserID), a.idConverter.ToDRoleID(change.RoleID), domain)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tenantCopy 创建只包含租户策略的内存授权器，不关联适配器，修改不会写入数据库
+func (a *Authz) tenantCopy(tenantID int64) (*Authz, error) {
+	m, err := model.NewModelFromString(a.GetModel().ToText())
+	if err != nil {
+		return nil, err
+	}
+	enforcer, err := casbin.NewSyncedCachedEnforcer(m)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := a.idConverter.ToDDomainID(tenantID)
+	policies, err := a.GetFilteredPolicy(2, domain)
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) > 0 {
+		if _, err := enforcer.AddPolicies(policies); err != nil {
+			return nil, err
+		}
+	}
+	rules, err := a.GetFilteredGroupingPolicy(2, domain)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) > 0 {
+		if _, err := enforcer.AddGroupingPolicies(rules); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Authz{SyncedCachedEnforcer: enforcer, tenantResolver: a.tenantResolver, idConverter: a.idConverter, systemRoles: a.systemRoles, sodRules: a.sodRules}, nil
+}
+
+// validatePolicyChange 校验策略变更并补齐默认效果
+func validatePolicyChange(change *PolicyChange) error {
+	if change.RoleID <= 0 {
+		return fmt.Errorf("%w: role_id is required", ErrInvalidPolicyChange)
+	}
+	switch change.Op {
+	case ChangeGrantPermission, ChangeRevokePermission:
+		if change.PermissionID <= 0 {
+			return fmt.Errorf("%w: permission_id is required for %s", ErrInvalidPolicyChange, change.Op)
+		}
+		if change.Effect == "" {
+			change.Effect = EffectAllow
+		}
+		if !IsValidEffect(change.Effect) {
+			return fmt.Errorf("%w: invalid effect %q", ErrInvalidPolicyChange, change.Effect)
+		}
+	case ChangeAddUserRole, ChangeRemoveUserRole:
+		if change.UserID <= 0 {
+			return fmt.Errorf("%w: user_id is required for %s", ErrInvalidPolicyChange, change.Op)
+		}
+	default:
+		return fmt.Errorf("%w: unknown op %q", ErrInvalidPolicyChange, change.Op)
+	}
+	return nil
+}
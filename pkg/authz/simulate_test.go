@@ -0,0 +1,45 @@
+package authz
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulatePolicyChanges(t *testing.T) {
+	a := newExplainTestAuthz(t)
+	for _, rule := range [][]string{{"u10", "r2", "t1"}, {"u11", "r3", "t1"}, {"r3", "r2", "t1"}, {"u12", "r4", "t1"}} {
+		_, err := a.AddGroupingPolicy(rule[0], rule[1], rule[2])
+		require.NoError(t, err)
+	}
+	_, err := a.AddPermissionForRole(2, 30, 1, EffectAllow)
+	require.NoError(t, err)
+	_, err = a.AddPermissionForRole(4, 40, 1, EffectAllow)
+	require.NoError(t, err)
+
+	users, err := a.SimulatePolicyChanges(1, []PolicyChange{
+		{Op: ChangeGrantPermission, RoleID: 2, PermissionID: 31},
+		{Op: ChangeGrantPermission, RoleID: 3, PermissionID: 30, Effect: EffectDeny},
+		{Op: ChangeAddUserRole, UserID: 13, RoleID: 4},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []SimulatedUser{
+		{UserID: 10, Before: []int64{30}, After: []int64{30, 31}},
+		// u11 通过 r3 继承 r2
+		{UserID: 11, Before: []int64{30}, After: []int64{31}},
+		{UserID: 13, After: []int64{40}},
+	}, users)
+
+	// 线上策略不受影响
+	grants, err := a.GetPermissionsForRole(2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []PermissionGrant{{PermissionID: 30, Effect: EffectAllow}}, grants)
+	has, err := a.HasGroupingPolicy("u13", "r4", "t1")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	_, err = a.SimulatePolicyChanges(1, []PolicyChange{{Op: "drop_table", RoleID: 2}})
+	assert.True(t, errors.Is(err, ErrInvalidPolicyChange))
+}
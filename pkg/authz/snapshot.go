@@ -0,0 +1,77 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// snapshot 缓存从数据库加载的只读数据.
+// 数据过期后由一个调用方负责刷新，其余调用方继续使用旧数据；刷新失败时保留旧数据.
+type snapshot[T any] struct {
+	ttl   time.Duration
+	load  func() (*T, error)
+	value atomic.Pointer[snapshotValue[T]]
+	mu    sync.Mutex
+}
+
+type snapshotValue[T any] struct {
+	data     *T
+	loadedAt time.Time
+}
+
+// newSnapshot 创建快照缓存，ttl 为 0 时只在失效后重新加载
+func newSnapshot[T any](ttl time.Duration, load func() (*T, error)) *snapshot[T] {
+	return &snapshot[T]{ttl: ttl, load: load}
+}
+
+// get 返回当前数据，首次访问或失效后同步加载
+func (s *snapshot[T]) get() (*T, error) {
+	v := s.value.Load()
+	if v == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if v = s.value.Load(); v != nil {
+			return v.data, nil
+		}
+		if err := s.reloadLocked(); err != nil {
+			return nil, err
+		}
+		return s.value.Load().data, nil
+	}
+
+	if s.ttl > 0 && time.Since(v.loadedAt) > s.ttl && s.mu.TryLock() {
+		defer s.mu.Unlock()
+		if err := s.reloadLocked(); err == nil {
+			v = s.value.Load()
+		}
+	}
+	return v.data, nil
+}
+
+// reload 立即重新加载数据
+func (s *snapshot[T]) reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reloadLocked()
+}
+
+// invalidate 使当前数据失效，下一次访问时重新加载
+func (s *snapshot[T]) invalidate() {
+	s.value.Store(nil)
+}
+
+func (s *snapshot[T]) reloadLocked() error {
+	data, err := s.load()
+	if err != nil {
+		return err
+	}
+	s.value.Store(&snapshotValue[T]{data: data, loadedAt: time.Now()})
+	return nil
+}
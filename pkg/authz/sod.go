@@ -0,0 +1,495 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// 静态职责分离规则的类型
+const (
+	SoDKindExclusive   = "exclusive"   // 互斥角色集合，同一用户最多拥有其中 Limit 个角色
+	SoDKindCardinality = "cardinality" // 角色基数限制，集合中每个角色最多分配给 Limit 个用户
+)
+
+// SoDRule 定义租户内的静态职责分离规则，约束用户直接拥有和经由角色继承得到的角色
+type SoDRule struct {
+	ID       int64
+	TenantID int64
+	Name     string
+	Kind     string
+	RoleIDs  []int64
+	Limit    int
+}
+
+// SoDViolation 描述一条被违反的职责分离规则，同时作为拒绝角色分配时返回的错误
+type SoDViolation struct {
+	RuleID   int64
+	RuleName string
+	Kind     string
+	TenantID int64
+	UserIDs  []int64
+	RoleIDs  []int64
+	Limit    int
+}
+
+// Error 实现 error 接口
+func (v *SoDViolation) Error() string {
+	if v.Kind == SoDKindCardinality {
+		return fmt.Sprintf("separation of duties rule %q violated: role %v may be assigned to at most %d users, got users %v",
+			v.RuleName, v.RoleIDs, v.Limit, v.UserIDs)
+	}
+	return fmt.Sprintf("separation of duties rule %q violated: user %v may hold at most %d of the exclusive roles, got roles %v",
+		v.RuleName, v.UserIDs, v.Limit, v.RoleIDs)
+}
+
+// IsSoDViolation 检查错误是否由违反职责分离规则引起
+func IsSoDViolation(err error) bool {
+	var violation *SoDViolation
+	return errors.As(err, &violation)
+}
+
+// sodTable 保存全部职责分离规则，键为租户ID
+type sodTable struct {
+	rules map[int64][]SoDRule
+}
+
+// newSoDTable 按租户整理职责分离规则
+func newSoDTable(rules []SoDRule) *sodTable {
+	table := &sodTable{rules: make(map[int64][]SoDRule)}
+	for _, rule := range rules {
+		if rule.Limit <= 0 {
+			rule.Limit = 1
+		}
+		table.rules[rule.TenantID] = append(table.rules[rule.TenantID], rule)
+	}
+	return table
+}
+
+// loadSoDTable 从数据库加载职责分离规则
+func loadSoDTable(db *gorm.DB) (*sodTable, error) {
+	var rows []struct {
+		ID       int64  `gorm:"column:id"`
+		TenantID int64  `gorm:"column:tenant_id"`
+		Name     string `gorm:"column:name"`
+		Kind     string `gorm:"column:kind"`
+		RoleIDs  string `gorm:"column:role_ids"`
+		MaxCount int    `gorm:"column:max_count"`
+	}
+	if err := db.Table("sod_rules").Select("id, tenant_id, name, kind, role_ids, max_count").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	rules := make([]SoDRule, 0, len(rows))
+	for _, row := range rows {
+		rule := SoDRule{ID: row.ID, TenantID: row.TenantID, Name: row.Name, Kind: row.Kind, Limit: row.MaxCount}
+		for _, s := range strings.Split(row.RoleIDs, ",") {
+			if id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil {
+				rule.RoleIDs = append(rule.RoleIDs, id)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return newSoDTable(rules), nil
+}
+
+// InvalidateSoDRules 在职责分离规则变更后使缓存失效
+func (a *Authz) InvalidateSoDRules() {
+	if a.sodRules != nil {
+		a.sodRules.invalidate()
+	}
+}
+
+// AddGroupingPolicy 在写入用户角色分配前检查职责分离规则.
+// 覆盖 Casbin 的同名方法，使所有经过 Authz 的角色分配都受到约束.
+func (a *Authz) AddGroupingPolicy(params ...interface{}) (bool, error) {
+	return a.AddNamedGroupingPolicy("g", params...)
+}
+
+// AddNamedGroupingPolicy 在写入用户角色分配前检查职责分离规则
+func (a *Authz) AddNamedGroupingPolicy(ptype string, params ...interface{}) (bool, error) {
+	a.sodMu.Lock()
+	defer a.sodMu.Unlock()
+	if err := a.checkSoD(ptype, [][]string{groupingParams(params)}); err != nil {
+		return false, err
+	}
+	return a.SyncedCachedEnforcer.AddNamedGroupingPolicy(ptype, params...)
+}
+
+// AddGroupingPolicies 在批量写入用户角色分配前检查职责分离规则
+func (a *Authz) AddGroupingPolicies(rules [][]string) (bool, error) {
+	return a.AddNamedGroupingPolicies("g", rules)
+}
+
+// AddNamedGroupingPolicies 在批量写入用户角色分配前检查职责分离规则
+func (a *Authz) AddNamedGroupingPolicies(ptype string, rules [][]string) (bool, error) {
+	a.sodMu.Lock()
+	defer a.sodMu.Unlock()
+	if err := a.checkSoD(ptype, rules); err != nil {
+		return false, err
+	}
+	return a.SyncedCachedEnforcer.AddNamedGroupingPolicies(ptype, rules)
+}
+
+// AddGroupingPoliciesEx 在批量写入用户角色分配前检查职责分离规则，忽略已存在的规则
+func (a *Authz) AddGroupingPoliciesEx(rules [][]string) (bool, error) {
+	a.sodMu.Lock()
+	defer a.sodMu.Unlock()
+	if err := a.checkSoD("g", rules); err != nil {
+		return false, err
+	}
+	return a.SyncedCachedEnforcer.AddGroupingPoliciesEx(rules)
+}
+
+// AddRoleForUserInDomain 在写入用户角色分配前检查职责分离规则
+func (a *Authz) AddRoleForUserInDomain(user, role, domain string) (bool, error) {
+	return a.AddGroupingPolicy(user, role, domain)
+}
+
+// UpdateGroupingPolicy 在修改用户角色分配前检查职责分离规则
+func (a *Authz) UpdateGroupingPolicy(oldRule []string, newRule []string) (bool, error) {
+	a.sodMu.Lock()
+	defer a.sodMu.Unlock()
+	if err := a.checkSoD("g", [][]string{newRule}, oldRule); err != nil {
+		return false, err
+	}
+	return a.SyncedCachedEnforcer.UpdateGroupingPolicy(oldRule, newRule)
+}
+
+// groupingParams 将 Casbin 风格的参数转换为规则
+func groupingParams(params []interface{}) []string {
+	if len(params) == 1 {
+		if rule, ok := params[0].([]string); ok {
+			return rule
+		}
+	}
+	rule := make([]string, 0, len(params))
+	for _, p := range params {
+		rule = append(rule, fmt.Sprint(p))
+	}
+	return rule
+}
+
+// CheckSoD 检查写入 g 规则后是否违反职责分离规则，不修改策略
+func (a *Authz) CheckSoD(rules [][]string) error {
+	return a.checkSoD("g", rules)
+}
+
+// CheckRoleForUser 检查为用户在租户下添加角色后是否违反职责分离规则
+func (a *Authz) CheckRoleForUser(userID, roleID, tenantID int64) error {
+	return a.CheckSoD([][]string{{a.idConverter.ToDUserID(userID), a.idConverter.ToDRoleID(roleID), a.idConverter.ToDDomainID(tenantID)}})
+}
+
+// CheckRolesForUser 检查将用户在租户下的角色替换为 roleIDs 后是否违反职责分离规则
+func (a *Authz) CheckRolesForUser(userID, tenantID int64, roleIDs []int64) error {
+	user := a.idConverter.ToDUserID(userID)
+	domain := a.idConverter.ToDDomainID(tenantID)
+	current, err := a.GetFilteredGroupingPolicy(0, user, "", domain)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[string]bool, len(roleIDs))
+	rules := make([][]string, 0, len(roleIDs))
+	for _, roleID := range roleIDs {
+		role := a.idConverter.ToDRoleID(roleID)
+		keep[role] = true
+		rules = append(rules, []string{user, role, domain})
+	}
+	var removed [][]string
+	for _, rule := range current {
+		if !keep[rule[1]] {
+			removed = append(removed, rule)
+		}
+	}
+	return a.checkSoD("g", rules, removed...)
+}
+
+// checkSoD 检查写入 g 规则后是否违反职责分离规则，removed 为同时移除的规则.
+// 用户拥有的角色包括经由角色继承得到的角色，因此用户角色分配和角色继承规则的变更都会被检查.
+// 只检查变更后新增的用户角色涉及的用户和角色，已存在的违规不会阻止无关的分配.
+func (a *Authz) checkSoD(ptype string, added [][]string, removed ...[]string) error {
+	if a.sodRules == nil || ptype != "g" {
+		return nil
+	}
+	table, err := a.sodRules.get()
+	if err != nil {
+		return err
+	}
+	if len(table.rules) == 0 {
+		return nil
+	}
+
+	// 按租户整理新增的规则
+	domains := make(map[string]bool)
+	for _, rule := range added {
+		if len(rule) >= 3 {
+			domains[rule[2]] = true
+		}
+	}
+
+	for domain := range domains {
+		tenantID := a.idConverter.ToDomainID(domain)
+		rules := table.rules[tenantID]
+		if len(rules) == 0 {
+			continue
+		}
+
+		state, err := a.sodState(domain)
+		if err != nil {
+			return err
+		}
+		before := state.roles()
+		for _, rule := range removed {
+			if len(rule) >= 3 && rule[2] == domain {
+				state.apply(a, rule, false)
+			}
+		}
+		for _, rule := range added {
+			if len(rule) >= 3 && rule[2] == domain {
+				state.apply(a, rule, true)
+			}
+		}
+		after := state.roles()
+		changed := after.diff(before)
+		if len(changed) == 0 {
+			continue
+		}
+
+		for _, rule := range rules {
+			if violations := after.violations(rule, changed); len(violations) > 0 {
+				return violations[0]
+			}
+		}
+	}
+	return nil
+}
+
+// SoDViolations 返回租户当前违反职责分离规则的角色分配
+func (a *Authz) SoDViolations(tenantID int64) ([]*SoDViolation, error) {
+	if a.sodRules == nil {
+		return nil, nil
+	}
+	table, err := a.sodRules.get()
+	if err != nil {
+		return nil, err
+	}
+	rules := table.rules[tenantID]
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	state, err := a.sodState(a.idConverter.ToDDomainID(tenantID))
+	if err != nil {
+		return nil, err
+	}
+	roles := state.roles()
+	var violations []*SoDViolation
+	for _, rule := range rules {
+		violations = append(violations, roles.violations(rule, nil)...)
+	}
+	return violations, nil
+}
+
+// userRoleRule 解析用户到角色的 g 规则，角色继承规则返回 false
+func (a *Authz) userRoleRule(rule []string) (int64, int64, bool) {
+	if len(rule) < 3 || !strings.HasPrefix(rule[0], PrefixUserID) || !strings.HasPrefix(rule[1], PrefixRoleID) {
+		return 0, 0, false
+	}
+	userID := a.idConverter.ToUserID(rule[0])
+	roleID := a.idConverter.ToRoleID(rule[1])
+	return userID, roleID, userID != 0 && roleID != 0
+}
+
+// roleInheritRule 解析角色到父角色的 g 规则
+func (a *Authz) roleInheritRule(rule []string) (int64, int64, bool) {
+	if len(rule) < 3 || !isRoleSubject(rule[0]) || !isRoleSubject(rule[1]) {
+		return 0, 0, false
+	}
+	return a.idConverter.ToRoleID(rule[0]), a.idConverter.ToRoleID(rule[1]), true
+}
+
+// sodState 保存租户内用户直接拥有的角色和角色继承关系
+type sodState struct {
+	tenantID int64
+	direct   map[int64]map[int64]bool
+	parents  map[int64]map[int64]bool
+}
+
+// sodState 读取租户内当前的用户角色分配和角色继承关系
+func (a *Authz) sodState(domain string) (*sodState, error) {
+	rules, err := a.GetFilteredGroupingPolicy(2, domain)
+	if err != nil {
+		return nil, err
+	}
+	state := &sodState{
+		tenantID: a.idConverter.ToDomainID(domain),
+		direct:   make(map[int64]map[int64]bool),
+		parents:  make(map[int64]map[int64]bool),
+	}
+	for _, rule := range rules {
+		state.apply(a, rule, true)
+	}
+	return state, nil
+}
+
+// apply 添加或移除一条 g 规则
+func (s *sodState) apply(a *Authz, rule []string, add bool) {
+	var edges map[int64]map[int64]bool
+	from, to, ok := a.userRoleRule(rule)
+	if ok {
+		edges = s.direct
+	} else if from, to, ok = a.roleInheritRule(rule); ok {
+		edges = s.parents
+	} else {
+		return
+	}
+
+	if !add {
+		delete(edges[from], to)
+		return
+	}
+	if edges[from] == nil {
+		edges[from] = make(map[int64]bool)
+	}
+	edges[from][to] = true
+}
+
+// roles 计算用户直接拥有和经由角色继承得到的全部角色
+func (s *sodState) roles() *sodRoles {
+	roles := &sodRoles{
+		tenantID:  s.tenantID,
+		userRoles: make(map[int64]map[int64]bool),
+		roleUsers: make(map[int64]map[int64]bool),
+	}
+	for userID, direct := range s.direct {
+		for roleID := range direct {
+			for _, id := range s.ancestors(roleID) {
+				roles.add(userID, id)
+			}
+		}
+	}
+	return roles
+}
+
+// ancestors 返回角色自身及其继承的全部角色，继承层数与 Casbin 角色管理器一致
+func (s *sodState) ancestors(roleID int64) []int64 {
+	result := []int64{roleID}
+	depth := map[int64]int{roleID: 1}
+	for i := 0; i < len(result); i++ {
+		current := result[i]
+		if depth[current] >= MaxRoleInheritanceDepth {
+			continue
+		}
+		for parent := range s.parents[current] {
+			if _, ok := depth[parent]; !ok {
+				depth[parent] = depth[current] + 1
+				result = append(result, parent)
+			}
+		}
+	}
+	return result
+}
+
+// sodRoles 保存租户内用户拥有的全部角色
+type sodRoles struct {
+	tenantID  int64
+	userRoles map[int64]map[int64]bool
+	roleUsers map[int64]map[int64]bool
+}
+
+// add 添加用户拥有的角色
+func (s *sodRoles) add(userID, roleID int64) {
+	if s.userRoles[userID] == nil {
+		s.userRoles[userID] = make(map[int64]bool)
+	}
+	if s.roleUsers[roleID] == nil {
+		s.roleUsers[roleID] = make(map[int64]bool)
+	}
+	s.userRoles[userID][roleID] = true
+	s.roleUsers[roleID][userID] = true
+}
+
+// diff 返回相比 before 新增的用户角色
+func (s *sodRoles) diff(before *sodRoles) map[int64]map[int64]bool {
+	changed := make(map[int64]map[int64]bool)
+	for userID, held := range s.userRoles {
+		for roleID := range held {
+			if before.userRoles[userID][roleID] {
+				continue
+			}
+			if changed[userID] == nil {
+				changed[userID] = make(map[int64]bool)
+			}
+			changed[userID][roleID] = true
+		}
+	}
+	return changed
+}
+
+// violations 返回违反规则的分配，changed 不为空时只检查新增分配涉及的用户和角色
+func (s *sodRoles) violations(rule SoDRule, changed map[int64]map[int64]bool) []*SoDViolation {
+	var violations []*SoDViolation
+	newViolation := func(userIDs, roleIDs []int64) *SoDViolation {
+		sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+		sort.Slice(roleIDs, func(i, j int) bool { return roleIDs[i] < roleIDs[j] })
+		return &SoDViolation{
+			RuleID:   rule.ID,
+			RuleName: rule.Name,
+			Kind:     rule.Kind,
+			TenantID: s.tenantID,
+			UserIDs:  userIDs,
+			RoleIDs:  roleIDs,
+			Limit:    rule.Limit,
+		}
+	}
+
+	switch rule.Kind {
+	case SoDKindExclusive:
+		for userID, held := range s.userRoles {
+			var roleIDs []int64
+			touched := changed == nil
+			for _, roleID := range rule.RoleIDs {
+				if held[roleID] {
+					roleIDs = append(roleIDs, roleID)
+					touched = touched || changed[userID][roleID]
+				}
+			}
+			if touched && len(roleIDs) > rule.Limit {
+				violations = append(violations, newViolation([]int64{userID}, roleIDs))
+			}
+		}
+	case SoDKindCardinality:
+		for _, roleID := range rule.RoleIDs {
+			touched := changed == nil
+			for userID := range s.roleUsers[roleID] {
+				touched = touched || changed[userID][roleID]
+			}
+			if touched && len(s.roleUsers[roleID]) > rule.Limit {
+				userIDs := make([]int64, 0, len(s.roleUsers[roleID]))
+				for userID := range s.roleUsers[roleID] {
+					userIDs = append(userIDs, userID)
+				}
+				violations = append(violations, newViolation(userIDs, []int64{roleID}))
+			}
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].RoleIDs[0] != violations[j].RoleIDs[0] {
+			return violations[i].RoleIDs[0] < violations[j].RoleIDs[0]
+		}
+		return violations[i].UserIDs[0] < violations[j].UserIDs[0]
+	})
+	return violations
+}
@@ -0,0 +1,105 @@
+package authz
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSoDAuthz(t *testing.T, rules ...SoDRule) *Authz {
+	a := newTestAuthz(t)
+	a.sodRules = newSnapshot(0, func() (*sodTable, error) {
+		return newSoDTable(rules), nil
+	})
+	return a
+}
+
+func TestSoD_ExclusiveRoles(t *testing.T) {
+	a := newTestSoDAuthz(t, SoDRule{ID: 1, TenantID: 1, Name: "payment", Kind: SoDKindExclusive, RoleIDs: []int64{2, 3}})
+	require.NoError(t, a.AddRoleIDForUser(10, 2, 1))
+
+	// 所有写入用户角色的途径都会被拒绝
+	var violation *SoDViolation
+	err := a.AddRoleIDForUser(10, 3, 1)
+	require.True(t, errors.As(err, &violation))
+	assert.Equal(t, []int64{10}, violation.UserIDs)
+	assert.Equal(t, []int64{2, 3}, violation.RoleIDs)
+	_, err = a.AddGroupingPolicies([][]string{{"u10", "r3", "t1"}})
+	assert.ErrorAs(t, err, &violation)
+	_, err = a.AddRoleForUserInDomain("u10", "r3", "t1")
+	assert.ErrorAs(t, err, &violation)
+	_, err = a.UpdateGroupingPolicy([]string{"u10", "r9", "t1"}, []string{"u10", "r3", "t1"})
+	assert.ErrorAs(t, err, &violation)
+	assert.ErrorAs(t, a.SetRolesForUser(10, 1, []int64{2, 3}, nil), &violation)
+
+	// 替换角色时旧角色不计入，失败时保留原有角色
+	require.NoError(t, a.SetRolesForUser(10, 1, []int64{3}, nil))
+	roles, err := a.GetRoleIDsForUser(10, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, roles)
+
+	// 其他租户不受约束
+	require.NoError(t, a.AddRoleIDForUser(10, 2, 2))
+}
+
+func TestSoD_InheritedRoles(t *testing.T) {
+	a := newTestSoDAuthz(t, SoDRule{ID: 1, TenantID: 1, Name: "payment", Kind: SoDKindExclusive, RoleIDs: []int64{2, 3}})
+	require.NoError(t, a.SetParentRoles(4, 1, []int64{3}))
+	require.NoError(t, a.AddRoleIDForUser(10, 2, 1))
+
+	// 经由继承拥有互斥角色时拒绝分配
+	var violation *SoDViolation
+	require.ErrorAs(t, a.AddRoleIDForUser(10, 4, 1), &violation)
+	assert.Equal(t, []int64{2, 3}, violation.RoleIDs)
+
+	// 修改继承关系时重新检查拥有该角色及其子角色的用户
+	require.NoError(t, a.SetParentRoles(6, 1, []int64{5}))
+	require.NoError(t, a.AddRoleIDForUser(11, 6, 1))
+	require.NoError(t, a.AddRoleIDForUser(11, 2, 1))
+	assert.ErrorAs(t, a.SetParentRoles(5, 1, []int64{3}), &violation)
+	parents, err := a.GetParentRoles(5, 1)
+	require.NoError(t, err)
+	assert.Empty(t, parents)
+	_, err = a.AddGroupingPolicy("r5", "r3", "t1")
+	assert.ErrorAs(t, err, &violation)
+
+	// 没有用户受影响的继承关系可以设置
+	require.NoError(t, a.SetParentRoles(7, 1, []int64{2, 3}))
+}
+
+func TestSoD_Cardinality(t *testing.T) {
+	a := newTestSoDAuthz(t, SoDRule{ID: 1, TenantID: 1, Name: "auditor", Kind: SoDKindCardinality, RoleIDs: []int64{5}, Limit: 2})
+	require.NoError(t, a.AddRoleIDForUser(10, 5, 1))
+	require.NoError(t, a.AddRoleIDForUser(11, 5, 1))
+	require.NoError(t, a.AddRoleIDForUser(11, 5, 1))
+
+	var violation *SoDViolation
+	assert.ErrorAs(t, a.AddRoleIDForUser(12, 5, 1), &violation)
+}
+
+func TestSoD_Violations(t *testing.T) {
+	a := newTestSoDAuthz(t)
+	require.NoError(t, a.AddRoleIDForUser(10, 2, 1))
+	require.NoError(t, a.AddRoleIDForUser(10, 3, 1))
+	require.NoError(t, a.AddRoleIDForUser(11, 2, 1))
+
+	// 规则创建前已存在的分配只出现在报告中
+	a.sodRules = newSnapshot(0, func() (*sodTable, error) {
+		return newSoDTable([]SoDRule{
+			{ID: 1, TenantID: 1, Name: "payment", Kind: SoDKindExclusive, RoleIDs: []int64{2, 3}},
+			{ID: 2, TenantID: 1, Name: "creator", Kind: SoDKindCardinality, RoleIDs: []int64{2}, Limit: 1},
+		}), nil
+	})
+	violations, err := a.SoDViolations(1)
+	require.NoError(t, err)
+	require.Len(t, violations, 2)
+	assert.Equal(t, int64(1), violations[0].RuleID)
+	assert.Equal(t, []int64{10}, violations[0].UserIDs)
+	assert.Equal(t, int64(2), violations[1].RuleID)
+	assert.Equal(t, []int64{10, 11}, violations[1].UserIDs)
+
+	// 已存在的违规不阻止无关的分配
+	require.NoError(t, a.AddRoleIDForUser(10, 4, 1))
+}
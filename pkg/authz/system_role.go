@@ -0,0 +1,76 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"gorm.io/gorm"
+)
+
+// 租户内置的系统角色，通过 roles.system_role 字段标记
+const (
+	SystemRoleTenantOwner = "tenant_owner" // 租户所有者，拥有租户内的全部权限
+	SystemRoleTenantAdmin = "tenant_admin" // 租户管理员，自动获得管理类权限
+)
+
+// systemRoleTable 保存租户所有者角色，键为角色ID，值为角色所属的租户ID
+type systemRoleTable struct {
+	owners map[int64]int64
+}
+
+// loadSystemRoleTable 从数据库加载租户所有者角色
+func loadSystemRoleTable(db *gorm.DB) (*systemRoleTable, error) {
+	var roles []struct {
+		ID       int64 `gorm:"column:id"`
+		TenantID int64 `gorm:"column:tenant_id"`
+	}
+	err := db.Table("roles").
+		Select("id, tenant_id").
+		Where("system_role = ? AND deleted_at IS NULL", SystemRoleTenantOwner).
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	table := &systemRoleTable{owners: make(map[int64]int64, len(roles))}
+	for _, r := range roles {
+		table.owners[r.ID] = r.TenantID
+	}
+	return table, nil
+}
+
+// isSuperAdmin 检查用户是否直接拥有租户的所有者角色.
+// 角色必须属于该租户，其他租户的所有者角色不生效.
+func (a *Authz) isSuperAdmin(userID, tenantIdentifier string) (bool, error) {
+	if a.systemRoles == nil {
+		return false, nil
+	}
+	table, err := a.systemRoles.get()
+	if err != nil {
+		return false, err
+	}
+
+	domain := a.resolveTenantDomain(tenantIdentifier)
+	tenantID := a.idConverter.ToDomainID(domain)
+	roles, err := a.SyncedCachedEnforcer.GetRolesForUser(a.idConverter.ToDUserID(a.parseStringToInt64(userID)), domain)
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		if owner, ok := table.owners[a.idConverter.ToRoleID(role)]; ok && owner == tenantID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// InvalidateSystemRoles 在系统角色变更后使缓存和授权决策缓存失效
+func (a *Authz) InvalidateSystemRoles() {
+	if a.systemRoles != nil {
+		a.systemRoles.invalidate()
+	}
+	a.decisionCache.purge()
+}
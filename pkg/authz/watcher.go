@@ -0,0 +1,197 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// DefaultPolicyChannel 是广播策略变更的默认 Redis 频道
+	DefaultPolicyChannel = "one-auth:casbin:policy"
+	// DefaultReconcileInterval 是启用 Watcher 后全量重新加载策略的默认间隔
+	DefaultReconcileInterval = 5 * time.Minute
+
+	// publishTimeout 是发布一条策略变更的超时时间
+	publishTimeout = 3 * time.Second
+)
+
+// 策略变更操作类型
+const (
+	PolicyOpAdd            = "add"             // 添加规则
+	PolicyOpRemove         = "remove"          // 删除规则
+	PolicyOpRemoveFiltered = "remove_filtered" // 按字段删除规则
+	PolicyOpUpdate         = "update"          // 修改规则
+	PolicyOpReload         = "reload"          // 从数据库全量重新加载
+)
+
+// PolicyUpdate 是实例之间广播的一条策略变更.
+type PolicyUpdate struct {
+	Origin      string     `json:"origin"`                 // 发布变更的实例ID
+	Op          string     `json:"op"`                     // 操作类型
+	Sec         string     `json:"sec,omitempty"`          // 规则分类：p 或 g
+	Ptype       string     `json:"ptype,omitempty"`        // 规则类型
+	Rules       [][]string `json:"rules,omitempty"`        // 添加、删除的规则，修改时为旧规则
+	NewRules    [][]string `json:"new_rules,omitempty"`    // 修改后的规则
+	FieldIndex  int        `json:"field_index,omitempty"`  // 按字段删除的起始字段
+	FieldValues []string   `json:"field_values,omitempty"` // 按字段删除的字段值
+}
+
+// policyWatcher 将 Casbin 的增量变更编码为 PolicyUpdate 发布出去，并把其他实例的变更交给回调处理.
+// 消息的发布和接收由具体的传输实现负责.
+type policyWatcher struct {
+	id       string
+	publish  func(payload []byte) error
+	mu       sync.RWMutex
+	callback func(string)
+}
+
+// 确保 policyWatcher 实现了 Casbin 的增量 Watcher 接口.
+var (
+	_ persist.WatcherEx        = (*policyWatcher)(nil)
+	_ persist.UpdatableWatcher = (*policyWatcher)(nil)
+)
+
+// newPolicyWatcher 创建一个使用 publish 发布变更的 policyWatcher
+func newPolicyWatcher(publish func(payload []byte) error) *policyWatcher {
+	return &policyWatcher{id: uuid.NewString(), publish: publish}
+}
+
+// SetUpdateCallback 设置收到其他实例的策略变更时的回调函数.
+func (w *policyWatcher) SetUpdateCallback(callback func(string)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callback = callback
+	return nil
+}
+
+// Update 通知其他实例从数据库全量重新加载策略.
+func (w *policyWatcher) Update() error {
+	return w.send(&PolicyUpdate{Op: PolicyOpReload})
+}
+
+// Close 停止 Watcher.
+func (w *policyWatcher) Close() {}
+
+// UpdateForAddPolicy 广播添加一条规则.
+func (w *policyWatcher) UpdateForAddPolicy(sec, ptype string, params ...string) error {
+	return w.send(&PolicyUpdate{Op: PolicyOpAdd, Sec: sec, Ptype: ptype, Rules: [][]string{params}})
+}
+
+// UpdateForRemovePolicy 广播删除一条规则.
+func (w *policyWatcher) UpdateForRemovePolicy(sec, ptype string, params ...string) error {
+	return w.send(&PolicyUpdate{Op: PolicyOpRemove, Sec: sec, Ptype: ptype, Rules: [][]string{params}})
+}
+
+// UpdateForRemoveFilteredPolicy 广播按字段删除规则.
+func (w *policyWatcher) UpdateForRemoveFilteredPolicy(sec, ptype string, fieldIndex int, fieldValues ...string) error {
+	return w.send(&PolicyUpdate{Op: PolicyOpRemoveFiltered, Sec: sec, Ptype: ptype, FieldIndex: fieldIndex, FieldValues: fieldValues})
+}
+
+// UpdateForSavePolicy 保存全部策略后通知其他实例全量重新加载.
+func (w *policyWatcher) UpdateForSavePolicy(model.Model) error {
+	return w.Update()
+}
+
+// UpdateForAddPolicies 广播批量添加规则.
+func (w *policyWatcher) UpdateForAddPolicies(sec, ptype string, rules ...[]string) error {
+	return w.send(&PolicyUpdate{Op: PolicyOpAdd, Sec: sec, Ptype: ptype, Rules: rules})
+}
+
+// UpdateForRemovePolicies 广播批量删除规则.
+func (w *policyWatcher) UpdateForRemovePolicies(sec, ptype string, rules ...[]string) error {
+	return w.send(&PolicyUpdate{Op: PolicyOpRemove, Sec: sec, Ptype: ptype, Rules: rules})
+}
+
+// UpdateForUpdatePolicy 广播修改一条规则.
+func (w *policyWatcher) UpdateForUpdatePolicy(sec, ptype string, oldRule, newRule []string) error {
+	return w.send(&PolicyUpdate{Op: PolicyOpUpdate, Sec: sec, Ptype: ptype, Rules: [][]string{oldRule}, NewRules: [][]string{newRule}})
+}
+
+// UpdateForUpdatePolicies 广播批量修改规则.
+func (w *policyWatcher) UpdateForUpdatePolicies(sec, ptype string, oldRules, newRules [][]string) error {
+	return w.send(&PolicyUpdate{Op: PolicyOpUpdate, Sec: sec, Ptype: ptype, Rules: oldRules, NewRules: newRules})
+}
+
+// send 编码并发布一条策略变更
+func (w *policyWatcher) send(update *PolicyUpdate) error {
+	update.Origin = w.id
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+	return w.publish(payload)
+}
+
+// receive 处理收到的一条消息，忽略本实例发布的变更
+func (w *policyWatcher) receive(payload string) {
+	var update PolicyUpdate
+	if err := json.Unmarshal([]byte(payload), &update); err == nil && update.Origin == w.id {
+		return
+	}
+
+	w.mu.RLock()
+	callback := w.callback
+	w.mu.RUnlock()
+	if callback != nil {
+		callback(payload)
+	}
+}
+
+// RedisWatcher 通过 Redis 发布订阅在多个实例之间同步 Casbin 策略的增量变更.
+// 订阅断开期间的变更会丢失，需要配合定期全量加载兜底.
+type RedisWatcher struct {
+	*policyWatcher
+	pubsub *redis.PubSub
+	once   sync.Once
+}
+
+// NewRedisWatcher 创建 Redis Watcher 并订阅指定频道，channel 为空时使用默认频道.
+func NewRedisWatcher(client redis.UniversalClient, channel string) (*RedisWatcher, error) {
+	if channel == "" {
+		channel = DefaultPolicyChannel
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	// 等待订阅确认，避免启动后立即发生的变更丢失
+	pubsub := client.Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	w := &RedisWatcher{pubsub: pubsub}
+	w.policyWatcher = newPolicyWatcher(func(payload []byte) error {
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		defer cancel()
+		return client.Publish(ctx, channel, payload).Err()
+	})
+
+	go func() {
+		for msg := range pubsub.Channel() {
+			w.receive(msg.Payload)
+		}
+	}()
+	return w, nil
+}
+
+// Close 取消订阅，停止接收其他实例的策略变更.
+func (w *RedisWatcher) Close() {
+	w.once.Do(func() {
+		_ = w.pubsub.Close()
+	})
+}
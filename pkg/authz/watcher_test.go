@@ -0,0 +1,138 @@
+package authz
+
+import (
+	"testing"
+
+	casbin "github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingAdapter 记录写入数据库的规则
+type recordingAdapter struct {
+	writes int
+}
+
+func (r *recordingAdapter) LoadPolicy(model.Model) error { return nil }
+func (r *recordingAdapter) SavePolicy(model.Model) error { return nil }
+func (r *recordingAdapter) AddPolicy(string, string, []string) error {
+	r.writes++
+	return nil
+}
+func (r *recordingAdapter) RemovePolicy(string, string, []string) error {
+	r.writes++
+	return nil
+}
+func (r *recordingAdapter) RemoveFilteredPolicy(string, string, int, ...string) error {
+	r.writes++
+	return nil
+}
+
+// newWatchedAuthz 创建使用 Watcher 的授权器，publish 模拟发布订阅的广播
+func newWatchedAuthz(t *testing.T, publish func([]byte) error) (*Authz, *policyWatcher, *recordingAdapter) {
+	m, err := model.NewModelFromString(defaultRBACWithDomainsModel)
+	require.NoError(t, err)
+	adapter := &recordingAdapter{}
+	enforcer, err := casbin.NewSyncedCachedEnforcer(m, adapter)
+	require.NoError(t, err)
+
+	a := &Authz{SyncedCachedEnforcer: enforcer, idConverter: NewIDConverter()}
+	w := newPolicyWatcher(publish)
+	require.NoError(t, a.setWatcher(w))
+	return a, w, adapter
+}
+
+func TestWatcher_PropagatesIncrementalChanges(t *testing.T) {
+	var watchers []*policyWatcher
+	var published int
+	broadcast := func(payload []byte) error {
+		published++
+		for _, w := range watchers {
+			w.receive(string(payload))
+		}
+		return nil
+	}
+	a, wa, adapterA := newWatchedAuthz(t, broadcast)
+	b, wb, adapterB := newWatchedAuthz(t, broadcast)
+	watchers = append(watchers, wa, wb)
+	var systemRoleLoads int
+	b.systemRoles = newSnapshot(0, func() (*systemRoleTable, error) {
+		systemRoleLoads++
+		return &systemRoleTable{}, nil
+	})
+	_, err := b.systemRoles.get()
+	require.NoError(t, err)
+
+	_, err = a.AddGroupingPolicy("u10", "r2", "t1")
+	require.NoError(t, err)
+	_, err = a.AddPermissionForRole(2, 30, 1, EffectAllow)
+	require.NoError(t, err)
+	assert.NotZero(t, published)
+	assert.NotZero(t, adapterA.writes)
+	assert.Zero(t, adapterB.writes, "applied changes must not be written again")
+
+	// 增量变更与全量加载一样使由策略派生的缓存失效
+	_, err = b.systemRoles.get()
+	require.NoError(t, err)
+	assert.Equal(t, 2, systemRoleLoads)
+
+	// 缓存授权结果后撤销权限
+	d, err := b.DecidePermission("10", 1, 30, nil)
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+	ok, err := b.Enforce("u10", "p30", "t1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = a.DeletePermissionForRole(2, 30, 1)
+	require.NoError(t, err)
+	ok, err = b.Enforce("u10", "p30", "t1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// 按字段删除
+	_, err = a.AddPermissionForRole(2, 31, 1, EffectDeny)
+	require.NoError(t, err)
+	has, err := b.HasPolicy("r2", "p31", "t1", EffectDeny)
+	require.NoError(t, err)
+	assert.True(t, has)
+	_, err = a.DeletePermissionPolicies(31, 1)
+	require.NoError(t, err)
+	has, err = b.HasPolicy("r2", "p31", "t1", EffectDeny)
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// 角色继承变更
+	_, err = a.RemoveGroupingPolicy("u10", "r2", "t1")
+	require.NoError(t, err)
+	assert.Empty(t, b.GetRolesForUserInDomain("u10", "t1"))
+
+	// 修改规则
+	_, err = a.AddPermissionForRole(5, 40, 1, EffectAllow)
+	require.NoError(t, err)
+	require.NoError(t, wa.UpdateForUpdatePolicy("p", "p", []string{"r5", "p40", "t1", EffectAllow}, []string{"r5", "p40", "t1", EffectDeny}))
+	has, err = b.HasPolicy("r5", "p40", "t1", EffectDeny)
+	require.NoError(t, err)
+	assert.True(t, has)
+	has, err = b.HasPolicy("r5", "p40", "t1", EffectAllow)
+	require.NoError(t, err)
+	assert.False(t, has)
+	assert.Zero(t, adapterB.writes)
+}
+
+func TestWatcher_IgnoresOwnChanges(t *testing.T) {
+	var w *policyWatcher
+	var calls int
+	w = newPolicyWatcher(func(payload []byte) error {
+		w.receive(string(payload))
+		return nil
+	})
+	require.NoError(t, w.SetUpdateCallback(func(string) { calls++ }))
+
+	require.NoError(t, w.UpdateForAddPolicy("p", "p", "r1", "p1", "t1", EffectAllow))
+	assert.Zero(t, calls)
+
+	w.receive(`{"origin":"other","op":"add","sec":"p","ptype":"p","rules":[["r1","p1","t1","allow"]]}`)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,338 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package ldap provides an LDAP / Active Directory authenticator.
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+var (
+	// ErrInvalidCredentials 用户名或密码错误
+	ErrInvalidCredentials = errors.New("ldap: invalid credentials")
+	// ErrUserNotFound 目录中未找到用户
+	ErrUserNotFound = errors.New("ldap: user not found")
+	// ErrMultipleUsers 用户过滤器匹配到多个条目
+	ErrMultipleUsers = errors.New("ldap: filter matched multiple users")
+	// ErrClientClosed 客户端已关闭
+	ErrClientClosed = errors.New("ldap: client closed")
+)
+
+// Config LDAP 连接和查询配置
+type Config struct {
+	URL                string        // 服务地址，例如 ldap://ldap.example.com:389、ldaps://ad.example.com:636
+	StartTLS           bool          // 是否在 ldap:// 连接上使用 StartTLS
+	InsecureSkipVerify bool          // 是否跳过服务端证书校验（仅用于测试）
+	CACert             string        // PEM 格式的 CA 证书，为空时使用系统证书
+	BindDN             string        // 服务账号 DN
+	BindPassword       string        // 服务账号密码
+	BaseDN             string        // 用户搜索的根 DN
+	UserFilter         string        // 用户过滤器，%s 为转义后的用户名，例如 (&(objectClass=person)(uid=%s))
+	UsernameAttr       string        // 用户名属性，默认 uid，AD 一般为 sAMAccountName
+	EmailAttr          string        // 邮箱属性，默认 mail
+	DisplayNameAttr    string        // 显示名属性，默认 displayName
+	PhoneAttr          string        // 手机号属性，默认 mobile
+	GroupAttr          string        // 用户条目上的组属性，例如 memberOf
+	GroupBaseDN        string        // 组搜索的根 DN，为空时不搜索组
+	GroupFilter        string        // 组过滤器，%s 为转义后的用户 DN，例如 (member=%s)
+	PoolSize           int           // 连接池大小
+	Timeout            time.Duration // 连接和请求超时时间
+}
+
+// Entry 认证通过的目录用户
+type Entry struct {
+	DN          string
+	Username    string
+	Email       string
+	DisplayName string
+	Phone       string
+	Groups      []string // 用户所属组的 DN
+}
+
+// Client LDAP 认证客户端，复用以服务账号绑定的连接.
+type Client struct {
+	cfg       Config
+	tlsConfig *tls.Config
+
+	mu     sync.Mutex
+	pool   chan *goldap.Conn
+	closed bool
+}
+
+// NewClient 创建 LDAP 认证客户端.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("ldap: url is required")
+	}
+	if cfg.BaseDN == "" {
+		return nil, errors.New("ldap: base dn is required")
+	}
+	if cfg.UserFilter == "" {
+		cfg.UserFilter = "(uid=%s)"
+	}
+	if strings.Count(cfg.UserFilter, "%s") != 1 {
+		return nil, errors.New("ldap: user filter must contain exactly one %s")
+	}
+	if cfg.GroupBaseDN != "" && strings.Count(cfg.GroupFilter, "%s") != 1 {
+		return nil, errors.New("ldap: group filter must contain exactly one %s")
+	}
+	if cfg.UsernameAttr == "" {
+		cfg.UsernameAttr = "uid"
+	}
+	if cfg.EmailAttr == "" {
+		cfg.EmailAttr = "mail"
+	}
+	if cfg.DisplayNameAttr == "" {
+		cfg.DisplayNameAttr = "displayName"
+	}
+	if cfg.PhoneAttr == "" {
+		cfg.PhoneAttr = "mobile"
+	}
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = 4
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify} // nolint: gosec
+	if cfg.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cfg.CACert)) {
+			return nil, errors.New("ldap: invalid ca certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &Client{
+		cfg:       cfg,
+		tlsConfig: tlsConfig,
+		pool:      make(chan *goldap.Conn, cfg.PoolSize),
+	}, nil
+}
+
+// Authenticate 使用服务账号搜索用户，再以用户身份绑定校验密码.
+func (c *Client) Authenticate(ctx context.Context, username, password string) (*Entry, error) {
+	// 空密码会被服务端当作匿名绑定，必须拒绝
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	conn, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := c.authenticate(conn, username, password)
+	if err != nil && !errors.Is(err, ErrInvalidCredentials) && !errors.Is(err, ErrUserNotFound) && !errors.Is(err, ErrMultipleUsers) {
+		// 网络或协议错误时丢弃连接
+		_ = conn.Close()
+		return nil, err
+	}
+
+	c.put(conn)
+	return entry, err
+}
+
+func (c *Client) authenticate(conn *goldap.Conn, username, password string) (*Entry, error) {
+	attrs := []string{c.cfg.UsernameAttr, c.cfg.EmailAttr, c.cfg.DisplayNameAttr, c.cfg.PhoneAttr}
+	if c.cfg.GroupAttr != "" {
+		attrs = append(attrs, c.cfg.GroupAttr)
+	}
+
+	result, err := conn.Search(goldap.NewSearchRequest(
+		c.cfg.BaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 2, int(c.cfg.Timeout.Seconds()), false,
+		fmt.Sprintf(c.cfg.UserFilter, goldap.EscapeFilter(username)), attrs, nil,
+	))
+	if goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		return nil, ErrMultipleUsers
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ldap: search user: %w", err)
+	}
+	switch len(result.Entries) {
+	case 0:
+		return nil, ErrUserNotFound
+	case 1:
+	default:
+		return nil, ErrMultipleUsers
+	}
+
+	found := result.Entries[0]
+	entry := &Entry{
+		DN:          found.DN,
+		Username:    found.GetAttributeValue(c.cfg.UsernameAttr),
+		Email:       found.GetAttributeValue(c.cfg.EmailAttr),
+		DisplayName: found.GetAttributeValue(c.cfg.DisplayNameAttr),
+		Phone:       found.GetAttributeValue(c.cfg.PhoneAttr),
+	}
+	if entry.Username == "" {
+		entry.Username = username
+	}
+	if c.cfg.GroupAttr != "" {
+		entry.Groups = append(entry.Groups, found.GetAttributeValues(c.cfg.GroupAttr)...)
+	}
+
+	// 以用户身份绑定校验密码，之后无论成功与否都要恢复服务账号身份
+	bindErr := conn.Bind(entry.DN, password)
+	if err := c.bindService(conn); err != nil {
+		return nil, err
+	}
+	if bindErr != nil {
+		if goldap.IsErrorWithCode(bindErr, goldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("ldap: bind user: %w", bindErr)
+	}
+
+	if c.cfg.GroupBaseDN != "" {
+		groups, err := c.searchGroups(conn, entry.DN)
+		if err != nil {
+			return nil, err
+		}
+		entry.Groups = append(entry.Groups, groups...)
+	}
+
+	return entry, nil
+}
+
+// searchGroups 按组过滤器查找用户所属的组.
+func (c *Client) searchGroups(conn *goldap.Conn, userDN string) ([]string, error) {
+	result, err := conn.Search(goldap.NewSearchRequest(
+		c.cfg.GroupBaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, int(c.cfg.Timeout.Seconds()), false,
+		fmt.Sprintf(c.cfg.GroupFilter, goldap.EscapeFilter(userDN)), []string{"dn"}, nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("ldap: search groups: %w", err)
+	}
+
+	groups := make([]string, 0, len(result.Entries))
+	for _, e := range result.Entries {
+		groups = append(groups, e.DN)
+	}
+	return groups, nil
+}
+
+// get 从连接池取出连接，池为空时新建连接.
+func (c *Client) get() (*goldap.Conn, error) {
+	c.mu.Lock()
+	closed := c.closed
+	c.mu.Unlock()
+	if closed {
+		return nil, ErrClientClosed
+	}
+
+	for {
+		select {
+		case conn := <-c.pool:
+			if !conn.IsClosing() {
+				return conn, nil
+			}
+		default:
+			return c.dial()
+		}
+	}
+}
+
+// put 将连接放回连接池，池已满或客户端已关闭时关闭连接.
+func (c *Client) put(conn *goldap.Conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed || conn.IsClosing() {
+		_ = conn.Close()
+		return
+	}
+	select {
+	case c.pool <- conn:
+	default:
+		_ = conn.Close()
+	}
+}
+
+// dial 建立新连接并以服务账号绑定.
+func (c *Client) dial() (*goldap.Conn, error) {
+	conn, err := goldap.DialURL(c.cfg.URL,
+		goldap.DialWithDialer(&net.Dialer{Timeout: c.cfg.Timeout}),
+		goldap.DialWithTLSConfig(c.tlsConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: dial: %w", err)
+	}
+	conn.SetTimeout(c.cfg.Timeout)
+
+	if c.cfg.StartTLS {
+		if err := conn.StartTLS(c.tlsConfig); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("ldap: start tls: %w", err)
+		}
+	}
+
+	if err := c.bindService(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// bindService 以服务账号绑定，未配置服务账号时使用匿名绑定.
+func (c *Client) bindService(conn *goldap.Conn) error {
+	var err error
+	if c.cfg.BindDN == "" {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(c.cfg.BindDN, c.cfg.BindPassword)
+	}
+	if err != nil {
+		return fmt.Errorf("ldap: bind service account: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭客户端及连接池中的所有连接.
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+	for {
+		select {
+		case conn := <-c.pool:
+			_ = conn.Close()
+		default:
+			return
+		}
+	}
+}
+
+// GroupMatches 判断组 DN 是否与映射键匹配，映射键可以是完整 DN 或组的 CN.
+func GroupMatches(groupDN, key string) bool {
+	if strings.EqualFold(groupDN, key) {
+		return true
+	}
+	dn, err := goldap.ParseDN(groupDN)
+	if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+		return false
+	}
+	return strings.EqualFold(dn.RDNs[0].Attributes[0].Value, key)
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ashwinyue/one-auth/pkg/client/ldap/ldaptest"
+)
+
+// TestAuthenticate 测试基于进程内 LDAP 服务的认证流程
+func TestAuthenticate(t *testing.T) {
+	srv, err := ldaptest.NewServer(
+		&ldaptest.Entry{DN: "cn=svc,dc=example,dc=com", Attributes: map[string][]string{"userPassword": {"svc-secret"}}},
+		&ldaptest.Entry{DN: "uid=alice,ou=people,dc=example,dc=com", Attributes: map[string][]string{
+			"objectClass":  {"person"},
+			"uid":          {"alice"},
+			"mail":         {"alice@example.com"},
+			"displayName":  {"Alice"},
+			"memberOf":     {"cn=admins,ou=groups,dc=example,dc=com"},
+			"userPassword": {"alice-secret"},
+		}},
+		&ldaptest.Entry{DN: "cn=devs,ou=groups,dc=example,dc=com", Attributes: map[string][]string{
+			"member": {"uid=alice,ou=people,dc=example,dc=com"},
+		}},
+	)
+	require.NoError(t, err)
+	defer srv.Close()
+
+	c, err := NewClient(Config{
+		URL:          srv.URL(),
+		BindDN:       "cn=svc,dc=example,dc=com",
+		BindPassword: "svc-secret",
+		BaseDN:       "ou=people,dc=example,dc=com",
+		UserFilter:   "(&(objectClass=person)(uid=%s))",
+		GroupAttr:    "memberOf",
+		GroupBaseDN:  "ou=groups,dc=example,dc=com",
+		GroupFilter:  "(member=%s)",
+		PoolSize:     1,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	ctx := context.Background()
+
+	entry, err := c.Authenticate(ctx, "alice", "alice-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "uid=alice,ou=people,dc=example,dc=com", entry.DN)
+	assert.Equal(t, "alice", entry.Username)
+	assert.Equal(t, "alice@example.com", entry.Email)
+	assert.Equal(t, "Alice", entry.DisplayName)
+	assert.ElementsMatch(t, []string{"cn=admins,ou=groups,dc=example,dc=com", "cn=devs,ou=groups,dc=example,dc=com"}, entry.Groups)
+
+	_, err = c.Authenticate(ctx, "alice", "wrong")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = c.Authenticate(ctx, "alice", "")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = c.Authenticate(ctx, "bob", "secret")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	// 过滤器注入被转义
+	_, err = c.Authenticate(ctx, "*", "alice-secret")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	// 连接池复用同一条连接
+	_, err = c.Authenticate(ctx, "alice", "alice-secret")
+	require.NoError(t, err)
+
+	assert.True(t, GroupMatches("cn=admins,ou=groups,dc=example,dc=com", "Admins"))
+	assert.True(t, GroupMatches("cn=admins,ou=groups,dc=example,dc=com", "CN=admins,ou=groups,dc=example,dc=com"))
+	assert.False(t, GroupMatches("cn=admins,ou=groups,dc=example,dc=com", "devs"))
+}
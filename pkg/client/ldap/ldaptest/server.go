@@ -0,0 +1,419 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package ldaptest provides an in-process LDAP server for tests.
+// It supports simple bind, search with and/or/not/equality/substring/present
+// filters, and unbind, which is enough to exercise the ldap client.
+package ldaptest
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+)
+
+// LDAP 协议操作标签
+const (
+	appBindRequest       ber.Tag = 0
+	appBindResponse      ber.Tag = 1
+	appUnbindRequest     ber.Tag = 2
+	appSearchRequest     ber.Tag = 3
+	appSearchResultEntry ber.Tag = 4
+	appSearchResultDone  ber.Tag = 5
+	appExtendedRequest   ber.Tag = 23
+	appExtendedResponse  ber.Tag = 24
+)
+
+// LDAP 结果码
+const (
+	resultSuccess            = 0
+	resultProtocolError      = 2
+	resultSizeLimitExceeded  = 4
+	resultNoSuchObject       = 32
+	resultInvalidCredentials = 49
+	resultInsufficientAccess = 50
+	resultUnwillingToPerform = 53
+)
+
+const (
+	passwordAttr           = "userpassword"
+	scopeBaseObject  int64 = 0
+	scopeSingleLevel int64 = 1
+)
+
+// Entry 目录条目，属性名不区分大小写，userPassword 属性用于绑定校验.
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Server 进程内 LDAP 服务.
+type Server struct {
+	listener net.Listener
+
+	mu      sync.RWMutex
+	entries []*Entry
+	conns   map[net.Conn]struct{}
+	binds   int
+	wg      sync.WaitGroup
+}
+
+// NewServer 在 127.0.0.1 的随机端口上启动 LDAP 服务.
+func NewServer(entries ...*Entry) (*Server, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{listener: l, conns: make(map[net.Conn]struct{})}
+	for _, e := range entries {
+		s.Add(e)
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// URL 返回服务地址.
+func (s *Server) URL() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+// Add 添加或替换目录条目.
+func (s *Server) Add(entry *Entry) {
+	attrs := make(map[string][]string, len(entry.Attributes))
+	for k, v := range entry.Attributes {
+		attrs[strings.ToLower(k)] = v
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.entries {
+		if strings.EqualFold(e.DN, entry.DN) {
+			s.entries[i] = &Entry{DN: entry.DN, Attributes: attrs}
+			return
+		}
+	}
+	s.entries = append(s.entries, &Entry{DN: entry.DN, Attributes: attrs})
+}
+
+// Binds 返回成功绑定的次数.
+func (s *Server) Binds() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.binds
+}
+
+// Close 关闭服务并断开所有连接.
+func (s *Server) Close() {
+	_ = s.listener.Close()
+
+	s.mu.Lock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	var conns sync.WaitGroup
+	defer conns.Wait()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		conns.Add(1)
+		go func() {
+			defer conns.Done()
+			s.handle(conn)
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	bound := false
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		id, _ := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		var responses []*ber.Packet
+		switch op.Tag {
+		case appBindRequest:
+			code := s.bind(op)
+			bound = code == resultSuccess
+			responses = append(responses, result(appBindResponse, code))
+		case appUnbindRequest:
+			return
+		case appSearchRequest:
+			if !bound {
+				responses = append(responses, result(appSearchResultDone, resultInsufficientAccess))
+				break
+			}
+			responses = append(responses, s.search(op)...)
+		case appExtendedRequest:
+			responses = append(responses, result(appExtendedResponse, resultProtocolError))
+		default:
+			return
+		}
+
+		for _, r := range responses {
+			if _, err := conn.Write(envelope(id, r).Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// bind 处理简单绑定，拒绝匿名绑定和空密码绑定.
+func (s *Server) bind(op *ber.Packet) int {
+	if len(op.Children) < 3 {
+		return resultProtocolError
+	}
+	dn := str(op.Children[1])
+	password := str(op.Children[2])
+	if dn == "" || password == "" {
+		return resultUnwillingToPerform
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.entries {
+		if !strings.EqualFold(e.DN, dn) {
+			continue
+		}
+		for _, p := range e.Attributes[passwordAttr] {
+			if p == password {
+				s.binds++
+				return resultSuccess
+			}
+		}
+	}
+	return resultInvalidCredentials
+}
+
+// search 处理搜索请求.
+func (s *Server) search(op *ber.Packet) []*ber.Packet {
+	if len(op.Children) < 8 {
+		return []*ber.Packet{result(appSearchResultDone, resultProtocolError)}
+	}
+	base := strings.ToLower(str(op.Children[0]))
+	scope, _ := op.Children[1].Value.(int64)
+	sizeLimit, _ := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+
+	var wanted []string
+	for _, a := range op.Children[7].Children {
+		wanted = append(wanted, str(a))
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []*ber.Packet
+	for _, e := range s.entries {
+		if !inScope(strings.ToLower(e.DN), base, scope) || !match(filter, e) {
+			continue
+		}
+		if sizeLimit > 0 && int64(len(out)) >= sizeLimit {
+			return append(out, result(appSearchResultDone, resultSizeLimitExceeded))
+		}
+		out = append(out, entryPacket(e, wanted))
+	}
+
+	if len(out) == 0 && !s.exists(base) {
+		return []*ber.Packet{result(appSearchResultDone, resultNoSuchObject)}
+	}
+	return append(out, result(appSearchResultDone, resultSuccess))
+}
+
+func (s *Server) exists(dn string) bool {
+	for _, e := range s.entries {
+		if strings.HasSuffix(strings.ToLower(e.DN), dn) {
+			return true
+		}
+	}
+	return false
+}
+
+func inScope(dn, base string, scope int64) bool {
+	switch scope {
+	case scopeBaseObject:
+		return dn == base
+	case scopeSingleLevel:
+		i := strings.Index(dn, ",")
+		return i >= 0 && dn[i+1:] == base
+	default:
+		return dn == base || strings.HasSuffix(dn, ","+base)
+	}
+}
+
+// match 计算过滤器，支持 and(0)、or(1)、not(2)、equality(3)、substrings(4)、present(7).
+func match(f *ber.Packet, e *Entry) bool {
+	switch f.Tag {
+	case 0:
+		for _, c := range f.Children {
+			if !match(c, e) {
+				return false
+			}
+		}
+		return true
+	case 1:
+		for _, c := range f.Children {
+			if match(c, e) {
+				return true
+			}
+		}
+		return false
+	case 2:
+		return len(f.Children) == 1 && !match(f.Children[0], e)
+	case 3:
+		if len(f.Children) != 2 {
+			return false
+		}
+		want := str(f.Children[1])
+		for _, v := range values(e, str(f.Children[0])) {
+			if strings.EqualFold(v, want) {
+				return true
+			}
+		}
+		return false
+	case 4:
+		if len(f.Children) != 2 {
+			return false
+		}
+		for _, v := range values(e, str(f.Children[0])) {
+			if matchSubstrings(strings.ToLower(v), f.Children[1].Children) {
+				return true
+			}
+		}
+		return false
+	case 7:
+		return len(values(e, f.Data.String())) > 0
+	default:
+		return false
+	}
+}
+
+func matchSubstrings(v string, parts []*ber.Packet) bool {
+	for _, p := range parts {
+		s := strings.ToLower(str(p))
+		switch p.Tag {
+		case 0: // initial
+			if !strings.HasPrefix(v, s) {
+				return false
+			}
+			v = v[len(s):]
+		case 1: // any
+			i := strings.Index(v, s)
+			if i < 0 {
+				return false
+			}
+			v = v[i+len(s):]
+		case 2: // final
+			if !strings.HasSuffix(v, s) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func values(e *Entry, attr string) []string {
+	attr = strings.ToLower(attr)
+	if attr == "dn" || attr == "distinguishedname" {
+		return []string{e.DN}
+	}
+	return e.Attributes[attr]
+}
+
+func entryPacket(e *Entry, wanted []string) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, appSearchResultEntry, nil, "Search Result Entry")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, "Object Name"))
+
+	attrs := ber.NewSequence("Attributes")
+	for name, vals := range e.Attributes {
+		if name == passwordAttr || !wantedAttr(wanted, name) {
+			continue
+		}
+		attr := ber.NewSequence("Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, originalName(wanted, name), "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range vals {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	op.AppendChild(attrs)
+	return op
+}
+
+func wantedAttr(wanted []string, name string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	for _, w := range wanted {
+		if w == "*" || strings.EqualFold(w, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// originalName 返回请求中的属性名，客户端按请求的大小写读取属性.
+func originalName(wanted []string, name string) string {
+	for _, w := range wanted {
+		if strings.EqualFold(w, name) {
+			return w
+		}
+	}
+	return name
+}
+
+func result(tag ber.Tag, code int) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return op
+}
+
+func envelope(id int64, op *ber.Packet) *ber.Packet {
+	p := ber.NewSequence("LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+	p.AppendChild(op)
+	return p
+}
+
+func str(p *ber.Packet) string {
+	if p.Data == nil {
+		return ""
+	}
+	return p.Data.String()
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package pdp
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheEntry 是带过期时间的缓存条目
+type cacheEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// ttlCache 是有容量上限的本地缓存，每个条目使用各自的过期时间.
+type ttlCache[V any] struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry[V]
+}
+
+// newTTLCache 创建本地缓存，size 小于 0 时不缓存
+func newTTLCache[V any](size int) *ttlCache[V] {
+	return &ttlCache[V]{size: size, entries: make(map[string]cacheEntry[V])}
+}
+
+// get 返回未过期的缓存值
+func (c *ttlCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// set 缓存值，ttl 不大于 0 时不缓存
+func (c *ttlCache[V]) set(key string, value V, ttl time.Duration) {
+	if c.size < 0 || ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		c.evict()
+	}
+	c.entries[key] = cacheEntry[V]{value: value, expiresAt: time.Now().Add(ttl)}
+}
+
+// evict 清理过期条目，仍然已满时随机淘汰一个条目
+func (c *ttlCache[V]) evict() {
+	now := time.Now()
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	for key := range c.entries {
+		if len(c.entries) < c.size {
+			return
+		}
+		delete(c.entries, key)
+	}
+}
+
+// flush 清空缓存
+func (c *ttlCache[V]) flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]cacheEntry[V])
+}
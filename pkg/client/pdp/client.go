@@ -0,0 +1,263 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package pdp provides a Go client for the one-auth policy decision point.
+package pdp
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pdpv1 "github.com/ashwinyue/one-auth/pkg/api/pdp/v1"
+)
+
+// FailureMode 决定策略决策点不可用时 Check 返回的结果
+type FailureMode int
+
+const (
+	// FailClosed 策略决策点不可用时拒绝访问（默认）
+	FailClosed FailureMode = iota
+	// FailOpen 策略决策点不可用时允许访问
+	FailOpen
+)
+
+// ReasonUnavailable 表示结果由失败模式决定，而不是策略决策点返回
+const ReasonUnavailable = "pdp_unavailable"
+
+// maxBatchSize 是服务端一次批量检查最多接受的访问数量
+const maxBatchSize = 100
+
+// Config 策略决策点客户端配置
+type Config struct {
+	Token       string                               // 服务凭证，在平台管理接口 /v1/platform/service-clients 创建
+	FailureMode FailureMode                          // 策略决策点不可用时的处理方式
+	Timeout     time.Duration                        // 单次请求超时时间，默认 1s
+	CacheSize   int                                  // 本地缓存的最大条目数，默认 10000，小于 0 时不缓存
+	MaxTTL      time.Duration                        // 本地缓存时长的上限，为 0 时使用服务端返回的 ttl_seconds
+	OnError     func(ctx context.Context, err error) // 失败模式生效时的回调，可用于记录日志或指标
+}
+
+// Request 表示一次访问检查
+type Request struct {
+	UserID     int64
+	TenantID   int64
+	Resource   string            // 资源，例如 user
+	Action     string            // 操作，例如 view
+	IP         string            // 终端用户的客户端IP，用于 cidr_match 等条件
+	Attributes map[string]string // 被访问资源的属性，例如 owner
+}
+
+// Result 表示一次访问检查的结果
+type Result struct {
+	Allowed bool
+	Reason  string
+	Cached  bool // 是否来自本地缓存
+}
+
+// Client 策略决策点客户端，按服务端返回的 ttl_seconds 在本地缓存决策.
+type Client struct {
+	cfg       Config
+	rpc       pdpv1.AuthorizationClient
+	decisions *ttlCache[Result]
+	actions   *ttlCache[[]string]
+	resources *ttlCache[map[string][]string]
+}
+
+// NewClient 使用已建立的 gRPC 连接创建策略决策点客户端.
+func NewClient(conn grpc.ClientConnInterface, cfg Config) (*Client, error) {
+	return newClient(pdpv1.NewAuthorizationClient(conn), cfg)
+}
+
+// newClient 创建策略决策点客户端
+func newClient(rpc pdpv1.AuthorizationClient, cfg Config) (*Client, error) {
+	if cfg.Token == "" {
+		return nil, errors.New("pdp: token is required")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = time.Second
+	}
+	if cfg.CacheSize == 0 {
+		cfg.CacheSize = 10000
+	}
+
+	return &Client{
+		cfg:       cfg,
+		rpc:       rpc,
+		decisions: newTTLCache[Result](cfg.CacheSize),
+		actions:   newTTLCache[[]string](cfg.CacheSize),
+		resources: newTTLCache[map[string][]string](cfg.CacheSize),
+	}, nil
+}
+
+// Allowed 检查用户能否对资源执行操作.
+func (c *Client) Allowed(ctx context.Context, rq Request) (bool, error) {
+	results, err := c.Check(ctx, rq)
+	if err != nil {
+		return false, err
+	}
+	return results[0].Allowed, nil
+}
+
+// Check 批量检查访问权限，结果与 rqs 一一对应.
+// 策略决策点不可用时按 FailureMode 返回结果，Reason 为 ReasonUnavailable；
+// 凭证无效、参数错误等不会自行恢复的错误直接返回.
+func (c *Client) Check(ctx context.Context, rqs ...Request) ([]Result, error) {
+	results := make([]Result, len(rqs))
+	var missed []int
+	for i, rq := range rqs {
+		if result, ok := c.decisions.get(decisionKey(rq)); ok {
+			result.Cached = true
+			results[i] = result
+			continue
+		}
+		missed = append(missed, i)
+	}
+
+	for start := 0; start < len(missed); start += maxBatchSize {
+		batch := missed[start:min(start+maxBatchSize, len(missed))]
+		items := make([]*pdpv1.CheckItem, 0, len(batch))
+		for _, i := range batch {
+			items = append(items, toCheckItem(rqs[i]))
+		}
+
+		resp, err := c.call(ctx, func(ctx context.Context) (any, error) {
+			return c.rpc.Check(ctx, &pdpv1.CheckRequest{Items: items})
+		})
+		if err != nil {
+			if !c.degradable(ctx, err) {
+				return nil, err
+			}
+			for _, i := range batch {
+				results[i] = Result{Allowed: c.cfg.FailureMode == FailOpen, Reason: ReasonUnavailable}
+			}
+			continue
+		}
+
+		checkResults := resp.(*pdpv1.CheckResponse).GetResults()
+		if len(checkResults) != len(batch) {
+			return nil, errors.New("pdp: check response does not match request")
+		}
+		for j, i := range batch {
+			r := checkResults[j]
+			results[i] = Result{Allowed: r.GetAllowed(), Reason: r.GetReason()}
+			c.decisions.set(decisionKey(rqs[i]), results[i], c.ttl(r.GetTtlSeconds()))
+		}
+	}
+	return results, nil
+}
+
+// ListAllowedActions 列出用户可以对资源执行的操作.
+// 列表无法按失败模式构造，策略决策点不可用时返回错误.
+func (c *Client) ListAllowedActions(ctx context.Context, userID, tenantID int64, resource string) ([]string, error) {
+	key := subjectKey(userID, tenantID) + "|" + resource
+	if actions, ok := c.actions.get(key); ok {
+		return actions, nil
+	}
+
+	resp, err := c.call(ctx, func(ctx context.Context) (any, error) {
+		return c.rpc.ListAllowedActions(ctx, &pdpv1.ListAllowedActionsRequest{UserId: userID, TenantId: tenantID, Resource: resource})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := resp.(*pdpv1.ListAllowedActionsResponse)
+	c.actions.set(key, r.GetActions(), c.ttl(r.GetTtlSeconds()))
+	return r.GetActions(), nil
+}
+
+// ListAccessibleResources 列出用户可以访问的资源及允许的操作，action 不为空时只返回允许该操作的资源.
+// 策略决策点不可用时返回错误.
+func (c *Client) ListAccessibleResources(ctx context.Context, userID, tenantID int64, action string) (map[string][]string, error) {
+	key := subjectKey(userID, tenantID) + "|" + action
+	if resources, ok := c.resources.get(key); ok {
+		return resources, nil
+	}
+
+	resp, err := c.call(ctx, func(ctx context.Context) (any, error) {
+		return c.rpc.ListAccessibleResources(ctx, &pdpv1.ListAccessibleResourcesRequest{UserId: userID, TenantId: tenantID, Action: action})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := resp.(*pdpv1.ListAccessibleResourcesResponse)
+	resources := make(map[string][]string, len(r.GetResources()))
+	for _, res := range r.GetResources() {
+		resources[res.GetResource()] = res.GetActions()
+	}
+	c.resources.set(key, resources, c.ttl(r.GetTtlSeconds()))
+	return resources, nil
+}
+
+// Flush 清空本地缓存，例如收到权限变更通知后调用.
+func (c *Client) Flush() {
+	c.decisions.flush()
+	c.actions.flush()
+	c.resources.flush()
+}
+
+// call 携带服务凭证和超时时间调用策略决策点
+func (c *Client) call(ctx context.Context, fn func(ctx context.Context) (any, error)) (any, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.cfg.Token)
+	return fn(ctx)
+}
+
+// degradable 判断错误是否由策略决策点不可用导致，只有这类错误按失败模式处理
+func (c *Client) degradable(ctx context.Context, err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown:
+	default:
+		return false
+	}
+	// 调用方主动取消时不做降级
+	if ctx.Err() != nil {
+		return false
+	}
+	if c.cfg.OnError != nil {
+		c.cfg.OnError(ctx, err)
+	}
+	return true
+}
+
+// ttl 返回本地缓存时长，不超过 MaxTTL
+func (c *Client) ttl(seconds int64) time.Duration {
+	ttl := time.Duration(seconds) * time.Second
+	if c.cfg.MaxTTL > 0 && ttl > c.cfg.MaxTTL {
+		ttl = c.cfg.MaxTTL
+	}
+	return ttl
+}
+
+// toCheckItem 转换访问检查为请求格式
+func toCheckItem(rq Request) *pdpv1.CheckItem {
+	item := &pdpv1.CheckItem{UserId: rq.UserID, TenantId: rq.TenantID, Resource: rq.Resource, Action: rq.Action}
+	if rq.IP != "" || len(rq.Attributes) > 0 {
+		item.Context = &pdpv1.Context{Ip: rq.IP, Resource: rq.Attributes}
+	}
+	return item
+}
+
+// decisionKey 返回决策的缓存键.
+// 依赖请求上下文的决策 ttl_seconds 为 0 不会缓存，因此缓存键不包含请求上下文.
+func decisionKey(rq Request) string {
+	return subjectKey(rq.UserID, rq.TenantID) + "|" + rq.Resource + ":" + rq.Action
+}
+
+// subjectKey 返回用户和租户的缓存键前缀
+func subjectKey(userID, tenantID int64) string {
+	return strconv.FormatInt(tenantID, 10) + "|" + strconv.FormatInt(userID, 10)
+}
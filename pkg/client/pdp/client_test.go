@@ -0,0 +1,111 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package pdp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pdpv1 "github.com/ashwinyue/one-auth/pkg/api/pdp/v1"
+)
+
+// fakeAuthorization 按权限编码返回决策的策略决策点
+type fakeAuthorization struct {
+	pdpv1.AuthorizationClient
+
+	allowed map[string]int64 // 权限编码 -> ttl_seconds
+	err     error
+	calls   int
+	token   string
+}
+
+func (f *fakeAuthorization) Check(ctx context.Context, rq *pdpv1.CheckRequest, _ ...grpc.CallOption) (*pdpv1.CheckResponse, error) {
+	f.calls++
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get("authorization")) > 0 {
+		f.token = md.Get("authorization")[0]
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	resp := &pdpv1.CheckResponse{}
+	for _, item := range rq.GetItems() {
+		ttl, ok := f.allowed[item.GetResource()+":"+item.GetAction()]
+		resp.Results = append(resp.Results, &pdpv1.CheckResult{Allowed: ok, Reason: "test", TtlSeconds: ttl})
+	}
+	return resp, nil
+}
+
+// TestCheck 测试批量检查、服务凭证和按 TTL 的本地缓存
+func TestCheck(t *testing.T) {
+	fake := &fakeAuthorization{allowed: map[string]int64{"user:view": 60, "user:update": 0}}
+	c, err := newClient(fake, Config{Token: "svc_test"})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	view := Request{UserID: 1, TenantID: 1, Resource: "user", Action: "view"}
+	update := Request{UserID: 1, TenantID: 1, Resource: "user", Action: "update"}
+
+	results, err := c.Check(ctx, view, update, Request{UserID: 1, TenantID: 1, Resource: "user", Action: "delete"})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, false}, []bool{results[0].Allowed, results[1].Allowed, results[2].Allowed})
+	assert.Equal(t, "Bearer svc_test", fake.token)
+	assert.Equal(t, 1, fake.calls)
+
+	// ttl_seconds 为 60 的结果命中本地缓存，为 0 的结果重新查询
+	results, err = c.Check(ctx, view)
+	require.NoError(t, err)
+	assert.True(t, results[0].Allowed)
+	assert.True(t, results[0].Cached)
+	assert.Equal(t, 1, fake.calls)
+
+	allowed, err := c.Allowed(ctx, update)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, 2, fake.calls)
+
+	c.Flush()
+	_, err = c.Check(ctx, view)
+	require.NoError(t, err)
+	assert.Equal(t, 3, fake.calls)
+}
+
+// TestFailureMode 测试策略决策点不可用时的失败模式
+func TestFailureMode(t *testing.T) {
+	ctx := context.Background()
+	rq := Request{UserID: 1, TenantID: 1, Resource: "user", Action: "view"}
+
+	unavailable := &fakeAuthorization{err: status.Error(codes.Unavailable, "connection refused")}
+	closed, err := newClient(unavailable, Config{Token: "svc_test"})
+	require.NoError(t, err)
+	results, err := closed.Check(ctx, rq)
+	require.NoError(t, err)
+	assert.False(t, results[0].Allowed)
+	assert.Equal(t, ReasonUnavailable, results[0].Reason)
+
+	var reported error
+	open, err := newClient(unavailable, Config{Token: "svc_test", FailureMode: FailOpen, OnError: func(_ context.Context, err error) { reported = err }})
+	require.NoError(t, err)
+	allowed, err := open.Allowed(ctx, rq)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Error(t, reported)
+
+	// 凭证无效不按失败模式处理
+	revoked := &fakeAuthorization{err: status.Error(codes.Unauthenticated, "invalid service token")}
+	open, err = newClient(revoked, Config{Token: "svc_test", FailureMode: FailOpen})
+	require.NoError(t, err)
+	_, err = open.Allowed(ctx, rq)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
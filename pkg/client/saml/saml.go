@@ -0,0 +1,352 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package saml provides a SAML 2.0 service provider for per-tenant SSO.
+package saml
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	crewsaml "github.com/crewjam/saml"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+// 支持的 AuthnRequest 绑定方式
+const (
+	BindingRedirect = "redirect"
+	BindingPost     = "post"
+)
+
+// maxIDPMetadataSize IdP 元数据的最大字节数
+const maxIDPMetadataSize = 1 << 20
+
+var (
+	// ErrInvalidResponse 断言校验失败（签名、有效期、受众等）
+	ErrInvalidResponse = errors.New("saml: invalid response")
+	// ErrReplayedAssertion 断言已被使用过
+	ErrReplayedAssertion = errors.New("saml: assertion replayed")
+	// ErrUnsupportedBinding IdP 不支持请求的绑定方式
+	ErrUnsupportedBinding = errors.New("saml: unsupported binding")
+)
+
+// ReplayCache 记录已使用的断言ID，防止断言重放.
+type ReplayCache interface {
+	// Reserve 占用断言ID，ID 已存在时返回 false
+	Reserve(ctx context.Context, assertionID string, ttl time.Duration) (bool, error)
+}
+
+// Config 服务提供方（SP）配置
+type Config struct {
+	EntityID          string      // SP 实体ID
+	ACSURL            string      // 断言消费服务地址
+	MetadataURL       string      // SP 元数据地址
+	Certificate       string      // PEM 格式的 SP 证书，用于请求签名和断言解密
+	PrivateKey        string      // PEM 格式的 SP 私钥
+	IDPMetadata       []byte      // IdP 元数据 XML
+	AllowIDPInitiated bool        // 是否允许 IdP 发起的登录
+	SignRequests      bool        // 是否对 AuthnRequest 签名
+	NameIDFormat      string      // 请求的 NameID 格式，为空时不限制
+	ReplayCache       ReplayCache // 断言重放缓存
+}
+
+// Assertion 校验通过的断言
+type Assertion struct {
+	ID           string
+	Issuer       string
+	NameID       string
+	SessionIndex string
+	Attributes   map[string][]string
+	NotOnOrAfter time.Time
+}
+
+// Attr 返回属性的第一个值.
+func (a *Assertion) Attr(name string) string {
+	if v := a.Attributes[name]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// AuthnRequest SP 发起的认证请求
+type AuthnRequest struct {
+	ID       string // 请求ID，用于校验响应的 InResponseTo
+	Binding  string // 实际使用的绑定方式
+	URL      string // Redirect 绑定时的跳转地址
+	PostForm []byte // POST 绑定时自动提交的 HTML 表单
+}
+
+// ServiceProvider 租户的 SAML 服务提供方.
+type ServiceProvider struct {
+	sp          *crewsaml.ServiceProvider
+	replayCache ReplayCache
+}
+
+// NewServiceProvider 创建 SAML 服务提供方.
+func NewServiceProvider(cfg Config) (*ServiceProvider, error) {
+	if cfg.EntityID == "" || cfg.ACSURL == "" {
+		return nil, errors.New("saml: entity id and acs url are required")
+	}
+	acsURL, err := url.Parse(cfg.ACSURL)
+	if err != nil {
+		return nil, fmt.Errorf("saml: invalid acs url: %w", err)
+	}
+	metadataURL, err := url.Parse(cfg.MetadataURL)
+	if err != nil {
+		return nil, fmt.Errorf("saml: invalid metadata url: %w", err)
+	}
+
+	idp, err := ParseIDPMetadata(cfg.IDPMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	sp := &crewsaml.ServiceProvider{
+		EntityID:          cfg.EntityID,
+		AcsURL:            *acsURL,
+		MetadataURL:       *metadataURL,
+		IDPMetadata:       idp,
+		AllowIDPInitiated: cfg.AllowIDPInitiated,
+		AuthnNameIDFormat: crewsaml.NameIDFormat(cfg.NameIDFormat),
+	}
+	if sp.AuthnNameIDFormat == "" {
+		sp.AuthnNameIDFormat = crewsaml.UnspecifiedNameIDFormat
+	}
+
+	if cfg.Certificate != "" || cfg.PrivateKey != "" {
+		pair, err := tls.X509KeyPair([]byte(cfg.Certificate), []byte(cfg.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("saml: invalid sp key pair: %w", err)
+		}
+		signer, ok := pair.PrivateKey.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("saml: sp private key cannot sign")
+		}
+		cert, err := x509.ParseCertificate(pair.Certificate[0])
+		if err != nil {
+			return nil, fmt.Errorf("saml: invalid sp certificate: %w", err)
+		}
+		sp.Key = signer
+		sp.Certificate = cert
+		if cfg.SignRequests {
+			sp.SignatureMethod = dsig.RSASHA256SignatureMethod
+		}
+	} else if cfg.SignRequests {
+		return nil, errors.New("saml: signing requests requires an sp key pair")
+	}
+
+	return &ServiceProvider{sp: sp, replayCache: cfg.ReplayCache}, nil
+}
+
+// ParseIDPMetadata 解析并校验 IdP 元数据.
+func ParseIDPMetadata(data []byte) (*crewsaml.EntityDescriptor, error) {
+	if len(data) == 0 {
+		return nil, errors.New("saml: idp metadata is required")
+	}
+
+	var idp crewsaml.EntityDescriptor
+	if err := xml.Unmarshal(data, &idp); err != nil {
+		// 部分 IdP 返回 EntitiesDescriptor，取第一个包含 IDPSSODescriptor 的实体
+		var entities crewsaml.EntitiesDescriptor
+		if err2 := xml.Unmarshal(data, &entities); err2 != nil {
+			return nil, fmt.Errorf("saml: invalid idp metadata: %w", err)
+		}
+		found := false
+		for _, e := range entities.EntityDescriptors {
+			if len(e.IDPSSODescriptors) > 0 {
+				idp, found = e, true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.New("saml: idp metadata has no IDPSSODescriptor")
+		}
+	}
+
+	if idp.EntityID == "" || len(idp.IDPSSODescriptors) == 0 {
+		return nil, errors.New("saml: idp metadata has no IDPSSODescriptor")
+	}
+	hasCert := false
+	for _, kd := range idp.IDPSSODescriptors[0].KeyDescriptors {
+		if kd.Use != "encryption" && len(kd.KeyInfo.X509Data.X509Certificates) > 0 {
+			hasCert = true
+		}
+	}
+	if !hasCert {
+		return nil, errors.New("saml: idp metadata has no signing certificate")
+	}
+
+	return &idp, nil
+}
+
+// FetchIDPMetadata 从 URL 下载 IdP 元数据.
+func FetchIDPMetadata(ctx context.Context, client *http.Client, metadataURL string) ([]byte, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("saml: invalid metadata url: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("saml: fetch idp metadata: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("saml: fetch idp metadata: unexpected status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxIDPMetadataSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("saml: fetch idp metadata: %w", err)
+	}
+	if len(data) > maxIDPMetadataSize {
+		return nil, errors.New("saml: idp metadata too large")
+	}
+
+	if _, err := ParseIDPMetadata(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// IDPEntityID 返回 IdP 的实体ID.
+func (s *ServiceProvider) IDPEntityID() string {
+	return s.sp.IDPMetadata.EntityID
+}
+
+// Metadata 生成 SP 元数据 XML.
+func (s *ServiceProvider) Metadata() ([]byte, error) {
+	md := s.sp.Metadata()
+
+	// 只支持 HTTP-POST 绑定的断言消费服务
+	for i := range md.SPSSODescriptors {
+		acs := md.SPSSODescriptors[i].AssertionConsumerServices[:0]
+		for _, ep := range md.SPSSODescriptors[i].AssertionConsumerServices {
+			if ep.Binding == crewsaml.HTTPPostBinding {
+				acs = append(acs, ep)
+			}
+		}
+		md.SPSSODescriptors[i].AssertionConsumerServices = acs
+	}
+
+	buf, err := xml.MarshalIndent(md, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), buf...), nil
+}
+
+// MakeAuthnRequest 生成 SP 发起的认证请求，IdP 不支持首选绑定时自动回退.
+func (s *ServiceProvider) MakeAuthnRequest(binding, relayState string) (*AuthnRequest, error) {
+	bindings := []string{crewsaml.HTTPRedirectBinding, crewsaml.HTTPPostBinding}
+	if binding == BindingPost {
+		bindings = []string{crewsaml.HTTPPostBinding, crewsaml.HTTPRedirectBinding}
+	}
+
+	for _, b := range bindings {
+		location := s.sp.GetSSOBindingLocation(b)
+		if location == "" {
+			continue
+		}
+
+		req, err := s.sp.MakeAuthenticationRequest(location, b, crewsaml.HTTPPostBinding)
+		if err != nil {
+			return nil, fmt.Errorf("saml: make authn request: %w", err)
+		}
+
+		if b == crewsaml.HTTPRedirectBinding {
+			u, err := req.Redirect(relayState, s.sp)
+			if err != nil {
+				return nil, fmt.Errorf("saml: make authn request: %w", err)
+			}
+			return &AuthnRequest{ID: req.ID, Binding: BindingRedirect, URL: u.String()}, nil
+		}
+		return &AuthnRequest{ID: req.ID, Binding: BindingPost, URL: location, PostForm: req.Post(relayState)}, nil
+	}
+
+	return nil, ErrUnsupportedBinding
+}
+
+// ParseResponse 校验 HTTP-POST 绑定的 SAMLResponse，并检查断言是否被重放.
+// possibleRequestIDs 为 SP 发起的请求ID，IdP 发起的登录可以为空.
+func (s *ServiceProvider) ParseResponse(ctx context.Context, samlResponse string, possibleRequestIDs []string) (*Assertion, error) {
+	raw, err := base64.StdEncoding.DecodeString(samlResponse)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot decode response", ErrInvalidResponse)
+	}
+
+	assertion, err := s.sp.ParseXMLResponse(raw, possibleRequestIDs, s.sp.AcsURL)
+	if err != nil {
+		var ire *crewsaml.InvalidResponseError
+		if errors.As(err, &ire) && ire.PrivateErr != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, ire.PrivateErr)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+
+	result := &Assertion{
+		ID:         assertion.ID,
+		Attributes: make(map[string][]string),
+	}
+	if assertion.Issuer.Value != "" {
+		result.Issuer = assertion.Issuer.Value
+	}
+	if assertion.Subject != nil && assertion.Subject.NameID != nil {
+		result.NameID = assertion.Subject.NameID.Value
+	}
+	if assertion.Conditions != nil {
+		result.NotOnOrAfter = assertion.Conditions.NotOnOrAfter
+	}
+	for _, stmt := range assertion.AuthnStatements {
+		if stmt.SessionIndex != "" {
+			result.SessionIndex = stmt.SessionIndex
+			break
+		}
+	}
+	for _, stmt := range assertion.AttributeStatements {
+		for _, attr := range stmt.Attributes {
+			for _, v := range attr.Values {
+				result.Attributes[attr.Name] = append(result.Attributes[attr.Name], v.Value)
+				if attr.FriendlyName != "" && attr.FriendlyName != attr.Name {
+					result.Attributes[attr.FriendlyName] = append(result.Attributes[attr.FriendlyName], v.Value)
+				}
+			}
+		}
+	}
+	if result.NameID == "" {
+		return nil, fmt.Errorf("%w: assertion has no NameID", ErrInvalidResponse)
+	}
+
+	if s.replayCache != nil {
+		// 断言在有效期（含时钟偏差）内只能使用一次
+		ttl := time.Until(result.NotOnOrAfter.Add(crewsaml.MaxClockSkew))
+		if result.NotOnOrAfter.IsZero() || ttl <= 0 {
+			ttl = crewsaml.MaxIssueDelay + crewsaml.MaxClockSkew
+		}
+		ok, err := s.replayCache.Reserve(ctx, result.ID, ttl)
+		if err != nil {
+			return nil, fmt.Errorf("saml: replay cache: %w", err)
+		}
+		if !ok {
+			return nil, ErrReplayedAssertion
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package saml
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"encoding/xml"
+	"math/big"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	crewsaml "github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryReplayCache 内存版断言重放缓存
+type memoryReplayCache struct {
+	mu  sync.Mutex
+	ids map[string]bool
+}
+
+func (c *memoryReplayCache) Reserve(_ context.Context, id string, _ time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ids[id] {
+		return false, nil
+	}
+	c.ids[id] = true
+	return true, nil
+}
+
+func newKeyPair(t *testing.T, cn string) (*rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return key, cert
+}
+
+func mustURL(t *testing.T, s string) url.URL {
+	u, err := url.Parse(s)
+	require.NoError(t, err)
+	return *u
+}
+
+// TestServiceProvider 测试 SP 发起的登录、断言校验和重放检测
+func TestServiceProvider(t *testing.T) {
+	idpKey, idpCert := newKeyPair(t, "idp")
+	spKey, spCert := newKeyPair(t, "sp")
+
+	idp := &crewsaml.IdentityProvider{
+		Key:         idpKey,
+		Signer:      idpKey,
+		Certificate: idpCert,
+		MetadataURL: mustURL(t, "https://idp.example.com/metadata"),
+		SSOURL:      mustURL(t, "https://idp.example.com/sso"),
+	}
+	idpMetadata, err := xml.Marshal(idp.Metadata())
+	require.NoError(t, err)
+
+	sp, err := NewServiceProvider(Config{
+		EntityID:     "https://auth.example.com/v1/saml/1/metadata",
+		ACSURL:       "https://auth.example.com/v1/saml/1/acs",
+		MetadataURL:  "https://auth.example.com/v1/saml/1/metadata",
+		Certificate:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: spCert.Raw})),
+		PrivateKey:   string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(spKey)})),
+		IDPMetadata:  idpMetadata,
+		SignRequests: true,
+		ReplayCache:  &memoryReplayCache{ids: map[string]bool{}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com/metadata", sp.IDPEntityID())
+
+	metadata, err := sp.Metadata()
+	require.NoError(t, err)
+	assert.Contains(t, string(metadata), "https://auth.example.com/v1/saml/1/acs")
+	assert.NotContains(t, string(metadata), crewsaml.HTTPArtifactBinding)
+
+	authn, err := sp.MakeAuthnRequest(BindingRedirect, "state")
+	require.NoError(t, err)
+	assert.Equal(t, BindingRedirect, authn.Binding)
+	assert.Contains(t, authn.URL, "https://idp.example.com/sso?SAMLRequest=")
+	assert.Contains(t, authn.URL, "Signature=")
+
+	spMetadata := sp.sp.Metadata()
+	makeResponse := func(requestID string) string {
+		req := &crewsaml.IdpAuthnRequest{
+			IDP:                     idp,
+			HTTPRequest:             httptest.NewRequest("POST", "https://idp.example.com/sso", nil),
+			Request:                 crewsaml.AuthnRequest{ID: requestID, IssueInstant: time.Now()},
+			ServiceProviderMetadata: spMetadata,
+			SPSSODescriptor:         &spMetadata.SPSSODescriptors[0],
+			ACSEndpoint:             &crewsaml.IndexedEndpoint{Binding: crewsaml.HTTPPostBinding, Location: "https://auth.example.com/v1/saml/1/acs"},
+			Now:                     time.Now(),
+		}
+		require.NoError(t, crewsaml.DefaultAssertionMaker{}.MakeAssertion(req, &crewsaml.Session{
+			ID:        "session-1",
+			Index:     "index-1",
+			NameID:    "alice@example.com",
+			UserName:  "alice",
+			UserEmail: "alice@example.com",
+			Groups:    []string{"admins"},
+		}))
+		form, err := req.PostBinding()
+		require.NoError(t, err)
+		return form.SAMLResponse
+	}
+
+	ctx := context.Background()
+	response := makeResponse(authn.ID)
+
+	assertion, err := sp.ParseResponse(ctx, response, []string{authn.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", assertion.NameID)
+	assert.Equal(t, "index-1", assertion.SessionIndex)
+	assert.Equal(t, "alice", assertion.Attr("uid"))
+	assert.Equal(t, []string{"admins"}, assertion.Attributes["eduPersonAffiliation"])
+
+	// 同一断言不能重复使用
+	_, err = sp.ParseResponse(ctx, response, []string{authn.ID})
+	assert.ErrorIs(t, err, ErrReplayedAssertion)
+
+	// 请求ID不匹配
+	_, err = sp.ParseResponse(ctx, makeResponse("other-id"), []string{authn.ID})
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+
+	// 未开启 IdP 发起的登录
+	_, err = sp.ParseResponse(ctx, makeResponse(""), nil)
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+
+	_, err = sp.ParseResponse(ctx, "not-base64", nil)
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package sms
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	// HeaderCallbackTimestamp 回执回调的时间戳请求头，值为 Unix 秒
+	HeaderCallbackTimestamp = "X-SMS-Timestamp"
+	// HeaderCallbackSignature 回执回调的签名请求头，值为十六进制的 HMAC-SHA256
+	HeaderCallbackSignature = "X-SMS-Signature"
+	// CallbackMaxSkew 回执时间戳与服务器时间允许的最大偏差，超出时视为重放
+	CallbackMaxSkew = 5 * time.Minute
+)
+
+// ErrInvalidSignature 回执回调未签名、签名错误或已过期
+var ErrInvalidSignature = errors.New("invalid sms callback signature")
+
+// SignCallback 使用服务商的共享密钥计算回执回调的签名：HMAC-SHA256(secret, timestamp + "." + body).
+func SignCallback(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyCallback 校验回执回调的签名和时间戳，未配置密钥时拒绝回调
+func verifyCallback(secret, timestamp, signature string, body []byte, now time.Time) error {
+	if secret == "" || timestamp == "" || signature == "" {
+		return ErrInvalidSignature
+	}
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if skew := now.Sub(time.Unix(sec, 0)); skew > CallbackMaxSkew || skew < -CallbackMaxSkew {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(SignCallback(secret, timestamp, body)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -9,9 +9,10 @@ package sms
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
-	"strconv"
+	"sync"
 	"time"
 
 	"github.com/ashwinyue/one-auth/internal/pkg/log"
@@ -23,8 +24,18 @@ type Client interface {
 	SendVerifyCode(ctx context.Context, phone, code, template string) error
 	// GenerateCode 生成验证码
 	GenerateCode() string
-	// IsValidPhone 验证手机号格式
+	// IsValidPhone 验证手机号格式（支持 E.164 国际号码）
 	IsValidPhone(phone string) bool
+	// NormalizePhone 将手机号规范化为 E.164 格式，并校验租户的国家/地区白名单
+	NormalizePhone(tenantID, phone string) (string, error)
+	// OnDeliveryStatus 注册短信回执处理函数
+	OnDeliveryStatus(handler DeliveryStatusHandler)
+	// VerifyDeliveryReport 使用服务商的共享密钥校验回执回调的签名
+	VerifyDeliveryReport(provider, timestamp, signature string, body []byte) error
+	// HandleDeliveryReport 处理服务商推送的短信回执
+	HandleDeliveryReport(ctx context.Context, report *DeliveryReport) error
+	// ProviderHealth 返回各服务商的健康状态
+	ProviderHealth() []ProviderHealth
 }
 
 // Config 短信客户端配置
@@ -35,109 +46,246 @@ type Config struct {
 	Region      string            `yaml:"region"`        // 区域
 	SignName    string            `yaml:"sign_name"`     // 签名名称
 	Templates   map[string]string `yaml:"templates"`     // 模板配置
+
+	DefaultCountryCode     string              `yaml:"default_country_code"`     // 未携带国家码时使用的呼叫代码
+	AllowedCountries       []string            `yaml:"allowed_countries"`        // 全局国家/地区白名单
+	TenantAllowedCountries map[string][]string `yaml:"tenant_allowed_countries"` // 租户国家/地区白名单，优先于全局配置
+	Routes                 map[string][]string `yaml:"routes"`                   // 呼叫代码 -> 有序服务商列表，"*" 为默认路由
+	SendTimeout            time.Duration       `yaml:"send_timeout"`             // 单个服务商的发送超时时间
+	FailureThreshold       int                 `yaml:"failure_threshold"`        // 连续失败多少次后熔断
+	CooldownPeriod         time.Duration       `yaml:"cooldown_period"`          // 熔断冷却时间
+	CallbackSecrets        map[string]string   `yaml:"callback_secrets"`         // 服务商 -> 回执回调签名密钥，未配置的服务商的回调会被拒绝
 }
 
-// client 短信客户端实现
+// client 短信客户端实现，按国家/地区路由到服务商并在失败时自动切换.
 type client struct {
-	config *Config
+	config  *Config
+	senders map[string]Sender
+	health  *healthTracker
+
+	mu       sync.RWMutex
+	handlers []DeliveryStatusHandler
 }
 
-// NewClient 创建短信客户端实例
-func NewClient(config *Config) Client {
+// NewClient 创建短信客户端实例，配置无效或引用了未注册的服务商时返回错误.
+// senders 用于注册自定义服务商，同名时覆盖内置实现.
+func NewClient(config *Config, senders ...Sender) (Client, error) {
 	if config == nil {
-		config = &Config{
-			Provider: "mock",
-			SignName: "One-Auth",
-			Templates: map[string]string{
-				"login":          "LOGIN_VERIFY_CODE",
-				"register":       "REGISTER_VERIFY_CODE",
-				"reset_password": "RESET_PASSWORD_VERIFY_CODE",
-				"bind_phone":     "BIND_PHONE_VERIFY_CODE",
-			},
+		config = DefaultConfig()
+	}
+	if err := ValidateConfig(config); err != nil {
+		return nil, err
+	}
+
+	c := &client{
+		config: config,
+		senders: map[string]Sender{
+			string(ProviderMock):    &mockSender{signName: config.SignName},
+			string(ProviderAliyun):  &aliyunSender{config: config},
+			string(ProviderTencent): &tencentSender{config: config},
+		},
+		health: newHealthTracker(config.FailureThreshold, config.CooldownPeriod),
+	}
+	for _, s := range senders {
+		c.senders[s.Name()] = s
+	}
+
+	if _, ok := c.senders[config.Provider]; !ok {
+		return nil, fmt.Errorf("unknown sms provider: %s", config.Provider)
+	}
+	for code, route := range config.Routes {
+		for _, name := range route {
+			if _, ok := c.senders[name]; !ok {
+				return nil, fmt.Errorf("sms route %q references unknown provider: %s", code, name)
+			}
 		}
 	}
-	return &client{config: config}
+
+	return c, nil
 }
 
 // SendVerifyCode 发送验证码
 func (c *client) SendVerifyCode(ctx context.Context, phone, code, template string) error {
-	// 根据配置的提供商发送短信
-	switch c.config.Provider {
-	case "aliyun":
-		return c.sendAliyunSMS(ctx, phone, code, template)
-	case "tencent":
-		return c.sendTencentSMS(ctx, phone, code, template)
-	default:
-		// 模拟发送短信（开发环境）
-		return c.mockSendSMS(ctx, phone, code, template)
+	p, err := ParsePhone(phone, c.config.DefaultCountryCode)
+	if err != nil {
+		return err
+	}
+
+	templateName := c.config.Templates[template]
+	if templateName == "" {
+		templateName = "VERIFY_CODE"
+	}
+	msg := &Message{
+		Phone:    p.E164(),
+		Region:   p.Region,
+		Code:     code,
+		Template: templateName,
+		SignName: c.config.SignName,
+	}
+
+	var errs []error
+	for _, name := range c.candidates(p.CountryCode) {
+		sender, ok := c.senders[name]
+		if !ok {
+			log.W(ctx).Warnw("SMS provider not registered, skipped", "provider", name)
+			continue
+		}
+
+		messageID, err := c.sendWithTimeout(ctx, sender, msg)
+		if err == nil {
+			log.W(ctx).Infow("SMS sent", "provider", name, "phone", msg.Phone, "message_id", messageID)
+			return nil
+		}
+
+		log.W(ctx).Warnw("SMS provider failed, trying next", "provider", name, "phone", msg.Phone, "err", err)
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+
+		// 调用方已取消则不再切换
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if len(errs) == 0 {
+		return fmt.Errorf("no sms provider available for country code %s", p.CountryCode)
+	}
+	return errors.Join(errs...)
+}
+
+// sendWithTimeout 使用单次超时调用服务商并记录健康状态.
+func (c *client) sendWithTimeout(ctx context.Context, sender Sender, msg *Message) (string, error) {
+	if c.config.SendTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.SendTimeout)
+		defer cancel()
+	}
+
+	type result struct {
+		messageID string
+		err       error
+	}
+
+	// 服务商实现未必遵守 ctx，这里单独等待以保证超时后能切换到下一个服务商
+	start := time.Now()
+	done := make(chan result, 1)
+	go func() {
+		messageID, err := sender.Send(ctx, msg)
+		done <- result{messageID: messageID, err: err}
+	}()
+
+	var messageID string
+	var err error
+	select {
+	case r := <-done:
+		messageID, err = r.messageID, r.err
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	latency := time.Since(start)
+
+	if err != nil {
+		c.health.recordFailure(sender.Name(), latency, err)
+		return "", err
+	}
+	c.health.recordSuccess(sender.Name(), latency)
+	return messageID, nil
+}
+
+// candidates 返回呼叫代码对应的服务商列表，冷却中的服务商排在最后.
+func (c *client) candidates(countryCode string) []string {
+	route, ok := c.config.Routes[countryCode]
+	if !ok {
+		route, ok = c.config.Routes["*"]
+	}
+	if !ok || len(route) == 0 {
+		route = []string{c.config.Provider}
+	}
+
+	healthy := make([]string, 0, len(route))
+	var degraded []string
+	for _, name := range route {
+		if c.health.available(name) {
+			healthy = append(healthy, name)
+		} else {
+			degraded = append(degraded, name)
+		}
 	}
+	return append(healthy, degraded...)
 }
 
 // GenerateCode 生成6位数字验证码
 func (c *client) GenerateCode() string {
-	rand.Seed(time.Now().UnixNano())
 	code := rand.Intn(1000000)
 	return fmt.Sprintf("%06d", code)
 }
 
-// IsValidPhone 验证中国手机号格式
+// IsValidPhone 验证手机号格式
 func (c *client) IsValidPhone(phone string) bool {
-	if len(phone) != 11 {
-		return false
-	}
+	_, err := ParsePhone(phone, c.config.DefaultCountryCode)
+	return err == nil
+}
 
-	// 检查是否以1开头
-	if phone[0] != '1' {
-		return false
+// NormalizePhone 将手机号规范化为 E.164 格式，并校验租户的国家/地区白名单
+func (c *client) NormalizePhone(tenantID, phone string) (string, error) {
+	p, err := ParsePhone(phone, c.config.DefaultCountryCode)
+	if err != nil {
+		return "", err
 	}
 
-	// 检查第二位是否为3-9
-	secondDigit, err := strconv.Atoi(string(phone[1]))
-	if err != nil || secondDigit < 3 || secondDigit > 9 {
-		return false
+	allowed, ok := c.config.TenantAllowedCountries[tenantID]
+	if !ok {
+		allowed = c.config.AllowedCountries
 	}
-
-	// 检查剩余位数是否都是数字
-	for i := 2; i < 11; i++ {
-		if phone[i] < '0' || phone[i] > '9' {
-			return false
-		}
+	if !CountryAllowed(p, allowed) {
+		return "", ErrCountryNotAllowed
 	}
 
-	return true
+	return p.E164(), nil
 }
 
-// mockSendSMS 模拟发送短信（用于开发环境）
-func (c *client) mockSendSMS(ctx context.Context, phone, code, template string) error {
-	templateName := c.config.Templates[template]
-	if templateName == "" {
-		templateName = "VERIFY_CODE"
+// OnDeliveryStatus 注册短信回执处理函数
+func (c *client) OnDeliveryStatus(handler DeliveryStatusHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers = append(c.handlers, handler)
+}
+
+// VerifyDeliveryReport 使用服务商的共享密钥校验回执回调的签名，未配置密钥的服务商的回调一律拒绝
+func (c *client) VerifyDeliveryReport(provider, timestamp, signature string, body []byte) error {
+	return verifyCallback(c.config.CallbackSecrets[provider], timestamp, signature, body, time.Now())
+}
+
+// HandleDeliveryReport 处理服务商推送的短信回执
+func (c *client) HandleDeliveryReport(ctx context.Context, report *DeliveryReport) error {
+	if _, ok := c.senders[report.Provider]; !ok {
+		return fmt.Errorf("unknown sms provider: %s", report.Provider)
 	}
 
-	log.Infow("模拟发送短信验证码",
-		"phone", phone,
-		"code", code,
-		"template", templateName,
-		"sign_name", c.config.SignName,
-		"message", fmt.Sprintf("【%s】您的验证码是：%s，5分钟内有效，请勿泄露。", c.config.SignName, code),
-	)
+	switch report.Status {
+	case DeliveryStatusDelivered:
+		c.health.recordDelivery(report.Provider, true)
+	case DeliveryStatusFailed:
+		c.health.recordDelivery(report.Provider, false)
+	case DeliveryStatusPending:
+	default:
+		return fmt.Errorf("unknown delivery status: %s", report.Status)
+	}
 
-	// 模拟网络延迟
-	time.Sleep(100 * time.Millisecond)
+	if report.ReportedAt.IsZero() {
+		report.ReportedAt = time.Now()
+	}
 
-	return nil
-}
+	c.mu.RLock()
+	handlers := c.handlers
+	c.mu.RUnlock()
+	for _, handler := range handlers {
+		handler(ctx, report)
+	}
 
-// sendAliyunSMS 阿里云短信发送（待实现）
-func (c *client) sendAliyunSMS(ctx context.Context, phone, code, template string) error {
-	// TODO: 实现阿里云短信发送
-	log.Warnw("阿里云短信发送功能待实现", "phone", phone, "template", template)
-	return c.mockSendSMS(ctx, phone, code, template)
+	return nil
 }
 
-// sendTencentSMS 腾讯云短信发送（待实现）
-func (c *client) sendTencentSMS(ctx context.Context, phone, code, template string) error {
-	// TODO: 实现腾讯云短信发送
-	log.Warnw("腾讯云短信发送功能待实现", "phone", phone, "template", template)
-	return c.mockSendSMS(ctx, phone, code, template)
+// ProviderHealth 返回各服务商的健康状态
+func (c *client) ProviderHealth() []ProviderHealth {
+	return c.health.snapshot()
 }
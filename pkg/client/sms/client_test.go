@@ -0,0 +1,174 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package sms
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSender 用于测试的服务商实现
+type fakeSender struct {
+	name  string
+	err   error
+	delay time.Duration
+	calls atomic.Int32
+}
+
+func (s *fakeSender) Name() string { return s.name }
+
+func (s *fakeSender) Send(ctx context.Context, msg *Message) (string, error) {
+	s.calls.Add(1)
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return "", s.err
+	}
+	return s.name + "-1", nil
+}
+
+// TestParsePhone 测试手机号解析
+func TestParsePhone(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{"13800138000", "+8613800138000", true},
+		{"+86 138-0013-8000", "+8613800138000", true},
+		{"008613800138000", "+8613800138000", true},
+		{"+14155552671", "+14155552671", true},
+		{"+852 9123 4567", "+85291234567", true},
+		{"+447911123456", "+447911123456", true},
+		{"12800138000", "", false},   // 中国大陆号段不合法
+		{"+8612345", "", false},      // 中国大陆长度不合法
+		{"+999123456789", "", false}, // 未知呼叫代码
+		{"+4479111234567890", "", false},
+		{"abc", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		p, err := ParsePhone(tt.input, DefaultCountryCode)
+		if !tt.ok {
+			assert.ErrorIs(t, err, ErrInvalidPhone, tt.input)
+			continue
+		}
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.want, p.E164(), tt.input)
+		}
+	}
+}
+
+// TestNormalizePhoneAllowlist 测试租户国家/地区白名单
+func TestNormalizePhoneAllowlist(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TenantAllowedCountries = map[string][]string{"2": {"CN", "+1"}}
+	c, err := NewClient(cfg)
+	require.NoError(t, err)
+
+	phone, err := c.NormalizePhone("1", "13800138000")
+	assert.NoError(t, err)
+	assert.Equal(t, "+8613800138000", phone)
+
+	_, err = c.NormalizePhone("1", "+14155552671")
+	assert.ErrorIs(t, err, ErrCountryNotAllowed)
+
+	phone, err = c.NormalizePhone("2", "+14155552671")
+	assert.NoError(t, err)
+	assert.Equal(t, "+14155552671", phone)
+}
+
+// TestNewClientInvalidConfig 测试无效配置返回错误
+func TestNewClientInvalidConfig(t *testing.T) {
+	for _, mutate := range []func(*Config){
+		func(cfg *Config) { cfg.Provider = "unknown" },
+		func(cfg *Config) { cfg.DefaultCountryCode = "999" },
+		func(cfg *Config) { cfg.Routes = map[string][]string{"86": {"mock", "unknown"}} },
+		func(cfg *Config) { cfg.SendTimeout = -time.Second },
+	} {
+		cfg := DefaultConfig()
+		mutate(cfg)
+		_, err := NewClient(cfg)
+		assert.Error(t, err)
+	}
+}
+
+// TestSendVerifyCodeFailover 测试服务商失败和超时后的切换
+func TestSendVerifyCodeFailover(t *testing.T) {
+	failing := &fakeSender{name: "a", err: errors.New("quota exceeded")}
+	slow := &fakeSender{name: "b", delay: 200 * time.Millisecond}
+	backup := &fakeSender{name: "c"}
+
+	cfg := DefaultConfig()
+	cfg.Routes = map[string][]string{"86": {"a", "b", "c"}, "*": {"c"}}
+	cfg.SendTimeout = 50 * time.Millisecond
+	cfg.FailureThreshold = 1
+	c, err := NewClient(cfg, failing, slow, backup)
+	require.NoError(t, err)
+
+	assert.NoError(t, c.SendVerifyCode(context.Background(), "13800138000", "123456", "login"))
+	assert.Equal(t, int32(1), failing.calls.Load())
+	assert.Equal(t, int32(1), slow.calls.Load())
+	assert.Equal(t, int32(1), backup.calls.Load())
+
+	health := c.ProviderHealth()
+	if assert.Len(t, health, 3) {
+		assert.Equal(t, int64(1), health[0].Failures)
+		assert.False(t, health[0].CooldownUntil.IsZero())
+		assert.Equal(t, context.DeadlineExceeded.Error(), health[1].LastError)
+		assert.Equal(t, int64(1), health[2].Successes)
+	}
+
+	// 熔断中的服务商排在最后
+	failing.err = nil
+	assert.NoError(t, c.SendVerifyCode(context.Background(), "+8613800138000", "123456", "login"))
+	assert.Equal(t, int32(2), backup.calls.Load())
+	assert.Equal(t, int32(1), failing.calls.Load())
+
+	// 回执回调
+	var got *DeliveryReport
+	c.OnDeliveryStatus(func(ctx context.Context, report *DeliveryReport) { got = report })
+	assert.NoError(t, c.HandleDeliveryReport(context.Background(), &DeliveryReport{Provider: "c", MessageID: "c-1", Status: DeliveryStatusFailed}))
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "c-1", got.MessageID)
+	}
+	assert.Error(t, c.HandleDeliveryReport(context.Background(), &DeliveryReport{Provider: "unknown", Status: DeliveryStatusDelivered}))
+}
+
+// TestVerifyDeliveryReport 测试回执回调签名校验
+func TestVerifyDeliveryReport(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CallbackSecrets = map[string]string{"mock": "s3cret"}
+	c, err := NewClient(cfg)
+	require.NoError(t, err)
+
+	body := []byte(`{"message_id":"m-1","status":"failed"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	assert.NoError(t, c.VerifyDeliveryReport("mock", now, SignCallback("s3cret", now, body), body))
+
+	// 未签名、签名错误、请求体被篡改、时间戳过期和未配置密钥的服务商都被拒绝
+	stale := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	for _, tt := range []struct {
+		provider, timestamp, signature string
+		body                           []byte
+	}{
+		{"mock", "", "", body},
+		{"mock", now, SignCallback("wrong", now, body), body},
+		{"mock", now, SignCallback("s3cret", now, body), []byte(`{"message_id":"m-1","status":"delivered"}`)},
+		{"mock", stale, SignCallback("s3cret", stale, body), body},
+		{"aliyun", now, SignCallback("s3cret", now, body), body},
+	} {
+		assert.ErrorIs(t, c.VerifyDeliveryReport(tt.provider, tt.timestamp, tt.signature, tt.body), ErrInvalidSignature)
+	}
+}
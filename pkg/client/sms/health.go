@@ -0,0 +1,119 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package sms
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// ProviderHealth 短信服务商的健康状态
+type ProviderHealth struct {
+	Provider            string        `json:"provider"`
+	Successes           int64         `json:"successes"`            // 发送成功次数
+	Failures            int64         `json:"failures"`             // 发送失败次数（含超时）
+	ConsecutiveFailures int           `json:"consecutive_failures"` // 连续失败次数
+	Delivered           int64         `json:"delivered"`            // 回执确认送达次数
+	Undelivered         int64         `json:"undelivered"`          // 回执确认未送达次数
+	LastError           string        `json:"last_error,omitempty"`
+	LastLatency         time.Duration `json:"last_latency"`
+	LastSuccessAt       time.Time     `json:"last_success_at,omitempty"`
+	LastFailureAt       time.Time     `json:"last_failure_at,omitempty"`
+	CooldownUntil       time.Time     `json:"cooldown_until,omitempty"` // 熔断截止时间
+}
+
+// healthTracker 记录各服务商的发送结果，连续失败达到阈值后进入冷却期.
+type healthTracker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	stats     map[string]*ProviderHealth
+	now       func() time.Time
+}
+
+func newHealthTracker(threshold int, cooldown time.Duration) *healthTracker {
+	return &healthTracker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		stats:     make(map[string]*ProviderHealth),
+		now:       time.Now,
+	}
+}
+
+// get 获取服务商的统计项，调用方需持有锁.
+func (h *healthTracker) get(provider string) *ProviderHealth {
+	s, ok := h.stats[provider]
+	if !ok {
+		s = &ProviderHealth{Provider: provider}
+		h.stats[provider] = s
+	}
+	return s
+}
+
+// available 判断服务商是否可用（不在冷却期内）.
+func (h *healthTracker) available(provider string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.stats[provider]
+	return !ok || !h.now().Before(s.CooldownUntil)
+}
+
+func (h *healthTracker) recordSuccess(provider string, latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := h.get(provider)
+	s.Successes++
+	s.ConsecutiveFailures = 0
+	s.LastLatency = latency
+	s.LastSuccessAt = h.now()
+	s.CooldownUntil = time.Time{}
+}
+
+func (h *healthTracker) recordFailure(provider string, latency time.Duration, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := h.get(provider)
+	s.Failures++
+	s.ConsecutiveFailures++
+	s.LastLatency = latency
+	s.LastFailureAt = h.now()
+	if err != nil {
+		s.LastError = err.Error()
+	}
+	if h.threshold > 0 && s.ConsecutiveFailures >= h.threshold {
+		s.CooldownUntil = s.LastFailureAt.Add(h.cooldown)
+	}
+}
+
+func (h *healthTracker) recordDelivery(provider string, delivered bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := h.get(provider)
+	if delivered {
+		s.Delivered++
+	} else {
+		s.Undelivered++
+	}
+}
+
+// snapshot 返回按服务商名称排序的健康状态副本.
+func (h *healthTracker) snapshot() []ProviderHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result := make([]ProviderHealth, 0, len(h.stats))
+	for _, s := range h.stats {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Provider < result[j].Provider })
+	return result
+}
@@ -0,0 +1,175 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package sms
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrInvalidPhone 手机号格式不合法
+	ErrInvalidPhone = errors.New("invalid phone number")
+	// ErrCountryNotAllowed 手机号所属国家/地区不在白名单内
+	ErrCountryNotAllowed = errors.New("phone country is not allowed")
+)
+
+// DefaultCountryCode 未携带国家码的手机号默认按中国大陆处理
+const DefaultCountryCode = "86"
+
+// countryRegions 国家/地区呼叫代码到 ISO 3166-1 地区码的映射.
+// 呼叫代码满足前缀唯一性，共用呼叫代码的地区（如 +1、+7）取主地区.
+var countryRegions = map[string]string{
+	"1": "US", "7": "RU", "20": "EG", "27": "ZA", "30": "GR", "31": "NL", "32": "BE", "33": "FR",
+	"34": "ES", "36": "HU", "39": "IT", "40": "RO", "41": "CH", "43": "AT", "44": "GB", "45": "DK",
+	"46": "SE", "47": "NO", "48": "PL", "49": "DE", "51": "PE", "52": "MX", "53": "CU", "54": "AR",
+	"55": "BR", "56": "CL", "57": "CO", "58": "VE", "60": "MY", "61": "AU", "62": "ID", "63": "PH",
+	"64": "NZ", "65": "SG", "66": "TH", "81": "JP", "82": "KR", "84": "VN", "86": "CN", "90": "TR",
+	"91": "IN", "92": "PK", "93": "AF", "94": "LK", "95": "MM", "98": "IR",
+	"211": "SS", "212": "MA", "213": "DZ", "216": "TN", "218": "LY", "220": "GM", "221": "SN",
+	"222": "MR", "223": "ML", "224": "GN", "225": "CI", "226": "BF", "227": "NE", "228": "TG",
+	"229": "BJ", "230": "MU", "231": "LR", "232": "SL", "233": "GH", "234": "NG", "235": "TD",
+	"236": "CF", "237": "CM", "238": "CV", "239": "ST", "240": "GQ", "241": "GA", "242": "CG",
+	"243": "CD", "244": "AO", "245": "GW", "248": "SC", "249": "SD", "250": "RW", "251": "ET",
+	"252": "SO", "253": "DJ", "254": "KE", "255": "TZ", "256": "UG", "257": "BI", "258": "MZ",
+	"260": "ZM", "261": "MG", "262": "RE", "263": "ZW", "264": "NA", "265": "MW", "266": "LS",
+	"267": "BW", "268": "SZ", "269": "KM", "290": "SH", "291": "ER", "297": "AW", "298": "FO",
+	"299": "GL", "350": "GI", "351": "PT", "352": "LU", "353": "IE", "354": "IS", "355": "AL",
+	"356": "MT", "357": "CY", "358": "FI", "359": "BG", "370": "LT", "371": "LV", "372": "EE",
+	"373": "MD", "374": "AM", "375": "BY", "376": "AD", "377": "MC", "378": "SM", "380": "UA",
+	"381": "RS", "382": "ME", "383": "XK", "385": "HR", "386": "SI", "387": "BA", "389": "MK",
+	"420": "CZ", "421": "SK", "423": "LI", "500": "FK", "501": "BZ", "502": "GT", "503": "SV",
+	"504": "HN", "505": "NI", "506": "CR", "507": "PA", "508": "PM", "509": "HT", "590": "GP",
+	"591": "BO", "592": "GY", "593": "EC", "594": "GF", "595": "PY", "596": "MQ", "597": "SR",
+	"598": "UY", "599": "CW", "670": "TL", "672": "NF", "673": "BN", "674": "NR", "675": "PG",
+	"676": "TO", "677": "SB", "678": "VU", "679": "FJ", "680": "PW", "681": "WF", "682": "CK",
+	"683": "NU", "685": "WS", "686": "KI", "687": "NC", "688": "TV", "689": "PF", "690": "TK",
+	"691": "FM", "692": "MH", "850": "KP", "852": "HK", "853": "MO", "855": "KH", "856": "LA",
+	"880": "BD", "886": "TW", "960": "MV", "961": "LB", "962": "JO", "963": "SY", "964": "IQ",
+	"965": "KW", "966": "SA", "967": "YE", "968": "OM", "970": "PS", "971": "AE", "972": "IL",
+	"973": "BH", "974": "QA", "975": "BT", "976": "MN", "977": "NP", "992": "TJ", "993": "TM",
+	"994": "AZ", "995": "GE", "996": "KG", "998": "UZ",
+}
+
+// mobileRules 部分国家/地区的手机号码（国内号码部分）校验规则.
+// 未配置规则的国家/地区仅做 E.164 长度校验.
+var mobileRules = map[string]*regexp.Regexp{
+	"86":  regexp.MustCompile(`^1[3-9]\d{9}$`),          // 中国大陆
+	"852": regexp.MustCompile(`^[4-9]\d{7}$`),           // 中国香港
+	"853": regexp.MustCompile(`^6\d{7}$`),               // 中国澳门
+	"886": regexp.MustCompile(`^9\d{8}$`),               // 中国台湾
+	"1":   regexp.MustCompile(`^[2-9]\d{2}[2-9]\d{6}$`), // 北美
+}
+
+// phoneSeparator 用户输入中允许出现的分隔符
+var phoneSeparator = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+
+// PhoneNumber 表示解析后的国际手机号.
+type PhoneNumber struct {
+	// CountryCode 国家/地区呼叫代码，不含 "+"
+	CountryCode string
+	// NationalNumber 国内号码部分
+	NationalNumber string
+	// Region ISO 3166-1 地区码
+	Region string
+}
+
+// E164 返回 E.164 格式的手机号，例如 +8613800138000.
+func (p *PhoneNumber) E164() string {
+	return "+" + p.CountryCode + p.NationalNumber
+}
+
+// ParsePhone 解析手机号.
+// 支持 "+8613800138000"、"008613800138000" 形式的国际号码，
+// 以及不带国家码的国内号码（按 defaultCountryCode 处理）.
+func ParsePhone(phone, defaultCountryCode string) (*PhoneNumber, error) {
+	s := phoneSeparator.Replace(strings.TrimSpace(phone))
+	if s == "" {
+		return nil, ErrInvalidPhone
+	}
+
+	var p *PhoneNumber
+	switch {
+	case strings.HasPrefix(s, "+"):
+		p = splitCountryCode(s[1:])
+	case strings.HasPrefix(s, "00"):
+		p = splitCountryCode(s[2:])
+	default:
+		if defaultCountryCode == "" {
+			defaultCountryCode = DefaultCountryCode
+		}
+		defaultCountryCode = strings.TrimPrefix(defaultCountryCode, "+")
+		// 去掉国内长途前缀 0
+		p = &PhoneNumber{
+			CountryCode:    defaultCountryCode,
+			NationalNumber: strings.TrimPrefix(s, "0"),
+			Region:         countryRegions[defaultCountryCode],
+		}
+	}
+	if p == nil || p.Region == "" || !isDigits(p.NationalNumber) {
+		return nil, ErrInvalidPhone
+	}
+
+	// E.164 规定号码总长度不超过 15 位
+	if n := len(p.NationalNumber); n < 4 || len(p.CountryCode)+n > 15 {
+		return nil, ErrInvalidPhone
+	}
+	if rule, ok := mobileRules[p.CountryCode]; ok && !rule.MatchString(p.NationalNumber) {
+		return nil, ErrInvalidPhone
+	}
+
+	return p, nil
+}
+
+// NormalizePhone 使用包默认的呼叫代码将手机号规范化为 E.164 格式.
+// 不读取客户端配置的 DefaultCountryCode，业务中应使用 Client.NormalizePhone.
+func NormalizePhone(phone string) (string, error) {
+	p, err := ParsePhone(phone, DefaultCountryCode)
+	if err != nil {
+		return "", err
+	}
+	return p.E164(), nil
+}
+
+// CountryAllowed 判断手机号是否在国家/地区白名单内.
+// 白名单项可以是呼叫代码（"86"、"+1"）或地区码（"CN"），"*" 表示不限制，空白名单表示不限制.
+func CountryAllowed(p *PhoneNumber, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, item := range allowed {
+		item = strings.TrimPrefix(strings.TrimSpace(item), "+")
+		if item == "*" || item == p.CountryCode || strings.EqualFold(item, p.Region) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitCountryCode 从不含 "+" 的国际号码中拆分出呼叫代码.
+func splitCountryCode(digits string) *PhoneNumber {
+	for i := 1; i <= 3 && i < len(digits); i++ {
+		if region, ok := countryRegions[digits[:i]]; ok {
+			return &PhoneNumber{CountryCode: digits[:i], NationalNumber: digits[i:], Region: region}
+		}
+	}
+	return nil
+}
+
+// isDigits 判断字符串是否全部由数字组成.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
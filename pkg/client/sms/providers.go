@@ -6,6 +6,16 @@
 
 package sms
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ashwinyue/one-auth/internal/pkg/log"
+)
+
 // Provider 定义短信提供商类型
 type Provider string
 
@@ -32,6 +42,101 @@ const (
 	CodeTypeBindPhone CodeType = "bind_phone"
 )
 
+// Message 待发送的短信
+type Message struct {
+	Phone    string // E.164 格式手机号
+	Region   string // 手机号所属地区码
+	Code     string // 验证码
+	Template string // 服务商模板ID
+	SignName string // 签名名称
+}
+
+// Sender 短信服务商的发送实现
+type Sender interface {
+	// Name 返回服务商名称，与路由配置中的名称对应
+	Name() string
+	// Send 发送短信并返回服务商的消息ID
+	Send(ctx context.Context, msg *Message) (string, error)
+}
+
+// mockSender 模拟发送短信（用于开发环境）
+type mockSender struct {
+	signName string
+}
+
+func (s *mockSender) Name() string { return string(ProviderMock) }
+
+func (s *mockSender) Send(ctx context.Context, msg *Message) (string, error) {
+	log.Infow("模拟发送短信验证码",
+		"phone", msg.Phone,
+		"code", msg.Code,
+		"template", msg.Template,
+		"sign_name", msg.SignName,
+		"message", fmt.Sprintf("【%s】您的验证码是：%s，5分钟内有效，请勿泄露。", msg.SignName, msg.Code),
+	)
+
+	// 模拟网络延迟
+	select {
+	case <-time.After(100 * time.Millisecond):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	return fmt.Sprintf("mock-%d", time.Now().UnixNano()), nil
+}
+
+// aliyunSender 阿里云短信发送（待实现）
+type aliyunSender struct {
+	config *Config
+}
+
+func (s *aliyunSender) Name() string { return string(ProviderAliyun) }
+
+func (s *aliyunSender) Send(ctx context.Context, msg *Message) (string, error) {
+	// TODO: 实现阿里云短信发送
+	log.Warnw("阿里云短信发送功能待实现", "phone", msg.Phone, "template", msg.Template)
+	return (&mockSender{signName: s.config.SignName}).Send(ctx, msg)
+}
+
+// tencentSender 腾讯云短信发送（待实现）
+type tencentSender struct {
+	config *Config
+}
+
+func (s *tencentSender) Name() string { return string(ProviderTencent) }
+
+func (s *tencentSender) Send(ctx context.Context, msg *Message) (string, error) {
+	// TODO: 实现腾讯云短信发送
+	log.Warnw("腾讯云短信发送功能待实现", "phone", msg.Phone, "template", msg.Template)
+	return (&mockSender{signName: s.config.SignName}).Send(ctx, msg)
+}
+
+// DeliveryStatus 短信投递状态
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusPending 投递中
+	DeliveryStatusPending DeliveryStatus = "pending"
+	// DeliveryStatusDelivered 已送达
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	// DeliveryStatusFailed 投递失败
+	DeliveryStatusFailed DeliveryStatus = "failed"
+)
+
+// DeliveryReport 服务商推送的短信回执
+type DeliveryReport struct {
+	Provider     string
+	MessageID    string
+	Phone        string
+	Status       DeliveryStatus
+	ErrorCode    string
+	ErrorMessage string
+	ReportedAt   time.Time
+}
+
+// DeliveryStatusHandler 短信回执处理函数
+type DeliveryStatusHandler func(ctx context.Context, report *DeliveryReport)
+
 // DefaultConfig 返回默认配置
 func DefaultConfig() *Config {
 	return &Config{
@@ -43,21 +148,22 @@ func DefaultConfig() *Config {
 			string(CodeTypeResetPassword): "RESET_PASSWORD_VERIFY_CODE",
 			string(CodeTypeBindPhone):     "BIND_PHONE_VERIFY_CODE",
 		},
+		DefaultCountryCode: DefaultCountryCode,
+		AllowedCountries:   []string{DefaultCountryCode},
+		SendTimeout:        5 * time.Second,
+		FailureThreshold:   3,
+		CooldownPeriod:     time.Minute,
 	}
 }
 
-// ValidateConfig 验证配置
+// ValidateConfig 验证配置，并为未设置的字段填充默认值
 func ValidateConfig(config *Config) error {
 	if config == nil {
 		return nil // 使用默认配置
 	}
 
-	// 验证提供商
-	provider := Provider(config.Provider)
-	switch provider {
-	case ProviderMock, ProviderAliyun, ProviderTencent:
-		// 合法的提供商
-	default:
+	// 未设置服务商时使用 mock，服务商是否已注册由 NewClient 检查
+	if config.Provider == "" {
 		config.Provider = string(ProviderMock)
 	}
 
@@ -71,5 +177,27 @@ func ValidateConfig(config *Config) error {
 		config.Templates = make(map[string]string)
 	}
 
+	// 默认呼叫代码必须是已知的国家/地区
+	config.DefaultCountryCode = strings.TrimPrefix(config.DefaultCountryCode, "+")
+	if config.DefaultCountryCode == "" {
+		config.DefaultCountryCode = DefaultCountryCode
+	}
+	if _, ok := countryRegions[config.DefaultCountryCode]; !ok {
+		return fmt.Errorf("unknown default country code: %s", config.DefaultCountryCode)
+	}
+
+	if config.SendTimeout < 0 {
+		return errors.New("send timeout cannot be negative")
+	}
+	for code, providers := range config.Routes {
+		if len(providers) == 0 {
+			return fmt.Errorf("sms route %q must have at least one provider", code)
+		}
+	}
+
+	if config.FailureThreshold > 0 && config.CooldownPeriod <= 0 {
+		config.CooldownPeriod = time.Minute
+	}
+
 	return nil
 }
@@ -0,0 +1,110 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ashwinyue/one-auth/pkg/client/sms"
+)
+
+var _ IOptions = (*SMSOptions)(nil)
+
+// SMSOptions defines options for sms client.
+type SMSOptions struct {
+	Provider           string            `json:"provider" mapstructure:"provider"`
+	AccessKeyID        string            `json:"access-key-id" mapstructure:"access-key-id"`
+	SecretKey          string            `json:"secret-key" mapstructure:"secret-key"`
+	Region             string            `json:"region" mapstructure:"region"`
+	SignName           string            `json:"sign-name" mapstructure:"sign-name"`
+	Templates          map[string]string `json:"templates" mapstructure:"templates"`
+	DefaultCountryCode string            `json:"default-country-code" mapstructure:"default-country-code"`
+	AllowedCountries   []string          `json:"allowed-countries" mapstructure:"allowed-countries"`
+	// TenantAllowedCountries overrides AllowedCountries for the given tenant ID.
+	TenantAllowedCountries map[string][]string `json:"tenant-allowed-countries" mapstructure:"tenant-allowed-countries"`
+	// Routes maps a country calling code to an ordered provider list, "*" is the default route.
+	Routes           map[string][]string `json:"routes" mapstructure:"routes"`
+	SendTimeout      time.Duration       `json:"send-timeout" mapstructure:"send-timeout"`
+	FailureThreshold int                 `json:"failure-threshold" mapstructure:"failure-threshold"`
+	CooldownPeriod   time.Duration       `json:"cooldown-period" mapstructure:"cooldown-period"`
+	// CallbackSecrets maps a provider to the shared secret used to sign its delivery callbacks.
+	CallbackSecrets map[string]string `json:"callback-secrets" mapstructure:"callback-secrets"`
+}
+
+// NewSMSOptions create a `zero` value instance.
+func NewSMSOptions() *SMSOptions {
+	def := sms.DefaultConfig()
+	return &SMSOptions{
+		Provider:           def.Provider,
+		SignName:           def.SignName,
+		Templates:          def.Templates,
+		DefaultCountryCode: def.DefaultCountryCode,
+		AllowedCountries:   def.AllowedCountries,
+		SendTimeout:        def.SendTimeout,
+		FailureThreshold:   def.FailureThreshold,
+		CooldownPeriod:     def.CooldownPeriod,
+	}
+}
+
+// Validate verifies flags passed to SMSOptions.
+func (o *SMSOptions) Validate() []error {
+	errs := []error{}
+
+	if o.SendTimeout < 0 {
+		errs = append(errs, fmt.Errorf("--sms.send-timeout cannot be negative"))
+	}
+
+	for code, providers := range o.Routes {
+		if len(providers) == 0 {
+			errs = append(errs, fmt.Errorf("sms route %q must have at least one provider", code))
+		}
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to sms for a specific APIServer to the specified FlagSet.
+func (o *SMSOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
+	fs.StringVar(&o.Provider, "sms.provider", o.Provider, "Default sms provider, available options: mock, aliyun, tencent.")
+	fs.StringVar(&o.AccessKeyID, "sms.access-key-id", o.AccessKeyID, "Access key ID of the sms provider.")
+	fs.StringVar(&o.SecretKey, "sms.secret-key", o.SecretKey, "Secret key of the sms provider.")
+	fs.StringVar(&o.Region, "sms.region", o.Region, "Region of the sms provider.")
+	fs.StringVar(&o.SignName, "sms.sign-name", o.SignName, "Sign name of sms messages.")
+	fs.StringVar(&o.DefaultCountryCode, "sms.default-country-code", o.DefaultCountryCode,
+		"Country calling code used for phone numbers without a country code.")
+	fs.StringSliceVar(&o.AllowedCountries, "sms.allowed-countries", o.AllowedCountries,
+		"Country calling codes or region codes allowed to receive sms, empty means no restriction.")
+	fs.DurationVar(&o.SendTimeout, "sms.send-timeout", o.SendTimeout, "Timeout of a single provider before failing over.")
+	fs.IntVar(&o.FailureThreshold, "sms.failure-threshold", o.FailureThreshold,
+		"Consecutive failures before a provider is put into cooldown, 0 disables it.")
+	fs.DurationVar(&o.CooldownPeriod, "sms.cooldown-period", o.CooldownPeriod, "Cooldown period of an unhealthy provider.")
+	fs.StringToStringVar(&o.CallbackSecrets, "sms.callback-secrets", o.CallbackSecrets,
+		"Shared secrets used to verify delivery callbacks, keyed by provider. Callbacks from providers without a secret are rejected.")
+}
+
+// NewClient create a sms client with the given options.
+func (o *SMSOptions) NewClient() (sms.Client, error) {
+	return sms.NewClient(&sms.Config{
+		Provider:               o.Provider,
+		AccessKeyID:            o.AccessKeyID,
+		SecretKey:              o.SecretKey,
+		Region:                 o.Region,
+		SignName:               o.SignName,
+		Templates:              o.Templates,
+		DefaultCountryCode:     o.DefaultCountryCode,
+		AllowedCountries:       o.AllowedCountries,
+		TenantAllowedCountries: o.TenantAllowedCountries,
+		Routes:                 o.Routes,
+		SendTimeout:            o.SendTimeout,
+		FailureThreshold:       o.FailureThreshold,
+		CooldownPeriod:         o.CooldownPeriod,
+		CallbackSecrets:        o.CallbackSecrets,
+	})
+}
@@ -0,0 +1,571 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter 过滤表达式，由 ParseFilter 解析得到
+type Filter interface {
+	isFilter()
+}
+
+// AttrExpr 属性比较表达式，例如 userName eq "alice"
+type AttrExpr struct {
+	Path string
+	Op   string
+	// Value 比较值，类型为 string、float64、bool 或 nil
+	Value any
+}
+
+// LogicalExpr 逻辑表达式，Op 为 and 或 or
+type LogicalExpr struct {
+	Op          string
+	Left, Right Filter
+}
+
+// NotExpr 取反表达式
+type NotExpr struct {
+	Filter Filter
+}
+
+// ValuePathExpr 多值属性过滤表达式，例如 emails[type eq "work"]
+type ValuePathExpr struct {
+	Path   string
+	Filter Filter
+}
+
+func (*AttrExpr) isFilter()      {}
+func (*LogicalExpr) isFilter()   {}
+func (*NotExpr) isFilter()       {}
+func (*ValuePathExpr) isFilter() {}
+
+// 支持的比较运算符
+var compareOps = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true,
+}
+
+// ParseFilter 解析 RFC 7644 3.4.2.2 定义的过滤表达式
+func ParseFilter(s string) (Filter, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, invalidFilter("unexpected %q", p.peek().text)
+	}
+	return f, nil
+}
+
+// tokenKind 词法单元类型
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize 将过滤表达式拆分为词法单元
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")"})
+			i++
+		case c == '[':
+			tokens = append(tokens, token{kind: tokenLBracket, text: "["})
+			i++
+		case c == ']':
+			tokens = append(tokens, token{kind: tokenRBracket, text: "]"})
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(s); j++ {
+				if s[j] == '\\' {
+					j++
+					continue
+				}
+				if s[j] == '"' {
+					break
+				}
+			}
+			if j >= len(s) {
+				return nil, invalidFilter("unterminated string")
+			}
+			var str string
+			if err := json.Unmarshal([]byte(s[i:j+1]), &str); err != nil {
+				return nil, invalidFilter("invalid string %s", s[i:j+1])
+			}
+			tokens = append(tokens, token{kind: tokenString, text: str})
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n\r()[]\"", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: s[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+// parser 递归下降解析器
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{kind: tokenWord}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+// peekKeyword 判断下一个词法单元是否为指定关键字
+func (p *parser) peekKeyword(kw string) bool {
+	t := p.peek()
+	return !p.done() && t.kind == tokenWord && strings.EqualFold(t.text, kw)
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	if p.done() || p.peek().kind != kind {
+		return invalidFilter("expected %q", text)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &LogicalExpr{Op: "or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &LogicalExpr{Op: "and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Filter, error) {
+	if p.peekKeyword("not") {
+		p.pos++
+		if err := p.expect(tokenLParen, "("); err != nil {
+			return nil, err
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return &NotExpr{Filter: f}, nil
+	}
+
+	if p.peek().kind == tokenLParen && !p.done() {
+		p.pos++
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+
+	return p.parseAttr()
+}
+
+func (p *parser) parseAttr() (Filter, error) {
+	if p.done() {
+		return nil, invalidFilter("unexpected end of filter")
+	}
+	t := p.next()
+	if t.kind != tokenWord {
+		return nil, invalidFilter("expected attribute path, got %q", t.text)
+	}
+	path := trimSchema(t.text)
+
+	if p.peek().kind == tokenLBracket && !p.done() {
+		p.pos++
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRBracket, "]"); err != nil {
+			return nil, err
+		}
+		return &ValuePathExpr{Path: path, Filter: f}, nil
+	}
+
+	if p.done() || p.peek().kind != tokenWord {
+		return nil, invalidFilter("expected operator after %q", t.text)
+	}
+	op := strings.ToLower(p.next().text)
+	if op == "pr" {
+		return &AttrExpr{Path: path, Op: op}, nil
+	}
+	if !compareOps[op] {
+		return nil, invalidFilter("unsupported operator %q", op)
+	}
+
+	if p.done() {
+		return nil, invalidFilter("expected value after %q", op)
+	}
+	v := p.next()
+	var value any
+	switch {
+	case v.kind == tokenString:
+		value = v.text
+	case v.kind == tokenWord && strings.EqualFold(v.text, "true"):
+		value = true
+	case v.kind == tokenWord && strings.EqualFold(v.text, "false"):
+		value = false
+	case v.kind == tokenWord && strings.EqualFold(v.text, "null"):
+		value = nil
+	case v.kind == tokenWord:
+		n, err := strconv.ParseFloat(v.text, 64)
+		if err != nil {
+			return nil, invalidFilter("invalid value %q", v.text)
+		}
+		value = n
+	default:
+		return nil, invalidFilter("invalid value %q", v.text)
+	}
+	return &AttrExpr{Path: path, Op: op, Value: value}, nil
+}
+
+// Match 判断资源（以 map 表示）是否满足过滤表达式
+func Match(f Filter, resource map[string]any) bool {
+	switch e := f.(type) {
+	case *LogicalExpr:
+		if e.Op == "and" {
+			return Match(e.Left, resource) && Match(e.Right, resource)
+		}
+		return Match(e.Left, resource) || Match(e.Right, resource)
+	case *NotExpr:
+		return !Match(e.Filter, resource)
+	case *ValuePathExpr:
+		for _, elem := range asSlice(lookup(resource, e.Path)) {
+			if m, ok := elem.(map[string]any); ok && Match(e.Filter, m) {
+				return true
+			}
+		}
+		return false
+	case *AttrExpr:
+		values := attrValues(resource, e.Path)
+		if e.Op == "ne" {
+			for _, v := range values {
+				if compare(v, "eq", e.Value) {
+					return false
+				}
+			}
+			return true
+		}
+		for _, v := range values {
+			if compare(v, e.Op, e.Value) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// attrValues 返回属性路径对应的所有值，复杂多值属性默认取 value 子属性
+func attrValues(resource map[string]any, path string) []any {
+	attr, sub, _ := strings.Cut(path, ".")
+	var values []any
+	for _, v := range asSlice(lookup(resource, attr)) {
+		if m, ok := v.(map[string]any); ok {
+			key := sub
+			if key == "" {
+				key = "value"
+			}
+			if sv, ok := getKey(m, key); ok {
+				values = append(values, sv)
+			}
+			continue
+		}
+		if sub == "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// compare 按 SCIM 语义比较属性值和过滤值，字符串比较不区分大小写
+func compare(actual any, op string, expected any) bool {
+	if op == "pr" {
+		switch v := actual.(type) {
+		case nil:
+			return false
+		case string:
+			return v != ""
+		}
+		return true
+	}
+
+	switch want := expected.(type) {
+	case nil:
+		return op == "eq" && actual == nil
+	case bool:
+		got, ok := actual.(bool)
+		return ok && op == "eq" && got == want
+	case float64:
+		got, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		return compareOrdered(got, want, op)
+	case string:
+		got, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		got, want = strings.ToLower(got), strings.ToLower(want)
+		switch op {
+		case "eq":
+			return got == want
+		case "co":
+			return strings.Contains(got, want)
+		case "sw":
+			return strings.HasPrefix(got, want)
+		case "ew":
+			return strings.HasSuffix(got, want)
+		}
+		return compareOrdered(got, want, op)
+	}
+	return false
+}
+
+func compareOrdered[T float64 | string](got, want T, op string) bool {
+	switch op {
+	case "eq":
+		return got == want
+	case "gt":
+		return got > want
+	case "ge":
+		return got >= want
+	case "lt":
+		return got < want
+	case "le":
+		return got <= want
+	}
+	return false
+}
+
+// Columns 过滤属性路径（小写）到数据库列的映射
+type Columns map[string]string
+
+// column 查找属性对应的列，复杂多值属性默认使用 value 子属性
+func (c Columns) column(path string) (string, bool) {
+	path = strings.ToLower(path)
+	if col, ok := c[path]; ok {
+		return col, true
+	}
+	col, ok := c[path+".value"]
+	return col, ok
+}
+
+// ToSQL 将过滤表达式编译为 SQL 条件，columns 之外的属性返回 invalidFilter 错误
+func ToSQL(f Filter, columns Columns) (string, []any, error) {
+	switch e := f.(type) {
+	case *LogicalExpr:
+		left, largs, err := ToSQL(e.Left, columns)
+		if err != nil {
+			return "", nil, err
+		}
+		right, rargs, err := ToSQL(e.Right, columns)
+		if err != nil {
+			return "", nil, err
+		}
+		return "(" + left + " " + strings.ToUpper(e.Op) + " " + right + ")", append(largs, rargs...), nil
+	case *NotExpr:
+		cond, args, err := ToSQL(e.Filter, columns)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT (" + cond + ")", args, nil
+	case *ValuePathExpr:
+		return ToSQL(prefixPaths(e.Filter, e.Path), columns)
+	case *AttrExpr:
+		col, ok := columns.column(e.Path)
+		if !ok {
+			return "", nil, invalidFilter("filtering on %q is not supported", e.Path)
+		}
+		return attrSQL(col, e)
+	}
+	return "", nil, invalidFilter("unsupported filter")
+}
+
+// attrSQL 生成单个属性比较的 SQL 条件
+func attrSQL(col string, e *AttrExpr) (string, []any, error) {
+	if e.Op == "pr" {
+		return "(" + col + " IS NOT NULL AND " + col + " <> '')", nil, nil
+	}
+	if e.Value == nil {
+		switch e.Op {
+		case "eq":
+			return col + " IS NULL", nil, nil
+		case "ne":
+			return col + " IS NOT NULL", nil, nil
+		}
+		return "", nil, invalidFilter("operator %q does not accept null", e.Op)
+	}
+
+	value := e.Value
+	if s, ok := value.(string); ok {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			value = t
+		}
+	}
+
+	switch e.Op {
+	case "eq":
+		return col + " = ?", []any{value}, nil
+	case "ne":
+		return "(" + col + " <> ? OR " + col + " IS NULL)", []any{value}, nil
+	case "gt":
+		return col + " > ?", []any{value}, nil
+	case "ge":
+		return col + " >= ?", []any{value}, nil
+	case "lt":
+		return col + " < ?", []any{value}, nil
+	case "le":
+		return col + " <= ?", []any{value}, nil
+	}
+
+	s, ok := e.Value.(string)
+	if !ok {
+		return "", nil, invalidFilter("operator %q requires a string value", e.Op)
+	}
+	s = escapeLike(s)
+	switch e.Op {
+	case "co":
+		s = "%" + s + "%"
+	case "sw":
+		s = s + "%"
+	case "ew":
+		s = "%" + s
+	}
+	return col + " LIKE ?", []any{s}, nil
+}
+
+// prefixPaths 将多值属性过滤中的子属性路径展开为完整路径
+func prefixPaths(f Filter, prefix string) Filter {
+	switch e := f.(type) {
+	case *LogicalExpr:
+		return &LogicalExpr{Op: e.Op, Left: prefixPaths(e.Left, prefix), Right: prefixPaths(e.Right, prefix)}
+	case *NotExpr:
+		return &NotExpr{Filter: prefixPaths(e.Filter, prefix)}
+	case *AttrExpr:
+		return &AttrExpr{Path: prefix + "." + e.Path, Op: e.Op, Value: e.Value}
+	}
+	return f
+}
+
+// escapeLike 转义 LIKE 模式中的通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// lookup 不区分大小写地读取属性
+func lookup(m map[string]any, attr string) any {
+	v, _ := getKey(m, attr)
+	return v
+}
+
+// getKey 不区分大小写地查找 key
+func getKey(m map[string]any, key string) (any, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// asSlice 将单值或多值属性统一为切片
+func asSlice(v any) []any {
+	switch s := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		return s
+	}
+	return []any{v}
+}
+
+// invalidFilter 创建 invalidFilter 错误
+func invalidFilter(format string, args ...any) *Error {
+	return NewError(http.StatusBadRequest, ErrTypeInvalidFilter, format, args...)
+}
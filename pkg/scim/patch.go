@@ -0,0 +1,315 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package scim
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// patchPath 解析后的 PATCH 路径：attr[filter].sub
+type patchPath struct {
+	Attr   string
+	Filter Filter
+	Sub    string
+}
+
+// ApplyPatch 对资源（以 map 表示）依次执行 RFC 7644 3.5.2 定义的 PATCH 操作.
+// 操作名不区分大小写；路径支持子属性和多值属性过滤，例如 members[value eq "2"]、
+// emails[type eq "work"].value。
+func ApplyPatch(resource map[string]any, ops []PatchOperation) error {
+	for _, op := range ops {
+		if err := applyOp(resource, op); err != nil {
+			return err
+		}
+	}
+	normalize(resource)
+	return nil
+}
+
+// applyOp 执行单个 PATCH 操作
+func applyOp(resource map[string]any, op PatchOperation) error {
+	kind := strings.ToLower(op.Op)
+	switch kind {
+	case "add", "replace", "remove":
+	default:
+		return NewError(http.StatusBadRequest, ErrTypeInvalidSyntax, "unsupported patch operation %q", op.Op)
+	}
+
+	if strings.TrimSpace(op.Path) != "" {
+		return applyPath(resource, kind, op.Path, op.Value)
+	}
+
+	if kind == "remove" {
+		return NewError(http.StatusBadRequest, ErrTypeNoTarget, "remove operation requires a path")
+	}
+	values, ok := op.Value.(map[string]any)
+	if !ok {
+		return NewError(http.StatusBadRequest, ErrTypeInvalidValue, "value must be an object when path is omitted")
+	}
+	for k, v := range values {
+		// 不支持的扩展 schema 以 URN 为 key 整体传入，直接忽略
+		if _, nested := v.(map[string]any); nested && strings.HasPrefix(strings.ToLower(k), "urn:") {
+			continue
+		}
+		if err := applyPath(resource, kind, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parsePath 解析 PATCH 路径
+func parsePath(raw string) (*patchPath, error) {
+	raw = strings.TrimSpace(raw)
+	i := strings.Index(raw, "[")
+	if i < 0 {
+		attr, sub, _ := strings.Cut(trimSchema(raw), ".")
+		if attr == "" {
+			return nil, invalidPath(raw)
+		}
+		return &patchPath{Attr: attr, Sub: sub}, nil
+	}
+
+	j := strings.LastIndex(raw, "]")
+	if j < i {
+		return nil, invalidPath(raw)
+	}
+	f, err := ParseFilter(raw[i+1 : j])
+	if err != nil {
+		return nil, invalidPath(raw)
+	}
+	p := &patchPath{Attr: trimSchema(raw[:i]), Filter: f}
+	if rest := raw[j+1:]; rest != "" {
+		if !strings.HasPrefix(rest, ".") || len(rest) == 1 {
+			return nil, invalidPath(raw)
+		}
+		p.Sub = rest[1:]
+	}
+	return p, nil
+}
+
+// applyPath 按路径执行操作
+func applyPath(resource map[string]any, kind, raw string, value any) error {
+	p, err := parsePath(raw)
+	if err != nil {
+		return err
+	}
+	if p.Filter != nil {
+		return applyFiltered(resource, kind, p, value)
+	}
+
+	if p.Sub != "" {
+		switch c := lookup(resource, p.Attr).(type) {
+		case []any:
+			for _, e := range c {
+				if m, ok := e.(map[string]any); ok {
+					setOrRemove(m, kind, p.Sub, value)
+				}
+			}
+		case map[string]any:
+			setOrRemove(c, kind, p.Sub, value)
+		case nil:
+			if kind != "remove" {
+				setKey(resource, p.Attr, map[string]any{p.Sub: value})
+			}
+		default:
+			return invalidPath(raw)
+		}
+		return nil
+	}
+
+	existing := lookup(resource, p.Attr)
+	switch kind {
+	case "remove":
+		list, isList := existing.([]any)
+		if value == nil || !isList {
+			deleteKey(resource, p.Attr)
+			return nil
+		}
+		// 带 value 的 remove 只移除指定元素
+		var out []any
+		for _, e := range list {
+			if !containsValue(asSlice(value), e) {
+				out = append(out, e)
+			}
+		}
+		setList(resource, p.Attr, out)
+	case "add":
+		list, isList := existing.([]any)
+		if add, ok := value.([]any); ok || isList {
+			if !ok {
+				add = []any{value}
+			}
+			for _, v := range add {
+				if !containsValue(list, v) {
+					list = append(list, v)
+				}
+			}
+			setKey(resource, p.Attr, list)
+			return nil
+		}
+		mergeOrSet(resource, p.Attr, existing, value)
+	case "replace":
+		mergeOrSet(resource, p.Attr, existing, value)
+	}
+	return nil
+}
+
+// applyFiltered 对多值属性中满足过滤条件的元素执行操作，
+// add/replace 没有匹配元素时按过滤条件中的 eq 条件创建新元素
+func applyFiltered(resource map[string]any, kind string, p *patchPath, value any) error {
+	var out []any
+	matched := false
+	for _, e := range asSlice(lookup(resource, p.Attr)) {
+		m, ok := e.(map[string]any)
+		if !ok || !Match(p.Filter, m) {
+			out = append(out, e)
+			continue
+		}
+		matched = true
+		if kind == "remove" {
+			if p.Sub != "" {
+				deleteKey(m, p.Sub)
+				out = append(out, m)
+			}
+			continue
+		}
+		if err := setElement(m, p.Sub, value); err != nil {
+			return err
+		}
+		out = append(out, m)
+	}
+
+	if !matched {
+		if kind == "remove" {
+			return nil
+		}
+		elem := map[string]any{}
+		if !filterTemplate(p.Filter, elem) {
+			return NewError(http.StatusBadRequest, ErrTypeNoTarget, "no %s value matches the filter", p.Attr)
+		}
+		if err := setElement(elem, p.Sub, value); err != nil {
+			return err
+		}
+		out = append(out, elem)
+	}
+
+	setList(resource, p.Attr, out)
+	return nil
+}
+
+// setElement 设置多值属性元素的子属性，或用对象值更新整个元素
+func setElement(elem map[string]any, sub string, value any) error {
+	if sub != "" {
+		setKey(elem, sub, value)
+		return nil
+	}
+	vm, ok := value.(map[string]any)
+	if !ok {
+		return NewError(http.StatusBadRequest, ErrTypeInvalidValue, "value must be an object")
+	}
+	for k, v := range vm {
+		setKey(elem, k, v)
+	}
+	return nil
+}
+
+// filterTemplate 从只包含 eq 和 and 的过滤条件中提取元素的属性值
+func filterTemplate(f Filter, elem map[string]any) bool {
+	switch e := f.(type) {
+	case *AttrExpr:
+		if e.Op != "eq" || strings.Contains(e.Path, ".") {
+			return false
+		}
+		elem[e.Path] = e.Value
+		return true
+	case *LogicalExpr:
+		return e.Op == "and" && filterTemplate(e.Left, elem) && filterTemplate(e.Right, elem)
+	}
+	return false
+}
+
+// setOrRemove 设置或删除子属性
+func setOrRemove(m map[string]any, kind, key string, value any) {
+	if kind == "remove" {
+		deleteKey(m, key)
+		return
+	}
+	setKey(m, key, value)
+}
+
+// mergeOrSet 复杂属性按子属性合并，其余情况直接替换
+func mergeOrSet(resource map[string]any, attr string, existing, value any) {
+	em, ok1 := existing.(map[string]any)
+	vm, ok2 := value.(map[string]any)
+	if ok1 && ok2 {
+		for k, v := range vm {
+			setKey(em, k, v)
+		}
+		return
+	}
+	setKey(resource, attr, value)
+}
+
+// containsValue 判断多值属性中是否已包含某个值，复杂值按 value 子属性比较
+func containsValue(list []any, v any) bool {
+	for _, e := range list {
+		if sameValue(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameValue(a, b any) bool {
+	am, ok1 := a.(map[string]any)
+	bm, ok2 := b.(map[string]any)
+	if ok1 && ok2 {
+		av, aok := getKey(am, "value")
+		bv, bok := getKey(bm, "value")
+		if aok && bok {
+			return reflect.DeepEqual(av, bv)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// setList 写入多值属性，为空时删除属性
+func setList(m map[string]any, attr string, list []any) {
+	if len(list) == 0 {
+		deleteKey(m, attr)
+		return
+	}
+	setKey(m, attr, list)
+}
+
+// setKey 不区分大小写地写入 key，已存在时沿用原 key
+func setKey(m map[string]any, key string, value any) {
+	for k := range m {
+		if strings.EqualFold(k, key) {
+			m[k] = value
+			return
+		}
+	}
+	m[key] = value
+}
+
+// deleteKey 不区分大小写地删除 key
+func deleteKey(m map[string]any, key string) {
+	for k := range m {
+		if strings.EqualFold(k, key) {
+			delete(m, k)
+		}
+	}
+}
+
+// invalidPath 创建 invalidPath 错误
+func invalidPath(path string) *Error {
+	return NewError(http.StatusBadRequest, ErrTypeInvalidPath, "invalid path %q", path)
+}
@@ -0,0 +1,340 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package scim 实现 SCIM 2.0（RFC 7643/7644）协议的资源模型、过滤表达式和 PATCH 操作.
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentType SCIM 协议规定的媒体类型
+const ContentType = "application/scim+json"
+
+// SCIM 资源和消息的 schema URN
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// 分页参数默认值
+const (
+	DefaultCount = 100
+	MaxCount     = 1000
+)
+
+// Meta 资源元数据
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+	Version      string     `json:"version,omitempty"`
+}
+
+// Name 用户姓名
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+// MultiValue 多值属性（emails、phoneNumbers、groups）的元素
+type MultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// User SCIM 用户资源
+type User struct {
+	Schemas      []string     `json:"schemas"`
+	ID           string       `json:"id,omitempty"`
+	ExternalID   string       `json:"externalId,omitempty"`
+	UserName     string       `json:"userName"`
+	Name         *Name        `json:"name,omitempty"`
+	DisplayName  string       `json:"displayName,omitempty"`
+	Active       *bool        `json:"active,omitempty"`
+	Password     string       `json:"password,omitempty"`
+	Emails       []MultiValue `json:"emails,omitempty"`
+	PhoneNumbers []MultiValue `json:"phoneNumbers,omitempty"`
+	Groups       []MultiValue `json:"groups,omitempty"`
+	Meta         *Meta        `json:"meta,omitempty"`
+}
+
+// PrimaryEmail 返回主邮箱，没有标记主邮箱时返回第一个
+func (u *User) PrimaryEmail() string {
+	return primaryValue(u.Emails)
+}
+
+// PrimaryPhone 返回主手机号，没有标记主手机号时返回第一个
+func (u *User) PrimaryPhone() string {
+	return primaryValue(u.PhoneNumbers)
+}
+
+// IsActive 返回用户是否启用，未指定时视为启用
+func (u *User) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// Member 组成员
+type Member struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// Group SCIM 组资源
+type Group struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []Member `json:"members,omitempty"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+// ListResponse 列表查询响应
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int64    `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// NewListResponse 创建列表响应
+func NewListResponse(total int64, startIndex int, resources []any) *ListResponse {
+	if resources == nil {
+		resources = []any{}
+	}
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// PatchOperation PATCH 请求中的单个操作
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// PatchRequest PATCH 请求
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// ListQuery 列表查询参数
+type ListQuery struct {
+	Filter             string `form:"filter"`
+	StartIndex         int    `form:"startIndex"`
+	Count              *int   `form:"count"`
+	Attributes         string `form:"attributes"`
+	ExcludedAttributes string `form:"excludedAttributes"`
+}
+
+// Page 返回从 1 开始的起始序号、数据库偏移量和每页数量
+func (q *ListQuery) Page() (startIndex, offset, limit int) {
+	startIndex = q.StartIndex
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	limit = DefaultCount
+	if q.Count != nil {
+		limit = *q.Count
+	}
+	if limit < 0 {
+		limit = 0
+	}
+	if limit > MaxCount {
+		limit = MaxCount
+	}
+	return startIndex, startIndex - 1, limit
+}
+
+// Excludes 判断属性是否在 excludedAttributes 中
+func (q *ListQuery) Excludes(attr string) bool {
+	if q == nil {
+		return false
+	}
+	for _, a := range strings.Split(q.ExcludedAttributes, ",") {
+		if strings.EqualFold(trimSchema(strings.TrimSpace(a)), attr) {
+			return true
+		}
+	}
+	return false
+}
+
+// SCIM 错误类型
+const (
+	ErrTypeInvalidFilter = "invalidFilter"
+	ErrTypeTooMany       = "tooMany"
+	ErrTypeUniqueness    = "uniqueness"
+	ErrTypeMutability    = "mutability"
+	ErrTypeInvalidSyntax = "invalidSyntax"
+	ErrTypeInvalidPath   = "invalidPath"
+	ErrTypeNoTarget      = "noTarget"
+	ErrTypeInvalidValue  = "invalidValue"
+	ErrTypeInvalidVers   = "invalidVers"
+)
+
+// Error SCIM 错误响应
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// NewError 创建 SCIM 错误
+func NewError(status int, scimType, format string, args ...any) *Error {
+	return &Error{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   fmt.Sprintf(format, args...),
+	}
+}
+
+// Error 实现 error 接口
+func (e *Error) Error() string {
+	if e.ScimType != "" {
+		return fmt.Sprintf("scim: %s (%s): %s", e.Status, e.ScimType, e.Detail)
+	}
+	return fmt.Sprintf("scim: %s: %s", e.Status, e.Detail)
+}
+
+// StatusCode 返回 HTTP 状态码
+func (e *Error) StatusCode() int {
+	code, err := strconv.Atoi(e.Status)
+	if err != nil {
+		return http.StatusInternalServerError
+	}
+	return code
+}
+
+// ErrNotFound 资源不存在
+func ErrNotFound(resourceType, id string) *Error {
+	return NewError(http.StatusNotFound, "", "%s %s not found", resourceType, id)
+}
+
+// ErrPreconditionFailed 资源版本与 If-Match 不一致
+func ErrPreconditionFailed() *Error {
+	return NewError(http.StatusPreconditionFailed, "", "resource version does not match If-Match")
+}
+
+// Decode 解析请求体到资源，兼容部分 IdP 以字符串传递布尔值的情况
+func Decode(data []byte, v any) error {
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return NewError(http.StatusBadRequest, ErrTypeInvalidSyntax, "invalid JSON body: %v", err)
+	}
+	normalize(m)
+	return FromMap(m, v)
+}
+
+// ToMap 将资源转换为通用 map 表示
+func ToMap(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FromMap 将通用 map 表示转换为资源
+func FromMap(m map[string]any, v any) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return NewError(http.StatusBadRequest, ErrTypeInvalidValue, "%v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return NewError(http.StatusBadRequest, ErrTypeInvalidValue, "%v", err)
+	}
+	return nil
+}
+
+// ETag 根据资源最后修改时间生成弱 ETag
+func ETag(lastModified time.Time) string {
+	return fmt.Sprintf(`W/"%d"`, lastModified.UnixNano())
+}
+
+// MatchETag 判断 If-Match 头是否与资源版本匹配，头为空或 * 时视为匹配
+func MatchETag(ifMatch, version string) bool {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(ifMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == strings.TrimPrefix(version, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryValue 返回多值属性中的主值
+func primaryValue(values []MultiValue) string {
+	for _, v := range values {
+		if v.Primary && v.Value != "" {
+			return v.Value
+		}
+	}
+	for _, v := range values {
+		if v.Value != "" {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+// trimSchema 去掉属性路径上的 schema URN 前缀
+func trimSchema(path string) string {
+	if !strings.HasPrefix(strings.ToLower(path), "urn:") {
+		return path
+	}
+	if i := strings.LastIndex(path, ":"); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
+
+// normalize 将以字符串传递的 active 属性转换为布尔值
+func normalize(m map[string]any) {
+	for k, v := range m {
+		if !strings.EqualFold(k, "active") {
+			continue
+		}
+		if s, ok := v.(string); ok {
+			if b, err := strconv.ParseBool(strings.ToLower(s)); err == nil {
+				m[k] = b
+			}
+		}
+	}
+}
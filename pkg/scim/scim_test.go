@@ -0,0 +1,111 @@
+package scim_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ashwinyue/one-auth/pkg/scim"
+)
+
+func mustMap(t *testing.T, s string) map[string]any {
+	var m map[string]any
+	require.NoError(t, json.Unmarshal([]byte(s), &m))
+	return m
+}
+
+func TestFilter_Match(t *testing.T) {
+	user := mustMap(t, `{
+		"userName": "Alice@Example.com",
+		"active": true,
+		"name": {"givenName": "Alice"},
+		"emails": [{"value": "alice@example.com", "type": "work"}, {"value": "a@home.net", "type": "home"}]
+	}`)
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{`userName eq "alice@example.com"`, true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName sw "alice"`, true},
+		{`userName ne "alice@example.com"`, false},
+		{`name.givenName co "lic"`, true},
+		{`active eq true and emails[type eq "work" and value ew "example.com"]`, true},
+		{`emails co "home.net"`, true},
+		{`emails[type eq "other"] or not (active eq true)`, false},
+		{`externalId pr`, false},
+	}
+	for _, tt := range tests {
+		f, err := scim.ParseFilter(tt.filter)
+		require.NoError(t, err, tt.filter)
+		assert.Equal(t, tt.want, scim.Match(f, user), tt.filter)
+	}
+
+	for _, bad := range []string{`userName eq`, `userName foo "x"`, `(userName eq "x"`, `userName eq "x`} {
+		_, err := scim.ParseFilter(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestFilter_ToSQL(t *testing.T) {
+	columns := scim.Columns{"username": "u.username", "emails.value": "u.email", "active": "ut.status"}
+
+	f, err := scim.ParseFilter(`(userName sw "a_b" or emails[value eq "x@y.z"]) and not (active eq false)`)
+	require.NoError(t, err)
+	cond, args, err := scim.ToSQL(f, columns)
+	require.NoError(t, err)
+	assert.Equal(t, "((u.username LIKE ? OR u.email = ?) AND NOT (ut.status = ?))", cond)
+	assert.Equal(t, []any{`a\_b%`, "x@y.z", false}, args)
+
+	f, err = scim.ParseFilter(`title eq "x"`)
+	require.NoError(t, err)
+	_, _, err = scim.ToSQL(f, columns)
+	assert.Error(t, err)
+}
+
+func TestApplyPatch(t *testing.T) {
+	group := mustMap(t, `{"displayName": "dev", "members": [{"value": "1"}, {"value": "2"}]}`)
+	err := scim.ApplyPatch(group, []scim.PatchOperation{
+		{Op: "Add", Path: "members", Value: []any{map[string]any{"value": "3"}, map[string]any{"value": "1"}}},
+		{Op: "remove", Path: `members[value eq "2"]`},
+		{Op: "Remove", Path: "members", Value: []any{map[string]any{"value": "3"}}},
+		{Op: "replace", Value: map[string]any{"displayName": "ops"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ops", group["displayName"])
+	assert.Equal(t, []any{map[string]any{"value": "1"}}, group["members"])
+
+	user := mustMap(t, `{"userName": "bob", "active": true}`)
+	err = scim.ApplyPatch(user, []scim.PatchOperation{
+		{Op: "Replace", Path: "active", Value: "False"},
+		{Op: "Add", Path: `emails[type eq "work"].value`, Value: "bob@example.com"},
+		{Op: "replace", Path: "name.familyName", Value: "Smith"},
+	})
+	require.NoError(t, err)
+
+	var u scim.User
+	require.NoError(t, scim.FromMap(user, &u))
+	assert.False(t, u.IsActive())
+	assert.Equal(t, "bob@example.com", u.PrimaryEmail())
+	assert.Equal(t, "Smith", u.Name.FamilyName)
+
+	err = scim.ApplyPatch(user, []scim.PatchOperation{{Op: "remove"}})
+	assert.Error(t, err)
+}
+
+func TestMatchETag(t *testing.T) {
+	assert.True(t, scim.MatchETag("", `W/"1"`))
+	assert.True(t, scim.MatchETag(`W/"2", W/"1"`, `W/"1"`))
+	assert.False(t, scim.MatchETag(`W/"2"`, `W/"1"`))
+}
+
+func TestGenerateToken(t *testing.T) {
+	token, prefix, hash, err := scim.GenerateToken()
+	require.NoError(t, err)
+	assert.True(t, len(token) > len(prefix))
+	assert.Equal(t, token[:len(prefix)], prefix)
+	assert.Equal(t, hash, scim.HashToken(token))
+	assert.Len(t, hash, 64)
+}
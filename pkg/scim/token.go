@@ -0,0 +1,32 @@
+// Copyright 2024 孔令飞 <colin404@foxmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/ashwinyue/one-auth. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package scim
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// tokenPrefix 令牌明文前缀，便于在日志和密钥扫描中识别
+const tokenPrefix = "scim_"
+
+// GenerateToken 生成新的 Bearer 令牌，返回明文、用于展示的前缀和用于存储的摘要
+func GenerateToken() (token, prefix, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	token = tokenPrefix + hex.EncodeToString(b)
+	return token, token[:len(tokenPrefix)+8], HashToken(token), nil
+}
+
+// HashToken 计算令牌的 SHA-256 摘要（十六进制）
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
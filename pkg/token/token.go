@@ -50,8 +50,25 @@ func Init(key string, identityKey string, expiration time.Duration) {
 	})
 }
 
+// Claims 是从 token 中解析出的身份信息.
+type Claims struct {
+	// Identity 是 token 中存放的用户身份.
+	Identity string
+	// IssuedAt 是 token 的签发时间，未携带 iat 时为零值.
+	IssuedAt time.Time
+}
+
 // Parse 使用指定的密钥 key 解析 token，解析成功返回 token 上下文，否则报错.
 func Parse(tokenString string, key string) (string, error) {
+	claims, err := ParseClaims(tokenString, key)
+	if err != nil {
+		return "", err
+	}
+	return claims.Identity, nil
+}
+
+// ParseClaims 使用指定的密钥 key 解析 token，返回用户身份和签发时间.
+func ParseClaims(tokenString string, key string) (*Claims, error) {
 	// 解析 token
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		// 确保 token 加密算法是预期的加密算法
@@ -63,27 +80,37 @@ func Parse(tokenString string, key string) (string, error) {
 	})
 	// 解析失败
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	var identityKey string
-	// 如果解析成功，从 token 中取出 token 的主题
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		if key, exists := claims[config.identityKey]; exists {
-			if identity, valid := key.(string); valid {
-				identityKey = identity // 获取身份键
-			}
+	var claims Claims
+	// 如果解析成功，从 token 中取出 token 的主题和签发时间
+	if mapClaims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		if identity, valid := mapClaims[config.identityKey].(string); valid {
+			claims.Identity = identity // 获取身份键
+		}
+		if iat, valid := mapClaims["iat"].(float64); valid {
+			claims.IssuedAt = time.Unix(int64(iat), 0)
 		}
 	}
-	if identityKey == "" {
-		return "", jwt.ErrSignatureInvalid
+	if claims.Identity == "" {
+		return nil, jwt.ErrSignatureInvalid
 	}
 
-	return identityKey, nil
+	return &claims, nil
 }
 
 // ParseRequest 从请求头中获取令牌，并将其传递给 Parse 函数以解析令牌.
 func ParseRequest(ctx context.Context) (string, error) {
+	claims, err := ParseRequestClaims(ctx)
+	if err != nil {
+		return "", err
+	}
+	return claims.Identity, nil
+}
+
+// ParseRequestClaims 从请求头中获取令牌并解析，返回用户身份和签发时间.
+func ParseRequestClaims(ctx context.Context) (*Claims, error) {
 	var (
 		token string
 		err   error
@@ -95,7 +122,7 @@ func ParseRequest(ctx context.Context) (string, error) {
 		header := typed.Request.Header.Get("Authorization")
 		if len(header) == 0 {
 			//nolint: err113
-			return "", errors.New("the length of the `Authorization` header is zero") // 返回错误
+			return nil, errors.New("the length of the `Authorization` header is zero") // 返回错误
 		}
 
 		// 从请求头中取出 token
@@ -104,11 +131,21 @@ func ParseRequest(ctx context.Context) (string, error) {
 	default:
 		token, err = auth.AuthFromMD(typed, "Bearer")
 		if err != nil {
-			return "", status.Errorf(codes.Unauthenticated, "invalid auth token")
+			return nil, status.Errorf(codes.Unauthenticated, "invalid auth token")
 		}
 	}
 
-	return Parse(token, config.key) // 解析 token
+	return ParseTokenClaims(token) // 解析 token
+}
+
+// ParseToken 使用包级别的密钥解析 token，用于令牌不在当前请求头中的场景，例如 Envoy 外部授权.
+func ParseToken(tokenString string) (string, error) {
+	return Parse(tokenString, config.key)
+}
+
+// ParseTokenClaims 使用包级别的密钥解析 token，返回用户身份和签发时间.
+func ParseTokenClaims(tokenString string) (*Claims, error) {
+	return ParseClaims(tokenString, config.key)
 }
 
 // Sign 使用 jwtSecret 签发 token，token 的 claims 中会存放传入的 subject.
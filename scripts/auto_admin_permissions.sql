@@ -1,3 +1,6 @@
+-- 注意：该脚本已被 apiserver 中按租户配置的管理员权限自动分配取代
+-- （/v1/permissions/auto-assign、/v1/permissions/admin-sync），不要与其同时使用。
+
 -- 自动为超级管理员分配新权限的触发器
 -- 当permissions表新增记录时，自动为超级管理员角色分配该权限
 
@@ -0,0 +1,50 @@
+-- =======================================================
+-- 访问申请和审批策略的数据库迁移脚本
+-- =======================================================
+
+-- 1. 访问申请表
+CREATE TABLE IF NOT EXISTS `access_requests` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `requester_id` bigint NOT NULL COMMENT '申请人用户ID',
+  `target_type` varchar(16) NOT NULL COMMENT '申请对象类型：role,permission',
+  `target_id` bigint NOT NULL COMMENT '申请的角色或权限ID',
+  `justification` varchar(1000) NOT NULL COMMENT '申请理由',
+  `status` varchar(16) NOT NULL COMMENT '状态：pending,approved,rejected,expired,cancelled',
+  `approver_ids` varchar(1000) NOT NULL COMMENT '提交时确定的审批人用户ID，逗号分隔',
+  `quorum` int NOT NULL COMMENT '需要的批准人数',
+  `approvals` int NOT NULL DEFAULT '0' COMMENT '已批准的人数',
+  `expires_at` datetime NOT NULL COMMENT '未审批完成时的过期时间',
+  `decided_at` datetime DEFAULT NULL COMMENT '申请结束的时间',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_tenant_requester` (`tenant_id`, `requester_id`),
+  KEY `idx_status_expires_at` (`status`, `expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='访问申请表';
+
+-- 2. 访问申请审批记录表
+CREATE TABLE IF NOT EXISTS `access_request_reviews` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `request_id` bigint NOT NULL COMMENT '申请ID',
+  `reviewer_id` bigint NOT NULL COMMENT '审批人用户ID',
+  `decision` varchar(16) NOT NULL COMMENT '审批结果：approve,reject',
+  `comment` varchar(500) DEFAULT NULL COMMENT '审批意见',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_request_reviewer` (`request_id`, `reviewer_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='访问申请审批记录表';
+
+-- 3. 访问申请审批策略表
+CREATE TABLE IF NOT EXISTS `access_approval_policies` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `target_type` varchar(16) NOT NULL COMMENT '申请对象类型：role,permission',
+  `target_id` bigint NOT NULL COMMENT '角色或权限ID',
+  `approver_ids` varchar(1000) NOT NULL COMMENT '审批人用户ID，逗号分隔',
+  `quorum` int NOT NULL DEFAULT '1' COMMENT '需要的批准人数',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_tenant_target` (`tenant_id`, `target_type`, `target_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='访问申请审批策略表';
@@ -0,0 +1,32 @@
+-- =======================================================
+-- 按 proto 声明的权限编码授权 gRPC 方法的数据库迁移脚本
+-- =======================================================
+
+-- 1. 为每个租户补充文章接口使用的权限编码，已存在时跳过
+INSERT INTO permissions (tenant_id, permission_code, name, description, resource_type, action, status)
+SELECT t.id, p.permission_code, p.name, p.description, 'api', p.action, 1
+FROM tenants t
+CROSS JOIN (
+  SELECT 'post:view' AS permission_code, '查看文章' AS name, '查看文章列表和详情' AS description, 'view' AS action
+  UNION ALL SELECT 'post:create', '创建文章', '发布新文章', 'create'
+  UNION ALL SELECT 'post:update', '编辑文章', '修改文章内容', 'update'
+  UNION ALL SELECT 'post:delete', '删除文章', '删除文章', 'delete'
+) p
+WHERE t.deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM permissions e
+    WHERE e.tenant_id = t.id AND e.permission_code = p.permission_code AND e.deleted_at IS NULL
+  );
+
+-- 2. 原先文章接口对所有登录用户开放，迁移后为租户内的全部角色授予文章权限以保持原有行为
+INSERT INTO casbin_rule (ptype, v0, v1, v2, v3, v4, v5)
+SELECT 'p', CONCAT('r', r.id), CONCAT('p', p.id), CONCAT('t', r.tenant_id), 'allow', '', ''
+FROM roles r
+JOIN permissions p ON p.tenant_id = r.tenant_id AND p.permission_code LIKE 'post:%' AND p.deleted_at IS NULL
+WHERE r.deleted_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM casbin_rule c
+    WHERE c.ptype = 'p' AND c.v0 = CONCAT('r', r.id) AND c.v1 = CONCAT('p', p.id) AND c.v2 = CONCAT('t', r.tenant_id)
+  );
+
+-- 3. 执行后调用 POST /v1/roles/refresh（RefreshPrivilegeData）通知所有实例重新加载策略
@@ -0,0 +1,28 @@
+-- =======================================================
+-- 手机号统一为 E.164 格式的数据库迁移脚本
+-- =======================================================
+
+-- 1. 将历史的中国大陆 11 位手机号认证标识符补全国家码
+UPDATE user_status
+SET auth_id = CONCAT('+86', auth_id)
+WHERE auth_type = 3
+  AND auth_id REGEXP '^1[3-9][0-9]{9}$';
+
+-- 2. 将 00 开头的国际号码替换为 + 开头
+UPDATE user_status
+SET auth_id = CONCAT('+', SUBSTRING(auth_id, 3))
+WHERE auth_type = 3
+  AND auth_id REGEXP '^00[1-9][0-9]{6,14}$';
+
+-- 3. 同步规范化用户表中的手机号
+UPDATE user
+SET phone = CONCAT('+86', phone)
+WHERE phone REGEXP '^1[3-9][0-9]{9}$';
+
+UPDATE user
+SET phone = CONCAT('+', SUBSTRING(phone, 3))
+WHERE phone REGEXP '^00[1-9][0-9]{6,14}$';
+
+-- 4. 检查仍不符合 E.164 格式的手机号，需人工处理
+SELECT id, user_id, auth_id FROM user_status
+WHERE auth_type = 3 AND auth_id NOT REGEXP '^\\+[1-9][0-9]{3,14}$';
@@ -0,0 +1,32 @@
+-- =======================================================
+-- 限时角色授予和临时提权的数据库迁移脚本
+-- =======================================================
+
+-- 1. 限时角色授予表，过期回收由 apiserver 的 rolegrant 后台任务完成
+CREATE TABLE IF NOT EXISTS `user_role_grants` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `user_id` bigint NOT NULL COMMENT '用户ID',
+  `role_id` bigint NOT NULL COMMENT '角色ID',
+  `kind` varchar(16) NOT NULL COMMENT '授予类型：scheduled 限时授予，jit 临时提权申请',
+  `status` varchar(16) NOT NULL COMMENT '状态：pending,approved,active,rejected,expired,revoked',
+  `valid_from` datetime DEFAULT NULL COMMENT '生效时间，临时提权申请在审批通过时设置',
+  `valid_until` datetime DEFAULT NULL COMMENT '失效时间',
+  `duration_seconds` bigint NOT NULL DEFAULT '0' COMMENT '授予时长（秒）',
+  `reason` varchar(500) DEFAULT NULL COMMENT '申请原因',
+  `ticket_ref` varchar(128) DEFAULT NULL COMMENT '关联的工单号',
+  `requested_by` bigint NOT NULL COMMENT '申请人或创建人用户ID',
+  `reviewed_by` bigint NOT NULL DEFAULT '0' COMMENT '审批人用户ID',
+  `review_comment` varchar(500) DEFAULT NULL COMMENT '审批意见',
+  `reviewed_at` datetime DEFAULT NULL COMMENT '审批时间',
+  `ended_at` datetime DEFAULT NULL COMMENT '过期或撤销时间',
+  `notified_at` datetime DEFAULT NULL COMMENT '通知用户授予结束的时间',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_tenant_user_role` (`tenant_id`, `user_id`, `role_id`),
+  KEY `idx_status_valid_from` (`status`, `valid_from`),
+  KEY `idx_status_valid_until` (`status`, `valid_until`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='限时角色授予表';
+
+-- 2. 后台任务的分布式锁表 locks 由 apiserver 启动时自动创建，无需手动迁移
@@ -0,0 +1,9 @@
+-- =======================================================
+-- 服务凭证权限编码的数据库迁移脚本
+-- =======================================================
+
+-- 服务凭证增加授权访问的权限编码.
+-- 迁移前创建的凭证没有权限编码，只能调用策略决策点，不能再通过网关访问需要权限的路由，
+-- 需要为其重新创建带权限编码的凭证
+ALTER TABLE service_clients
+  ADD COLUMN scopes varchar(1024) NOT NULL DEFAULT '' COMMENT '授权访问的权限编码，逗号分隔，为空表示不能访问需要权限的网关路由' AFTER token_prefix;
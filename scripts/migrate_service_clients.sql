@@ -0,0 +1,21 @@
+-- =======================================================
+-- 策略决策点服务凭证的数据库迁移脚本
+-- =======================================================
+
+CREATE TABLE IF NOT EXISTS `service_clients` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `name` varchar(100) NOT NULL COMMENT '调用方服务名称',
+  `tenant_id` bigint NOT NULL DEFAULT '0' COMMENT '可以查询的租户ID，0 表示全部租户',
+  `token_hash` char(64) NOT NULL COMMENT '令牌的 SHA-256 摘要（十六进制），明文只在创建时返回一次',
+  `token_prefix` varchar(16) NOT NULL DEFAULT '' COMMENT '令牌前缀，用于辨认令牌',
+  `scopes` varchar(1024) NOT NULL DEFAULT '' COMMENT '授权访问的权限编码，逗号分隔，为空表示不能访问需要权限的网关路由',
+  `expires_at` datetime DEFAULT NULL COMMENT '过期时间，为空表示永不过期',
+  `last_used_at` datetime DEFAULT NULL COMMENT '最后使用时间',
+  `created_by` bigint NOT NULL DEFAULT '0' COMMENT '创建人用户ID',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间（软删除）',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_token_hash` (`token_hash`),
+  KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='策略决策点服务凭证表';
@@ -0,0 +1,17 @@
+-- =======================================================
+-- 职责分离规则的数据库迁移脚本
+-- =======================================================
+
+CREATE TABLE IF NOT EXISTS `sod_rules` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  `tenant_id` bigint NOT NULL COMMENT '租户ID',
+  `name` varchar(100) NOT NULL COMMENT '规则名称',
+  `kind` varchar(16) NOT NULL COMMENT '规则类型：exclusive,cardinality',
+  `role_ids` varchar(1000) NOT NULL COMMENT '约束的角色ID，逗号分隔',
+  `max_count` int NOT NULL DEFAULT '1' COMMENT '同一用户最多拥有的角色数或每个角色最多分配的用户数',
+  `description` varchar(500) DEFAULT NULL COMMENT '规则描述',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_tenant_name` (`tenant_id`, `name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='职责分离规则表';
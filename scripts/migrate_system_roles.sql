@@ -0,0 +1,52 @@
+-- =======================================================
+-- 租户系统内置角色和平台运营人员的数据库迁移脚本
+-- =======================================================
+
+-- 1. 角色表增加系统内置角色标记
+ALTER TABLE roles
+  ADD COLUMN system_role varchar(32) DEFAULT NULL COMMENT '系统内置角色：tenant_owner,tenant_admin，为空表示普通角色' AFTER status,
+  ADD UNIQUE KEY idx_system_role_tenant (system_role, tenant_id);
+
+-- 2. 原有的 super_admin、admin 角色分别标记为租户所有者和租户管理员.
+--    之前 ID 为 1 的角色在所有租户中都视为超级管理员，迁移后只在所属租户内生效，
+--    跨租户管理改由平台运营人员完成
+UPDATE roles SET system_role = 'tenant_owner'
+WHERE name = 'super_admin' AND deleted_at IS NULL;
+
+UPDATE roles SET system_role = 'tenant_admin'
+WHERE name = 'admin' AND deleted_at IS NULL;
+
+-- 3. 平台运营人员表
+CREATE TABLE IF NOT EXISTS platform_operators (
+  id bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  user_id bigint NOT NULL COMMENT '用户ID',
+  created_by bigint NOT NULL DEFAULT '0' COMMENT '添加人用户ID，0 表示通过初始化脚本添加',
+  created_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  updated_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='平台运营人员表';
+
+-- 4. 平台操作审计日志表
+CREATE TABLE IF NOT EXISTS platform_audit_logs (
+  id bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+  operator_id bigint NOT NULL COMMENT '操作人用户ID',
+  method varchar(10) NOT NULL COMMENT 'HTTP 方法',
+  route varchar(255) NOT NULL DEFAULT '' COMMENT '路由模板',
+  path varchar(500) NOT NULL DEFAULT '' COMMENT '请求路径',
+  tenant_id bigint NOT NULL DEFAULT '0' COMMENT '操作的租户ID，0 表示不针对租户',
+  status_code int NOT NULL COMMENT '响应状态码',
+  message varchar(1000) DEFAULT NULL COMMENT '失败原因',
+  request_id varchar(64) NOT NULL DEFAULT '' COMMENT '请求ID',
+  client_ip varchar(64) NOT NULL DEFAULT '' COMMENT '客户端IP',
+  created_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (id),
+  KEY idx_operator_id (operator_id),
+  KEY idx_tenant_id (tenant_id),
+  KEY idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='平台操作审计日志表';
+
+-- 5. 将原来依赖 ID 为 1 的角色跨租户管理的用户设为平台运营人员，请按需调整
+INSERT IGNORE INTO platform_operators (user_id, created_by)
+SELECT DISTINCT CAST(SUBSTRING(v0, 2) AS UNSIGNED), 0 FROM casbin_rule
+WHERE ptype = 'g' AND v1 = 'r1' AND v0 LIKE 'u%';